@@ -28,4 +28,5 @@ func LoadEnvConfig() {
 	LoadConfigJQuants()
 	LoadConfigFeatureFlag()
 	LoadConfigBOX()
+	LoadConfigSignalIngest()
 }
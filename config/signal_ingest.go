@@ -0,0 +1,26 @@
+package config
+
+import (
+	"log"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+type SignalIngest struct {
+	// SignalIngestToken 外部シグナル取り込み API の Bearer トークン。空なら取り込みを受け付けない。
+	SignalIngestToken string `envconfig:"signal_ingest_token" default:""`
+}
+
+var signalIngest SignalIngest
+
+func LoadConfigSignalIngest() {
+	prefix := ""
+	err := envconfig.Process(prefix, &signalIngest)
+	if err != nil {
+		log.Fatalf("failed to init config: %v", err)
+	}
+}
+
+func GetSignalIngest() *SignalIngest {
+	return &signalIngest
+}
@@ -39,6 +39,21 @@ var usecaseSet = wire.NewSet(
 	usecase.NewCreateDailyStockPicksInteractor,
 	usecase.NewEvaluateDailyStockPicksInteractor,
 	usecase.NewDailyStockPickInteractor,
+	usecase.NewReplayDailyStockPicksInteractor,
+	usecase.NewRunPaperTradingInteractor,
+	usecase.NewNotifyPaperPortfolioInteractor,
+	usecase.NewPortfolioBacktestInteractor,
+	usecase.NewStrategyOptimizationInteractor,
+	usecase.NewCandlestickPatternInteractor,
+	usecase.NewRelativeStrengthInteractor,
+	usecase.NewMarketBreadthInteractor,
+	usecase.NewMarketRegimeInteractor,
+	usecase.NewEventStudyInteractor,
+	usecase.NewPaperPortfolioInteractor,
+	usecase.NewCalibrationInteractor,
+	usecase.NewSignalIngestInteractor,
+	usecase.NewMarkAnalyzeSignalsInteractor,
+	usecase.NewSignalConfluenceInteractor,
 )
 
 var driverSet = wire.NewSet(
@@ -73,6 +88,14 @@ var cliSet = wire.NewSet(
 	commands.NewCreateQuizDailyUniverseV1Command,
 	commands.NewCreateDailyStockPicksV1Command,
 	commands.NewEvaluateDailyStockPicksV1Command,
+	commands.NewOptimizeStrategyParamsV1Command,
+	commands.NewCalculateRelativeStrengthV1Command,
+	commands.NewCalculateMarketBreadthV1Command,
+	commands.NewClassifyMarketRegimeV1Command,
+	commands.NewReplayDailyStockPicksV1Command,
+	commands.NewRunPaperTradingV1Command,
+	commands.NewNotifyPaperPortfolioV1Command,
+	commands.NewMarkAnalyzeSignalsV1Command,
 )
 
 var databaseSet = wire.NewSet(
@@ -81,6 +104,9 @@ var databaseSet = wire.NewSet(
 	database.NewNikkeiRepositoryImpl,
 	database.NewDjiRepositoryImpl,
 	database.NewTopixRepositoryImpl,
+	database.NewRelativeStrengthRepositoryImpl,
+	database.NewMarketBreadthRepositoryImpl,
+	database.NewMarketRegimeRepositoryImpl,
 	database.NewStockBrandsDailyPriceRepositoryImpl,
 	database.NewAnalyzeStockBrandPriceHistoryRepositoryImpl,
 	database.NewStockBrandsDailyPriceForAnalyzeRepositoryImpl,
@@ -96,6 +122,8 @@ var databaseSet = wire.NewSet(
 	database.NewQuizDailyUniverseRepositoryImpl,
 	database.NewQuizAnswerRepositoryImpl,
 	database.NewDailyStockPickRepositoryImpl,
+	database.NewPaperTradingRepositoryImpl,
+	database.NewStrategyRankingRunRepositoryImpl,
 )
 
 func InitializeCli(ctx context.Context) (*cli.Runner, func(), error) {
@@ -125,10 +153,28 @@ var apiSet = wire.NewSet(
 	handler.NewSectorPerformanceHandler,
 	handler.NewQuizHandler,
 	handler.NewDailyStockPickHandler,
+	handler.NewPortfolioBacktestHandler,
+	handler.NewStrategyOptimizationHandler,
+	handler.NewCandlestickPatternHandler,
+	handler.NewRelativeStrengthHandler,
+	handler.NewMarketBreadthHandler,
+	handler.NewMarketRegimeHandler,
+	handler.NewEventStudyHandler,
+	handler.NewPaperPortfolioHandler,
+	handler.NewCalibrationHandler,
+	handler.NewSignalIngestHandler,
 	router.NewRouter,
+	wire.Struct(new(ApiServerComponents), "*"),
 )
 
-func InitializeApiServer(ctx context.Context) (*http.ServeMux, func(), error) {
+// ApiServerComponents API サーバーの起動に必要なもの。StrategyOptimization / StrategyRanking は起動時に中断ジョブを片付けるために使う。
+type ApiServerComponents struct {
+	Mux                  *http.ServeMux
+	StrategyOptimization usecase.StrategyOptimizationInteractor
+	StrategyRanking      usecase.StrategyRankingInteractor
+}
+
+func InitializeApiServer(ctx context.Context) (*ApiServerComponents, func(), error) {
 	wire.Build(
 		apiSet,
 		usecaseSet,
@@ -141,6 +187,7 @@ func InitializeApiServer(ctx context.Context) (*http.ServeMux, func(), error) {
 var grpcSet = wire.NewSet(
 	server.NewStockServiceServer,
 	usecase.NewGetHighVolumeStockBrandsUseCase,
+	usecase.NewSignalIngestInteractor,
 	wire.Struct(new(GrpcServerComponents), "*"),
 )
 
@@ -44,8 +44,9 @@ func InitializeCli(ctx context.Context) (*cli.Runner, func(), error) {
 	stockBrandsDailyPriceForAnalyzeRepository := database.NewStockBrandsDailyPriceForAnalyzeRepositoryImpl(gormDB)
 	finAnnouncementRepository := database.NewFinAnnouncementRepositoryImpl(gormDB)
 	finStatementRepository := database.NewFinStatementRepositoryImpl(gormDB)
+	relativeStrengthRepository := database.NewRelativeStrengthRepositoryImpl(gormDB)
 	stockAPIClient := driver.NewStockAPIClient(httpRequest, client)
-	stockBrandInteractor := usecase.NewStockBrandInteractor(transaction, stockBrandRepository, stockBrandsDailyPriceRepository, analyzeStockBrandPriceHistoryRepository, stockBrandsDailyPriceForAnalyzeRepository, finAnnouncementRepository, finStatementRepository, stockAPIClient, client)
+	stockBrandInteractor := usecase.NewStockBrandInteractor(transaction, stockBrandRepository, stockBrandsDailyPriceRepository, analyzeStockBrandPriceHistoryRepository, stockBrandsDailyPriceForAnalyzeRepository, finAnnouncementRepository, finStatementRepository, relativeStrengthRepository, stockAPIClient, client)
 	updateStockBrandsV1Command := commands.NewUpdateStockBrandsV1Command(stockBrandInteractor)
 	stockBrandsDailyPriceInteractor := usecase.NewStockBrandsDailyPriceInteractor(transaction, stockBrandRepository, stockBrandsDailyPriceRepository, stockBrandsDailyPriceForAnalyzeRepository, stockAPIClient, client, slackAPIClient)
 	createHistoricalDailyStockPricesV1Command := commands.NewCreateHistoricalDailyStockPricesV1Command(stockBrandsDailyPriceInteractor)
@@ -67,7 +68,8 @@ func InitializeCli(ctx context.Context) (*cli.Runner, func(), error) {
 	exportMasterDataCommand := commands.NewExportMasterDataCommand(mySQLDumpClient, boxClient)
 	syncFinAnnouncementsCommand := commands.NewSyncFinAnnouncementsCommand(stockBrandInteractor)
 	syncFinStatementsCommand := commands.NewSyncFinStatementsCommand(stockBrandInteractor)
-	strategyRankingInteractor := usecase.NewStrategyRankingInteractor(stockBrandRepository, stockBrandsDailyPriceRepository, client)
+	strategyRankingRunRepository := database.NewStrategyRankingRunRepositoryImpl(gormDB)
+	strategyRankingInteractor := usecase.NewStrategyRankingInteractor(transaction, stockBrandRepository, stockBrandsDailyPriceRepository, nikkeiRepository, strategyRankingRunRepository, client)
 	backtestAllStocksCommand := commands.NewBacktestAllStocksCommand(strategyRankingInteractor)
 	syncFinStatementsAllStocksCommand := commands.NewSyncFinStatementsAllStocksCommand(stockBrandInteractor)
 	quizAnswerRepository := database.NewQuizAnswerRepositoryImpl(gormDB)
@@ -79,15 +81,34 @@ func InitializeCli(ctx context.Context) (*cli.Runner, func(), error) {
 	dailyStockPickRepository := database.NewDailyStockPickRepositoryImpl(gormDB)
 	evaluateDailyStockPicksInteractor := usecase.NewEvaluateDailyStockPicksInteractor(transaction, dailyStockPickRepository, stockBrandsDailyPriceRepository, appliedStockSplitsHistoryRepository, appliedStockConsolidationsHistoryRepository)
 	evaluateDailyStockPicksV1Command := commands.NewEvaluateDailyStockPicksV1Command(evaluateDailyStockPicksInteractor)
-	createDailyStockPicksInteractor := usecase.NewCreateDailyStockPicksInteractor(transaction, stockBrandsDailyPriceRepository, stockBrandRepository, dailyStockPickRepository, slackAPIClient)
+	marketRegimeRepository := database.NewMarketRegimeRepositoryImpl(gormDB)
+	createDailyStockPicksInteractor := usecase.NewCreateDailyStockPicksInteractor(transaction, stockBrandsDailyPriceRepository, stockBrandRepository, dailyStockPickRepository, marketRegimeRepository, analyzeStockBrandPriceHistoryRepository, slackAPIClient)
 	createDailyStockPicksV1Command := commands.NewCreateDailyStockPicksV1Command(createDailyStockPicksInteractor)
-	runner := cli.NewRunner(healthCheckCommand, updateStockBrandsV1Command, createHistoricalDailyStockPricesV1Command, createDailyStockPriceV1Command, createNikkeiAndDjiHistoricalDataV1Command, adjustHistoricalDataForStockSplitCommand, adjustHistoricalDataForStockConsolidationCommand, exportYearlyDataCommand, exportMasterDataCommand, syncFinAnnouncementsCommand, syncFinStatementsCommand, backtestAllStocksCommand, syncFinStatementsAllStocksCommand, gradeQuizAnswersV1Command, createQuizDailyUniverseV1Command, evaluateDailyStockPicksV1Command, createDailyStockPicksV1Command, indexInteractor, slackAPIClient)
+	strategyOptimizationInteractor := usecase.NewStrategyOptimizationInteractor(stockBrandRepository, stockBrandsDailyPriceRepository, client)
+	optimizeStrategyParamsV1Command := commands.NewOptimizeStrategyParamsV1Command(strategyOptimizationInteractor)
+	relativeStrengthInteractor := usecase.NewRelativeStrengthInteractor(transaction, stockBrandRepository, stockBrandsDailyPriceRepository, topixRepository, relativeStrengthRepository)
+	calculateRelativeStrengthV1Command := commands.NewCalculateRelativeStrengthV1Command(relativeStrengthInteractor)
+	marketBreadthRepository := database.NewMarketBreadthRepositoryImpl(gormDB)
+	marketBreadthInteractor := usecase.NewMarketBreadthInteractor(stockBrandRepository, stockBrandsDailyPriceRepository, marketBreadthRepository)
+	calculateMarketBreadthV1Command := commands.NewCalculateMarketBreadthV1Command(marketBreadthInteractor)
+	marketRegimeInteractor := usecase.NewMarketRegimeInteractor(topixRepository, nikkeiRepository, marketBreadthRepository, marketRegimeRepository)
+	classifyMarketRegimeV1Command := commands.NewClassifyMarketRegimeV1Command(marketRegimeInteractor)
+	replayDailyStockPicksInteractor := usecase.NewReplayDailyStockPicksInteractor(transaction, stockBrandsDailyPriceRepository, stockBrandRepository, dailyStockPickRepository, marketRegimeRepository, appliedStockSplitsHistoryRepository, appliedStockConsolidationsHistoryRepository)
+	replayDailyStockPicksV1Command := commands.NewReplayDailyStockPicksV1Command(replayDailyStockPicksInteractor)
+	paperTradingRepository := database.NewPaperTradingRepositoryImpl(gormDB)
+	runPaperTradingInteractor := usecase.NewRunPaperTradingInteractor(transaction, paperTradingRepository, dailyStockPickRepository, stockBrandsDailyPriceRepository, appliedStockSplitsHistoryRepository, appliedStockConsolidationsHistoryRepository)
+	runPaperTradingV1Command := commands.NewRunPaperTradingV1Command(runPaperTradingInteractor)
+	notifyPaperPortfolioInteractor := usecase.NewNotifyPaperPortfolioInteractor(paperTradingRepository, stockBrandRepository, slackAPIClient)
+	notifyPaperPortfolioV1Command := commands.NewNotifyPaperPortfolioV1Command(notifyPaperPortfolioInteractor)
+	markAnalyzeSignalsInteractor := usecase.NewMarkAnalyzeSignalsInteractor(transaction, analyzeStockBrandPriceHistoryRepository, stockBrandsDailyPriceRepository, appliedStockSplitsHistoryRepository, appliedStockConsolidationsHistoryRepository)
+	markAnalyzeSignalsV1Command := commands.NewMarkAnalyzeSignalsV1Command(markAnalyzeSignalsInteractor)
+	runner := cli.NewRunner(healthCheckCommand, updateStockBrandsV1Command, createHistoricalDailyStockPricesV1Command, createDailyStockPriceV1Command, createNikkeiAndDjiHistoricalDataV1Command, adjustHistoricalDataForStockSplitCommand, adjustHistoricalDataForStockConsolidationCommand, exportYearlyDataCommand, exportMasterDataCommand, syncFinAnnouncementsCommand, syncFinStatementsCommand, backtestAllStocksCommand, syncFinStatementsAllStocksCommand, gradeQuizAnswersV1Command, createQuizDailyUniverseV1Command, evaluateDailyStockPicksV1Command, createDailyStockPicksV1Command, optimizeStrategyParamsV1Command, calculateRelativeStrengthV1Command, calculateMarketBreadthV1Command, classifyMarketRegimeV1Command, replayDailyStockPicksV1Command, runPaperTradingV1Command, notifyPaperPortfolioV1Command, markAnalyzeSignalsV1Command, indexInteractor, slackAPIClient)
 	return runner, func() {
 		cleanup()
 	}, nil
 }
 
-func InitializeApiServer(ctx context.Context) (*http.ServeMux, func(), error) {
+func InitializeApiServer(ctx context.Context) (*ApiServerComponents, func(), error) {
 	db, cleanup, err := driver.NewDBConn()
 	if err != nil {
 		return nil, nil, err
@@ -116,10 +137,12 @@ func InitializeApiServer(ctx context.Context) (*http.ServeMux, func(), error) {
 	analyzeStockBrandPriceHistoryRepository := database.NewAnalyzeStockBrandPriceHistoryRepositoryImpl(gormDB)
 	finAnnouncementRepository := database.NewFinAnnouncementRepositoryImpl(gormDB)
 	finStatementRepository := database.NewFinStatementRepositoryImpl(gormDB)
-	stockBrandInteractor := usecase.NewStockBrandInteractor(transaction, stockBrandRepository, stockBrandsDailyPriceRepository, analyzeStockBrandPriceHistoryRepository, stockBrandsDailyPriceForAnalyzeRepository, finAnnouncementRepository, finStatementRepository, stockAPIClient, client)
+	relativeStrengthRepository := database.NewRelativeStrengthRepositoryImpl(gormDB)
+	stockBrandInteractor := usecase.NewStockBrandInteractor(transaction, stockBrandRepository, stockBrandsDailyPriceRepository, analyzeStockBrandPriceHistoryRepository, stockBrandsDailyPriceForAnalyzeRepository, finAnnouncementRepository, finStatementRepository, relativeStrengthRepository, stockAPIClient, client)
 	stockBrandHandler := handler.NewStockBrandHandler(stockBrandInteractor, httpServer, logger)
 	analyzeStockBrandPriceHistoryHandler := handler.NewAnalyzeStockBrandPriceHistoryHandler(stockBrandInteractor, httpServer, logger)
-	multipleSignalStocksHandler := handler.NewMultipleSignalStocksHandler(stockBrandInteractor, httpServer, logger)
+	signalConfluenceInteractor := usecase.NewSignalConfluenceInteractor(analyzeStockBrandPriceHistoryRepository, stockBrandsDailyPriceRepository)
+	multipleSignalStocksHandler := handler.NewMultipleSignalStocksHandler(stockBrandInteractor, signalConfluenceInteractor, httpServer, logger)
 	finAnnouncementHandler := handler.NewFinAnnouncementHandler(stockBrandInteractor, httpServer, logger)
 	finStatementHandler := handler.NewFinStatementHandler(stockBrandInteractor, httpServer, logger)
 	daytradeExecutionRepository := database.NewDaytradeExecutionRepositoryImpl(gormDB)
@@ -132,27 +155,57 @@ func InitializeApiServer(ctx context.Context) (*http.ServeMux, func(), error) {
 	returnAnalysisHandler := handler.NewReturnAnalysisHandler(returnAnalysisInteractor, httpServer, logger)
 	backtestInteractor := usecase.NewBacktestInteractor(stockBrandsDailyPriceRepository)
 	backtestHandler := handler.NewBacktestHandler(backtestInteractor, httpServer, logger)
-	strategyRankingInteractor := usecase.NewStrategyRankingInteractor(stockBrandRepository, stockBrandsDailyPriceRepository, client)
+	strategyRankingRunRepository := database.NewStrategyRankingRunRepositoryImpl(gormDB)
+	strategyRankingInteractor := usecase.NewStrategyRankingInteractor(transaction, stockBrandRepository, stockBrandsDailyPriceRepository, nikkeiRepository, strategyRankingRunRepository, client)
 	strategyRankingHandler := handler.NewStrategyRankingHandler(strategyRankingInteractor, httpServer, logger)
 	valuationInteractor := usecase.NewValuationInteractor(finStatementRepository, stockBrandsDailyPriceRepository)
 	valuationHandler := handler.NewValuationHandler(valuationInteractor, httpServer, logger)
 	technicalIndicatorsInteractor := usecase.NewTechnicalIndicatorsInteractor(stockBrandsDailyPriceRepository)
 	technicalIndicatorsHandler := handler.NewTechnicalIndicatorsHandler(technicalIndicatorsInteractor, httpServer, logger)
-	signalPerformanceInteractor := usecase.NewSignalPerformanceInteractor(analyzeStockBrandPriceHistoryRepository, stockBrandsDailyPriceRepository)
+	signalPerformanceInteractor := usecase.NewSignalPerformanceInteractor(analyzeStockBrandPriceHistoryRepository, stockBrandRepository, stockBrandsDailyPriceRepository, topixRepository, nikkeiRepository)
 	signalPerformanceHandler := handler.NewSignalPerformanceHandler(signalPerformanceInteractor, httpServer, logger)
 	sector33AverageDailyPriceRepository := database.NewSector33AverageDailyPriceRepositoryImpl(gormDB)
 	sector17AverageDailyPriceRepository := database.NewSector17AverageDailyPriceRepositoryImpl(gormDB)
-	sectorPerformanceInteractor := usecase.NewSectorPerformanceInteractor(sector33AverageDailyPriceRepository, sector17AverageDailyPriceRepository)
+	sectorPerformanceInteractor := usecase.NewSectorPerformanceInteractor(sector33AverageDailyPriceRepository, sector17AverageDailyPriceRepository, topixRepository)
 	sectorPerformanceHandler := handler.NewSectorPerformanceHandler(sectorPerformanceInteractor, httpServer, logger)
 	quizDailyUniverseRepository := database.NewQuizDailyUniverseRepositoryImpl(gormDB)
 	quizAnswerRepository := database.NewQuizAnswerRepositoryImpl(gormDB)
 	quizInteractor := usecase.NewQuizInteractor(quizDailyUniverseRepository, quizAnswerRepository, stockBrandsDailyPriceRepository, stockBrandRepository)
 	quizHandler := handler.NewQuizHandler(quizInteractor, httpServer, logger)
 	dailyStockPickRepository := database.NewDailyStockPickRepositoryImpl(gormDB)
-	dailyStockPickInteractor := usecase.NewDailyStockPickInteractor(dailyStockPickRepository, stockBrandRepository)
+	marketRegimeRepository := database.NewMarketRegimeRepositoryImpl(gormDB)
+	dailyStockPickInteractor := usecase.NewDailyStockPickInteractor(dailyStockPickRepository, stockBrandRepository, marketRegimeRepository, analyzeStockBrandPriceHistoryRepository, stockBrandsDailyPriceRepository)
 	dailyStockPickHandler := handler.NewDailyStockPickHandler(dailyStockPickInteractor, httpServer, logger)
-	serveMux := router.NewRouter(stockPriceHandler, stockBrandHandler, analyzeStockBrandPriceHistoryHandler, multipleSignalStocksHandler, finAnnouncementHandler, finStatementHandler, daytradeHandler, returnAnalysisHandler, backtestHandler, strategyRankingHandler, valuationHandler, technicalIndicatorsHandler, signalPerformanceHandler, sectorPerformanceHandler, quizHandler, dailyStockPickHandler)
-	return serveMux, func() {
+	portfolioBacktestInteractor := usecase.NewPortfolioBacktestInteractor(stockBrandRepository, stockBrandsDailyPriceRepository)
+	portfolioBacktestHandler := handler.NewPortfolioBacktestHandler(portfolioBacktestInteractor, httpServer, logger)
+	strategyOptimizationInteractor := usecase.NewStrategyOptimizationInteractor(stockBrandRepository, stockBrandsDailyPriceRepository, client)
+	strategyOptimizationHandler := handler.NewStrategyOptimizationHandler(strategyOptimizationInteractor, httpServer, logger)
+	candlestickPatternInteractor := usecase.NewCandlestickPatternInteractor(stockBrandRepository, stockBrandsDailyPriceRepository)
+	candlestickPatternHandler := handler.NewCandlestickPatternHandler(candlestickPatternInteractor, httpServer, logger)
+	relativeStrengthInteractor := usecase.NewRelativeStrengthInteractor(transaction, stockBrandRepository, stockBrandsDailyPriceRepository, topixRepository, relativeStrengthRepository)
+	relativeStrengthHandler := handler.NewRelativeStrengthHandler(relativeStrengthInteractor, httpServer, logger)
+	marketBreadthRepository := database.NewMarketBreadthRepositoryImpl(gormDB)
+	marketBreadthInteractor := usecase.NewMarketBreadthInteractor(stockBrandRepository, stockBrandsDailyPriceRepository, marketBreadthRepository)
+	marketBreadthHandler := handler.NewMarketBreadthHandler(marketBreadthInteractor, httpServer, logger)
+	marketRegimeInteractor := usecase.NewMarketRegimeInteractor(topixRepository, nikkeiRepository, marketBreadthRepository, marketRegimeRepository)
+	marketRegimeHandler := handler.NewMarketRegimeHandler(marketRegimeInteractor, httpServer, logger)
+	appliedStockSplitsHistoryRepository := database.NewAppliedStockSplitsHistoryRepositoryImpl(gormDB)
+	eventStudyInteractor := usecase.NewEventStudyInteractor(stockBrandsDailyPriceRepository, topixRepository, nikkeiRepository, finAnnouncementRepository, analyzeStockBrandPriceHistoryRepository, dailyStockPickRepository, appliedStockSplitsHistoryRepository)
+	eventStudyHandler := handler.NewEventStudyHandler(eventStudyInteractor, httpServer, logger)
+	paperTradingRepository := database.NewPaperTradingRepositoryImpl(gormDB)
+	paperPortfolioInteractor := usecase.NewPaperPortfolioInteractor(paperTradingRepository, stockBrandRepository)
+	paperPortfolioHandler := handler.NewPaperPortfolioHandler(paperPortfolioInteractor, httpServer, logger)
+	calibrationInteractor := usecase.NewCalibrationInteractor(dailyStockPickRepository, analyzeStockBrandPriceHistoryRepository, stockBrandsDailyPriceRepository)
+	calibrationHandler := handler.NewCalibrationHandler(calibrationInteractor, httpServer, logger)
+	signalIngestInteractor := usecase.NewSignalIngestInteractor(transaction, stockBrandRepository, analyzeStockBrandPriceHistoryRepository)
+	signalIngestHandler := handler.NewSignalIngestHandler(signalIngestInteractor, httpServer, logger)
+	serveMux := router.NewRouter(stockPriceHandler, stockBrandHandler, analyzeStockBrandPriceHistoryHandler, multipleSignalStocksHandler, finAnnouncementHandler, finStatementHandler, daytradeHandler, returnAnalysisHandler, backtestHandler, strategyRankingHandler, valuationHandler, technicalIndicatorsHandler, signalPerformanceHandler, sectorPerformanceHandler, quizHandler, dailyStockPickHandler, portfolioBacktestHandler, strategyOptimizationHandler, candlestickPatternHandler, relativeStrengthHandler, marketBreadthHandler, marketRegimeHandler, eventStudyHandler, paperPortfolioHandler, calibrationHandler, signalIngestHandler)
+	apiServerComponents := &ApiServerComponents{
+		Mux:                  serveMux,
+		StrategyOptimization: strategyOptimizationInteractor,
+		StrategyRanking:      strategyRankingInteractor,
+	}
+	return apiServerComponents, func() {
 		cleanup()
 	}, nil
 }
@@ -169,7 +222,11 @@ func InitializeStockServiceServer(ctx context.Context) (*GrpcServerComponents, f
 	}
 	highVolumeStockBrandRepository := database.NewHighVolumeStockBrandRepositoryImpl(gormDB)
 	getHighVolumeStockBrandsUseCase := usecase.NewGetHighVolumeStockBrandsUseCase(highVolumeStockBrandRepository)
-	stockServiceServer := server.NewStockServiceServer(getHighVolumeStockBrandsUseCase)
+	transaction := database.NewTransaction(gormDB)
+	stockBrandRepository := database.NewStockBrandRepositoryImpl(gormDB)
+	analyzeStockBrandPriceHistoryRepository := database.NewAnalyzeStockBrandPriceHistoryRepositoryImpl(gormDB)
+	signalIngestInteractor := usecase.NewSignalIngestInteractor(transaction, stockBrandRepository, analyzeStockBrandPriceHistoryRepository)
+	stockServiceServer := server.NewStockServiceServer(getHighVolumeStockBrandsUseCase, signalIngestInteractor)
 	logger, err := driver.NewLogger()
 	if err != nil {
 		cleanup()
@@ -186,17 +243,24 @@ func InitializeStockServiceServer(ctx context.Context) (*GrpcServerComponents, f
 
 // wire.go:
 
-var usecaseSet = wire.NewSet(usecase.NewStockBrandInteractor, usecase.NewIndexInteractor, usecase.NewStockBrandsDailyPriceInteractor, usecase.NewAdjustHistoricalDataForStockSplit, usecase.NewAdjustHistoricalDataForStockConsolidation, usecase.NewDaytradeInteractor, usecase.NewReturnAnalysisInteractor, usecase.NewBacktestInteractor, usecase.NewStrategyRankingInteractor, usecase.NewValuationInteractor, usecase.NewTechnicalIndicatorsInteractor, usecase.NewSignalPerformanceInteractor, usecase.NewSectorPerformanceInteractor, usecase.NewCreateQuizDailyUniverseInteractor, usecase.NewGradeQuizAnswersInteractor, usecase.NewQuizInteractor, usecase.NewCreateDailyStockPicksInteractor, usecase.NewEvaluateDailyStockPicksInteractor, usecase.NewDailyStockPickInteractor)
+var usecaseSet = wire.NewSet(usecase.NewStockBrandInteractor, usecase.NewIndexInteractor, usecase.NewStockBrandsDailyPriceInteractor, usecase.NewAdjustHistoricalDataForStockSplit, usecase.NewAdjustHistoricalDataForStockConsolidation, usecase.NewDaytradeInteractor, usecase.NewReturnAnalysisInteractor, usecase.NewBacktestInteractor, usecase.NewStrategyRankingInteractor, usecase.NewValuationInteractor, usecase.NewTechnicalIndicatorsInteractor, usecase.NewSignalPerformanceInteractor, usecase.NewSectorPerformanceInteractor, usecase.NewCreateQuizDailyUniverseInteractor, usecase.NewGradeQuizAnswersInteractor, usecase.NewQuizInteractor, usecase.NewCreateDailyStockPicksInteractor, usecase.NewEvaluateDailyStockPicksInteractor, usecase.NewDailyStockPickInteractor, usecase.NewReplayDailyStockPicksInteractor, usecase.NewRunPaperTradingInteractor, usecase.NewNotifyPaperPortfolioInteractor, usecase.NewPortfolioBacktestInteractor, usecase.NewStrategyOptimizationInteractor, usecase.NewCandlestickPatternInteractor, usecase.NewRelativeStrengthInteractor, usecase.NewMarketBreadthInteractor, usecase.NewMarketRegimeInteractor, usecase.NewEventStudyInteractor, usecase.NewPaperPortfolioInteractor, usecase.NewCalibrationInteractor, usecase.NewSignalIngestInteractor, usecase.NewMarkAnalyzeSignalsInteractor, usecase.NewSignalConfluenceInteractor)
 
 var driverSet = wire.NewSet(driver.NewGorm, driver.NewDBConn, driver.NewHTTPRequest, driver.NewHTTPServer, driver.NewSlackAPIClient, driver.OpenRedis, driver.NewStockAPIClient, driver.NewMySQLDumpClient, driver.NewBoxAPIClient, driver.NewLogger)
 
-var cliSet = wire.NewSet(cli.NewRunner, commands.NewHealthCheckCommand, commands.NewUpdateStockBrandsV1Command, commands.NewCreateHistoricalDailyStockPricesV1Command, commands.NewCreateDailyStockPriceV1Command, commands.NewCreateNikkeiAndDjiHistoricalDataV1Command, commands.NewAdjustHistoricalDataForStockSplitCommand, commands.NewAdjustHistoricalDataForStockConsolidationCommand, commands.NewExportYearlyDataCommand, commands.NewExportMasterDataCommand, commands.NewSyncFinAnnouncementsCommand, commands.NewSyncFinStatementsCommand, commands.NewBacktestAllStocksCommand, commands.NewSyncFinStatementsAllStocksCommand, commands.NewGradeQuizAnswersV1Command, commands.NewCreateQuizDailyUniverseV1Command, commands.NewCreateDailyStockPicksV1Command, commands.NewEvaluateDailyStockPicksV1Command)
+var cliSet = wire.NewSet(cli.NewRunner, commands.NewHealthCheckCommand, commands.NewUpdateStockBrandsV1Command, commands.NewCreateHistoricalDailyStockPricesV1Command, commands.NewCreateDailyStockPriceV1Command, commands.NewCreateNikkeiAndDjiHistoricalDataV1Command, commands.NewAdjustHistoricalDataForStockSplitCommand, commands.NewAdjustHistoricalDataForStockConsolidationCommand, commands.NewExportYearlyDataCommand, commands.NewExportMasterDataCommand, commands.NewSyncFinAnnouncementsCommand, commands.NewSyncFinStatementsCommand, commands.NewBacktestAllStocksCommand, commands.NewSyncFinStatementsAllStocksCommand, commands.NewGradeQuizAnswersV1Command, commands.NewCreateQuizDailyUniverseV1Command, commands.NewCreateDailyStockPicksV1Command, commands.NewEvaluateDailyStockPicksV1Command, commands.NewOptimizeStrategyParamsV1Command, commands.NewCalculateRelativeStrengthV1Command, commands.NewCalculateMarketBreadthV1Command, commands.NewClassifyMarketRegimeV1Command, commands.NewReplayDailyStockPicksV1Command, commands.NewRunPaperTradingV1Command, commands.NewNotifyPaperPortfolioV1Command, commands.NewMarkAnalyzeSignalsV1Command)
+
+var databaseSet = wire.NewSet(database.NewTransaction, database.NewStockBrandRepositoryImpl, database.NewNikkeiRepositoryImpl, database.NewDjiRepositoryImpl, database.NewTopixRepositoryImpl, database.NewRelativeStrengthRepositoryImpl, database.NewMarketBreadthRepositoryImpl, database.NewMarketRegimeRepositoryImpl, database.NewStockBrandsDailyPriceRepositoryImpl, database.NewAnalyzeStockBrandPriceHistoryRepositoryImpl, database.NewStockBrandsDailyPriceForAnalyzeRepositoryImpl, database.NewHighVolumeStockBrandRepositoryImpl, database.NewAppliedStockSplitsHistoryRepositoryImpl, database.NewAppliedStockConsolidationsHistoryRepositoryImpl, database.NewFinAnnouncementRepositoryImpl, database.NewFinStatementRepositoryImpl, database.NewDaytradeExecutionRepositoryImpl, database.NewDaytradeTradeNoteRepositoryImpl, database.NewSector33AverageDailyPriceRepositoryImpl, database.NewSector17AverageDailyPriceRepositoryImpl, database.NewQuizDailyUniverseRepositoryImpl, database.NewQuizAnswerRepositoryImpl, database.NewDailyStockPickRepositoryImpl, database.NewPaperTradingRepositoryImpl, database.NewStrategyRankingRunRepositoryImpl)
 
-var databaseSet = wire.NewSet(database.NewTransaction, database.NewStockBrandRepositoryImpl, database.NewNikkeiRepositoryImpl, database.NewDjiRepositoryImpl, database.NewTopixRepositoryImpl, database.NewStockBrandsDailyPriceRepositoryImpl, database.NewAnalyzeStockBrandPriceHistoryRepositoryImpl, database.NewStockBrandsDailyPriceForAnalyzeRepositoryImpl, database.NewHighVolumeStockBrandRepositoryImpl, database.NewAppliedStockSplitsHistoryRepositoryImpl, database.NewAppliedStockConsolidationsHistoryRepositoryImpl, database.NewFinAnnouncementRepositoryImpl, database.NewFinStatementRepositoryImpl, database.NewDaytradeExecutionRepositoryImpl, database.NewDaytradeTradeNoteRepositoryImpl, database.NewSector33AverageDailyPriceRepositoryImpl, database.NewSector17AverageDailyPriceRepositoryImpl, database.NewQuizDailyUniverseRepositoryImpl, database.NewQuizAnswerRepositoryImpl, database.NewDailyStockPickRepositoryImpl)
+var apiSet = wire.NewSet(handler.NewStockPriceHandler, handler.NewStockBrandHandler, handler.NewAnalyzeStockBrandPriceHistoryHandler, handler.NewMultipleSignalStocksHandler, handler.NewFinAnnouncementHandler, handler.NewFinStatementHandler, handler.NewDaytradeHandler, handler.NewReturnAnalysisHandler, handler.NewBacktestHandler, handler.NewStrategyRankingHandler, handler.NewValuationHandler, handler.NewTechnicalIndicatorsHandler, handler.NewSignalPerformanceHandler, handler.NewSectorPerformanceHandler, handler.NewQuizHandler, handler.NewDailyStockPickHandler, handler.NewPortfolioBacktestHandler, handler.NewStrategyOptimizationHandler, handler.NewCandlestickPatternHandler, handler.NewRelativeStrengthHandler, handler.NewMarketBreadthHandler, handler.NewMarketRegimeHandler, handler.NewEventStudyHandler, handler.NewPaperPortfolioHandler, handler.NewCalibrationHandler, handler.NewSignalIngestHandler, router.NewRouter, wire.Struct(new(ApiServerComponents), "*"))
 
-var apiSet = wire.NewSet(handler.NewStockPriceHandler, handler.NewStockBrandHandler, handler.NewAnalyzeStockBrandPriceHistoryHandler, handler.NewMultipleSignalStocksHandler, handler.NewFinAnnouncementHandler, handler.NewFinStatementHandler, handler.NewDaytradeHandler, handler.NewReturnAnalysisHandler, handler.NewBacktestHandler, handler.NewStrategyRankingHandler, handler.NewValuationHandler, handler.NewTechnicalIndicatorsHandler, handler.NewSignalPerformanceHandler, handler.NewSectorPerformanceHandler, handler.NewQuizHandler, handler.NewDailyStockPickHandler, router.NewRouter)
+// ApiServerComponents API サーバーの起動に必要なもの。StrategyOptimization / StrategyRanking は起動時に中断ジョブを片付けるために使う。
+type ApiServerComponents struct {
+	Mux                  *http.ServeMux
+	StrategyOptimization usecase.StrategyOptimizationInteractor
+	StrategyRanking      usecase.StrategyRankingInteractor
+}
 
-var grpcSet = wire.NewSet(server.NewStockServiceServer, usecase.NewGetHighVolumeStockBrandsUseCase, wire.Struct(new(GrpcServerComponents), "*"))
+var grpcSet = wire.NewSet(server.NewStockServiceServer, usecase.NewGetHighVolumeStockBrandsUseCase, usecase.NewSignalIngestInteractor, wire.Struct(new(GrpcServerComponents), "*"))
 
 type GrpcServerComponents struct {
 	Server *server.StockServiceServer
@@ -0,0 +1,100 @@
+package domain_service
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// SignalShareAdjustment シグナル日より後・asOf 以前に実施された分割・併合から、
+// asOf 時点の株価をシグナル日の株数基準に戻す倍率を返す（分割 1→ratio 株は ratio 倍、併合 ratio→1 株は 1/ratio 倍）。
+// 実施日当日の日足は既に新しい株数基準なので、シグナル日当日の分割・併合は調整しない。
+// splits・consolidations は対象銘柄のものだけを渡すこと。
+func SignalShareAdjustment(
+	signalDate, asOf time.Time,
+	splits []*models.AppliedStockSplitHistory,
+	consolidations []*models.AppliedStockConsolidationHistory,
+) decimal.Decimal {
+	from, to := util.DatetimeToDateStr(signalDate), util.DatetimeToDateStr(asOf)
+	inRange := func(d time.Time) bool {
+		s := util.DatetimeToDateStr(d)
+		return s > from && s <= to
+	}
+
+	factor := decimal.NewFromInt(1)
+	for _, s := range splits {
+		if inRange(s.SplitDate) && s.Ratio.IsPositive() {
+			factor = factor.Mul(s.Ratio)
+		}
+	}
+	for _, c := range consolidations {
+		if inRange(c.ConsolidationDate) && c.Ratio.IsPositive() {
+			factor = factor.Div(c.Ratio)
+		}
+	}
+	return factor
+}
+
+// MarkAnalyzeSignal 建玉中のシグナル1件を日足で値洗いする。prices は同じ銘柄の日足を日付昇順で渡す（asOf 以前のみ）。
+// closeAfterDays > 0 で、シグナル日より後の営業日が closeAfterDays 日に達していれば、その日の終値でクローズする。
+// それ以外は最新の終値で現在値を更新する。使える日足が無ければ nil を返す。
+func MarkAnalyzeSignal(
+	h *models.AnalyzeStockBrandPriceHistory,
+	prices []*models.StockBrandDailyPrice,
+	splits []*models.AppliedStockSplitHistory,
+	consolidations []*models.AppliedStockConsolidationHistory,
+	closeAfterDays int,
+) *models.AnalyzeStockBrandPriceHistoryMark {
+	signalDate := util.DatetimeToDateStr(h.CreatedAt)
+
+	var latest *models.StockBrandDailyPrice
+	heldDays := 0
+	for _, p := range prices {
+		d := util.DatetimeToDateStr(p.Date)
+		if d < signalDate {
+			continue
+		}
+		latest = p
+		if d == signalDate {
+			continue
+		}
+		heldDays++
+		if closeAfterDays > 0 && heldDays == closeAfterDays {
+			break
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+
+	price := latest.Close.Mul(SignalShareAdjustment(h.CreatedAt, latest.Date, splits, consolidations)).Round(4)
+	mark := &models.AnalyzeStockBrandPriceHistoryMark{
+		ID:              h.ID,
+		CurrentPrice:    price,
+		PriceDifference: price.Sub(h.TradePrice),
+		MarkedAt:        latest.Date,
+	}
+	if closeAfterDays > 0 && heldDays == closeAfterDays {
+		closedAt := latest.Date
+		ret := SignalHoldingReturn(h.Action, h.TradePrice, price)
+		mark.ClosedAt = &closedAt
+		mark.ExitPrice = &price
+		mark.HoldingReturn = &ret
+	}
+	return mark
+}
+
+// SignalHoldingReturn シグナル価格から出口価格までの保有リターン。Buy は exit/trade-1、Sell は 1-exit/trade（売りで下がれば正）。
+func SignalHoldingReturn(action string, tradePrice, exitPrice decimal.Decimal) decimal.Decimal {
+	if !tradePrice.IsPositive() {
+		return decimal.Zero
+	}
+	ret := exitPrice.Div(tradePrice).Sub(decimal.NewFromInt(1))
+	if action == models.AnalyzeStockBrandPriceHistoryActionSell {
+		ret = ret.Neg()
+	}
+	return ret.Round(6)
+}
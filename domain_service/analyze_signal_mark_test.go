@@ -0,0 +1,117 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func markTestBar(date time.Time, closePrice int64) *models.StockBrandDailyPrice {
+	return &models.StockBrandDailyPrice{TickerSymbol: "7203", Date: date, Close: decimal.NewFromInt(closePrice)}
+}
+
+func TestSignalShareAdjustment(t *testing.T) {
+	signalDate := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+	asOf := time.Date(2024, 6, 28, 0, 0, 0, 0, time.Local)
+	split := func(d time.Time, ratio int64) *models.AppliedStockSplitHistory {
+		return &models.AppliedStockSplitHistory{Symbol: "7203", SplitDate: d, Ratio: decimal.NewFromInt(ratio)}
+	}
+	consolidation := func(d time.Time, ratio int64) *models.AppliedStockConsolidationHistory {
+		return &models.AppliedStockConsolidationHistory{Symbol: "7203", ConsolidationDate: d, Ratio: decimal.NewFromInt(ratio)}
+	}
+
+	tests := []struct {
+		name           string
+		splits         []*models.AppliedStockSplitHistory
+		consolidations []*models.AppliedStockConsolidationHistory
+		want           string
+	}{
+		{name: "分割・併合なし", want: "1"},
+		{name: "期間中の分割は ratio 倍", splits: []*models.AppliedStockSplitHistory{split(signalDate.AddDate(0, 0, 10), 5)}, want: "5"},
+		{name: "シグナル日当日の分割は調整しない", splits: []*models.AppliedStockSplitHistory{split(signalDate, 5)}, want: "1"},
+		{name: "基準日より後の分割は調整しない", splits: []*models.AppliedStockSplitHistory{split(asOf.AddDate(0, 0, 1), 5)}, want: "1"},
+		{name: "基準日当日の分割は調整する", splits: []*models.AppliedStockSplitHistory{split(asOf, 2)}, want: "2"},
+		{
+			name:           "分割と併合の組み合わせ",
+			splits:         []*models.AppliedStockSplitHistory{split(signalDate.AddDate(0, 0, 3), 10)},
+			consolidations: []*models.AppliedStockConsolidationHistory{consolidation(signalDate.AddDate(0, 0, 20), 5)},
+			want:           "2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SignalShareAdjustment(signalDate, asOf, tt.splits, tt.consolidations)
+			assert.True(t, decimal.RequireFromString(tt.want).Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestMarkAnalyzeSignal(t *testing.T) {
+	signalDate := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+	day := func(n int) time.Time { return signalDate.AddDate(0, 0, n) }
+	prices := []*models.StockBrandDailyPrice{
+		markTestBar(day(-1), 990),
+		markTestBar(day(0), 1000),
+		markTestBar(day(3), 1050),
+		markTestBar(day(4), 1100),
+		markTestBar(day(5), 1200),
+	}
+	newSignal := func(action string) *models.AnalyzeStockBrandPriceHistory {
+		return &models.AnalyzeStockBrandPriceHistory{ID: "h1", TickerSymbol: "7203", TradePrice: decimal.NewFromInt(1000), Action: action, CreatedAt: signalDate}
+	}
+
+	t.Run("最新終値で値洗いしクローズしない", func(t *testing.T) {
+		mark := MarkAnalyzeSignal(newSignal(models.AnalyzeStockBrandPriceHistoryActionBuy), prices, nil, nil, 0)
+		require.NotNil(t, mark)
+		assert.Equal(t, "h1", mark.ID)
+		assert.True(t, decimal.NewFromInt(1200).Equal(mark.CurrentPrice))
+		assert.True(t, decimal.NewFromInt(200).Equal(mark.PriceDifference))
+		assert.Equal(t, day(5), mark.MarkedAt)
+		assert.Nil(t, mark.ClosedAt)
+		assert.Nil(t, mark.ExitPrice)
+		assert.Nil(t, mark.HoldingReturn)
+	})
+
+	t.Run("N営業日目の終値でクローズする（Buy）", func(t *testing.T) {
+		mark := MarkAnalyzeSignal(newSignal(models.AnalyzeStockBrandPriceHistoryActionBuy), prices, nil, nil, 2)
+		require.NotNil(t, mark)
+		require.NotNil(t, mark.ClosedAt)
+		assert.Equal(t, day(4), *mark.ClosedAt)
+		assert.Equal(t, day(4), mark.MarkedAt)
+		assert.True(t, decimal.NewFromInt(1100).Equal(*mark.ExitPrice))
+		assert.True(t, decimal.RequireFromString("0.1").Equal(*mark.HoldingReturn))
+	})
+
+	t.Run("Sell は下落で正のリターン", func(t *testing.T) {
+		mark := MarkAnalyzeSignal(newSignal(models.AnalyzeStockBrandPriceHistoryActionSell), prices, nil, nil, 1)
+		require.NotNil(t, mark)
+		require.NotNil(t, mark.ClosedAt)
+		assert.True(t, decimal.RequireFromString("-0.05").Equal(*mark.HoldingReturn))
+	})
+
+	t.Run("N営業日に満たなければ値洗いだけ行う", func(t *testing.T) {
+		mark := MarkAnalyzeSignal(newSignal(models.AnalyzeStockBrandPriceHistoryActionBuy), prices, nil, nil, 5)
+		require.NotNil(t, mark)
+		assert.Nil(t, mark.ClosedAt)
+		assert.True(t, decimal.NewFromInt(1200).Equal(mark.CurrentPrice))
+	})
+
+	t.Run("シグナル後の分割を調整してシグナル日の株数基準にする", func(t *testing.T) {
+		splitPrices := []*models.StockBrandDailyPrice{markTestBar(day(0), 1000), markTestBar(day(3), 210)}
+		splits := []*models.AppliedStockSplitHistory{{Symbol: "7203", SplitDate: day(3), Ratio: decimal.NewFromInt(5)}}
+		mark := MarkAnalyzeSignal(newSignal(models.AnalyzeStockBrandPriceHistoryActionBuy), splitPrices, splits, nil, 1)
+		require.NotNil(t, mark)
+		assert.True(t, decimal.NewFromInt(1050).Equal(mark.CurrentPrice))
+		assert.True(t, decimal.NewFromInt(50).Equal(mark.PriceDifference))
+		assert.True(t, decimal.RequireFromString("0.05").Equal(*mark.HoldingReturn))
+	})
+
+	t.Run("シグナル日以降の日足が無ければ nil", func(t *testing.T) {
+		assert.Nil(t, MarkAnalyzeSignal(newSignal(models.AnalyzeStockBrandPriceHistoryActionBuy), prices[:1], nil, nil, 0))
+	})
+}
@@ -1,6 +1,9 @@
 package domain_service
 
 import (
+	"math"
+	"time"
+
 	"github.com/Code0716/stock-price-repository/models"
 	"github.com/Code0716/stock-price-repository/util"
 	"github.com/shopspring/decimal"
@@ -13,6 +16,15 @@ type ExitParams struct {
 	MaxHoldDays    int             // 最大保有営業日数
 	CommissionRate decimal.Decimal // 片道手数料率（例: 0.0005）。ゼロ値 = コストなし（後方互換）
 	SlippageRate   decimal.Decimal // 片道スリッページ率（例: 0.001）。ゼロ値 = コストなし
+	// 約定モデル（models.BacktestParams と同じ値）。ゼロ値は従来動作。
+	EntryTiming      string // models.EntryTimingClose / EntryTimingNextOpen
+	ExitFill         string // models.ExitFillClose / ExitFillIntrabar
+	IntrabarPriority string // models.IntrabarPriorityStopLoss（既定）/ IntrabarPriorityTakeProfit
+	TickRounding     bool   // ExitFill=intrabar の利確・損切り水準を呼値単位に丸める
+	// Side 売買方向。models.PositionSideShort で空売り（ゼロ値は買い）。
+	Side string
+	// BorrowRate 空売りの貸株料（日率）。売り約定代金に暦日数分を掛けて差し引く。買いでは無視。
+	BorrowRate decimal.Decimal
 }
 
 // tradeStats 約定確定時にインクリメンタルに加算する集計アキュムレータ。
@@ -51,6 +63,9 @@ func (ts *tradeStats) record(ret decimal.Decimal, holdDays int) {
 // exitSignals: 戦略固有の手仕舞いシグナル（ExitSignalsByStrategy の戻り値）。nil を渡すと
 // 共通ルール（TakeProfit/StopLoss/MaxHoldDays）のみで判定する従来動作になる（後方互換）。
 // 優先順位: 既存の TP/SL 判定 -> シグナルイグジット（同日成立時は既存判定を優先）。
+//
+// params.EntryTiming=next_open ではシグナル翌営業日の始値で買い、params.ExitFill=intrabar では
+// 高値・安値で TP/SL の到達を判定して水準価格（寄りで跨いだ場合は始値）で手仕舞う。
 func RunBacktest(prices []*models.StockBrandDailyPrice, entrySignals []bool, exitSignals []bool, params ExitParams) models.BacktestResult {
 	return runBacktest(prices, entrySignals, exitSignals, params, true)
 }
@@ -90,6 +105,103 @@ func decideExitReason(rawRet decimal.Decimal, holdDays int, exitSignals []bool,
 	return ""
 }
 
+// intrabarExitLevels エントリー価格から利確・損切りの水準を返す。
+// 空売りは利確が下、損切りが上になる。呼値丸めは約定しにくい側・約定が不利になる側に寄せる。
+func intrabarExitLevels(entry decimal.Decimal, params ExitParams) (takeProfit, stopLoss decimal.Decimal) {
+	one := decimal.NewFromInt(1)
+	if params.Side == models.PositionSideShort {
+		takeProfit = entry.Mul(one.Sub(params.TakeProfit))
+		stopLoss = entry.Mul(one.Add(params.StopLoss))
+		if params.TickRounding {
+			takeProfit = RoundDownToTick(takeProfit)
+			stopLoss = RoundUpToTick(stopLoss)
+		}
+		return takeProfit, stopLoss
+	}
+	takeProfit = entry.Mul(one.Add(params.TakeProfit))
+	stopLoss = entry.Mul(one.Sub(params.StopLoss))
+	if params.TickRounding {
+		takeProfit = RoundUpToTick(takeProfit)
+		stopLoss = RoundDownToTick(stopLoss)
+	}
+	return takeProfit, stopLoss
+}
+
+// intrabarExit 高値・安値で利確・損切りの到達を判定し、約定価格と理由を返す。未到達なら ""。
+// checkGap のとき、始値が既に水準を跨いでいれば始値で約定する（窓開け）。
+// 同一バーで両方に達した場合は priority に従う（既定は損切り優先）。
+// short のときは価格の上下を反転して判定する（損切りは高値、利確は安値）。
+func intrabarExit(bar *models.StockBrandDailyPrice, takeProfit, stopLoss decimal.Decimal, checkGap, short bool, priority string) (decimal.Decimal, string) {
+	// 欠損（0）の四本値は終値で代用する
+	open, high, low := bar.Open, bar.High, bar.Low
+	if !open.IsPositive() {
+		open = bar.Close
+	}
+	if !high.IsPositive() {
+		high = bar.Close
+	}
+	if !low.IsPositive() {
+		low = bar.Close
+	}
+
+	// reached: 価格 p が水準 level に不利/有利方向で達したか
+	stopReached := func(p decimal.Decimal) bool {
+		if short {
+			return p.GreaterThanOrEqual(stopLoss)
+		}
+		return p.LessThanOrEqual(stopLoss)
+	}
+	takeReached := func(p decimal.Decimal) bool {
+		if short {
+			return p.LessThanOrEqual(takeProfit)
+		}
+		return p.GreaterThanOrEqual(takeProfit)
+	}
+
+	if checkGap {
+		if stopReached(open) {
+			return open, "stop_loss"
+		}
+		if takeReached(open) {
+			return open, "take_profit"
+		}
+	}
+	adverse, favorable := low, high
+	if short {
+		adverse, favorable = high, low
+	}
+	hitStop := stopReached(adverse)
+	hitTake := takeReached(favorable)
+	switch {
+	case hitStop && hitTake && priority == models.IntrabarPriorityTakeProfit:
+		return takeProfit, "take_profit"
+	case hitStop:
+		return stopLoss, "stop_loss"
+	case hitTake:
+		return takeProfit, "take_profit"
+	}
+	return decimal.Zero, ""
+}
+
+// shortReturn 空売りの損益率を返す。
+//
+//	リターン = (売り手取り − 買戻し価格 − 貸株料) ÷ 売り手取り
+//	貸株料 = 売り約定価格 × 日率 × 暦日数
+//
+// cover に生 Close を渡せば時価評価、コスト込みの買戻し単価を渡せば約定リターンになる。
+func shortReturn(proceeds, entryFill, cover decimal.Decimal, days int, borrowRate decimal.Decimal) decimal.Decimal {
+	borrow := decimal.Zero
+	if !borrowRate.IsZero() && days > 0 {
+		borrow = entryFill.Mul(borrowRate).Mul(decimal.NewFromInt(int64(days)))
+	}
+	return proceeds.Sub(cover).Sub(borrow).Div(proceeds)
+}
+
+// calendarDays from から to までの暦日数。
+func calendarDays(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
 // effectiveEntryPrice 手数料・スリッページを加味した実効エントリー取得単価を返す。
 // ゼロ値の場合は生の Close をそのまま返す（後方互換）。
 //
@@ -151,21 +263,50 @@ func runBacktest(prices []*models.StockBrandDailyPrice, entrySignals []bool, exi
 	realizedEquity := one // 直近に確定した資産（フラット時の資産）
 	inPosition := false
 	entryIdx := 0
-	// entryPrice: コスト込みの実効取得単価（エクイティ計算・リターン算出に使用）
-	// entryClose: エントリー当日の生 Close（イグジット判定用）
-	var entryPrice, entryClose, entryEquity decimal.Decimal
+	nextOpen := params.EntryTiming == models.EntryTimingNextOpen
+	short := params.Side == models.PositionSideShort
+	intrabar := params.ExitFill == models.ExitFillIntrabar
+	pendingEntry := false // 翌営業日の始値でエントリー予定（EntryTiming=next_open）
+	// entryPrice: コスト込みの実効取得単価（空売りは売り手取り単価）。エクイティ計算・リターン算出に使用
+	// entryFill: エントリーの生約定価格（イグジット判定用）
+	var entryPrice, entryFill, entryEquity decimal.Decimal
+	// ExitFill=intrabar の利確・損切り水準
+	var takeProfitLevel, stopLossLevel decimal.Decimal
 
 	equitySeries := make([]decimal.Decimal, 0, n)
 	var stats tradeStats
 
-	closeTrade := func(i int, reason string) {
-		exitClose := prices[i].Close
-		// 利確/損切りの判定は生 Close ベース済み（exitReason で実施）。
+	openTrade := func(i int, fill decimal.Decimal) {
+		inPosition = true
+		entryIdx = i
+		entryFill = fill
+		// 実効エントリー価格（コスト込み取得単価。空売りはコスト控除後の売り手取り）
+		if short {
+			entryPrice = effectiveExitPrice(fill, params.CommissionRate, params.SlippageRate)
+		} else {
+			entryPrice = effectiveEntryPrice(fill, params.CommissionRate, params.SlippageRate)
+		}
+		entryEquity = realizedEquity
+		if intrabar {
+			takeProfitLevel, stopLossLevel = intrabarExitLevels(fill, params)
+		}
+	}
+
+	closeTrade := func(i int, reason string, exitFill decimal.Decimal) {
+		// 利確/損切りの判定は生の約定価格ベース済み。
 		// 約定リターンとエクイティの計算にのみコストを適用する。
-		ret := effectiveExitReturn(exitClose, entryPrice, params.CommissionRate, params.SlippageRate)
-		// エクイティ更新: 手取りイグジット ÷ 実効エントリー
-		exitEffective := effectiveExitPrice(exitClose, params.CommissionRate, params.SlippageRate)
-		realizedEquity = entryEquity.Mul(exitEffective.Div(entryPrice))
+		var ret decimal.Decimal
+		if short {
+			// 買戻しはコスト込みの支払単価、貸株料は保有暦日数で日割り
+			cover := effectiveEntryPrice(exitFill, params.CommissionRate, params.SlippageRate)
+			ret = shortReturn(entryPrice, entryFill, cover, calendarDays(prices[entryIdx].Date, prices[i].Date), params.BorrowRate)
+			realizedEquity = entryEquity.Mul(one.Add(ret))
+		} else {
+			ret = effectiveExitReturn(exitFill, entryPrice, params.CommissionRate, params.SlippageRate)
+			// エクイティ更新: 手取りイグジット ÷ 実効エントリー
+			exitEffective := effectiveExitPrice(exitFill, params.CommissionRate, params.SlippageRate)
+			realizedEquity = entryEquity.Mul(exitEffective.Div(entryPrice))
+		}
 
 		// 約定をインクリメンタルに集計（TradeList 非依存）
 		stats.record(ret, i-entryIdx)
@@ -175,7 +316,7 @@ func runBacktest(prices []*models.StockBrandDailyPrice, entrySignals []bool, exi
 				EntryDate:  prices[entryIdx].Date.Format(util.DateLayout),
 				ExitDate:   prices[i].Date.Format(util.DateLayout),
 				EntryPrice: entryPrice.Round(6),
-				ExitPrice:  exitClose.Round(6),
+				ExitPrice:  exitFill.Round(6),
 				Return:     ret.Round(6),
 				HoldDays:   i - entryIdx,
 				Reason:     reason,
@@ -185,30 +326,56 @@ func runBacktest(prices []*models.StockBrandDailyPrice, entrySignals []bool, exi
 	}
 
 	for i := 0; i < n; i++ {
-		// Step A: イグジット判定（エントリー当日は判定しない）
-		// 判定は生 Close ベース（コスト考慮前）
-		if inPosition && i > entryIdx {
-			rawRet := prices[i].Close.Div(entryClose).Sub(one)
-			if reason := decideExitReason(rawRet, i-entryIdx, exitSignals, i, params); reason != "" {
-				closeTrade(i, reason)
+		// Step 0: 前営業日のシグナルを当日の始値で約定（EntryTiming=next_open）
+		if pendingEntry {
+			pendingEntry = false
+			fill := prices[i].Open
+			if !fill.IsPositive() {
+				fill = prices[i].Close
+			}
+			openTrade(i, fill)
+		}
+
+		// Step A: イグジット判定
+		// 終値エントリーは当日は判定しない。始値エントリーは当日の値動きから判定する（窓開けは判定しない）。
+		if inPosition && (i > entryIdx || nextOpen) {
+			holdDays := i - entryIdx
+			if intrabar {
+				if fill, reason := intrabarExit(prices[i], takeProfitLevel, stopLossLevel, i > entryIdx, short, params.IntrabarPriority); reason != "" {
+					closeTrade(i, reason, fill)
+				} else if holdDays >= params.MaxHoldDays {
+					closeTrade(i, "max_hold", prices[i].Close)
+				} else if exitSignals != nil && i < len(exitSignals) && exitSignals[i] {
+					closeTrade(i, "signal_exit", prices[i].Close)
+				}
+			} else {
+				// 判定は生 Close ベース（コスト考慮前）。空売りは値下がりを利益とする
+				rawRet := prices[i].Close.Div(entryFill).Sub(one)
+				if short {
+					rawRet = rawRet.Neg()
+				}
+				if reason := decideExitReason(rawRet, holdDays, exitSignals, i, params); reason != "" {
+					closeTrade(i, reason, prices[i].Close)
+				}
 			}
 		}
 
 		// Step B: エントリー（フラット時かつシグナル成立）
 		if !inPosition && entrySignals[i] {
-			inPosition = true
-			entryIdx = i
-			entryClose = prices[i].Close
-			// 実効エントリー価格（コスト込み取得単価）
-			entryPrice = effectiveEntryPrice(entryClose, params.CommissionRate, params.SlippageRate)
-			entryEquity = realizedEquity
+			if nextOpen {
+				pendingEntry = true
+			} else {
+				openTrade(i, prices[i].Close)
+			}
 		}
 
 		// Step C: 当日のエクイティをマーク（保有中は時価評価）
 		// 時価評価は「生 Close ÷ 実効エントリー価格」ベースで算出する。
 		// これによりコスト込みのエクイティカーブが得られる。
 		var eq decimal.Decimal
-		if inPosition {
+		if inPosition && short {
+			eq = entryEquity.Mul(one.Add(shortReturn(entryPrice, entryFill, prices[i].Close, calendarDays(prices[entryIdx].Date, prices[i].Date), params.BorrowRate)))
+		} else if inPosition {
 			eq = entryEquity.Mul(prices[i].Close.Div(entryPrice))
 		} else {
 			eq = realizedEquity
@@ -225,7 +392,7 @@ func runBacktest(prices []*models.StockBrandDailyPrice, entrySignals []bool, exi
 	// データ末尾で保有が残っていれば強制クローズ（最終日の終値）。
 	// closeTrade 内で realizedEquity が最終確定値に更新される。
 	if inPosition {
-		closeTrade(n-1, "end_of_data")
+		closeTrade(n-1, "end_of_data", prices[n-1].Close)
 	}
 
 	result.Trades = stats.trades
@@ -287,3 +287,174 @@ func TestRunBacktest_WithExitSignals(t *testing.T) {
 		})
 	})
 }
+
+// ohlc 始値・高値・安値・終値を指定した日足を作る。
+func ohlc(day int, o, h, l, c float64) *models.StockBrandDailyPrice {
+	return &models.StockBrandDailyPrice{
+		Date:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, day),
+		Open:  decimal.NewFromFloat(o),
+		High:  decimal.NewFromFloat(h),
+		Low:   decimal.NewFromFloat(l),
+		Close: decimal.NewFromFloat(c),
+	}
+}
+
+func TestRunBacktest_FillModels(t *testing.T) {
+	base := ExitParams{
+		TakeProfit:  decimal.NewFromFloat(0.10),
+		StopLoss:    decimal.NewFromFloat(0.05),
+		MaxHoldDays: 10,
+	}
+
+	t.Run("翌寄り付きエントリー", func(t *testing.T) {
+		p := base
+		p.EntryTiming = models.EntryTimingNextOpen
+		prices := []*models.StockBrandDailyPrice{
+			ohlc(0, 100, 100, 100, 100),
+			ohlc(1, 102, 103, 101, 102),
+			ohlc(2, 103, 113, 102, 113),
+		}
+		res := RunBacktest(prices, boolsAt(3, 0), nil, p)
+		assert.Equal(t, 1, res.Trades)
+		assert.Equal(t, "2024-01-02", res.TradeList[0].EntryDate)
+		assert.InDelta(t, 102, f64FromDec(res.TradeList[0].EntryPrice), 1e-9)
+		assert.Equal(t, "take_profit", res.TradeList[0].Reason)
+	})
+
+	t.Run("最終日のシグナルは約定しない", func(t *testing.T) {
+		p := base
+		p.EntryTiming = models.EntryTimingNextOpen
+		prices := []*models.StockBrandDailyPrice{ohlc(0, 100, 100, 100, 100), ohlc(1, 100, 100, 100, 100)}
+		res := RunBacktest(prices, boolsAt(2, 1), nil, p)
+		assert.Equal(t, 0, res.Trades)
+	})
+
+	t.Run("高値で利確水準に達したら水準価格で約定", func(t *testing.T) {
+		p := base
+		p.ExitFill = models.ExitFillIntrabar
+		prices := []*models.StockBrandDailyPrice{
+			ohlc(0, 100, 100, 100, 100),
+			ohlc(1, 101, 111, 100, 105),
+		}
+		res := RunBacktest(prices, boolsAt(2, 0), nil, p)
+		assert.Equal(t, "take_profit", res.TradeList[0].Reason)
+		assert.InDelta(t, 110, f64FromDec(res.TradeList[0].ExitPrice), 1e-9)
+		assert.InDelta(t, 0.10, f64FromDec(res.TotalReturn), 1e-9)
+	})
+
+	t.Run("同一バーで両方到達: 既定は損切り優先、指定で利確優先", func(t *testing.T) {
+		prices := []*models.StockBrandDailyPrice{
+			ohlc(0, 100, 100, 100, 100),
+			ohlc(1, 100, 112, 94, 100),
+		}
+		p := base
+		p.ExitFill = models.ExitFillIntrabar
+		res := RunBacktest(prices, boolsAt(2, 0), nil, p)
+		assert.Equal(t, "stop_loss", res.TradeList[0].Reason)
+		assert.InDelta(t, 95, f64FromDec(res.TradeList[0].ExitPrice), 1e-9)
+
+		p.IntrabarPriority = models.IntrabarPriorityTakeProfit
+		res = RunBacktest(prices, boolsAt(2, 0), nil, p)
+		assert.Equal(t, "take_profit", res.TradeList[0].Reason)
+	})
+
+	t.Run("寄りで損切り水準を跨いだら始値で約定", func(t *testing.T) {
+		p := base
+		p.ExitFill = models.ExitFillIntrabar
+		prices := []*models.StockBrandDailyPrice{
+			ohlc(0, 100, 100, 100, 100),
+			ohlc(1, 90, 92, 88, 91),
+		}
+		res := RunBacktest(prices, boolsAt(2, 0), nil, p)
+		assert.Equal(t, "stop_loss", res.TradeList[0].Reason)
+		assert.InDelta(t, 90, f64FromDec(res.TradeList[0].ExitPrice), 1e-9)
+		assert.InDelta(t, -0.10, f64FromDec(res.TotalReturn), 1e-9)
+	})
+
+	t.Run("翌寄りエントリー当日の安値で損切り（窓判定はしない）", func(t *testing.T) {
+		p := base
+		p.EntryTiming = models.EntryTimingNextOpen
+		p.ExitFill = models.ExitFillIntrabar
+		prices := []*models.StockBrandDailyPrice{
+			ohlc(0, 100, 100, 100, 100),
+			ohlc(1, 100, 101, 94, 96),
+		}
+		res := RunBacktest(prices, boolsAt(2, 0), nil, p)
+		assert.Equal(t, 0, res.TradeList[0].HoldDays)
+		assert.Equal(t, "stop_loss", res.TradeList[0].Reason)
+		assert.InDelta(t, 95, f64FromDec(res.TradeList[0].ExitPrice), 1e-9)
+	})
+
+	t.Run("呼値丸め: 利確は切り上げ・損切りは切り捨て", func(t *testing.T) {
+		p := base
+		p.ExitFill = models.ExitFillIntrabar
+		p.TickRounding = true
+		// 利確 3003×1.1 = 3303.3 → 3305、損切り 3003×0.95 = 2852.85 → 2852
+		tp, sl := intrabarExitLevels(decimal.NewFromInt(3003), p)
+		assert.True(t, tp.Equal(decimal.NewFromInt(3305)))
+		assert.True(t, sl.Equal(decimal.NewFromInt(2852)))
+	})
+}
+
+func TestRunBacktest_Short(t *testing.T) {
+	base := ExitParams{
+		TakeProfit:  decimal.NewFromFloat(0.10),
+		StopLoss:    decimal.NewFromFloat(0.05),
+		MaxHoldDays: 10,
+		Side:        models.PositionSideShort,
+	}
+
+	t.Run("下落で利確", func(t *testing.T) {
+		res := RunBacktest(pricesFromCloses(100, 95, 90), boolsAt(3, 0), nil, base)
+		assert.Equal(t, 1, res.Trades)
+		assert.Equal(t, "take_profit", res.TradeList[0].Reason)
+		assert.InDelta(t, 0.10, f64FromDec(res.TotalReturn), 1e-9)
+	})
+
+	t.Run("上昇で損切り", func(t *testing.T) {
+		res := RunBacktest(pricesFromCloses(100, 106), boolsAt(2, 0), nil, base)
+		assert.Equal(t, "stop_loss", res.TradeList[0].Reason)
+		assert.InDelta(t, -0.06, f64FromDec(res.TotalReturn), 1e-9)
+	})
+
+	t.Run("貸株料は暦日数分だけリターンを押し下げる", func(t *testing.T) {
+		p := base
+		p.BorrowRate = decimal.NewFromFloat(0.001)
+		// 2日保有: (100 - 90 - 100×0.001×2) / 100 = 0.098
+		res := RunBacktest(pricesFromCloses(100, 95, 90), boolsAt(3, 0), nil, p)
+		assert.InDelta(t, 0.098, f64FromDec(res.TotalReturn), 1e-9)
+	})
+
+	t.Run("ザラ場: 高値で損切り水準に達したら水準価格で買い戻す", func(t *testing.T) {
+		p := base
+		p.ExitFill = models.ExitFillIntrabar
+		prices := []*models.StockBrandDailyPrice{
+			ohlc(0, 100, 100, 100, 100),
+			ohlc(1, 101, 106, 99, 104),
+		}
+		res := RunBacktest(prices, boolsAt(2, 0), nil, p)
+		assert.Equal(t, "stop_loss", res.TradeList[0].Reason)
+		assert.InDelta(t, 105, f64FromDec(res.TradeList[0].ExitPrice), 1e-9)
+	})
+
+	t.Run("ザラ場: 寄りで損切り水準を上に跨いだら始値で買い戻す", func(t *testing.T) {
+		p := base
+		p.ExitFill = models.ExitFillIntrabar
+		prices := []*models.StockBrandDailyPrice{
+			ohlc(0, 100, 100, 100, 100),
+			ohlc(1, 107, 108, 106, 107),
+		}
+		res := RunBacktest(prices, boolsAt(2, 0), nil, p)
+		assert.InDelta(t, 107, f64FromDec(res.TradeList[0].ExitPrice), 1e-9)
+		assert.InDelta(t, -0.07, f64FromDec(res.TotalReturn), 1e-9)
+	})
+
+	t.Run("呼値丸め: 空売りの利確は切り捨て・損切りは切り上げ", func(t *testing.T) {
+		p := base
+		p.TickRounding = true
+		// 利確 3003×0.9 = 2702.7 → 2702、損切り 3003×1.05 = 3153.15 → 3155
+		tp, sl := intrabarExitLevels(decimal.NewFromInt(3003), p)
+		assert.True(t, tp.Equal(decimal.NewFromInt(2702)))
+		assert.True(t, sl.Equal(decimal.NewFromInt(3155)))
+	})
+}
@@ -0,0 +1,236 @@
+package domain_service
+
+import (
+	"slices"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+const (
+	// calibrationBucketCount スコアを件数ベースで等分割する数（十分位）。
+	calibrationBucketCount = 10
+	// calibrationMinICCount 日次 IC を出す1日あたりの最小標本数。これ未満の日は順位が数件しかなく IC が ±1 に張り付くため除く。
+	calibrationMinICCount = 5
+	// calibrationMinRhoBuckets 十分位の単調性の順位相関を出す最小の帯数。
+	calibrationMinRhoBuckets = 3
+)
+
+// DailyPickCalibrationHorizons 買い候補の較正に使う horizon（保存済みの1/3/5営業日後リターン）。
+var DailyPickCalibrationHorizons = []int{1, 3, 5}
+
+// DailyPickCalibrationSamples horizon 営業日後リターンが確定した推奨を較正の標本にする（horizon は DailyPickCalibrationHorizons のいずれか）。
+func DailyPickCalibrationSamples(picks []*models.DailyStockPick, horizon int) []models.CalibrationSample {
+	out := make([]models.CalibrationSample, 0, len(picks))
+	for _, p := range picks {
+		var r *decimal.Decimal
+		switch horizon {
+		case 1:
+			r = p.Return1D
+		case 3:
+			r = p.Return3D
+		case 5:
+			r = p.Return5D
+		}
+		if r == nil {
+			continue
+		}
+		out = append(out, models.CalibrationSample{Date: p.PickDate, Score: p.Score, Return: *r})
+	}
+	return out
+}
+
+// SignalCalibrationSamples スコアがあり horizon 営業日後リターンが到来済みのシグナルを較正の標本にする。
+// リターンは ForwardReturns と同じく Sell シグナルは符号反転済みのものを使う。
+func SignalCalibrationSamples(signals []*models.EvaluatedSignal, horizon int) []models.CalibrationSample {
+	out := make([]models.CalibrationSample, 0, len(signals))
+	for _, s := range signals {
+		if s.Score == nil || s.Returns == nil || s.Returns[horizon] == nil {
+			continue
+		}
+		out = append(out, models.CalibrationSample{Date: s.Date, Score: *s.Score, Return: *s.Returns[horizon]})
+	}
+	return out
+}
+
+// AnalyzeScoreCalibration スコアが高いほど成績が良いかを検証する。
+// スコア昇順に件数ベースで十分位に分け（標本が10件未満なら十分位・スプレッド・曲線は空）、各帯の平均リターンと的中率（リターン>0 の割合）、
+// 十分位の単調性、最上位と最下位の平均リターン差、各十分位の下限スコアで足切りしたときの的中率曲線、日次の順位 IC を返す。
+func AnalyzeScoreCalibration(samples []models.CalibrationSample, horizon int) *models.CalibrationHorizon {
+	out := &models.CalibrationHorizon{
+		Horizon:      horizon,
+		SampleCount:  len(samples),
+		Deciles:      []*models.CalibrationBucket{},
+		HitRateCurve: []*models.CalibrationHitRatePoint{},
+		IC:           calibrationIC(samples),
+	}
+	if len(samples) < calibrationBucketCount {
+		return out
+	}
+
+	sorted := slices.Clone(samples)
+	slices.SortStableFunc(sorted, func(a, b models.CalibrationSample) int { return a.Score.Cmp(b.Score) })
+
+	n := len(sorted)
+	for b := 0; b < calibrationBucketCount; b++ {
+		bucket := sorted[b*n/calibrationBucketCount : (b+1)*n/calibrationBucketCount]
+		avg, hit := calibrationReturnStats(bucket)
+		out.Deciles = append(out.Deciles, &models.CalibrationBucket{
+			Bucket:    b + 1,
+			MinScore:  bucket[0].Score,
+			MaxScore:  bucket[len(bucket)-1].Score,
+			Count:     len(bucket),
+			AvgReturn: avg,
+			HitRate:   hit,
+		})
+	}
+
+	out.Monotonicity = calibrationMonotonicity(out.Deciles)
+	top := out.Deciles[len(out.Deciles)-1].AvgReturn
+	bottom := out.Deciles[0].AvgReturn
+	spread := top.Sub(bottom)
+	out.TopReturn, out.BottomReturn, out.DecileSpread = &top, &bottom, &spread
+
+	for _, d := range out.Deciles {
+		// 同じスコアが帯の境界をまたぐこともあるため、帯ではなくスコアで足切りし直す。
+		i, _ := slices.BinarySearchFunc(sorted, d.MinScore, func(s models.CalibrationSample, t decimal.Decimal) int { return s.Score.Cmp(t) })
+		kept := sorted[i:]
+		avg, hit := calibrationReturnStats(kept)
+		if len(out.HitRateCurve) > 0 && out.HitRateCurve[len(out.HitRateCurve)-1].Count == len(kept) {
+			continue
+		}
+		out.HitRateCurve = append(out.HitRateCurve, &models.CalibrationHitRatePoint{
+			MinScore:  d.MinScore,
+			Count:     len(kept),
+			HitRate:   hit,
+			AvgReturn: avg,
+		})
+	}
+	return out
+}
+
+// calibrationReturnStats 平均リターンと的中率（リターン>0 の割合）。
+func calibrationReturnStats(samples []models.CalibrationSample) (avg, hitRate decimal.Decimal) {
+	if len(samples) == 0 {
+		return decimal.Zero, decimal.Zero
+	}
+	returns := make([]decimal.Decimal, len(samples))
+	hits := 0
+	for i, s := range samples {
+		returns[i] = s.Return
+		if s.Return.IsPositive() {
+			hits++
+		}
+	}
+	return mean(returns).Round(6), decimal.NewFromInt(int64(hits)).Div(decimal.NewFromInt(int64(len(samples)))).Round(4)
+}
+
+// calibrationMonotonicity 十分位の平均リターンがスコア順に上がっているか。
+func calibrationMonotonicity(deciles []*models.CalibrationBucket) models.CalibrationMonotonicity {
+	m := models.CalibrationMonotonicity{Steps: len(deciles) - 1}
+	idx := make([]decimal.Decimal, len(deciles))
+	avgs := make([]decimal.Decimal, len(deciles))
+	for i, d := range deciles {
+		idx[i] = decimal.NewFromInt(int64(d.Bucket))
+		avgs[i] = d.AvgReturn
+		if i > 0 && d.AvgReturn.GreaterThan(deciles[i-1].AvgReturn) {
+			m.IncreasingSteps++
+		}
+	}
+	m.Monotonic = m.Steps > 0 && m.IncreasingSteps == m.Steps
+	if len(deciles) >= calibrationMinRhoBuckets && !stdDevSample(avgs).IsZero() {
+		rho := SpearmanCorrelation(idx, avgs).Round(4)
+		m.Rho = &rho
+	}
+	return m
+}
+
+// calibrationIC 日ごとにスコアとリターンの順位相関を取り、時系列と平均・標準偏差・t 値・プラスの日の割合を返す。
+// 標本が calibrationMinICCount 未満の日や、スコアかリターンが全件同じ日は除く。
+func calibrationIC(samples []models.CalibrationSample) models.CalibrationIC {
+	byDate := make(map[string][]models.CalibrationSample)
+	for _, s := range samples {
+		day := s.Date.Format(dailyPickDateLayout)
+		byDate[day] = append(byDate[day], s)
+	}
+	days := make([]string, 0, len(byDate))
+	for d := range byDate {
+		days = append(days, d)
+	}
+	slices.Sort(days)
+
+	out := models.CalibrationIC{Series: []*models.CalibrationICPoint{}}
+	ics := make([]decimal.Decimal, 0, len(days))
+	positive := 0
+	for _, day := range days {
+		ds := byDate[day]
+		if len(ds) < calibrationMinICCount {
+			continue
+		}
+		scores := make([]decimal.Decimal, len(ds))
+		returns := make([]decimal.Decimal, len(ds))
+		for i, s := range ds {
+			scores[i], returns[i] = s.Score, s.Return
+		}
+		if stdDevSample(scores).IsZero() || stdDevSample(returns).IsZero() {
+			continue
+		}
+		ic := SpearmanCorrelation(scores, returns)
+		ics = append(ics, ic)
+		if ic.IsPositive() {
+			positive++
+		}
+		out.Series = append(out.Series, &models.CalibrationICPoint{Date: day, IC: ic.Round(4), Count: len(ds)})
+	}
+
+	out.Days = len(ics)
+	if out.Days == 0 {
+		return out
+	}
+	m := mean(ics).Round(4)
+	rate := decimal.NewFromInt(int64(positive)).Div(decimal.NewFromInt(int64(out.Days))).Round(4)
+	out.Mean, out.PositiveRate = &m, &rate
+	if out.Days >= 2 {
+		sd := stdDevSample(ics)
+		sdRounded := sd.Round(4)
+		out.StdDev = &sdRounded
+		if !sd.IsZero() {
+			t := mean(ics).Div(sd.Div(decimalSqrt(decimal.NewFromInt(int64(out.Days))))).Round(4)
+			out.TStat = &t
+		}
+	}
+	return out
+}
+
+// SpearmanCorrelation 2系列のスピアマン順位相関（同順位は平均順位にしてから Correlation を取る）。
+func SpearmanCorrelation(a, b []decimal.Decimal) decimal.Decimal {
+	if len(a) < 2 || len(a) != len(b) {
+		return decimal.Zero
+	}
+	return Correlation(averageRanks(a), averageRanks(b))
+}
+
+// averageRanks 昇順の順位（1始まり）を返す。同じ値には平均順位を付ける。
+func averageRanks(xs []decimal.Decimal) []decimal.Decimal {
+	order := make([]int, len(xs))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return xs[i].Cmp(xs[j]) })
+
+	ranks := make([]decimal.Decimal, len(xs))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && xs[order[end]].Equal(xs[order[start]]) {
+			end++
+		}
+		// 順位 start+1 .. end の平均
+		avg := decimal.NewFromInt(int64(start + 1 + end)).Div(decimal.NewFromInt(2))
+		for _, i := range order[start:end] {
+			ranks[i] = avg
+		}
+		start = end
+	}
+	return ranks
+}
@@ -0,0 +1,167 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func calibrationSample(day int, score, ret float64) models.CalibrationSample {
+	return models.CalibrationSample{
+		Date:   time.Date(2026, 7, day, 0, 0, 0, 0, time.UTC),
+		Score:  decimal.NewFromFloat(score),
+		Return: decimal.NewFromFloat(ret),
+	}
+}
+
+func TestSpearmanCorrelation(t *testing.T) {
+	ds := func(vs ...float64) []decimal.Decimal {
+		out := make([]decimal.Decimal, len(vs))
+		for i, v := range vs {
+			out[i] = decimal.NewFromFloat(v)
+		}
+		return out
+	}
+	assert.Equal(t, "1", SpearmanCorrelation(ds(1, 2, 3, 4), ds(10, 20, 1000, 1001)).Round(4).String(), "単調増加なら値の大きさによらず1")
+	assert.Equal(t, "-1", SpearmanCorrelation(ds(1, 2, 3, 4), ds(4, 3, 2, 1)).Round(4).String())
+	assert.Equal(t, []string{"1", "2.5", "2.5", "4"}, func() []string {
+		var out []string
+		for _, r := range averageRanks(ds(1, 5, 5, 9)) {
+			out = append(out, r.String())
+		}
+		return out
+	}(), "同値は平均順位")
+	assert.True(t, SpearmanCorrelation(ds(1), ds(1)).IsZero())
+}
+
+func TestAnalyzeScoreCalibration(t *testing.T) {
+	t.Run("スコアが高いほどリターンが高ければ単調・正のIC・正のスプレッド", func(t *testing.T) {
+		var samples []models.CalibrationSample
+		// 2日 × 10件。どちらの日もスコア順にリターンが上がる
+		for day := 1; day <= 2; day++ {
+			for i := 0; i < 10; i++ {
+				samples = append(samples, calibrationSample(day, float64(i*10), float64(i-4)/100))
+			}
+		}
+		got := AnalyzeScoreCalibration(samples, 5)
+
+		assert.Equal(t, 5, got.Horizon)
+		assert.Equal(t, 20, got.SampleCount)
+		if assert.Len(t, got.Deciles, 10) {
+			assert.Equal(t, 1, got.Deciles[0].Bucket)
+			assert.Equal(t, 2, got.Deciles[0].Count)
+			assert.Equal(t, "-0.04", got.Deciles[0].AvgReturn.String())
+			assert.Equal(t, "0", got.Deciles[0].HitRate.String())
+			assert.Equal(t, "90", got.Deciles[9].MinScore.String())
+			assert.Equal(t, "1", got.Deciles[9].HitRate.String())
+		}
+		assert.True(t, got.Monotonicity.Monotonic)
+		assert.Equal(t, 9, got.Monotonicity.IncreasingSteps)
+		assert.Equal(t, "1", got.Monotonicity.Rho.String())
+		assert.Equal(t, "0.09", got.DecileSpread.String())
+
+		// 足切り曲線: 全件 → 上位だけ、と件数が減り的中率が上がる
+		if assert.Len(t, got.HitRateCurve, 10) {
+			assert.Equal(t, 20, got.HitRateCurve[0].Count)
+			assert.Equal(t, "0.5", got.HitRateCurve[0].HitRate.String())
+			assert.Equal(t, 2, got.HitRateCurve[9].Count)
+		}
+
+		assert.Equal(t, 2, got.IC.Days)
+		assert.Equal(t, "1", got.IC.Mean.String())
+		assert.Equal(t, "1", got.IC.PositiveRate.String())
+		assert.Nil(t, got.IC.TStat, "日次ICのばらつきが0なら t 値は出さない")
+		if assert.Len(t, got.IC.Series, 2) {
+			assert.Equal(t, "2026-07-01", got.IC.Series[0].Date)
+			assert.Equal(t, 10, got.IC.Series[0].Count)
+		}
+	})
+
+	t.Run("日次ICのt値は平均/(標準偏差/√日数)", func(t *testing.T) {
+		var samples []models.CalibrationSample
+		// 1日目は完全に順相関、2日目は一部逆転、3日目は逆相関気味
+		orders := [][]float64{
+			{1, 2, 3, 4, 5},
+			{2, 1, 3, 5, 4},
+			{5, 4, 3, 1, 2},
+		}
+		for d, rets := range orders {
+			for i, r := range rets {
+				samples = append(samples, calibrationSample(d+1, float64(i), r/100))
+			}
+		}
+		got := AnalyzeScoreCalibration(samples, 1)
+
+		assert.Equal(t, 3, got.IC.Days)
+		assert.Equal(t, "1", got.IC.Series[0].IC.String())
+		assert.Equal(t, "0.8", got.IC.Series[1].IC.String())
+		assert.Equal(t, "-0.9", got.IC.Series[2].IC.String())
+		assert.Equal(t, "0.3", got.IC.Mean.String())
+		assert.Equal(t, "0.6667", got.IC.PositiveRate.String())
+		if assert.NotNil(t, got.IC.TStat) {
+			assert.Equal(t, "0.4977", got.IC.TStat.String())
+		}
+		assert.Len(t, got.Deciles, 10, "15件なら十分位に分ける")
+	})
+
+	t.Run("標本が少なければ十分位とICを出さない", func(t *testing.T) {
+		samples := []models.CalibrationSample{
+			calibrationSample(1, 10, 0.01),
+			calibrationSample(1, 20, 0.02),
+			calibrationSample(1, 30, 0.03),
+		}
+		got := AnalyzeScoreCalibration(samples, 5)
+		assert.Equal(t, 3, got.SampleCount)
+		assert.Empty(t, got.Deciles)
+		assert.Empty(t, got.HitRateCurve)
+		assert.Nil(t, got.DecileSpread)
+		assert.Nil(t, got.Monotonicity.Rho)
+		assert.Equal(t, 0, got.IC.Days, "1日5件未満の日は除く")
+		assert.Nil(t, got.IC.Mean)
+	})
+
+	t.Run("同じスコアが多いときは足切り曲線の重複点を除く", func(t *testing.T) {
+		var samples []models.CalibrationSample
+		for i := 0; i < 10; i++ {
+			score := 50.0
+			if i >= 8 {
+				score = 90
+			}
+			samples = append(samples, calibrationSample(1+i, score, 0.01))
+		}
+		got := AnalyzeScoreCalibration(samples, 5)
+		if assert.Len(t, got.HitRateCurve, 2) {
+			assert.Equal(t, 10, got.HitRateCurve[0].Count)
+			assert.Equal(t, 2, got.HitRateCurve[1].Count)
+		}
+		assert.False(t, got.Monotonicity.Monotonic)
+		assert.Nil(t, got.Monotonicity.Rho, "平均リターンが全帯同じなら順位相関は出さない")
+	})
+}
+
+func TestCalibrationSamples(t *testing.T) {
+	r1, r5 := decimal.RequireFromString("0.01"), decimal.RequireFromString("0.05")
+	picks := []*models.DailyStockPick{
+		{PickDate: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), Score: decimal.NewFromInt(80), Return1D: &r1, Return5D: &r5},
+		{PickDate: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), Score: decimal.NewFromInt(70), Return1D: &r1},
+	}
+	assert.Len(t, DailyPickCalibrationSamples(picks, 1), 2)
+	if got := DailyPickCalibrationSamples(picks, 5); assert.Len(t, got, 1) {
+		assert.Equal(t, "80", got[0].Score.String())
+		assert.Equal(t, "0.05", got[0].Return.String())
+	}
+	assert.Empty(t, DailyPickCalibrationSamples(picks, 3))
+
+	score := decimal.NewFromInt(3)
+	signals := []*models.EvaluatedSignal{
+		{Score: &score, Returns: map[int]*decimal.Decimal{5: &r5, 10: nil}},
+		{Score: nil, Returns: map[int]*decimal.Decimal{5: &r5}},
+		{Score: &score},
+	}
+	assert.Len(t, SignalCalibrationSamples(signals, 5), 1)
+	assert.Empty(t, SignalCalibrationSamples(signals, 10), "未到来は除く")
+}
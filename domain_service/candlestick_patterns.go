@@ -0,0 +1,438 @@
+package domain_service
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// 酒田五法などで知られる代表的なローソク足パターンの検出。
+// いずれも当日までの日足だけで判定する（先読みなし）。
+
+const (
+	// candlestickAvgBodyPeriod 「大きな実体」の基準にする直近平均実体の日数
+	candlestickAvgBodyPeriod = 10
+	// candlestickTrendPeriod 直前のトレンド判定に使う日数（終値の比較）
+	candlestickTrendPeriod = 5
+	// candlestickMinIndex 判定を始める最初のインデックス。3本組パターンの1本目にも平均実体が確定している位置。
+	candlestickMinIndex = candlestickAvgBodyPeriod + 2
+)
+
+var (
+	// candlestickDojiBodyRatio 実体が値幅のこの割合以下なら十字線
+	candlestickDojiBodyRatio = decimal.NewFromFloat(0.1)
+	// candlestickSmallShadowRatio 「ヒゲがほぼない」とみなす値幅に対する割合
+	candlestickSmallShadowRatio = decimal.NewFromFloat(0.1)
+	// candlestickLongShadowRatio 足長十字線とみなす上下ヒゲそれぞれの値幅に対する割合
+	candlestickLongShadowRatio = decimal.NewFromFloat(0.3)
+	// candlestickStarBodyRatio 明けの明星・宵の明星の2本目（星）の実体の上限（1本目の実体比）
+	candlestickStarBodyRatio = decimal.NewFromFloat(0.3)
+	// candlestickGapFullStrength この割合（5%）以上の窓を強度100とする
+	candlestickGapFullStrength = decimal.NewFromFloat(0.05)
+
+	decimalHundred = decimal.NewFromInt(100)
+	decimalTwo     = decimal.NewFromInt(2)
+)
+
+// candlestickPatternDef パターン定義。detect は i 本目で成立したときに強度（0〜100）と true を返す。
+type candlestickPatternDef struct {
+	id        string
+	label     string
+	direction string
+	detect    func(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool)
+}
+
+// candlestickPatternDefs 検出順（= 同日内の並び順・統計の並び順）。
+var candlestickPatternDefs = []candlestickPatternDef{
+	{models.CandlestickPatternBullishEngulfing, "陽の包み足", models.CandlestickDirectionBullish, detectBullishEngulfing},
+	{models.CandlestickPatternBearishEngulfing, "陰の包み足", models.CandlestickDirectionBearish, detectBearishEngulfing},
+	{models.CandlestickPatternBullishHarami, "陽のはらみ足", models.CandlestickDirectionBullish, detectBullishHarami},
+	{models.CandlestickPatternBearishHarami, "陰のはらみ足", models.CandlestickDirectionBearish, detectBearishHarami},
+	{models.CandlestickPatternHammer, "たくり線（ハンマー）", models.CandlestickDirectionBullish, detectHammer},
+	{models.CandlestickPatternShootingStar, "流れ星（シューティングスター）", models.CandlestickDirectionBearish, detectShootingStar},
+	{models.CandlestickPatternDragonflyDoji, "トンボ", models.CandlestickDirectionBullish, detectDojiVariant(models.CandlestickPatternDragonflyDoji)},
+	{models.CandlestickPatternGravestoneDoji, "トウバ", models.CandlestickDirectionBearish, detectDojiVariant(models.CandlestickPatternGravestoneDoji)},
+	{models.CandlestickPatternLongLeggedDoji, "足長同時線", models.CandlestickDirectionNeutral, detectDojiVariant(models.CandlestickPatternLongLeggedDoji)},
+	{models.CandlestickPatternDoji, "同時線（十字線）", models.CandlestickDirectionNeutral, detectDojiVariant(models.CandlestickPatternDoji)},
+	{models.CandlestickPatternMorningStar, "明けの明星", models.CandlestickDirectionBullish, detectMorningStar},
+	{models.CandlestickPatternEveningStar, "宵の明星", models.CandlestickDirectionBearish, detectEveningStar},
+	{models.CandlestickPatternThreeWhiteSoldiers, "赤三兵", models.CandlestickDirectionBullish, detectThreeWhiteSoldiers},
+	{models.CandlestickPatternGapUp, "窓開け（上昇）", models.CandlestickDirectionBullish, detectGapUp},
+	{models.CandlestickPatternGapDown, "窓開け（下落）", models.CandlestickDirectionBearish, detectGapDown},
+}
+
+// CandlestickPatternIDs 検出対象のパターン ID（検出順）。
+func CandlestickPatternIDs() []string {
+	ids := make([]string, 0, len(candlestickPatternDefs))
+	for _, d := range candlestickPatternDefs {
+		ids = append(ids, d.id)
+	}
+	return ids
+}
+
+// DetectCandlestickPatterns 日足（date 昇順）から全パターンを検出する。
+// 戻り値は日付昇順、同日内は検出順。同じ日に複数のパターンが成立することもある。
+func DetectCandlestickPatterns(prices []*models.StockBrandDailyPrice) []*models.CandlestickPatternHit {
+	hits := make([]*models.CandlestickPatternHit, 0)
+	for i := candlestickMinIndex; i < len(prices); i++ {
+		for _, d := range candlestickPatternDefs {
+			strength, ok := d.detect(prices, i)
+			if !ok {
+				continue
+			}
+			hits = append(hits, &models.CandlestickPatternHit{
+				Date:      prices[i].Date,
+				Pattern:   d.id,
+				Label:     d.label,
+				Direction: d.direction,
+				Strength:  clampStrength(strength),
+			})
+		}
+	}
+	return hits
+}
+
+// CandlestickPatternSignals direction のパターンが1つ以上成立した日を true とするシグナル。
+func CandlestickPatternSignals(prices []*models.StockBrandDailyPrice, direction string) []bool {
+	signals := make([]bool, len(prices))
+	for i := candlestickMinIndex; i < len(prices); i++ {
+		for _, d := range candlestickPatternDefs {
+			if d.direction != direction {
+				continue
+			}
+			if _, ok := d.detect(prices, i); ok {
+				signals[i] = true
+				break
+			}
+		}
+	}
+	return signals
+}
+
+// CandlestickBullishEntrySignals 強気パターンの出現でエントリー（買い）。
+func CandlestickBullishEntrySignals(prices []*models.StockBrandDailyPrice) []bool {
+	return CandlestickPatternSignals(prices, models.CandlestickDirectionBullish)
+}
+
+// CandlestickBullishExitSignals 弱気パターンの出現で手仕舞い。
+func CandlestickBullishExitSignals(prices []*models.StockBrandDailyPrice) []bool {
+	return CandlestickPatternSignals(prices, models.CandlestickDirectionBearish)
+}
+
+// CandlestickBearishEntrySignals 弱気パターンの出現でエントリー（空売り）。
+func CandlestickBearishEntrySignals(prices []*models.StockBrandDailyPrice) []bool {
+	return CandlestickPatternSignals(prices, models.CandlestickDirectionBearish)
+}
+
+// CandlestickBearishExitSignals 強気パターンの出現で買い戻し。
+func CandlestickBearishExitSignals(prices []*models.StockBrandDailyPrice) []bool {
+	return CandlestickPatternSignals(prices, models.CandlestickDirectionBullish)
+}
+
+// CandlestickPatternStatistics 検出結果 hits について、出現日終値からの horizon 営業日後リターンをパターン別に集計する。
+// prices は hits の検出元の日足（date 昇順、horizon 分の後続日足を含む）。
+// 弱気パターンは売り方向（符号反転）、強気・中立パターンは買い方向のリターンで評価する。
+// 戻り値は検出順で、出現しなかったパターンは含まない。
+func CandlestickPatternStatistics(prices []*models.StockBrandDailyPrice, hits []*models.CandlestickPatternHit, horizons []int) []*models.CandlestickPatternStats {
+	indexByDate := make(map[string]int, len(prices))
+	for i, p := range prices {
+		indexByDate[util.DatetimeToDateStr(p.Date)] = i
+	}
+
+	counts := make(map[string]int)
+	returns := make(map[string]map[int][]decimal.Decimal)
+	for _, hit := range hits {
+		idx, ok := indexByDate[util.DatetimeToDateStr(hit.Date)]
+		if !ok {
+			continue
+		}
+		counts[hit.Pattern]++
+		action := models.AnalyzeStockBrandPriceHistoryActionBuy
+		if hit.Direction == models.CandlestickDirectionBearish {
+			action = models.AnalyzeStockBrandPriceHistoryActionSell
+		}
+		rs, ok := ForwardReturns(prices[idx:], action, horizons)
+		if !ok {
+			continue
+		}
+		if returns[hit.Pattern] == nil {
+			returns[hit.Pattern] = make(map[int][]decimal.Decimal, len(horizons))
+		}
+		for _, h := range horizons {
+			if v := rs[h]; v != nil {
+				returns[hit.Pattern][h] = append(returns[hit.Pattern][h], *v)
+			}
+		}
+	}
+
+	stats := make([]*models.CandlestickPatternStats, 0)
+	for _, d := range candlestickPatternDefs {
+		if counts[d.id] == 0 {
+			continue
+		}
+		byHorizon := make(map[int]*models.HorizonStats, len(horizons))
+		for _, h := range horizons {
+			byHorizon[h] = calcHorizonStats(returns[d.id][h])
+		}
+		stats = append(stats, &models.CandlestickPatternStats{
+			Pattern:   d.id,
+			Label:     d.label,
+			Direction: d.direction,
+			Count:     counts[d.id],
+			Stats:     byHorizon,
+		})
+	}
+	return stats
+}
+
+func candleBody(p *models.StockBrandDailyPrice) decimal.Decimal {
+	return p.Close.Sub(p.Open).Abs()
+}
+
+func candleRange(p *models.StockBrandDailyPrice) decimal.Decimal {
+	return p.High.Sub(p.Low)
+}
+
+func candleUpperShadow(p *models.StockBrandDailyPrice) decimal.Decimal {
+	return p.High.Sub(decimal.Max(p.Open, p.Close))
+}
+
+func candleLowerShadow(p *models.StockBrandDailyPrice) decimal.Decimal {
+	return decimal.Min(p.Open, p.Close).Sub(p.Low)
+}
+
+func isBullishCandle(p *models.StockBrandDailyPrice) bool {
+	return p.Close.GreaterThan(p.Open)
+}
+
+func isBearishCandle(p *models.StockBrandDailyPrice) bool {
+	return p.Close.LessThan(p.Open)
+}
+
+// averageBody i 本目より前の candlestickAvgBodyPeriod 本の平均実体。
+func averageBody(prices []*models.StockBrandDailyPrice, i int) decimal.Decimal {
+	sum := decimal.Zero
+	for j := i - candlestickAvgBodyPeriod; j < i; j++ {
+		sum = sum.Add(candleBody(prices[j]))
+	}
+	return sum.Div(decimal.NewFromInt(candlestickAvgBodyPeriod))
+}
+
+// isLongBody 実体が直近の平均実体以上か。
+func isLongBody(prices []*models.StockBrandDailyPrice, i int) bool {
+	body := candleBody(prices[i])
+	return body.IsPositive() && body.GreaterThanOrEqual(averageBody(prices, i))
+}
+
+// isPriorDowntrend i 本目の直前が下落基調（前日終値 < その candlestickTrendPeriod 日前の終値）か。
+func isPriorDowntrend(prices []*models.StockBrandDailyPrice, i int) bool {
+	return prices[i-1].Close.LessThan(prices[i-1-candlestickTrendPeriod].Close)
+}
+
+func isPriorUptrend(prices []*models.StockBrandDailyPrice, i int) bool {
+	return prices[i-1].Close.GreaterThan(prices[i-1-candlestickTrendPeriod].Close)
+}
+
+func isDoji(p *models.StockBrandDailyPrice) bool {
+	r := candleRange(p)
+	return r.IsPositive() && candleBody(p).LessThanOrEqual(r.Mul(candlestickDojiBodyRatio))
+}
+
+// clampStrength 強度を 0〜100 に丸める。
+func clampStrength(v decimal.Decimal) decimal.Decimal {
+	return decimal.Min(decimal.Max(v, decimal.Zero), decimalHundred).Round(2)
+}
+
+// detectBullishEngulfing 陰線の実体を翌日の陽線の実体が包む。強度は実体比（2倍で100）。
+func detectBullishEngulfing(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	prev, cur := prices[i-1], prices[i]
+	if !isBearishCandle(prev) || !isBullishCandle(cur) {
+		return decimal.Zero, false
+	}
+	if cur.Open.GreaterThan(prev.Close) || cur.Close.LessThan(prev.Open) || !candleBody(cur).GreaterThan(candleBody(prev)) {
+		return decimal.Zero, false
+	}
+	return candleBody(cur).Div(candleBody(prev)).Mul(decimal.NewFromInt(50)), true
+}
+
+// detectBearishEngulfing 陽線の実体を翌日の陰線の実体が包む。
+func detectBearishEngulfing(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	prev, cur := prices[i-1], prices[i]
+	if !isBullishCandle(prev) || !isBearishCandle(cur) {
+		return decimal.Zero, false
+	}
+	if cur.Open.LessThan(prev.Close) || cur.Close.GreaterThan(prev.Open) || !candleBody(cur).GreaterThan(candleBody(prev)) {
+		return decimal.Zero, false
+	}
+	return candleBody(cur).Div(candleBody(prev)).Mul(decimal.NewFromInt(50)), true
+}
+
+// detectBullishHarami 大陰線の実体の内側に小さな陽線が収まる。強度は実体が小さいほど高い。
+func detectBullishHarami(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	prev, cur := prices[i-1], prices[i]
+	if !isBearishCandle(prev) || !isLongBody(prices, i-1) || !isBullishCandle(cur) {
+		return decimal.Zero, false
+	}
+	if cur.Open.LessThan(prev.Close) || cur.Close.GreaterThan(prev.Open) || !candleBody(cur).LessThan(candleBody(prev)) {
+		return decimal.Zero, false
+	}
+	return decimal.NewFromInt(1).Sub(candleBody(cur).Div(candleBody(prev))).Mul(decimalHundred), true
+}
+
+// detectBearishHarami 大陽線の実体の内側に小さな陰線が収まる。
+func detectBearishHarami(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	prev, cur := prices[i-1], prices[i]
+	if !isBullishCandle(prev) || !isLongBody(prices, i-1) || !isBearishCandle(cur) {
+		return decimal.Zero, false
+	}
+	if cur.Open.GreaterThan(prev.Close) || cur.Close.LessThan(prev.Open) || !candleBody(cur).LessThan(candleBody(prev)) {
+		return decimal.Zero, false
+	}
+	return decimal.NewFromInt(1).Sub(candleBody(cur).Div(candleBody(prev))).Mul(decimalHundred), true
+}
+
+// detectHammer 下落基調の後、実体の2倍以上の下ヒゲを持ち上ヒゲがほぼない足。強度は下ヒゲの値幅比。
+func detectHammer(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	cur := prices[i]
+	r := candleRange(cur)
+	if isDoji(cur) || !r.IsPositive() || !isPriorDowntrend(prices, i) {
+		return decimal.Zero, false
+	}
+	lower := candleLowerShadow(cur)
+	if lower.LessThan(candleBody(cur).Mul(decimalTwo)) || candleUpperShadow(cur).GreaterThan(r.Mul(candlestickSmallShadowRatio)) {
+		return decimal.Zero, false
+	}
+	return lower.Div(r).Mul(decimalHundred), true
+}
+
+// detectShootingStar 上昇基調の後、実体の2倍以上の上ヒゲを持ち下ヒゲがほぼない足。
+func detectShootingStar(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	cur := prices[i]
+	r := candleRange(cur)
+	if isDoji(cur) || !r.IsPositive() || !isPriorUptrend(prices, i) {
+		return decimal.Zero, false
+	}
+	upper := candleUpperShadow(cur)
+	if upper.LessThan(candleBody(cur).Mul(decimalTwo)) || candleLowerShadow(cur).GreaterThan(r.Mul(candlestickSmallShadowRatio)) {
+		return decimal.Zero, false
+	}
+	return upper.Div(r).Mul(decimalHundred), true
+}
+
+// classifyDoji 十字線を トンボ / トウバ / 足長同時線 / 同時線 のいずれか1つに分類する。十字線でなければ空文字。
+func classifyDoji(p *models.StockBrandDailyPrice) string {
+	if !isDoji(p) {
+		return ""
+	}
+	r := candleRange(p)
+	small := r.Mul(candlestickSmallShadowRatio)
+	long := r.Mul(candlestickLongShadowRatio)
+	upper, lower := candleUpperShadow(p), candleLowerShadow(p)
+	switch {
+	case upper.LessThanOrEqual(small):
+		return models.CandlestickPatternDragonflyDoji
+	case lower.LessThanOrEqual(small):
+		return models.CandlestickPatternGravestoneDoji
+	case upper.GreaterThanOrEqual(long) && lower.GreaterThanOrEqual(long):
+		return models.CandlestickPatternLongLeggedDoji
+	default:
+		return models.CandlestickPatternDoji
+	}
+}
+
+// detectDojiVariant 十字線の分類が id に一致すれば成立。
+// トンボ・トウバの強度は長いほうのヒゲの値幅比、それ以外は実体の小ささ。
+func detectDojiVariant(id string) func(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	return func(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+		cur := prices[i]
+		if classifyDoji(cur) != id {
+			return decimal.Zero, false
+		}
+		r := candleRange(cur)
+		switch id {
+		case models.CandlestickPatternDragonflyDoji:
+			return candleLowerShadow(cur).Div(r).Mul(decimalHundred), true
+		case models.CandlestickPatternGravestoneDoji:
+			return candleUpperShadow(cur).Div(r).Mul(decimalHundred), true
+		default:
+			bodyRatio := candleBody(cur).Div(r)
+			return decimal.NewFromInt(1).Sub(bodyRatio.Div(candlestickDojiBodyRatio)).Mul(decimalHundred), true
+		}
+	}
+}
+
+// detectMorningStar 大陰線 → 実体が下に離れた小さな星 → 1本目の実体の半値を超える陽線。
+// 強度は3本目の終値が1本目の実体の半値（50）から始値（100）のどこまで戻したか。
+func detectMorningStar(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	first, star, last := prices[i-2], prices[i-1], prices[i]
+	if !isBearishCandle(first) || !isLongBody(prices, i-2) || !isBullishCandle(last) {
+		return decimal.Zero, false
+	}
+	if candleBody(star).GreaterThan(candleBody(first).Mul(candlestickStarBodyRatio)) || decimal.Max(star.Open, star.Close).GreaterThan(first.Close) {
+		return decimal.Zero, false
+	}
+	mid := first.Open.Add(first.Close).Div(decimalTwo)
+	if !last.Close.GreaterThan(mid) {
+		return decimal.Zero, false
+	}
+	return decimal.NewFromInt(50).Add(last.Close.Sub(mid).Div(first.Open.Sub(mid)).Mul(decimal.NewFromInt(50))), true
+}
+
+// detectEveningStar 大陽線 → 実体が上に離れた小さな星 → 1本目の実体の半値を割り込む陰線。
+func detectEveningStar(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	first, star, last := prices[i-2], prices[i-1], prices[i]
+	if !isBullishCandle(first) || !isLongBody(prices, i-2) || !isBearishCandle(last) {
+		return decimal.Zero, false
+	}
+	if candleBody(star).GreaterThan(candleBody(first).Mul(candlestickStarBodyRatio)) || decimal.Min(star.Open, star.Close).LessThan(first.Close) {
+		return decimal.Zero, false
+	}
+	mid := first.Open.Add(first.Close).Div(decimalTwo)
+	if !last.Close.LessThan(mid) {
+		return decimal.Zero, false
+	}
+	return decimal.NewFromInt(50).Add(mid.Sub(last.Close).Div(mid.Sub(first.Open)).Mul(decimal.NewFromInt(50))), true
+}
+
+// detectThreeWhiteSoldiers 前日の実体内で寄り付き、高値圏で引ける陽線が3本続く。
+// 強度は3本の平均実体が直近平均実体の何倍か（2倍で100）。
+func detectThreeWhiteSoldiers(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	bodySum := decimal.Zero
+	for j := i - 2; j <= i; j++ {
+		cur := prices[j]
+		if !isBullishCandle(cur) || candleUpperShadow(cur).GreaterThan(candleBody(cur).Div(decimalTwo)) {
+			return decimal.Zero, false
+		}
+		if j > i-2 {
+			prev := prices[j-1]
+			if !cur.Close.GreaterThan(prev.Close) || cur.Open.LessThan(prev.Open) || cur.Open.GreaterThan(prev.Close) {
+				return decimal.Zero, false
+			}
+		}
+		bodySum = bodySum.Add(candleBody(cur))
+	}
+	avg := averageBody(prices, i-2)
+	if avg.IsZero() {
+		return decimalHundred, true
+	}
+	return bodySum.Div(decimal.NewFromInt(3)).Div(avg).Mul(decimal.NewFromInt(50)), true
+}
+
+// detectGapUp 当日安値が前日高値を上回る（上放れの窓開け）。強度は窓の大きさ（前日終値比 5% で100）。
+func detectGapUp(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	prev, cur := prices[i-1], prices[i]
+	if !cur.Low.GreaterThan(prev.High) || !prev.Close.IsPositive() {
+		return decimal.Zero, false
+	}
+	return cur.Low.Sub(prev.High).Div(prev.Close).Div(candlestickGapFullStrength).Mul(decimalHundred), true
+}
+
+// detectGapDown 当日高値が前日安値を下回る（下放れの窓開け）。
+func detectGapDown(prices []*models.StockBrandDailyPrice, i int) (decimal.Decimal, bool) {
+	prev, cur := prices[i-1], prices[i]
+	if !cur.High.LessThan(prev.Low) || !prev.Close.IsPositive() {
+		return decimal.Zero, false
+	}
+	return prev.Low.Sub(cur.High).Div(prev.Close).Div(candlestickGapFullStrength).Mul(decimalHundred), true
+}
@@ -0,0 +1,181 @@
+package domain_service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// candles 小陽線（始値100・終値100.5）を candlestickMinIndex 本並べた後に rows（始値・高値・安値・終値）を続ける。
+func candles(rows ...[4]float64) []*models.StockBrandDailyPrice {
+	prices := make([]*models.StockBrandDailyPrice, 0, candlestickMinIndex+len(rows))
+	for i := 0; i < candlestickMinIndex; i++ {
+		prices = append(prices, ohlc(i, 100, 101, 99, 100.5))
+	}
+	for _, r := range rows {
+		prices = append(prices, ohlc(len(prices), r[0], r[1], r[2], r[3]))
+	}
+	for _, p := range prices {
+		p.Adjclose = p.Close
+	}
+	return prices
+}
+
+// patternsOn idx 本目に検出されたパターン ID と強度。
+func patternsOn(prices []*models.StockBrandDailyPrice, idx int) map[string]float64 {
+	got := make(map[string]float64)
+	for _, hit := range DetectCandlestickPatterns(prices) {
+		if hit.Date.Equal(prices[idx].Date) {
+			got[hit.Pattern] = f64FromDec(hit.Strength)
+		}
+	}
+	return got
+}
+
+func TestDetectCandlestickPatterns(t *testing.T) {
+	t.Run("ベースの小陽線の連続では何も検出しない", func(t *testing.T) {
+		assert.Empty(t, DetectCandlestickPatterns(candles()))
+		assert.Empty(t, DetectCandlestickPatterns(nil))
+	})
+
+	t.Run("陽の包み足", func(t *testing.T) {
+		prices := candles(
+			[4]float64{101, 101.5, 99.5, 100}, // 陰線（実体1）
+			[4]float64{99.5, 102.5, 99, 102},  // 実体2で包む
+		)
+		assert.Equal(t, map[string]float64{models.CandlestickPatternBullishEngulfing: 100}, patternsOn(prices, len(prices)-1))
+	})
+
+	t.Run("陰のはらみ足", func(t *testing.T) {
+		prices := candles(
+			[4]float64{100, 103.2, 99.8, 103},      // 大陽線（実体3）
+			[4]float64{102.5, 102.8, 101.3, 101.5}, // 実体1の陰線が内側に収まる
+		)
+		got := patternsOn(prices, len(prices)-1)
+		require.Contains(t, got, models.CandlestickPatternBearishHarami)
+		assert.InDelta(t, 66.67, got[models.CandlestickPatternBearishHarami], 1e-9)
+	})
+
+	t.Run("下落後のたくり線", func(t *testing.T) {
+		rows := make([][4]float64, 0, 7)
+		for k := 1; k <= 6; k++ {
+			c := 100 - float64(k)
+			rows = append(rows, [4]float64{c + 0.5, c + 1, c - 0.5, c})
+		}
+		rows = append(rows, [4]float64{94.5, 95, 91, 95}) // 下ヒゲ3.5 / 値幅4
+		prices := candles(rows...)
+		assert.Equal(t, map[string]float64{models.CandlestickPatternHammer: 87.5}, patternsOn(prices, len(prices)-1))
+	})
+
+	t.Run("上昇トレンドがなければ流れ星にならない", func(t *testing.T) {
+		prices := candles([4]float64{100.5, 104, 100.4, 100.9})
+		assert.NotContains(t, patternsOn(prices, len(prices)-1), models.CandlestickPatternShootingStar)
+	})
+
+	t.Run("明けの明星", func(t *testing.T) {
+		prices := candles(
+			[4]float64{104, 104.5, 99.5, 100},  // 大陰線
+			[4]float64{99, 99.5, 98.5, 99.2},   // 下に離れた星
+			[4]float64{99.5, 103.2, 99.4, 103}, // 半値(102)を超える陽線
+		)
+		got := patternsOn(prices, len(prices)-1)
+		require.Contains(t, got, models.CandlestickPatternMorningStar)
+		assert.InDelta(t, 75.0, got[models.CandlestickPatternMorningStar], 1e-9)
+	})
+
+	t.Run("宵の明星", func(t *testing.T) {
+		prices := candles(
+			[4]float64{100, 104.5, 99.5, 104}, // 大陽線
+			[4]float64{105, 105.5, 104.5, 104.8},
+			[4]float64{104.5, 104.6, 100.8, 101}, // 半値(102)を割り込む陰線
+		)
+		got := patternsOn(prices, len(prices)-1)
+		require.Contains(t, got, models.CandlestickPatternEveningStar)
+		assert.InDelta(t, 75.0, got[models.CandlestickPatternEveningStar], 1e-9)
+	})
+
+	t.Run("赤三兵", func(t *testing.T) {
+		prices := candles(
+			[4]float64{100.5, 102.1, 100.4, 102},
+			[4]float64{101, 103.6, 100.9, 103.5},
+			[4]float64{102.5, 105.1, 102.4, 105},
+		)
+		got := patternsOn(prices, len(prices)-1)
+		require.Contains(t, got, models.CandlestickPatternThreeWhiteSoldiers)
+		assert.Equal(t, 100.0, got[models.CandlestickPatternThreeWhiteSoldiers])
+	})
+
+	t.Run("窓開け", func(t *testing.T) {
+		up := candles([4]float64{102.5, 104, 102, 103.5})
+		got := patternsOn(up, len(up)-1)
+		require.Contains(t, got, models.CandlestickPatternGapUp)
+		// (102-101)/100.5 を 5% で100に換算
+		assert.InDelta(t, 19.9, got[models.CandlestickPatternGapUp], 0.01)
+
+		down := candles([4]float64{97, 97.5, 96, 96.5})
+		assert.Contains(t, patternsOn(down, len(down)-1), models.CandlestickPatternGapDown)
+	})
+}
+
+func TestClassifyDoji(t *testing.T) {
+	tests := []struct {
+		name string
+		bar  *models.StockBrandDailyPrice
+		want string
+	}{
+		{"トンボ", ohlc(0, 100, 100, 96, 100), models.CandlestickPatternDragonflyDoji},
+		{"トウバ", ohlc(0, 100, 104, 100, 100), models.CandlestickPatternGravestoneDoji},
+		{"足長同時線", ohlc(0, 100, 102, 98, 100.1), models.CandlestickPatternLongLeggedDoji},
+		{"同時線", ohlc(0, 100, 100.8, 97, 100.1), models.CandlestickPatternDoji},
+		{"実体が大きい", ohlc(0, 100, 102, 98, 101), ""},
+		{"値幅ゼロ", ohlc(0, 100, 100, 100, 100), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyDoji(tt.bar))
+		})
+	}
+}
+
+func TestCandlestickPatternSignals(t *testing.T) {
+	prices := candles([4]float64{102.5, 104, 102, 103.5})
+	last := len(prices) - 1
+	assert.Equal(t, boolsAt(len(prices), last), CandlestickBullishEntrySignals(prices))
+	assert.Equal(t, boolsAt(len(prices), last), CandlestickBearishExitSignals(prices))
+	assert.Equal(t, boolsAt(len(prices)), CandlestickBullishExitSignals(prices))
+	assert.Equal(t, boolsAt(len(prices)), CandlestickBearishEntrySignals(prices))
+}
+
+func TestCandlestickPatternStatistics(t *testing.T) {
+	prices := pricesFromCloses(100, 110, 121)
+	for _, p := range prices {
+		p.Adjclose = p.Close
+	}
+	hits := []*models.CandlestickPatternHit{
+		{Date: prices[0].Date, Pattern: models.CandlestickPatternGapUp, Direction: models.CandlestickDirectionBullish},
+		{Date: prices[1].Date, Pattern: models.CandlestickPatternGapUp, Direction: models.CandlestickDirectionBullish},
+		{Date: prices[0].Date, Pattern: models.CandlestickPatternGapDown, Direction: models.CandlestickDirectionBearish},
+		{Date: prices[0].Date.AddDate(1, 0, 0), Pattern: models.CandlestickPatternDoji, Direction: models.CandlestickDirectionNeutral},
+	}
+
+	stats := CandlestickPatternStatistics(prices, hits, []int{1, 2})
+	require.Len(t, stats, 2) // 日足にない日付のヒットは無視
+
+	up := stats[0]
+	assert.Equal(t, models.CandlestickPatternGapUp, up.Pattern)
+	assert.Equal(t, "窓開け（上昇）", up.Label)
+	assert.Equal(t, 2, up.Count)
+	assert.Equal(t, 2, up.Stats[1].EvaluatedCount)
+	assert.True(t, up.Stats[1].AvgReturn.Equal(decimal.NewFromFloat(0.1)))
+	assert.Equal(t, 1, up.Stats[2].EvaluatedCount) // 2日目起点の2日後は未到来
+
+	down := stats[1]
+	assert.Equal(t, models.CandlestickPatternGapDown, down.Pattern)
+	// 弱気パターンは売り方向で評価
+	assert.True(t, down.Stats[2].AvgReturn.Equal(decimal.NewFromFloat(-0.21)))
+	assert.Equal(t, 0, down.Stats[2].WinCount)
+}
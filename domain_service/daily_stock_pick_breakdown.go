@@ -0,0 +1,280 @@
+package domain_service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// DailyPickBreakdownUnknownKey 業種・市場・戦略・流動性が分からない件をまとめるキー。
+const DailyPickBreakdownUnknownKey = "unknown"
+
+// dailyPickBreakdownUnknownLabel unknown の表示名。
+const dailyPickBreakdownUnknownLabel = "不明"
+
+// dailyPickBreakdownSignalHorizons 分析シグナルの答え合わせに使う営業日数（推奨の Return1D/3D/5D と揃える）。
+var dailyPickBreakdownSignalHorizons = []int{1, 3, 5}
+
+// dailyPickLiquidityBucket 流動性帯（直近平均売買代金）。Lower 以上・次の帯の Lower 未満に入る。
+type dailyPickLiquidityBucket struct {
+	Key   string
+	Label string
+	Lower decimal.Decimal
+}
+
+// dailyPickLiquidityBuckets 流動性帯。境界はスコアの流動性カーブ（dailyPickLiquidityCurve）の 1億/3億/10億/50億 に揃える。
+var dailyPickLiquidityBuckets = []dailyPickLiquidityBucket{
+	{Key: "lt_100m", Label: "1億円未満", Lower: decimal.Zero},
+	{Key: "100m_300m", Label: "1〜3億円", Lower: decimal.RequireFromString("100000000")},
+	{Key: "300m_1b", Label: "3〜10億円", Lower: decimal.RequireFromString("300000000")},
+	{Key: "1b_5b", Label: "10〜50億円", Lower: decimal.RequireFromString("1000000000")},
+	{Key: "gte_5b", Label: "50億円以上", Lower: decimal.RequireFromString("5000000000")},
+}
+
+// dailyPickWeekdayOrder 曜日別の並び順（月曜始まり）と表示名。
+var dailyPickWeekdayOrder = []struct {
+	Weekday time.Weekday
+	Label   string
+}{
+	{time.Monday, "月"},
+	{time.Tuesday, "火"},
+	{time.Wednesday, "水"},
+	{time.Thursday, "木"},
+	{time.Friday, "金"},
+	{time.Saturday, "土"},
+	{time.Sunday, "日"},
+}
+
+// DailyPickBreakdownSample 切り口別集計の1件。推奨と分析シグナルをこの形に揃えて同じ定義で集計する。
+type DailyPickBreakdownSample struct {
+	Date             time.Time
+	Sector33CodeName string
+	MarketCode       string
+	MarketName       string
+	// Strategies 寄与した戦略（分析シグナルは手法1つ）。空なら unknown に数える
+	Strategies []string
+	// AvgTradingValue 直近平均売買代金（nil は算出できず unknown 帯）
+	AvgTradingValue *decimal.Decimal
+	Evaluated       bool
+	Outcome         *models.DailyStockPickOutcome
+	Return1D        *decimal.Decimal
+	Return3D        *decimal.Decimal
+	Return5D        *decimal.Decimal
+}
+
+// DailyPickBreakdownSamplesFromPicks 推奨を集計用の形にする。市場は brandsByID（stock_brand.id → 銘柄）から引き、見つからなければ unknown。
+func DailyPickBreakdownSamplesFromPicks(picks []*models.DailyStockPick, brandsByID map[string]*models.StockBrand) []DailyPickBreakdownSample {
+	out := make([]DailyPickBreakdownSample, 0, len(picks))
+	for _, p := range picks {
+		avgTradingValue := p.AvgTradingValue
+		s := DailyPickBreakdownSample{
+			Date:             p.PickDate,
+			Sector33CodeName: p.Sector33CodeName,
+			Strategies:       p.Strategies,
+			AvgTradingValue:  &avgTradingValue,
+			Evaluated:        p.Evaluated(),
+			Outcome:          p.Outcome,
+			Return1D:         p.Return1D,
+			Return3D:         p.Return3D,
+			Return5D:         p.Return5D,
+		}
+		if b, ok := brandsByID[p.StockBrandID]; ok {
+			s.MarketCode = b.MarketCode
+			s.MarketName = b.MarketName
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// DailyPickBreakdownSampleFromSignal 分析シグナルを集計用の形にする。prices はその銘柄の日足（date 昇順）で、シグナル日以前の
+// 直近 metricsWindowDays 本から平均売買代金を、シグナル日以降から1/3/5営業日後の方向込みリターンを求める。
+// 5営業日後リターンが出ていれば答え合わせ済みとして JudgeDailyPickOutcome で勝敗を付け、シグナル日の日足が無いものは void にする。
+// brand が nil なら業種・市場は unknown。
+func DailyPickBreakdownSampleFromSignal(signal *models.AnalyzeStockBrandPriceHistory, brand *models.StockBrand, prices []*models.StockBrandDailyPrice, metricsWindowDays int) DailyPickBreakdownSample {
+	s := DailyPickBreakdownSample{
+		Date:       signal.CreatedAt,
+		Strategies: []string{signal.Method},
+	}
+	if brand != nil {
+		s.Sector33CodeName = brand.Sector33CodeName
+		s.MarketCode = brand.MarketCode
+		s.MarketName = brand.MarketName
+	}
+
+	sigDay := dailyPickDateOnly(signal.CreatedAt)
+	start := sort.Search(len(prices), func(i int) bool {
+		return !dailyPickDateOnly(prices[i].Date).Before(sigDay)
+	})
+	// シグナル日当日の日足まで（当日を含む）で平均売買代金を測る
+	end := start
+	if end < len(prices) && dailyPickDateOnly(prices[end].Date).Equal(sigDay) {
+		end++
+	}
+	if end > 0 {
+		avg := windowAvgTradingValue(prices[max(0, end-metricsWindowDays):end])
+		s.AvgTradingValue = &avg
+	}
+
+	afterSignal := prices[start:]
+	if len(afterSignal) == 0 || !dailyPickDateOnly(afterSignal[0].Date).Equal(sigDay) {
+		afterSignal = nil
+	}
+	returns, found := ForwardReturns(afterSignal, signal.Action, dailyPickBreakdownSignalHorizons)
+	if !found {
+		void := models.DailyStockPickOutcomeVoid
+		s.Evaluated = true
+		s.Outcome = &void
+		return s
+	}
+	s.Return1D, s.Return3D, s.Return5D = returns[1], returns[3], returns[5]
+	if s.Return5D != nil {
+		outcome := JudgeDailyPickOutcome(*s.Return5D)
+		s.Evaluated = true
+		s.Outcome = &outcome
+	}
+	return s
+}
+
+// AggregateDailyPickBreakdown 業種・市場・戦略・流動性帯・曜日の切り口で集計する。0件入力でも各スライスは空スライス（nil ではない）。
+func AggregateDailyPickBreakdown(samples []DailyPickBreakdownSample) models.DailyStockPickBreakdown {
+	sector := newDailyPickBreakdownGroup()
+	market := newDailyPickBreakdownGroup()
+	strategy := newDailyPickBreakdownGroup()
+	liquidity := newDailyPickBreakdownGroup()
+	weekday := newDailyPickBreakdownGroup()
+
+	for i := range samples {
+		s := &samples[i]
+		sector.add(s.Sector33CodeName, s.Sector33CodeName, s)
+		market.add(s.MarketCode, s.MarketName, s)
+		if len(s.Strategies) == 0 {
+			strategy.add("", "", s)
+		}
+		for _, k := range s.Strategies {
+			label := k
+			if st, ok := LookupStrategy(k); ok {
+				label = st.Label()
+			}
+			strategy.add(k, label, s)
+		}
+		if b, ok := dailyPickLiquidityBucketOf(s.AvgTradingValue); ok {
+			liquidity.add(b.Key, b.Label, s)
+		} else {
+			liquidity.add("", "", s)
+		}
+		wd := dailyPickDateOnly(s.Date).Weekday()
+		weekday.add(strings.ToLower(wd.String()), dailyPickWeekdayLabel(wd), s)
+	}
+
+	liquidityOrder := make([]string, 0, len(dailyPickLiquidityBuckets)+1)
+	for _, b := range dailyPickLiquidityBuckets {
+		liquidityOrder = append(liquidityOrder, b.Key)
+	}
+	weekdayOrder := make([]string, 0, len(dailyPickWeekdayOrder))
+	for _, w := range dailyPickWeekdayOrder {
+		weekdayOrder = append(weekdayOrder, strings.ToLower(w.Weekday.String()))
+	}
+
+	return models.DailyStockPickBreakdown{
+		BySector33:  sector.byTotal(),
+		ByMarket:    market.byTotal(),
+		ByStrategy:  strategy.byTotal(),
+		ByLiquidity: liquidity.inOrder(append(liquidityOrder, DailyPickBreakdownUnknownKey)),
+		ByWeekday:   weekday.inOrder(weekdayOrder),
+	}
+}
+
+// dailyPickBreakdownGroup 1つの切り口の集計途中の状態。
+type dailyPickBreakdownGroup struct {
+	accs   map[string]*dailyPickStatAcc
+	labels map[string]string
+}
+
+func newDailyPickBreakdownGroup() *dailyPickBreakdownGroup {
+	return &dailyPickBreakdownGroup{accs: map[string]*dailyPickStatAcc{}, labels: map[string]string{}}
+}
+
+// add key が空なら unknown に数える。label が空ならキーを表示名にする。
+func (g *dailyPickBreakdownGroup) add(key, label string, s *DailyPickBreakdownSample) {
+	if key == "" {
+		key, label = DailyPickBreakdownUnknownKey, dailyPickBreakdownUnknownLabel
+	}
+	if label == "" {
+		label = key
+	}
+	acc, ok := g.accs[key]
+	if !ok {
+		acc = &dailyPickStatAcc{}
+		g.accs[key] = acc
+		g.labels[key] = label
+	}
+	acc.record(s.Evaluated, s.Outcome, s.Return1D, s.Return3D, s.Return5D)
+}
+
+func (g *dailyPickBreakdownGroup) stat(key string) *models.DailyStockPickBreakdownStat {
+	return &models.DailyStockPickBreakdownStat{
+		Key:                       key,
+		Label:                     g.labels[key],
+		DailyStockPickStatSummary: g.accs[key].summary(),
+	}
+}
+
+// byTotal 件数の多い順（同数はキー昇順）で、unknown は最後に回す。
+func (g *dailyPickBreakdownGroup) byTotal() []*models.DailyStockPickBreakdownStat {
+	keys := make([]string, 0, len(g.accs))
+	for k := range g.accs {
+		if k != DailyPickBreakdownUnknownKey {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if ti, tj := g.accs[keys[i]].total, g.accs[keys[j]].total; ti != tj {
+			return ti > tj
+		}
+		return keys[i] < keys[j]
+	})
+	if _, ok := g.accs[DailyPickBreakdownUnknownKey]; ok {
+		keys = append(keys, DailyPickBreakdownUnknownKey)
+	}
+	return g.inOrder(keys)
+}
+
+// inOrder order の順で返す。該当0件のキーは飛ばす。
+func (g *dailyPickBreakdownGroup) inOrder(order []string) []*models.DailyStockPickBreakdownStat {
+	out := make([]*models.DailyStockPickBreakdownStat, 0, len(g.accs))
+	for _, k := range order {
+		if _, ok := g.accs[k]; ok {
+			out = append(out, g.stat(k))
+		}
+	}
+	return out
+}
+
+// dailyPickLiquidityBucketOf 平均売買代金の流動性帯。nil なら false。
+func dailyPickLiquidityBucketOf(avgTradingValue *decimal.Decimal) (dailyPickLiquidityBucket, bool) {
+	if avgTradingValue == nil {
+		return dailyPickLiquidityBucket{}, false
+	}
+	bucket := dailyPickLiquidityBuckets[0]
+	for _, b := range dailyPickLiquidityBuckets[1:] {
+		if avgTradingValue.LessThan(b.Lower) {
+			break
+		}
+		bucket = b
+	}
+	return bucket, true
+}
+
+func dailyPickWeekdayLabel(wd time.Weekday) string {
+	for _, w := range dailyPickWeekdayOrder {
+		if w.Weekday == wd {
+			return w.Label
+		}
+	}
+	return wd.String()
+}
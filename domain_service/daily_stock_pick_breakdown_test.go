@@ -0,0 +1,133 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestAggregateDailyPickBreakdown(t *testing.T) {
+	thu := time.Date(2026, 7, 23, 0, 0, 0, 0, time.UTC)
+	fri := time.Date(2026, 7, 24, 0, 0, 0, 0, time.UTC)
+	mon := time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC)
+	dec := func(s string) *decimal.Decimal { d := decimal.RequireFromString(s); return &d }
+
+	pick := func(d time.Time, brandID, sector, avgTradingValue string, strategies []string, outcome *models.DailyStockPickOutcome, r5d *string) *models.DailyStockPick {
+		p := &models.DailyStockPick{
+			PickDate:         d,
+			StockBrandID:     brandID,
+			Sector33CodeName: sector,
+			Strategies:       strategies,
+			AvgTradingValue:  decimal.RequireFromString(avgTradingValue),
+			Outcome:          outcome,
+		}
+		if r5d != nil {
+			p.Return5D = dec(*r5d)
+			now := d
+			p.EvaluatedAt = &now
+		}
+		return p
+	}
+	plus, minus := "0.02", "-0.04"
+	picks := []*models.DailyStockPick{
+		pick(thu, "b1", "輸送用機器", "6000000000", []string{"macd_bullish", "ma_cross"}, statOutcome(models.DailyStockPickOutcomeWin), &plus),
+		pick(fri, "b2", "輸送用機器", "300000000", []string{"ma_cross"}, statOutcome(models.DailyStockPickOutcomeLose), &minus),
+		pick(mon, "b3", "", "50000000", nil, nil, nil),
+	}
+	brands := map[string]*models.StockBrand{
+		"b1": {ID: "b1", MarketCode: "111", MarketName: "プライム"},
+		"b2": {ID: "b2", MarketCode: "112", MarketName: "スタンダード"},
+	}
+
+	got := AggregateDailyPickBreakdown(DailyPickBreakdownSamplesFromPicks(picks, brands))
+
+	require.Len(t, got.BySector33, 2)
+	assert.Equal(t, "輸送用機器", got.BySector33[0].Key)
+	assert.Equal(t, 2, got.BySector33[0].Total)
+	assert.Equal(t, "0.5", got.BySector33[0].WinRate.String())
+	assert.Equal(t, "-0.01", got.BySector33[0].AvgReturn5D.String())
+	assert.Equal(t, DailyPickBreakdownUnknownKey, got.BySector33[1].Key, "業種なしは unknown で最後")
+	assert.Equal(t, 1, got.BySector33[1].PendingCount)
+
+	require.Len(t, got.ByMarket, 3)
+	assert.Equal(t, "111", got.ByMarket[0].Key, "同数はキー昇順")
+	assert.Equal(t, "プライム", got.ByMarket[0].Label)
+	assert.Equal(t, DailyPickBreakdownUnknownKey, got.ByMarket[2].Key)
+
+	require.Len(t, got.ByStrategy, 3, "1件が複数の戦略を持てばそれぞれに数える")
+	assert.Equal(t, "ma_cross", got.ByStrategy[0].Key)
+	assert.Equal(t, 2, got.ByStrategy[0].Total)
+	assert.Equal(t, "macd_bullish", got.ByStrategy[1].Key)
+	assert.Equal(t, DailyPickBreakdownUnknownKey, got.ByStrategy[2].Key)
+
+	require.Len(t, got.ByLiquidity, 3)
+	assert.Equal(t, "lt_100m", got.ByLiquidity[0].Key, "流動性帯は昇順で、該当0件の帯は返さない")
+	assert.Equal(t, "300m_1b", got.ByLiquidity[1].Key, "境界値は上の帯に入る")
+	assert.Equal(t, "gte_5b", got.ByLiquidity[2].Key)
+	assert.Equal(t, "50億円以上", got.ByLiquidity[2].Label)
+
+	require.Len(t, got.ByWeekday, 3)
+	assert.Equal(t, "monday", got.ByWeekday[0].Key, "曜日は月曜始まり")
+	assert.Equal(t, "月", got.ByWeekday[0].Label)
+	assert.Equal(t, "thursday", got.ByWeekday[1].Key)
+	assert.Equal(t, "friday", got.ByWeekday[2].Key)
+
+	empty := AggregateDailyPickBreakdown(nil)
+	assert.NotNil(t, empty.BySector33)
+	assert.Empty(t, empty.BySector33)
+	assert.NotNil(t, empty.ByWeekday)
+}
+
+func TestDailyPickBreakdownSampleFromSignal(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, time.UTC) }
+	bar := func(d int, close int64, volume int64) *models.StockBrandDailyPrice {
+		return &models.StockBrandDailyPrice{Date: day(d), Close: decimal.NewFromInt(close), Adjclose: decimal.NewFromInt(close), Volume: volume}
+	}
+	prices := []*models.StockBrandDailyPrice{
+		bar(1, 100, 1000), bar(2, 100, 3000), bar(3, 100, 5000),
+		bar(6, 101, 9000), bar(7, 102, 9000), bar(8, 103, 9000), bar(9, 104, 9000), bar(10, 95, 9000),
+	}
+	brand := &models.StockBrand{Sector33CodeName: "電気機器", MarketCode: "111", MarketName: "プライム"}
+	signal := func(d int, action string) *models.AnalyzeStockBrandPriceHistory {
+		return &models.AnalyzeStockBrandPriceHistory{Method: "a", Action: action, CreatedAt: day(d)}
+	}
+
+	t.Run("5営業日後リターンで勝敗を付け、シグナル日までの直近本数で平均売買代金を測る", func(t *testing.T) {
+		got := DailyPickBreakdownSampleFromSignal(signal(3, models.AnalyzeStockBrandPriceHistoryActionSell), brand, prices, 2)
+		assert.Equal(t, []string{"a"}, got.Strategies)
+		assert.Equal(t, "電気機器", got.Sector33CodeName)
+		assert.Equal(t, "111", got.MarketCode)
+		assert.Equal(t, "400000", got.AvgTradingValue.String(), "7/2 と 7/3 の売買代金の平均")
+		assert.Equal(t, "-0.01", got.Return1D.String(), "Sell は符号反転")
+		assert.Equal(t, "0.05", got.Return5D.String())
+		assert.True(t, got.Evaluated)
+		assert.Equal(t, models.DailyStockPickOutcomeWin, *got.Outcome)
+	})
+
+	t.Run("5営業日後が未到来なら未評価", func(t *testing.T) {
+		got := DailyPickBreakdownSampleFromSignal(signal(7, models.AnalyzeStockBrandPriceHistoryActionBuy), nil, prices, 20)
+		assert.Empty(t, got.MarketCode)
+		require.NotNil(t, got.Return3D)
+		assert.Nil(t, got.Return5D)
+		assert.False(t, got.Evaluated)
+		assert.Nil(t, got.Outcome)
+	})
+
+	t.Run("シグナル日の日足が無ければ void", func(t *testing.T) {
+		got := DailyPickBreakdownSampleFromSignal(signal(4, models.AnalyzeStockBrandPriceHistoryActionBuy), brand, prices, 20)
+		assert.True(t, got.Evaluated)
+		assert.Equal(t, models.DailyStockPickOutcomeVoid, *got.Outcome)
+		assert.Equal(t, "300000", got.AvgTradingValue.String(), "シグナル日より前の日足だけで測る")
+	})
+
+	t.Run("日足が無ければ流動性も不明", func(t *testing.T) {
+		got := DailyPickBreakdownSampleFromSignal(signal(3, models.AnalyzeStockBrandPriceHistoryActionBuy), brand, nil, 20)
+		assert.Nil(t, got.AvgTradingValue)
+		assert.Equal(t, models.DailyStockPickOutcomeVoid, *got.Outcome)
+	})
+}
@@ -2,6 +2,7 @@ package domain_service
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -106,8 +107,8 @@ func chunkDailyStockPickBlocks(blocks []string, maxRunes int) []dailyStockPickCh
 func formatDailyStockPickBlock(rank int, p *models.DailyStockPick) string {
 	strategyLabels := make([]string, 0, len(p.Strategies))
 	for _, s := range p.Strategies {
-		if label, ok := StrategyLabels[s]; ok {
-			strategyLabels = append(strategyLabels, label)
+		if strategy, ok := LookupStrategy(s); ok {
+			strategyLabels = append(strategyLabels, strategy.Label())
 		} else {
 			strategyLabels = append(strategyLabels, s)
 		}
@@ -119,7 +120,7 @@ func formatDailyStockPickBlock(rank int, p *models.DailyStockPick) string {
 		sectorPart = fmt.Sprintf(" 〔%s〕", escapeSlackText(sector))
 	}
 
-	return fmt.Sprintf(
+	block := fmt.Sprintf(
 		"*%d.* `%s` %s%s\n"+
 			"    %s円 / score *%s* / %d戦略: %s\n"+
 			"    出来高 %s倍 / ADX %s / ATR %s%% / RSI %s / 平均代金 %s億円",
@@ -137,6 +138,44 @@ func formatDailyStockPickBlock(rank int, p *models.DailyStockPick) string {
 		p.RSI.StringFixed(1),
 		p.AvgTradingValue.Div(decimalFromInt(100000000)).StringFixed(1),
 	)
+	if why := formatDailyStockPickWhy(p.ScoreComponents); why != "" {
+		block += "\n    " + why
+	}
+	return block
+}
+
+// dailyPickWhyTopN 「なぜ」行に出す寄与上位の因子数。
+const dailyPickWhyTopN = 3
+
+// formatDailyStockPickWhy スコアへの寄与が大きい因子を上位から並べた1行（例: "寄与: 戦略数+30.0 ADX+16.0 出来高+14.0"）。
+// 満点に届かなかった分が最も大きい因子を「弱」として添える。内訳が無い（導入前の）推奨は空文字。
+func formatDailyStockPickWhy(components []models.DailyStockPickScoreComponent) string {
+	if len(components) == 0 {
+		return ""
+	}
+	sorted := slices.Clone(components)
+	slices.SortStableFunc(sorted, func(a, b models.DailyStockPickScoreComponent) int {
+		return b.Contribution.Cmp(a.Contribution)
+	})
+
+	n := min(dailyPickWhyTopN, len(sorted))
+	parts := make([]string, 0, n)
+	for _, c := range sorted[:n] {
+		parts = append(parts, DailyPickComponentLabel(c.Key)+"+"+c.Contribution.StringFixed(1))
+	}
+	why := "寄与: " + strings.Join(parts, " ")
+
+	var weakest *models.DailyStockPickScoreComponent
+	for i := range components {
+		c := &components[i]
+		if weakest == nil || c.Weight.Sub(c.Contribution).GreaterThan(weakest.Weight.Sub(weakest.Contribution)) {
+			weakest = c
+		}
+	}
+	if missed := weakest.Weight.Sub(weakest.Contribution); missed.IsPositive() {
+		why += fmt.Sprintf("（弱: %s %s/%s）", DailyPickComponentLabel(weakest.Key), weakest.Contribution.StringFixed(1), weakest.Weight.StringFixed(0))
+	}
+	return why
 }
 
 func dailyStockPickLegend() string {
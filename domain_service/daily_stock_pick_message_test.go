@@ -67,6 +67,26 @@ func TestFormatDailyStockPickMessages(t *testing.T) {
 		assert.NotContains(t, joined, "A&B<C>D")
 	})
 
+	t.Run("内訳があれば寄与上位の因子と最も取りこぼした因子を1行で添える", func(t *testing.T) {
+		p := messageTestPick(1, "1000", "テスト銘柄", "電気機器", "60.5")
+		p.ScoreComponents = []models.DailyStockPickScoreComponent{
+			{Key: DailyPickComponentSignal, Weight: decimal.NewFromInt(30), Contribution: decimal.RequireFromString("19.5")},
+			{Key: DailyPickComponentVolume, Weight: decimal.NewFromInt(20), Contribution: decimal.NewFromInt(14)},
+			{Key: DailyPickComponentTrend, Weight: decimal.NewFromInt(20), Contribution: decimal.NewFromInt(16)},
+			{Key: DailyPickComponentVolatility, Weight: decimal.NewFromInt(10), Contribution: decimal.NewFromInt(10)},
+			{Key: DailyPickComponentLiquidity, Weight: decimal.NewFromInt(10), Contribution: decimal.NewFromInt(1)},
+			{Key: DailyPickComponentOverheat, Weight: decimal.NewFromInt(10), Contribution: decimal.NewFromInt(0)},
+		}
+		_, bodies := FormatDailyStockPickMessages([]*models.DailyStockPick{p}, DailyPickSlackMaxRunes)
+		joined := strings.Join(bodies, "\n")
+		assert.Contains(t, joined, "寄与: 戦略数+19.5 ADX+16.0 出来高+14.0（弱: 戦略数 19.5/30）")
+	})
+
+	t.Run("内訳が無い推奨は寄与行を出さない", func(t *testing.T) {
+		_, bodies := FormatDailyStockPickMessages([]*models.DailyStockPick{messageTestPick(1, "1000", "テスト銘柄", "電気機器", "50")}, DailyPickSlackMaxRunes)
+		assert.NotContains(t, strings.Join(bodies, "\n"), "寄与:")
+	})
+
 	t.Run("小さいmaxRunesでも1件は必ず出力される", func(t *testing.T) {
 		picks := []*models.DailyStockPick{messageTestPick(1, "1000", "テスト銘柄", "電気機器", "50")}
 		_, bodies := FormatDailyStockPickMessages(picks, 1)
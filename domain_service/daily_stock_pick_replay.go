@@ -0,0 +1,49 @@
+package domain_service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// DailyPickReplayVersionPrefix 過去日リプレイで作った推奨の score_version に付ける接頭辞。
+// 毎晩の live / shadow の推奨と同じテーブルに置きつつ、集計・通知・日付一覧に混ざらないよう名前空間を分ける。
+const DailyPickReplayVersionPrefix = "replay/"
+
+// DailyPickReplayScoreVersion スコア設定 version をリプレイした推奨の score_version を返す（例: "replay/v1"）。
+func DailyPickReplayScoreVersion(version string) string {
+	return DailyPickReplayVersionPrefix + version
+}
+
+// IsDailyPickReplayScoreVersion score_version がリプレイの名前空間か。
+func IsDailyPickReplayScoreVersion(scoreVersion string) bool {
+	return strings.HasPrefix(scoreVersion, DailyPickReplayVersionPrefix)
+}
+
+// DailyPickReplayScoreVersions 並走させているスコア設定のリプレイ版バージョン（live のリプレイが先頭）。
+// 合流スコアを使う設定は時点を再現できないためリプレイしない。
+func DailyPickReplayScoreVersions() []string {
+	configs := DailyPickScoringConfigs()
+	out := make([]string, 0, len(configs))
+	for _, c := range configs {
+		if c.Weights.UsesConfluence() {
+			continue
+		}
+		out = append(out, DailyPickReplayScoreVersion(c.Version))
+	}
+	return out
+}
+
+// DailyPickPricesAsOf date 昇順の日足から from〜asOf（両端含む）のバーだけを切り出す。
+// リプレイで各営業日時点に入手できた日足だけを見るために使う（asOf より後のバーは含めない）。
+// 戻り値は prices を共有するスライス。
+func DailyPickPricesAsOf(prices []*models.StockBrandDailyPrice, from, asOf time.Time) []*models.StockBrandDailyPrice {
+	lo := sort.Search(len(prices), func(i int) bool { return !prices[i].Date.Before(from) })
+	hi := sort.Search(len(prices), func(i int) bool { return prices[i].Date.After(asOf) })
+	if lo >= hi {
+		return nil
+	}
+	return prices[lo:hi]
+}
@@ -0,0 +1,47 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestDailyPickReplayScoreVersion(t *testing.T) {
+	assert.Equal(t, "replay/v1", DailyPickReplayScoreVersion("v1"))
+	assert.True(t, IsDailyPickReplayScoreVersion("replay/v1-trend"))
+	assert.False(t, IsDailyPickReplayScoreVersion(DailyPickScoreVersion))
+
+	versions := DailyPickReplayScoreVersions()
+	assert.Len(t, versions, len(DailyPickScoreVersions())-1, "合流スコアを使う設定はリプレイしない")
+	assert.NotContains(t, versions, DailyPickReplayScoreVersion("v1-confluence"))
+	assert.Equal(t, DailyPickReplayScoreVersion(DailyPickScoreVersion), versions[0], "live のリプレイが先頭")
+	for _, v := range versions {
+		assert.LessOrEqual(t, len(v), 32, "daily_stock_pick.score_version の桁数に収まる")
+	}
+}
+
+func TestDailyPickPricesAsOf(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, time.UTC) }
+	prices := []*models.StockBrandDailyPrice{
+		{Date: day(1)}, {Date: day(2)}, {Date: day(6)}, {Date: day(7)}, {Date: day(8)},
+	}
+
+	t.Run("asOfより後のバーを含めない", func(t *testing.T) {
+		got := DailyPickPricesAsOf(prices, day(2), day(7))
+		assert.Len(t, got, 3)
+		assert.Equal(t, day(2), got[0].Date)
+		assert.Equal(t, day(7), got[len(got)-1].Date)
+	})
+	t.Run("端点が休場日でも範囲内のバーだけ返す", func(t *testing.T) {
+		got := DailyPickPricesAsOf(prices, day(3), day(5))
+		assert.Empty(t, got)
+		got = DailyPickPricesAsOf(prices, day(3), day(6))
+		assert.Len(t, got, 1)
+	})
+	t.Run("空", func(t *testing.T) {
+		assert.Empty(t, DailyPickPricesAsOf(nil, day(1), day(8)))
+	})
+}
@@ -2,21 +2,14 @@ package domain_service
 
 import (
 	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
 )
 
 // DailyPickScoreVersion スコア定義のバージョン。重み・カーブを変えたら必ずインクリメントし、
 // DB の score_version と突き合わせて過去分と混ぜて集計しないようにする。
 const DailyPickScoreVersion = "v1"
 
-// DailyPickBaseStrategies スコアの点灯数カウント対象。
-// multiple_signals は他4戦略の派生（2つ以上成立）であり、二重計上と計算の無駄を避けるため除外する。
-var DailyPickBaseStrategies = []string{
-	StrategyMACDBullish,
-	StrategyBollingerBreakout,
-	StrategyTriangleFormation,
-	StrategyMovingAverageCross,
-}
-
 // DailyPickScoreWeights 各因子の重み。合計 100 になるよう保つ（ScoreDailyPick はスケーリングしない）。
 type DailyPickScoreWeights struct {
 	Signal     decimal.Decimal
@@ -25,6 +18,14 @@ type DailyPickScoreWeights struct {
 	Volatility decimal.Decimal
 	Liquidity  decimal.Decimal
 	Overheat   decimal.Decimal
+	// Confluence 分析シグナルの合流スコア（/multiple-signal-stocks?mode=weighted）の重み。
+	// 正の設定は合流スコアの算出が必要なため、create_daily_stock_picks_v1 の --confluence 指定時だけ走らせる。
+	Confluence decimal.Decimal
+}
+
+// UsesConfluence 合流スコアを因子に使う重みか。
+func (w DailyPickScoreWeights) UsesConfluence() bool {
+	return w.Confluence.IsPositive()
 }
 
 // DefaultDailyPickScoreWeights 標準の重み配分（合計100）。
@@ -39,6 +40,75 @@ func DefaultDailyPickScoreWeights() DailyPickScoreWeights {
 	}
 }
 
+// DailyPickScoringConfig 名前付きのスコア設定（重み＋事前フィルタ）。Version が daily_stock_pick.score_version に入る。
+type DailyPickScoringConfig struct {
+	Version string
+	Weights DailyPickScoreWeights
+	Filter  DailyPickFilterParams
+}
+
+// DailyPickScoringConfigs 毎晩並走させるスコア設定。先頭（DailyPickScoreVersion）が live で Slack に通知し、
+// 残りはシャドーとして保存と答え合わせだけ行う。新しい重み付けはまずシャドーとして追加し、
+// /daily-stock-picks/stats の versions で live と同じ日同士を比べてから live に昇格させる。
+// WindowDays はスクリーニングで取得する日足の本数と揃えること（シャドーごとに変えない）。
+func DailyPickScoringConfigs() []DailyPickScoringConfig {
+	liquid := DefaultDailyPickFilterParams()
+	liquid.MinAvgTradingValue = decimal.RequireFromString("500000000")
+
+	return []DailyPickScoringConfig{
+		{Version: DailyPickScoreVersion, Weights: DefaultDailyPickScoreWeights(), Filter: DefaultDailyPickFilterParams()},
+		// v1-trend トレンドの強さと出来高の急増を重く見る
+		{
+			Version: "v1-trend",
+			Weights: DailyPickScoreWeights{
+				Signal:     decimal.RequireFromString("20"),
+				Volume:     decimal.RequireFromString("25"),
+				Trend:      decimal.RequireFromString("30"),
+				Volatility: decimal.RequireFromString("10"),
+				Liquidity:  decimal.RequireFromString("5"),
+				Overheat:   decimal.RequireFromString("10"),
+			},
+			Filter: DefaultDailyPickFilterParams(),
+		},
+		// v1-liquid 重みは live と同じで、平均売買代金5億円以上の銘柄に絞る
+		{Version: "v1-liquid", Weights: DefaultDailyPickScoreWeights(), Filter: liquid},
+		// v1-confluence 戦略数の重みの半分を、手法の的中率で重み付けした分析シグナルの合流スコアに振り替える
+		{
+			Version: "v1-confluence",
+			Weights: DailyPickScoreWeights{
+				Signal:     decimal.RequireFromString("15"),
+				Volume:     decimal.RequireFromString("20"),
+				Trend:      decimal.RequireFromString("20"),
+				Volatility: decimal.RequireFromString("10"),
+				Liquidity:  decimal.RequireFromString("10"),
+				Overheat:   decimal.RequireFromString("10"),
+				Confluence: decimal.RequireFromString("15"),
+			},
+			Filter: DefaultDailyPickFilterParams(),
+		},
+	}
+}
+
+// FindDailyPickScoringConfig version のスコア設定を返す。
+func FindDailyPickScoringConfig(version string) (DailyPickScoringConfig, bool) {
+	for _, c := range DailyPickScoringConfigs() {
+		if c.Version == version {
+			return c, true
+		}
+	}
+	return DailyPickScoringConfig{}, false
+}
+
+// DailyPickScoreVersions 並走させているスコア設定のバージョン（live が先頭）。
+func DailyPickScoreVersions() []string {
+	configs := DailyPickScoringConfigs()
+	out := make([]string, 0, len(configs))
+	for _, c := range configs {
+		out = append(out, c.Version)
+	}
+	return out
+}
+
 // scoreBreakpoint 因子の正規化カーブの折れ点。X=生値、Y=0..1の正規化値。
 type scoreBreakpoint struct {
 	X decimal.Decimal
@@ -123,23 +193,91 @@ var (
 		bp("80", "0.10"),
 		bp("90", "0"),
 	}
+	// dailyPickConfluenceCurve 合流スコアの買い優勢分（BuyScore - SellScore）: 0→0, 0.05（的中率55%の手法が当日1つ）→0.35, 0.1→0.70, 0.2以上→1.00。
+	dailyPickConfluenceCurve = []scoreBreakpoint{
+		bp("0", "0"),
+		bp("0.05", "0.35"),
+		bp("0.1", "0.70"),
+		bp("0.2", "1.00"),
+	}
 )
 
+// 複合スコアの因子キー。DailyStockPickScoreComponent.Key に入る（DailyPickScoreWeights のフィールドと1:1対応）。
+const (
+	DailyPickComponentSignal     = "signal"
+	DailyPickComponentVolume     = "volume"
+	DailyPickComponentTrend      = "trend"
+	DailyPickComponentVolatility = "volatility"
+	DailyPickComponentLiquidity  = "liquidity"
+	DailyPickComponentOverheat   = "overheat"
+	DailyPickComponentConfluence = "confluence"
+)
+
+// DailyPickComponentKeys 因子キーの表示・集計順。
+var DailyPickComponentKeys = []string{
+	DailyPickComponentSignal,
+	DailyPickComponentVolume,
+	DailyPickComponentTrend,
+	DailyPickComponentVolatility,
+	DailyPickComponentLiquidity,
+	DailyPickComponentOverheat,
+	DailyPickComponentConfluence,
+}
+
+var dailyPickComponentLabels = map[string]string{
+	DailyPickComponentSignal:     "戦略数",
+	DailyPickComponentVolume:     "出来高",
+	DailyPickComponentTrend:      "ADX",
+	DailyPickComponentVolatility: "ATR",
+	DailyPickComponentLiquidity:  "流動性",
+	DailyPickComponentOverheat:   "RSI",
+	DailyPickComponentConfluence: "合流スコア",
+}
+
+// DailyPickComponentLabel 因子キーの日本語表示名。未知のキーはキーをそのまま返す。
+func DailyPickComponentLabel(key string) string {
+	if label, ok := dailyPickComponentLabels[key]; ok {
+		return label
+	}
+	return key
+}
+
 // ScoreDailyPick 各因子の生値から 0..100 の複合スコアを算出する（Round(2)済み）。
 func ScoreDailyPick(m DailyPickMetrics, w DailyPickScoreWeights) decimal.Decimal {
-	signal := normalizeByBreakpoints(decimal.NewFromInt(int64(m.SignalCount)), dailyPickSignalCurve)
-	volume := normalizeByBreakpoints(m.VolumeRatio, dailyPickVolumeCurve)
-	trend := normalizeByBreakpoints(m.ADX, dailyPickADXCurve)
-	volatility := normalizeByBreakpoints(m.ATRRatio, dailyPickATRRatioCurve)
-	liquidity := normalizeByBreakpoints(m.AvgTradingValue, dailyPickLiquidityCurve)
-	overheat := normalizeByBreakpoints(m.RSI, dailyPickRSICurve)
-
-	score := w.Signal.Mul(signal).
-		Add(w.Volume.Mul(volume)).
-		Add(w.Trend.Mul(trend)).
-		Add(w.Volatility.Mul(volatility)).
-		Add(w.Liquidity.Mul(liquidity)).
-		Add(w.Overheat.Mul(overheat))
-
+	score := decimal.Zero
+	for _, c := range dailyPickScoreComponents(m, w) {
+		score = score.Add(c.Contribution)
+	}
 	return score.Round(2)
 }
+
+// DailyPickScoreComponents 複合スコアの因子ごとの内訳（DailyPickComponentKeys 順）を返す。
+// 保存用に Normalized / Contribution は Round(4) する（合計は ScoreDailyPick と丸め誤差の範囲で一致する）。
+func DailyPickScoreComponents(m DailyPickMetrics, w DailyPickScoreWeights) []models.DailyStockPickScoreComponent {
+	components := dailyPickScoreComponents(m, w)
+	for i := range components {
+		components[i].Normalized = components[i].Normalized.Round(4)
+		components[i].Contribution = components[i].Contribution.Round(4)
+	}
+	return components
+}
+
+func dailyPickScoreComponents(m DailyPickMetrics, w DailyPickScoreWeights) []models.DailyStockPickScoreComponent {
+	component := func(key string, weight, normalized decimal.Decimal) models.DailyStockPickScoreComponent {
+		return models.DailyStockPickScoreComponent{
+			Key:          key,
+			Normalized:   normalized,
+			Weight:       weight,
+			Contribution: weight.Mul(normalized),
+		}
+	}
+	return []models.DailyStockPickScoreComponent{
+		component(DailyPickComponentSignal, w.Signal, normalizeByBreakpoints(decimal.NewFromInt(int64(m.SignalCount)), dailyPickSignalCurve)),
+		component(DailyPickComponentVolume, w.Volume, normalizeByBreakpoints(m.VolumeRatio, dailyPickVolumeCurve)),
+		component(DailyPickComponentTrend, w.Trend, normalizeByBreakpoints(m.ADX, dailyPickADXCurve)),
+		component(DailyPickComponentVolatility, w.Volatility, normalizeByBreakpoints(m.ATRRatio, dailyPickATRRatioCurve)),
+		component(DailyPickComponentLiquidity, w.Liquidity, normalizeByBreakpoints(m.AvgTradingValue, dailyPickLiquidityCurve)),
+		component(DailyPickComponentOverheat, w.Overheat, normalizeByBreakpoints(m.RSI, dailyPickRSICurve)),
+		component(DailyPickComponentConfluence, w.Confluence, normalizeByBreakpoints(m.Confluence, dailyPickConfluenceCurve)),
+	}
+}
@@ -99,3 +99,65 @@ func TestScoreDailyPick(t *testing.T) {
 		assert.Equal(t, "23.50", got.StringFixed(2))
 	})
 }
+
+func TestDailyPickScoreComponents(t *testing.T) {
+	w := DefaultDailyPickScoreWeights()
+	m := DailyPickMetrics{
+		SignalCount:     2,                                  // 0.65
+		VolumeRatio:     decimal.NewFromInt(1),              // 0
+		ADX:             decimal.NewFromInt(15),             // 0
+		ATRRatio:        decimal.RequireFromString("0.010"), // 0
+		AvgTradingValue: decimal.NewFromInt(100_000_000),    // 0
+		RSI:             decimal.NewFromInt(30),             // 0.40
+	}
+
+	got := DailyPickScoreComponents(m, w)
+	keys := make([]string, 0, len(got))
+	sum := decimal.Zero
+	for _, c := range got {
+		keys = append(keys, c.Key)
+		sum = sum.Add(c.Contribution)
+	}
+	assert.Equal(t, DailyPickComponentKeys, keys)
+	assert.True(t, sum.Round(2).Equal(ScoreDailyPick(m, w)), "寄与の合計がスコアと一致する")
+
+	assert.Equal(t, "0.65", got[0].Normalized.String())
+	assert.True(t, got[0].Weight.Equal(w.Signal))
+	assert.Equal(t, "19.5", got[0].Contribution.String())
+	assert.Equal(t, "4", got[5].Contribution.String(), "RSI 10*0.40")
+	assert.True(t, got[1].Contribution.IsZero())
+}
+
+func TestDailyPickComponentLabel(t *testing.T) {
+	assert.Equal(t, "出来高", DailyPickComponentLabel(DailyPickComponentVolume))
+	assert.Equal(t, "unknown", DailyPickComponentLabel("unknown"))
+}
+
+func TestDailyPickScoringConfigs(t *testing.T) {
+	configs := DailyPickScoringConfigs()
+	assert.Equal(t, DailyPickScoreVersion, configs[0].Version, "先頭が live")
+	assert.Equal(t, DailyPickScoreVersions()[0], DailyPickScoreVersion)
+
+	seen := make(map[string]bool, len(configs))
+	for _, c := range configs {
+		assert.False(t, seen[c.Version], "バージョンが重複している: %s", c.Version)
+		seen[c.Version] = true
+		assert.LessOrEqual(t, len(c.Version), 32, "score_version は varchar(32)")
+		assert.Equal(t, DefaultDailyPickFilterParams().WindowDays, c.Filter.WindowDays)
+
+		w := c.Weights
+		sum := w.Signal.Add(w.Volume).Add(w.Trend).Add(w.Volatility).Add(w.Liquidity).Add(w.Overheat).Add(w.Confluence)
+		assert.True(t, sum.Equal(decimal.NewFromInt(100)), "%s の重みの合計が100でない: %s", c.Version, sum)
+	}
+
+	c, ok := FindDailyPickScoringConfig("v1-liquid")
+	assert.True(t, ok)
+	assert.True(t, c.Filter.MinAvgTradingValue.Equal(decimal.NewFromInt(500_000_000)))
+	_, ok = FindDailyPickScoringConfig("v0")
+	assert.False(t, ok)
+
+	c, ok = FindDailyPickScoringConfig("v1-confluence")
+	assert.True(t, ok)
+	assert.True(t, c.Weights.UsesConfluence())
+	assert.False(t, DefaultDailyPickScoreWeights().UsesConfluence(), "live は合流スコアを使わない")
+}
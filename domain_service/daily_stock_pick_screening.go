@@ -1,6 +1,7 @@
 package domain_service
 
 import (
+	"slices"
 	"sort"
 	"time"
 
@@ -16,6 +17,12 @@ type DailyPickFilterParams struct {
 	MinClosePrice      decimal.Decimal // 株価下限（300円）
 	MinAvgTradingValue decimal.Decimal // 直近平均売買代金の下限（1億円）
 	MinAvgVolume       decimal.Decimal // 直近平均出来高の下限（50,000株）
+	AllowedRegimes     []string        // スクリーニングを行う市場局面（空なら局面で絞らない）
+}
+
+// AllowsRegime 市場局面 regime の日にスクリーニングを行うか。局面ゲート未指定なら常に true。
+func (f DailyPickFilterParams) AllowsRegime(regime string) bool {
+	return len(f.AllowedRegimes) == 0 || slices.Contains(f.AllowedRegimes, regime)
 }
 
 // DefaultDailyPickFilterParams 標準の事前フィルタ閾値。
@@ -41,6 +48,8 @@ type DailyPickMetrics struct {
 	RSI             decimal.Decimal
 	Close           decimal.Decimal
 	AdjClose        decimal.Decimal
+	// Confluence 分析シグナルの合流スコアの買い優勢分（SignalConfluenceBuyStrength）。日足からは求まらないため ApplyDailyPickConfluence で後から入れる
+	Confluence decimal.Decimal
 }
 
 // DailyPickCandidate スクリーニング通過銘柄（スコア済み）。
@@ -48,7 +57,8 @@ type DailyPickCandidate struct {
 	Brand      *models.StockBrand
 	Metrics    DailyPickMetrics
 	Score      decimal.Decimal
-	Strategies []string // 点灯した基本戦略キー（StrategyOrder順）
+	Components []models.DailyStockPickScoreComponent // Score の因子ごとの内訳
+	Strategies []string                              // 点灯した基本戦略キー（登録順）
 }
 
 // EvaluateDailyPickCandidate 1銘柄の日足（date昇順、末尾が最新営業日のバー）から候補を評価する。
@@ -113,10 +123,20 @@ func EvaluateDailyPickCandidate(
 		Brand:      brand,
 		Metrics:    metrics,
 		Score:      ScoreDailyPick(metrics, weights),
+		Components: DailyPickScoreComponents(metrics, weights),
 		Strategies: strategies,
 	}
 }
 
+// ApplyDailyPickConfluence 候補に合流スコアの買い優勢分を入れ、weights でスコアと内訳を算出し直した新しい候補を返す（c は変更しない）。
+func ApplyDailyPickConfluence(c *DailyPickCandidate, confluence decimal.Decimal, weights DailyPickScoreWeights) *DailyPickCandidate {
+	out := *c
+	out.Metrics.Confluence = confluence
+	out.Score = ScoreDailyPick(out.Metrics, weights)
+	out.Components = DailyPickScoreComponents(out.Metrics, weights)
+	return &out
+}
+
 // passesDailyPickHardFilters 株価・値幅・流動性の事前フィルタを検証する。
 func passesDailyPickHardFilters(prices []*models.StockBrandDailyPrice, filter DailyPickFilterParams) bool {
 	n := len(prices)
@@ -167,14 +187,17 @@ func lastRSIAndATRRatio(prices []*models.StockBrandDailyPrice, lastClose decimal
 	return rsiSeries[n-1], atrRatio, true
 }
 
-// detectDailyPickStrategies 最新営業日に点灯した基本4戦略のキーを返す（StrategyOrder順）。
+// detectDailyPickStrategies 最新営業日に点灯した基本戦略（登録簿で DailyPick の戦略）のキーを返す（登録順）。
 func detectDailyPickStrategies(prices []*models.StockBrandDailyPrice) []string {
 	n := len(prices)
 	var strategies []string
-	for _, s := range DailyPickBaseStrategies {
-		signals := EntrySignalsByStrategy(s, prices)
+	for _, s := range RegisteredStrategies() {
+		if !s.DailyPick() {
+			continue
+		}
+		signals := s.EntrySignals(prices)
 		if len(signals) == n && signals[n-1] {
-			strategies = append(strategies, s)
+			strategies = append(strategies, s.ID())
 		}
 	}
 	return strategies
@@ -182,8 +205,8 @@ func detectDailyPickStrategies(prices []*models.StockBrandDailyPrice) []string {
 
 // RankDailyPickCandidates スコア降順（同点は TickerSymbol 昇順）に並べ、
 // 同一 Sector33CodeName の採用数を maxPerSector 以下に抑えつつ上位 topN を選ぶ。
-// maxPerSector <= 0 で無制限。Sector33CodeName が空の銘柄は上限の対象外。
-func RankDailyPickCandidates(candidates []*DailyPickCandidate, pickDate time.Time, topN, maxPerSector int) []*models.DailyStockPick {
+// maxPerSector <= 0 で無制限。Sector33CodeName が空の銘柄は上限の対象外。scoreVersion は推奨の ScoreVersion に入る。
+func RankDailyPickCandidates(candidates []*DailyPickCandidate, pickDate time.Time, scoreVersion string, topN, maxPerSector int) []*models.DailyStockPick {
 	sorted := make([]*DailyPickCandidate, len(candidates))
 	copy(sorted, candidates)
 	sort.Slice(sorted, func(i, j int) bool {
@@ -203,7 +226,7 @@ func RankDailyPickCandidates(candidates []*DailyPickCandidate, pickDate time.Tim
 		if maxPerSector > 0 && sector != "" && sectorCount[sector] >= maxPerSector {
 			continue
 		}
-		picks = append(picks, candidateToDailyStockPick(c, pickDate, len(picks)+1))
+		picks = append(picks, candidateToDailyStockPick(c, pickDate, scoreVersion, len(picks)+1))
 		if sector != "" {
 			sectorCount[sector]++
 		}
@@ -211,7 +234,7 @@ func RankDailyPickCandidates(candidates []*DailyPickCandidate, pickDate time.Tim
 	return picks
 }
 
-func candidateToDailyStockPick(c *DailyPickCandidate, pickDate time.Time, rank int) *models.DailyStockPick {
+func candidateToDailyStockPick(c *DailyPickCandidate, pickDate time.Time, scoreVersion string, rank int) *models.DailyStockPick {
 	return &models.DailyStockPick{
 		PickDate:          pickDate,
 		StockBrandID:      c.Brand.ID,
@@ -219,7 +242,7 @@ func candidateToDailyStockPick(c *DailyPickCandidate, pickDate time.Time, rank i
 		Name:              c.Brand.Name,
 		PickRank:          rank,
 		Score:             c.Score,
-		ScoreVersion:      DailyPickScoreVersion,
+		ScoreVersion:      scoreVersion,
 		SignalCount:       c.Metrics.SignalCount,
 		Strategies:        c.Strategies,
 		Sector33CodeName:  c.Brand.Sector33CodeName,
@@ -232,6 +255,7 @@ func candidateToDailyStockPick(c *DailyPickCandidate, pickDate time.Time, rank i
 		MinusDI:           c.Metrics.MinusDI,
 		ATRRatio:          c.Metrics.ATRRatio,
 		RSI:               c.Metrics.RSI,
+		ScoreComponents:   c.Components,
 	}
 }
 
@@ -67,6 +67,7 @@ func TestEvaluateDailyPickCandidate(t *testing.T) {
 		assert.True(t, c.Score.GreaterThan(decimal.Zero))
 		assert.True(t, c.Score.LessThanOrEqual(decimal.NewFromInt(100)))
 		assert.Equal(t, brand, c.Brand)
+		assert.Len(t, c.Components, len(DailyPickComponentKeys), "因子ごとの内訳も持つ")
 	})
 
 	t.Run("戦略が1つも点灯しない（完全に横ばい） → nil", func(t *testing.T) {
@@ -136,7 +137,7 @@ func TestRankDailyPickCandidates(t *testing.T) {
 			rankTestCandidate("b1", "1000", "sec-a", "80"),
 			rankTestCandidate("b2", "2000", "sec-a", "80"),
 		}
-		picks := RankDailyPickCandidates(cands, pickDate, 10, 0)
+		picks := RankDailyPickCandidates(cands, pickDate, DailyPickScoreVersion, 10, 0)
 		assert.Len(t, picks, 3)
 		assert.Equal(t, "1000", picks[0].TickerSymbol)
 		assert.Equal(t, "2000", picks[1].TickerSymbol)
@@ -146,6 +147,12 @@ func TestRankDailyPickCandidates(t *testing.T) {
 		assert.Equal(t, 3, picks[2].PickRank)
 	})
 
+	t.Run("scoreVersion を推奨に入れる", func(t *testing.T) {
+		picks := RankDailyPickCandidates([]*DailyPickCandidate{rankTestCandidate("b1", "1000", "sec-a", "80")}, pickDate, "v1-trend", 10, 0)
+		assert.Len(t, picks, 1)
+		assert.Equal(t, "v1-trend", picks[0].ScoreVersion)
+	})
+
 	t.Run("セクター上限に達すると次点が繰り上がる", func(t *testing.T) {
 		cands := []*DailyPickCandidate{
 			rankTestCandidate("b1", "1001", "sec-a", "90"),
@@ -153,7 +160,7 @@ func TestRankDailyPickCandidates(t *testing.T) {
 			rankTestCandidate("b3", "1003", "sec-a", "80"),
 			rankTestCandidate("b4", "1004", "sec-b", "75"),
 		}
-		picks := RankDailyPickCandidates(cands, pickDate, 3, 2)
+		picks := RankDailyPickCandidates(cands, pickDate, DailyPickScoreVersion, 3, 2)
 		assert.Len(t, picks, 3)
 		tickers := []string{picks[0].TickerSymbol, picks[1].TickerSymbol, picks[2].TickerSymbol}
 		assert.Equal(t, []string{"1001", "1002", "1004"}, tickers)
@@ -165,13 +172,13 @@ func TestRankDailyPickCandidates(t *testing.T) {
 			rankTestCandidate("b2", "1002", "sec-a", "85"),
 			rankTestCandidate("b3", "1003", "sec-a", "80"),
 		}
-		picks := RankDailyPickCandidates(cands, pickDate, 3, 0)
+		picks := RankDailyPickCandidates(cands, pickDate, DailyPickScoreVersion, 3, 0)
 		assert.Len(t, picks, 3)
 	})
 
 	t.Run("topNより候補が少ない場合はそのまま返す", func(t *testing.T) {
 		cands := []*DailyPickCandidate{rankTestCandidate("b1", "1001", "sec-a", "90")}
-		picks := RankDailyPickCandidates(cands, pickDate, 25, 4)
+		picks := RankDailyPickCandidates(cands, pickDate, DailyPickScoreVersion, 25, 4)
 		assert.Len(t, picks, 1)
 		assert.Equal(t, 1, picks[0].PickRank)
 	})
@@ -182,7 +189,17 @@ func TestRankDailyPickCandidates(t *testing.T) {
 			rankTestCandidate("b2", "1002", "", "85"),
 			rankTestCandidate("b3", "1003", "", "80"),
 		}
-		picks := RankDailyPickCandidates(cands, pickDate, 3, 1)
+		picks := RankDailyPickCandidates(cands, pickDate, DailyPickScoreVersion, 3, 1)
 		assert.Len(t, picks, 3)
 	})
 }
+
+func TestDailyPickFilterParams_AllowsRegime(t *testing.T) {
+	filter := DefaultDailyPickFilterParams()
+	assert.True(t, filter.AllowsRegime(models.MarketRegimeTrendDown), "局面ゲート未指定なら常に通す")
+
+	filter.AllowedRegimes = []string{models.MarketRegimeTrendUp, models.MarketRegimeRange}
+	assert.True(t, filter.AllowsRegime(models.MarketRegimeTrendUp))
+	assert.False(t, filter.AllowsRegime(models.MarketRegimeTrendDown))
+	assert.False(t, filter.AllowsRegime(models.MarketRegimeHighVolatility))
+}
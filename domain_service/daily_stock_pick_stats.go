@@ -1,6 +1,7 @@
 package domain_service
 
 import (
+	"slices"
 	"sort"
 	"strconv"
 	"time"
@@ -31,12 +32,17 @@ type dailyPickStatAcc struct {
 }
 
 func (a *dailyPickStatAcc) add(p *models.DailyStockPick) {
+	a.record(p.Evaluated(), p.Outcome, p.Return1D, p.Return3D, p.Return5D)
+}
+
+// record 1件分の答え合わせ結果を加える。推奨以外（分析シグナルなど）も同じ定義で集計するために切り出している。
+func (a *dailyPickStatAcc) record(evaluated bool, outcome *models.DailyStockPickOutcome, return1D, return3D, return5D *decimal.Decimal) {
 	a.total++
-	if p.Evaluated() {
+	if evaluated {
 		a.evaluatedCount++
 	}
-	if p.Outcome != nil {
-		switch *p.Outcome {
+	if outcome != nil {
+		switch *outcome {
 		case models.DailyStockPickOutcomeWin:
 			a.win++
 		case models.DailyStockPickOutcomeLose:
@@ -47,16 +53,16 @@ func (a *dailyPickStatAcc) add(p *models.DailyStockPick) {
 			a.voidCount++
 		}
 	}
-	if p.Return1D != nil {
-		a.sum1D = a.sum1D.Add(*p.Return1D)
+	if return1D != nil {
+		a.sum1D = a.sum1D.Add(*return1D)
 		a.cnt1D++
 	}
-	if p.Return3D != nil {
-		a.sum3D = a.sum3D.Add(*p.Return3D)
+	if return3D != nil {
+		a.sum3D = a.sum3D.Add(*return3D)
 		a.cnt3D++
 	}
-	if p.Return5D != nil {
-		a.sum5D = a.sum5D.Add(*p.Return5D)
+	if return5D != nil {
+		a.sum5D = a.sum5D.Add(*return5D)
 		a.cnt5D++
 	}
 }
@@ -162,6 +168,187 @@ func AggregateDailyPicksByScoreBand(picks []*models.DailyStockPick) []*models.Da
 	return out
 }
 
+// AggregateDailyPicksByRegime 推奨日の市場局面別に集計する。regimeByDate は "2006-01-02" → 局面。
+// 局面が未算出の日は unknown にまとめる。並びは models.MarketRegimes の順で unknown を最後にし、該当0件の局面は返さない。
+func AggregateDailyPicksByRegime(picks []*models.DailyStockPick, regimeByDate map[string]string) []*models.DailyStockPickRegimeStat {
+	byRegime := make(map[string]*dailyPickStatAcc)
+	for _, p := range picks {
+		regime, ok := regimeByDate[p.PickDate.Format(dailyPickDateLayout)]
+		if !ok {
+			regime = models.MarketRegimeUnknown
+		}
+		acc, ok := byRegime[regime]
+		if !ok {
+			acc = &dailyPickStatAcc{}
+			byRegime[regime] = acc
+		}
+		acc.add(p)
+	}
+
+	out := make([]*models.DailyStockPickRegimeStat, 0, len(byRegime))
+	for _, regime := range append(slices.Clone(models.MarketRegimes), models.MarketRegimeUnknown) {
+		acc, ok := byRegime[regime]
+		if !ok {
+			continue
+		}
+		out = append(out, &models.DailyStockPickRegimeStat{
+			Regime:                    regime,
+			DailyStockPickStatSummary: acc.summary(),
+		})
+	}
+	return out
+}
+
+// dailyPickComponentMinCorrelationCount 相関係数を出す最小件数。
+const dailyPickComponentMinCorrelationCount = 3
+
+// AggregateDailyPicksByComponent 因子ごとに、正規化値・寄与の平均と5営業日後リターンとの関係を集計する（DailyPickComponentKeys 順で常に全因子を返す）。
+// 5営業日後リターンと内訳の両方がある推奨だけを使う。上位半分・下位半分は正規化値で並べて件数を二等分する（奇数なら中央の1件は除く）。
+func AggregateDailyPicksByComponent(picks []*models.DailyStockPick) []*models.DailyStockPickComponentStat {
+	type sample struct {
+		normalized, contribution, return5D decimal.Decimal
+	}
+	byKey := make(map[string][]sample, len(DailyPickComponentKeys))
+	for _, p := range picks {
+		if p.Return5D == nil {
+			continue
+		}
+		for _, c := range p.ScoreComponents {
+			byKey[c.Key] = append(byKey[c.Key], sample{normalized: c.Normalized, contribution: c.Contribution, return5D: *p.Return5D})
+		}
+	}
+
+	out := make([]*models.DailyStockPickComponentStat, 0, len(DailyPickComponentKeys))
+	for _, key := range DailyPickComponentKeys {
+		samples := byKey[key]
+		stat := &models.DailyStockPickComponentStat{
+			Key:   key,
+			Label: DailyPickComponentLabel(key),
+			Count: len(samples),
+		}
+		if len(samples) == 0 {
+			out = append(out, stat)
+			continue
+		}
+
+		slices.SortStableFunc(samples, func(a, b sample) int { return a.normalized.Cmp(b.normalized) })
+		normalized := make([]decimal.Decimal, len(samples))
+		contributions := make([]decimal.Decimal, len(samples))
+		returns := make([]decimal.Decimal, len(samples))
+		for i, sm := range samples {
+			normalized[i] = sm.normalized
+			contributions[i] = sm.contribution
+			returns[i] = sm.return5D
+		}
+		stat.AvgNormalized = mean(normalized).Round(4)
+		stat.AvgContribution = mean(contributions).Round(4)
+		if len(samples) >= dailyPickComponentMinCorrelationCount &&
+			!stdDevSample(normalized).IsZero() && !stdDevSample(returns).IsZero() {
+			corr := Correlation(normalized, returns).Round(4)
+			stat.Correlation = &corr
+		}
+		half := len(samples) / 2
+		if half > 0 {
+			stat.LowAvgReturn5D = mean(returns[:half]).Round(6)
+			stat.HighAvgReturn5D = mean(returns[len(returns)-half:]).Round(6)
+		}
+		out = append(out, stat)
+	}
+	return out
+}
+
+// CompareDailyPickVersions スコア設定ごとの推奨を live と比べる。picksByVersion はバージョン → 推奨、versions は返す順（live を含める）。
+// live 以外は live にも推奨がある日だけで集計し、同じ日の live の成績を Baseline に入れる。推奨が1件も無いバージョンも Days=0 で返す。
+func CompareDailyPickVersions(picksByVersion map[string][]*models.DailyStockPick, versions []string, live string) []*models.DailyStockPickVersionStat {
+	liveByDate := groupDailyPicksByDate(picksByVersion[live])
+
+	out := make([]*models.DailyStockPickVersionStat, 0, len(versions))
+	for _, version := range versions {
+		if version == live {
+			out = append(out, &models.DailyStockPickVersionStat{
+				ScoreVersion:              live,
+				Live:                      true,
+				Days:                      len(liveByDate),
+				DailyStockPickStatSummary: SummarizeDailyPicksForView(picksByVersion[live]),
+				OverlapRate:               decimal.NewFromInt(1),
+			})
+			continue
+		}
+
+		var acc, baseline dailyPickStatAcc
+		stat := &models.DailyStockPickVersionStat{ScoreVersion: version}
+		overlapSum := decimal.Zero
+		for day, picks := range groupDailyPicksByDate(picksByVersion[version]) {
+			livePicks, ok := liveByDate[day]
+			if !ok {
+				continue
+			}
+			stat.Days++
+			for _, p := range picks {
+				acc.add(p)
+			}
+			for _, p := range livePicks {
+				baseline.add(p)
+			}
+			overlapSum = overlapSum.Add(dailyPickOverlap(picks, livePicks))
+			if beatsOnReturn5D(picks, livePicks) {
+				stat.BeatLiveDays++
+			}
+		}
+		stat.DailyStockPickStatSummary = acc.summary()
+		baselineSummary := baseline.summary()
+		stat.Baseline = &baselineSummary
+		if stat.Days > 0 {
+			stat.OverlapRate = overlapSum.Div(decimal.NewFromInt(int64(stat.Days))).Round(4)
+		}
+		out = append(out, stat)
+	}
+	return out
+}
+
+// groupDailyPicksByDate 推奨を "2006-01-02" ごとにまとめる。
+func groupDailyPicksByDate(picks []*models.DailyStockPick) map[string][]*models.DailyStockPick {
+	out := make(map[string][]*models.DailyStockPick)
+	for _, p := range picks {
+		day := p.PickDate.Format(dailyPickDateLayout)
+		out[day] = append(out[day], p)
+	}
+	return out
+}
+
+// dailyPickOverlap picks のうち base にも含まれる銘柄の割合。
+func dailyPickOverlap(picks, base []*models.DailyStockPick) decimal.Decimal {
+	if len(picks) == 0 {
+		return decimal.Zero
+	}
+	inBase := make(map[string]struct{}, len(base))
+	for _, p := range base {
+		inBase[p.StockBrandID] = struct{}{}
+	}
+	common := 0
+	for _, p := range picks {
+		if _, ok := inBase[p.StockBrandID]; ok {
+			common++
+		}
+	}
+	return decimal.NewFromInt(int64(common)).Div(decimal.NewFromInt(int64(len(picks))))
+}
+
+// beatsOnReturn5D picks の5営業日後平均リターンが base を上回るか。どちらかに5営業日後リターンが無ければ false。
+func beatsOnReturn5D(picks, base []*models.DailyStockPick) bool {
+	var a, b dailyPickStatAcc
+	for _, p := range picks {
+		a.add(p)
+	}
+	for _, p := range base {
+		b.add(p)
+	}
+	if a.cnt5D == 0 || b.cnt5D == 0 {
+		return false
+	}
+	return a.summary().AvgReturn5D.GreaterThan(b.summary().AvgReturn5D)
+}
+
 // scoreBandLower スコアが属する帯の下限を返す。100 以上は最終帯（90）に含める。負値は 0 帯に丸める。
 func scoreBandLower(score decimal.Decimal) int {
 	s := score.IntPart()
@@ -207,3 +207,134 @@ func TestAggregateDailyPicksByScoreBand(t *testing.T) {
 		assert.Equal(t, "90-100", got[0].Band)
 	})
 }
+
+func TestAggregateDailyPicksByRegime(t *testing.T) {
+	d1 := time.Date(2026, 7, 22, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2026, 7, 23, 0, 0, 0, 0, time.UTC)
+	d3 := time.Date(2026, 7, 24, 0, 0, 0, 0, time.UTC)
+
+	t.Run("0件入力は空スライス（nilではない）", func(t *testing.T) {
+		got := AggregateDailyPicksByRegime(nil, nil)
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+	})
+
+	t.Run("局面の表示順で並び、未算出の日は unknown に入る", func(t *testing.T) {
+		picks := []*models.DailyStockPick{
+			statPick(d1, "80", statOutcome(models.DailyStockPickOutcomeLose), nil, nil, statStr("-0.03")),
+			statPick(d2, "80", statOutcome(models.DailyStockPickOutcomeWin), nil, nil, statStr("0.05")),
+			statPick(d2, "70", statOutcome(models.DailyStockPickOutcomeWin), nil, nil, statStr("0.02")),
+			statPick(d3, "75", nil, nil, nil, nil),
+		}
+		regimeByDate := map[string]string{
+			"2026-07-22": models.MarketRegimeTrendDown,
+			"2026-07-23": models.MarketRegimeTrendUp,
+		}
+		got := AggregateDailyPicksByRegime(picks, regimeByDate)
+		assert.Len(t, got, 3)
+		assert.Equal(t, models.MarketRegimeTrendUp, got[0].Regime)
+		assert.Equal(t, 2, got[0].Total)
+		assert.Equal(t, "1", got[0].WinRate.String())
+		assert.Equal(t, models.MarketRegimeTrendDown, got[1].Regime)
+		assert.Equal(t, "0", got[1].WinRate.String())
+		assert.Equal(t, models.MarketRegimeUnknown, got[2].Regime)
+		assert.Equal(t, 1, got[2].Total)
+	})
+}
+
+func TestCompareDailyPickVersions(t *testing.T) {
+	d1 := time.Date(2026, 7, 22, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2026, 7, 23, 0, 0, 0, 0, time.UTC)
+	d3 := time.Date(2026, 7, 24, 0, 0, 0, 0, time.UTC)
+	withBrand := func(p *models.DailyStockPick, id string) *models.DailyStockPick {
+		p.StockBrandID = id
+		return p
+	}
+
+	live := []*models.DailyStockPick{
+		withBrand(statPick(d1, "80", statOutcome(models.DailyStockPickOutcomeWin), nil, nil, statStr("0.02")), "a"),
+		withBrand(statPick(d1, "70", statOutcome(models.DailyStockPickOutcomeLose), nil, nil, statStr("-0.02")), "b"),
+		withBrand(statPick(d2, "80", statOutcome(models.DailyStockPickOutcomeWin), nil, nil, statStr("0.04")), "a"),
+	}
+	shadow := []*models.DailyStockPick{
+		withBrand(statPick(d1, "90", statOutcome(models.DailyStockPickOutcomeWin), nil, nil, statStr("0.02")), "a"),
+		withBrand(statPick(d1, "85", statOutcome(models.DailyStockPickOutcomeWin), nil, nil, statStr("0.03")), "c"),
+		withBrand(statPick(d2, "80", statOutcome(models.DailyStockPickOutcomeLose), nil, nil, statStr("-0.01")), "d"),
+		// live に推奨が無い日は比較に入れない
+		withBrand(statPick(d3, "80", statOutcome(models.DailyStockPickOutcomeWin), nil, nil, statStr("0.10")), "e"),
+	}
+
+	got := CompareDailyPickVersions(map[string][]*models.DailyStockPick{
+		"v1":       live,
+		"v1-trend": shadow,
+	}, []string{"v1", "v1-trend", "v1-liquid"}, "v1")
+
+	assert.Len(t, got, 3)
+
+	assert.Equal(t, "v1", got[0].ScoreVersion)
+	assert.True(t, got[0].Live)
+	assert.Equal(t, 2, got[0].Days)
+	assert.Equal(t, 3, got[0].Total)
+	assert.Nil(t, got[0].Baseline)
+	assert.Equal(t, "1", got[0].OverlapRate.String())
+
+	trend := got[1]
+	assert.False(t, trend.Live)
+	assert.Equal(t, 2, trend.Days)
+	assert.Equal(t, 3, trend.Total)
+	assert.Equal(t, 2, trend.Win)
+	assert.Equal(t, "0.013333", trend.AvgReturn5D.String())
+	if assert.NotNil(t, trend.Baseline) {
+		assert.Equal(t, 3, trend.Baseline.Total)
+		assert.Equal(t, "0.013333", trend.Baseline.AvgReturn5D.String())
+	}
+	// d1: shadow 平均 0.025 > live 平均 0、d2: -0.01 < 0.04
+	assert.Equal(t, 1, trend.BeatLiveDays)
+	// d1: 2銘柄中 a のみ live と共通（0.5）、d2: 共通なし（0）
+	assert.Equal(t, "0.25", trend.OverlapRate.String())
+
+	liquid := got[2]
+	assert.Equal(t, "v1-liquid", liquid.ScoreVersion)
+	assert.Equal(t, 0, liquid.Days)
+	assert.Equal(t, 0, liquid.Total)
+}
+
+func TestAggregateDailyPicksByComponent(t *testing.T) {
+	d := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	withVolume := func(normalized, r5d string) *models.DailyStockPick {
+		p := statPick(d, "50", statOutcome(models.DailyStockPickOutcomeWin), nil, nil, statStr(r5d))
+		n := decimal.RequireFromString(normalized)
+		p.ScoreComponents = []models.DailyStockPickScoreComponent{
+			{Key: DailyPickComponentVolume, Normalized: n, Weight: decimal.NewFromInt(20), Contribution: n.Mul(decimal.NewFromInt(20))},
+		}
+		return p
+	}
+	picks := []*models.DailyStockPick{
+		withVolume("0.2", "-0.02"),
+		withVolume("0.4", "0.00"),
+		withVolume("0.6", "0.01"),
+		withVolume("0.8", "0.03"),
+		// 5営業日後リターンが無い推奨・内訳が無い推奨は対象外
+		{PickDate: d, ScoreComponents: []models.DailyStockPickScoreComponent{{Key: DailyPickComponentVolume, Normalized: decimal.NewFromInt(1)}}},
+		statPick(d, "50", statOutcome(models.DailyStockPickOutcomeWin), nil, nil, statStr("0.50")),
+	}
+
+	got := AggregateDailyPicksByComponent(picks)
+	assert.Len(t, got, len(DailyPickComponentKeys), "件数0の因子も返す")
+
+	volume := got[1]
+	assert.Equal(t, DailyPickComponentVolume, volume.Key)
+	assert.Equal(t, "出来高", volume.Label)
+	assert.Equal(t, 4, volume.Count)
+	assert.Equal(t, "0.5", volume.AvgNormalized.String())
+	assert.Equal(t, "10", volume.AvgContribution.String())
+	if assert.NotNil(t, volume.Correlation) {
+		assert.True(t, volume.Correlation.GreaterThan(decimal.RequireFromString("0.9")), "正規化値が高いほどリターンが高い")
+	}
+	assert.Equal(t, "-0.01", volume.LowAvgReturn5D.String())
+	assert.Equal(t, "0.02", volume.HighAvgReturn5D.String())
+
+	signal := got[0]
+	assert.Equal(t, 0, signal.Count)
+	assert.Nil(t, signal.Correlation)
+}
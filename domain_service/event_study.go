@@ -0,0 +1,228 @@
+package domain_service
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// eventStudyMinEstimationReturns 市場モデルを推定するのに最低限必要な推定期間のリターン数。
+const eventStudyMinEstimationReturns = 30
+
+// EventStudyParams イベントスタディの窓の設定（いずれも営業日数）。
+//
+//	|--- EstimationDays ---|-- GapDays --|-- PreDays --|0|-- PostDays --|
+type EventStudyParams struct {
+	PreDays        int
+	PostDays       int
+	EstimationDays int
+	GapDays        int // 推定期間とイベント窓の間を空け、事前の情報漏れを推定に混ぜない
+}
+
+// DefaultEventStudyParams 標準の窓（イベント前5日〜後20日、推定120日、間隔10日）。
+func DefaultEventStudyParams() EventStudyParams {
+	return EventStudyParams{
+		PreDays:        5,
+		PostDays:       20,
+		EstimationDays: 120,
+		GapDays:        10,
+	}
+}
+
+// EventStudyLookbackDays イベント日より前に必要な日足の暦日数の目安（営業日→暦日の換算に余裕を持たせる）。
+func (p EventStudyParams) EventStudyLookbackDays() int {
+	return (p.EstimationDays+p.GapDays+p.PreDays+1)*7/5 + 14
+}
+
+// EventStudyLookaheadDays イベント日より後に必要な日足の暦日数の目安。
+func (p EventStudyParams) EventStudyLookaheadDays() int {
+	return p.PostDays*7/5 + 14
+}
+
+// RunEventStudy 市場モデル（R = α + βRm）で各イベントの異常リターン（AR）を求め、AAR・CAAR と t 値を集計する。
+// pricesBySymbol は銘柄ごとの日足（date 昇順、調整後終値を使う）、benchmark は市場ポートフォリオの日足（date 昇順）、benchmarkName はその名前（topix/nikkei）。
+// イベント日が休場日なら直後の営業日を offset 0 とする。推定期間・イベント窓の日足が足りないイベントは SkippedCount に数える。
+func RunEventStudy(
+	events []*models.EventStudyEvent,
+	pricesBySymbol map[string][]*models.StockBrandDailyPrice,
+	benchmark models.IndexStockAverageDailyPrices,
+	benchmarkName string,
+	params EventStudyParams,
+) *models.EventStudyResult {
+	benchmarkClose := make(map[string]decimal.Decimal, len(benchmark))
+	for _, b := range benchmark {
+		if !b.Close.IsZero() {
+			benchmarkClose[b.Date.Format(util.DateLayout)] = b.Close
+		}
+	}
+
+	windowLen := params.PreDays + params.PostDays + 1
+	result := &models.EventStudyResult{
+		Benchmark:      benchmarkName,
+		PreDays:        params.PreDays,
+		PostDays:       params.PostDays,
+		EstimationDays: params.EstimationDays,
+		EventCount:     len(events),
+		Days:           []*models.EventStudyDay{},
+		Events:         []*models.EventStudyEventResult{},
+	}
+
+	var abnormal [][]decimal.Decimal // イベント × イベント窓の AR
+	for _, ev := range events {
+		ar, evResult := eventAbnormalReturns(ev, pricesBySymbol[ev.TickerSymbol], benchmarkClose, params)
+		if ar == nil {
+			result.SkippedCount++
+			continue
+		}
+		abnormal = append(abnormal, ar)
+		result.Events = append(result.Events, evResult)
+	}
+	result.EvaluatedCount = len(abnormal)
+	if len(abnormal) == 0 {
+		return result
+	}
+
+	cumulative := make([]decimal.Decimal, len(abnormal))
+	for k := range windowLen {
+		ars := make([]decimal.Decimal, len(abnormal))
+		for i, ar := range abnormal {
+			ars[i] = ar[k]
+			cumulative[i] = cumulative[i].Add(ar[k])
+		}
+		result.Days = append(result.Days, &models.EventStudyDay{
+			Offset:    k - params.PreDays,
+			AAR:       mean(ars).Round(6),
+			AARTStat:  crossSectionalTStat(ars).Round(4),
+			CAAR:      mean(cumulative).Round(6),
+			CAARTStat: crossSectionalTStat(cumulative).Round(4),
+		})
+	}
+	return result
+}
+
+// eventAbnormalReturns 1イベントのイベント窓の AR と、推定した市場モデル・CAR を返す。日足が足りなければ nil。
+func eventAbnormalReturns(
+	ev *models.EventStudyEvent,
+	prices []*models.StockBrandDailyPrice,
+	benchmarkClose map[string]decimal.Decimal,
+	params EventStudyParams,
+) ([]decimal.Decimal, *models.EventStudyEventResult) {
+	eventDay := ev.Date.Format(util.DateLayout)
+	idx0 := -1
+	for i, p := range prices {
+		if p.Date.Format(util.DateLayout) >= eventDay {
+			idx0 = i
+			break
+		}
+	}
+	windowStart := idx0 - params.PreDays
+	windowEnd := idx0 + params.PostDays
+	estStart := windowStart - params.GapDays - params.EstimationDays
+	// 推定期間の最初の日もリターンを出すため、その前日の日足が要る
+	if idx0 < 0 || estStart < 1 || windowEnd >= len(prices) {
+		return nil, nil
+	}
+
+	var rs, rms []decimal.Decimal
+	for i := estStart; i < estStart+params.EstimationDays; i++ {
+		r, rm, ok := stockAndMarketReturn(prices, i, benchmarkClose)
+		if !ok {
+			continue
+		}
+		rs = append(rs, r)
+		rms = append(rms, rm)
+	}
+	varM := sampleVariance(rms)
+	if len(rs) < eventStudyMinEstimationReturns || varM.IsZero() {
+		return nil, nil
+	}
+	beta := sampleCovariance(rs, rms).Div(varM)
+	alpha := mean(rs).Sub(beta.Mul(mean(rms)))
+
+	residualSS := decimal.Zero
+	for i := range rs {
+		e := rs[i].Sub(alpha.Add(beta.Mul(rms[i])))
+		residualSS = residualSS.Add(e.Mul(e))
+	}
+	residualSD := decimalSqrt(residualSS.Div(decimal.NewFromInt(int64(len(rs) - 2))))
+
+	ar := make([]decimal.Decimal, 0, windowEnd-windowStart+1)
+	car := decimal.Zero
+	for i := windowStart; i <= windowEnd; i++ {
+		r, rm, ok := stockAndMarketReturn(prices, i, benchmarkClose)
+		if !ok {
+			return nil, nil
+		}
+		a := r.Sub(alpha.Add(beta.Mul(rm)))
+		if ev.Short {
+			a = a.Neg()
+		}
+		ar = append(ar, a)
+		car = car.Add(a)
+	}
+
+	carT := decimal.Zero
+	if denom := residualSD.Mul(decimalSqrt(decimal.NewFromInt(int64(len(ar))))); !denom.IsZero() {
+		carT = car.Div(denom)
+	}
+	return ar, &models.EventStudyEventResult{
+		TickerSymbol: ev.TickerSymbol,
+		Date:         ev.Date,
+		EventDate:    prices[idx0].Date,
+		Label:        ev.Label,
+		Alpha:        alpha.Round(6),
+		Beta:         beta.Round(4),
+		CAR:          car.Round(6),
+		CARTStat:     carT.Round(4),
+	}
+}
+
+// stockAndMarketReturn prices[i] の前営業日比の銘柄リターンと、同じ2日間の市場リターン。どちらかが欠ければ ok=false。
+func stockAndMarketReturn(prices []*models.StockBrandDailyPrice, i int, benchmarkClose map[string]decimal.Decimal) (decimal.Decimal, decimal.Decimal, bool) {
+	prev, cur := prices[i-1], prices[i]
+	if prev.Adjclose.IsZero() || cur.Adjclose.IsZero() {
+		return decimal.Zero, decimal.Zero, false
+	}
+	mPrev, ok := benchmarkClose[prev.Date.Format(util.DateLayout)]
+	if !ok {
+		return decimal.Zero, decimal.Zero, false
+	}
+	mCur, ok := benchmarkClose[cur.Date.Format(util.DateLayout)]
+	if !ok {
+		return decimal.Zero, decimal.Zero, false
+	}
+	one := decimal.NewFromInt(1)
+	return cur.Adjclose.Div(prev.Adjclose).Sub(one), mCur.Div(mPrev).Sub(one), true
+}
+
+// crossSectionalTStat 平均が 0 かどうかの t 値（mean / (sd/√n)）。2件未満・ばらつき無しは 0。
+func crossSectionalTStat(xs []decimal.Decimal) decimal.Decimal {
+	if len(xs) < 2 {
+		return decimal.Zero
+	}
+	se := stdDevSample(xs).Div(decimalSqrt(decimal.NewFromInt(int64(len(xs)))))
+	if se.IsZero() {
+		return decimal.Zero
+	}
+	return mean(xs).Div(se)
+}
+
+// EventStudyPriceRange events のイベント日から、日足・ベンチマークの取得に必要な期間を返す。events が空なら ok=false。
+func EventStudyPriceRange(events []*models.EventStudyEvent, params EventStudyParams) (from, to time.Time, ok bool) {
+	if len(events) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	minDate, maxDate := events[0].Date, events[0].Date
+	for _, ev := range events[1:] {
+		if ev.Date.Before(minDate) {
+			minDate = ev.Date
+		}
+		if ev.Date.After(maxDate) {
+			maxDate = ev.Date
+		}
+	}
+	return minDate.AddDate(0, 0, -params.EventStudyLookbackDays()), maxDate.AddDate(0, 0, params.EventStudyLookaheadDays()), true
+}
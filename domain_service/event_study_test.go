@@ -0,0 +1,136 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// eventStudySeries 2024-01-01 から n 日分の指数と銘柄の日足。
+// 銘柄のリターンは 0.0005 + 1.5×市場リターン + 小さな誤差で、jumpDay の日だけ jump を上乗せする。
+func eventStudySeries(n, jumpDay int, jump float64) (models.IndexStockAverageDailyPrices, []*models.StockBrandDailyPrice) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	index := make(models.IndexStockAverageDailyPrices, 0, n)
+	prices := make([]*models.StockBrandDailyPrice, 0, n)
+	m, s := 1000.0, 500.0
+	for i := range n {
+		if i > 0 {
+			rm := float64(i%5-2) * 0.004
+			r := 0.0005 + 1.5*rm + float64(i%3-1)*0.001
+			if i == jumpDay {
+				r += jump
+			}
+			m *= 1 + rm
+			s *= 1 + r
+		}
+		d := base.AddDate(0, 0, i)
+		index = append(index, &models.IndexStockAverageDailyPrice{Date: d, Close: decimal.NewFromFloat(m)})
+		prices = append(prices, &models.StockBrandDailyPrice{Date: d, Close: decimal.NewFromFloat(s), Adjclose: decimal.NewFromFloat(s)})
+	}
+	return index, prices
+}
+
+func TestRunEventStudy(t *testing.T) {
+	params := EventStudyParams{PreDays: 2, PostDays: 5, EstimationDays: 60, GapDays: 5}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("イベント日の上乗せが異常リターンとして現れる", func(t *testing.T) {
+		index, prices := eventStudySeries(100, 80, 0.05)
+		events := []*models.EventStudyEvent{{TickerSymbol: "1111", Date: base.AddDate(0, 0, 80), Label: "決算"}}
+		got := RunEventStudy(events, map[string][]*models.StockBrandDailyPrice{"1111": prices}, index, models.EventStudyBenchmarkTopix, params)
+
+		assert.Equal(t, models.EventStudyBenchmarkTopix, got.Benchmark)
+		assert.Equal(t, 1, got.EventCount)
+		assert.Equal(t, 1, got.EvaluatedCount)
+		assert.Equal(t, 0, got.SkippedCount)
+		require.Len(t, got.Days, params.PreDays+params.PostDays+1)
+		assert.Equal(t, -2, got.Days[0].Offset)
+		assert.Equal(t, 5, got.Days[len(got.Days)-1].Offset)
+
+		day0 := got.Days[params.PreDays]
+		assert.Equal(t, 0, day0.Offset)
+		assert.InDelta(t, 0.05, day0.AAR.InexactFloat64(), 0.005)
+		assert.True(t, day0.AARTStat.IsZero(), "1件ではクロスセクションの t 値は出さない")
+		assert.InDelta(t, 0.05, got.Days[len(got.Days)-1].CAAR.InexactFloat64(), 0.01)
+
+		require.Len(t, got.Events, 1)
+		ev := got.Events[0]
+		assert.Equal(t, "決算", ev.Label)
+		assert.InDelta(t, 1.5, ev.Beta.InexactFloat64(), 0.1)
+		assert.True(t, ev.CARTStat.GreaterThan(decimal.NewFromInt(2)))
+	})
+
+	t.Run("休場日のイベントは次の営業日を offset 0 にする", func(t *testing.T) {
+		index, prices := eventStudySeries(100, 80, 0.05)
+		// 79日目を休場日にする
+		prices = append(prices[:79:79], prices[80:]...)
+		events := []*models.EventStudyEvent{{TickerSymbol: "1111", Date: base.AddDate(0, 0, 79)}}
+		got := RunEventStudy(events, map[string][]*models.StockBrandDailyPrice{"1111": prices}, index, models.EventStudyBenchmarkNikkei, params)
+
+		require.Len(t, got.Events, 1)
+		assert.Equal(t, base.AddDate(0, 0, 80), got.Events[0].EventDate)
+	})
+
+	t.Run("複数イベントで AAR の t 値を出す", func(t *testing.T) {
+		index, prices := eventStudySeries(100, 80, 0.05)
+		_, prices2 := eventStudySeries(100, 80, 0.04)
+		events := []*models.EventStudyEvent{
+			{TickerSymbol: "1111", Date: base.AddDate(0, 0, 80)},
+			{TickerSymbol: "2222", Date: base.AddDate(0, 0, 80)},
+		}
+		got := RunEventStudy(events, map[string][]*models.StockBrandDailyPrice{"1111": prices, "2222": prices2}, index, models.EventStudyBenchmarkTopix, params)
+
+		assert.Equal(t, 2, got.EvaluatedCount)
+		day0 := got.Days[params.PreDays]
+		assert.InDelta(t, 0.045, day0.AAR.InexactFloat64(), 0.005)
+		assert.True(t, day0.AARTStat.GreaterThan(decimal.NewFromInt(2)))
+	})
+
+	t.Run("売り方向のイベントは異常リターンの符号を反転する", func(t *testing.T) {
+		index, prices := eventStudySeries(100, 80, -0.05)
+		events := []*models.EventStudyEvent{{TickerSymbol: "1111", Date: base.AddDate(0, 0, 80), Short: true}}
+		got := RunEventStudy(events, map[string][]*models.StockBrandDailyPrice{"1111": prices}, index, models.EventStudyBenchmarkTopix, params)
+
+		require.Len(t, got.Events, 1)
+		assert.InDelta(t, 0.05, got.Days[params.PreDays].AAR.InexactFloat64(), 0.005, "下落を当てた売りは正の異常リターン")
+		assert.True(t, got.Events[0].CAR.IsPositive())
+		assert.True(t, got.Events[0].CARTStat.GreaterThan(decimal.NewFromInt(2)))
+	})
+
+	t.Run("日足が足りないイベントは除外する", func(t *testing.T) {
+		index, prices := eventStudySeries(100, 80, 0.05)
+		events := []*models.EventStudyEvent{
+			{TickerSymbol: "1111", Date: base.AddDate(0, 0, 30)}, // 推定期間が足りない
+			{TickerSymbol: "1111", Date: base.AddDate(0, 0, 97)}, // イベント後の日足が足りない
+			{TickerSymbol: "9999", Date: base.AddDate(0, 0, 80)}, // 日足が無い
+		}
+		got := RunEventStudy(events, map[string][]*models.StockBrandDailyPrice{"1111": prices}, index, models.EventStudyBenchmarkTopix, params)
+
+		assert.Equal(t, 3, got.EventCount)
+		assert.Equal(t, 0, got.EvaluatedCount)
+		assert.Equal(t, 3, got.SkippedCount)
+		assert.Empty(t, got.Days)
+		assert.Empty(t, got.Events)
+	})
+}
+
+func TestEventStudyPriceRange(t *testing.T) {
+	params := DefaultEventStudyParams()
+
+	_, _, ok := EventStudyPriceRange(nil, params)
+	assert.False(t, ok)
+
+	d1 := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	from, to, ok := EventStudyPriceRange([]*models.EventStudyEvent{{Date: d2}, {Date: d1}}, params)
+	require.True(t, ok)
+	assert.Equal(t, d1.AddDate(0, 0, -params.EventStudyLookbackDays()), from)
+	assert.Equal(t, d2.AddDate(0, 0, params.EventStudyLookaheadDays()), to)
+	// 推定期間＋間隔＋イベント前の営業日数を暦日で十分に覆う
+	assert.Greater(t, params.EventStudyLookbackDays(), params.EstimationDays+params.GapDays+params.PreDays)
+}
@@ -0,0 +1,167 @@
+package domain_service
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+const (
+	// marketBreadthNewHighLowDays 新高値・新安値の判定期間（52週 ≒ 250営業日）。
+	marketBreadthNewHighLowDays = 250
+	// marketBreadthADRatioDays 騰落レシオの期間。
+	marketBreadthADRatioDays = 25
+)
+
+// marketBreadthMAPeriods 終値が上回っているかを数える移動平均線の期間。
+var marketBreadthMAPeriods = []int{25, 75, 200}
+
+// マクレラン・オシレーターの EMA の平滑化係数（2/(N+1)）。
+var (
+	mcclellanFastK = decimal.NewFromInt(2).Div(decimal.NewFromInt(20))
+	mcclellanSlowK = decimal.NewFromInt(2).Div(decimal.NewFromInt(40))
+)
+
+// breadthSeries 1銘柄の日付（YYYY-MM-DD）と調整後終値。全銘柄×全営業日を走査するため float64 で持つ。
+type breadthSeries struct {
+	days   []string
+	closes []float64
+}
+
+func newBreadthSeries(prices []*models.StockBrandDailyPrice) breadthSeries {
+	s := breadthSeries{
+		days:   make([]string, 0, len(prices)),
+		closes: make([]float64, 0, len(prices)),
+	}
+	for _, p := range prices {
+		if p.Adjclose.IsZero() {
+			continue
+		}
+		s.days = append(s.days, p.Date.Format(util.DateLayout))
+		s.closes = append(s.closes, p.Adjclose.InexactFloat64())
+	}
+	return s
+}
+
+// CountMarketBreadth 銘柄ごとの日足（date 昇順）から、days の各営業日の騰落数・新高値/新安値・移動平均線を上回る銘柄の割合を数える。
+// 当日と前営業日の日足がある銘柄だけを数え、新高値/新安値と各移動平均線は判定に必要な本数がある銘柄に限る。
+// 累積系（A/D ライン・騰落レシオ・マクレラン・オシレーター）は ChainMarketBreadth で埋める。
+func CountMarketBreadth(market string, prices [][]*models.StockBrandDailyPrice, days []time.Time) []*models.MarketBreadth {
+	series := make([]breadthSeries, 0, len(prices))
+	for _, p := range prices {
+		series = append(series, newBreadthSeries(p))
+	}
+
+	rows := make([]*models.MarketBreadth, 0, len(days))
+	for _, date := range days {
+		day := date.Format(util.DateLayout)
+		row := &models.MarketBreadth{Date: date, Market: market}
+		above := make([]int, len(marketBreadthMAPeriods))
+		eligible := make([]int, len(marketBreadthMAPeriods))
+
+		for _, s := range series {
+			i := sort.SearchStrings(s.days, day)
+			if i == 0 || i >= len(s.days) || s.days[i] != day {
+				continue
+			}
+			c := s.closes[i]
+			switch prev := s.closes[i-1]; {
+			case c > prev:
+				row.Advancers++
+			case c < prev:
+				row.Decliners++
+			default:
+				row.Unchanged++
+			}
+
+			if i >= marketBreadthNewHighLowDays {
+				window := s.closes[i-marketBreadthNewHighLowDays : i]
+				if c > slices.Max(window) {
+					row.NewHighs++
+				} else if c < slices.Min(window) {
+					row.NewLows++
+				}
+			}
+
+			for k, n := range marketBreadthMAPeriods {
+				if i+1 < n {
+					continue
+				}
+				eligible[k]++
+				if c*float64(n) > sumFloat(s.closes[i+1-n:i+1]) {
+					above[k]++
+				}
+			}
+		}
+
+		row.AboveMA25Pct = breadthPercent(above[0], eligible[0])
+		row.AboveMA75Pct = breadthPercent(above[1], eligible[1])
+		row.AboveMA200Pct = breadthPercent(above[2], eligible[2])
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// ChainMarketBreadth 同じ market の保存済みの直前の行 prev（date 昇順）に続けて、rows（date 昇順）の
+// A/D ライン・騰落レシオ・マクレラン・オシレーターを算出する。
+// prev が空なら rows の先頭日を起点とし、EMA は先頭日の純騰落数で初期化する。騰落レシオには prev の直近24営業日分も使う。
+func ChainMarketBreadth(prev, rows []*models.MarketBreadth) {
+	history := make([]*models.MarketBreadth, 0, len(prev)+len(rows))
+	history = append(history, prev...)
+	for _, row := range rows {
+		net := int64(row.Advancers - row.Decliners)
+		netDec := decimal.NewFromInt(net)
+		if len(history) == 0 {
+			row.ADLine = net
+			row.AdvanceEMA19 = netDec
+			row.AdvanceEMA39 = netDec
+		} else {
+			last := history[len(history)-1]
+			row.ADLine = last.ADLine + net
+			row.AdvanceEMA19 = last.AdvanceEMA19.Add(netDec.Sub(last.AdvanceEMA19).Mul(mcclellanFastK)).Round(4)
+			row.AdvanceEMA39 = last.AdvanceEMA39.Add(netDec.Sub(last.AdvanceEMA39).Mul(mcclellanSlowK)).Round(4)
+		}
+		row.McClellanOscillator = row.AdvanceEMA19.Sub(row.AdvanceEMA39).Round(2)
+
+		history = append(history, row)
+		row.ADRatio25 = advanceDeclineRatio(history)
+	}
+}
+
+// advanceDeclineRatio history 末尾25営業日の騰落レシオ。
+func advanceDeclineRatio(history []*models.MarketBreadth) *decimal.Decimal {
+	if len(history) < marketBreadthADRatioDays {
+		return nil
+	}
+	advancers, decliners := 0, 0
+	for _, r := range history[len(history)-marketBreadthADRatioDays:] {
+		advancers += r.Advancers
+		decliners += r.Decliners
+	}
+	if decliners == 0 {
+		return nil
+	}
+	v := decimal.NewFromInt(int64(advancers) * 100).Div(decimal.NewFromInt(int64(decliners))).Round(2)
+	return &v
+}
+
+func breadthPercent(count, total int) *decimal.Decimal {
+	if total == 0 {
+		return nil
+	}
+	v := decimal.NewFromInt(int64(count) * 100).Div(decimal.NewFromInt(int64(total))).Round(2)
+	return &v
+}
+
+func sumFloat(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
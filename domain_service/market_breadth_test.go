@@ -0,0 +1,97 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// breadthPrices 2024-01-01 から start 日目以降に調整後終値 closes が並ぶ日足。
+func breadthPrices(start int, closes ...float64) []*models.StockBrandDailyPrice {
+	prices := pricesFromCloses(closes...)
+	for _, p := range prices {
+		p.Date = p.Date.AddDate(0, 0, start)
+		p.Adjclose = p.Close
+	}
+	return prices
+}
+
+// flatThenLast 100 が n-1 本続き、末尾だけ last の終値。
+func flatThenLast(n int, last float64) []float64 {
+	closes := make([]float64, n)
+	for i := range closes {
+		closes[i] = 100
+	}
+	closes[n-1] = last
+	return closes
+}
+
+func TestCountMarketBreadth(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	date := base.AddDate(0, 0, 250)
+
+	rising := make([]float64, 30)
+	for i := range rising {
+		rising[i] = 100 + float64(i)
+	}
+	prices := [][]*models.StockBrandDailyPrice{
+		breadthPrices(0, flatThenLast(251, 110)...), // 値上がり・新高値・全移動平均線の上
+		breadthPrices(0, flatThenLast(251, 90)...),  // 値下がり・新安値
+		breadthPrices(0, flatThenLast(251, 100)...), // 変わらず（移動平均線と同値は上回らない）
+		breadthPrices(221, rising...),               // 値上がり・25日線のみ判定対象
+		breadthPrices(0, flatThenLast(100, 120)...), // 当日の日足が無い
+	}
+
+	rows := CountMarketBreadth("111", prices, []time.Time{base, date})
+	require.Len(t, rows, 2)
+
+	// 初日は前営業日が無いので数えない
+	assert.Equal(t, "111", rows[0].Market)
+	assert.Zero(t, rows[0].Advancers+rows[0].Decliners+rows[0].Unchanged)
+	assert.Nil(t, rows[0].AboveMA25Pct)
+
+	got := rows[1]
+	assert.Equal(t, date, got.Date)
+	assert.Equal(t, 2, got.Advancers)
+	assert.Equal(t, 1, got.Decliners)
+	assert.Equal(t, 1, got.Unchanged)
+	assert.Equal(t, 1, got.NewHighs)
+	assert.Equal(t, 1, got.NewLows)
+	assert.Equal(t, "50", got.AboveMA25Pct.String())
+	assert.Equal(t, "33.33", got.AboveMA75Pct.String())
+	assert.Equal(t, "33.33", got.AboveMA200Pct.String())
+}
+
+func TestChainMarketBreadth(t *testing.T) {
+	t.Run("起点から累積し25日目から騰落レシオを出す", func(t *testing.T) {
+		rows := make([]*models.MarketBreadth, 0, 25)
+		for range 25 {
+			rows = append(rows, &models.MarketBreadth{Advancers: 2, Decliners: 1})
+		}
+		ChainMarketBreadth(nil, rows)
+
+		assert.Equal(t, int64(1), rows[0].ADLine)
+		assert.Equal(t, int64(25), rows[24].ADLine)
+		assert.Nil(t, rows[23].ADRatio25)
+		require.NotNil(t, rows[24].ADRatio25)
+		assert.Equal(t, "200", rows[24].ADRatio25.String())
+		assert.True(t, rows[24].McClellanOscillator.IsZero())
+	})
+
+	t.Run("保存済みの直前行から EMA を継続する", func(t *testing.T) {
+		prev := []*models.MarketBreadth{{ADLine: 10, AdvanceEMA19: decimal.Zero, AdvanceEMA39: decimal.Zero}}
+		rows := []*models.MarketBreadth{{Advancers: 11, Decliners: 1}}
+		ChainMarketBreadth(prev, rows)
+
+		assert.Equal(t, int64(20), rows[0].ADLine)
+		assert.Equal(t, "1", rows[0].AdvanceEMA19.String())
+		assert.Equal(t, "0.5", rows[0].AdvanceEMA39.String())
+		assert.Equal(t, "0.5", rows[0].McClellanOscillator.String())
+		assert.Nil(t, rows[0].ADRatio25)
+	})
+}
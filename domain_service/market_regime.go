@@ -0,0 +1,161 @@
+package domain_service
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+const (
+	// marketRegimeShortMA / marketRegimeLongMA トレンド判定に使う移動平均の期間。
+	marketRegimeShortMA = 25
+	marketRegimeLongMA  = 75
+	// marketRegimeSlopeDays 長期移動平均の傾きを測る営業日数。
+	marketRegimeSlopeDays = 20
+	// marketRegimeATRPeriod ボラティリティ判定の ATR 期間。
+	marketRegimeATRPeriod = 14
+	// marketRegimeVolatilityBaselineDays ATR 比率の平常水準（平均）を取る営業日数。
+	marketRegimeVolatilityBaselineDays = 250
+)
+
+// MarketRegimeMinBars 1日の局面判定に必要な TOPIX の本数（75日移動平均とその20営業日前の値）。
+const MarketRegimeMinBars = marketRegimeLongMA + marketRegimeSlopeDays
+
+var (
+	// marketRegimeHighVolatilityMultiple ATR 比率が平常水準のこの倍率以上なら高ボラティリティ。
+	marketRegimeHighVolatilityMultiple = decimal.RequireFromString("1.5")
+	// marketRegimeBreadthNeutral 75日線を上回る銘柄比率の中立値(%)。上昇トレンドはこれ以上、下降トレンドはこれ以下を求める。
+	marketRegimeBreadthNeutral = decimal.NewFromInt(50)
+)
+
+// ClassifyMarketRegimes TOPIX 日足（date 昇順）の各日について市場局面を判定する。判定に必要な本数に満たない日は返さない。
+//   - high_volatility: TOPIX の ATR(14)/終値 が直近250営業日平均の1.5倍以上
+//   - trend_up: 終値 > 25日線 > 75日線 かつ 75日線が20営業日前より上
+//   - trend_down: 終値 < 25日線 < 75日線 かつ 75日線が20営業日前より下
+//   - range: 上記以外
+//
+// 同じ日の日経平均（75日線との位置）と騰落指標（75日線を上回る銘柄比率）があれば、トレンドの確認に使い、食い違えば range とする。
+func ClassifyMarketRegimes(topix, nikkei models.IndexStockAverageDailyPrices, breadth []*models.MarketBreadth) []*models.MarketRegime {
+	n := len(topix)
+	if n < MarketRegimeMinBars {
+		return nil
+	}
+
+	prices := indexToDailyPrices(topix)
+	closes := ExtractClosePrices(prices)
+	ma25 := smaSeries(closes, marketRegimeShortMA)
+	ma75 := smaSeries(closes, marketRegimeLongMA)
+	atrRatios := atrRatioSeries(prices)
+
+	nikkeiAbove := indexAboveLongMA(nikkei)
+	breadthByDay := make(map[string]*decimal.Decimal, len(breadth))
+	for _, b := range breadth {
+		if b.AboveMA75Pct != nil {
+			breadthByDay[b.Date.Format(util.DateLayout)] = b.AboveMA75Pct
+		}
+	}
+
+	regimes := make([]*models.MarketRegime, 0, n-MarketRegimeMinBars+1)
+	for i := MarketRegimeMinBars - 1; i < n; i++ {
+		day := topix[i].Date.Format(util.DateLayout)
+		r := &models.MarketRegime{
+			Date:                topix[i].Date,
+			TopixClose:          closes[i],
+			TopixMA25:           ma25[i].Round(4),
+			TopixMA75:           ma75[i].Round(4),
+			ATRRatio:            atrRatios[i].Round(6),
+			ATRRatioBaseline:    atrRatioBaseline(atrRatios, i).Round(6),
+			BreadthAboveMA75Pct: breadthByDay[day],
+		}
+		if above, ok := nikkeiAbove[day]; ok {
+			r.NikkeiAboveMA75 = &above
+		}
+		if prev := ma75[i-marketRegimeSlopeDays]; !prev.IsZero() {
+			r.TopixMA75Slope = ma75[i].Div(prev).Sub(decimal.NewFromInt(1)).Round(6)
+		}
+		r.Regime = classifyMarketRegime(r, ma25[i], ma75[i])
+		regimes = append(regimes, r)
+	}
+	return regimes
+}
+
+func classifyMarketRegime(r *models.MarketRegime, ma25, ma75 decimal.Decimal) string {
+	if !r.ATRRatioBaseline.IsZero() && r.ATRRatio.GreaterThanOrEqual(r.ATRRatioBaseline.Mul(marketRegimeHighVolatilityMultiple)) {
+		return models.MarketRegimeHighVolatility
+	}
+
+	up := r.TopixClose.GreaterThan(ma25) && ma25.GreaterThan(ma75) && r.TopixMA75Slope.IsPositive() &&
+		(r.NikkeiAboveMA75 == nil || *r.NikkeiAboveMA75) &&
+		(r.BreadthAboveMA75Pct == nil || r.BreadthAboveMA75Pct.GreaterThanOrEqual(marketRegimeBreadthNeutral))
+	if up {
+		return models.MarketRegimeTrendUp
+	}
+
+	down := r.TopixClose.LessThan(ma25) && ma25.LessThan(ma75) && r.TopixMA75Slope.IsNegative() &&
+		(r.NikkeiAboveMA75 == nil || !*r.NikkeiAboveMA75) &&
+		(r.BreadthAboveMA75Pct == nil || r.BreadthAboveMA75Pct.LessThanOrEqual(marketRegimeBreadthNeutral))
+	if down {
+		return models.MarketRegimeTrendDown
+	}
+	return models.MarketRegimeRange
+}
+
+// atrRatioSeries 各日の ATR(14) / 終値。ATR 未確定の日は Zero。
+func atrRatioSeries(prices []*models.StockBrandDailyPrice) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(prices))
+	atr := CalculateATR(prices, marketRegimeATRPeriod)
+	if atr == nil {
+		return out
+	}
+	for i := marketRegimeATRPeriod; i < len(prices); i++ {
+		if !prices[i].Close.IsZero() {
+			out[i] = atr[i].Div(prices[i].Close)
+		}
+	}
+	return out
+}
+
+// atrRatioBaseline i 以前の直近250営業日（ATR 確定後のみ）の ATR 比率の平均。
+func atrRatioBaseline(atrRatios []decimal.Decimal, i int) decimal.Decimal {
+	start := max(marketRegimeATRPeriod, i-marketRegimeVolatilityBaselineDays+1)
+	if start > i {
+		return decimal.Zero
+	}
+	sum := decimal.Zero
+	for _, v := range atrRatios[start : i+1] {
+		sum = sum.Add(v)
+	}
+	return sum.Div(decimal.NewFromInt(int64(i - start + 1)))
+}
+
+// indexAboveLongMA 指数の各日（75日線が確定した日）について終値が75日線を上回るか。
+func indexAboveLongMA(index models.IndexStockAverageDailyPrices) map[string]bool {
+	closes := make([]decimal.Decimal, len(index))
+	for i, p := range index {
+		closes[i] = p.Close
+	}
+	ma := smaSeries(closes, marketRegimeLongMA)
+	out := make(map[string]bool, len(index))
+	for i := marketRegimeLongMA - 1; i < len(index); i++ {
+		out[index[i].Date.Format(util.DateLayout)] = closes[i].GreaterThan(ma[i])
+	}
+	return out
+}
+
+// indexToDailyPrices 指数日足を個別銘柄の日足の形に詰め替える（ATR など既存の指標計算を使い回すため）。
+func indexToDailyPrices(index models.IndexStockAverageDailyPrices) []*models.StockBrandDailyPrice {
+	out := make([]*models.StockBrandDailyPrice, 0, len(index))
+	for _, p := range index {
+		out = append(out, &models.StockBrandDailyPrice{
+			Date:     p.Date,
+			Open:     p.Open,
+			High:     p.High,
+			Low:      p.Low,
+			Close:    p.Close,
+			Adjclose: p.Adjclose,
+			Volume:   p.Volume,
+		})
+	}
+	return out
+}
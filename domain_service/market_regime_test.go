@@ -0,0 +1,85 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// regimeIndex 2024-01-01 から n 日分、終値が start から step ずつ動き高値・安値が ±5 の指数日足。
+func regimeIndex(n int, start, step float64) models.IndexStockAverageDailyPrices {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make(models.IndexStockAverageDailyPrices, 0, n)
+	for i := range n {
+		c := decimal.NewFromFloat(start + step*float64(i))
+		out = append(out, &models.IndexStockAverageDailyPrice{
+			Date:  base.AddDate(0, 0, i),
+			Open:  c,
+			High:  c.Add(decimal.NewFromInt(5)),
+			Low:   c.Sub(decimal.NewFromInt(5)),
+			Close: c,
+		})
+	}
+	return out
+}
+
+func TestClassifyMarketRegimes(t *testing.T) {
+	const n = 120
+
+	t.Run("本数不足なら判定しない", func(t *testing.T) {
+		assert.Nil(t, ClassifyMarketRegimes(regimeIndex(MarketRegimeMinBars-1, 1000, 2), nil, nil))
+	})
+
+	t.Run("上昇が続けば trend_up", func(t *testing.T) {
+		got := ClassifyMarketRegimes(regimeIndex(n, 1000, 2), regimeIndex(n, 30000, 50), nil)
+		require.Len(t, got, n-MarketRegimeMinBars+1)
+		last := got[len(got)-1]
+		assert.Equal(t, models.MarketRegimeTrendUp, last.Regime)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, n-1), last.Date)
+		assert.True(t, last.TopixMA75Slope.IsPositive())
+		require.NotNil(t, last.NikkeiAboveMA75)
+		assert.True(t, *last.NikkeiAboveMA75)
+		assert.Nil(t, last.BreadthAboveMA75Pct)
+	})
+
+	t.Run("下落が続けば trend_down", func(t *testing.T) {
+		got := ClassifyMarketRegimes(regimeIndex(n, 2000, -2), nil, nil)
+		require.NotEmpty(t, got)
+		last := got[len(got)-1]
+		assert.Equal(t, models.MarketRegimeTrendDown, last.Regime)
+		assert.Nil(t, last.NikkeiAboveMA75)
+	})
+
+	t.Run("値幅が急拡大した日は high_volatility", func(t *testing.T) {
+		topix := regimeIndex(n, 1000, 2)
+		last := topix[n-1]
+		last.High = last.Close.Add(decimal.NewFromInt(100))
+		last.Low = last.Close.Sub(decimal.NewFromInt(100))
+
+		got := ClassifyMarketRegimes(topix, nil, nil)
+		require.NotEmpty(t, got)
+		assert.Equal(t, models.MarketRegimeHighVolatility, got[len(got)-1].Regime)
+		assert.Equal(t, models.MarketRegimeTrendUp, got[len(got)-2].Regime)
+	})
+
+	t.Run("日経平均や騰落指標と食い違えば range", func(t *testing.T) {
+		topix := regimeIndex(n, 1000, 2)
+		lastDay := topix[n-1].Date
+
+		got := ClassifyMarketRegimes(topix, regimeIndex(n, 40000, -50), nil)
+		require.NotEmpty(t, got)
+		assert.Equal(t, models.MarketRegimeRange, got[len(got)-1].Regime)
+
+		weak := decimal.NewFromInt(30)
+		got = ClassifyMarketRegimes(topix, nil, []*models.MarketBreadth{{Date: lastDay, AboveMA75Pct: &weak}})
+		require.NotEmpty(t, got)
+		assert.Equal(t, models.MarketRegimeRange, got[len(got)-1].Regime)
+		assert.Equal(t, "30", got[len(got)-1].BreadthAboveMA75Pct.String())
+		assert.Equal(t, models.MarketRegimeTrendUp, got[len(got)-2].Regime)
+	})
+}
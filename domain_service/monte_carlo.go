@@ -0,0 +1,182 @@
+package domain_service
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// monteCarloTopTradesExcluded ReturnWithoutTopTrades で除外する上位トレード数。
+const monteCarloTopTradesExcluded = 2
+
+// tradePathStats トレード列を順に複利で積み上げたときの指標。
+type tradePathStats struct {
+	totalReturn     float64
+	maxDrawdown     float64 // 負値
+	maxLosingStreak int
+	ruined          bool
+}
+
+// evalTradePath returns を順に適用したエクイティ（初期 1.0）の指標を返す。
+// ruinLevel 以下のエクイティに一度でも達したら ruined。
+func evalTradePath(returns []float64, ruinLevel float64) tradePathStats {
+	var s tradePathStats
+	equity, peak := 1.0, 1.0
+	streak := 0
+	for _, r := range returns {
+		equity *= 1 + r
+		if equity > peak {
+			peak = equity
+		}
+		if dd := equity/peak - 1; dd < s.maxDrawdown {
+			s.maxDrawdown = dd
+		}
+		if equity <= ruinLevel {
+			s.ruined = true
+		}
+		if r < 0 {
+			streak++
+			if streak > s.maxLosingStreak {
+				s.maxLosingStreak = streak
+			}
+		} else {
+			streak = 0
+		}
+	}
+	s.totalReturn = equity - 1
+	return s
+}
+
+// RunMonteCarlo トレードリストのリターンをブートストラップ（復元抽出）と順序入れ替えで
+// iterations 回ずつ再標本化し、トータルリターン・最大ドローダウン・最大連敗数の分布と破産確率を返す。
+// 試行回数が多いため内部計算は float64 で行い、結果のみ decimal に丸める。
+// 順序入れ替えではリターンの集合が変わらないため、トータルリターンの分布は1点に縮退する（ドローダウンと連敗の評価用）。
+func RunMonteCarlo(trades []models.BacktestTrade, params models.BacktestMonteCarloParams, rng *rand.Rand) models.BacktestMonteCarlo {
+	returns := make([]float64, len(trades))
+	for i, t := range trades {
+		returns[i] = t.Return.InexactFloat64()
+	}
+	ruinLevel := 1 - params.RuinThreshold.InexactFloat64()
+	original := evalTradePath(returns, ruinLevel)
+
+	result := models.BacktestMonteCarlo{
+		MonteCarlo:              params,
+		Trades:                  len(trades),
+		OriginalTotalReturn:     decimal.NewFromFloat(original.totalReturn).Round(6),
+		OriginalMaxDrawdown:     decimal.NewFromFloat(original.maxDrawdown).Round(6),
+		OriginalMaxLosingStreak: original.maxLosingStreak,
+		ReturnWithoutTopTrades:  decimal.NewFromFloat(returnWithoutTopTrades(returns, monteCarloTopTradesExcluded)).Round(6),
+		TopTradesExcluded:       monteCarloTopTradesExcluded,
+	}
+
+	sample := make([]float64, len(returns))
+	result.Bootstrap = simulateMonteCarlo(models.MonteCarloMethodBootstrap, params.Iterations, len(returns), original.totalReturn, func() tradePathStats {
+		for i := range sample {
+			sample[i] = returns[rng.Intn(len(returns))]
+		}
+		return evalTradePath(sample, ruinLevel)
+	})
+	result.Permutation = simulateMonteCarlo(models.MonteCarloMethodPermutation, params.Iterations, len(returns), original.totalReturn, func() tradePathStats {
+		copy(sample, returns)
+		rng.Shuffle(len(sample), func(i, j int) { sample[i], sample[j] = sample[j], sample[i] })
+		return evalTradePath(sample, ruinLevel)
+	})
+	return result
+}
+
+// simulateMonteCarlo trial を iterations 回実行して分布を集計する。トレードが無ければ空の結果。
+func simulateMonteCarlo(method string, iterations, trades int, originalReturn float64, trial func() tradePathStats) models.MonteCarloSimulation {
+	sim := models.MonteCarloSimulation{Method: method}
+	if trades == 0 || iterations <= 0 {
+		return sim
+	}
+
+	totals := make([]float64, iterations)
+	drawdowns := make([]float64, iterations)
+	streaks := make([]float64, iterations)
+	losses, ruins := 0, 0
+	for i := 0; i < iterations; i++ {
+		s := trial()
+		totals[i] = s.totalReturn
+		drawdowns[i] = s.maxDrawdown
+		streaks[i] = float64(s.maxLosingStreak)
+		if s.totalReturn < 0 {
+			losses++
+		}
+		if s.ruined {
+			ruins++
+		}
+	}
+
+	n := float64(iterations)
+	sim.TotalReturn = summarizeDistribution(totals)
+	sim.MaxDrawdown = summarizeDistribution(drawdowns)
+	sim.MaxLosingStreak = summarizeDistribution(streaks)
+	sim.ProbabilityOfLoss = decimal.NewFromFloat(float64(losses) / n).Round(4)
+	sim.RiskOfRuin = decimal.NewFromFloat(float64(ruins) / n).Round(4)
+
+	// summarizeDistribution で totals は昇順に並んでいる
+	below := sort.SearchFloat64s(totals, originalReturn)
+	sim.OriginalPercentile = decimal.NewFromFloat(float64(below) / n).Round(4)
+	return sim
+}
+
+// summarizeDistribution xs を昇順に並べ替え、平均・標本標準偏差・パーセンタイルを返す。
+func summarizeDistribution(xs []float64) models.MonteCarloDistribution {
+	sort.Float64s(xs)
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	m := sum / float64(len(xs))
+	sd := 0.0
+	if len(xs) > 1 {
+		ss := 0.0
+		for _, x := range xs {
+			ss += (x - m) * (x - m)
+		}
+		sd = math.Sqrt(ss / float64(len(xs)-1))
+	}
+	round := func(f float64) decimal.Decimal { return decimal.NewFromFloat(f).Round(6) }
+	return models.MonteCarloDistribution{
+		Mean:   round(m),
+		StdDev: round(sd),
+		Min:    round(xs[0]),
+		P5:     round(percentileSorted(xs, 0.05)),
+		P25:    round(percentileSorted(xs, 0.25)),
+		P50:    round(percentileSorted(xs, 0.50)),
+		P75:    round(percentileSorted(xs, 0.75)),
+		P95:    round(percentileSorted(xs, 0.95)),
+		Max:    round(xs[len(xs)-1]),
+	}
+}
+
+// percentileSorted 昇順ソート済み xs の p 分位点（線形補間）。
+func percentileSorted(xs []float64, p float64) float64 {
+	if len(xs) == 1 {
+		return xs[0]
+	}
+	pos := p * float64(len(xs)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return xs[lo] + (xs[hi]-xs[lo])*(pos-float64(lo))
+}
+
+// returnWithoutTopTrades リターン上位 k 件（勝ちトレードのみ）を除いた複利トータルリターン。
+func returnWithoutTopTrades(returns []float64, k int) float64 {
+	sorted := make([]float64, len(returns))
+	copy(sorted, returns)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+	equity := 1.0
+	for i, r := range sorted {
+		if i < k && r > 0 {
+			continue
+		}
+		equity *= 1 + r
+	}
+	return equity - 1
+}
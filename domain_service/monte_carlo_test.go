@@ -0,0 +1,93 @@
+package domain_service
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func tradesFromReturns(returns ...float64) []models.BacktestTrade {
+	trades := make([]models.BacktestTrade, len(returns))
+	for i, r := range returns {
+		trades[i] = models.BacktestTrade{Return: decimal.NewFromFloat(r)}
+	}
+	return trades
+}
+
+func TestRunMonteCarlo(t *testing.T) {
+	params := models.BacktestMonteCarloParams{Iterations: 500, RuinThreshold: decimal.NewFromFloat(0.5)}
+
+	t.Run("実際の順序の指標と上位トレード除外リターン", func(t *testing.T) {
+		trades := tradesFromReturns(0.10, -0.05, -0.05, 0.50, -0.05)
+		got := RunMonteCarlo(trades, params, rand.New(rand.NewSource(1)))
+
+		assert.Equal(t, 5, got.Trades)
+		assert.Equal(t, 2, got.OriginalMaxLosingStreak)
+		// 1.1 × 0.95 × 0.95 × 1.5 × 0.95 - 1
+		assert.InDelta(t, 0.414669, f64FromDec(got.OriginalTotalReturn), 1e-6)
+		// ピーク 1.1 → 1.1×0.95×0.95
+		assert.InDelta(t, -0.0975, f64FromDec(got.OriginalMaxDrawdown), 1e-6)
+		// 0.50 と 0.10 を除外: 0.95^3 - 1
+		assert.InDelta(t, -0.142625, f64FromDec(got.ReturnWithoutTopTrades), 1e-6)
+	})
+
+	t.Run("順序入れ替えはトータルリターンが不変で連敗数が変化する", func(t *testing.T) {
+		trades := tradesFromReturns(0.10, -0.05, -0.05, 0.50, -0.05)
+		got := RunMonteCarlo(trades, params, rand.New(rand.NewSource(1)))
+
+		p := got.Permutation
+		assert.Equal(t, models.MonteCarloMethodPermutation, p.Method)
+		assert.InDelta(t, f64FromDec(got.OriginalTotalReturn), f64FromDec(p.TotalReturn.Min), 1e-6)
+		assert.InDelta(t, f64FromDec(got.OriginalTotalReturn), f64FromDec(p.TotalReturn.Max), 1e-6)
+		assert.InDelta(t, 1.0, f64FromDec(p.MaxLosingStreak.Min), 1e-9)
+		assert.InDelta(t, 3.0, f64FromDec(p.MaxLosingStreak.Max), 1e-9)
+		assert.True(t, p.ProbabilityOfLoss.IsZero())
+	})
+
+	t.Run("ブートストラップは分布が広がり分位点が単調", func(t *testing.T) {
+		trades := tradesFromReturns(0.10, -0.05, -0.05, 0.50, -0.05)
+		got := RunMonteCarlo(trades, params, rand.New(rand.NewSource(1)))
+
+		b := got.Bootstrap
+		assert.True(t, b.TotalReturn.Min.LessThan(b.TotalReturn.Max))
+		assert.True(t, b.TotalReturn.P5.LessThanOrEqual(b.TotalReturn.P50))
+		assert.True(t, b.TotalReturn.P50.LessThanOrEqual(b.TotalReturn.P95))
+		assert.True(t, b.ProbabilityOfLoss.GreaterThan(decimal.Zero))
+		assert.True(t, b.OriginalPercentile.GreaterThan(decimal.Zero))
+	})
+
+	t.Run("大きな損失が続くと破産確率が立つ", func(t *testing.T) {
+		trades := tradesFromReturns(-0.30, -0.30, -0.01)
+		got := RunMonteCarlo(trades, params, rand.New(rand.NewSource(1)))
+
+		// どの順序でも最終的に 0.7×0.7×0.99 ≤ 0.5 に達するため、順序入れ替えでは必ず破産
+		assert.InDelta(t, 1.0, f64FromDec(got.Permutation.RiskOfRuin), 1e-9)
+		assert.True(t, got.Bootstrap.RiskOfRuin.GreaterThan(decimal.Zero))
+	})
+
+	t.Run("取引0件は空の分布", func(t *testing.T) {
+		got := RunMonteCarlo(nil, params, rand.New(rand.NewSource(1)))
+		assert.Equal(t, 0, got.Trades)
+		assert.True(t, got.Bootstrap.TotalReturn.Mean.IsZero())
+		assert.Equal(t, models.MonteCarloMethodBootstrap, got.Bootstrap.Method)
+	})
+
+	t.Run("同じシードで同じ結果", func(t *testing.T) {
+		trades := tradesFromReturns(0.10, -0.05, 0.02, 0.50, -0.08)
+		a := RunMonteCarlo(trades, params, rand.New(rand.NewSource(42)))
+		b := RunMonteCarlo(trades, params, rand.New(rand.NewSource(42)))
+		assert.Equal(t, a, b)
+	})
+}
+
+func TestPercentileSorted(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5}
+	assert.InDelta(t, 3.0, percentileSorted(xs, 0.5), 1e-9)
+	assert.InDelta(t, 1.2, percentileSorted(xs, 0.05), 1e-9)
+	assert.InDelta(t, 5.0, percentileSorted(xs, 1), 1e-9)
+	assert.InDelta(t, 7.0, percentileSorted([]float64{7}, 0.95), 1e-9)
+}
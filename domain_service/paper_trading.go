@@ -0,0 +1,399 @@
+package domain_service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// PaperTradingLotSize 模擬売買の売買単位（株）。
+const PaperTradingLotSize = 100
+
+// paperTradingWeeklyTopN 週次通知で売却の上位・下位それぞれに並べる件数。
+const paperTradingWeeklyTopN = 3
+
+// PaperTradingQuantity 予算内で買える最大株数を売買単位の倍数で返す（1単位も買えなければ 0）。
+func PaperTradingQuantity(budget, price decimal.Decimal) int {
+	if !budget.IsPositive() || !price.IsPositive() {
+		return 0
+	}
+	lots := budget.Div(price.Mul(decimalFromInt(PaperTradingLotSize))).Floor().IntPart()
+	return int(lots) * PaperTradingLotSize
+}
+
+// PaperExit 手仕舞いの約定単価と理由。
+type PaperExit struct {
+	Price  decimal.Decimal
+	Reason string
+}
+
+// EvaluatePaperExit bar の日にポジションを手仕舞うかを判定する。pos.HeldDays は bar の日を含めた保有営業日数であること。
+// 寄りで利確・損切りラインを跨いでいれば始値、場中に触れればライン価格で約定したとみなす。
+// 日足では高値・安値の順序が分からないため、両方に触れた日は損切りを優先する（保守的に見積もる）。
+// どちらにも掛からず保有営業日数に達したら終値で売却する。手仕舞わないなら nil。
+func EvaluatePaperExit(pos *models.PaperPosition, bar *models.StockBrandDailyPrice, account *models.PaperAccount) *PaperExit {
+	one := decimal.NewFromInt(1)
+	if account.StopLossPct != nil {
+		stop := pos.EntryPrice.Mul(one.Sub(*account.StopLossPct)).Round(4)
+		if bar.Open.LessThanOrEqual(stop) {
+			return &PaperExit{Price: bar.Open, Reason: models.PaperExitReasonStopLoss}
+		}
+		if bar.Low.LessThanOrEqual(stop) {
+			return &PaperExit{Price: stop, Reason: models.PaperExitReasonStopLoss}
+		}
+	}
+	if account.TakeProfitPct != nil {
+		target := pos.EntryPrice.Mul(one.Add(*account.TakeProfitPct)).Round(4)
+		if bar.Open.GreaterThanOrEqual(target) {
+			return &PaperExit{Price: bar.Open, Reason: models.PaperExitReasonTakeProfit}
+		}
+		if bar.High.GreaterThanOrEqual(target) {
+			return &PaperExit{Price: target, Reason: models.PaperExitReasonTakeProfit}
+		}
+	}
+	if pos.HeldDays >= account.HoldingDays {
+		return &PaperExit{Price: bar.Close, Reason: models.PaperExitReasonHoldingPeriod}
+	}
+	return nil
+}
+
+// PaperTradingDay 1営業日ぶんの模擬売買の結果。
+type PaperTradingDay struct {
+	// Opened この日に買い付けたポジション（ID は未採番。同日に手仕舞ったものは closed で入る）
+	Opened []*models.PaperPosition
+	// Updated 前日までに持っていたポジション（値洗い・手仕舞いを反映済み）
+	Updated []*models.PaperPosition
+	Nav     *models.PaperNav
+	// Skipped 対象の推奨のうち買えなかった件数（売買停止・予算不足・資金不足・同一銘柄を保有中）
+	Skipped int
+}
+
+// StepPaperTradingDay 前日引け時点の現金・累計確定損益・保有ポジションから、day の1日ぶんの模擬売買を進める。
+// picks は day の前営業日の推奨、bars は day の日足（銘柄コード → バー）、corporateActions は day に分割・併合が適用された銘柄コード。
+// 寄りで推奨の上位 TopN を前日引けの現金の範囲で買い付け（同日の売却代金は当てにしない）、その後に全ポジションの手仕舞いを判定し、
+// 残ったポジションを終値で値洗いして NAV を出す。日足が無い（売買停止の）ポジションは保有日数を進めず前日の評価額のまま据え置く。
+// 分割・併合が適用された銘柄は株数が変わり以後の日足と取得単価が比べられないため、前日の評価額で手仕舞う。
+func StepPaperTradingDay(
+	account *models.PaperAccount,
+	day time.Time,
+	cash, realizedPnL decimal.Decimal,
+	open []*models.PaperPosition,
+	picks []*models.DailyStockPick,
+	bars map[string]*models.StockBrandDailyPrice,
+	corporateActions map[string]bool,
+) *PaperTradingDay {
+	result := &PaperTradingDay{Updated: open}
+
+	held := make(map[string]bool, len(open))
+	for _, p := range open {
+		held[p.TickerSymbol] = true
+	}
+	sorted := slices.Clone(picks)
+	slices.SortStableFunc(sorted, func(a, b *models.DailyStockPick) int { return a.PickRank - b.PickRank })
+	for _, pick := range sorted {
+		if pick.PickRank > account.TopN {
+			break
+		}
+		bar := bars[pick.TickerSymbol]
+		if held[pick.TickerSymbol] || bar == nil {
+			result.Skipped++
+			continue
+		}
+		qty := PaperTradingQuantity(account.BudgetPerPick, bar.Open)
+		cost := bar.Open.Mul(decimalFromInt(qty))
+		if qty == 0 || cost.GreaterThan(cash) {
+			result.Skipped++
+			continue
+		}
+		cash = cash.Sub(cost)
+		held[pick.TickerSymbol] = true
+		result.Opened = append(result.Opened, &models.PaperPosition{
+			Account:      account.Name,
+			PickDate:     pick.PickDate,
+			StockBrandID: pick.StockBrandID,
+			TickerSymbol: pick.TickerSymbol,
+			PickRank:     pick.PickRank,
+			Status:       models.PaperPositionStatusOpen,
+			EntryDate:    day,
+			EntryPrice:   bar.Open,
+			Quantity:     qty,
+			MarkDate:     day,
+			MarkPrice:    bar.Open,
+		})
+	}
+
+	closePosition := func(p *models.PaperPosition, price decimal.Decimal, reason string) {
+		exitDate := day
+		p.Status = models.PaperPositionStatusClosed
+		p.ExitDate = &exitDate
+		p.ExitPrice = &price
+		p.ExitReason = &reason
+		p.MarkDate = day
+		p.MarkPrice = price
+		cash = cash.Add(p.MarketValue())
+		realizedPnL = realizedPnL.Add(p.PnL())
+	}
+
+	marketValue := decimal.Zero
+	openCount := 0
+	for _, p := range slices.Concat(open, result.Opened) {
+		bar := bars[p.TickerSymbol]
+		switch {
+		case corporateActions[p.TickerSymbol] && p.EntryDate.Before(day):
+			closePosition(p, p.MarkPrice, models.PaperExitReasonCorporateAction)
+		case bar == nil:
+			// 売買停止等。評価額は前日のまま据え置く。
+		default:
+			p.HeldDays++
+			if exit := EvaluatePaperExit(p, bar, account); exit != nil {
+				closePosition(p, exit.Price, exit.Reason)
+			} else {
+				p.MarkDate = day
+				p.MarkPrice = bar.Close
+			}
+		}
+		if p.Open() {
+			marketValue = marketValue.Add(p.MarketValue())
+			openCount++
+		}
+	}
+
+	result.Nav = &models.PaperNav{
+		Account:       account.Name,
+		Date:          day,
+		Cash:          cash,
+		MarketValue:   marketValue,
+		Nav:           cash.Add(marketValue),
+		OpenPositions: openCount,
+		RealizedPnL:   realizedPnL,
+	}
+	return result
+}
+
+// PaperBuyFill ポジションの買付約定。
+func PaperBuyFill(p *models.PaperPosition) *models.PaperFill {
+	return &models.PaperFill{
+		Account:      p.Account,
+		PositionID:   p.ID,
+		FillDate:     p.EntryDate,
+		TickerSymbol: p.TickerSymbol,
+		Side:         models.PaperFillSideBuy,
+		Quantity:     p.Quantity,
+		Price:        p.EntryPrice,
+		Amount:       p.CostBasis(),
+	}
+}
+
+// PaperSellFill 手仕舞い済みポジションの売却約定。未決済なら nil。
+func PaperSellFill(p *models.PaperPosition) *models.PaperFill {
+	if p.Open() || p.ExitDate == nil || p.ExitPrice == nil {
+		return nil
+	}
+	reason := ""
+	if p.ExitReason != nil {
+		reason = *p.ExitReason
+	}
+	return &models.PaperFill{
+		Account:      p.Account,
+		PositionID:   p.ID,
+		FillDate:     *p.ExitDate,
+		TickerSymbol: p.TickerSymbol,
+		Side:         models.PaperFillSideSell,
+		Quantity:     p.Quantity,
+		Price:        *p.ExitPrice,
+		Amount:       p.MarketValue(),
+		Reason:       reason,
+	}
+}
+
+// PaperPositionItemOf 表示用に損益と取得金額比リターンを付ける。
+func PaperPositionItemOf(p *models.PaperPosition, name string) *models.PaperPositionItem {
+	item := &models.PaperPositionItem{PaperPosition: p, Name: name, ProfitLoss: p.PnL()}
+	if cost := p.CostBasis(); cost.IsPositive() {
+		item.Return = item.ProfitLoss.Div(cost).Round(6)
+	}
+	return item
+}
+
+// SummarizePaperPortfolio 期間内の NAV（date 昇順）と保有中・期間内に売却したポジションから成績サマリを作る。
+// NAV が1件も無い（未稼働の）口座は初期資金だけのサマリを返す。
+func SummarizePaperPortfolio(
+	account *models.PaperAccount,
+	navs []*models.PaperNav,
+	open, closed []*models.PaperPositionItem,
+) *models.PaperPortfolioSummary {
+	s := &models.PaperPortfolioSummary{
+		Nav:           account.InitialCash,
+		Cash:          account.InitialCash,
+		OpenPositions: len(open),
+		ClosedTrades:  len(closed),
+	}
+	if len(navs) > 0 {
+		last := navs[len(navs)-1]
+		asOf := util.DatetimeToDateStr(last.Date)
+		s.AsOf = &asOf
+		s.Nav = last.Nav
+		s.Cash = last.Cash
+		s.MarketValue = last.MarketValue
+		s.RealizedPnL = last.RealizedPnL
+
+		values := make([]decimal.Decimal, 0, len(navs))
+		for _, n := range navs {
+			values = append(values, n.Nav)
+		}
+		s.MaxDrawdown = MaxDrawdown(values).Neg().Round(6)
+	}
+	if account.InitialCash.IsPositive() {
+		s.TotalReturn = s.Nav.Div(account.InitialCash).Sub(decimal.NewFromInt(1)).Round(6)
+	}
+	for _, p := range open {
+		s.UnrealizedPnL = s.UnrealizedPnL.Add(p.ProfitLoss)
+	}
+	if len(closed) > 0 {
+		wins := 0
+		sum := decimal.Zero
+		for _, p := range closed {
+			if p.ProfitLoss.IsPositive() {
+				wins++
+			}
+			sum = sum.Add(p.Return)
+		}
+		s.WinRate = decimalFromInt(wins).Div(decimalFromInt(len(closed))).Round(4)
+		s.AvgReturn = sum.Div(decimalFromInt(len(closed))).Round(6)
+	}
+	return s
+}
+
+// BuildPaperPortfolioWeeklyReport weekFrom〜最新の NAV の1週間ぶんの損益をまとめる。
+// navs は date 昇順で、weekFrom より前の NAV（週初の基準）を含めてよい。週初の基準が無ければ初期資金を基準にする。
+// NAV が1件も無ければ nil。
+func BuildPaperPortfolioWeeklyReport(
+	account *models.PaperAccount,
+	navs []*models.PaperNav,
+	weekFrom time.Time,
+	bought int,
+	closed []*models.PaperPositionItem,
+	open []*models.PaperPositionItem,
+) *models.PaperPortfolioWeeklyReport {
+	if len(navs) == 0 {
+		return nil
+	}
+	last := navs[len(navs)-1]
+	start := account.InitialCash
+	for _, n := range navs {
+		if !n.Date.Before(weekFrom) {
+			break
+		}
+		start = n.Nav
+	}
+
+	r := &models.PaperPortfolioWeeklyReport{
+		Account:       account.Name,
+		From:          weekFrom,
+		To:            last.Date,
+		StartNav:      start,
+		EndNav:        last.Nav,
+		WeekPnL:       last.Nav.Sub(start),
+		Bought:        bought,
+		Closed:        slices.Clone(closed),
+		OpenPositions: len(open),
+	}
+	if start.IsPositive() {
+		r.WeekReturn = r.WeekPnL.Div(start).Round(6)
+	}
+	if account.InitialCash.IsPositive() {
+		r.TotalReturn = last.Nav.Div(account.InitialCash).Sub(decimal.NewFromInt(1)).Round(6)
+	}
+	for _, p := range open {
+		r.UnrealizedPnL = r.UnrealizedPnL.Add(p.ProfitLoss)
+	}
+	slices.SortStableFunc(r.Closed, func(a, b *models.PaperPositionItem) int { return b.ProfitLoss.Cmp(a.ProfitLoss) })
+	return r
+}
+
+// FormatPaperPortfolioWeeklyMessage 週次損益を Slack の親タイトル＋本文に整形する。
+// title は SendMessageByStrings 側で *%s* に包まれるため、自分で強調記号を付けない。
+func FormatPaperPortfolioWeeklyMessage(r *models.PaperPortfolioWeeklyReport) (title, body string) {
+	title = fmt.Sprintf(
+		"模擬売買 週次損益 %s〜%s [%s] / 週 %s円 (%s%%)",
+		util.DatetimeToDateStr(r.From),
+		util.DatetimeToDateStr(r.To),
+		escapeSlackText(r.Account),
+		formatSignedYen(r.WeekPnL),
+		formatSignedPercent(r.WeekReturn),
+	)
+
+	wins := 0
+	realized := decimal.Zero
+	for _, p := range r.Closed {
+		if p.ProfitLoss.IsPositive() {
+			wins++
+		}
+		realized = realized.Add(p.ProfitLoss)
+	}
+
+	lines := []string{
+		fmt.Sprintf("NAV %s円（週初 %s円 / 開始来 %s%%）", formatDecimalComma(r.EndNav), formatDecimalComma(r.StartNav), formatSignedPercent(r.TotalReturn)),
+		fmt.Sprintf("買付 %d件 / 売却 %d件（勝ち %d・負け %d、確定損益 %s円）", r.Bought, len(r.Closed), wins, len(r.Closed)-wins, formatSignedYen(realized)),
+		fmt.Sprintf("保有中 %d件 / 評価損益 %s円", r.OpenPositions, formatSignedYen(r.UnrealizedPnL)),
+	}
+
+	n := min(paperTradingWeeklyTopN, len(r.Closed))
+	if n > 0 {
+		lines = append(lines, "", "*売却の上位*")
+		for _, p := range r.Closed[:n] {
+			lines = append(lines, formatPaperPositionLine(p))
+		}
+	}
+	if len(r.Closed) > n {
+		m := min(paperTradingWeeklyTopN, len(r.Closed)-n)
+		lines = append(lines, "", "*売却の下位*")
+		for i := len(r.Closed) - 1; i >= len(r.Closed)-m; i-- {
+			lines = append(lines, formatPaperPositionLine(r.Closed[i]))
+		}
+	}
+	return title, strings.Join(lines, "\n")
+}
+
+func formatPaperPositionLine(p *models.PaperPositionItem) string {
+	name := ""
+	if p.Name != "" {
+		name = " " + escapeSlackText(p.Name)
+	}
+	reason := ""
+	if p.ExitReason != nil {
+		reason = " " + PaperExitReasonLabel(*p.ExitReason)
+	}
+	return fmt.Sprintf("`%s`%s %s円 (%s%%)%s", p.TickerSymbol, name, formatSignedYen(p.ProfitLoss), formatSignedPercent(p.Return), reason)
+}
+
+// PaperExitReasonLabel 手仕舞い理由の表示名（未知の値はそのまま返す）。
+func PaperExitReasonLabel(reason string) string {
+	switch reason {
+	case models.PaperExitReasonHoldingPeriod:
+		return "期間満了"
+	case models.PaperExitReasonTakeProfit:
+		return "利確"
+	case models.PaperExitReasonStopLoss:
+		return "損切り"
+	case models.PaperExitReasonCorporateAction:
+		return "分割・併合"
+	default:
+		return reason
+	}
+}
+
+// formatSignedYen 金額を符号付き・3桁区切りの円表記（例: "+12,300"）にする。
+func formatSignedYen(d decimal.Decimal) string {
+	s := formatDecimalComma(d)
+	if strings.HasPrefix(s, "-") || s == "0" {
+		return s
+	}
+	return "+" + s
+}
@@ -0,0 +1,284 @@
+package domain_service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func paperTestAccount() *models.PaperAccount {
+	tp := decimal.RequireFromString("0.10")
+	sl := decimal.RequireFromString("0.05")
+	return &models.PaperAccount{
+		Name:          "default",
+		ScoreVersion:  DailyPickScoreVersion,
+		TopN:          2,
+		BudgetPerPick: decimal.NewFromInt(500_000),
+		HoldingDays:   5,
+		TakeProfitPct: &tp,
+		StopLossPct:   &sl,
+		InitialCash:   decimal.NewFromInt(1_000_000),
+	}
+}
+
+func paperTestBar(symbol string, open, high, low, close int64) *models.StockBrandDailyPrice {
+	return &models.StockBrandDailyPrice{
+		TickerSymbol: symbol,
+		Open:         decimal.NewFromInt(open),
+		High:         decimal.NewFromInt(high),
+		Low:          decimal.NewFromInt(low),
+		Close:        decimal.NewFromInt(close),
+	}
+}
+
+func paperTestPosition(symbol string, entry int64, heldDays int) *models.PaperPosition {
+	return &models.PaperPosition{
+		Account:      "default",
+		TickerSymbol: symbol,
+		Status:       models.PaperPositionStatusOpen,
+		EntryDate:    time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+		EntryPrice:   decimal.NewFromInt(entry),
+		Quantity:     100,
+		HeldDays:     heldDays,
+		MarkPrice:    decimal.NewFromInt(entry),
+	}
+}
+
+func TestPaperTradingQuantity(t *testing.T) {
+	assert.Equal(t, 400, PaperTradingQuantity(decimal.NewFromInt(500_000), decimal.NewFromInt(1200)))
+	assert.Equal(t, 100, PaperTradingQuantity(decimal.NewFromInt(500_000), decimal.NewFromInt(5000)), "ちょうど予算内")
+	assert.Equal(t, 0, PaperTradingQuantity(decimal.NewFromInt(500_000), decimal.NewFromInt(5001)), "1単位も買えない")
+	assert.Equal(t, 0, PaperTradingQuantity(decimal.NewFromInt(500_000), decimal.Zero))
+}
+
+func TestEvaluatePaperExit(t *testing.T) {
+	account := paperTestAccount()
+
+	tests := []struct {
+		name       string
+		heldDays   int
+		bar        *models.StockBrandDailyPrice
+		wantPrice  string
+		wantReason string
+	}{
+		{"寄りで損切りラインを割ったら始値", 2, paperTestBar("1000", 900, 950, 880, 940), "900", models.PaperExitReasonStopLoss},
+		{"場中に損切りラインに触れたらライン価格", 2, paperTestBar("1000", 990, 1000, 940, 960), "950", models.PaperExitReasonStopLoss},
+		{"利確と損切りの両方に触れた日は損切りを優先", 2, paperTestBar("1000", 1000, 1120, 940, 1050), "950", models.PaperExitReasonStopLoss},
+		{"寄りで利確ラインを超えたら始値", 2, paperTestBar("1000", 1150, 1200, 1140, 1180), "1150", models.PaperExitReasonTakeProfit},
+		{"場中に利確ラインに触れたらライン価格", 2, paperTestBar("1000", 1010, 1110, 1000, 1080), "1100", models.PaperExitReasonTakeProfit},
+		{"保有営業日数に達したら終値", 5, paperTestBar("1000", 1010, 1030, 990, 1020), "1020", models.PaperExitReasonHoldingPeriod},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exit := EvaluatePaperExit(paperTestPosition("1000", 1000, tt.heldDays), tt.bar, account)
+			if assert.NotNil(t, exit) {
+				assert.Equal(t, tt.wantPrice, exit.Price.String())
+				assert.Equal(t, tt.wantReason, exit.Reason)
+			}
+		})
+	}
+
+	t.Run("どの条件にも掛からなければnil", func(t *testing.T) {
+		assert.Nil(t, EvaluatePaperExit(paperTestPosition("1000", 1000, 2), paperTestBar("1000", 1010, 1030, 990, 1020), account))
+	})
+	t.Run("利確・損切りを使わない口座は期間満了だけ", func(t *testing.T) {
+		a := paperTestAccount()
+		a.TakeProfitPct, a.StopLossPct = nil, nil
+		assert.Nil(t, EvaluatePaperExit(paperTestPosition("1000", 1000, 2), paperTestBar("1000", 500, 2000, 400, 1000), a))
+	})
+}
+
+func TestStepPaperTradingDay(t *testing.T) {
+	day := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	pickDate := day.AddDate(0, 0, -1)
+	pick := func(rank int, symbol string) *models.DailyStockPick {
+		return &models.DailyStockPick{PickDate: pickDate, PickRank: rank, TickerSymbol: symbol, StockBrandID: "b" + symbol}
+	}
+
+	t.Run("上位TopNを寄りで買い、保有中は手仕舞い判定して終値で値洗いする", func(t *testing.T) {
+		account := paperTestAccount()
+		held := paperTestPosition("2000", 1000, 1)
+		expiring := paperTestPosition("3000", 1000, 4)
+		picks := []*models.DailyStockPick{pick(3, "5000"), pick(2, "2000"), pick(1, "1000")}
+		bars := map[string]*models.StockBrandDailyPrice{
+			"1000": paperTestBar("1000", 1200, 1250, 1190, 1240),
+			"2000": paperTestBar("2000", 1010, 1050, 1000, 1040),
+			"3000": paperTestBar("3000", 1000, 1020, 980, 1010),
+			"5000": paperTestBar("5000", 100, 100, 100, 100),
+		}
+
+		got := StepPaperTradingDay(account, day, decimal.NewFromInt(1_000_000), decimal.NewFromInt(5000),
+			[]*models.PaperPosition{held, expiring}, picks, bars, nil)
+
+		// 1位だけ買う（2位は保有中の銘柄、3位は TopN 外）
+		if assert.Len(t, got.Opened, 1) {
+			p := got.Opened[0]
+			assert.Equal(t, "1000", p.TickerSymbol)
+			assert.Equal(t, 400, p.Quantity, "500,000円 / 1,200円 → 400株")
+			assert.Equal(t, "1200", p.EntryPrice.String())
+			assert.Equal(t, 1, p.HeldDays)
+			assert.Equal(t, "1240", p.MarkPrice.String())
+			assert.True(t, p.Open())
+		}
+		assert.Equal(t, 1, got.Skipped)
+
+		assert.Equal(t, 2, held.HeldDays)
+		assert.Equal(t, "1040", held.MarkPrice.String())
+		assert.False(t, expiring.Open(), "5営業日目で期間満了")
+		assert.Equal(t, models.PaperExitReasonHoldingPeriod, *expiring.ExitReason)
+		assert.Equal(t, "1010", expiring.ExitPrice.String())
+
+		// 現金: 1,000,000 - 480,000（買付）+ 101,000（売却）
+		assert.Equal(t, "621000", got.Nav.Cash.String())
+		// 評価額: 1,240*400 + 1,040*100
+		assert.Equal(t, "600000", got.Nav.MarketValue.String())
+		assert.Equal(t, "1221000", got.Nav.Nav.String())
+		assert.Equal(t, 2, got.Nav.OpenPositions)
+		assert.Equal(t, "6000", got.Nav.RealizedPnL.String(), "5,000 + 売却益 1,000")
+	})
+
+	t.Run("売買停止・資金不足の推奨は買わず、売買停止の保有は据え置く", func(t *testing.T) {
+		account := paperTestAccount()
+		suspended := paperTestPosition("2000", 1000, 1)
+		picks := []*models.DailyStockPick{pick(1, "1000"), pick(2, "3000")}
+		bars := map[string]*models.StockBrandDailyPrice{
+			"3000": paperTestBar("3000", 1000, 1000, 1000, 1000),
+		}
+
+		got := StepPaperTradingDay(account, day, decimal.NewFromInt(100_000), decimal.Zero,
+			[]*models.PaperPosition{suspended}, picks, bars, nil)
+
+		assert.Empty(t, got.Opened)
+		assert.Equal(t, 2, got.Skipped)
+		assert.Equal(t, 1, suspended.HeldDays, "日足が無い日は保有日数を進めない")
+		assert.Equal(t, "200000", got.Nav.Nav.String())
+	})
+
+	t.Run("分割・併合が適用された保有は前日の評価額で手仕舞う", func(t *testing.T) {
+		account := paperTestAccount()
+		p := paperTestPosition("2000", 1000, 2)
+		p.MarkPrice = decimal.NewFromInt(1100)
+		bars := map[string]*models.StockBrandDailyPrice{"2000": paperTestBar("2000", 550, 560, 540, 555)}
+
+		got := StepPaperTradingDay(account, day, decimal.Zero, decimal.Zero,
+			[]*models.PaperPosition{p}, nil, bars, map[string]bool{"2000": true})
+
+		assert.False(t, p.Open())
+		assert.Equal(t, models.PaperExitReasonCorporateAction, *p.ExitReason)
+		assert.Equal(t, "1100", p.ExitPrice.String())
+		assert.Equal(t, "110000", got.Nav.Cash.String())
+		assert.Equal(t, "10000", got.Nav.RealizedPnL.String())
+	})
+}
+
+func TestPaperFills(t *testing.T) {
+	p := paperTestPosition("1000", 1000, 3)
+	p.ID = 7
+	buy := PaperBuyFill(p)
+	assert.Equal(t, models.PaperFillSideBuy, buy.Side)
+	assert.Equal(t, uint64(7), buy.PositionID)
+	assert.Equal(t, "100000", buy.Amount.String())
+	assert.Nil(t, PaperSellFill(p), "保有中は売却約定なし")
+
+	exitDate := p.EntryDate.AddDate(0, 0, 3)
+	price := decimal.NewFromInt(1100)
+	reason := models.PaperExitReasonTakeProfit
+	p.Status, p.ExitDate, p.ExitPrice, p.ExitReason, p.MarkPrice = models.PaperPositionStatusClosed, &exitDate, &price, &reason, price
+	sell := PaperSellFill(p)
+	if assert.NotNil(t, sell) {
+		assert.Equal(t, exitDate, sell.FillDate)
+		assert.Equal(t, "110000", sell.Amount.String())
+		assert.Equal(t, reason, sell.Reason)
+	}
+}
+
+func TestSummarizePaperPortfolio(t *testing.T) {
+	account := paperTestAccount()
+
+	t.Run("NAVが無ければ初期資金だけのサマリ", func(t *testing.T) {
+		s := SummarizePaperPortfolio(account, nil, nil, nil)
+		assert.Nil(t, s.AsOf)
+		assert.Equal(t, "1000000", s.Nav.String())
+		assert.True(t, s.TotalReturn.IsZero())
+	})
+
+	t.Run("最新NAVと売却済みから成績を出す", func(t *testing.T) {
+		d := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+		navs := []*models.PaperNav{
+			{Date: d, Nav: decimal.NewFromInt(1_000_000)},
+			{Date: d.AddDate(0, 0, 1), Nav: decimal.NewFromInt(1_100_000)},
+			{Date: d.AddDate(0, 0, 2), Nav: decimal.NewFromInt(990_000)},
+			{Date: d.AddDate(0, 0, 3), Nav: decimal.NewFromInt(1_050_000), Cash: decimal.NewFromInt(950_000), MarketValue: decimal.NewFromInt(100_000), RealizedPnL: decimal.NewFromInt(40_000)},
+		}
+		win := PaperPositionItemOf(paperTestPosition("1000", 1000, 5), "")
+		win.ProfitLoss, win.Return = decimal.NewFromInt(10_000), decimal.RequireFromString("0.1")
+		lose := PaperPositionItemOf(paperTestPosition("2000", 1000, 5), "")
+		lose.ProfitLoss, lose.Return = decimal.NewFromInt(-5_000), decimal.RequireFromString("-0.05")
+
+		s := SummarizePaperPortfolio(account, navs, nil, []*models.PaperPositionItem{win, lose})
+		assert.Equal(t, "2026-07-04", *s.AsOf)
+		assert.Equal(t, "1050000", s.Nav.String())
+		assert.Equal(t, "0.05", s.TotalReturn.String())
+		assert.Equal(t, "0.1", s.MaxDrawdown.String(), "1,100,000 → 990,000")
+		assert.Equal(t, "40000", s.RealizedPnL.String())
+		assert.Equal(t, 2, s.ClosedTrades)
+		assert.Equal(t, "0.5", s.WinRate.String())
+		assert.Equal(t, "0.025", s.AvgReturn.String())
+	})
+}
+
+func TestPaperPortfolioWeeklyReport(t *testing.T) {
+	account := paperTestAccount()
+	to := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	weekFrom := to.AddDate(0, 0, -6)
+	navs := []*models.PaperNav{
+		{Date: weekFrom.AddDate(0, 0, -1), Nav: decimal.NewFromInt(1_000_000)},
+		{Date: weekFrom.AddDate(0, 0, 2), Nav: decimal.NewFromInt(1_010_000)},
+		{Date: to, Nav: decimal.NewFromInt(1_020_000)},
+	}
+	closedItem := func(symbol, name string, pnl int64, reason string) *models.PaperPositionItem {
+		p := paperTestPosition(symbol, 1000, 5)
+		p.ExitReason = &reason
+		item := PaperPositionItemOf(p, name)
+		item.ProfitLoss = decimal.NewFromInt(pnl)
+		item.Return = decimal.NewFromInt(pnl).Div(decimal.NewFromInt(100_000))
+		return item
+	}
+	closed := []*models.PaperPositionItem{
+		closedItem("1000", "小幅", 1_000, models.PaperExitReasonHoldingPeriod),
+		closedItem("2000", "大勝", 10_000, models.PaperExitReasonTakeProfit),
+		closedItem("3000", "負け", -5_000, models.PaperExitReasonStopLoss),
+		closedItem("4000", "微益", 500, models.PaperExitReasonHoldingPeriod),
+	}
+
+	r := BuildPaperPortfolioWeeklyReport(account, navs, weekFrom, 4, closed, nil)
+	if !assert.NotNil(t, r) {
+		return
+	}
+	assert.Equal(t, "1000000", r.StartNav.String(), "週初より前の最新NAVが基準")
+	assert.Equal(t, "20000", r.WeekPnL.String())
+	assert.Len(t, r.Closed, 4)
+	assert.Equal(t, "0.02", r.WeekReturn.String())
+	assert.Equal(t, "2000", r.Closed[0].TickerSymbol, "損益の大きい順")
+
+	title, body := FormatPaperPortfolioWeeklyMessage(r)
+	assert.Equal(t, "模擬売買 週次損益 2026-07-04〜2026-07-10 [default] / 週 +20,000円 (+2.0%)", title)
+	assert.Contains(t, body, "NAV 1,020,000円（週初 1,000,000円 / 開始来 +2.0%）")
+	assert.Contains(t, body, "買付 4件 / 売却 4件（勝ち 3・負け 1、確定損益 +6,500円）")
+	assert.Contains(t, body, "`2000` 大勝 +10,000円 (+10.0%) 利確")
+	assert.Contains(t, body, "*売却の下位*")
+	assert.True(t, strings.HasSuffix(body, "`3000` 負け -5,000円 (-5.0%) 損切り"), "下位は最も悪いものだけ（上位と重複させない）")
+
+	t.Run("週初より前のNAVが無ければ初期資金が基準", func(t *testing.T) {
+		r := BuildPaperPortfolioWeeklyReport(account, navs[1:], weekFrom, 0, nil, nil)
+		assert.Equal(t, "1000000", r.StartNav.String())
+	})
+	t.Run("NAVが無ければnil", func(t *testing.T) {
+		assert.Nil(t, BuildPaperPortfolioWeeklyReport(account, nil, weekFrom, 0, nil, nil))
+	})
+}
@@ -0,0 +1,356 @@
+package domain_service
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// walkForwardMinBars 1窓（IS/OOS それぞれ）で検証に必要な最低営業日数。
+const walkForwardMinBars = 20
+
+// OptimizerSearchSpace イグジット条件の探索空間。シグナルパラメータの探索値は戦略登録簿の Params()（SearchValues）を使う。
+type OptimizerSearchSpace struct {
+	TakeProfits []decimal.Decimal
+	StopLosses  []decimal.Decimal
+	MaxHoldDays []int
+}
+
+// DefaultOptimizerSearchSpace 標準の探索空間（イグジット 5×3×4 = 60 通り。シグナルパラメータを持つ戦略はさらにその組み合わせ数倍）。
+func DefaultOptimizerSearchSpace() OptimizerSearchSpace {
+	return OptimizerSearchSpace{
+		TakeProfits: []decimal.Decimal{
+			decimal.RequireFromString("0.05"),
+			decimal.RequireFromString("0.08"),
+			decimal.RequireFromString("0.10"),
+			decimal.RequireFromString("0.15"),
+			decimal.RequireFromString("0.20"),
+		},
+		StopLosses: []decimal.Decimal{
+			decimal.RequireFromString("0.03"),
+			decimal.RequireFromString("0.05"),
+			decimal.RequireFromString("0.08"),
+		},
+		MaxHoldDays: []int{5, 10, 20, 40},
+	}
+}
+
+// OptimizerCandidate 評価する1組のパラメータ。
+type OptimizerCandidate struct {
+	Exit ExitParams
+	// Params 戦略のシグナルパラメータ（名前 → 値）。探索するパラメータを持たない戦略は nil
+	Params map[string]string
+}
+
+// signalKey エントリーシグナルのキャッシュキー（シグナルに影響するパラメータだけで決まる）。
+func (c OptimizerCandidate) signalKey() string {
+	names := slices.Sorted(maps.Keys(c.Params))
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+"="+c.Params[name])
+	}
+	return strings.Join(parts, "/")
+}
+
+// ToParamSet API 表示用のパラメータ組に変換する。
+func (c OptimizerCandidate) ToParamSet() models.OptimizationParamSet {
+	return models.OptimizationParamSet{
+		TakeProfit:  c.Exit.TakeProfit,
+		StopLoss:    c.Exit.StopLoss,
+		MaxHoldDays: c.Exit.MaxHoldDays,
+		Params:      maps.Clone(c.Params),
+	}
+}
+
+// GridOptimizerCandidates 探索空間と戦略の Params() の SearchValues の全組み合わせを返す。
+// 探索する利確・損切り・最大保有日数以外（コスト・売買方向・約定ルール）は cost をそのまま引き継ぐ。
+func GridOptimizerCandidates(strategy string, space OptimizerSearchSpace, cost ExitParams) []OptimizerCandidate {
+	paramSets := []map[string]string{nil}
+	if st, ok := LookupStrategy(strategy); ok {
+		for _, spec := range st.Params() {
+			if len(spec.SearchValues) == 0 {
+				continue
+			}
+			next := make([]map[string]string, 0, len(paramSets)*len(spec.SearchValues))
+			for _, base := range paramSets {
+				for _, v := range spec.SearchValues {
+					set := make(map[string]string, len(base)+1)
+					maps.Copy(set, base)
+					set[spec.Name] = v
+					next = append(next, set)
+				}
+			}
+			paramSets = next
+		}
+	}
+
+	candidates := make([]OptimizerCandidate, 0)
+	for _, params := range paramSets {
+		for _, tp := range space.TakeProfits {
+			for _, sl := range space.StopLosses {
+				for _, hold := range space.MaxHoldDays {
+					exit := cost
+					exit.TakeProfit = tp
+					exit.StopLoss = sl
+					exit.MaxHoldDays = hold
+					candidates = append(candidates, OptimizerCandidate{Exit: exit, Params: params})
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// RandomOptimizerCandidates グリッドから samples 件を非復元抽出する（グリッド件数以下ならグリッド全件）。
+// 同じ seed の rng なら同じ候補列になる。
+func RandomOptimizerCandidates(strategy string, space OptimizerSearchSpace, cost ExitParams, samples int, rng *rand.Rand) []OptimizerCandidate {
+	grid := GridOptimizerCandidates(strategy, space, cost)
+	if samples <= 0 || samples >= len(grid) {
+		return grid
+	}
+	rng.Shuffle(len(grid), func(i, j int) { grid[i], grid[j] = grid[j], grid[i] })
+	return grid[:samples]
+}
+
+// WalkForwardWindow インサンプル [InFrom, InTo) とアウトオブサンプル [OutFrom, OutTo) の組。
+type WalkForwardWindow struct {
+	InFrom  time.Time
+	InTo    time.Time
+	OutFrom time.Time
+	OutTo   time.Time
+}
+
+// BuildWalkForwardWindows from〜to をローリングする窓に分割する。
+// 各窓は IS inSampleMonths ヶ月 + 直後の OOS outOfSampleMonths ヶ月で、OOS の幅ずつ前進する
+// （OOS 同士は重ならない）。OOS 末尾が to を超える窓は作らない。
+func BuildWalkForwardWindows(from, to time.Time, inSampleMonths, outOfSampleMonths int) []WalkForwardWindow {
+	windows := make([]WalkForwardWindow, 0)
+	if inSampleMonths <= 0 || outOfSampleMonths <= 0 {
+		return windows
+	}
+	for start := from; ; start = start.AddDate(0, outOfSampleMonths, 0) {
+		inTo := start.AddDate(0, inSampleMonths, 0)
+		outTo := inTo.AddDate(0, outOfSampleMonths, 0)
+		if outTo.After(to.AddDate(0, 0, 1)) {
+			break
+		}
+		windows = append(windows, WalkForwardWindow{InFrom: start, InTo: inTo, OutFrom: inTo, OutTo: outTo})
+	}
+	return windows
+}
+
+// WalkForwardScore 1窓×1候補の銘柄横断集計。
+type WalkForwardScore struct {
+	InSum     decimal.Decimal
+	InCount   int
+	InTrades  int
+	OutSum    decimal.Decimal
+	OutCount  int
+	OutTrades int
+}
+
+// WalkForwardScores [窓][候補] の集計表。ワーカーごとに持ち、最後に Merge する。
+type WalkForwardScores [][]WalkForwardScore
+
+// NewWalkForwardScores 空の集計表を返す。
+func NewWalkForwardScores(windows, candidates int) WalkForwardScores {
+	s := make(WalkForwardScores, windows)
+	for w := range s {
+		s[w] = make([]WalkForwardScore, candidates)
+	}
+	return s
+}
+
+// Merge src の集計を加算する。decimal の総和は順序非依存なので並列集計でも結果は一致する。
+func (s WalkForwardScores) Merge(src WalkForwardScores) {
+	for w := range s {
+		for c := range s[w] {
+			d, o := &s[w][c], src[w][c]
+			d.InSum = d.InSum.Add(o.InSum)
+			d.InCount += o.InCount
+			d.InTrades += o.InTrades
+			d.OutSum = d.OutSum.Add(o.OutSum)
+			d.OutCount += o.OutCount
+			d.OutTrades += o.OutTrades
+		}
+	}
+}
+
+// AccumulateWalkForward 1銘柄の日足で全窓×全候補をバックテストし scores に加算する。
+// prices は最初の窓より戦略の WarmupBars 分以上前から渡す。窓より前の日足はシグナル計算のウォームアップにだけ使い、
+// 売買は窓の中だけで行う。エントリーシグナルは全期間で1度だけ計算してから窓で切り出す（指標は因果的なので
+// 先読みにならず、窓の先頭でもウォームアップ済みの値を使える）。集計のみ必要なので RunBacktestMetrics を使う。
+func AccumulateWalkForward(strategy string, prices []*models.StockBrandDailyPrice, candidates []OptimizerCandidate, windows []WalkForwardWindow, scores WalkForwardScores) {
+	signalCache := make(map[string][]bool)
+	signalsFor := func(c OptimizerCandidate) []bool {
+		key := c.signalKey()
+		if s, ok := signalCache[key]; ok {
+			return s
+		}
+		var s []bool
+		if st, ok := LookupStrategy(strategy); ok {
+			s = st.EntrySignalsWithParams(prices, c.Params)
+		} else {
+			s = EntrySignalsByStrategy(strategy, prices)
+		}
+		signalCache[key] = s
+		return s
+	}
+
+	for w, win := range windows {
+		inA, inB := priceIndexRange(prices, win.InFrom, win.InTo)
+		outA, outB := priceIndexRange(prices, win.OutFrom, win.OutTo)
+		if inB-inA < walkForwardMinBars || outB-outA < walkForwardMinBars {
+			continue
+		}
+		for c, cand := range candidates {
+			signals := signalsFor(cand)
+			in := RunBacktestMetrics(prices[inA:inB], signals[inA:inB], nil, cand.Exit)
+			out := RunBacktestMetrics(prices[outA:outB], signals[outA:outB], nil, cand.Exit)
+			sc := &scores[w][c]
+			sc.InSum = sc.InSum.Add(in.TotalReturn)
+			sc.InCount++
+			sc.InTrades += in.Trades
+			sc.OutSum = sc.OutSum.Add(out.TotalReturn)
+			sc.OutCount++
+			sc.OutTrades += out.Trades
+		}
+	}
+}
+
+// priceIndexRange 日付昇順の日足から [from, to) に入る添字範囲 [a, b) を返す。
+func priceIndexRange(prices []*models.StockBrandDailyPrice, from, to time.Time) (int, int) {
+	a := sort.Search(len(prices), func(i int) bool { return !prices[i].Date.Before(from) })
+	b := sort.Search(len(prices), func(i int) bool { return !prices[i].Date.Before(to) })
+	return a, b
+}
+
+// SummarizeWalkForward 窓ごとに IS 平均リターン最大の候補を選び、その OOS 成績・パラメータの安定性・
+// OOS 劣化を集計する。result の Windows / Stability / MostFrequent / 平均 / 劣化を埋める。
+func SummarizeWalkForward(candidates []OptimizerCandidate, windows []WalkForwardWindow, scores WalkForwardScores, result *models.StrategyOptimizationResult) {
+	result.Windows = []models.WalkForwardWindowResult{}
+	result.Stability = []models.ParameterStability{}
+	result.AvgInSampleReturn = decimal.Zero
+	result.AvgOutOfSampleReturn = decimal.Zero
+	result.Degradation = decimal.Zero
+	result.Efficiency = decimal.Zero
+	result.MostFrequentRate = decimal.Zero
+
+	chosen := make([]OptimizerCandidate, 0, len(windows))
+	chosenCount := make(map[int]int)
+	sumIn, sumOut := decimal.Zero, decimal.Zero
+	for w, win := range windows {
+		best := -1
+		var bestAvg decimal.Decimal
+		for c := range candidates {
+			sc := scores[w][c]
+			if sc.InCount == 0 {
+				continue
+			}
+			avg := sc.InSum.Div(decimal.NewFromInt(int64(sc.InCount)))
+			if best < 0 || avg.GreaterThan(bestAvg) {
+				best, bestAvg = c, avg
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		sc := scores[w][best]
+		outAvg := decimal.Zero
+		if sc.OutCount > 0 {
+			outAvg = sc.OutSum.Div(decimal.NewFromInt(int64(sc.OutCount)))
+		}
+		result.Windows = append(result.Windows, models.WalkForwardWindowResult{
+			InSampleFrom:         win.InFrom.Format(util.DateLayout),
+			InSampleTo:           win.InTo.AddDate(0, 0, -1).Format(util.DateLayout),
+			OutOfSampleFrom:      win.OutFrom.Format(util.DateLayout),
+			OutOfSampleTo:        win.OutTo.AddDate(0, 0, -1).Format(util.DateLayout),
+			Best:                 candidates[best].ToParamSet(),
+			InSampleAvgReturn:    bestAvg.Round(6),
+			OutOfSampleAvgReturn: outAvg.Round(6),
+			InSampleTrades:       sc.InTrades,
+			OutOfSampleTrades:    sc.OutTrades,
+			StockCount:           sc.InCount,
+		})
+		chosen = append(chosen, candidates[best])
+		chosenCount[best]++
+		sumIn = sumIn.Add(bestAvg)
+		sumOut = sumOut.Add(outAvg)
+	}
+	if len(chosen) == 0 {
+		return
+	}
+
+	n := decimal.NewFromInt(int64(len(chosen)))
+	result.AvgInSampleReturn = sumIn.Div(n).Round(6)
+	result.AvgOutOfSampleReturn = sumOut.Div(n).Round(6)
+	result.Degradation = result.AvgOutOfSampleReturn.Sub(result.AvgInSampleReturn).Round(6)
+	if result.AvgInSampleReturn.IsPositive() {
+		result.Efficiency = result.AvgOutOfSampleReturn.Div(result.AvgInSampleReturn).Round(6)
+	}
+
+	// 最頻パラメータ（同数なら候補順で先のもの）
+	mostIdx, mostCount := -1, 0
+	for c := range candidates {
+		if cnt := chosenCount[c]; cnt > mostCount {
+			mostIdx, mostCount = c, cnt
+		}
+	}
+	ps := candidates[mostIdx].ToParamSet()
+	result.MostFrequent = &ps
+	result.MostFrequentRate = decimal.NewFromInt(int64(mostCount)).Div(n).Round(4)
+
+	result.Stability = append(result.Stability,
+		parameterStability("takeProfit", chosen, func(c OptimizerCandidate) decimal.Decimal { return c.Exit.TakeProfit }),
+		parameterStability("stopLoss", chosen, func(c OptimizerCandidate) decimal.Decimal { return c.Exit.StopLoss }),
+		parameterStability("maxHoldDays", chosen, func(c OptimizerCandidate) decimal.Decimal { return decimal.NewFromInt(int64(c.Exit.MaxHoldDays)) }),
+	)
+	// シグナルパラメータは数値として解釈できるものだけ安定性を測る（名前順）
+	for _, name := range slices.Sorted(maps.Keys(chosen[0].Params)) {
+		if _, err := decimal.NewFromString(chosen[0].Params[name]); err != nil {
+			continue
+		}
+		result.Stability = append(result.Stability, parameterStability("params."+name, chosen, func(c OptimizerCandidate) decimal.Decimal {
+			v, _ := decimal.NewFromString(c.Params[name])
+			return v
+		}))
+	}
+}
+
+// parameterStability 窓ごとに選ばれた値の平均・標準偏差（標本）・変動係数・最小・最大を返す。
+func parameterStability(name string, chosen []OptimizerCandidate, value func(OptimizerCandidate) decimal.Decimal) models.ParameterStability {
+	values := make([]decimal.Decimal, 0, len(chosen))
+	minV, maxV := value(chosen[0]), value(chosen[0])
+	for _, c := range chosen {
+		v := value(c)
+		values = append(values, v)
+		if v.LessThan(minV) {
+			minV = v
+		}
+		if v.GreaterThan(maxV) {
+			maxV = v
+		}
+	}
+	m := mean(values)
+	sd := stdDevSample(values)
+	cv := decimal.Zero
+	if !m.IsZero() {
+		cv = sd.Div(m.Abs())
+	}
+	return models.ParameterStability{
+		Param:                  name,
+		Mean:                   m.Round(6),
+		StdDev:                 sd.Round(6),
+		CoefficientOfVariation: cv.Round(6),
+		Min:                    minV,
+		Max:                    maxV,
+	}
+}
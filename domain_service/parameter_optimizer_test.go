@@ -0,0 +1,141 @@
+package domain_service
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestGridOptimizerCandidates(t *testing.T) {
+	space := DefaultOptimizerSearchSpace()
+	cost := ExitParams{CommissionRate: decimal.NewFromFloat(0.0005)}
+
+	t.Run("イグジットのみの戦略は 5×3×4 通り", func(t *testing.T) {
+		got := GridOptimizerCandidates(StrategyMACDBullish, space, cost)
+		assert.Len(t, got, 60)
+		assert.Nil(t, got[0].Params)
+		assert.True(t, got[0].Exit.CommissionRate.Equal(cost.CommissionRate))
+	})
+
+	t.Run("探索するパラメータ以外の約定ルールは cost をそのまま引き継ぐ", func(t *testing.T) {
+		exec := ExitParams{
+			TakeProfit:       decimal.NewFromFloat(0.5),
+			CommissionRate:   decimal.NewFromFloat(0.0005),
+			EntryTiming:      models.EntryTimingNextOpen,
+			ExitFill:         models.ExitFillIntrabar,
+			IntrabarPriority: models.IntrabarPriorityTakeProfit,
+			TickRounding:     true,
+			Side:             models.PositionSideShort,
+			BorrowRate:       decimal.NewFromFloat(0.00003),
+		}
+		got := GridOptimizerCandidates(StrategyMACDBullish, space, exec)
+		want := exec
+		want.TakeProfit = space.TakeProfits[0]
+		want.StopLoss = space.StopLosses[0]
+		want.MaxHoldDays = space.MaxHoldDays[0]
+		assert.Equal(t, want, got[0].Exit)
+	})
+
+	t.Run("登録簿の Params() の探索値も掛け合わせる", func(t *testing.T) {
+		got := GridOptimizerCandidates(StrategyTriangleFormation, space, cost)
+		assert.Len(t, got, 480)
+		assert.Equal(t, map[string]string{"window": "40", "rangeContractionRatio": "0.7", "volumeMultiplier": "1.2"}, got[0].Params)
+		assert.Equal(t, map[string]string{"window": "60", "rangeContractionRatio": "0.8", "volumeMultiplier": "1.5"}, got[len(got)-1].Params)
+		assert.Equal(t, got[0].Params, got[0].ToParamSet().Params)
+	})
+
+	t.Run("ランダム探索は同じシードで同じ候補", func(t *testing.T) {
+		a := RandomOptimizerCandidates(StrategyMACDBullish, space, cost, 10, rand.New(rand.NewSource(42)))
+		b := RandomOptimizerCandidates(StrategyMACDBullish, space, cost, 10, rand.New(rand.NewSource(42)))
+		assert.Len(t, a, 10)
+		assert.Equal(t, a, b)
+	})
+}
+
+func TestBuildWalkForwardWindows(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	got := BuildWalkForwardWindows(from, to, 12, 6)
+	assert.Len(t, got, 2)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), got[0].OutFrom)
+	assert.Equal(t, time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC), got[0].OutTo)
+	assert.Equal(t, time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), got[1].InFrom)
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), got[1].OutTo)
+
+	assert.Empty(t, BuildWalkForwardWindows(from, to, 0, 6))
+}
+
+func TestSummarizeWalkForward(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	windows := []WalkForwardWindow{
+		{InFrom: base, InTo: base.AddDate(1, 0, 0), OutFrom: base.AddDate(1, 0, 0), OutTo: base.AddDate(1, 6, 0)},
+		{InFrom: base.AddDate(0, 6, 0), InTo: base.AddDate(1, 6, 0), OutFrom: base.AddDate(1, 6, 0), OutTo: base.AddDate(2, 0, 0)},
+	}
+	candidates := []OptimizerCandidate{
+		{Exit: ExitParams{TakeProfit: decimal.NewFromFloat(0.05), StopLoss: decimal.NewFromFloat(0.03), MaxHoldDays: 10}},
+		{Exit: ExitParams{TakeProfit: decimal.NewFromFloat(0.10), StopLoss: decimal.NewFromFloat(0.05), MaxHoldDays: 20}},
+	}
+	scores := NewWalkForwardScores(len(windows), len(candidates))
+	// 窓0: 候補1が IS 最良（0.2）→ OOS 0.1
+	scores[0][0] = WalkForwardScore{InSum: decimal.NewFromFloat(0.1), InCount: 1, OutSum: decimal.NewFromFloat(0.05), OutCount: 1}
+	scores[0][1] = WalkForwardScore{InSum: decimal.NewFromFloat(0.2), InCount: 1, OutSum: decimal.NewFromFloat(0.1), OutCount: 1}
+	// 窓1: 候補1が IS 最良（0.1）→ OOS -0.1
+	scores[1][0] = WalkForwardScore{InSum: decimal.NewFromFloat(0.0), InCount: 1, OutSum: decimal.Zero, OutCount: 1}
+	scores[1][1] = WalkForwardScore{InSum: decimal.NewFromFloat(0.1), InCount: 1, OutSum: decimal.NewFromFloat(-0.1), OutCount: 1}
+
+	other := NewWalkForwardScores(len(windows), len(candidates))
+	other.Merge(scores)
+
+	var result models.StrategyOptimizationResult
+	SummarizeWalkForward(candidates, windows, other, &result)
+
+	assert.Len(t, result.Windows, 2)
+	assert.Equal(t, "2020-12-31", result.Windows[0].InSampleTo)
+	assert.Equal(t, 20, result.Windows[0].Best.MaxHoldDays)
+	assert.InDelta(t, 0.15, f64FromDec(result.AvgInSampleReturn), 1e-9)
+	assert.InDelta(t, 0.0, f64FromDec(result.AvgOutOfSampleReturn), 1e-9)
+	assert.InDelta(t, -0.15, f64FromDec(result.Degradation), 1e-9)
+	assert.InDelta(t, 1.0, f64FromDec(result.MostFrequentRate), 1e-9)
+	assert.Len(t, result.Stability, 3)
+	assert.InDelta(t, 0.0, f64FromDec(result.Stability[0].StdDev), 1e-9)
+
+	t.Run("シグナルパラメータも名前順に安定性を測る", func(t *testing.T) {
+		withParams := []OptimizerCandidate{
+			{Exit: candidates[0].Exit, Params: map[string]string{"window": "40", "volumeMultiplier": "1.2"}},
+			{Exit: candidates[1].Exit, Params: map[string]string{"window": "60", "volumeMultiplier": "1.5"}},
+		}
+		var got models.StrategyOptimizationResult
+		SummarizeWalkForward(withParams, windows, scores, &got)
+		require.Len(t, got.Stability, 5)
+		assert.Equal(t, "params.volumeMultiplier", got.Stability[3].Param)
+		assert.Equal(t, "params.window", got.Stability[4].Param)
+		assert.Equal(t, map[string]string{"window": "60", "volumeMultiplier": "1.5"}, got.MostFrequent.Params)
+	})
+}
+
+func TestAccumulateWalkForward(t *testing.T) {
+	closes := make([]float64, 0, 120)
+	for i := 0; i < 120; i++ {
+		closes = append(closes, 100+float64(i%10))
+	}
+	prices := pricesFromCloses(closes...)
+	from := prices[0].Date
+	windows := []WalkForwardWindow{{
+		InFrom: from, InTo: from.AddDate(0, 0, 60), OutFrom: from.AddDate(0, 0, 60), OutTo: from.AddDate(0, 0, 120),
+	}}
+	candidates := GridOptimizerCandidates(StrategyMovingAverageCross, DefaultOptimizerSearchSpace(), ExitParams{})
+	scores := NewWalkForwardScores(len(windows), len(candidates))
+
+	AccumulateWalkForward(StrategyMovingAverageCross, prices, candidates, windows, scores)
+	for c := range candidates {
+		assert.Equal(t, 1, scores[0][c].InCount)
+		assert.Equal(t, 1, scores[0][c].OutCount)
+	}
+}
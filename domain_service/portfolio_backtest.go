@@ -0,0 +1,374 @@
+package domain_service
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// portfolioRankWindow 優先順位スコア（出来高倍率・売買代金・モメンタム）の算出期間。
+const portfolioRankWindow = 20
+
+// PortfolioSeries ポートフォリオに投入する1銘柄分の日足とシグナル。
+// EntrySignals / ExitSignals は Prices と同じ長さ。ExitSignals は nil 可（共通ルールのみ）。
+type PortfolioSeries struct {
+	TickerSymbol string
+	Prices       []*models.StockBrandDailyPrice
+	EntrySignals []bool
+	ExitSignals  []bool
+}
+
+// PortfolioParams ポートフォリオシミュレーションの資金・枠・優先順位設定。
+type PortfolioParams struct {
+	InitialCapital decimal.Decimal // 初期資金（円）
+	MaxPositions   int             // 同時保有上限
+	LotSize        int64           // 売買単位（株）。<=0 なら models.PortfolioLotSize
+	RankBy         string          // models.PortfolioRank*。空なら出来高倍率
+	// Exit 利確/損切り/最大保有/コストと約定モデル（RunBacktest と同じ意味）。現金ベースの買いのみを扱うため Side・BorrowRate は使わない
+	Exit ExitParams
+}
+
+// portfolioPosition 保有中ポジション。
+type portfolioPosition struct {
+	series     int
+	entryIdx   int
+	shares     int64
+	entryFill  decimal.Decimal // イグジット判定用の生の約定価格
+	entryPrice decimal.Decimal // コスト込み実効取得単価
+	lastClose  decimal.Decimal // 直近に観測した終値（休場・売買停止日の時価評価用）
+	lastIdx    int
+	// ExitFill=intrabar の利確・損切り水準
+	takeProfit decimal.Decimal
+	stopLoss   decimal.Decimal
+}
+
+// portfolioCandidate エントリー候補（同日に点灯したシグナル）。
+type portfolioCandidate struct {
+	series int
+	idx    int
+	score  decimal.Decimal
+}
+
+// RunPortfolioBacktest 複数銘柄のシグナルを共通資金で売買するポートフォリオバックテストを実行する。
+//
+// 全銘柄の営業日の和集合を時系列で走査し、各日で
+//  1. 保有ポジションのイグジット判定（RunBacktest と同じ共通ルール + 戦略シグナル）
+//  2. 空き枠へのエントリー（候補が空き枠を超える場合は RankBy の降順で採用）
+//  3. 終値での時価評価
+//
+// を行う。1ポジションの予算は「当日評価額 ÷ MaxPositions」（現金が足りなければ現金残高）で、
+// 単元株（LotSize）単位に切り下げる。1単元も買えないシグナルは見送りとして数える。
+// 約定モデルは RunBacktest と同じ: Exit.EntryTiming=next_open ではシグナル翌営業日の始値で
+// （寄りの時点で空いている枠に）買い、Exit.ExitFill=intrabar では高値・安値で TP/SL の到達を判定して水準価格で手仕舞う。
+func RunPortfolioBacktest(series []PortfolioSeries, params PortfolioParams) models.PortfolioBacktestResult {
+	result := models.PortfolioBacktestResult{
+		FinalEquity: params.InitialCapital,
+		TotalReturn: decimal.Zero,
+		MaxDrawdown: decimal.Zero,
+		AvgExposure: decimal.Zero,
+		Turnover:    decimal.Zero,
+		WinRate:     decimal.Zero,
+		Equity:      []models.PortfolioEquityPoint{},
+		TradeList:   []models.PortfolioTrade{},
+	}
+	if params.MaxPositions <= 0 || !params.InitialCapital.IsPositive() {
+		return result
+	}
+	lotSize := params.LotSize
+	if lotSize <= 0 {
+		lotSize = models.PortfolioLotSize
+	}
+	lot := decimal.NewFromInt(lotSize)
+	// 現金ベースの買いのみを扱う（空売りの水準・貸株料は使わない）
+	params.Exit.Side = models.PositionSideLong
+	slotCount := decimal.NewFromInt(int64(params.MaxPositions))
+
+	dates, seriesDates := portfolioDates(series)
+	if len(dates) == 0 {
+		return result
+	}
+
+	cash := params.InitialCapital
+	cursors := make([]int, len(series))
+	barIdx := make([]int, len(series))
+	held := make(map[int]bool, params.MaxPositions)
+	positions := make([]*portfolioPosition, 0, params.MaxPositions)
+
+	var stats tradeStats
+	buyValue := decimal.Zero
+	sellValue := decimal.Zero
+	equitySeries := make([]decimal.Decimal, 0, len(dates))
+	exposureSum := decimal.Zero
+
+	nextOpen := params.Exit.EntryTiming == models.EntryTimingNextOpen
+	intrabar := params.Exit.ExitFill == models.ExitFillIntrabar
+	// pending EntryTiming=next_open で翌営業日の始値に買う予定の銘柄と、シグナル日の優先順位スコア
+	pending := make(map[int]decimal.Decimal)
+
+	closePosition := func(pos *portfolioPosition, reason string, fill decimal.Decimal) {
+		s := series[pos.series]
+		exitEff := effectiveExitPrice(fill, params.Exit.CommissionRate, params.Exit.SlippageRate)
+		shares := decimal.NewFromInt(pos.shares)
+		proceeds := exitEff.Mul(shares)
+		cash = cash.Add(proceeds)
+		sellValue = sellValue.Add(proceeds)
+
+		ret := effectiveExitReturn(fill, pos.entryPrice, params.Exit.CommissionRate, params.Exit.SlippageRate)
+		holdDays := pos.lastIdx - pos.entryIdx
+		stats.record(ret, holdDays)
+		result.TradeList = append(result.TradeList, models.PortfolioTrade{
+			TickerSymbol: s.TickerSymbol,
+			EntryDate:    seriesDates[pos.series][pos.entryIdx],
+			ExitDate:     seriesDates[pos.series][pos.lastIdx],
+			Shares:       pos.shares,
+			EntryPrice:   pos.entryPrice.Round(6),
+			ExitPrice:    fill.Round(6),
+			ProfitLoss:   proceeds.Sub(pos.entryPrice.Mul(shares)).Round(2),
+			Return:       ret.Round(6),
+			HoldDays:     holdDays,
+			Reason:       reason,
+		})
+		delete(held, pos.series)
+	}
+
+	positionValue := func() decimal.Decimal {
+		v := decimal.Zero
+		for _, pos := range positions {
+			v = v.Add(pos.lastClose.Mul(decimal.NewFromInt(pos.shares)))
+		}
+		return v
+	}
+
+	// openPositions 候補を優先順位の降順に空き枠へ買い付ける。fillOf は候補の生の約定価格。
+	openPositions := func(candidates []portfolioCandidate, fillOf func(portfolioCandidate) decimal.Decimal) {
+		if len(candidates) == 0 {
+			return
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if !candidates[i].score.Equal(candidates[j].score) {
+				return candidates[i].score.GreaterThan(candidates[j].score)
+			}
+			return series[candidates[i].series].TickerSymbol < series[candidates[j].series].TickerSymbol
+		})
+		equityNow := cash.Add(positionValue())
+		for _, c := range candidates {
+			if len(positions) >= params.MaxPositions {
+				result.SkippedSignals++
+				continue
+			}
+			fill := fillOf(c)
+			entryPrice := effectiveEntryPrice(fill, params.Exit.CommissionRate, params.Exit.SlippageRate)
+			budget := equityNow.Div(slotCount)
+			if budget.GreaterThan(cash) {
+				budget = cash
+			}
+			lotCost := entryPrice.Mul(lot)
+			if !lotCost.IsPositive() {
+				result.SkippedSignals++
+				continue
+			}
+			lots := budget.Div(lotCost).Floor().IntPart()
+			if lots <= 0 {
+				result.SkippedSignals++
+				continue
+			}
+			shares := lots * lotSize
+			cost := entryPrice.Mul(decimal.NewFromInt(shares))
+			cash = cash.Sub(cost)
+			buyValue = buyValue.Add(cost)
+			held[c.series] = true
+			pos := &portfolioPosition{
+				series:     c.series,
+				entryIdx:   c.idx,
+				shares:     shares,
+				entryFill:  fill,
+				entryPrice: entryPrice,
+				lastClose:  series[c.series].Prices[c.idx].Close,
+				lastIdx:    c.idx,
+			}
+			if intrabar {
+				pos.takeProfit, pos.stopLoss = intrabarExitLevels(fill, params.Exit)
+			}
+			positions = append(positions, pos)
+		}
+	}
+
+	for _, date := range dates {
+		// 当日バーを持つ銘柄のインデックスを進める
+		for si := range series {
+			barIdx[si] = -1
+			c := cursors[si]
+			if c < len(seriesDates[si]) && seriesDates[si][c] == date {
+				barIdx[si] = c
+				cursors[si] = c + 1
+			}
+		}
+
+		// Step 0: 前営業日のシグナルを当日の始値で約定（EntryTiming=next_open）
+		if nextOpen {
+			candidates := make([]portfolioCandidate, 0, len(pending))
+			for si, score := range pending {
+				if idx := barIdx[si]; idx >= 0 {
+					candidates = append(candidates, portfolioCandidate{series: si, idx: idx, score: score})
+					delete(pending, si)
+				}
+			}
+			openPositions(candidates, func(c portfolioCandidate) decimal.Decimal {
+				bar := series[c.series].Prices[c.idx]
+				if bar.Open.IsPositive() {
+					return bar.Open
+				}
+				return bar.Close
+			})
+		}
+
+		// Step A: イグジット判定
+		// 終値エントリーは当日は判定しない。始値エントリーは当日の値動きから判定する（窓開けは判定しない）。
+		remaining := positions[:0]
+		for _, pos := range positions {
+			idx := barIdx[pos.series]
+			if idx < 0 {
+				remaining = append(remaining, pos)
+				continue
+			}
+			bar := series[pos.series].Prices[idx]
+			pos.lastClose = bar.Close
+			pos.lastIdx = idx
+			if idx > pos.entryIdx || nextOpen {
+				holdDays := idx - pos.entryIdx
+				exitSignals := series[pos.series].ExitSignals
+				if intrabar {
+					if fill, reason := intrabarExit(bar, pos.takeProfit, pos.stopLoss, idx > pos.entryIdx, false, params.Exit.IntrabarPriority); reason != "" {
+						closePosition(pos, reason, fill)
+						continue
+					}
+					if holdDays >= params.Exit.MaxHoldDays {
+						closePosition(pos, "max_hold", bar.Close)
+						continue
+					}
+					if exitSignals != nil && idx < len(exitSignals) && exitSignals[idx] {
+						closePosition(pos, "signal_exit", bar.Close)
+						continue
+					}
+				} else {
+					rawRet := bar.Close.Div(pos.entryFill).Sub(decimal.NewFromInt(1))
+					if reason := decideExitReason(rawRet, holdDays, exitSignals, idx, params.Exit); reason != "" {
+						closePosition(pos, reason, bar.Close)
+						continue
+					}
+				}
+			}
+			remaining = append(remaining, pos)
+		}
+		positions = remaining
+
+		// Step B: エントリー（空き枠を優先順位順に埋める。next_open は翌営業日の約定に回す）
+		candidates := make([]portfolioCandidate, 0)
+		for si, s := range series {
+			idx := barIdx[si]
+			if idx < 0 || held[si] || idx >= len(s.EntrySignals) || !s.EntrySignals[idx] {
+				continue
+			}
+			candidates = append(candidates, portfolioCandidate{
+				series: si,
+				idx:    idx,
+				score:  portfolioRankScore(s.Prices, idx, params.RankBy),
+			})
+		}
+		if nextOpen {
+			for _, c := range candidates {
+				pending[c.series] = c.score
+			}
+		} else {
+			openPositions(candidates, func(c portfolioCandidate) decimal.Decimal {
+				return series[c.series].Prices[c.idx].Close
+			})
+		}
+
+		// Step C: 終値で時価評価
+		pv := positionValue()
+		equity := cash.Add(pv)
+		exposure := decimal.Zero
+		if equity.IsPositive() {
+			exposure = pv.Div(equity)
+		}
+		exposureSum = exposureSum.Add(exposure)
+		equitySeries = append(equitySeries, equity)
+		result.Equity = append(result.Equity, models.PortfolioEquityPoint{
+			Date:          date,
+			Equity:        equity.Round(2),
+			Cash:          cash.Round(2),
+			PositionValue: pv.Round(2),
+			Exposure:      exposure.Round(6),
+			OpenPositions: len(positions),
+		})
+	}
+
+	// データ末尾で保有が残っていれば各銘柄の最終観測終値で強制クローズ
+	for _, pos := range positions {
+		closePosition(pos, "end_of_data", pos.lastClose)
+	}
+
+	result.From = dates[0]
+	result.To = dates[len(dates)-1]
+	result.FinalEquity = cash.Round(2)
+	result.TotalReturn = cash.Div(params.InitialCapital).Sub(decimal.NewFromInt(1)).Round(6)
+	result.MaxDrawdown = MaxDrawdown(equitySeries).Round(6)
+	days := decimal.NewFromInt(int64(len(equitySeries)))
+	result.AvgExposure = exposureSum.Div(days).Round(6)
+	if avgEquity := mean(equitySeries); avgEquity.IsPositive() {
+		result.Turnover = buyValue.Add(sellValue).Div(decimal.NewFromInt(2)).Div(avgEquity).Round(6)
+	}
+	result.Trades = stats.trades
+	if stats.trades > 0 {
+		result.WinRate = decimal.NewFromInt(int64(stats.wins)).Div(decimal.NewFromInt(int64(stats.trades))).Round(4)
+	}
+	return result
+}
+
+// portfolioDates 全銘柄の営業日（YYYY-MM-DD）の和集合を昇順で返す。
+// 日付の突き合わせで毎日 Format しないよう、銘柄ごとの日付文字列も併せて返す。
+func portfolioDates(series []PortfolioSeries) (dates []string, seriesDates [][]string) {
+	seen := make(map[string]struct{})
+	seriesDates = make([][]string, len(series))
+	for si, s := range series {
+		keys := make([]string, len(s.Prices))
+		for i, p := range s.Prices {
+			keys[i] = p.Date.Format(util.DateLayout)
+			seen[keys[i]] = struct{}{}
+		}
+		seriesDates[si] = keys
+	}
+	dates = make([]string, 0, len(seen))
+	for d := range seen {
+		dates = append(dates, d)
+	}
+	sort.Strings(dates)
+	return dates, seriesDates
+}
+
+// portfolioRankScore エントリー候補の優先順位スコアを返す（大きいほど優先）。
+func portfolioRankScore(prices []*models.StockBrandDailyPrice, idx int, rankBy string) decimal.Decimal {
+	switch rankBy {
+	case models.PortfolioRankTradingValue:
+		start := idx - portfolioRankWindow + 1
+		if start < 0 {
+			start = 0
+		}
+		return windowAvgTradingValue(prices[start : idx+1])
+	case models.PortfolioRankMomentum:
+		if idx < portfolioRankWindow || prices[idx-portfolioRankWindow].Close.IsZero() {
+			return decimal.Zero
+		}
+		return prices[idx].Close.Div(prices[idx-portfolioRankWindow].Close).Sub(decimal.NewFromInt(1))
+	default:
+		avg := avgVolume(prices, idx, portfolioRankWindow)
+		if avg.IsZero() {
+			return decimal.Zero
+		}
+		return decimal.NewFromInt(prices[idx].Volume).Div(avg)
+	}
+}
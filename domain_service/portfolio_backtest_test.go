@@ -0,0 +1,156 @@
+package domain_service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func withVolumes(prices []*models.StockBrandDailyPrice, volumes ...int64) []*models.StockBrandDailyPrice {
+	for i, v := range volumes {
+		prices[i].Volume = v
+	}
+	return prices
+}
+
+func TestRunPortfolioBacktest(t *testing.T) {
+	exit := ExitParams{
+		TakeProfit:  decimal.NewFromFloat(0.10),
+		StopLoss:    decimal.NewFromFloat(0.05),
+		MaxHoldDays: 10,
+	}
+
+	t.Run("単元株で買付し利確で手仕舞い", func(t *testing.T) {
+		series := []PortfolioSeries{{
+			TickerSymbol: "1001",
+			Prices:       pricesFromCloses(100, 100, 110),
+			EntrySignals: boolsAt(3, 1),
+		}}
+		res := RunPortfolioBacktest(series, PortfolioParams{
+			InitialCapital: decimal.NewFromInt(100_000),
+			MaxPositions:   1,
+			Exit:           exit,
+		})
+		assert.Equal(t, 1, res.Trades)
+		assert.Len(t, res.TradeList, 1)
+		assert.Equal(t, int64(1000), res.TradeList[0].Shares)
+		assert.Equal(t, "take_profit", res.TradeList[0].Reason)
+		assert.InDelta(t, 10_000, f64FromDec(res.TradeList[0].ProfitLoss), 1e-9)
+		assert.InDelta(t, 110_000, f64FromDec(res.FinalEquity), 1e-9)
+		assert.InDelta(t, 0.10, f64FromDec(res.TotalReturn), 1e-9)
+		assert.Len(t, res.Equity, 3)
+		assert.InDelta(t, 1.0, f64FromDec(res.Equity[1].Exposure), 1e-9)
+		assert.InDelta(t, 0.0, f64FromDec(res.Equity[2].Exposure), 1e-9)
+	})
+
+	t.Run("枠を超えるシグナルは優先順位で採用し残りは見送る", func(t *testing.T) {
+		low := withVolumes(pricesFromCloses(100, 100, 100), 100, 100, 100)
+		high := withVolumes(pricesFromCloses(100, 100, 100), 100, 100, 500)
+		series := []PortfolioSeries{
+			{TickerSymbol: "1001", Prices: low, EntrySignals: boolsAt(3, 2)},
+			{TickerSymbol: "1002", Prices: high, EntrySignals: boolsAt(3, 2)},
+		}
+		res := RunPortfolioBacktest(series, PortfolioParams{
+			InitialCapital: decimal.NewFromInt(100_000),
+			MaxPositions:   1,
+			RankBy:         models.PortfolioRankVolumeRatio,
+			Exit:           exit,
+		})
+		assert.Equal(t, 1, res.SkippedSignals)
+		assert.Len(t, res.TradeList, 1)
+		assert.Equal(t, "1002", res.TradeList[0].TickerSymbol)
+		assert.Equal(t, "end_of_data", res.TradeList[0].Reason)
+	})
+
+	t.Run("1単元も買えない場合は見送り", func(t *testing.T) {
+		series := []PortfolioSeries{{
+			TickerSymbol: "1001",
+			Prices:       pricesFromCloses(2000, 2000, 2200),
+			EntrySignals: boolsAt(3, 1),
+		}}
+		res := RunPortfolioBacktest(series, PortfolioParams{
+			InitialCapital: decimal.NewFromInt(100_000),
+			MaxPositions:   1,
+			Exit:           exit,
+		})
+		assert.Equal(t, 0, res.Trades)
+		assert.Equal(t, 1, res.SkippedSignals)
+		assert.InDelta(t, 100_000, f64FromDec(res.FinalEquity), 1e-9)
+	})
+
+	t.Run("資金は枠数で等分する", func(t *testing.T) {
+		series := []PortfolioSeries{
+			{TickerSymbol: "1001", Prices: pricesFromCloses(100, 100, 100), EntrySignals: boolsAt(3, 1)},
+			{TickerSymbol: "1002", Prices: pricesFromCloses(100, 100, 100), EntrySignals: boolsAt(3, 1)},
+		}
+		res := RunPortfolioBacktest(series, PortfolioParams{
+			InitialCapital: decimal.NewFromInt(100_000),
+			MaxPositions:   2,
+			Exit:           exit,
+		})
+		assert.Len(t, res.TradeList, 2)
+		for _, tr := range res.TradeList {
+			assert.Equal(t, int64(500), tr.Shares)
+		}
+		assert.Equal(t, 2, res.Equity[1].OpenPositions)
+		// 買付 100,000 + 売却 100,000 の半分 ÷ 平均評価額 100,000
+		assert.InDelta(t, 1.0, f64FromDec(res.Turnover), 1e-9)
+	})
+
+	t.Run("翌寄り付きエントリーと高値での利確は単銘柄バックテストと同じ約定になる", func(t *testing.T) {
+		p := exit
+		p.EntryTiming = models.EntryTimingNextOpen
+		p.ExitFill = models.ExitFillIntrabar
+		prices := []*models.StockBrandDailyPrice{
+			ohlc(0, 100, 100, 100, 100),
+			ohlc(1, 100, 101, 99, 100),
+			ohlc(2, 101, 111, 100, 105),
+		}
+		series := []PortfolioSeries{{TickerSymbol: "1001", Prices: prices, EntrySignals: boolsAt(3, 0)}}
+		res := RunPortfolioBacktest(series, PortfolioParams{
+			InitialCapital: decimal.NewFromInt(100_000),
+			MaxPositions:   1,
+			Exit:           p,
+		})
+		single := RunBacktest(prices, boolsAt(3, 0), nil, p)
+		assert.Len(t, res.TradeList, 1)
+		assert.Equal(t, "2024-01-02", res.TradeList[0].EntryDate, "シグナル翌営業日に買う")
+		assert.InDelta(t, 100, f64FromDec(res.TradeList[0].EntryPrice), 1e-9)
+		assert.Equal(t, "take_profit", res.TradeList[0].Reason)
+		assert.InDelta(t, 110, f64FromDec(res.TradeList[0].ExitPrice), 1e-9, "終値ではなく利確水準で約定")
+		assert.Equal(t, single.TradeList[0].Reason, res.TradeList[0].Reason)
+		assert.True(t, single.TradeList[0].Return.Equal(res.TradeList[0].Return))
+		assert.InDelta(t, 110_000, f64FromDec(res.FinalEquity), 1e-9)
+	})
+
+	t.Run("翌寄り付きエントリーは寄りの時点の空き枠で優先順位を付ける", func(t *testing.T) {
+		p := exit
+		p.EntryTiming = models.EntryTimingNextOpen
+		low := withVolumes(pricesFromCloses(100, 100, 100), 100, 100, 100)
+		high := withVolumes(pricesFromCloses(100, 100, 100), 100, 500, 100)
+		series := []PortfolioSeries{
+			{TickerSymbol: "1001", Prices: low, EntrySignals: boolsAt(3, 1)},
+			{TickerSymbol: "1002", Prices: high, EntrySignals: boolsAt(3, 1)},
+		}
+		res := RunPortfolioBacktest(series, PortfolioParams{
+			InitialCapital: decimal.NewFromInt(100_000),
+			MaxPositions:   1,
+			RankBy:         models.PortfolioRankVolumeRatio,
+			Exit:           p,
+		})
+		assert.Equal(t, 1, res.SkippedSignals)
+		assert.Len(t, res.TradeList, 1)
+		assert.Equal(t, "1002", res.TradeList[0].TickerSymbol, "シグナル日の出来高倍率で優先する")
+		assert.Equal(t, "2024-01-03", res.TradeList[0].EntryDate)
+		assert.Equal(t, 0, res.Equity[1].OpenPositions, "シグナル日はまだ買わない")
+	})
+
+	t.Run("枠数ゼロは空結果", func(t *testing.T) {
+		res := RunPortfolioBacktest(nil, PortfolioParams{InitialCapital: decimal.NewFromInt(100_000)})
+		assert.Equal(t, 0, res.Trades)
+		assert.Empty(t, res.Equity)
+	})
+}
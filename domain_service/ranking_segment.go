@@ -0,0 +1,199 @@
+package domain_service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// NikkeiRegimeSMAPeriod 日経平均レジーム判定に使う移動平均の期間（営業日）。
+const NikkeiRegimeSMAPeriod = 200
+
+// RankingLiquidityWindow ユニバースの流動性判定に使う平均売買代金の期間（営業日）。
+const RankingLiquidityWindow = 20
+
+// MaxRankingSegments 1回の戦略ランキングで指定できる明示セグメント数の上限。
+const MaxRankingSegments = 20
+
+var rankingSegmentNameRegex = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// ValidateRankingSegments 明示指定のセグメントを検証する。名前は英小文字・数字・_ の32文字以内で重複不可、
+// 日経レジームの予約名（nikkei_bull / nikkei_bear）は使えない。各期間は YYYY-MM-DD で From<=To。
+func ValidateRankingSegments(segments []models.RankingSegment) error {
+	if len(segments) > MaxRankingSegments {
+		return fmt.Errorf("セグメントは%d個以下で指定してください", MaxRankingSegments)
+	}
+	seen := make(map[string]bool, len(segments))
+	for _, seg := range segments {
+		if !rankingSegmentNameRegex.MatchString(seg.Name) {
+			return fmt.Errorf("セグメント名 %q は英小文字・数字・_ の32文字以内で指定してください", seg.Name)
+		}
+		if seg.Name == models.RankingSegmentNikkeiBull || seg.Name == models.RankingSegmentNikkeiBear {
+			return fmt.Errorf("セグメント名 %q は日経レジーム用に予約されています", seg.Name)
+		}
+		if seen[seg.Name] {
+			return fmt.Errorf("セグメント名 %q が重複しています", seg.Name)
+		}
+		seen[seg.Name] = true
+		if len(seg.Ranges) == 0 {
+			return fmt.Errorf("セグメント %q の期間を指定してください", seg.Name)
+		}
+		for _, r := range seg.Ranges {
+			from, err := time.Parse(util.DateLayout, r.From)
+			if err != nil {
+				return fmt.Errorf("セグメント %q の開始日は YYYY-MM-DD で指定してください", seg.Name)
+			}
+			to, err := time.Parse(util.DateLayout, r.To)
+			if err != nil {
+				return fmt.Errorf("セグメント %q の終了日は YYYY-MM-DD で指定してください", seg.Name)
+			}
+			if to.Before(from) {
+				return fmt.Errorf("セグメント %q の終了日は開始日以降で指定してください", seg.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// RecentAvgTradingValue 末尾 window 本（当日含む）の平均売買代金 (volume*close)。
+func RecentAvgTradingValue(prices []*models.StockBrandDailyPrice, window int) decimal.Decimal {
+	start := len(prices) - window
+	if start < 0 {
+		start = 0
+	}
+	return windowAvgTradingValue(prices[start:])
+}
+
+// AvgTradingValueBefore date（YYYY-MM-DD）より前の直近 window 本（date 当日は含まない）の平均売買代金。
+// 評価期間の開始時点で流動性を判定し、期間中の売買代金を先読みしないために使う。date より前の日足が無ければゼロ。
+func AvgTradingValueBefore(prices []*models.StockBrandDailyPrice, date string, window int) decimal.Decimal {
+	end := sort.Search(len(prices), func(i int) bool { return prices[i].Date.Format(util.DateLayout) >= date })
+	return RecentAvgTradingValue(prices[:end], window)
+}
+
+// LiquidSegmentRanges 各セグメントの期間のうち、開始日時点の平均売買代金（AvgTradingValueBefore）が
+// minTradingValue 以上の期間だけを残したセグメントを返す。添字は segments と対応し、該当期間が無ければ Ranges は空。
+func LiquidSegmentRanges(prices []*models.StockBrandDailyPrice, segments []models.RankingSegment, window int, minTradingValue decimal.Decimal) []models.RankingSegment {
+	out := make([]models.RankingSegment, len(segments))
+	for i, seg := range segments {
+		out[i] = models.RankingSegment{Name: seg.Name, Source: seg.Source, Ranges: []models.RankingDateRange{}}
+		for _, r := range seg.Ranges {
+			if AvgTradingValueBefore(prices, r.From, window).GreaterThanOrEqual(minTradingValue) {
+				out[i].Ranges = append(out[i].Ranges, r)
+			}
+		}
+	}
+	return out
+}
+
+// SegmentContains date（YYYY-MM-DD）がセグメントのいずれかの期間に含まれるか。
+func SegmentContains(segment models.RankingSegment, date string) bool {
+	for _, r := range segment.Ranges {
+		if date >= r.From && date <= r.To {
+			return true
+		}
+	}
+	return false
+}
+
+// PricesOverlapSegment 日足（日付昇順）がセグメントの期間と1日でも重なるか。
+// セグメント期間に上場していない銘柄を集計の分母から外すために使う。
+func PricesOverlapSegment(prices []*models.StockBrandDailyPrice, segment models.RankingSegment) bool {
+	if len(prices) == 0 {
+		return false
+	}
+	first := prices[0].Date.Format(util.DateLayout)
+	last := prices[len(prices)-1].Date.Format(util.DateLayout)
+	for _, r := range segment.Ranges {
+		if r.From <= last && r.To >= first {
+			return true
+		}
+	}
+	return false
+}
+
+// TradesInSegment エントリー日がセグメント内のトレードを返す。
+func TradesInSegment(trades []models.BacktestTrade, segment models.RankingSegment) []models.BacktestTrade {
+	out := make([]models.BacktestTrade, 0, len(trades))
+	for _, t := range trades {
+		if SegmentContains(segment, t.EntryDate) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// SummarizeTrades トレード列だけから成績指標を算出する。TotalReturn は各トレードのリターンの複利、
+// MaxDrawdown は約定ごとの確定資産の推移から求める（保有中の含み損は含まない）。
+func SummarizeTrades(trades []models.BacktestTrade) models.BacktestResult {
+	result := models.BacktestResult{
+		TotalReturn:  decimal.Zero,
+		WinRate:      decimal.Zero,
+		ProfitFactor: decimal.Zero,
+		MaxDrawdown:  decimal.Zero,
+		AvgWin:       decimal.Zero,
+		AvgLoss:      decimal.Zero,
+		PayoffRatio:  decimal.Zero,
+	}
+	if len(trades) == 0 {
+		return result
+	}
+
+	one := decimal.NewFromInt(1)
+	equity := one
+	path := make([]decimal.Decimal, 0, len(trades)+1)
+	path = append(path, equity)
+	var stats tradeStats
+	for _, t := range trades {
+		stats.record(t.Return, t.HoldDays)
+		equity = equity.Mul(one.Add(t.Return))
+		path = append(path, equity)
+	}
+
+	result.Trades = stats.trades
+	result.TotalReturn = equity.Sub(one).Round(6)
+	result.MaxDrawdown = MaxDrawdown(path).Round(6)
+	result.AvgHoldDays = float64(stats.holdDaysSum) / float64(stats.trades)
+	fillTradeStats(&result, &stats)
+	return result
+}
+
+// NikkeiRegimeSegments 日経平均の終値が200日移動平均より上の日を nikkei_bull、以下の日を nikkei_bear とし、
+// 連続する日をまとめた期間のセグメントを返す。from より前の日と移動平均が計算できない日は含めない。
+// index は日付昇順。
+func NikkeiRegimeSegments(index models.IndexStockAverageDailyPrices, from time.Time) []models.RankingSegment {
+	bull := models.RankingSegment{Name: models.RankingSegmentNikkeiBull, Source: models.RankingSegmentSourceNikkeiRegime, Ranges: []models.RankingDateRange{}}
+	bear := models.RankingSegment{Name: models.RankingSegmentNikkeiBear, Source: models.RankingSegmentSourceNikkeiRegime, Ranges: []models.RankingDateRange{}}
+
+	closes := make([]decimal.Decimal, len(index))
+	for i, p := range index {
+		closes[i] = p.Close
+	}
+	sma := smaSeries(closes, NikkeiRegimeSMAPeriod)
+	fromStr := from.Format(util.DateLayout)
+
+	var current *models.RankingSegment
+	for i := NikkeiRegimeSMAPeriod - 1; i < len(index); i++ {
+		date := index[i].Date.Format(util.DateLayout)
+		if date < fromStr {
+			continue
+		}
+		seg := &bear
+		if closes[i].GreaterThan(sma[i]) {
+			seg = &bull
+		}
+		if seg == current {
+			seg.Ranges[len(seg.Ranges)-1].To = date
+			continue
+		}
+		seg.Ranges = append(seg.Ranges, models.RankingDateRange{From: date, To: date})
+		current = seg
+	}
+	return []models.RankingSegment{bull, bear}
+}
@@ -0,0 +1,204 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestSummarizeTrades(t *testing.T) {
+	t.Run("トレードなしはゼロ", func(t *testing.T) {
+		res := SummarizeTrades(nil)
+		assert.Equal(t, 0, res.Trades)
+		assert.True(t, res.TotalReturn.IsZero())
+		assert.True(t, res.MaxDrawdown.IsZero())
+	})
+
+	t.Run("複利リターン・勝率・PF・ドローダウン", func(t *testing.T) {
+		trades := []models.BacktestTrade{
+			{EntryDate: "2024-01-01", Return: decimal.NewFromFloat(0.10), HoldDays: 2},
+			{EntryDate: "2024-01-10", Return: decimal.NewFromFloat(-0.05), HoldDays: 4},
+			{EntryDate: "2024-01-20", Return: decimal.NewFromFloat(0.02), HoldDays: 3},
+		}
+		res := SummarizeTrades(trades)
+		assert.Equal(t, 3, res.Trades)
+		// 1.1 * 0.95 * 1.02 - 1 = 0.0659
+		assert.InDelta(t, 0.0659, f64FromDec(res.TotalReturn), 1e-9)
+		assert.InDelta(t, 0.6667, f64FromDec(res.WinRate), 1e-9)
+		// (0.10 + 0.02) / 0.05
+		assert.InDelta(t, 2.4, f64FromDec(res.ProfitFactor), 1e-9)
+		assert.InDelta(t, -0.05, f64FromDec(res.MaxDrawdown), 1e-9)
+		assert.InDelta(t, 3.0, res.AvgHoldDays, 1e-9)
+	})
+}
+
+func TestTradesInSegment(t *testing.T) {
+	seg := models.RankingSegment{
+		Name: "y2023",
+		Ranges: []models.RankingDateRange{
+			{From: "2023-01-01", To: "2023-03-31"},
+			{From: "2023-07-01", To: "2023-07-31"},
+		},
+	}
+	trades := []models.BacktestTrade{
+		{EntryDate: "2022-12-30"},
+		{EntryDate: "2023-01-01"},
+		{EntryDate: "2023-03-31"},
+		{EntryDate: "2023-05-10"},
+		{EntryDate: "2023-07-15"},
+	}
+	got := TradesInSegment(trades, seg)
+	require.Len(t, got, 3)
+	assert.Equal(t, "2023-01-01", got[0].EntryDate)
+	assert.Equal(t, "2023-03-31", got[1].EntryDate)
+	assert.Equal(t, "2023-07-15", got[2].EntryDate)
+}
+
+func TestPricesOverlapSegment(t *testing.T) {
+	prices := pricesFromCloses(100, 101, 102) // 2024-01-01..2024-01-03
+	tests := []struct {
+		name string
+		r    models.RankingDateRange
+		want bool
+	}{
+		{name: "重なる", r: models.RankingDateRange{From: "2023-12-01", To: "2024-01-01"}, want: true},
+		{name: "内包", r: models.RankingDateRange{From: "2024-01-02", To: "2024-01-02"}, want: true},
+		{name: "前", r: models.RankingDateRange{From: "2023-01-01", To: "2023-12-31"}, want: false},
+		{name: "後", r: models.RankingDateRange{From: "2024-01-04", To: "2024-12-31"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seg := models.RankingSegment{Name: "s", Ranges: []models.RankingDateRange{tt.r}}
+			assert.Equal(t, tt.want, PricesOverlapSegment(prices, seg))
+		})
+	}
+	assert.False(t, PricesOverlapSegment(nil, models.RankingSegment{Ranges: []models.RankingDateRange{{From: "2024-01-01", To: "2024-01-31"}}}))
+}
+
+func TestNikkeiRegimeSegments(t *testing.T) {
+	// 200日は横ばい、その後 5日上昇 → 5日急落 → 3日上昇
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	closes := make([]float64, 0, 213)
+	for i := 0; i < 200; i++ {
+		closes = append(closes, 100)
+	}
+	closes = append(closes, 110, 111, 112, 113, 114)
+	closes = append(closes, 90, 89, 88, 87, 86)
+	closes = append(closes, 120, 121, 122)
+	index := make(models.IndexStockAverageDailyPrices, 0, len(closes))
+	for i, c := range closes {
+		index = append(index, &models.IndexStockAverageDailyPrice{Date: base.AddDate(0, 0, i), Close: decimal.NewFromFloat(c)})
+	}
+	day := func(i int) string { return base.AddDate(0, 0, i).Format("2006-01-02") }
+
+	t.Run("連続する日をまとめる", func(t *testing.T) {
+		segs := NikkeiRegimeSegments(index, base)
+		require.Len(t, segs, 2)
+		bull, bear := segs[0], segs[1]
+		assert.Equal(t, models.RankingSegmentNikkeiBull, bull.Name)
+		assert.Equal(t, models.RankingSegmentSourceNikkeiRegime, bull.Source)
+		assert.Equal(t, []models.RankingDateRange{
+			{From: day(200), To: day(204)},
+			{From: day(210), To: day(212)},
+		}, bull.Ranges)
+		// 199日目は SMA=100 と同値なので bear
+		assert.Equal(t, []models.RankingDateRange{
+			{From: day(199), To: day(199)},
+			{From: day(205), To: day(209)},
+		}, bear.Ranges)
+	})
+
+	t.Run("from より前は含めない", func(t *testing.T) {
+		segs := NikkeiRegimeSegments(index, base.AddDate(0, 0, 207))
+		assert.Equal(t, []models.RankingDateRange{{From: day(210), To: day(212)}}, segs[0].Ranges)
+		assert.Equal(t, []models.RankingDateRange{{From: day(207), To: day(209)}}, segs[1].Ranges)
+	})
+
+	t.Run("データ不足なら空", func(t *testing.T) {
+		segs := NikkeiRegimeSegments(index[:100], base)
+		assert.Empty(t, segs[0].Ranges)
+		assert.Empty(t, segs[1].Ranges)
+	})
+}
+
+func TestValidateRankingSegments(t *testing.T) {
+	valid := models.RankingSegment{Name: "trend_2023", Ranges: []models.RankingDateRange{{From: "2023-01-01", To: "2023-12-31"}}}
+	tests := []struct {
+		name     string
+		segments []models.RankingSegment
+		wantErr  bool
+	}{
+		{name: "正常", segments: []models.RankingSegment{valid}},
+		{name: "名前不正", segments: []models.RankingSegment{{Name: "Trend 2023", Ranges: valid.Ranges}}, wantErr: true},
+		{name: "予約名", segments: []models.RankingSegment{{Name: models.RankingSegmentNikkeiBull, Ranges: valid.Ranges}}, wantErr: true},
+		{name: "重複", segments: []models.RankingSegment{valid, valid}, wantErr: true},
+		{name: "期間なし", segments: []models.RankingSegment{{Name: "x"}}, wantErr: true},
+		{name: "日付形式不正", segments: []models.RankingSegment{{Name: "x", Ranges: []models.RankingDateRange{{From: "2023/01/01", To: "2023-12-31"}}}}, wantErr: true},
+		{name: "逆順", segments: []models.RankingSegment{{Name: "x", Ranges: []models.RankingDateRange{{From: "2023-12-31", To: "2023-01-01"}}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRankingSegments(tt.segments)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRecentAvgTradingValue(t *testing.T) {
+	prices := pricesFromCloses(100, 200, 300)
+	for i, p := range prices {
+		p.Volume = int64(10 * (i + 1))
+	}
+	// 直近2本: (200*20 + 300*30) / 2 = 6500
+	assert.True(t, decimal.NewFromInt(6500).Equal(RecentAvgTradingValue(prices, 2)))
+	// window が本数より大きければ全期間
+	assert.True(t, decimal.NewFromInt(14000).Div(decimal.NewFromInt(3)).Round(6).Equal(RecentAvgTradingValue(prices, 20).Round(6)))
+}
+
+func TestAvgTradingValueBefore(t *testing.T) {
+	prices := pricesFromCloses(100, 200, 300)
+	for i, p := range prices {
+		p.Volume = int64(10 * (i + 1))
+	}
+	// 2024-01-03 当日は含まない: (100*10 + 200*20) / 2 = 2500
+	assert.True(t, decimal.NewFromInt(2500).Equal(AvgTradingValueBefore(prices, "2024-01-03", 20)))
+	assert.True(t, decimal.NewFromInt(4000).Equal(AvgTradingValueBefore(prices, "2024-01-03", 1)))
+	// 開始日より前の日足が無ければゼロ
+	assert.True(t, AvgTradingValueBefore(prices, "2024-01-01", 20).IsZero())
+}
+
+func TestLiquidSegmentRanges(t *testing.T) {
+	// 2024-01-01..05 の出来高が 1, 1, 100, 100, 100（終値は 100 固定）
+	prices := pricesFromCloses(100, 100, 100, 100, 100)
+	for i, p := range prices {
+		p.Volume = 1
+		if i >= 2 {
+			p.Volume = 100
+		}
+	}
+	segments := []models.RankingSegment{
+		{Name: "x", Source: models.RankingSegmentSourceExplicit, Ranges: []models.RankingDateRange{
+			{From: "2024-01-02", To: "2024-01-03"},
+			{From: "2024-01-05", To: "2024-01-05"},
+		}},
+		{Name: "y", Source: models.RankingSegmentSourceExplicit, Ranges: []models.RankingDateRange{{From: "2024-01-03", To: "2024-01-05"}}},
+	}
+
+	got := LiquidSegmentRanges(prices, segments, 2, decimal.NewFromInt(5000))
+	require.Len(t, got, 2)
+	// 期間中に売買代金が増えても、開始日より前の売買代金が足りない期間は残さない
+	assert.Equal(t, []models.RankingDateRange{{From: "2024-01-05", To: "2024-01-05"}}, got[0].Ranges)
+	assert.Equal(t, "x", got[0].Name)
+	assert.Empty(t, got[1].Ranges)
+	// 元のセグメントは変更しない
+	assert.Len(t, segments[0].Ranges, 2)
+}
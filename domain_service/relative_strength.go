@@ -0,0 +1,105 @@
+package domain_service
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// relativeStrengthPeriod RS レーティングの1期間（営業日数と重み）。
+type relativeStrengthPeriod struct {
+	days   int
+	weight decimal.Decimal
+}
+
+// relativeStrengthPeriods IBD 方式の 3/6/9/12 か月（63/126/189/252 営業日）。直近3か月を2倍に重み付けする。
+var relativeStrengthPeriods = []relativeStrengthPeriod{
+	{days: 63, weight: decimal.RequireFromString("0.4")},
+	{days: 126, weight: decimal.RequireFromString("0.2")},
+	{days: 189, weight: decimal.RequireFromString("0.2")},
+	{days: 252, weight: decimal.RequireFromString("0.2")},
+}
+
+// RelativeStrengthLookbackDays RS 算出に必要な最大の営業日数（12か月）。
+const RelativeStrengthLookbackDays = 252
+
+// CalculateRelativeStrengthScore 日足（date 昇順、末尾が算出日）と TOPIX 日足（date 昇順）から、
+// 末尾日時点の TOPIX 比相対リターンと加重スコアを算出する。Rating は未設定（0）。
+// 相対リターンは (1+銘柄リターン)/(1+TOPIXリターン)-1。起点日に TOPIX が無い場合は直前の営業日の値を使う。
+// 3か月分の履歴が無い銘柄は ok=false。6〜12か月が不足する場合はその期間を除いて重みを按分し直す。
+func CalculateRelativeStrengthScore(prices []*models.StockBrandDailyPrice, topix models.IndexStockAverageDailyPrices) (*models.RelativeStrengthRating, bool) {
+	n := len(prices)
+	if n <= relativeStrengthPeriods[0].days {
+		return nil, false
+	}
+	last := prices[n-1]
+	topixLast, ok := indexAdjCloseOnOrBefore(topix, last)
+	if !ok || last.Adjclose.IsZero() {
+		return nil, false
+	}
+
+	rating := &models.RelativeStrengthRating{
+		TickerSymbol: last.TickerSymbol,
+		Date:         last.Date,
+	}
+	fields := []**decimal.Decimal{&rating.Relative3M, &rating.Relative6M, &rating.Relative9M, &rating.Relative12M}
+
+	weighted, weightSum := decimal.Zero, decimal.Zero
+	for i, period := range relativeStrengthPeriods {
+		if n <= period.days {
+			continue
+		}
+		base := prices[n-1-period.days]
+		topixBase, ok := indexAdjCloseOnOrBefore(topix, base)
+		if !ok || base.Adjclose.IsZero() || topixBase.IsZero() {
+			continue
+		}
+		stockGrowth := last.Adjclose.Div(base.Adjclose)
+		topixGrowth := topixLast.Div(topixBase)
+		relative := stockGrowth.Div(topixGrowth).Sub(decimal.NewFromInt(1)).Round(6)
+		*fields[i] = &relative
+
+		weighted = weighted.Add(relative.Mul(period.weight))
+		weightSum = weightSum.Add(period.weight)
+	}
+	if rating.Relative3M == nil {
+		return nil, false
+	}
+	rating.Score = weighted.Div(weightSum).Round(6)
+	return rating, true
+}
+
+// indexAdjCloseOnOrBefore p の日付以前で最も新しい指数の調整後終値。
+func indexAdjCloseOnOrBefore(index models.IndexStockAverageDailyPrices, p *models.StockBrandDailyPrice) (decimal.Decimal, bool) {
+	i := sort.Search(len(index), func(i int) bool { return index[i].Date.After(p.Date) })
+	if i == 0 {
+		return decimal.Zero, false
+	}
+	return index[i-1].Adjclose, true
+}
+
+// AssignRelativeStrengthRatings 同一日のスコアをパーセンタイル化して Rating（1〜99）を設定する。
+// 自分より Score が低い銘柄の割合で順位付けするため、同スコアは同じ Rating になる。1銘柄だけなら 99。
+func AssignRelativeStrengthRatings(ratings []*models.RelativeStrengthRating) {
+	n := len(ratings)
+	if n == 0 {
+		return
+	}
+	if n == 1 {
+		ratings[0].Rating = 99
+		return
+	}
+	scores := make([]decimal.Decimal, n)
+	for i, r := range ratings {
+		scores[i] = r.Score
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].LessThan(scores[j]) })
+
+	for _, r := range ratings {
+		lower := sort.Search(n, func(i int) bool { return !scores[i].LessThan(r.Score) })
+		percentile := decimal.NewFromInt(int64(lower)).Div(decimal.NewFromInt(int64(n - 1)))
+		r.Rating = 1 + int(percentile.Mul(decimal.NewFromInt(98)).Round(0).IntPart())
+	}
+}
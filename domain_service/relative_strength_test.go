@@ -0,0 +1,89 @@
+package domain_service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// rsSeries 調整後終値 100 が n 本続き末尾だけ last の日足と、同じ日付で終値 1000 の TOPIX を返す。
+func rsSeries(n int, last float64) ([]*models.StockBrandDailyPrice, models.IndexStockAverageDailyPrices) {
+	closes := make([]float64, n)
+	for i := range closes {
+		closes[i] = 100
+	}
+	closes[n-1] = last
+	prices := pricesFromCloses(closes...)
+	topix := make(models.IndexStockAverageDailyPrices, 0, n)
+	for _, p := range prices {
+		p.TickerSymbol = "7203"
+		p.Adjclose = p.Close
+		topix = append(topix, &models.IndexStockAverageDailyPrice{Date: p.Date, Adjclose: decimal.NewFromInt(1000)})
+	}
+	return prices, topix
+}
+
+func TestCalculateRelativeStrengthScore(t *testing.T) {
+	t.Run("3か月分のみなら3か月の相対リターンがそのままスコア", func(t *testing.T) {
+		prices, topix := rsSeries(64, 110)
+		got, ok := CalculateRelativeStrengthScore(prices, topix)
+		require.True(t, ok)
+		assert.Equal(t, "7203", got.TickerSymbol)
+		assert.Equal(t, prices[63].Date, got.Date)
+		assert.Equal(t, "0.1", got.Relative3M.String())
+		assert.Nil(t, got.Relative6M)
+		assert.Nil(t, got.Relative12M)
+		assert.Equal(t, "0.1", got.Score.String())
+		assert.Zero(t, got.Rating)
+	})
+
+	t.Run("TOPIX 比で加重する", func(t *testing.T) {
+		prices, topix := rsSeries(253, 110)
+		topix[252].Adjclose = decimal.NewFromInt(1100) // 当日 TOPIX も +10%
+		topix[0].Adjclose = decimal.NewFromInt(500)    // 12か月前の TOPIX は半値
+		// 3か月前の TOPIX が欠けていても直前の営業日の値で補う
+		topix = append(topix[:189:189], topix[190:]...)
+
+		got, ok := CalculateRelativeStrengthScore(prices, topix)
+		require.True(t, ok)
+		assert.True(t, got.Relative3M.IsZero())
+		assert.True(t, got.Relative6M.IsZero())
+		assert.True(t, got.Relative9M.IsZero())
+		assert.Equal(t, "-0.5", got.Relative12M.String())
+		assert.Equal(t, "-0.1", got.Score.String())
+	})
+
+	t.Run("履歴不足・TOPIX 無しは算出しない", func(t *testing.T) {
+		prices, topix := rsSeries(63, 110)
+		_, ok := CalculateRelativeStrengthScore(prices, topix)
+		assert.False(t, ok)
+
+		prices, _ = rsSeries(64, 110)
+		_, ok = CalculateRelativeStrengthScore(prices, nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestAssignRelativeStrengthRatings(t *testing.T) {
+	ratings := make([]*models.RelativeStrengthRating, 0)
+	for _, s := range []string{"0.3", "0.1", "0.2", "0.2", "-0.1"} {
+		ratings = append(ratings, &models.RelativeStrengthRating{Score: decimal.RequireFromString(s)})
+	}
+	AssignRelativeStrengthRatings(ratings)
+
+	got := make([]int, 0, len(ratings))
+	for _, r := range ratings {
+		got = append(got, r.Rating)
+	}
+	assert.Equal(t, []int{99, 26, 50, 50, 1}, got)
+
+	single := []*models.RelativeStrengthRating{{Score: decimal.Zero}}
+	AssignRelativeStrengthRatings(single)
+	assert.Equal(t, 99, single[0].Rating)
+
+	AssignRelativeStrengthRatings(nil)
+}
@@ -0,0 +1,167 @@
+package domain_service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// SectorRotationWeeklyBars RS-Momentum の最初の1点を出すのに必要な週足の本数（window 週の RS-Ratio とその変化率の window 週分）。
+func SectorRotationWeeklyBars(window int) int {
+	return 2 * window
+}
+
+// weeklyClose 週の最終営業日とその終値。
+type weeklyClose struct {
+	date  time.Time
+	close decimal.Decimal
+}
+
+// CalcSector33Rotation 33業種平均日足と TOPIX 日足（いずれも date 昇順）から、業種ごとの RRG（JdK RS-Ratio / RS-Momentum）の直近 tail 週分を算出する。
+// adj_close がゼロの行はスキップする。週足が足りない業種は返さない。結果は業種コード昇順。
+func CalcSector33Rotation(rows []*models.Sector33AverageDailyPrice, topix models.IndexStockAverageDailyPrices, names map[string]string, window, tail int) []*models.SectorRotationItem {
+	byCode := make(map[string][]weeklyClose)
+	for _, row := range rows {
+		if row.SectorCode == "" || row.Adjclose.IsZero() {
+			continue
+		}
+		byCode[row.SectorCode] = append(byCode[row.SectorCode], weeklyClose{date: row.Date, close: row.Adjclose})
+	}
+	return calcSectorRotation(byCode, topix, names, window, tail)
+}
+
+// CalcSector17Rotation 17業種平均日足版の CalcSector33Rotation。
+func CalcSector17Rotation(rows []*models.Sector17AverageDailyPrice, topix models.IndexStockAverageDailyPrices, names map[string]string, window, tail int) []*models.SectorRotationItem {
+	byCode := make(map[string][]weeklyClose)
+	for _, row := range rows {
+		if row.SectorCode == "" || row.Adjclose.IsZero() {
+			continue
+		}
+		byCode[row.SectorCode] = append(byCode[row.SectorCode], weeklyClose{date: row.Date, close: row.Adjclose})
+	}
+	return calcSectorRotation(byCode, topix, names, window, tail)
+}
+
+func calcSectorRotation(dailyByCode map[string][]weeklyClose, topix models.IndexStockAverageDailyPrices, names map[string]string, window, tail int) []*models.SectorRotationItem {
+	topixDaily := make([]weeklyClose, 0, len(topix))
+	for _, p := range topix {
+		if !p.Close.IsZero() {
+			topixDaily = append(topixDaily, weeklyClose{date: p.Date, close: p.Close})
+		}
+	}
+	benchmark := make(map[[2]int]decimal.Decimal)
+	for _, w := range toWeeklyCloses(topixDaily) {
+		benchmark[isoWeekKey(w.date)] = w.close
+	}
+
+	items := make([]*models.SectorRotationItem, 0, len(dailyByCode))
+	for code, daily := range dailyByCode {
+		var dates []time.Time
+		var rs []decimal.Decimal
+		for _, w := range toWeeklyCloses(daily) {
+			b, ok := benchmark[isoWeekKey(w.date)]
+			if !ok {
+				continue
+			}
+			dates = append(dates, w.date)
+			rs = append(rs, w.close.Div(b).Mul(decimal.NewFromInt(100)))
+		}
+
+		points := rrgPoints(dates, rs, window)
+		if len(points) == 0 {
+			continue
+		}
+		if len(points) > tail {
+			points = points[len(points)-tail:]
+		}
+		name, ok := names[code]
+		if !ok {
+			name = code
+		}
+		last := points[len(points)-1]
+		items = append(items, &models.SectorRotationItem{
+			SectorCode: code,
+			SectorName: name,
+			Quadrant:   sectorQuadrant(last.RSRatio, last.RSMomentum),
+			Tail:       points,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].SectorCode < items[j].SectorCode })
+	return items
+}
+
+// rrgPoints 週次の相対強度 rs から RRG の点列を作る。
+//   - RS-Ratio = 100 + rs の直近 window 週の z スコア
+//   - RS-Momentum = 100 + RS-Ratio の前週比変化率(%)の直近 window 週の z スコア
+func rrgPoints(dates []time.Time, rs []decimal.Decimal, window int) []*models.SectorRotationPoint {
+	n := len(rs)
+	if window < 2 || n < SectorRotationWeeklyBars(window) {
+		return nil
+	}
+	hundred := decimal.NewFromInt(100)
+
+	ratio := make([]decimal.Decimal, n)
+	for i := window - 1; i < n; i++ {
+		ratio[i] = hundred.Add(zScore(rs[i-window+1:i+1], rs[i]))
+	}
+	roc := make([]decimal.Decimal, n)
+	for i := window; i < n; i++ {
+		roc[i] = ratio[i].Div(ratio[i-1]).Sub(decimal.NewFromInt(1)).Mul(hundred)
+	}
+
+	points := make([]*models.SectorRotationPoint, 0, n-SectorRotationWeeklyBars(window)+1)
+	for i := SectorRotationWeeklyBars(window) - 1; i < n; i++ {
+		points = append(points, &models.SectorRotationPoint{
+			Date:       dates[i].Format(util.DateLayout),
+			RSRatio:    ratio[i].Round(4),
+			RSMomentum: hundred.Add(zScore(roc[i-window+1:i+1], roc[i])).Round(4),
+		})
+	}
+	return points
+}
+
+// zScore x の xs（標本標準偏差）に対する z スコア。ばらつきが無ければ 0。
+func zScore(xs []decimal.Decimal, x decimal.Decimal) decimal.Decimal {
+	sd := stdDevSample(xs)
+	if sd.IsZero() {
+		return decimal.Zero
+	}
+	return x.Sub(mean(xs)).Div(sd)
+}
+
+func sectorQuadrant(ratio, momentum decimal.Decimal) string {
+	hundred := decimal.NewFromInt(100)
+	switch {
+	case ratio.GreaterThanOrEqual(hundred) && momentum.GreaterThanOrEqual(hundred):
+		return models.SectorQuadrantLeading
+	case ratio.GreaterThanOrEqual(hundred):
+		return models.SectorQuadrantWeakening
+	case momentum.LessThan(hundred):
+		return models.SectorQuadrantLagging
+	default:
+		return models.SectorQuadrantImproving
+	}
+}
+
+// toWeeklyCloses date 昇順の日次終値を ISO 週ごとの最終営業日の終値にまとめる。
+func toWeeklyCloses(daily []weeklyClose) []weeklyClose {
+	weekly := make([]weeklyClose, 0, len(daily)/5+1)
+	for _, d := range daily {
+		if len(weekly) > 0 && isoWeekKey(weekly[len(weekly)-1].date) == isoWeekKey(d.date) {
+			weekly[len(weekly)-1] = d
+			continue
+		}
+		weekly = append(weekly, d)
+	}
+	return weekly
+}
+
+func isoWeekKey(t time.Time) [2]int {
+	y, w := t.ISOWeek()
+	return [2]int{y, w}
+}
@@ -0,0 +1,99 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// rotationRows 2024-01-01(月) から weeks 週分、月〜金に週ごとの終値 closeOf(週) が並ぶ33業種日足。
+func rotationRows(code string, weeks int, closeOf func(w int) float64) []*models.Sector33AverageDailyPrice {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var rows []*models.Sector33AverageDailyPrice
+	for w := range weeks {
+		for d := range 5 {
+			rows = append(rows, &models.Sector33AverageDailyPrice{
+				Date:       base.AddDate(0, 0, w*7+d),
+				SectorCode: code,
+				Adjclose:   decimal.NewFromFloat(closeOf(w)),
+			})
+		}
+	}
+	return rows
+}
+
+// rotationTopix rotationRows と同じ営業日に終値 1000 が並ぶ TOPIX 日足。
+func rotationTopix(weeks int) models.IndexStockAverageDailyPrices {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var out models.IndexStockAverageDailyPrices
+	for w := range weeks {
+		for d := range 5 {
+			out = append(out, &models.IndexStockAverageDailyPrice{Date: base.AddDate(0, 0, w*7+d), Close: decimal.NewFromInt(1000)})
+		}
+	}
+	return out
+}
+
+func TestCalcSector33Rotation(t *testing.T) {
+	const weeks, window, tail = 30, 5, 4
+
+	// 20週横ばいの後、加速しながら TOPIX を上回る / 下回る
+	accelerating := func(sign float64) func(w int) float64 {
+		return func(w int) float64 {
+			if w < 20 {
+				return 1000
+			}
+			k := float64(w - 19)
+			return 1000 + sign*k*k*5
+		}
+	}
+
+	var rows []*models.Sector33AverageDailyPrice
+	rows = append(rows, rotationRows("3050", weeks, accelerating(1))...)
+	rows = append(rows, rotationRows("3100", weeks, accelerating(-1))...)
+	rows = append(rows, rotationRows("3150", 6, func(int) float64 { return 1000 })...) // 週足不足
+	names := map[string]string{"3050": "食料品"}
+
+	got := CalcSector33Rotation(rows, rotationTopix(weeks), names, window, tail)
+	require.Len(t, got, 2)
+
+	up := got[0]
+	assert.Equal(t, "3050", up.SectorCode)
+	assert.Equal(t, "食料品", up.SectorName)
+	require.Len(t, up.Tail, tail)
+	assert.Equal(t, "2024-07-26", up.Tail[tail-1].Date, "週の最終営業日（金曜）")
+	assert.True(t, up.Tail[tail-1].RSRatio.GreaterThan(decimal.NewFromInt(100)))
+	assert.Equal(t, models.SectorQuadrantLeading, up.Quadrant)
+
+	down := got[1]
+	assert.Equal(t, "3100", down.SectorName, "業種名が無ければコード")
+	assert.True(t, down.Tail[tail-1].RSRatio.LessThan(decimal.NewFromInt(100)))
+	assert.Equal(t, models.SectorQuadrantLagging, down.Quadrant)
+}
+
+func TestSectorQuadrant(t *testing.T) {
+	d := decimal.NewFromFloat
+	assert.Equal(t, models.SectorQuadrantLeading, sectorQuadrant(d(101), d(100)))
+	assert.Equal(t, models.SectorQuadrantWeakening, sectorQuadrant(d(101), d(99)))
+	assert.Equal(t, models.SectorQuadrantLagging, sectorQuadrant(d(99), d(99)))
+	assert.Equal(t, models.SectorQuadrantImproving, sectorQuadrant(d(99), d(101)))
+}
+
+func TestToWeeklyCloses(t *testing.T) {
+	mon := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	daily := []weeklyClose{
+		{date: mon, close: decimal.NewFromInt(1)},
+		{date: mon.AddDate(0, 0, 3), close: decimal.NewFromInt(2)},
+		{date: mon.AddDate(0, 0, 7), close: decimal.NewFromInt(3)},
+	}
+	got := toWeeklyCloses(daily)
+	require.Len(t, got, 2)
+	assert.Equal(t, mon.AddDate(0, 0, 3), got[0].date)
+	assert.Equal(t, "2", got[0].close.String())
+	assert.Equal(t, "3", got[1].close.String())
+}
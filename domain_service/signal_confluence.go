@@ -0,0 +1,204 @@
+package domain_service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// SignalConfluenceParams 合流スコアのパラメータ。
+type SignalConfluenceParams struct {
+	LookbackDays int             // 合流に数える営業日数（基準日含む）
+	HalfLifeDays decimal.Decimal // 減衰の半減期（営業日）。0 以下なら減衰させない
+	Horizon      int             // 的中率を測る保有営業日数（/signal-performance の horizon）
+	PriorCount   int             // 的中率を 0.5 に縮める疑似件数（評価件数の少ない手法を過信しない）
+}
+
+// DefaultSignalConfluenceParams 標準の合流スコアパラメータ（直近5営業日・半減期2営業日・5営業日後の的中率）。
+func DefaultSignalConfluenceParams() SignalConfluenceParams {
+	return SignalConfluenceParams{
+		LookbackDays: 5,
+		HalfLifeDays: decimal.NewFromInt(2),
+		Horizon:      5,
+		PriorCount:   20,
+	}
+}
+
+// signalConfluencePriorHitRate 評価実績の無い手法の的中率（コイン投げ）。
+var signalConfluencePriorHitRate = decimal.RequireFromString("0.5")
+
+// SignalMethodHitRate 1手法の的中率。
+type SignalMethodHitRate struct {
+	EvaluatedCount int
+	HitRate        decimal.Decimal
+}
+
+// SignalMethodHitRates AggregateSignalPerformance の手法別サマリから horizon の的中率を返す。
+// 的中率は priorCount 件分の 0.5 を混ぜて縮める（(勝ち + 0.5×priorCount) / (評価件数 + priorCount)、Round(4)）。
+func SignalMethodHitRates(summaries []*models.SignalPerformanceSummary, horizon, priorCount int) map[string]SignalMethodHitRate {
+	prior := decimal.NewFromInt(int64(priorCount))
+	out := make(map[string]SignalMethodHitRate, len(summaries))
+	for _, s := range summaries {
+		stats := s.Stats[horizon]
+		if stats == nil {
+			continue
+		}
+		denom := decimal.NewFromInt(int64(stats.EvaluatedCount)).Add(prior)
+		hitRate := signalConfluencePriorHitRate
+		if denom.IsPositive() {
+			hitRate = decimal.NewFromInt(int64(stats.WinCount)).Add(signalConfluencePriorHitRate.Mul(prior)).Div(denom).Round(4)
+		}
+		out[s.Method] = SignalMethodHitRate{EvaluatedCount: stats.EvaluatedCount, HitRate: hitRate}
+	}
+	return out
+}
+
+// SignalConfluenceDecay ageDays 営業日前のシグナルの減衰係数 0.5^(ageDays/halfLifeDays)（Round(4)）。
+func SignalConfluenceDecay(ageDays int, halfLifeDays decimal.Decimal) decimal.Decimal {
+	if ageDays <= 0 || !halfLifeDays.IsPositive() {
+		return decimal.NewFromInt(1)
+	}
+	return decimal.NewFromFloat(math.Pow(0.5, float64(ageDays)/halfLifeDays.InexactFloat64())).Round(4)
+}
+
+// ScoreSignalConfluence 直近 LookbackDays 営業日のシグナルを銘柄ごとに合流させ、Score 降順（同点は銘柄コード昇順）で返す。
+// tradingDates は基準日を先頭とする営業日の降順。シグナルの経過営業日数は、シグナル日より後の営業日の数で数える。
+// 同じ手法・同じ方向のシグナルは直近の1件だけを数え、寄与は コイン投げ（0.5）を上回る的中率の分 × 減衰
+// （max(的中率 - 0.5, 0) × 減衰）。的中率が 5割以下の手法は何本出ても寄与しない。
+// Buy と Sell の寄与はそれぞれ合計し、差の符号を優勢な方向、差の絶対値を Score とする（両方に寄与があれば Conflict）。
+// 的中率が未算出の手法は 0.5（寄与 0）とみなす。
+func ScoreSignalConfluence(
+	signals []*models.AnalyzeStockBrandPriceHistory,
+	tradingDates []time.Time,
+	hitRates map[string]SignalMethodHitRate,
+	params SignalConfluenceParams,
+) []*models.WeightedSignalStock {
+	if len(tradingDates) == 0 {
+		return []*models.WeightedSignalStock{}
+	}
+	dates := make([]string, len(tradingDates))
+	for i, d := range tradingDates {
+		dates[i] = util.DatetimeToDateStr(d)
+	}
+	asOf := dates[0]
+	ageOf := func(d time.Time) int {
+		s := util.DatetimeToDateStr(d)
+		age := 0
+		for _, td := range dates {
+			if td > s {
+				age++
+			}
+		}
+		return age
+	}
+
+	type key struct{ method, action string }
+	stocks := make(map[string]*models.WeightedSignalStock)
+	latest := make(map[string]map[key]*models.SignalConfluenceContribution)
+	for _, sg := range signals {
+		if util.DatetimeToDateStr(sg.CreatedAt) > asOf {
+			continue
+		}
+		if sg.Action != models.AnalyzeStockBrandPriceHistoryActionBuy && sg.Action != models.AnalyzeStockBrandPriceHistoryActionSell {
+			continue
+		}
+		age := ageOf(sg.CreatedAt)
+		if age >= params.LookbackDays {
+			continue
+		}
+
+		if _, ok := stocks[sg.TickerSymbol]; !ok {
+			stocks[sg.TickerSymbol] = &models.WeightedSignalStock{
+				StockBrandID: sg.StockBrandID,
+				Name:         sg.Name,
+				TickerSymbol: sg.TickerSymbol,
+			}
+			latest[sg.TickerSymbol] = make(map[key]*models.SignalConfluenceContribution)
+		}
+		k := key{sg.Method, sg.Action}
+		if prev, ok := latest[sg.TickerSymbol][k]; ok && prev.AgeDays <= age {
+			continue
+		}
+
+		rate, ok := hitRates[sg.Method]
+		if !ok {
+			rate = SignalMethodHitRate{HitRate: signalConfluencePriorHitRate}
+		}
+		decay := SignalConfluenceDecay(age, params.HalfLifeDays)
+		latest[sg.TickerSymbol][k] = &models.SignalConfluenceContribution{
+			Method:         sg.Method,
+			Action:         sg.Action,
+			Date:           sg.CreatedAt,
+			AgeDays:        age,
+			HitRate:        rate.HitRate,
+			EvaluatedCount: rate.EvaluatedCount,
+			Decay:          decay,
+			Contribution:   decimal.Max(rate.HitRate.Sub(signalConfluencePriorHitRate), decimal.Zero).Mul(decay).Round(4),
+		}
+	}
+
+	out := make([]*models.WeightedSignalStock, 0, len(stocks))
+	for symbol, st := range stocks {
+		buy, sell := decimal.Zero, decimal.Zero
+		for _, c := range latest[symbol] {
+			if c.Action == models.AnalyzeStockBrandPriceHistoryActionBuy {
+				buy = buy.Add(c.Contribution)
+			} else {
+				sell = sell.Add(c.Contribution)
+			}
+			if c.Date.After(st.Date) {
+				st.Date = c.Date
+			}
+			st.Contributions = append(st.Contributions, c)
+		}
+		sort.Slice(st.Contributions, func(i, j int) bool {
+			a, b := st.Contributions[i], st.Contributions[j]
+			if !a.Contribution.Equal(b.Contribution) {
+				return a.Contribution.GreaterThan(b.Contribution)
+			}
+			if a.Method != b.Method {
+				return a.Method < b.Method
+			}
+			return a.Action < b.Action
+		})
+
+		net := buy.Sub(sell)
+		switch {
+		case net.IsPositive():
+			st.Action = models.AnalyzeStockBrandPriceHistoryActionBuy
+		case net.IsNegative():
+			st.Action = models.AnalyzeStockBrandPriceHistoryActionSell
+		}
+		st.BuyScore = buy
+		st.SellScore = sell
+		st.Score = net.Abs()
+		st.Conflict = buy.IsPositive() && sell.IsPositive()
+		st.SignalCount = len(st.Contributions)
+		out = append(out, st)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Score.Equal(out[j].Score) {
+			return out[i].Score.GreaterThan(out[j].Score)
+		}
+		return out[i].TickerSymbol < out[j].TickerSymbol
+	})
+	return out
+}
+
+// SignalConfluenceBuyStrength 買い推奨のスコアに使う合流の強さ（BuyScore - SellScore、負なら 0）。
+func SignalConfluenceBuyStrength(s *models.WeightedSignalStock) decimal.Decimal {
+	if s == nil {
+		return decimal.Zero
+	}
+	net := s.BuyScore.Sub(s.SellScore)
+	if net.IsNegative() {
+		return decimal.Zero
+	}
+	return net
+}
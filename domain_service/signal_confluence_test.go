@@ -0,0 +1,180 @@
+package domain_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestSignalMethodHitRates(t *testing.T) {
+	summaries := []*models.SignalPerformanceSummary{
+		{Method: "a", Stats: map[int]*models.HorizonStats{5: {EvaluatedCount: 80, WinCount: 60}}},
+		{Method: "b", Stats: map[int]*models.HorizonStats{5: {}}},
+		{Method: "c", Stats: map[int]*models.HorizonStats{10: {EvaluatedCount: 10, WinCount: 10}}},
+	}
+
+	got := SignalMethodHitRates(summaries, 5, 20)
+	require.Len(t, got, 2, "horizon の統計が無い手法は含めない")
+	assert.Equal(t, 80, got["a"].EvaluatedCount)
+	assert.Equal(t, "0.7", got["a"].HitRate.String(), "(60 + 0.5*20) / (80 + 20)")
+	assert.Equal(t, "0.5", got["b"].HitRate.String(), "評価0件は 0.5")
+
+	noPrior := SignalMethodHitRates(summaries, 5, 0)
+	assert.Equal(t, "0.75", noPrior["a"].HitRate.String())
+	assert.Equal(t, "0.5", noPrior["b"].HitRate.String(), "評価0件かつ疑似件数0でもゼロ除算しない")
+}
+
+func TestSignalConfluenceDecay(t *testing.T) {
+	two := decimal.NewFromInt(2)
+	assert.Equal(t, "1", SignalConfluenceDecay(0, two).String())
+	assert.Equal(t, "0.5", SignalConfluenceDecay(2, two).String())
+	assert.Equal(t, "0.7071", SignalConfluenceDecay(1, two).String())
+	assert.Equal(t, "1", SignalConfluenceDecay(3, decimal.Zero).String(), "半減期0なら減衰しない")
+}
+
+func TestScoreSignalConfluence(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, time.UTC) }
+	// 基準日 7/10 から遡る営業日（7/4・7/5 は休日）
+	tradingDates := []time.Time{day(10), day(9), day(8), day(7), day(6)}
+	params := DefaultSignalConfluenceParams()
+	hitRates := map[string]SignalMethodHitRate{
+		"a": {EvaluatedCount: 100, HitRate: decimal.RequireFromString("0.6")},
+		"b": {EvaluatedCount: 50, HitRate: decimal.RequireFromString("0.55")},
+	}
+	signal := func(symbol, method, action string, d time.Time) *models.AnalyzeStockBrandPriceHistory {
+		return &models.AnalyzeStockBrandPriceHistory{StockBrandID: "brand-" + symbol, Name: "銘柄" + symbol, TickerSymbol: symbol, Method: method, Action: action, CreatedAt: d}
+	}
+	buy, sell := models.AnalyzeStockBrandPriceHistoryActionBuy, models.AnalyzeStockBrandPriceHistoryActionSell
+
+	got := ScoreSignalConfluence([]*models.AnalyzeStockBrandPriceHistory{
+		// 1000: 当日 a(Buy) と 2営業日前 b(Buy)。a は 2営業日前にも出ているが直近の1件だけ数える
+		signal("1000", "a", buy, day(8)),
+		signal("1000", "a", buy, day(10)),
+		signal("1000", "b", buy, day(8)),
+		// 2000: 当日 a(Buy) と当日 b(Sell) で拮抗 → Buy 優勢だが Conflict
+		signal("2000", "a", buy, day(10)),
+		signal("2000", "b", sell, day(10)),
+		// 3000: 的中率未算出の手法の Sell
+		signal("3000", "x", sell, day(9)),
+		// 4000: LookbackDays より古い・基準日より後・方向不明はすべて対象外
+		signal("4000", "a", buy, day(3)),
+		signal("4000", "a", buy, day(13)),
+		signal("4000", "a", "Hold", day(10)),
+	}, tradingDates, hitRates, params)
+
+	require.Len(t, got, 3)
+
+	first := got[0]
+	assert.Equal(t, "1000", first.TickerSymbol)
+	assert.Equal(t, "brand-1000", first.StockBrandID)
+	assert.Equal(t, "銘柄1000", first.Name)
+	assert.Equal(t, buy, first.Action)
+	assert.Equal(t, "0.125", first.Score.String(), "(0.6-0.5)*1 + (0.55-0.5)*0.5")
+	assert.Equal(t, "0.125", first.BuyScore.String())
+	assert.True(t, first.SellScore.IsZero())
+	assert.False(t, first.Conflict)
+	assert.Equal(t, 2, first.SignalCount)
+	assert.Equal(t, day(10), first.Date)
+	require.Len(t, first.Contributions, 2)
+	assert.Equal(t, "a", first.Contributions[0].Method, "寄与の大きい順")
+	assert.Equal(t, 0, first.Contributions[0].AgeDays)
+	assert.Equal(t, 100, first.Contributions[0].EvaluatedCount)
+	assert.Equal(t, 2, first.Contributions[1].AgeDays)
+	assert.Equal(t, "0.5", first.Contributions[1].Decay.String())
+
+	second := got[1]
+	assert.Equal(t, "2000", second.TickerSymbol)
+	assert.Equal(t, buy, second.Action)
+	assert.Equal(t, "0.05", second.Score.String())
+	assert.True(t, second.Conflict)
+
+	third := got[2]
+	assert.Equal(t, "3000", third.TickerSymbol)
+	assert.Empty(t, third.Action, "未算出の手法は 0.5 とみなし寄与しない")
+	assert.True(t, third.Score.IsZero())
+	assert.Equal(t, "0.5", third.Contributions[0].HitRate.String())
+	assert.Equal(t, 0, third.Contributions[0].EvaluatedCount)
+
+	assert.Empty(t, ScoreSignalConfluence(nil, nil, hitRates, params))
+}
+
+func TestScoreSignalConfluence_Tie(t *testing.T) {
+	d := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	got := ScoreSignalConfluence([]*models.AnalyzeStockBrandPriceHistory{
+		{TickerSymbol: "1000", Method: "a", Action: models.AnalyzeStockBrandPriceHistoryActionBuy, CreatedAt: d},
+		{TickerSymbol: "1000", Method: "b", Action: models.AnalyzeStockBrandPriceHistoryActionSell, CreatedAt: d},
+	}, []time.Time{d}, map[string]SignalMethodHitRate{
+		"a": {EvaluatedCount: 100, HitRate: decimal.RequireFromString("0.6")},
+		"b": {EvaluatedCount: 100, HitRate: decimal.RequireFromString("0.6")},
+	}, DefaultSignalConfluenceParams())
+
+	require.Len(t, got, 1)
+	assert.Empty(t, got[0].Action, "Buy と Sell が拮抗すれば方向なし")
+	assert.True(t, got[0].Score.IsZero())
+	assert.True(t, got[0].Conflict)
+}
+
+func TestScoreSignalConfluence_BelowCoinFlip(t *testing.T) {
+	d := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	hitRates := map[string]SignalMethodHitRate{
+		"good": {EvaluatedCount: 100, HitRate: decimal.RequireFromString("0.6")},
+		"bad":  {EvaluatedCount: 100, HitRate: decimal.RequireFromString("0.4")},
+		"bad2": {EvaluatedCount: 100, HitRate: decimal.RequireFromString("0.3")},
+	}
+	signal := func(symbol, method, action string) *models.AnalyzeStockBrandPriceHistory {
+		return &models.AnalyzeStockBrandPriceHistory{TickerSymbol: symbol, Method: method, Action: action, CreatedAt: d}
+	}
+	buy, sell := models.AnalyzeStockBrandPriceHistoryActionBuy, models.AnalyzeStockBrandPriceHistoryActionSell
+
+	got := ScoreSignalConfluence([]*models.AnalyzeStockBrandPriceHistory{
+		// 1000: コイン投げ以下の手法が何本 Buy を出しても的中率6割の手法1本に及ばない
+		signal("1000", "bad", buy),
+		signal("1000", "bad2", buy),
+		// 2000: 的中率6割の Buy に、コイン投げ以下の Sell を重ねても打ち消さない
+		signal("2000", "good", buy),
+		signal("2000", "bad", sell),
+	}, []time.Time{d}, hitRates, DefaultSignalConfluenceParams())
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "2000", got[0].TickerSymbol)
+	assert.Equal(t, buy, got[0].Action)
+	assert.Equal(t, "0.1", got[0].Score.String())
+	assert.True(t, got[0].SellScore.IsZero(), "5割以下の手法は寄与しない")
+	assert.False(t, got[0].Conflict, "寄与 0 の逆方向シグナルは拮抗に数えない")
+
+	assert.Equal(t, "1000", got[1].TickerSymbol)
+	assert.Empty(t, got[1].Action)
+	assert.True(t, got[1].Score.IsZero())
+	assert.Equal(t, 2, got[1].SignalCount)
+	for _, c := range got[1].Contributions {
+		assert.True(t, c.Contribution.IsZero(), c.Method)
+	}
+}
+
+func TestSignalConfluenceBuyStrength(t *testing.T) {
+	assert.True(t, SignalConfluenceBuyStrength(nil).IsZero())
+	assert.Equal(t, "0.1", SignalConfluenceBuyStrength(&models.WeightedSignalStock{
+		BuyScore: decimal.RequireFromString("0.15"), SellScore: decimal.RequireFromString("0.05"),
+	}).String())
+	assert.True(t, SignalConfluenceBuyStrength(&models.WeightedSignalStock{
+		BuyScore: decimal.RequireFromString("0.02"), SellScore: decimal.RequireFromString("0.05"),
+	}).IsZero(), "Sell 優勢は 0")
+}
+
+func TestApplyDailyPickConfluence(t *testing.T) {
+	weights := DailyPickScoreWeights{Signal: decimal.NewFromInt(50), Confluence: decimal.NewFromInt(50)}
+	c := &DailyPickCandidate{Metrics: DailyPickMetrics{SignalCount: 3}}
+	c.Score = ScoreDailyPick(c.Metrics, weights)
+
+	got := ApplyDailyPickConfluence(c, decimal.RequireFromString("0.1"), weights)
+	assert.Equal(t, "85", got.Score.String(), "50*1.00 + 50*0.70")
+	assert.Equal(t, "50", c.Score.String(), "元の候補は変更しない")
+	require.Len(t, got.Components, len(DailyPickComponentKeys))
+	assert.Equal(t, DailyPickComponentConfluence, got.Components[len(got.Components)-1].Key)
+	assert.Equal(t, "0.7", got.Components[len(got.Components)-1].Normalized.String())
+}
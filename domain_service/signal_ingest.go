@@ -0,0 +1,83 @@
+package domain_service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+const (
+	// signalIngestMaxTickerLen 銘柄コードの最大長
+	signalIngestMaxTickerLen = 10
+	// signalIngestMaxMemoBytes memo（TEXT 列）の最大バイト数
+	signalIngestMaxMemoBytes = 65535
+)
+
+// signalIngestDecimalLimit trade_price / score（DECIMAL(10,4)）に入る絶対値の上限（未満）
+var signalIngestDecimalLimit = decimal.NewFromInt(1_000_000)
+
+// ValidateSignalIngestInputs 取り込むシグナルを analyze_stock_brand_price_history の列定義と手法の登録簿に照らして検証する。
+// 最初に見つかった誤りを *models.SignalIngestError で返す。同じバッチ内で (method, tickerSymbol, date, action) が重複するのも誤りとする。
+func ValidateSignalIngestInputs(inputs []*models.SignalIngestInput) error {
+	if len(inputs) == 0 {
+		return &models.SignalIngestError{Index: -1, Message: "historiesは1件以上指定してください"}
+	}
+	if len(inputs) > models.SignalIngestMaxBatch {
+		return &models.SignalIngestError{Index: -1, Message: fmt.Sprintf("historiesは%d件以下で指定してください", models.SignalIngestMaxBatch)}
+	}
+
+	seen := make(map[string]int, len(inputs))
+	for i, in := range inputs {
+		if msg := validateSignalIngestInput(in); msg != "" {
+			return &models.SignalIngestError{Index: i, Message: msg}
+		}
+		key := SignalIngestKey(in.Method, in.TickerSymbol, in.Date.Format(util.DateLayout), in.Action)
+		if j, ok := seen[key]; ok {
+			return &models.SignalIngestError{Index: i, Message: fmt.Sprintf("histories[%d] と method・tickerSymbol・date・action が重複しています", j)}
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+func validateSignalIngestInput(in *models.SignalIngestInput) string {
+	switch {
+	case in == nil:
+		return "シグナルが空です"
+	case in.TickerSymbol == "" || len(in.TickerSymbol) > signalIngestMaxTickerLen || !isAlphanumeric(in.TickerSymbol):
+		return fmt.Sprintf("tickerSymbolは%d文字以内の英数字である必要があります", signalIngestMaxTickerLen)
+	case in.Date.IsZero():
+		return "dateは必須です"
+	case !slices.Contains(models.AnalyzeStockBrandPriceHistoryMethods, in.Method):
+		return fmt.Sprintf("method %q は登録されていません", in.Method)
+	case in.Action != models.AnalyzeStockBrandPriceHistoryActionBuy && in.Action != models.AnalyzeStockBrandPriceHistoryActionSell:
+		return "actionはBuyまたはSellである必要があります"
+	case !in.TradePrice.IsPositive() || !in.TradePrice.LessThan(signalIngestDecimalLimit):
+		return "tradePriceは0より大きく1000000未満である必要があります"
+	case in.Score != nil && !in.Score.Abs().LessThan(signalIngestDecimalLimit):
+		return "scoreは絶対値1000000未満である必要があります"
+	case in.SignalRank != nil && *in.SignalRank < 1:
+		return "signalRankは1以上である必要があります"
+	case in.Memo != nil && len(*in.Memo) > signalIngestMaxMemoBytes:
+		return fmt.Sprintf("memoは%dバイト以内である必要があります", signalIngestMaxMemoBytes)
+	}
+	return ""
+}
+
+// SignalIngestKey 分析履歴1行を特定するキー（date は "2006-01-02"）。
+func SignalIngestKey(method, tickerSymbol, date, action string) string {
+	return method + "\x00" + tickerSymbol + "\x00" + date + "\x00" + action
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package domain_service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestValidateSignalIngestInputs(t *testing.T) {
+	date := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+	valid := func() *models.SignalIngestInput {
+		return &models.SignalIngestInput{
+			TickerSymbol: "7203",
+			Date:         date,
+			Method:       models.AnalyzeStockBrandPriceHistoryMethodFindMLRankedV1,
+			Action:       models.AnalyzeStockBrandPriceHistoryActionBuy,
+			TradePrice:   decimal.NewFromInt(2500),
+		}
+	}
+	with := func(f func(in *models.SignalIngestInput)) []*models.SignalIngestInput {
+		in := valid()
+		f(in)
+		return []*models.SignalIngestInput{in}
+	}
+	zeroRank := 0
+	bigScore := decimal.NewFromInt(1_000_000)
+	longMemo := strings.Repeat("a", 65536)
+
+	tests := []struct {
+		name    string
+		inputs  []*models.SignalIngestInput
+		wantErr string
+	}{
+		{name: "正常系", inputs: []*models.SignalIngestInput{valid()}},
+		{
+			name: "正常系: action が違えば同じ銘柄・日付でも別のシグナル",
+			inputs: []*models.SignalIngestInput{valid(), func() *models.SignalIngestInput {
+				in := valid()
+				in.Action = models.AnalyzeStockBrandPriceHistoryActionSell
+				return in
+			}()},
+		},
+		{name: "異常系: 空", inputs: nil, wantErr: "historiesは1件以上指定してください"},
+		{name: "異常系: 上限超過", inputs: make([]*models.SignalIngestInput, models.SignalIngestMaxBatch+1), wantErr: "historiesは1000件以下で指定してください"},
+		{name: "異常系: nil", inputs: []*models.SignalIngestInput{nil}, wantErr: "histories[0]: シグナルが空です"},
+		{
+			name:    "異常系: 銘柄コードが英数字でない",
+			inputs:  with(func(in *models.SignalIngestInput) { in.TickerSymbol = "72-03" }),
+			wantErr: "histories[0]: tickerSymbolは10文字以内の英数字である必要があります",
+		},
+		{
+			name:    "異常系: 日付なし",
+			inputs:  with(func(in *models.SignalIngestInput) { in.Date = time.Time{} }),
+			wantErr: "histories[0]: dateは必須です",
+		},
+		{
+			name:    "異常系: 未登録の method",
+			inputs:  with(func(in *models.SignalIngestInput) { in.Method = "find_unknown_v1" }),
+			wantErr: `histories[0]: method "find_unknown_v1" は登録されていません`,
+		},
+		{
+			name:    "異常系: action が不正",
+			inputs:  with(func(in *models.SignalIngestInput) { in.Action = "Hold" }),
+			wantErr: "histories[0]: actionはBuyまたはSellである必要があります",
+		},
+		{
+			name:    "異常系: tradePrice が0",
+			inputs:  with(func(in *models.SignalIngestInput) { in.TradePrice = decimal.Zero }),
+			wantErr: "histories[0]: tradePriceは0より大きく1000000未満である必要があります",
+		},
+		{
+			name:    "異常系: score が列の範囲外",
+			inputs:  with(func(in *models.SignalIngestInput) { in.Score = &bigScore }),
+			wantErr: "histories[0]: scoreは絶対値1000000未満である必要があります",
+		},
+		{
+			name:    "異常系: signalRank が0",
+			inputs:  with(func(in *models.SignalIngestInput) { in.SignalRank = &zeroRank }),
+			wantErr: "histories[0]: signalRankは1以上である必要があります",
+		},
+		{
+			name:    "異常系: memo が長すぎる",
+			inputs:  with(func(in *models.SignalIngestInput) { in.Memo = &longMemo }),
+			wantErr: "histories[0]: memoは65535バイト以内である必要があります",
+		},
+		{
+			name:    "異常系: バッチ内でキーが重複",
+			inputs:  []*models.SignalIngestInput{valid(), valid()},
+			wantErr: "histories[1]: histories[0] と method・tickerSymbol・date・action が重複しています",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSignalIngestInputs(tt.inputs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			var ierr *models.SignalIngestError
+			assert.ErrorAs(t, err, &ierr)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package domain_service
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+const (
+	// SignalTestIterations ブートストラップとランダムエントリーの試行回数。
+	SignalTestIterations = 1000
+	// signalTestMinCount 検定を行う最小件数。これ未満の手法は p 値・信頼区間を出さない。
+	signalTestMinCount = 5
+	// signalTestAlpha Benjamini–Hochberg 補正後に有意とみなす水準。
+	signalTestAlpha = 0.05
+)
+
+// SignalExcessReturns シグナルの各 horizon リターンからベンチマークの同じ期間のリターンを差し引いた超過リターンを返す。
+// after はシグナル日以降の日足（after[0] が基準）、benchmarkClose は "2006-01-02" → 終値。
+// 基準日か h 営業日後の日にベンチマークの終値が無ければその horizon は nil。Sell はベンチマーク側も符号を反転して比べる。
+func SignalExcessReturns(after []*models.StockBrandDailyPrice, action string, returns map[int]*decimal.Decimal, benchmarkClose map[string]decimal.Decimal) map[int]*decimal.Decimal {
+	if len(after) == 0 || returns == nil {
+		return nil
+	}
+	base, ok := benchmarkClose[after[0].Date.Format(util.DateLayout)]
+	if !ok || base.IsZero() {
+		return nil
+	}
+	out := make(map[int]*decimal.Decimal, len(returns))
+	for h, r := range returns {
+		out[h] = nil
+		if r == nil || h >= len(after) {
+			continue
+		}
+		cur, ok := benchmarkClose[after[h].Date.Format(util.DateLayout)]
+		if !ok {
+			continue
+		}
+		bench := cur.Div(base).Sub(decimal.NewFromInt(1))
+		if action == models.AnalyzeStockBrandPriceHistoryActionSell {
+			bench = bench.Neg()
+		}
+		excess := r.Sub(bench).Round(6)
+		out[h] = &excess
+	}
+	return out
+}
+
+// BenchmarkCloseByDate ベンチマーク日足を "2006-01-02" → 終値にする（終値ゼロの日は除く）。
+func BenchmarkCloseByDate(prices models.IndexStockAverageDailyPrices) map[string]decimal.Decimal {
+	out := make(map[string]decimal.Decimal, len(prices))
+	for _, p := range prices {
+		if !p.Close.IsZero() {
+			out[p.Date.Format(util.DateLayout)] = p.Close
+		}
+	}
+	return out
+}
+
+// SignalRandomEntryPool 同じ日に母集団の各銘柄を買っていた場合の horizon 別リターン（ランダムエントリーの抽選元）。
+// afters は銘柄ごとのその日以降の日足。基準価格が無い銘柄・未到来の horizon は含めない。
+func SignalRandomEntryPool(afters [][]*models.StockBrandDailyPrice, horizons []int) map[int][]float64 {
+	out := make(map[int][]float64, len(horizons))
+	for _, after := range afters {
+		returns, ok := ForwardReturns(after, models.AnalyzeStockBrandPriceHistoryActionBuy, horizons)
+		if !ok {
+			continue
+		}
+		for _, h := range horizons {
+			if r := returns[h]; r != nil {
+				out[h] = append(out[h], r.InexactFloat64())
+			}
+		}
+	}
+	return out
+}
+
+// AttachSignalTests 手法ごと・horizon ごとに超過リターンと検定結果を summaries の Tests に入れる。
+//   - 平均リターンの95%信頼区間と、平均=0 を帰無仮説とする両側 p 値をブートストラップ（iterations 回の復元抽出）で求める
+//   - 各シグナルを、同じ日の pools（シグナル日の日付キー → horizon → リターン）から無作為に選んだ銘柄に置き換えた平均を iterations 回作り、
+//     手法の平均以上になった割合を p 値とする（Sell は抽選したリターンの符号を反転する）
+//   - 両方の p 値を、同じ horizon で p 値を出せた手法の間で Benjamini–Hochberg 補正する
+//
+// 乱数は summaries（手法名順）・horizons の順に消費するため、同じシードなら同じ結果になる。
+func AttachSignalTests(
+	summaries []*models.SignalPerformanceSummary,
+	signals []*models.EvaluatedSignal,
+	pools map[string]map[int][]float64,
+	horizons []int,
+	iterations int,
+	rng *rand.Rand,
+) {
+	byMethod := make(map[string][]*models.EvaluatedSignal, len(summaries))
+	for _, s := range signals {
+		byMethod[s.Method] = append(byMethod[s.Method], s)
+	}
+
+	for _, summary := range summaries {
+		summary.Tests = make(map[int]*models.SignalHorizonTest, len(horizons))
+		for _, h := range horizons {
+			summary.Tests[h] = signalHorizonTest(byMethod[summary.Method], pools, h, iterations, rng)
+		}
+	}
+
+	for _, h := range horizons {
+		applyBenjaminiHochberg(summaries, h,
+			func(t *models.SignalHorizonTest) *decimal.Decimal { return t.PValue },
+			func(t *models.SignalHorizonTest, q decimal.Decimal) {
+				t.QValue = &q
+				t.Significant = q.InexactFloat64() <= signalTestAlpha
+			})
+		applyBenjaminiHochberg(summaries, h,
+			func(t *models.SignalHorizonTest) *decimal.Decimal { return t.BaselinePValue },
+			func(t *models.SignalHorizonTest, q decimal.Decimal) {
+				t.BaselineQValue = &q
+				t.BeatsBaseline = q.InexactFloat64() <= signalTestAlpha
+			})
+	}
+}
+
+// SignalPoolKey シグナル日をランダムエントリーの抽選元と突き合わせるキー。
+func SignalPoolKey(s *models.EvaluatedSignal) string {
+	return s.Date.Format(util.DateLayout)
+}
+
+func signalHorizonTest(signals []*models.EvaluatedSignal, pools map[string]map[int][]float64, h, iterations int, rng *rand.Rand) *models.SignalHorizonTest {
+	t := &models.SignalHorizonTest{}
+
+	var returns, excess []float64
+	type entry struct {
+		ret  float64
+		pool []float64
+		sign float64
+	}
+	var entries []entry
+	for _, s := range signals {
+		if s.Returns == nil || s.Returns[h] == nil {
+			continue
+		}
+		r := s.Returns[h].InexactFloat64()
+		returns = append(returns, r)
+		if e := s.ExcessReturns[h]; e != nil {
+			excess = append(excess, e.InexactFloat64())
+		}
+		if pool := pools[SignalPoolKey(s)][h]; len(pool) > 0 {
+			sign := 1.0
+			if s.Action == models.AnalyzeStockBrandPriceHistoryActionSell {
+				sign = -1
+			}
+			entries = append(entries, entry{ret: r, pool: pool, sign: sign})
+		}
+	}
+
+	t.ExcessCount = len(excess)
+	if len(excess) > 0 {
+		avg := decimal.NewFromFloat(meanFloat(excess)).Round(6)
+		wins := 0
+		for _, e := range excess {
+			if e > 0 {
+				wins++
+			}
+		}
+		rate := decimal.NewFromInt(int64(wins)).Div(decimal.NewFromInt(int64(len(excess)))).Round(4)
+		t.AvgExcessReturn, t.ExcessWinRate = &avg, &rate
+	}
+
+	if len(returns) >= signalTestMinCount && iterations > 0 {
+		observed := meanFloat(returns)
+		means := make([]float64, iterations)
+		extreme := 0
+		for i := range means {
+			sum := 0.0
+			for range returns {
+				sum += returns[rng.Intn(len(returns))]
+			}
+			means[i] = sum / float64(len(returns))
+			// 平均0へ平行移動した分布で、観測値以上に0から離れる割合
+			if math.Abs(means[i]-observed) >= math.Abs(observed) {
+				extreme++
+			}
+		}
+		slices.Sort(means)
+		lo := decimal.NewFromFloat(percentileSorted(means, 0.025)).Round(6)
+		hi := decimal.NewFromFloat(percentileSorted(means, 0.975)).Round(6)
+		p := decimal.NewFromFloat(float64(extreme+1) / float64(iterations+1)).Round(4)
+		t.CILow, t.CIHigh, t.PValue = &lo, &hi, &p
+	}
+
+	t.BaselineCount = len(entries)
+	if len(entries) >= signalTestMinCount && iterations > 0 {
+		observed, expected := 0.0, 0.0
+		for _, e := range entries {
+			observed += e.ret
+			expected += e.sign * meanFloat(e.pool)
+		}
+		observed /= float64(len(entries))
+		expected /= float64(len(entries))
+		atLeast := 0
+		for range iterations {
+			sum := 0.0
+			for _, e := range entries {
+				sum += e.sign * e.pool[rng.Intn(len(e.pool))]
+			}
+			if sum/float64(len(entries)) >= observed {
+				atLeast++
+			}
+		}
+		avg := decimal.NewFromFloat(expected).Round(6)
+		p := decimal.NewFromFloat(float64(atLeast+1) / float64(iterations+1)).Round(4)
+		t.BaselineAvgReturn, t.BaselinePValue = &avg, &p
+	}
+	return t
+}
+
+// applyBenjaminiHochberg horizon h で p 値を出せた手法の p 値を Benjamini–Hochberg 補正して set で書き戻す。
+func applyBenjaminiHochberg(
+	summaries []*models.SignalPerformanceSummary,
+	h int,
+	get func(*models.SignalHorizonTest) *decimal.Decimal,
+	set func(*models.SignalHorizonTest, decimal.Decimal),
+) {
+	var tests []*models.SignalHorizonTest
+	var pvalues []float64
+	for _, s := range summaries {
+		if t := s.Tests[h]; t != nil && get(t) != nil {
+			tests = append(tests, t)
+			pvalues = append(pvalues, get(t).InexactFloat64())
+		}
+	}
+	for i, q := range BenjaminiHochberg(pvalues) {
+		set(tests[i], decimal.NewFromFloat(q).Round(4))
+	}
+}
+
+// BenjaminiHochberg p 値を Benjamini–Hochberg 法で補正した q 値を入力と同じ並びで返す（1 を上限とする）。
+func BenjaminiHochberg(pvalues []float64) []float64 {
+	m := len(pvalues)
+	order := make([]int, m)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case pvalues[a] < pvalues[b]:
+			return -1
+		case pvalues[a] > pvalues[b]:
+			return 1
+		}
+		return 0
+	})
+
+	out := make([]float64, m)
+	running := 1.0
+	for k := m - 1; k >= 0; k-- {
+		i := order[k]
+		q := pvalues[i] * float64(m) / float64(k+1)
+		running = math.Min(running, q)
+		out[i] = running
+	}
+	return out
+}
+
+func meanFloat(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
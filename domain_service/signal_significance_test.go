@@ -0,0 +1,123 @@
+package domain_service
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func significanceSignal(method, action string, ret float64) *models.EvaluatedSignal {
+	r := decimal.NewFromFloat(ret)
+	return &models.EvaluatedSignal{
+		Method:  method,
+		Action:  action,
+		Date:    time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+		Returns: map[int]*decimal.Decimal{5: &r},
+	}
+}
+
+func TestBenjaminiHochberg(t *testing.T) {
+	got := BenjaminiHochberg([]float64{0.01, 0.04, 0.03, 0.5})
+	want := []float64{0.04, 0.04 * 4 / 3, 0.04 * 4 / 3, 0.5}
+	for i := range want {
+		assert.InDelta(t, want[i], got[i], 1e-9, "index=%d", i)
+	}
+	assert.InDeltaSlice(t, []float64{0.9, 0.9}, BenjaminiHochberg([]float64{0.9, 0.8}), 1e-9, "順位が上の q 値は下の q 値を超えない")
+	assert.Empty(t, BenjaminiHochberg(nil))
+}
+
+func TestSignalExcessReturns(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, time.UTC) }
+	after := []*models.StockBrandDailyPrice{
+		{Date: day(1)}, {Date: day(2)}, {Date: day(3)},
+	}
+	r1 := decimal.RequireFromString("0.03")
+	r2 := decimal.RequireFromString("0.05")
+	returns := map[int]*decimal.Decimal{1: &r1, 2: &r2}
+	bench := BenchmarkCloseByDate(models.IndexStockAverageDailyPrices{
+		{Date: day(1), Close: decimal.NewFromInt(1000)},
+		{Date: day(2), Close: decimal.NewFromInt(1010)},
+		// day(3) の日足なし
+	})
+
+	t.Run("Buy はベンチマークのリターンを差し引く", func(t *testing.T) {
+		got := SignalExcessReturns(after, models.AnalyzeStockBrandPriceHistoryActionBuy, returns, bench)
+		assert.Equal(t, "0.02", got[1].String())
+		assert.Nil(t, got[2], "h 営業日後のベンチマークが無ければ nil")
+	})
+
+	t.Run("Sell はベンチマークの下落を基準にする", func(t *testing.T) {
+		got := SignalExcessReturns(after, models.AnalyzeStockBrandPriceHistoryActionSell, returns, bench)
+		assert.Equal(t, "0.04", got[1].String())
+	})
+
+	t.Run("基準日のベンチマークが無ければ nil", func(t *testing.T) {
+		assert.Nil(t, SignalExcessReturns(after[2:], models.AnalyzeStockBrandPriceHistoryActionBuy, returns, bench))
+	})
+}
+
+func TestSignalRandomEntryPool(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, time.UTC) }
+	prices := func(closes ...float64) []*models.StockBrandDailyPrice {
+		out := make([]*models.StockBrandDailyPrice, len(closes))
+		for i, c := range closes {
+			out[i] = &models.StockBrandDailyPrice{Date: day(i + 1), Adjclose: decimal.NewFromFloat(c)}
+		}
+		return out
+	}
+	got := SignalRandomEntryPool([][]*models.StockBrandDailyPrice{
+		prices(100, 110, 120),
+		prices(200, 190),
+		nil,
+	}, []int{1, 2})
+
+	assert.InDeltaSlice(t, []float64{0.1, -0.05}, got[1], 1e-9)
+	assert.InDeltaSlice(t, []float64{0.2}, got[2], 1e-9, "未到来の horizon は含めない")
+}
+
+func TestAttachSignalTests(t *testing.T) {
+	const good, flat, few = "good", "flat", "few"
+	var signals []*models.EvaluatedSignal
+	for i := 0; i < 20; i++ {
+		signals = append(signals,
+			significanceSignal(good, models.AnalyzeStockBrandPriceHistoryActionBuy, 0.03+float64(i%5)/1000),
+			significanceSignal(flat, models.AnalyzeStockBrandPriceHistoryActionBuy, float64(i%5-2)/100))
+	}
+	for i := 0; i < signalTestMinCount-1; i++ {
+		signals = append(signals, significanceSignal(few, models.AnalyzeStockBrandPriceHistoryActionBuy, 0.05))
+	}
+	// 同じ日の母集団は -2%〜+2%（平均0）
+	pools := map[string]map[int][]float64{
+		"2026-07-01": {5: {-0.02, -0.01, 0, 0.01, 0.02}},
+	}
+	summaries := []*models.SignalPerformanceSummary{{Method: few}, {Method: flat}, {Method: good}}
+
+	AttachSignalTests(summaries, signals, pools, []int{5}, SignalTestIterations, rand.New(rand.NewSource(1)))
+
+	fewT, flatT, goodT := summaries[0].Tests[5], summaries[1].Tests[5], summaries[2].Tests[5]
+
+	assert.True(t, goodT.PValue.LessThan(decimal.RequireFromString("0.01")))
+	assert.True(t, goodT.CILow.IsPositive())
+	assert.True(t, goodT.Significant)
+	assert.Equal(t, 20, goodT.BaselineCount)
+	assert.Equal(t, "0", goodT.BaselineAvgReturn.String())
+	assert.True(t, goodT.BeatsBaseline)
+
+	assert.True(t, flatT.PValue.GreaterThan(decimal.RequireFromString("0.5")))
+	assert.True(t, flatT.CILow.IsNegative())
+	assert.False(t, flatT.Significant)
+	assert.False(t, flatT.BeatsBaseline)
+	// 2手法で補正するため q 値は p 値以上
+	assert.True(t, goodT.QValue.GreaterThanOrEqual(*goodT.PValue))
+
+	assert.Nil(t, fewT.PValue, "件数不足の手法は検定しない")
+	assert.Nil(t, fewT.QValue)
+	assert.Nil(t, fewT.BaselinePValue)
+	assert.Equal(t, 0, fewT.ExcessCount, "超過リターンが無ければ0件")
+	assert.Nil(t, fewT.AvgExcessReturn)
+}
@@ -0,0 +1,355 @@
+package domain_service
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// Strategy バックテスト・ランキング・買い候補スクリーニングが共通で扱う売買戦略。
+// 戦略を追加するときは実装を RegisterStrategy で登録するだけでよく、呼び出し側は登録順に全戦略を走査する。
+type Strategy interface {
+	// ID フロント表示・ランキングのキー（例: macd_bullish）
+	ID() string
+	// Label 日本語表示名
+	Label() string
+	// Side 売買方向（models.PositionSideLong / models.PositionSideShort）
+	Side() string
+	// WarmupBars 最初のエントリー判定に必要な日足本数
+	WarmupBars() int
+	// DailyPick 買い候補スクリーニングの点灯数カウント対象か
+	DailyPick() bool
+	// Params チューニング可能なパラメータ（固定値のみの戦略は空）
+	Params() []models.StrategyParamSpec
+	// EntrySignals エントリーシグナル。長さは len(prices) と一致する
+	EntrySignals(prices []*models.StockBrandDailyPrice) []bool
+	// EntrySignalsWithParams Params() のパラメータを params（名前 → 値の文字列）で差し替えたエントリーシグナル。
+	// 指定の無いパラメータは既定値を使い、パラメータを持たない戦略は EntrySignals と同じ
+	EntrySignalsWithParams(prices []*models.StockBrandDailyPrice, params map[string]string) []bool
+	// ExitSignals 反転（手仕舞い）シグナル。長さは len(prices) と一致する
+	ExitSignals(prices []*models.StockBrandDailyPrice) []bool
+}
+
+// SignalStrategy シグナル関数の組で定義する Strategy の標準実装。
+type SignalStrategy struct {
+	StrategyID    string
+	StrategyLabel string
+	PositionSide  string
+	Warmup        int
+	DailyPickBase bool
+	ParamSpecs    []models.StrategyParamSpec
+	Entry         func(prices []*models.StockBrandDailyPrice) []bool
+	// EntryWithParams ParamSpecs を差し替えたエントリーシグナル（nil なら Entry を使う）
+	EntryWithParams func(prices []*models.StockBrandDailyPrice, params map[string]string) []bool
+	Exit            func(prices []*models.StockBrandDailyPrice) []bool
+}
+
+func (s *SignalStrategy) ID() string      { return s.StrategyID }
+func (s *SignalStrategy) Label() string   { return s.StrategyLabel }
+func (s *SignalStrategy) WarmupBars() int { return s.Warmup }
+func (s *SignalStrategy) DailyPick() bool { return s.DailyPickBase }
+
+func (s *SignalStrategy) Side() string {
+	if s.PositionSide == "" {
+		return models.PositionSideLong
+	}
+	return s.PositionSide
+}
+
+func (s *SignalStrategy) Params() []models.StrategyParamSpec {
+	if s.ParamSpecs == nil {
+		return []models.StrategyParamSpec{}
+	}
+	return s.ParamSpecs
+}
+
+func (s *SignalStrategy) EntrySignals(prices []*models.StockBrandDailyPrice) []bool {
+	if s.Entry == nil {
+		return make([]bool, len(prices))
+	}
+	return s.Entry(prices)
+}
+
+func (s *SignalStrategy) EntrySignalsWithParams(prices []*models.StockBrandDailyPrice, params map[string]string) []bool {
+	if s.EntryWithParams == nil || len(params) == 0 {
+		return s.EntrySignals(prices)
+	}
+	return s.EntryWithParams(prices, params)
+}
+
+func (s *SignalStrategy) ExitSignals(prices []*models.StockBrandDailyPrice) []bool {
+	if s.Exit == nil {
+		return make([]bool, len(prices))
+	}
+	return s.Exit(prices)
+}
+
+// StrategyRegistry 戦略の登録簿。登録順がランキング表示・全戦略走査の順序になる。
+type StrategyRegistry struct {
+	strategies []Strategy
+	byID       map[string]Strategy
+}
+
+// NewStrategyRegistry strategies を順に登録した登録簿を返す。
+func NewStrategyRegistry(strategies ...Strategy) (*StrategyRegistry, error) {
+	r := &StrategyRegistry{byID: make(map[string]Strategy, len(strategies))}
+	for _, s := range strategies {
+		if err := r.Register(s); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register 戦略を末尾に登録する。ID が空・重複の場合はエラー。
+func (r *StrategyRegistry) Register(s Strategy) error {
+	if s == nil || s.ID() == "" {
+		return fmt.Errorf("戦略IDが空です")
+	}
+	if _, ok := r.byID[s.ID()]; ok {
+		return fmt.Errorf("戦略 %q は登録済みです", s.ID())
+	}
+	r.strategies = append(r.strategies, s)
+	r.byID[s.ID()] = s
+	return nil
+}
+
+// Lookup ID で戦略を引く。
+func (r *StrategyRegistry) Lookup(id string) (Strategy, bool) {
+	s, ok := r.byID[id]
+	return s, ok
+}
+
+// All 登録順の全戦略（コピー）。
+func (r *StrategyRegistry) All() []Strategy {
+	return append([]Strategy(nil), r.strategies...)
+}
+
+// defaultStrategyRegistry 組み込み戦略を登録したパッケージ既定の登録簿。
+// 追加登録はパッケージ初期化時（init）に限る（並行アクセスは保護していない）。
+var defaultStrategyRegistry = mustNewStrategyRegistry(builtinStrategies()...)
+
+func mustNewStrategyRegistry(strategies ...Strategy) *StrategyRegistry {
+	r, err := NewStrategyRegistry(strategies...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// builtinStrategies 組み込み戦略（登録順 = 表示順）。
+func builtinStrategies() []Strategy {
+	return []Strategy{
+		&SignalStrategy{
+			StrategyID:    StrategyMACDBullish,
+			StrategyLabel: "MACD強気",
+			Warmup:        36,
+			DailyPickBase: true,
+			Entry:         MACDBullishEntrySignals,
+			Exit:          MACDBullishExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategyBollingerBreakout,
+			StrategyLabel: "ボリンジャーブレイク",
+			Warmup:        40,
+			DailyPickBase: true,
+			Entry:         BollingerBreakoutEntrySignals,
+			Exit:          BollingerBreakoutExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:      StrategyTriangleFormation,
+			StrategyLabel:   "三角持ち合いブレイク",
+			Warmup:          DefaultTriangleFormationParams().Window + 1,
+			DailyPickBase:   true,
+			ParamSpecs:      triangleFormationParamSpecs(),
+			Entry:           TriangleFormationEntrySignals,
+			EntryWithParams: triangleFormationEntrySignalsWithParamValues,
+			Exit:            GenericTrendBreakExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategyMovingAverageCross,
+			StrategyLabel: "移動平均(5/25/75)上抜け",
+			Warmup:        76,
+			DailyPickBase: true,
+			Entry:         MovingAverageCrossEntrySignals,
+			Exit:          MovingAverageCrossExitSignals,
+		},
+		// multiple_signals は他4戦略の派生（2つ以上成立）なので点灯数カウントからは除外する
+		&SignalStrategy{
+			StrategyID:    StrategyMultipleSignals,
+			StrategyLabel: "複数シグナル(2つ以上)",
+			Warmup:        76,
+			Entry:         MultipleSignalsEntrySignals,
+			Exit:          GenericTrendBreakExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategyDonchianBreakout,
+			StrategyLabel: "ドンチャン20日ブレイク",
+			Warmup:        donchianBreakoutPeriod + 2,
+			Entry:         DonchianBreakoutEntrySignals,
+			Exit:          DonchianBreakoutExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategySupertrendFlip,
+			StrategyLabel: "スーパートレンド上昇転換",
+			Warmup:        supertrendPeriod + 2,
+			Entry:         SupertrendFlipEntrySignals,
+			Exit:          SupertrendFlipExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategyMADeviationReversion,
+			StrategyLabel: "25日乖離率の逆張り",
+			Warmup:        maDeviationPeriod + 1,
+			Entry:         MADeviationReversionEntrySignals,
+			Exit:          MADeviationReversionExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategyCandlestickBullish,
+			StrategyLabel: "ローソク足 強気パターン",
+			Warmup:        candlestickMinIndex + 1,
+			Entry:         CandlestickBullishEntrySignals,
+			Exit:          CandlestickBullishExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategyMACDBearish,
+			StrategyLabel: "MACD弱気(売り)",
+			PositionSide:  models.PositionSideShort,
+			Warmup:        36,
+			Entry:         MACDBearishEntrySignals,
+			Exit:          MACDBearishExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategyBollingerBreakdown,
+			StrategyLabel: "ボリンジャーブレイクダウン(売り)",
+			PositionSide:  models.PositionSideShort,
+			Warmup:        40,
+			Entry:         BollingerBreakdownEntrySignals,
+			Exit:          BollingerBreakdownExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategyMovingAverageDeadCross,
+			StrategyLabel: "移動平均(5/25/75)下抜け(売り)",
+			PositionSide:  models.PositionSideShort,
+			Warmup:        76,
+			Entry:         MovingAverageDeadCrossEntrySignals,
+			Exit:          MovingAverageDeadCrossExitSignals,
+		},
+		&SignalStrategy{
+			StrategyID:    StrategyCandlestickBearish,
+			StrategyLabel: "ローソク足 弱気パターン(売り)",
+			PositionSide:  models.PositionSideShort,
+			Warmup:        candlestickMinIndex + 1,
+			Entry:         CandlestickBearishEntrySignals,
+			Exit:          CandlestickBearishExitSignals,
+		},
+	}
+}
+
+// triangleFormationParamSpecs 三角持ち合いのうちパラメータ最適化で探索するパラメータ。
+func triangleFormationParamSpecs() []models.StrategyParamSpec {
+	def := DefaultTriangleFormationParams()
+	return []models.StrategyParamSpec{
+		{Name: "window", Label: "収縮判定の窓（営業日）", Type: models.StrategyParamTypeInt, Default: strconv.Itoa(def.Window), SearchValues: []string{"40", "60"}},
+		{Name: "rangeContractionRatio", Label: "日中レンジ収縮率の上限", Type: models.StrategyParamTypeDecimal, Default: def.RangeContractionRatio.String(), SearchValues: []string{"0.7", "0.8"}},
+		{Name: "volumeMultiplier", Label: "ブレイク時の出来高倍率", Type: models.StrategyParamTypeDecimal, Default: def.VolumeMultiplier.String(), SearchValues: []string{"1.2", "1.5"}},
+	}
+}
+
+// triangleFormationEntrySignalsWithParamValues triangleFormationParamSpecs の値で既定パラメータを上書きして三角持ち合いブレイクを検出する。
+// 解釈できない値は既定値のままにする。
+func triangleFormationEntrySignalsWithParamValues(prices []*models.StockBrandDailyPrice, params map[string]string) []bool {
+	p := DefaultTriangleFormationParams()
+	if v, err := strconv.Atoi(params["window"]); err == nil {
+		p.Window = v
+	}
+	if v, err := decimal.NewFromString(params["rangeContractionRatio"]); err == nil {
+		p.RangeContractionRatio = v
+	}
+	if v, err := decimal.NewFromString(params["volumeMultiplier"]); err == nil {
+		p.VolumeMultiplier = v
+	}
+	return TriangleFormationEntrySignalsWithParams(prices, p)
+}
+
+// RegisterStrategy 既定の登録簿に戦略を追加する。パッケージ初期化時（init）から呼ぶこと。
+func RegisterStrategy(s Strategy) error {
+	return defaultStrategyRegistry.Register(s)
+}
+
+// LookupStrategy 既定の登録簿から ID で戦略を引く。
+func LookupStrategy(id string) (Strategy, bool) {
+	return defaultStrategyRegistry.Lookup(id)
+}
+
+// RegisteredStrategies 既定の登録簿の全戦略（登録順）。
+func RegisteredStrategies() []Strategy {
+	return defaultStrategyRegistry.All()
+}
+
+// StrategyIDs 登録順の戦略 ID。ランキング表示・全戦略走査の順序。
+func StrategyIDs() []string {
+	all := RegisteredStrategies()
+	ids := make([]string, 0, len(all))
+	for _, s := range all {
+		ids = append(ids, s.ID())
+	}
+	return ids
+}
+
+// StrategyMinHistoryBars ids の戦略のバックテストに必要な最低日足本数（各戦略の WarmupBars の最大）。
+// ids を省略すると登録済みの全戦略で求める。未登録の ID は無視する。
+func StrategyMinHistoryBars(ids ...string) int {
+	strategies := RegisteredStrategies()
+	if len(ids) > 0 {
+		strategies = strategies[:0]
+		for _, id := range ids {
+			if s, ok := LookupStrategy(id); ok {
+				strategies = append(strategies, s)
+			}
+		}
+	}
+	bars := 0
+	for _, s := range strategies {
+		bars = max(bars, s.WarmupBars())
+	}
+	return bars
+}
+
+// StrategyLabel 戦略の日本語表示名。未登録なら空文字。
+func StrategyLabel(id string) string {
+	if s, ok := LookupStrategy(id); ok {
+		return s.Label()
+	}
+	return ""
+}
+
+// DailyPickStrategyIDs 買い候補スクリーニングの点灯数カウント対象の戦略 ID（登録順）。
+func DailyPickStrategyIDs() []string {
+	ids := make([]string, 0)
+	for _, s := range RegisteredStrategies() {
+		if s.DailyPick() {
+			ids = append(ids, s.ID())
+		}
+	}
+	return ids
+}
+
+// StrategyInfos 登録済み戦略のメタデータ（登録順）。
+func StrategyInfos() []models.StrategyInfo {
+	all := RegisteredStrategies()
+	infos := make([]models.StrategyInfo, 0, len(all))
+	for _, s := range all {
+		infos = append(infos, models.StrategyInfo{
+			ID:         s.ID(),
+			Label:      s.Label(),
+			Side:       s.Side(),
+			WarmupBars: s.WarmupBars(),
+			DailyPick:  s.DailyPick(),
+			Params:     s.Params(),
+		})
+	}
+	return infos
+}
@@ -0,0 +1,112 @@
+package domain_service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestStrategyRegistry_Register(t *testing.T) {
+	entry := func(prices []*models.StockBrandDailyPrice) []bool {
+		s := make([]bool, len(prices))
+		if len(prices) > 0 {
+			s[len(prices)-1] = true
+		}
+		return s
+	}
+	custom := &SignalStrategy{StrategyID: "custom", StrategyLabel: "カスタム", Warmup: 1, Entry: entry}
+
+	r, err := NewStrategyRegistry(custom)
+	require.NoError(t, err)
+
+	t.Run("ID で引ける", func(t *testing.T) {
+		got, ok := r.Lookup("custom")
+		require.True(t, ok)
+		assert.Equal(t, "カスタム", got.Label())
+		assert.Equal(t, models.PositionSideLong, got.Side()) // 未指定は Long
+		assert.Equal(t, []bool{false, true}, got.EntrySignals(pricesFromCloses(1, 2)))
+		// Exit 未指定は全 false
+		assert.Equal(t, []bool{false, false}, got.ExitSignals(pricesFromCloses(1, 2)))
+		assert.NotNil(t, got.Params())
+	})
+
+	t.Run("重複・空IDはエラー", func(t *testing.T) {
+		assert.Error(t, r.Register(custom))
+		assert.Error(t, r.Register(&SignalStrategy{}))
+		assert.Len(t, r.All(), 1)
+	})
+}
+
+func TestBuiltinStrategies(t *testing.T) {
+	ids := StrategyIDs()
+	assert.Equal(t, []string{
+		StrategyMACDBullish,
+		StrategyBollingerBreakout,
+		StrategyTriangleFormation,
+		StrategyMovingAverageCross,
+		StrategyMultipleSignals,
+		StrategyDonchianBreakout,
+		StrategySupertrendFlip,
+		StrategyMADeviationReversion,
+		StrategyCandlestickBullish,
+		StrategyMACDBearish,
+		StrategyBollingerBreakdown,
+		StrategyMovingAverageDeadCross,
+		StrategyCandlestickBearish,
+	}, ids)
+	// multiple_signals は他戦略の派生なので点灯数カウント対象外
+	assert.Equal(t, []string{
+		StrategyMACDBullish,
+		StrategyBollingerBreakout,
+		StrategyTriangleFormation,
+		StrategyMovingAverageCross,
+	}, DailyPickStrategyIDs())
+
+	for _, info := range StrategyInfos() {
+		assert.NotEmpty(t, info.Label, info.ID)
+		assert.Positive(t, info.WarmupBars, info.ID)
+		assert.Equal(t, StrategySide(info.ID), info.Side, info.ID)
+	}
+	assert.Equal(t, "", StrategyLabel("unknown"))
+
+	tri, ok := LookupStrategy(StrategyTriangleFormation)
+	require.True(t, ok)
+	require.Len(t, tri.Params(), 3)
+	assert.Equal(t, "window", tri.Params()[0].Name)
+	assert.Equal(t, "60", tri.Params()[0].Default)
+	assert.Equal(t, []string{"40", "60"}, tri.Params()[0].SearchValues)
+}
+
+// TestBuiltinStrategies_WarmupBars ウォームアップ本数未満ではシグナルが立たないことを確認する。
+func TestBuiltinStrategies_WarmupBars(t *testing.T) {
+	closes := make([]float64, 120)
+	for i := range closes {
+		closes[i] = 100 + float64(i%7)*3
+	}
+	prices := pricesFromCloses(closes...)
+	for i, p := range prices {
+		p.Volume = int64(1000 + i*100)
+	}
+	for _, s := range RegisteredStrategies() {
+		signals := s.EntrySignals(prices)
+		for i := 0; i < s.WarmupBars()-1 && i < len(signals); i++ {
+			assert.False(t, signals[i], "strategy %s idx %d", s.ID(), i)
+		}
+	}
+}
+
+func TestStrategyMinHistoryBars(t *testing.T) {
+	macd, ok := LookupStrategy(StrategyMACDBullish)
+	require.True(t, ok)
+	assert.Equal(t, macd.WarmupBars(), StrategyMinHistoryBars(StrategyMACDBullish))
+
+	longest := 0
+	for _, s := range RegisteredStrategies() {
+		longest = max(longest, s.WarmupBars())
+	}
+	assert.Equal(t, longest, StrategyMinHistoryBars(), "指定なしは全戦略の最長")
+	assert.Equal(t, 0, StrategyMinHistoryBars("unknown"))
+}
@@ -9,69 +9,49 @@ import (
 // 条件式は stt-golang の各 find_*_stock usecase から移植している。
 // 返り値の長さは len(prices) と一致し、true はその日の終値時点でエントリー条件成立を表す。
 
-// 戦略識別子（フロント表示・ランキングのキーに使う）
+// 組み込み戦略の識別子（フロント表示・ランキングのキーに使う）。表示名・売買方向・シグナル関数は
+// strategy_registry.go の登録簿で戦略ごとに定義する。
 const (
-	StrategyMACDBullish        = "macd_bullish"
-	StrategyBollingerBreakout  = "bollinger_breakout"
-	StrategyTriangleFormation  = "triangle_formation"
-	StrategyMovingAverageCross = "ma_cross"
-	StrategyMultipleSignals    = "multiple_signals"
+	StrategyMACDBullish          = "macd_bullish"
+	StrategyBollingerBreakout    = "bollinger_breakout"
+	StrategyTriangleFormation    = "triangle_formation"
+	StrategyMovingAverageCross   = "ma_cross"
+	StrategyMultipleSignals      = "multiple_signals"
+	StrategyDonchianBreakout     = "donchian_breakout"
+	StrategySupertrendFlip       = "supertrend_flip"
+	StrategyMADeviationReversion = "ma_deviation_reversion"
+	StrategyCandlestickBullish   = "candlestick_bullish"
+	// 空売り（信用売り）戦略
+	StrategyMACDBearish            = "macd_bearish"
+	StrategyBollingerBreakdown     = "bollinger_breakdown"
+	StrategyMovingAverageDeadCross = "ma_dead_cross"
+	StrategyCandlestickBearish     = "candlestick_bearish"
 )
 
-// StrategyLabels 戦略の日本語表示名
-var StrategyLabels = map[string]string{
-	StrategyMACDBullish:        "MACD強気",
-	StrategyBollingerBreakout:  "ボリンジャーブレイク",
-	StrategyTriangleFormation:  "三角持ち合いブレイク",
-	StrategyMovingAverageCross: "移動平均(5/25/75)上抜け",
-	StrategyMultipleSignals:    "複数シグナル(2つ以上)",
-}
-
-// StrategyOrder ランキング表示・全戦略走査の順序
-var StrategyOrder = []string{
-	StrategyMACDBullish,
-	StrategyBollingerBreakout,
-	StrategyTriangleFormation,
-	StrategyMovingAverageCross,
-	StrategyMultipleSignals,
+// StrategySide 戦略の売買方向を返す（空売り戦略は models.PositionSideShort、未登録を含むそれ以外は Long）。
+func StrategySide(strategy string) string {
+	if s, ok := LookupStrategy(strategy); ok {
+		return s.Side()
+	}
+	return models.PositionSideLong
 }
 
-// EntrySignalsByStrategy 指定戦略のエントリーシグナルを返す。
+// EntrySignalsByStrategy 指定戦略のエントリーシグナルを返す。未登録の strategy は全 false を返す。
 func EntrySignalsByStrategy(strategy string, prices []*models.StockBrandDailyPrice) []bool {
-	switch strategy {
-	case StrategyMACDBullish:
-		return MACDBullishEntrySignals(prices)
-	case StrategyBollingerBreakout:
-		return BollingerBreakoutEntrySignals(prices)
-	case StrategyTriangleFormation:
-		return TriangleFormationEntrySignals(prices)
-	case StrategyMovingAverageCross:
-		return MovingAverageCrossEntrySignals(prices)
-	case StrategyMultipleSignals:
-		return MultipleSignalsEntrySignals(prices)
-	default:
-		return make([]bool, len(prices))
+	if s, ok := LookupStrategy(strategy); ok {
+		return s.EntrySignals(prices)
 	}
+	return make([]bool, len(prices))
 }
 
 // ExitSignalsByStrategy 指定戦略の反転（手仕舞い）シグナルを日次の []bool で返す純粋関数。
 // 返り値の長さは len(prices) と一致し、true はその日の終値時点でイグジット条件成立を表す。
-// 未知の strategy は全 false を返す。
+// 未登録の strategy は全 false を返す。
 func ExitSignalsByStrategy(strategy string, prices []*models.StockBrandDailyPrice) []bool {
-	switch strategy {
-	case StrategyMACDBullish:
-		return MACDBullishExitSignals(prices)
-	case StrategyBollingerBreakout:
-		return BollingerBreakoutExitSignals(prices)
-	case StrategyTriangleFormation:
-		return GenericTrendBreakExitSignals(prices)
-	case StrategyMovingAverageCross:
-		return MovingAverageCrossExitSignals(prices)
-	case StrategyMultipleSignals:
-		return GenericTrendBreakExitSignals(prices)
-	default:
-		return make([]bool, len(prices))
+	if s, ok := LookupStrategy(strategy); ok {
+		return s.ExitSignals(prices)
 	}
+	return make([]bool, len(prices))
 }
 
 // MACDBullishExitSignals MACDデッドクロス（前日 MACD >= Signal かつ当日 MACD < Signal）で手仕舞い。
@@ -0,0 +1,167 @@
+package domain_service
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// 空売り戦略のエントリー（売り）・手仕舞い（買戻し）シグナル。
+// 各条件は買い戦略（strategy_signals.go）を上下反転したもの。
+
+// MACDBearishEntrySignals MACDデッドクロス(Signal≤0) + RSI>30 + 出来高>5日平均。
+// MACDBullishEntrySignals の反転。
+func MACDBearishEntrySignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	closes := ExtractClosePrices(prices)
+
+	macd := CalculateMACD(closes, 12, 26, 9)
+	rsi := CalculateRSI(closes, 14)
+	if macd == nil || rsi == nil {
+		return signals
+	}
+	const minIdx = 35
+	for i := minIdx; i < n; i++ {
+		cur := macd[i]
+		prev := macd[i-1]
+		deadCross := cur.MACD.LessThan(cur.Signal) &&
+			!prev.MACD.LessThan(prev.Signal) &&
+			cur.Signal.LessThanOrEqual(decimal.Zero)
+		if !deadCross {
+			continue
+		}
+		if rsi[i].LessThanOrEqual(decimal.NewFromInt(30)) {
+			continue
+		}
+		if decimal.NewFromInt(prices[i].Volume).LessThanOrEqual(avgVolume(prices, i, 5)) {
+			continue
+		}
+		signals[i] = true
+	}
+	return signals
+}
+
+// MACDBearishExitSignals MACDゴールデンクロス（前日 MACD <= Signal かつ当日 MACD > Signal）で買戻し。
+func MACDBearishExitSignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	closes := ExtractClosePrices(prices)
+
+	macd := CalculateMACD(closes, 12, 26, 9)
+	if macd == nil {
+		return signals
+	}
+	const minIdx = 35
+	for i := minIdx; i < n; i++ {
+		cur := macd[i]
+		prev := macd[i-1]
+		if cur.MACD.GreaterThan(cur.Signal) && !prev.MACD.GreaterThan(prev.Signal) {
+			signals[i] = true
+		}
+	}
+	return signals
+}
+
+// BollingerBreakdownEntrySignals ロワーバンド下抜け + スクイーズ + 出来高急増 + RSI>30。
+// BollingerBreakoutEntrySignals の反転。
+func BollingerBreakdownEntrySignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	closes := ExtractClosePrices(prices)
+
+	const bbPeriod = 20
+	bb := CalculateBollingerBands(closes, bbPeriod, decimal.NewFromInt(2))
+	rsi := CalculateRSI(closes, 14)
+	if bb == nil || rsi == nil {
+		return signals
+	}
+
+	minIdx := bbPeriod*2 - 1
+	for i := minIdx; i < n; i++ {
+		breakdown := closes[i].LessThan(bb[i].Lower) &&
+			!closes[i-1].LessThan(bb[i-1].Lower)
+		if !breakdown {
+			continue
+		}
+		shortAvgBW := avgBandWidth(bb, i, 5)
+		longAvgBW := avgBandWidth(bb, i, 20)
+		if !shortAvgBW.LessThan(longAvgBW) { // スクイーズ収束からの拡大
+			continue
+		}
+		if decimal.NewFromInt(prices[i].Volume).LessThanOrEqual(avgVolume(prices, i, 5).Mul(decimal.NewFromFloat(1.5))) {
+			continue
+		}
+		if rsi[i].LessThanOrEqual(decimal.NewFromInt(30)) {
+			continue
+		}
+		signals[i] = true
+	}
+	return signals
+}
+
+// BollingerBreakdownExitSignals 終値がミドルバンド（20SMA）を上抜けで買戻し。
+func BollingerBreakdownExitSignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	closes := ExtractClosePrices(prices)
+
+	const bbPeriod = 20
+	bb := CalculateBollingerBands(closes, bbPeriod, decimal.NewFromInt(2))
+	if bb == nil {
+		return signals
+	}
+	for i := bbPeriod; i < n; i++ {
+		if closes[i].GreaterThan(bb[i].Middle) && !closes[i-1].GreaterThan(bb[i-1].Middle) {
+			signals[i] = true
+		}
+	}
+	return signals
+}
+
+// MovingAverageDeadCrossEntrySignals 終値が5/25/75日線を全て下抜けた瞬間（前日は全下抜けでない）。
+// MovingAverageCrossEntrySignals の反転。
+func MovingAverageDeadCrossEntrySignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	closes := ExtractClosePrices(prices)
+
+	sma5 := smaSeries(closes, 5)
+	sma25 := smaSeries(closes, 25)
+	sma75 := smaSeries(closes, 75)
+
+	belowAll := func(idx int) bool {
+		if sma5[idx].IsZero() || sma25[idx].IsZero() || sma75[idx].IsZero() {
+			return false
+		}
+		return closes[idx].LessThan(sma5[idx]) &&
+			closes[idx].LessThan(sma25[idx]) &&
+			closes[idx].LessThan(sma75[idx])
+	}
+
+	for i := 75; i < n; i++ {
+		if belowAll(i) && !belowAll(i-1) {
+			signals[i] = true
+		}
+	}
+	return signals
+}
+
+// MovingAverageDeadCrossExitSignals 5日SMAが25日SMAを上抜けで買戻し。
+func MovingAverageDeadCrossExitSignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	closes := ExtractClosePrices(prices)
+
+	sma5 := smaSeries(closes, 5)
+	sma25 := smaSeries(closes, 25)
+	for i := 25; i < n; i++ {
+		if sma5[i].IsZero() || sma25[i].IsZero() || sma5[i-1].IsZero() || sma25[i-1].IsZero() {
+			continue
+		}
+		if sma5[i].GreaterThan(sma25[i]) && !sma5[i-1].GreaterThan(sma25[i-1]) {
+			signals[i] = true
+		}
+	}
+	return signals
+}
@@ -0,0 +1,63 @@
+package domain_service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// TestMovingAverageDeadCrossEntrySignals 終値が5/25/75SMAを全て下回った日を検出することを確認。
+func TestMovingAverageDeadCrossEntrySignals(t *testing.T) {
+	t.Run("短データは全 false", func(t *testing.T) {
+		sigs := MovingAverageDeadCrossEntrySignals(pricesFromCloses(make([]float64, 30)...))
+		for _, v := range sigs {
+			assert.False(t, v)
+		}
+	})
+
+	t.Run("フラット後の急落日のみ検出される", func(t *testing.T) {
+		closes := make([]float64, 79)
+		for i := range closes {
+			closes[i] = 100
+		}
+		closes = append(closes, 50)
+		sigs := MovingAverageDeadCrossEntrySignals(pricesFromCloses(closes...))
+		for i, v := range sigs {
+			assert.Equal(t, i == 79, v, "index %d", i)
+		}
+	})
+}
+
+// TestMovingAverageDeadCrossExitSignals 5SMAが25SMAを上抜けた日を検出することを確認。
+func TestMovingAverageDeadCrossExitSignals(t *testing.T) {
+	closes := make([]float64, 30)
+	for i := range closes {
+		closes[i] = 100
+	}
+	for i := 0; i < 5; i++ {
+		closes = append(closes, 150)
+	}
+	sigs := MovingAverageDeadCrossExitSignals(pricesFromCloses(closes...))
+	assert.True(t, sigs[30], "急騰初日に5SMA > 25SMA の上抜けが検出されるはず")
+}
+
+// TestBollingerBreakdownExitSignals 終値がミドルバンドを上抜けた日を検出することを確認。
+func TestBollingerBreakdownExitSignals(t *testing.T) {
+	closes := make([]float64, 25)
+	for i := range closes {
+		closes[i] = 100
+	}
+	closes = append(closes, 120, 100)
+	sigs := BollingerBreakdownExitSignals(pricesFromCloses(closes...))
+	assert.True(t, sigs[25], "index25 でミドルバンド上抜けシグナルが立つべき")
+}
+
+func TestStrategySide(t *testing.T) {
+	assert.Equal(t, models.PositionSideLong, StrategySide(StrategyMACDBullish))
+	assert.Equal(t, models.PositionSideShort, StrategySide(StrategyMACDBearish))
+	assert.Equal(t, models.PositionSideShort, StrategySide(StrategyBollingerBreakdown))
+	assert.Equal(t, models.PositionSideShort, StrategySide(StrategyMovingAverageDeadCross))
+	assert.Equal(t, models.PositionSideLong, StrategySide("unknown"))
+}
@@ -0,0 +1,121 @@
+package domain_service
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// 追加テクニカル指標（technical_indicators_extended.go）を使う戦略のエントリー・手仕舞いシグナル。
+
+const (
+	donchianBreakoutPeriod = 20 // ブレイク判定に使う直近高値の日数
+	donchianExitPeriod     = 10 // 手仕舞い判定に使う直近安値の日数
+	supertrendPeriod       = 10
+	maDeviationPeriod      = 25
+)
+
+var (
+	supertrendMultiplier = decimal.NewFromInt(3)
+	// maDeviationEntryThreshold 25日移動平均乖離率（%）がこれ以下に突っ込んだら逆張りで買う
+	maDeviationEntryThreshold = decimal.NewFromInt(-10)
+)
+
+// DonchianBreakoutEntrySignals 終値が前日までの20日高値（ドンチャン上限）を初めて上抜けた日。
+// 前日も上抜けていた場合は継続とみなしてエントリーしない。
+func DonchianBreakoutEntrySignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	dc := CalculateDonchianChannels(prices, donchianBreakoutPeriod)
+	if dc == nil {
+		return signals
+	}
+	// dc[i-2] が確定している必要がある
+	for i := donchianBreakoutPeriod + 1; i < n; i++ {
+		if prices[i].Close.GreaterThan(dc[i-1].Upper) && !prices[i-1].Close.GreaterThan(dc[i-2].Upper) {
+			signals[i] = true
+		}
+	}
+	return signals
+}
+
+// DonchianBreakoutExitSignals 終値が前日までの10日安値を下抜けたら手仕舞い（タートル流）。
+func DonchianBreakoutExitSignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	dc := CalculateDonchianChannels(prices, donchianExitPeriod)
+	if dc == nil {
+		return signals
+	}
+	for i := donchianExitPeriod; i < n; i++ {
+		if prices[i].Close.LessThan(dc[i-1].Lower) {
+			signals[i] = true
+		}
+	}
+	return signals
+}
+
+// SupertrendFlipEntrySignals スーパートレンド(10, 3)が下降から上昇に転換した日。
+func SupertrendFlipEntrySignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	st := CalculateSupertrend(prices, supertrendPeriod, supertrendMultiplier)
+	if st == nil {
+		return signals
+	}
+	for i := supertrendPeriod + 1; i < n; i++ {
+		if st[i].Uptrend && !st[i-1].Uptrend {
+			signals[i] = true
+		}
+	}
+	return signals
+}
+
+// SupertrendFlipExitSignals スーパートレンドが上昇から下降に転換したら手仕舞い。
+func SupertrendFlipExitSignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	st := CalculateSupertrend(prices, supertrendPeriod, supertrendMultiplier)
+	if st == nil {
+		return signals
+	}
+	for i := supertrendPeriod + 1; i < n; i++ {
+		if !st[i].Uptrend && st[i-1].Uptrend {
+			signals[i] = true
+		}
+	}
+	return signals
+}
+
+// MADeviationReversionEntrySignals 25日移動平均乖離率が -10% 以下に初めて突っ込んだ日（逆張り）。
+func MADeviationReversionEntrySignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	dev := CalculateMADeviation(ExtractClosePrices(prices), maDeviationPeriod)
+	if dev == nil {
+		return signals
+	}
+	// dev[i-1] が確定している必要がある
+	for i := maDeviationPeriod; i < n; i++ {
+		if dev[i].LessThanOrEqual(maDeviationEntryThreshold) && dev[i-1].GreaterThan(maDeviationEntryThreshold) {
+			signals[i] = true
+		}
+	}
+	return signals
+}
+
+// MADeviationReversionExitSignals 終値が25日移動平均まで戻った（乖離率 >= 0）ら手仕舞い。
+func MADeviationReversionExitSignals(prices []*models.StockBrandDailyPrice) []bool {
+	n := len(prices)
+	signals := make([]bool, n)
+	dev := CalculateMADeviation(ExtractClosePrices(prices), maDeviationPeriod)
+	if dev == nil {
+		return signals
+	}
+	for i := maDeviationPeriod - 1; i < n; i++ {
+		if !dev[i].IsNegative() {
+			signals[i] = true
+		}
+	}
+	return signals
+}
@@ -0,0 +1,69 @@
+package domain_service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flatThen 横ばい（高値+1/安値-1）を n 本並べた後に rows を続ける。
+func flatThen(n int, c float64, rows ...[]float64) [][]float64 {
+	out := make([][]float64, 0, n+len(rows))
+	for i := 0; i < n; i++ {
+		out = append(out, []float64{c + 1, c - 1, c, 1000})
+	}
+	return append(out, rows...)
+}
+
+func TestDonchianBreakoutSignals(t *testing.T) {
+	prices := pricesFromOHLCV(flatThen(25, 100,
+		[]float64{106, 100, 105, 1000}, // 25: 20日高値(101)を上抜け
+		[]float64{108, 104, 107, 1000}, // 26: 前日も上抜け済み → 継続
+		[]float64{104, 90, 92, 1000},   // 27: 10日安値(99)を下抜け
+	)...)
+
+	entry := DonchianBreakoutEntrySignals(prices)
+	assert.Equal(t, boolsAt(len(prices), 25), entry)
+
+	exit := DonchianBreakoutExitSignals(prices)
+	assert.Equal(t, boolsAt(len(prices), 27), exit)
+}
+
+func TestSupertrendFlipSignals(t *testing.T) {
+	rows := flatThen(15, 100)
+	rows = append(rows, []float64{85, 75, 80, 1000}) // 15: 急落で下降
+	rows = append(rows, []float64{81, 79, 80, 1000}) // 16
+	rows = append(rows, []float64{121, 99, 120, 1000})
+	prices := pricesFromOHLCV(rows...)
+
+	st := CalculateSupertrend(prices, supertrendPeriod, supertrendMultiplier)
+	entry := SupertrendFlipEntrySignals(prices)
+	exit := SupertrendFlipExitSignals(prices)
+	assert.Len(t, entry, len(prices))
+	for i := supertrendPeriod + 1; i < len(prices); i++ {
+		assert.Equal(t, st[i].Uptrend && !st[i-1].Uptrend, entry[i], "entry %d", i)
+		assert.Equal(t, !st[i].Uptrend && st[i-1].Uptrend, exit[i], "exit %d", i)
+	}
+	assert.True(t, exit[15])
+	assert.True(t, entry[17])
+}
+
+func TestMADeviationReversionSignals(t *testing.T) {
+	closes := make([]float64, 0, 30)
+	for i := 0; i < 25; i++ {
+		closes = append(closes, 100)
+	}
+	// 25: 85 → SMA=99.4, 乖離率 ≒ -14.5%（初めて -10% 以下）
+	// 26: 84 → 引き続き -10% 以下なので新規エントリーなし
+	// 27: 110 → 移動平均を上回り手仕舞い
+	closes = append(closes, 85, 84, 110)
+	prices := pricesFromCloses(closes...)
+
+	assert.Equal(t, boolsAt(len(prices), 25), MADeviationReversionEntrySignals(prices))
+
+	exit := MADeviationReversionExitSignals(prices)
+	assert.True(t, exit[24]) // 乖離率0（横ばい）
+	assert.False(t, exit[25])
+	assert.False(t, exit[26])
+	assert.True(t, exit[27])
+}
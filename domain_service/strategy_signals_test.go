@@ -11,7 +11,7 @@ import (
 
 func TestEntrySignalsByStrategy_LengthAndDefault(t *testing.T) {
 	prices := pricesFromCloses(make([]float64, 90)...) // 全て0.0でも長さ確認には十分
-	for _, s := range StrategyOrder {
+	for _, s := range StrategyIDs() {
 		got := EntrySignalsByStrategy(s, prices)
 		assert.Len(t, got, len(prices), "strategy %s", s)
 	}
@@ -275,7 +275,7 @@ func TestTriangleFormationEntrySignals(t *testing.T) {
 // TestExitSignalsByStrategy_LengthAndDefault ExitSignalsByStrategy の基本動作を確認。
 func TestExitSignalsByStrategy_LengthAndDefault(t *testing.T) {
 	prices := pricesFromCloses(make([]float64, 90)...)
-	for _, s := range StrategyOrder {
+	for _, s := range StrategyIDs() {
 		got := ExitSignalsByStrategy(s, prices)
 		assert.Len(t, got, len(prices), "strategy %s", s)
 	}
@@ -0,0 +1,380 @@
+package domain_service
+
+import (
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/shopspring/decimal"
+)
+
+// 追加のテクニカル指標（パラボリックSAR・ドンチャン・ケルトナー・CCI・ウィリアムズ%R・MFI・CMF・
+// アルーン・スーパートレンド・移動平均乖離率）。technical_indicators.go と同じく戻り値長は len(prices)、
+// ウォームアップ未確定のインデックスは Zero 値、データ不足は nil を返す。
+
+// typicalPrice 典型価格 (H+L+C)/3。
+func typicalPrice(p *models.StockBrandDailyPrice) decimal.Decimal {
+	return p.High.Add(p.Low).Add(p.Close).Div(decimal.NewFromInt(3))
+}
+
+// highestHighLowestLow start..end（両端含む）の最高値と最安値。
+func highestHighLowestLow(prices []*models.StockBrandDailyPrice, start, end int) (decimal.Decimal, decimal.Decimal) {
+	hh := prices[start].High
+	ll := prices[start].Low
+	for j := start + 1; j <= end; j++ {
+		if prices[j].High.GreaterThan(hh) {
+			hh = prices[j].High
+		}
+		if prices[j].Low.LessThan(ll) {
+			ll = prices[j].Low
+		}
+	}
+	return hh, ll
+}
+
+// ParabolicSARResult パラボリックSARの計算結果。
+type ParabolicSARResult struct {
+	SAR     decimal.Decimal
+	Uptrend bool // true: 上昇トレンド（SAR は価格の下）
+}
+
+// CalculateParabolicSAR パラボリックSAR を Wilder 方式で計算する（既定 step=0.02, maxStep=0.2）。
+// index 1 から確定し、初期トレンドは index 0→1 の終値の向きで決める。
+// 当日の安値（上昇時）/高値（下降時）が SAR を割り込んだら反転し、SAR は直前トレンドの極値から再開する。
+func CalculateParabolicSAR(prices []*models.StockBrandDailyPrice, step, maxStep decimal.Decimal) []ParabolicSARResult {
+	n := len(prices)
+	if n < 2 || !step.IsPositive() || maxStep.LessThan(step) {
+		return nil
+	}
+
+	results := make([]ParabolicSARResult, n)
+	up := prices[1].Close.GreaterThanOrEqual(prices[0].Close)
+	var sar, ep decimal.Decimal
+	if up {
+		sar = decimal.Min(prices[0].Low, prices[1].Low)
+		ep = decimal.Max(prices[0].High, prices[1].High)
+	} else {
+		sar = decimal.Max(prices[0].High, prices[1].High)
+		ep = decimal.Min(prices[0].Low, prices[1].Low)
+	}
+	af := step
+	results[1] = ParabolicSARResult{SAR: sar, Uptrend: up}
+
+	for i := 2; i < n; i++ {
+		next := sar.Add(af.Mul(ep.Sub(sar)))
+		if up {
+			// SAR は直近2本の安値を上回らない
+			next = decimal.Min(next, decimal.Min(prices[i-1].Low, prices[i-2].Low))
+			if prices[i].Low.LessThan(next) {
+				up = false
+				next = ep
+				ep = prices[i].Low
+				af = step
+			} else if prices[i].High.GreaterThan(ep) {
+				ep = prices[i].High
+				af = decimal.Min(af.Add(step), maxStep)
+			}
+		} else {
+			next = decimal.Max(next, decimal.Max(prices[i-1].High, prices[i-2].High))
+			if prices[i].High.GreaterThan(next) {
+				up = true
+				next = ep
+				ep = prices[i].High
+				af = step
+			} else if prices[i].Low.LessThan(ep) {
+				ep = prices[i].Low
+				af = decimal.Min(af.Add(step), maxStep)
+			}
+		}
+		sar = next
+		results[i] = ParabolicSARResult{SAR: sar, Uptrend: up}
+	}
+	return results
+}
+
+// ChannelResult 上限・中心・下限からなるチャネル（ドンチャン・ケルトナー）。
+type ChannelResult struct {
+	Upper  decimal.Decimal
+	Middle decimal.Decimal
+	Lower  decimal.Decimal
+}
+
+// CalculateDonchianChannels ドンチャンチャネル（直近 period 日の最高値・最安値とその中間）を計算する（既定 period=20）。
+// 窓は当日を含み、index >= period-1 から確定する。ブレイク判定では前日の値と比較すること。
+func CalculateDonchianChannels(prices []*models.StockBrandDailyPrice, period int) []ChannelResult {
+	n := len(prices)
+	if period <= 0 || n < period {
+		return nil
+	}
+
+	two := decimal.NewFromInt(2)
+	results := make([]ChannelResult, n)
+	for i := period - 1; i < n; i++ {
+		hh, ll := highestHighLowestLow(prices, i-period+1, i)
+		results[i] = ChannelResult{Upper: hh, Middle: hh.Add(ll).Div(two), Lower: ll}
+	}
+	return results
+}
+
+// CalculateKeltnerChannels ケルトナーチャネル（終値 EMA ± multiplier × ATR）を計算する
+// （既定 emaPeriod=20, atrPeriod=10, multiplier=2）。index >= max(emaPeriod-1, atrPeriod) から確定する。
+func CalculateKeltnerChannels(prices []*models.StockBrandDailyPrice, emaPeriod, atrPeriod int, multiplier decimal.Decimal) []ChannelResult {
+	n := len(prices)
+	if emaPeriod <= 0 || atrPeriod <= 0 || n < emaPeriod || n <= atrPeriod {
+		return nil
+	}
+
+	ema := CalculateEMA(ExtractClosePrices(prices), emaPeriod)
+	atr := CalculateATR(prices, atrPeriod)
+	start := emaPeriod - 1
+	if atrPeriod > start {
+		start = atrPeriod
+	}
+
+	results := make([]ChannelResult, n)
+	for i := start; i < n; i++ {
+		band := atr[i].Mul(multiplier)
+		results[i] = ChannelResult{Upper: ema[i].Add(band), Middle: ema[i], Lower: ema[i].Sub(band)}
+	}
+	return results
+}
+
+// CalculateCCI CCI（商品チャネル指数）を計算する（既定 period=20）。
+// CCI = (典型価格 - 典型価格のSMA) / (0.015 × 平均偏差)。index >= period-1 から確定し、
+// 平均偏差がゼロ（横ばい）の場合はゼロ除算回避のため 0 とする。
+func CalculateCCI(prices []*models.StockBrandDailyPrice, period int) []decimal.Decimal {
+	n := len(prices)
+	if period <= 0 || n < period {
+		return nil
+	}
+
+	tp := make([]decimal.Decimal, n)
+	for i, p := range prices {
+		tp[i] = typicalPrice(p)
+	}
+	sma := smaSeries(tp, period)
+
+	periodDec := decimal.NewFromInt(int64(period))
+	constant := decimal.RequireFromString("0.015")
+	cci := make([]decimal.Decimal, n)
+	for i := period - 1; i < n; i++ {
+		md := decimal.Zero
+		for j := i - period + 1; j <= i; j++ {
+			md = md.Add(tp[j].Sub(sma[i]).Abs())
+		}
+		md = md.Div(periodDec)
+		if md.IsZero() {
+			continue
+		}
+		cci[i] = tp[i].Sub(sma[i]).Div(constant.Mul(md))
+	}
+	return cci
+}
+
+// CalculateWilliamsR ウィリアムズ%R を計算する（既定 period=14）。
+// %R = (期間高値 - Close) / (期間高値 - 期間安値) × -100（-100〜0）。index >= period-1 から確定し、
+// レンジがゼロの場合はゼロ除算回避のため 0 とする。
+func CalculateWilliamsR(prices []*models.StockBrandDailyPrice, period int) []decimal.Decimal {
+	n := len(prices)
+	if period <= 0 || n < period {
+		return nil
+	}
+
+	minusHundred := decimal.NewFromInt(-100)
+	wr := make([]decimal.Decimal, n)
+	for i := period - 1; i < n; i++ {
+		hh, ll := highestHighLowestLow(prices, i-period+1, i)
+		rng := hh.Sub(ll)
+		if rng.IsZero() {
+			continue
+		}
+		wr[i] = hh.Sub(prices[i].Close).Div(rng).Mul(minusHundred)
+	}
+	return wr
+}
+
+// CalculateMFI MFI（マネーフローインデックス）を計算する（既定 period=14）。
+// 典型価格×出来高を前日比で正負に振り分け、MFI = 100 - 100 / (1 + 正のフロー / 負のフロー)。
+// index >= period から確定し、負のフローがゼロなら 100（正のフローもゼロなら 50）とする。
+func CalculateMFI(prices []*models.StockBrandDailyPrice, period int) []decimal.Decimal {
+	n := len(prices)
+	if period <= 0 || n <= period {
+		return nil
+	}
+
+	pos := make([]decimal.Decimal, n)
+	neg := make([]decimal.Decimal, n)
+	prevTP := typicalPrice(prices[0])
+	for i := 1; i < n; i++ {
+		tp := typicalPrice(prices[i])
+		flow := tp.Mul(decimal.NewFromInt(prices[i].Volume))
+		switch {
+		case tp.GreaterThan(prevTP):
+			pos[i] = flow
+		case tp.LessThan(prevTP):
+			neg[i] = flow
+		}
+		prevTP = tp
+	}
+
+	hundred := decimal.NewFromInt(100)
+	mfi := make([]decimal.Decimal, n)
+	for i := period; i < n; i++ {
+		var posSum, negSum decimal.Decimal
+		for j := i - period + 1; j <= i; j++ {
+			posSum = posSum.Add(pos[j])
+			negSum = negSum.Add(neg[j])
+		}
+		switch {
+		case negSum.IsZero() && posSum.IsZero():
+			mfi[i] = decimal.NewFromInt(50)
+		case negSum.IsZero():
+			mfi[i] = hundred
+		default:
+			mfi[i] = hundred.Sub(hundred.Div(decimal.NewFromInt(1).Add(posSum.Div(negSum))))
+		}
+	}
+	return mfi
+}
+
+// CalculateChaikinMoneyFlow チャイキン・マネーフロー（CMF）を計算する（既定 period=20）。
+// CMF = Σ(MFM × 出来高) / Σ出来高、MFM = ((C-L) - (H-C)) / (H-L)（-1〜1）。
+// index >= period-1 から確定する。日中レンジ・期間出来高がゼロの場合はゼロ除算回避のため 0 とする。
+func CalculateChaikinMoneyFlow(prices []*models.StockBrandDailyPrice, period int) []decimal.Decimal {
+	n := len(prices)
+	if period <= 0 || n < period {
+		return nil
+	}
+
+	mfv := make([]decimal.Decimal, n)
+	for i, p := range prices {
+		rng := p.High.Sub(p.Low)
+		if rng.IsZero() {
+			continue
+		}
+		mfm := p.Close.Sub(p.Low).Sub(p.High.Sub(p.Close)).Div(rng)
+		mfv[i] = mfm.Mul(decimal.NewFromInt(p.Volume))
+	}
+
+	cmf := make([]decimal.Decimal, n)
+	for i := period - 1; i < n; i++ {
+		var flowSum, volSum decimal.Decimal
+		for j := i - period + 1; j <= i; j++ {
+			flowSum = flowSum.Add(mfv[j])
+			volSum = volSum.Add(decimal.NewFromInt(prices[j].Volume))
+		}
+		if volSum.IsZero() {
+			continue
+		}
+		cmf[i] = flowSum.Div(volSum)
+	}
+	return cmf
+}
+
+// AroonResult アルーン指標の計算結果。
+type AroonResult struct {
+	Up         decimal.Decimal // (period - 最高値からの経過日数) / period × 100
+	Down       decimal.Decimal // (period - 最安値からの経過日数) / period × 100
+	Oscillator decimal.Decimal // Up - Down
+}
+
+// CalculateAroon アルーン指標を計算する（既定 period=25）。当日を含む直近 period+1 本を見て、
+// 同値の高値・安値は直近のものを採る。index >= period から確定する。
+func CalculateAroon(prices []*models.StockBrandDailyPrice, period int) []AroonResult {
+	n := len(prices)
+	if period <= 0 || n <= period {
+		return nil
+	}
+
+	periodDec := decimal.NewFromInt(int64(period))
+	hundred := decimal.NewFromInt(100)
+	results := make([]AroonResult, n)
+	for i := period; i < n; i++ {
+		highIdx, lowIdx := i-period, i-period
+		for j := i - period + 1; j <= i; j++ {
+			if prices[j].High.GreaterThanOrEqual(prices[highIdx].High) {
+				highIdx = j
+			}
+			if prices[j].Low.LessThanOrEqual(prices[lowIdx].Low) {
+				lowIdx = j
+			}
+		}
+		up := periodDec.Sub(decimal.NewFromInt(int64(i - highIdx))).Div(periodDec).Mul(hundred)
+		down := periodDec.Sub(decimal.NewFromInt(int64(i - lowIdx))).Div(periodDec).Mul(hundred)
+		results[i] = AroonResult{Up: up, Down: down, Oscillator: up.Sub(down)}
+	}
+	return results
+}
+
+// SupertrendResult スーパートレンドの計算結果。
+type SupertrendResult struct {
+	Value   decimal.Decimal // 上昇トレンド中は下側バンド、下降トレンド中は上側バンド
+	Uptrend bool
+}
+
+// CalculateSupertrend スーパートレンドを計算する（既定 period=10, multiplier=3）。
+// 基本バンド = (H+L)/2 ± multiplier × ATR。上側バンドは前日終値が上抜けない限り切り下げのみ、
+// 下側バンドは前日終値が下抜けない限り切り上げのみ許し、終値がバンドを抜けたらトレンドを反転する。
+// index >= period（ATR 確定後）から確定し、初日のトレンドは終値と (H+L)/2 の比較で決める。
+func CalculateSupertrend(prices []*models.StockBrandDailyPrice, period int, multiplier decimal.Decimal) []SupertrendResult {
+	n := len(prices)
+	if period <= 0 || n <= period {
+		return nil
+	}
+
+	atr := CalculateATR(prices, period)
+	two := decimal.NewFromInt(2)
+	results := make([]SupertrendResult, n)
+
+	var upper, lower decimal.Decimal
+	var up bool
+	for i := period; i < n; i++ {
+		mid := prices[i].High.Add(prices[i].Low).Div(two)
+		band := atr[i].Mul(multiplier)
+		basicUpper := mid.Add(band)
+		basicLower := mid.Sub(band)
+		c := prices[i].Close
+
+		if i == period {
+			upper, lower = basicUpper, basicLower
+			up = c.GreaterThanOrEqual(mid)
+		} else {
+			prevClose := prices[i-1].Close
+			if basicUpper.LessThan(upper) || prevClose.GreaterThan(upper) {
+				upper = basicUpper
+			}
+			if basicLower.GreaterThan(lower) || prevClose.LessThan(lower) {
+				lower = basicLower
+			}
+			if up && c.LessThan(lower) {
+				up = false
+			} else if !up && c.GreaterThan(upper) {
+				up = true
+			}
+		}
+
+		value := upper
+		if up {
+			value = lower
+		}
+		results[i] = SupertrendResult{Value: value, Uptrend: up}
+	}
+	return results
+}
+
+// CalculateMADeviation 移動平均乖離率（%）= (Close - SMA) / SMA × 100 を計算する（既定 period=25）。
+// index >= period-1 から確定し、SMA がゼロの場合はゼロ除算回避のため 0 とする。
+func CalculateMADeviation(closes []decimal.Decimal, period int) []decimal.Decimal {
+	n := len(closes)
+	if period <= 0 || n < period {
+		return nil
+	}
+
+	sma := smaSeries(closes, period)
+	hundred := decimal.NewFromInt(100)
+	dev := make([]decimal.Decimal, n)
+	for i := period - 1; i < n; i++ {
+		if sma[i].IsZero() {
+			continue
+		}
+		dev[i] = closes[i].Sub(sma[i]).Div(sma[i]).Mul(hundred)
+	}
+	return dev
+}
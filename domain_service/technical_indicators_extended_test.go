@@ -0,0 +1,177 @@
+package domain_service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// risingRows 高値=c+1, 安値=c-1, 終値=c が start から 1 ずつ上がる日足行を返す。
+func risingRows(n int, start float64) [][]float64 {
+	rows := make([][]float64, n)
+	for i := range rows {
+		c := start + float64(i)
+		rows[i] = []float64{c + 1, c - 1, c, 1000}
+	}
+	return rows
+}
+
+func TestCalculateParabolicSAR(t *testing.T) {
+	step, maxStep := decimal.NewFromFloat(0.02), decimal.NewFromFloat(0.2)
+
+	t.Run("データ不足はnil", func(t *testing.T) {
+		assert.Nil(t, CalculateParabolicSAR(pricesFromOHLCV([]float64{101, 99, 100, 1000}), step, maxStep))
+	})
+
+	t.Run("上昇中は価格の下にあり、急落で反転して直前の極値から再開する", func(t *testing.T) {
+		rows := risingRows(10, 100) // 終値 100..109、高値の最大 110
+		rows = append(rows, []float64{95, 85, 90, 1000})
+		prices := pricesFromOHLCV(rows...)
+		sar := CalculateParabolicSAR(prices, step, maxStep)
+		require.Len(t, sar, len(prices))
+		for i := 1; i < 10; i++ {
+			assert.True(t, sar[i].Uptrend, "index %d", i)
+			assert.True(t, sar[i].SAR.LessThanOrEqual(prices[i].Low), "index %d", i)
+		}
+		assert.False(t, sar[10].Uptrend)
+		assert.InDelta(t, 110.0, f64FromDec(sar[10].SAR), 1e-9)
+	})
+}
+
+func TestCalculateDonchianChannels(t *testing.T) {
+	prices := pricesFromOHLCV(
+		[]float64{105, 95, 100, 1000},
+		[]float64{110, 98, 104, 1000},
+		[]float64{108, 90, 95, 1000},
+		[]float64{103, 97, 99, 1000},
+	)
+	assert.Nil(t, CalculateDonchianChannels(prices, 5))
+
+	dc := CalculateDonchianChannels(prices, 3)
+	require.Len(t, dc, 4)
+	assert.True(t, dc[1].Upper.IsZero()) // 未確定
+	assert.InDelta(t, 110.0, f64FromDec(dc[2].Upper), 1e-9)
+	assert.InDelta(t, 90.0, f64FromDec(dc[2].Lower), 1e-9)
+	assert.InDelta(t, 100.0, f64FromDec(dc[2].Middle), 1e-9)
+	// index 3 の窓は 1..3
+	assert.InDelta(t, 110.0, f64FromDec(dc[3].Upper), 1e-9)
+	assert.InDelta(t, 90.0, f64FromDec(dc[3].Lower), 1e-9)
+}
+
+func TestCalculateKeltnerChannels(t *testing.T) {
+	rows := make([][]float64, 25)
+	for i := range rows {
+		rows[i] = []float64{102, 98, 100, 1000}
+	}
+	prices := pricesFromOHLCV(rows...)
+	kc := CalculateKeltnerChannels(prices, 20, 10, decimal.NewFromInt(2))
+	require.Len(t, kc, 25)
+	assert.True(t, kc[18].Middle.IsZero()) // 未確定
+	// 横ばい: EMA=100, ATR=4 → 100 ± 8
+	assert.InDelta(t, 100.0, f64FromDec(kc[19].Middle), 1e-9)
+	assert.InDelta(t, 108.0, f64FromDec(kc[19].Upper), 1e-9)
+	assert.InDelta(t, 92.0, f64FromDec(kc[19].Lower), 1e-9)
+}
+
+func TestCalculateCCI(t *testing.T) {
+	t.Run("横ばいは0", func(t *testing.T) {
+		rows := make([][]float64, 5)
+		for i := range rows {
+			rows[i] = []float64{100, 100, 100, 1000}
+		}
+		cci := CalculateCCI(pricesFromOHLCV(rows...), 3)
+		assert.True(t, cci[4].IsZero())
+	})
+
+	t.Run("手計算値", func(t *testing.T) {
+		// 典型価格 = 終値 = 1, 2, 3 → SMA=2, 平均偏差=2/3, CCI=(3-2)/(0.015*2/3)=100
+		prices := pricesFromOHLCV([]float64{1, 1, 1, 0}, []float64{2, 2, 2, 0}, []float64{3, 3, 3, 0})
+		cci := CalculateCCI(prices, 3)
+		assert.InDelta(t, 100.0, f64FromDec(cci[2]), 1e-6)
+	})
+}
+
+func TestCalculateWilliamsR(t *testing.T) {
+	prices := pricesFromOHLCV(
+		[]float64{110, 90, 100, 1000},
+		[]float64{105, 95, 105, 1000},
+		[]float64{108, 92, 95, 1000},
+	)
+	wr := CalculateWilliamsR(prices, 3)
+	require.Len(t, wr, 3)
+	// (110-95)/(110-90) * -100 = -75
+	assert.InDelta(t, -75.0, f64FromDec(wr[2]), 1e-9)
+	assert.Nil(t, CalculateWilliamsR(prices, 4))
+}
+
+func TestCalculateMFI(t *testing.T) {
+	t.Run("上昇のみは100", func(t *testing.T) {
+		mfi := CalculateMFI(pricesFromOHLCV(risingRows(6, 100)...), 3)
+		require.Len(t, mfi, 6)
+		assert.True(t, mfi[2].IsZero()) // 未確定
+		assert.InDelta(t, 100.0, f64FromDec(mfi[3]), 1e-9)
+	})
+
+	t.Run("正負のフローが等しければ50", func(t *testing.T) {
+		prices := pricesFromOHLCV(
+			[]float64{100, 100, 100, 1000},
+			[]float64{110, 110, 110, 1000},
+			[]float64{100, 100, 100, 1100},
+		)
+		mfi := CalculateMFI(prices, 2)
+		// 正: 110*1000=110000, 負: 100*1100=110000
+		assert.InDelta(t, 50.0, f64FromDec(mfi[2]), 1e-9)
+	})
+}
+
+func TestCalculateChaikinMoneyFlow(t *testing.T) {
+	prices := pricesFromOHLCV(
+		[]float64{110, 90, 110, 1000}, // MFM=1
+		[]float64{110, 90, 90, 3000},  // MFM=-1
+		[]float64{100, 100, 100, 0},   // レンジ0
+	)
+	cmf := CalculateChaikinMoneyFlow(prices, 2)
+	require.Len(t, cmf, 3)
+	// (1000 - 3000) / 4000 = -0.5
+	assert.InDelta(t, -0.5, f64FromDec(cmf[1]), 1e-9)
+	// (-3000 + 0) / 3000 = -1
+	assert.InDelta(t, -1.0, f64FromDec(cmf[2]), 1e-9)
+}
+
+func TestCalculateAroon(t *testing.T) {
+	prices := pricesFromOHLCV(risingRows(6, 100)...)
+	aroon := CalculateAroon(prices, 5)
+	require.Len(t, aroon, 6)
+	// 当日が最高値・最安値は5日前
+	assert.InDelta(t, 100.0, f64FromDec(aroon[5].Up), 1e-9)
+	assert.InDelta(t, 0.0, f64FromDec(aroon[5].Down), 1e-9)
+	assert.InDelta(t, 100.0, f64FromDec(aroon[5].Oscillator), 1e-9)
+	assert.Nil(t, CalculateAroon(prices, 6))
+}
+
+func TestCalculateSupertrend(t *testing.T) {
+	rows := risingRows(15, 100)
+	// 急落で下降転換
+	rows = append(rows, []float64{90, 70, 75, 1000})
+	prices := pricesFromOHLCV(rows...)
+	st := CalculateSupertrend(prices, 5, decimal.NewFromInt(3))
+	require.Len(t, st, len(prices))
+	for i := 5; i < 15; i++ {
+		assert.True(t, st[i].Uptrend, "index %d", i)
+		assert.True(t, st[i].Value.LessThan(prices[i].Close), "index %d", i)
+	}
+	assert.False(t, st[15].Uptrend)
+	assert.True(t, st[15].Value.GreaterThan(prices[15].Close))
+	assert.Nil(t, CalculateSupertrend(prices[:5], 5, decimal.NewFromInt(3)))
+}
+
+func TestCalculateMADeviation(t *testing.T) {
+	dev := CalculateMADeviation(decs(100, 100, 130), 3)
+	require.Len(t, dev, 3)
+	assert.True(t, dev[1].IsZero())
+	// SMA=110 → (130-110)/110*100
+	assert.InDelta(t, 18.181818, f64FromDec(dev[2]), 1e-6)
+	assert.Nil(t, CalculateMADeviation(decs(100), 3))
+}
@@ -0,0 +1,48 @@
+package domain_service
+
+import "github.com/shopspring/decimal"
+
+// tseTickBand 東証の呼値の刻み（価格が Upper 以下の帯で Tick 単位）。
+type tseTickBand struct {
+	upper decimal.Decimal
+	tick  decimal.Decimal
+}
+
+// tseTickBands 一般銘柄（TOPIX500 構成銘柄以外）の呼値テーブル。
+// TOPIX500 構成銘柄はより細かい刻みだが、銘柄区分を持たないため一般銘柄の刻みで統一する。
+var tseTickBands = []tseTickBand{
+	{decimal.NewFromInt(3000), decimal.NewFromInt(1)},
+	{decimal.NewFromInt(5000), decimal.NewFromInt(5)},
+	{decimal.NewFromInt(30000), decimal.NewFromInt(10)},
+	{decimal.NewFromInt(50000), decimal.NewFromInt(50)},
+	{decimal.NewFromInt(300000), decimal.NewFromInt(100)},
+	{decimal.NewFromInt(500000), decimal.NewFromInt(500)},
+	{decimal.NewFromInt(3000000), decimal.NewFromInt(1000)},
+	{decimal.NewFromInt(5000000), decimal.NewFromInt(5000)},
+	{decimal.NewFromInt(30000000), decimal.NewFromInt(10000)},
+	{decimal.NewFromInt(50000000), decimal.NewFromInt(50000)},
+}
+
+var tseTickMax = decimal.NewFromInt(100000)
+
+// TSETickSize 価格帯に対応する呼値の単位を返す。
+func TSETickSize(price decimal.Decimal) decimal.Decimal {
+	for _, b := range tseTickBands {
+		if price.LessThanOrEqual(b.upper) {
+			return b.tick
+		}
+	}
+	return tseTickMax
+}
+
+// RoundUpToTick 呼値単位に切り上げる（利確の売り指値など、到達しにくい側に丸めたい水準用）。
+func RoundUpToTick(price decimal.Decimal) decimal.Decimal {
+	tick := TSETickSize(price)
+	return price.Div(tick).Ceil().Mul(tick)
+}
+
+// RoundDownToTick 呼値単位に切り捨てる（損切りの逆指値など、約定が不利になる側に丸めたい水準用）。
+func RoundDownToTick(price decimal.Decimal) decimal.Decimal {
+	tick := TSETickSize(price)
+	return price.Div(tick).Floor().Mul(tick)
+}
@@ -0,0 +1,17 @@
+package domain_service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTSETickSize(t *testing.T) {
+	assert.True(t, TSETickSize(decimal.NewFromInt(3000)).Equal(decimal.NewFromInt(1)))
+	assert.True(t, TSETickSize(decimal.NewFromInt(3001)).Equal(decimal.NewFromInt(5)))
+	assert.True(t, TSETickSize(decimal.NewFromInt(30001)).Equal(decimal.NewFromInt(50)))
+	assert.True(t, TSETickSize(decimal.NewFromInt(60000000)).Equal(decimal.NewFromInt(100000)))
+	assert.True(t, RoundUpToTick(decimal.NewFromFloat(4001.2)).Equal(decimal.NewFromInt(4005)))
+	assert.True(t, RoundDownToTick(decimal.NewFromFloat(4004.9)).Equal(decimal.NewFromInt(4000)))
+}
@@ -19,6 +19,17 @@ var (
 	defaultMaxHoldDays = 20
 )
 
+// モンテカルロ分析の既定値・上限
+const (
+	defaultMonteCarloIterations = 1000
+	maxMonteCarloIterations     = 10000
+)
+
+var defaultMonteCarloRuinThreshold = decimal.NewFromFloat(0.5)
+
+// maxBorrowRate 貸株料（日率）の上限。0.001/日 ≈ 年36.5%。
+const maxBorrowRate = 0.001
+
 type getBacktestParams struct {
 	symbol string
 	from   *time.Time
@@ -98,61 +109,190 @@ func (h *BacktestHandler) validateGetBacktestParams(r *http.Request) (*getBackte
 	p.from = from
 	p.to = to
 
-	takeProfit, ok := parsePositiveRate(h.httpServer.GetQueryParam(r, "takeProfit"), defaultTakeProfit)
+	params, err := parseBacktestParams(h.httpServer, r)
+	if err != nil {
+		return nil, err
+	}
+	if err := parseExecutionParams(h.httpServer, r, &params); err != nil {
+		return nil, err
+	}
+	p.params = params
+	return p, nil
+}
+
+func (h *BacktestHandler) GetBacktest(w http.ResponseWriter, r *http.Request) {
+	p, err := h.validateGetBacktestParams(r)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate get backtest params", err)
+		return
+	}
+
+	result, err := h.usecase.GetBacktestComparison(r.Context(), p.symbol, p.from, p.to, p.params)
+	if err != nil {
+		writeError(w, h.logger, "failed to get backtest", err)
+		return
+	}
+
+	respondJSON(w, h.logger, result)
+}
+
+// GetStrategies GET /strategies
+// 登録済み戦略の ID・表示名・売買方向・ウォームアップ本数・チューニング可能なパラメータを返す。
+func (h *BacktestHandler) GetStrategies(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, h.logger, models.StrategyList{Strategies: h.usecase.ListStrategies(r.Context())})
+}
+
+// GetBacktestMonteCarlo GET /backtest/monte-carlo?symbol=&strategy=&iterations=
+// 1戦略のトレードリストをブートストラップ／順序入れ替えで再標本化した成績分布を返す。
+func (h *BacktestHandler) GetBacktestMonteCarlo(w http.ResponseWriter, r *http.Request) {
+	p, err := h.validateGetBacktestParams(r)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate get backtest monte carlo params", err)
+		return
+	}
+	strategy, mc, err := h.parseMonteCarloParams(r)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate get backtest monte carlo params", err)
+		return
+	}
+
+	result, err := h.usecase.GetBacktestMonteCarlo(r.Context(), p.symbol, strategy, p.from, p.to, p.params, mc)
+	if err != nil {
+		writeError(w, h.logger, "failed to get backtest monte carlo", err)
+		return
+	}
+
+	respondJSON(w, h.logger, result)
+}
+
+// parseMonteCarloParams strategy / iterations / seed / ruin のクエリを解析する。
+func (h *BacktestHandler) parseMonteCarloParams(r *http.Request) (string, models.BacktestMonteCarloParams, error) {
+	strategy := h.httpServer.GetQueryParam(r, "strategy")
+	if strategy == "" {
+		return "", models.BacktestMonteCarloParams{}, &validationError{message: "strategyは必須です"}
+	}
+	if !isValidStrategy(strategy) {
+		return "", models.BacktestMonteCarloParams{}, &validationError{message: "strategyが不正です"}
+	}
+
+	mc := models.BacktestMonteCarloParams{Iterations: defaultMonteCarloIterations}
+	if raw := h.httpServer.GetQueryParam(r, "iterations"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 100 || n > maxMonteCarloIterations {
+			return "", models.BacktestMonteCarloParams{}, &validationError{message: "iterationsは100〜10000である必要があります"}
+		}
+		mc.Iterations = n
+	}
+	if raw := h.httpServer.GetQueryParam(r, "seed"); raw != "" {
+		seed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return "", models.BacktestMonteCarloParams{}, &validationError{message: "seedは整数である必要があります"}
+		}
+		mc.Seed = seed
+	}
+	ruin, ok := parsePositiveRate(h.httpServer.GetQueryParam(r, "ruin"), defaultMonteCarloRuinThreshold)
+	if !ok {
+		return "", models.BacktestMonteCarloParams{}, &validationError{message: "ruinは0より大きく1以下である必要があります"}
+	}
+	mc.RuinThreshold = ruin
+	return strategy, mc, nil
+}
+
+// parseExecutionParams 約定モデル（entryTiming / exitFill / intrabarPriority / tickRounding）と
+// 空売りの貸株料（borrowRate）のクエリを解析する。省略時は従来動作（当日終値エントリー・終値で TP/SL 判定）。
+func parseExecutionParams(s driver.HTTPServer, r *http.Request, params *models.BacktestParams) error {
+	return applyExecutionParams(func(name string) string { return s.GetQueryParam(r, name) }, params)
+}
+
+// applyExecutionParams get（パラメータ名 → 文字列値。省略は空文字）から約定モデルと貸株料を検証して params に設定する。
+// クエリ（parseExecutionParams）と JSON ボディ（戦略ランキングの実行登録）で同じ検証を使う。
+func applyExecutionParams(get func(name string) string, params *models.BacktestParams) error {
+	switch entryTiming := get("entryTiming"); entryTiming {
+	case "", models.EntryTimingClose:
+		params.EntryTiming = models.EntryTimingClose
+	case models.EntryTimingNextOpen:
+		params.EntryTiming = entryTiming
+	default:
+		return &validationError{message: "entryTimingはcloseまたはnext_openである必要があります"}
+	}
+
+	switch exitFill := get("exitFill"); exitFill {
+	case "", models.ExitFillClose:
+		params.ExitFill = models.ExitFillClose
+	case models.ExitFillIntrabar:
+		params.ExitFill = exitFill
+	default:
+		return &validationError{message: "exitFillはcloseまたはintrabarである必要があります"}
+	}
+
+	switch priority := get("intrabarPriority"); priority {
+	case "", models.IntrabarPriorityStopLoss:
+		params.IntrabarPriority = models.IntrabarPriorityStopLoss
+	case models.IntrabarPriorityTakeProfit:
+		params.IntrabarPriority = priority
+	default:
+		return &validationError{message: "intrabarPriorityはstop_lossまたはtake_profitである必要があります"}
+	}
+
+	if raw := get("tickRounding"); raw != "" {
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return &validationError{message: "tickRoundingはtrueまたはfalseである必要があります"}
+		}
+		params.TickRounding = b
+	}
+
+	if raw := get("borrowRate"); raw != "" {
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil || f < 0 || f > maxBorrowRate {
+			return &validationError{message: "borrowRateは0以上0.001以下（日率）である必要があります"}
+		}
+		params.BorrowRate = decimal.NewFromFloat(f)
+	}
+	return nil
+}
+
+// parseBacktestParams 利確/損切り/最大保有/コスト/イグジットモードのクエリを解析する。
+// /backtest と /backtest/portfolio で共通。
+func parseBacktestParams(s driver.HTTPServer, r *http.Request) (models.BacktestParams, error) {
+	takeProfit, ok := parsePositiveRate(s.GetQueryParam(r, "takeProfit"), defaultTakeProfit)
 	if !ok {
-		return nil, &validationError{message: "takeProfitは0より大きく1以下である必要があります"}
+		return models.BacktestParams{}, &validationError{message: "takeProfitは0より大きく1以下である必要があります"}
 	}
-	stopLoss, ok := parsePositiveRate(h.httpServer.GetQueryParam(r, "stopLoss"), defaultStopLoss)
+	stopLoss, ok := parsePositiveRate(s.GetQueryParam(r, "stopLoss"), defaultStopLoss)
 	if !ok {
-		return nil, &validationError{message: "stopLossは0より大きく1以下である必要があります"}
+		return models.BacktestParams{}, &validationError{message: "stopLossは0より大きく1以下である必要があります"}
 	}
 
 	maxHoldDays := defaultMaxHoldDays
-	if raw := h.httpServer.GetQueryParam(r, "maxHoldDays"); raw != "" {
+	if raw := s.GetQueryParam(r, "maxHoldDays"); raw != "" {
 		d, err := strconv.Atoi(raw)
 		if err != nil || d <= 0 || d > 250 {
-			return nil, &validationError{message: "maxHoldDaysは1〜250である必要があります"}
+			return models.BacktestParams{}, &validationError{message: "maxHoldDaysは1〜250である必要があります"}
 		}
 		maxHoldDays = d
 	}
 
-	commissionRate, ok := parseNonNegativeRate(h.httpServer.GetQueryParam(r, "commission"))
+	commissionRate, ok := parseNonNegativeRate(s.GetQueryParam(r, "commission"))
 	if !ok {
-		return nil, &validationError{message: "commissionは0以上0.05以下である必要があります"}
+		return models.BacktestParams{}, &validationError{message: "commissionは0以上0.05以下である必要があります"}
 	}
-	slippageRate, ok := parseNonNegativeRate(h.httpServer.GetQueryParam(r, "slippage"))
+	slippageRate, ok := parseNonNegativeRate(s.GetQueryParam(r, "slippage"))
 	if !ok {
-		return nil, &validationError{message: "slippageは0以上0.05以下である必要があります"}
+		return models.BacktestParams{}, &validationError{message: "slippageは0以上0.05以下である必要があります"}
 	}
 
-	exitMode, ok := parseExitMode(h.httpServer.GetQueryParam(r, "exitMode"))
+	exitMode, ok := parseExitMode(s.GetQueryParam(r, "exitMode"))
 	if !ok {
-		return nil, &validationError{message: "exitModeはcommonまたはsignalである必要があります"}
+		return models.BacktestParams{}, &validationError{message: "exitModeはcommonまたはsignalである必要があります"}
 	}
 
-	p.params = models.BacktestParams{
+	return models.BacktestParams{
 		TakeProfit:     takeProfit,
 		StopLoss:       stopLoss,
 		MaxHoldDays:    maxHoldDays,
 		CommissionRate: commissionRate,
 		SlippageRate:   slippageRate,
 		ExitMode:       exitMode,
-	}
-	return p, nil
-}
-
-func (h *BacktestHandler) GetBacktest(w http.ResponseWriter, r *http.Request) {
-	p, err := h.validateGetBacktestParams(r)
-	if err != nil {
-		writeError(w, h.logger, "failed to validate get backtest params", err)
-		return
-	}
-
-	result, err := h.usecase.GetBacktestComparison(r.Context(), p.symbol, p.from, p.to, p.params)
-	if err != nil {
-		writeError(w, h.logger, "failed to get backtest", err)
-		return
-	}
-
-	respondJSON(w, h.logger, result)
+	}, nil
 }
@@ -18,15 +18,26 @@ import (
 	"go.uber.org/zap"
 )
 
+func expectDefaultExecutionQueryParams(m *mock_driver.MockHTTPServer) {
+	m.EXPECT().GetQueryParam(gomock.Any(), "entryTiming").Return("")
+	m.EXPECT().GetQueryParam(gomock.Any(), "exitFill").Return("")
+	m.EXPECT().GetQueryParam(gomock.Any(), "intrabarPriority").Return("")
+	m.EXPECT().GetQueryParam(gomock.Any(), "tickRounding").Return("")
+	m.EXPECT().GetQueryParam(gomock.Any(), "borrowRate").Return("")
+}
+
 func TestBacktestHandler_GetBacktest(t *testing.T) {
 	date, _ := time.ParseInLocation(util.DateLayout, "2021-01-04", time.Local)
 	defaultParams := models.BacktestParams{
-		TakeProfit:     decimal.NewFromFloat(0.10),
-		StopLoss:       decimal.NewFromFloat(0.05),
-		MaxHoldDays:    20,
-		CommissionRate: decimal.Zero,
-		SlippageRate:   decimal.Zero,
-		ExitMode:       models.ExitModeCommon,
+		TakeProfit:       decimal.NewFromFloat(0.10),
+		StopLoss:         decimal.NewFromFloat(0.05),
+		MaxHoldDays:      20,
+		CommissionRate:   decimal.Zero,
+		SlippageRate:     decimal.Zero,
+		ExitMode:         models.ExitModeCommon,
+		EntryTiming:      models.EntryTimingClose,
+		ExitFill:         models.ExitFillClose,
+		IntrabarPriority: models.IntrabarPriorityStopLoss,
 	}
 
 	type fields struct {
@@ -66,6 +77,7 @@ func TestBacktestHandler_GetBacktest(t *testing.T) {
 					m.EXPECT().GetQueryParam(gomock.Any(), "commission").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "slippage").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "exitMode").Return("")
+					expectDefaultExecutionQueryParams(m)
 					return m
 				},
 			},
@@ -151,6 +163,7 @@ func TestBacktestHandler_GetBacktest(t *testing.T) {
 					m.EXPECT().GetQueryParam(gomock.Any(), "commission").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "slippage").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "exitMode").Return("")
+					expectDefaultExecutionQueryParams(m)
 					return m
 				},
 			},
@@ -348,7 +361,6 @@ func TestBacktestHandler_GetBacktest_ExitMode(t *testing.T) {
 			req:            httptest.NewRequest(http.MethodGet, "/backtest?symbol=7203&exitMode=invalid", nil),
 			wantStatusCode: http.StatusBadRequest,
 			wantBody:       "exitModeはcommonまたはsignalである必要があります\n",
-
 		},
 		{
 			name: "正常系: exitMode=signal でバックテスト取得",
@@ -356,20 +368,23 @@ func TestBacktestHandler_GetBacktest_ExitMode(t *testing.T) {
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockBacktestInteractor {
 					m := mock_usecase.NewMockBacktestInteractor(ctrl)
 					signalParams := models.BacktestParams{
-						TakeProfit:     decimal.NewFromFloat(0.10),
-						StopLoss:       decimal.NewFromFloat(0.05),
-						MaxHoldDays:    20,
-						CommissionRate: decimal.Zero,
-						SlippageRate:   decimal.Zero,
-						ExitMode:       models.ExitModeSignal,
+						TakeProfit:       decimal.NewFromFloat(0.10),
+						StopLoss:         decimal.NewFromFloat(0.05),
+						MaxHoldDays:      20,
+						CommissionRate:   decimal.Zero,
+						SlippageRate:     decimal.Zero,
+						ExitMode:         models.ExitModeSignal,
+						EntryTiming:      models.EntryTimingClose,
+						ExitFill:         models.ExitFillClose,
+						IntrabarPriority: models.IntrabarPriorityStopLoss,
 					}
 					m.EXPECT().
 						GetBacktestComparison(gomock.Any(), "7203", &date, &date, signalParams).
 						Return(&models.BacktestComparison{
-							Symbol:     "7203",
+							Symbol:      "7203",
 							TradingDays: 1,
-							Params:     signalParams,
-							Strategies: []models.StrategyBacktest{},
+							Params:      signalParams,
+							Strategies:  []models.StrategyBacktest{},
 						}, nil)
 					return m
 				},
@@ -382,6 +397,7 @@ func TestBacktestHandler_GetBacktest_ExitMode(t *testing.T) {
 					m.EXPECT().GetQueryParam(gomock.Any(), "commission").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "slippage").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "exitMode").Return("signal")
+					expectDefaultExecutionQueryParams(m)
 					return m
 				},
 			},
@@ -394,20 +410,23 @@ func TestBacktestHandler_GetBacktest_ExitMode(t *testing.T) {
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockBacktestInteractor {
 					m := mock_usecase.NewMockBacktestInteractor(ctrl)
 					commonParams := models.BacktestParams{
-						TakeProfit:     decimal.NewFromFloat(0.10),
-						StopLoss:       decimal.NewFromFloat(0.05),
-						MaxHoldDays:    20,
-						CommissionRate: decimal.Zero,
-						SlippageRate:   decimal.Zero,
-						ExitMode:       models.ExitModeCommon,
+						TakeProfit:       decimal.NewFromFloat(0.10),
+						StopLoss:         decimal.NewFromFloat(0.05),
+						MaxHoldDays:      20,
+						CommissionRate:   decimal.Zero,
+						SlippageRate:     decimal.Zero,
+						ExitMode:         models.ExitModeCommon,
+						EntryTiming:      models.EntryTimingClose,
+						ExitFill:         models.ExitFillClose,
+						IntrabarPriority: models.IntrabarPriorityStopLoss,
 					}
 					m.EXPECT().
 						GetBacktestComparison(gomock.Any(), "7203", &date, &date, commonParams).
 						Return(&models.BacktestComparison{
-							Symbol:     "7203",
+							Symbol:      "7203",
 							TradingDays: 1,
-							Params:     commonParams,
-							Strategies: []models.StrategyBacktest{},
+							Params:      commonParams,
+							Strategies:  []models.StrategyBacktest{},
 						}, nil)
 					return m
 				},
@@ -420,6 +439,7 @@ func TestBacktestHandler_GetBacktest_ExitMode(t *testing.T) {
 					m.EXPECT().GetQueryParam(gomock.Any(), "commission").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "slippage").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "exitMode").Return("common")
+					expectDefaultExecutionQueryParams(m)
 					return m
 				},
 			},
@@ -445,3 +465,203 @@ func TestBacktestHandler_GetBacktest_ExitMode(t *testing.T) {
 		})
 	}
 }
+
+func TestBacktestHandler_GetBacktestMonteCarlo(t *testing.T) {
+	defaultParams := models.BacktestParams{
+		TakeProfit:       decimal.NewFromFloat(0.10),
+		StopLoss:         decimal.NewFromFloat(0.05),
+		MaxHoldDays:      20,
+		CommissionRate:   decimal.Zero,
+		SlippageRate:     decimal.Zero,
+		ExitMode:         models.ExitModeCommon,
+		EntryTiming:      models.EntryTimingClose,
+		ExitFill:         models.ExitFillClose,
+		IntrabarPriority: models.IntrabarPriorityStopLoss,
+	}
+
+	type fields struct {
+		usecase    func(ctrl *gomock.Controller) *mock_usecase.MockBacktestInteractor
+		httpServer func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: iterations/seed 指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockBacktestInteractor {
+					m := mock_usecase.NewMockBacktestInteractor(ctrl)
+					m.EXPECT().GetBacktestMonteCarlo(gomock.Any(), "7203", "macd_bullish", nil, nil, defaultParams, models.BacktestMonteCarloParams{
+						Iterations:    500,
+						Seed:          42,
+						RuinThreshold: decimal.NewFromFloat(0.5),
+					}).Return(&models.BacktestMonteCarlo{Symbol: "7203"}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("7203")
+					expectDefaultExitQueryParams(m)
+					expectDefaultExecutionQueryParams(m)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("macd_bullish")
+					m.EXPECT().GetQueryParam(gomock.Any(), "iterations").Return("500")
+					m.EXPECT().GetQueryParam(gomock.Any(), "seed").Return("42")
+					m.EXPECT().GetQueryParam(gomock.Any(), "ruin").Return("")
+					return m
+				},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: strategy未指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockBacktestInteractor {
+					return mock_usecase.NewMockBacktestInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("7203")
+					expectDefaultExitQueryParams(m)
+					expectDefaultExecutionQueryParams(m)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("")
+					return m
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "strategyは必須です\n",
+		},
+		{
+			name: "異常系: iterationsが範囲外",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockBacktestInteractor {
+					return mock_usecase.NewMockBacktestInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("7203")
+					expectDefaultExitQueryParams(m)
+					expectDefaultExecutionQueryParams(m)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("ma_cross")
+					m.EXPECT().GetQueryParam(gomock.Any(), "iterations").Return("20000")
+					return m
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "iterationsは100〜10000である必要があります\n",
+		},
+		{
+			name: "異常系: usecaseエラー",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockBacktestInteractor {
+					m := mock_usecase.NewMockBacktestInteractor(ctrl)
+					m.EXPECT().GetBacktestMonteCarlo(gomock.Any(), "7203", "ma_cross", nil, nil, defaultParams, gomock.Any()).Return(nil, errors.New("db error"))
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("7203")
+					expectDefaultExitQueryParams(m)
+					expectDefaultExecutionQueryParams(m)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("ma_cross")
+					m.EXPECT().GetQueryParam(gomock.Any(), "iterations").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "seed").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "ruin").Return("")
+					return m
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewBacktestHandler(tt.fields.usecase(ctrl), tt.fields.httpServer(ctrl), zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetBacktestMonteCarlo(w, httptest.NewRequest(http.MethodGet, "/backtest/monte-carlo?symbol=7203", nil))
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBacktestHandler_GetBacktest_FillParams(t *testing.T) {
+	t.Run("正常系: 翌寄りエントリー・高安判定・呼値丸め・貸株料", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		u := mock_usecase.NewMockBacktestInteractor(ctrl)
+		u.EXPECT().GetBacktestComparison(gomock.Any(), "7203", nil, nil, models.BacktestParams{
+			TakeProfit:       decimal.NewFromFloat(0.10),
+			StopLoss:         decimal.NewFromFloat(0.05),
+			MaxHoldDays:      20,
+			CommissionRate:   decimal.Zero,
+			SlippageRate:     decimal.Zero,
+			ExitMode:         models.ExitModeCommon,
+			EntryTiming:      models.EntryTimingNextOpen,
+			ExitFill:         models.ExitFillIntrabar,
+			IntrabarPriority: models.IntrabarPriorityTakeProfit,
+			TickRounding:     true,
+			BorrowRate:       decimal.NewFromFloat(0.00003),
+		}).Return(&models.BacktestComparison{}, nil)
+		s := mock_driver.NewMockHTTPServer(ctrl)
+		s.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("7203")
+		expectDefaultExitQueryParams(s)
+		s.EXPECT().GetQueryParam(gomock.Any(), "entryTiming").Return("next_open")
+		s.EXPECT().GetQueryParam(gomock.Any(), "exitFill").Return("intrabar")
+		s.EXPECT().GetQueryParam(gomock.Any(), "intrabarPriority").Return("take_profit")
+		s.EXPECT().GetQueryParam(gomock.Any(), "tickRounding").Return("true")
+		s.EXPECT().GetQueryParam(gomock.Any(), "borrowRate").Return("0.00003")
+
+		w := httptest.NewRecorder()
+		NewBacktestHandler(u, s, zap.NewNop()).GetBacktest(w, httptest.NewRequest(http.MethodGet, "/backtest", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("異常系: exitFill が不正値", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := mock_driver.NewMockHTTPServer(ctrl)
+		s.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("7203")
+		expectDefaultExitQueryParams(s)
+		s.EXPECT().GetQueryParam(gomock.Any(), "entryTiming").Return("")
+		s.EXPECT().GetQueryParam(gomock.Any(), "exitFill").Return("high_low")
+
+		w := httptest.NewRecorder()
+		NewBacktestHandler(mock_usecase.NewMockBacktestInteractor(ctrl), s, zap.NewNop()).GetBacktest(w, httptest.NewRequest(http.MethodGet, "/backtest", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "exitFillはcloseまたはintrabarである必要があります\n", w.Body.String())
+	})
+}
+
+func TestBacktestHandler_GetStrategies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	u := mock_usecase.NewMockBacktestInteractor(ctrl)
+	u.EXPECT().ListStrategies(gomock.Any()).Return([]models.StrategyInfo{
+		{ID: "macd_bullish", Label: "MACD強気", Side: models.PositionSideLong, WarmupBars: 36, DailyPick: true, Params: []models.StrategyParamSpec{}},
+	})
+	h := NewBacktestHandler(u, mock_driver.NewMockHTTPServer(ctrl), zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	h.GetStrategies(rec, httptest.NewRequest(http.MethodGet, "/strategies", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var got models.StrategyList
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	if assert.Len(t, got.Strategies, 1) {
+		assert.Equal(t, "macd_bullish", got.Strategies[0].ID)
+		assert.Equal(t, 36, got.Strategies[0].WarmupBars)
+		assert.True(t, got.Strategies[0].DailyPick)
+	}
+}
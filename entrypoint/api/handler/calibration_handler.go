@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+type CalibrationHandler struct {
+	usecase    usecase.CalibrationInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+}
+
+func NewCalibrationHandler(u usecase.CalibrationInteractor, s driver.HTTPServer, l *zap.Logger) *CalibrationHandler {
+	return &CalibrationHandler{usecase: u, httpServer: s, logger: l}
+}
+
+func (h *CalibrationHandler) validateParams(r *http.Request) (*models.CalibrationFilter, error) {
+	source := h.httpServer.GetQueryParam(r, "source")
+	if source == "" {
+		source = models.CalibrationSourcePicks
+	}
+	if source != models.CalibrationSourcePicks && source != models.CalibrationSourceSignals {
+		return nil, &validationError{message: "sourceはpicksまたはsignalsを指定してください"}
+	}
+	method := h.httpServer.GetQueryParam(r, "method")
+	if source == models.CalibrationSourceSignals && method == "" {
+		return nil, &validationError{message: "source=signals の場合 method は必須です"}
+	}
+
+	fromParam, toParam, err := parseDateRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if toParam != nil {
+		to = *toParam
+	}
+	from := to.AddDate(0, 0, -90)
+	if fromParam != nil {
+		from = *fromParam
+	}
+	if from.After(to) {
+		return nil, &validationError{message: "fromはto以前の日付である必要があります"}
+	}
+	if to.Sub(from).Hours()/24 > 366 {
+		return nil, &validationError{message: "期間は最大366日以内で指定してください"}
+	}
+
+	return &models.CalibrationFilter{
+		Source:       source,
+		From:         from,
+		To:           to,
+		ScoreVersion: h.httpServer.GetQueryParam(r, "score_version"),
+		Method:       method,
+	}, nil
+}
+
+// GetCalibration GET /calibration?source=picks|signals&from=&to=&score_version=&method=
+// source 省略時は picks。期間省略時は to（省略時は当日）までの90日間。
+func (h *CalibrationHandler) GetCalibration(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.validateParams(r)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate calibration params", err)
+		return
+	}
+
+	result, err := h.usecase.GetCalibration(r.Context(), filter)
+	if err != nil {
+		writeError(w, h.logger, "failed to get calibration", err)
+		return
+	}
+
+	respondJSON(w, h.logger, result)
+}
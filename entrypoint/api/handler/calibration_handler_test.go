@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+func TestCalibrationHandler_GetCalibration(t *testing.T) {
+	from, _ := time.ParseInLocation(util.DateLayout, "2026-04-01", time.Local)
+	to, _ := time.ParseInLocation(util.DateLayout, "2026-06-30", time.Local)
+
+	queryParams := func(ctrl *gomock.Controller, source, scoreVersion, method string) *mock_driver.MockHTTPServer {
+		m := mock_driver.NewMockHTTPServer(ctrl)
+		m.EXPECT().GetQueryParam(gomock.Any(), "source").Return(source)
+		m.EXPECT().GetQueryParam(gomock.Any(), "score_version").Return(scoreVersion).AnyTimes()
+		m.EXPECT().GetQueryParam(gomock.Any(), "method").Return(method).AnyTimes()
+		return m
+	}
+
+	type fields struct {
+		usecase    func(ctrl *gomock.Controller) *mock_usecase.MockCalibrationInteractor
+		httpServer func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: signals・期間・手法指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCalibrationInteractor {
+					m := mock_usecase.NewMockCalibrationInteractor(ctrl)
+					m.EXPECT().GetCalibration(gomock.Any(), &models.CalibrationFilter{
+						Source: models.CalibrationSourceSignals,
+						From:   from,
+						To:     to,
+						Method: "find_macd_bullish_stock_v1",
+					}).Return(&models.Calibration{}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return queryParams(ctrl, "signals", "", "find_macd_bullish_stock_v1")
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/calibration?source=signals&from=2026-04-01&to=2026-06-30&method=find_macd_bullish_stock_v1", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "正常系: source省略時はpicks",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCalibrationInteractor {
+					m := mock_usecase.NewMockCalibrationInteractor(ctrl)
+					m.EXPECT().GetCalibration(gomock.Any(), &models.CalibrationFilter{
+						Source:       models.CalibrationSourcePicks,
+						From:         to.AddDate(0, 0, -90),
+						To:           to,
+						ScoreVersion: "v1-trend",
+					}).Return(&models.Calibration{}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return queryParams(ctrl, "", "v1-trend", "")
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/calibration?to=2026-06-30&score_version=v1-trend", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: sourceが不正",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCalibrationInteractor {
+					return mock_usecase.NewMockCalibrationInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return queryParams(ctrl, "quiz", "", "")
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/calibration?source=quiz", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "sourceはpicksまたはsignalsを指定してください\n",
+		},
+		{
+			name: "異常系: signalsでmethod未指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCalibrationInteractor {
+					return mock_usecase.NewMockCalibrationInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return queryParams(ctrl, "signals", "", "")
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/calibration?source=signals", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "source=signals の場合 method は必須です\n",
+		},
+		{
+			name: "異常系: 期間が366日超",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCalibrationInteractor {
+					return mock_usecase.NewMockCalibrationInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return queryParams(ctrl, "picks", "", "")
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/calibration?source=picks&from=2025-01-01&to=2026-06-30", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "期間は最大366日以内で指定してください\n",
+		},
+		{
+			name: "異常系: usecaseエラー",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCalibrationInteractor {
+					m := mock_usecase.NewMockCalibrationInteractor(ctrl)
+					m.EXPECT().GetCalibration(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return queryParams(ctrl, "picks", "", "")
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/calibration?source=picks", nil),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewCalibrationHandler(tt.fields.usecase(ctrl), tt.fields.httpServer(ctrl), zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetCalibration(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+type CandlestickPatternHandler struct {
+	usecase    usecase.CandlestickPatternInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+}
+
+func NewCandlestickPatternHandler(u usecase.CandlestickPatternInteractor, h driver.HTTPServer, l *zap.Logger) *CandlestickPatternHandler {
+	return &CandlestickPatternHandler{
+		usecase:    u,
+		httpServer: h,
+		logger:     l,
+	}
+}
+
+// GetCandlestickPatterns GET /candlestick-patterns?symbol=&from=&to=
+// from 省略時は to（省略時は現在）から1年前。
+func (h *CandlestickPatternHandler) GetCandlestickPatterns(w http.ResponseWriter, r *http.Request) {
+	symbol := h.httpServer.GetQueryParam(r, "symbol")
+	if symbol == "" {
+		writeError(w, h.logger, "failed to validate candlestick patterns params", &validationError{message: "シンボルは必須です"})
+		return
+	}
+	if len(symbol) > 10 || !alphanumericRequiredRegex.MatchString(symbol) {
+		writeError(w, h.logger, "failed to validate candlestick patterns params", &validationError{message: "シンボルは10文字以内の英数字である必要があります"})
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate candlestick patterns params", err)
+		return
+	}
+
+	result, err := h.usecase.GetCandlestickPatterns(r.Context(), symbol, from, to)
+	if err != nil {
+		writeError(w, h.logger, "failed to get candlestick patterns", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
+
+// GetCandlestickPatternScreening GET /candlestick-patterns/screening?date=YYYY-MM-DD
+// date 省略時は当日。その日の日足が無い銘柄は対象外。
+func (h *CandlestickPatternHandler) GetCandlestickPatternScreening(w http.ResponseWriter, r *http.Request) {
+	date, err := h.httpServer.GetQueryParamDate(r, "date", util.DateLayout)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate candlestick pattern screening params", &validationError{message: "dateの日付形式が不正です (YYYY-MM-DD)"})
+		return
+	}
+
+	result, err := h.usecase.ScreenCandlestickPatterns(r.Context(), date)
+	if err != nil {
+		writeError(w, h.logger, "failed to screen candlestick patterns", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
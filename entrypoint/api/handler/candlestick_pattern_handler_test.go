@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+func TestCandlestickPatternHandler_GetCandlestickPatterns(t *testing.T) {
+	from, _ := time.ParseInLocation(util.DateLayout, "2024-01-01", time.Local)
+	to, _ := time.ParseInLocation(util.DateLayout, "2024-03-31", time.Local)
+
+	type fields struct {
+		usecase    func(ctrl *gomock.Controller) *mock_usecase.MockCandlestickPatternInteractor
+		httpServer func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: 期間指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCandlestickPatternInteractor {
+					m := mock_usecase.NewMockCandlestickPatternInteractor(ctrl)
+					m.EXPECT().GetCandlestickPatterns(gomock.Any(), "7203", &from, &to).Return(&models.CandlestickPatterns{Symbol: "7203"}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("7203")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/candlestick-patterns?symbol=7203&from=2024-01-01&to=2024-03-31", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: symbol未指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCandlestickPatternInteractor {
+					return mock_usecase.NewMockCandlestickPatternInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/candlestick-patterns", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "シンボルは必須です\n",
+		},
+		{
+			name: "異常系: fromがtoより後",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCandlestickPatternInteractor {
+					return mock_usecase.NewMockCandlestickPatternInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("7203")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/candlestick-patterns?symbol=7203&from=2024-04-01&to=2024-03-31", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "fromはto以前の日付である必要があります\n",
+		},
+		{
+			name: "異常系: usecaseエラー",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockCandlestickPatternInteractor {
+					m := mock_usecase.NewMockCandlestickPatternInteractor(ctrl)
+					m.EXPECT().GetCandlestickPatterns(gomock.Any(), "7203", nil, nil).Return(nil, errors.New("db error"))
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("7203")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/candlestick-patterns?symbol=7203", nil),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewCandlestickPatternHandler(tt.fields.usecase(ctrl), tt.fields.httpServer(ctrl), zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetCandlestickPatterns(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCandlestickPatternHandler_GetCandlestickPatternScreening(t *testing.T) {
+	date, _ := time.ParseInLocation(util.DateLayout, "2024-03-29", time.Local)
+
+	t.Run("正常系", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		u := mock_usecase.NewMockCandlestickPatternInteractor(ctrl)
+		u.EXPECT().ScreenCandlestickPatterns(gomock.Any(), &date).Return(&models.CandlestickPatternScreening{Date: date}, nil)
+		s := mock_driver.NewMockHTTPServer(ctrl)
+		s.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(&date, nil)
+
+		w := httptest.NewRecorder()
+		NewCandlestickPatternHandler(u, s, zap.NewNop()).GetCandlestickPatternScreening(w, httptest.NewRequest(http.MethodGet, "/candlestick-patterns/screening?date=2024-03-29", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("異常系: date形式不正", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		u := mock_usecase.NewMockCandlestickPatternInteractor(ctrl)
+		s := mock_driver.NewMockHTTPServer(ctrl)
+		s.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(nil, errors.New("parse error"))
+
+		w := httptest.NewRecorder()
+		NewCandlestickPatternHandler(u, s, zap.NewNop()).GetCandlestickPatternScreening(w, httptest.NewRequest(http.MethodGet, "/candlestick-patterns/screening?date=2024/03/29", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "dateの日付形式が不正です (YYYY-MM-DD)\n", w.Body.String())
+	})
+}
@@ -26,8 +26,9 @@ func NewDailyStockPickHandler(u usecase.DailyStockPickInteractor, s driver.HTTPS
 	return &DailyStockPickHandler{usecase: u, httpServer: s, logger: l}
 }
 
-// GetDailyStockPicks GET /daily-stock-picks?date=YYYY-MM-DD
+// GetDailyStockPicks GET /daily-stock-picks?date=YYYY-MM-DD&score_version=
 // date 省略時は最新の pick_date にフォールバックする。該当日が無くても 200 で空を返す。
+// score_version 省略時は live のスコア設定の推奨を返す（シャドーの推奨はバージョンを指定して見る）。
 func (h *DailyStockPickHandler) GetDailyStockPicks(w http.ResponseWriter, r *http.Request) {
 	date, err := h.httpServer.GetQueryParamDate(r, "date", util.DateLayout)
 	if err != nil {
@@ -35,7 +36,9 @@ func (h *DailyStockPickHandler) GetDailyStockPicks(w http.ResponseWriter, r *htt
 		return
 	}
 
-	day, err := h.usecase.GetDay(r.Context(), date)
+	scoreVersion := h.httpServer.GetQueryParam(r, "score_version")
+
+	day, err := h.usecase.GetDay(r.Context(), date, scoreVersion)
 	if err != nil {
 		writeError(w, h.logger, "daily stock picks get day failed", err)
 		return
@@ -69,6 +72,7 @@ func (h *DailyStockPickHandler) GetDailyStockPickDates(w http.ResponseWriter, r
 
 // GetDailyStockPickStats GET /daily-stock-picks/stats?from=&to=&score_version=
 // score_version 省略時は現行のスコア定義バージョンで絞る（定義の異なる推奨を混ぜて集計しないため）。
+// versions には並走させている各スコア設定の live との比較が入る。
 func (h *DailyStockPickHandler) GetDailyStockPickStats(w http.ResponseWriter, r *http.Request) {
 	from, to, err := parseDateRange(r)
 	if err != nil {
@@ -85,3 +89,23 @@ func (h *DailyStockPickHandler) GetDailyStockPickStats(w http.ResponseWriter, r
 	}
 	respondJSON(w, h.logger, stats)
 }
+
+// GetDailyStockPickStatsBreakdown GET /daily-stock-picks/stats/breakdown?from=&to=&score_version=
+// 推奨と同じ期間の分析シグナルの成績を、業種・市場・戦略・流動性帯・曜日の切り口で返す。
+// 特定の業種・市場で負け越す戦略を見つけて外す判断に使う。
+func (h *DailyStockPickHandler) GetDailyStockPickStatsBreakdown(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		writeError(w, h.logger, "daily stock picks stats breakdown invalid date range", err)
+		return
+	}
+
+	scoreVersion := h.httpServer.GetQueryParam(r, "score_version")
+
+	breakdown, err := h.usecase.GetStatsBreakdown(r.Context(), from, to, scoreVersion)
+	if err != nil {
+		writeError(w, h.logger, "daily stock picks get stats breakdown failed", err)
+		return
+	}
+	respondJSON(w, h.logger, breakdown)
+}
@@ -39,7 +39,7 @@ func TestDailyStockPickHandler_GetDailyStockPicks(t *testing.T) {
 			fields: fields{
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockDailyStockPickInteractor {
 					m := mock_usecase.NewMockDailyStockPickInteractor(ctrl)
-					m.EXPECT().GetDay(gomock.Any(), gomock.Eq(&pickDate)).Return(&models.DailyStockPickDay{
+					m.EXPECT().GetDay(gomock.Any(), gomock.Eq(&pickDate), "").Return(&models.DailyStockPickDay{
 						PickDate:     &pickDateStr,
 						ScoreVersion: "v1",
 						Evaluated:    false,
@@ -64,6 +64,7 @@ func TestDailyStockPickHandler_GetDailyStockPicks(t *testing.T) {
 				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
 					m := mock_driver.NewMockHTTPServer(ctrl)
 					m.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(&pickDate, nil)
+					m.EXPECT().GetQueryParam(gomock.Any(), "score_version").Return("")
 					return m
 				},
 			},
@@ -91,11 +92,11 @@ func TestDailyStockPickHandler_GetDailyStockPicks(t *testing.T) {
 			},
 		},
 		{
-			name: "正常系: date省略時もusecaseにnilを渡して200を返す",
+			name: "正常系: date省略時もusecaseにnilを渡して200を返す（score_versionはそのまま渡す）",
 			fields: fields{
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockDailyStockPickInteractor {
 					m := mock_usecase.NewMockDailyStockPickInteractor(ctrl)
-					m.EXPECT().GetDay(gomock.Any(), gomock.Nil()).Return(&models.DailyStockPickDay{
+					m.EXPECT().GetDay(gomock.Any(), gomock.Nil(), "v1-trend").Return(&models.DailyStockPickDay{
 						ScoreVersion: "v1",
 						Items:        []*models.DailyStockPickItem{},
 					}, nil)
@@ -104,6 +105,7 @@ func TestDailyStockPickHandler_GetDailyStockPicks(t *testing.T) {
 				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
 					m := mock_driver.NewMockHTTPServer(ctrl)
 					m.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(nil, nil)
+					m.EXPECT().GetQueryParam(gomock.Any(), "score_version").Return("v1-trend")
 					return m
 				},
 			},
@@ -135,12 +137,13 @@ func TestDailyStockPickHandler_GetDailyStockPicks(t *testing.T) {
 			fields: fields{
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockDailyStockPickInteractor {
 					m := mock_usecase.NewMockDailyStockPickInteractor(ctrl)
-					m.EXPECT().GetDay(gomock.Any(), gomock.Nil()).Return(nil, errors.New("db error"))
+					m.EXPECT().GetDay(gomock.Any(), gomock.Nil(), "").Return(nil, errors.New("db error"))
 					return m
 				},
 				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
 					m := mock_driver.NewMockHTTPServer(ctrl)
 					m.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(nil, nil)
+					m.EXPECT().GetQueryParam(gomock.Any(), "score_version").Return("")
 					return m
 				},
 			},
@@ -335,3 +338,54 @@ func TestDailyStockPickHandler_GetDailyStockPickStats(t *testing.T) {
 		assert.Equal(t, "内部サーバーエラー\n", w.Body.String())
 	})
 }
+
+func TestDailyStockPickHandler_GetDailyStockPickStatsBreakdown(t *testing.T) {
+	t.Run("正常系: 期間とscore_versionをそのまま渡す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.Local)
+		to := time.Date(2026, 7, 24, 0, 0, 0, 0, time.Local)
+		u := mock_usecase.NewMockDailyStockPickInteractor(ctrl)
+		u.EXPECT().GetStatsBreakdown(gomock.Any(), gomock.Eq(&from), gomock.Eq(&to), gomock.Eq("v2")).
+			Return(&models.DailyStockPickStatsBreakdown{ScoreVersion: "v2"}, nil)
+
+		s := mock_driver.NewMockHTTPServer(ctrl)
+		s.EXPECT().GetQueryParam(gomock.Any(), "score_version").Return("v2")
+
+		h := NewDailyStockPickHandler(u, s, zap.NewNop())
+		w := httptest.NewRecorder()
+		h.GetDailyStockPickStatsBreakdown(w, httptest.NewRequest(http.MethodGet, "/daily-stock-picks/stats/breakdown?from=2026-07-01&to=2026-07-24&score_version=v2", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("異常系: from > to は400", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		h := NewDailyStockPickHandler(mock_usecase.NewMockDailyStockPickInteractor(ctrl), mock_driver.NewMockHTTPServer(ctrl), zap.NewNop())
+		w := httptest.NewRecorder()
+		h.GetDailyStockPickStatsBreakdown(w, httptest.NewRequest(http.MethodGet, "/daily-stock-picks/stats/breakdown?from=2026-07-24&to=2026-07-01", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("異常系: usecaseエラーは500", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		u := mock_usecase.NewMockDailyStockPickInteractor(ctrl)
+		u.EXPECT().GetStatsBreakdown(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		s := mock_driver.NewMockHTTPServer(ctrl)
+		s.EXPECT().GetQueryParam(gomock.Any(), "score_version").Return("")
+
+		h := NewDailyStockPickHandler(u, s, zap.NewNop())
+		w := httptest.NewRecorder()
+		h.GetDailyStockPickStatsBreakdown(w, httptest.NewRequest(http.MethodGet, "/daily-stock-picks/stats/breakdown", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "内部サーバーエラー\n", w.Body.String())
+	})
+}
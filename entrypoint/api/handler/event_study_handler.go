@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+const (
+	eventStudyMaxEvents     = 2000
+	eventStudyMaxRangeDays  = 1096
+	eventStudyMaxPreDays    = 20
+	eventStudyMaxPostDays   = 60
+	eventStudyMinEstimation = 30
+	eventStudyMaxEstimation = 250
+)
+
+type EventStudyHandler struct {
+	usecase    usecase.EventStudyInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+}
+
+func NewEventStudyHandler(u usecase.EventStudyInteractor, h driver.HTTPServer, l *zap.Logger) *EventStudyHandler {
+	return &EventStudyHandler{
+		usecase:    u,
+		httpServer: h,
+		logger:     l,
+	}
+}
+
+type eventStudyRequest struct {
+	Source    string `json:"source"`
+	Benchmark string `json:"benchmark"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Method    string `json:"method"`
+	Events    []struct {
+		TickerSymbol string `json:"tickerSymbol"`
+		Date         string `json:"date"`
+		Label        string `json:"label"`
+	} `json:"events"`
+	PreDays        int `json:"preDays"`
+	PostDays       int `json:"postDays"`
+	EstimationDays int `json:"estimationDays"`
+}
+
+// RunEventStudy POST /event-study
+// source=custom なら events の (銘柄, 日付) を、それ以外なら from〜to のイベントを source から取り出し、
+// benchmark（topix|nikkei、既定 topix）に対する市場モデルの異常リターンを返す。
+func (h *EventStudyHandler) RunEventStudy(w http.ResponseWriter, r *http.Request) {
+	var req eventStudyRequest
+	if err := h.httpServer.ParseJSONBody(r, &req); err != nil {
+		http.Error(w, "リクエストボディが不正です", http.StatusBadRequest)
+		return
+	}
+	studyReq, err := parseEventStudyRequest(req)
+	if err != nil {
+		writeError(w, h.logger, "event study invalid request", err)
+		return
+	}
+
+	result, err := h.usecase.RunEventStudy(r.Context(), studyReq)
+	if err != nil {
+		writeError(w, h.logger, "failed to run event study", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
+
+func parseEventStudyRequest(req eventStudyRequest) (*models.EventStudyRequest, error) {
+	if !slices.Contains(models.EventStudySources, req.Source) {
+		return nil, &validationError{message: "source は " + strings.Join(models.EventStudySources, ", ") + " のいずれかを指定してください"}
+	}
+	benchmark := req.Benchmark
+	if benchmark == "" {
+		benchmark = models.EventStudyBenchmarkTopix
+	}
+	if benchmark != models.EventStudyBenchmarkTopix && benchmark != models.EventStudyBenchmarkNikkei {
+		return nil, &validationError{message: "benchmark は topix または nikkei を指定してください"}
+	}
+	if req.PreDays < 0 || req.PreDays > eventStudyMaxPreDays {
+		return nil, &validationError{message: "preDaysは0〜20である必要があります"}
+	}
+	if req.PostDays < 0 || req.PostDays > eventStudyMaxPostDays {
+		return nil, &validationError{message: "postDaysは0〜60である必要があります"}
+	}
+	if req.EstimationDays != 0 && (req.EstimationDays < eventStudyMinEstimation || req.EstimationDays > eventStudyMaxEstimation) {
+		return nil, &validationError{message: "estimationDaysは30〜250である必要があります"}
+	}
+
+	out := &models.EventStudyRequest{
+		Source:         req.Source,
+		Benchmark:      benchmark,
+		Method:         req.Method,
+		PreDays:        req.PreDays,
+		PostDays:       req.PostDays,
+		EstimationDays: req.EstimationDays,
+	}
+
+	if req.Source == models.EventStudySourceCustom {
+		if len(req.Events) == 0 || len(req.Events) > eventStudyMaxEvents {
+			return nil, &validationError{message: "source=custom の場合 events は 1〜2000 件で指定してください"}
+		}
+		for _, ev := range req.Events {
+			if len(ev.TickerSymbol) > 10 || !alphanumericRequiredRegex.MatchString(ev.TickerSymbol) {
+				return nil, &validationError{message: "events の tickerSymbol は英数字の銘柄コードで指定してください"}
+			}
+			date, err := time.ParseInLocation(util.DateLayout, ev.Date, time.Local)
+			if err != nil {
+				return nil, &validationError{message: "events の dateの日付形式が不正です (YYYY-MM-DD)"}
+			}
+			out.Events = append(out.Events, &models.EventStudyEvent{TickerSymbol: ev.TickerSymbol, Date: date, Label: ev.Label})
+		}
+		return out, nil
+	}
+
+	if req.From == "" || req.To == "" {
+		return nil, &validationError{message: "source=custom 以外の場合 from と to は必須です"}
+	}
+	from, err := time.ParseInLocation(util.DateLayout, req.From, time.Local)
+	if err != nil {
+		return nil, &validationError{message: "fromの日付形式が不正です (YYYY-MM-DD)"}
+	}
+	to, err := time.ParseInLocation(util.DateLayout, req.To, time.Local)
+	if err != nil {
+		return nil, &validationError{message: "toの日付形式が不正です (YYYY-MM-DD)"}
+	}
+	if from.After(to) {
+		return nil, &validationError{message: "fromはto以前の日付である必要があります"}
+	}
+	if to.Sub(from).Hours()/24 > eventStudyMaxRangeDays {
+		return nil, &validationError{message: "期間は最大1096日以内で指定してください"}
+	}
+	out.From = from
+	out.To = to
+	return out, nil
+}
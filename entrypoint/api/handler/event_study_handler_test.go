@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestEventStudyHandler_RunEventStudy(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		usecase        func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: custom のイベントを指定（benchmark 省略時は topix）",
+			body: `{"source":"custom","events":[{"tickerSymbol":"7203","date":"2024-05-10","label":"決算"}],"preDays":3,"postDays":10}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				m := mock_usecase.NewMockEventStudyInteractor(ctrl)
+				m.EXPECT().RunEventStudy(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, req *models.EventStudyRequest) (*models.EventStudyResult, error) {
+						assert.Equal(t, models.EventStudySourceCustom, req.Source)
+						assert.Equal(t, models.EventStudyBenchmarkTopix, req.Benchmark)
+						assert.Equal(t, 3, req.PreDays)
+						assert.Equal(t, 10, req.PostDays)
+						assert.Equal(t, "7203", req.Events[0].TickerSymbol)
+						assert.Equal(t, time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local), req.Events[0].Date)
+						assert.Equal(t, "決算", req.Events[0].Label)
+						return &models.EventStudyResult{Benchmark: req.Benchmark, EventCount: 1}, nil
+					})
+				return m
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "正常系: 決算発表を日経平均に対して評価",
+			body: `{"source":"fin_announcement","benchmark":"nikkei","from":"2024-01-01","to":"2024-06-30"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				m := mock_usecase.NewMockEventStudyInteractor(ctrl)
+				m.EXPECT().RunEventStudy(gomock.Any(), &models.EventStudyRequest{
+					Source:    models.EventStudySourceFinAnnouncement,
+					Benchmark: models.EventStudyBenchmarkNikkei,
+					From:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+					To:        time.Date(2024, 6, 30, 0, 0, 0, 0, time.Local),
+				}).Return(&models.EventStudyResult{}, nil)
+				return m
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: source が不正",
+			body: `{"source":"news"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				return mock_usecase.NewMockEventStudyInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "source は custom, fin_announcement, analyze_signal, daily_pick, stock_split のいずれかを指定してください\n",
+		},
+		{
+			name: "異常系: benchmark が不正",
+			body: `{"source":"custom","benchmark":"sp500","events":[{"tickerSymbol":"7203","date":"2024-05-10"}]}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				return mock_usecase.NewMockEventStudyInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "benchmark は topix または nikkei を指定してください\n",
+		},
+		{
+			name: "異常系: custom で events が空",
+			body: `{"source":"custom"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				return mock_usecase.NewMockEventStudyInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "source=custom の場合 events は 1〜2000 件で指定してください\n",
+		},
+		{
+			name: "異常系: events の日付形式が不正",
+			body: `{"source":"custom","events":[{"tickerSymbol":"7203","date":"2024/05/10"}]}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				return mock_usecase.NewMockEventStudyInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "events の dateの日付形式が不正です (YYYY-MM-DD)\n",
+		},
+		{
+			name: "異常系: custom 以外で from/to 未指定",
+			body: `{"source":"stock_split","from":"2024-01-01"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				return mock_usecase.NewMockEventStudyInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "source=custom 以外の場合 from と to は必須です\n",
+		},
+		{
+			name: "異常系: from が to より後",
+			body: `{"source":"daily_pick","from":"2024-06-30","to":"2024-01-01"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				return mock_usecase.NewMockEventStudyInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "fromはto以前の日付である必要があります\n",
+		},
+		{
+			name: "異常系: estimationDays が範囲外",
+			body: `{"source":"daily_pick","from":"2024-01-01","to":"2024-06-30","estimationDays":10}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				return mock_usecase.NewMockEventStudyInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "estimationDaysは30〜250である必要があります\n",
+		},
+		{
+			name: "異常系: 不正な JSON",
+			body: `{`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				return mock_usecase.NewMockEventStudyInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "リクエストボディが不正です\n",
+		},
+		{
+			name: "異常系: usecase エラー",
+			body: `{"source":"analyze_signal","from":"2024-01-01","to":"2024-06-30"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockEventStudyInteractor {
+				m := mock_usecase.NewMockEventStudyInteractor(ctrl)
+				m.EXPECT().RunEventStudy(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				return m
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := mock_driver.NewMockHTTPServer(ctrl)
+			server.EXPECT().ParseJSONBody(gomock.Any(), gomock.Any()).DoAndReturn(decodeJSONBody)
+			h := NewEventStudyHandler(tt.usecase(ctrl), server, zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.RunEventStudy(w, httptest.NewRequest(http.MethodPost, "/event-study", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+type MarketBreadthHandler struct {
+	usecase    usecase.MarketBreadthInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+}
+
+func NewMarketBreadthHandler(u usecase.MarketBreadthInteractor, h driver.HTTPServer, l *zap.Logger) *MarketBreadthHandler {
+	return &MarketBreadthHandler{
+		usecase:    u,
+		httpServer: h,
+		logger:     l,
+	}
+}
+
+// GetMarketBreadth GET /market-breadth?from=&to=&market=
+// market 省略時は all（主要市場全体）。from 省略時は to（省略時は現在）から1年前。
+func (h *MarketBreadthHandler) GetMarketBreadth(w http.ResponseWriter, r *http.Request) {
+	market := h.httpServer.GetQueryParam(r, "market")
+	if market == "" {
+		market = models.MarketBreadthMarketAll
+	}
+	if !slices.Contains(models.MarketBreadthMarkets, market) {
+		writeError(w, h.logger, "failed to validate market breadth params", &validationError{message: "marketはall, 111, 112, 113のいずれかである必要があります"})
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate market breadth params", err)
+		return
+	}
+
+	result, err := h.usecase.GetMarketBreadth(r.Context(), market, from, to)
+	if err != nil {
+		writeError(w, h.logger, "failed to get market breadth", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+func TestMarketBreadthHandler_GetMarketBreadth(t *testing.T) {
+	from, _ := time.ParseInLocation(util.DateLayout, "2024-01-01", time.Local)
+	to, _ := time.ParseInLocation(util.DateLayout, "2024-03-31", time.Local)
+
+	type fields struct {
+		usecase    func(ctrl *gomock.Controller) *mock_usecase.MockMarketBreadthInteractor
+		httpServer func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: 市場・期間指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockMarketBreadthInteractor {
+					m := mock_usecase.NewMockMarketBreadthInteractor(ctrl)
+					m.EXPECT().GetMarketBreadth(gomock.Any(), "111", &from, &to).Return(&models.MarketBreadthSeries{Market: "111"}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "market").Return("111")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/market-breadth?market=111&from=2024-01-01&to=2024-03-31", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "正常系: market省略時は主要市場全体",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockMarketBreadthInteractor {
+					m := mock_usecase.NewMockMarketBreadthInteractor(ctrl)
+					m.EXPECT().GetMarketBreadth(gomock.Any(), models.MarketBreadthMarketAll, nil, nil).Return(&models.MarketBreadthSeries{}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "market").Return("")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/market-breadth", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: 未対応の市場",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockMarketBreadthInteractor {
+					return mock_usecase.NewMockMarketBreadthInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "market").Return("999")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/market-breadth?market=999", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "marketはall, 111, 112, 113のいずれかである必要があります\n",
+		},
+		{
+			name: "異常系: fromがtoより後",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockMarketBreadthInteractor {
+					return mock_usecase.NewMockMarketBreadthInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "market").Return("")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/market-breadth?from=2024-04-01&to=2024-03-31", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "fromはto以前の日付である必要があります\n",
+		},
+		{
+			name: "異常系: usecaseエラー",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockMarketBreadthInteractor {
+					m := mock_usecase.NewMockMarketBreadthInteractor(ctrl)
+					m.EXPECT().GetMarketBreadth(gomock.Any(), models.MarketBreadthMarketAll, nil, nil).Return(nil, errors.New("db error"))
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "market").Return("")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/market-breadth", nil),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewMarketBreadthHandler(tt.fields.usecase(ctrl), tt.fields.httpServer(ctrl), zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetMarketBreadth(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+type MarketRegimeHandler struct {
+	usecase    usecase.MarketRegimeInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+}
+
+func NewMarketRegimeHandler(u usecase.MarketRegimeInteractor, h driver.HTTPServer, l *zap.Logger) *MarketRegimeHandler {
+	return &MarketRegimeHandler{
+		usecase:    u,
+		httpServer: h,
+		logger:     l,
+	}
+}
+
+// GetMarketRegimes GET /market-regime?from=&to=
+// from 省略時は to（省略時は現在）から1年前。
+func (h *MarketRegimeHandler) GetMarketRegimes(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate market regime params", err)
+		return
+	}
+
+	result, err := h.usecase.GetMarketRegimes(r.Context(), from, to)
+	if err != nil {
+		writeError(w, h.logger, "failed to get market regimes", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+func TestMarketRegimeHandler_GetMarketRegimes(t *testing.T) {
+	from, _ := time.ParseInLocation(util.DateLayout, "2024-01-01", time.Local)
+	to, _ := time.ParseInLocation(util.DateLayout, "2024-03-31", time.Local)
+
+	type fields struct {
+		usecase    func(ctrl *gomock.Controller) *mock_usecase.MockMarketRegimeInteractor
+		httpServer func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: 期間指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockMarketRegimeInteractor {
+					m := mock_usecase.NewMockMarketRegimeInteractor(ctrl)
+					m.EXPECT().GetMarketRegimes(gomock.Any(), &from, &to).Return(&models.MarketRegimeSeries{From: from, To: to}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return mock_driver.NewMockHTTPServer(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/market-regime?from=2024-01-01&to=2024-03-31", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "正常系: 期間省略",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockMarketRegimeInteractor {
+					m := mock_usecase.NewMockMarketRegimeInteractor(ctrl)
+					m.EXPECT().GetMarketRegimes(gomock.Any(), nil, nil).Return(&models.MarketRegimeSeries{}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return mock_driver.NewMockHTTPServer(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/market-regime", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: fromがtoより後",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockMarketRegimeInteractor {
+					return mock_usecase.NewMockMarketRegimeInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return mock_driver.NewMockHTTPServer(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/market-regime?from=2024-04-01&to=2024-03-31", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "fromはto以前の日付である必要があります\n",
+		},
+		{
+			name: "異常系: usecaseエラー",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockMarketRegimeInteractor {
+					m := mock_usecase.NewMockMarketRegimeInteractor(ctrl)
+					m.EXPECT().GetMarketRegimes(gomock.Any(), nil, nil).Return(nil, errors.New("db error"))
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return mock_driver.NewMockHTTPServer(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/market-regime", nil),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewMarketRegimeHandler(tt.fields.usecase(ctrl), tt.fields.httpServer(ctrl), zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetMarketRegimes(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -14,6 +14,11 @@ import (
 const (
 	defaultMultipleSignalStocksLimit = 100
 	maxMultipleSignalStocksLimit     = 500
+
+	// multipleSignalStocksModeCount 同一日に点灯した手法数で数える（既定）
+	multipleSignalStocksModeCount = "count"
+	// multipleSignalStocksModeWeighted 手法の的中率と経過日数で重み付けした合流スコアで並べる
+	multipleSignalStocksModeWeighted = "weighted"
 )
 
 type GetMultipleSignalStocksResponse struct {
@@ -22,20 +27,23 @@ type GetMultipleSignalStocksResponse struct {
 }
 
 type MultipleSignalStocksHandler struct {
-	usecase    usecase.StockBrandInteractor
-	httpServer driver.HTTPServer
-	logger     *zap.Logger
+	usecase           usecase.StockBrandInteractor
+	confluenceUsecase usecase.SignalConfluenceInteractor
+	httpServer        driver.HTTPServer
+	logger            *zap.Logger
 }
 
-func NewMultipleSignalStocksHandler(u usecase.StockBrandInteractor, h driver.HTTPServer, l *zap.Logger) *MultipleSignalStocksHandler {
+func NewMultipleSignalStocksHandler(u usecase.StockBrandInteractor, cu usecase.SignalConfluenceInteractor, h driver.HTTPServer, l *zap.Logger) *MultipleSignalStocksHandler {
 	return &MultipleSignalStocksHandler{
-		usecase:    u,
-		httpServer: h,
-		logger:     l,
+		usecase:           u,
+		confluenceUsecase: cu,
+		httpServer:        h,
+		logger:            l,
 	}
 }
 
 type getMultipleSignalStocksParams struct {
+	mode   string
 	date   *time.Time
 	cursor string
 	limit  int
@@ -43,9 +51,17 @@ type getMultipleSignalStocksParams struct {
 
 func (h *MultipleSignalStocksHandler) validateGetMultipleSignalStocksParams(r *http.Request) (*getMultipleSignalStocksParams, error) {
 	params := &getMultipleSignalStocksParams{
+		mode:  multipleSignalStocksModeCount,
 		limit: defaultMultipleSignalStocksLimit,
 	}
 
+	if mode := h.httpServer.GetQueryParam(r, "mode"); mode != "" {
+		if mode != multipleSignalStocksModeCount && mode != multipleSignalStocksModeWeighted {
+			return nil, &validationError{message: "modeはcountまたはweightedを指定してください"}
+		}
+		params.mode = mode
+	}
+
 	dateStr := h.httpServer.GetQueryParam(r, "date")
 	if dateStr != "" {
 		t, err := time.Parse("2006-01-02", dateStr)
@@ -59,6 +75,9 @@ func (h *MultipleSignalStocksHandler) validateGetMultipleSignalStocksParams(r *h
 	if len(params.cursor) > 20 {
 		return nil, &validationError{message: "cursorが長すぎます"}
 	}
+	if params.cursor != "" && params.mode == multipleSignalStocksModeWeighted {
+		return nil, &validationError{message: "mode=weightedではcursorを指定できません"}
+	}
 
 	limitStr := h.httpServer.GetQueryParam(r, "limit")
 	if limitStr != "" {
@@ -85,6 +104,11 @@ func (h *MultipleSignalStocksHandler) GetMultipleSignalStocks(w http.ResponseWri
 		return
 	}
 
+	if params.mode == multipleSignalStocksModeWeighted {
+		h.getWeightedSignalStocks(w, r, params)
+		return
+	}
+
 	result, err := h.usecase.GetMultipleSignalStocks(r.Context(), &models.MultipleSignalStockFilter{
 		Date:   params.date,
 		Cursor: params.cursor,
@@ -103,3 +127,17 @@ func (h *MultipleSignalStocksHandler) GetMultipleSignalStocks(w http.ResponseWri
 		},
 	})
 }
+
+// getWeightedSignalStocks mode=weighted: 合流スコアの降順に上位 limit 件を返す（ページングなし）
+func (h *MultipleSignalStocksHandler) getWeightedSignalStocks(w http.ResponseWriter, r *http.Request, params *getMultipleSignalStocksParams) {
+	result, err := h.confluenceUsecase.GetWeightedSignalStocks(r.Context(), &models.WeightedSignalStockFilter{
+		Date:  params.date,
+		Limit: params.limit,
+	})
+	if err != nil {
+		writeError(w, h.logger, "failed to get weighted signal stocks", err)
+		return
+	}
+
+	respondJSON(w, h.logger, result)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestMultipleSignalStocksHandler_GetMultipleSignalStocks(t *testing.T) {
+	date := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	type fields struct {
+		usecase           func(ctrl *gomock.Controller) *mock_usecase.MockStockBrandInteractor
+		confluenceUsecase func(ctrl *gomock.Controller) *mock_usecase.MockSignalConfluenceInteractor
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: mode省略時は手法数で数える",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStockBrandInteractor {
+					m := mock_usecase.NewMockStockBrandInteractor(ctrl)
+					m.EXPECT().GetMultipleSignalStocks(gomock.Any(), &models.MultipleSignalStockFilter{
+						Date:   &date,
+						Cursor: "7203",
+						Limit:  10,
+					}).Return(&models.PaginatedMultipleSignalStocks{Limit: 10}, nil)
+					return m
+				},
+				confluenceUsecase: mock_usecase.NewMockSignalConfluenceInteractor,
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/multiple-signal-stocks?date=2026-07-10&cursor=7203&limit=10", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "正常系: mode=weighted は合流スコアを返す",
+			fields: fields{
+				usecase: mock_usecase.NewMockStockBrandInteractor,
+				confluenceUsecase: func(ctrl *gomock.Controller) *mock_usecase.MockSignalConfluenceInteractor {
+					m := mock_usecase.NewMockSignalConfluenceInteractor(ctrl)
+					m.EXPECT().GetWeightedSignalStocks(gomock.Any(), &models.WeightedSignalStockFilter{
+						Date:  &date,
+						Limit: 20,
+					}).Return(&models.WeightedSignalStocks{Date: date, Stocks: []*models.WeightedSignalStock{{TickerSymbol: "7203"}}}, nil)
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/multiple-signal-stocks?mode=weighted&date=2026-07-10&limit=20", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: modeが不正",
+			fields: fields{
+				usecase:           mock_usecase.NewMockStockBrandInteractor,
+				confluenceUsecase: mock_usecase.NewMockSignalConfluenceInteractor,
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/multiple-signal-stocks?mode=rank", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "modeはcountまたはweightedを指定してください\n",
+		},
+		{
+			name: "異常系: mode=weightedにcursor",
+			fields: fields{
+				usecase:           mock_usecase.NewMockStockBrandInteractor,
+				confluenceUsecase: mock_usecase.NewMockSignalConfluenceInteractor,
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/multiple-signal-stocks?mode=weighted&cursor=7203", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "mode=weightedではcursorを指定できません\n",
+		},
+		{
+			name: "異常系: mode=weightedのusecaseエラー",
+			fields: fields{
+				usecase: mock_usecase.NewMockStockBrandInteractor,
+				confluenceUsecase: func(ctrl *gomock.Controller) *mock_usecase.MockSignalConfluenceInteractor {
+					m := mock_usecase.NewMockSignalConfluenceInteractor(ctrl)
+					m.EXPECT().GetWeightedSignalStocks(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/multiple-signal-stocks?mode=weighted", nil),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			httpServer := mock_driver.NewMockHTTPServer(ctrl)
+			httpServer.EXPECT().GetQueryParam(gomock.Any(), gomock.Any()).DoAndReturn(func(r *http.Request, key string) string {
+				return r.URL.Query().Get(key)
+			}).AnyTimes()
+
+			h := NewMultipleSignalStocksHandler(tt.fields.usecase(ctrl), tt.fields.confluenceUsecase(ctrl), httpServer, zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetMultipleSignalStocks(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+type PaperPortfolioHandler struct {
+	usecase    usecase.PaperPortfolioInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+}
+
+func NewPaperPortfolioHandler(u usecase.PaperPortfolioInteractor, s driver.HTTPServer, l *zap.Logger) *PaperPortfolioHandler {
+	return &PaperPortfolioHandler{usecase: u, httpServer: s, logger: l}
+}
+
+// GetPaperPortfolio GET /paper-portfolio?account=&from=&to=
+// account 省略時は既定の模擬口座。from 省略時は to（省略時は現在）の3か月前から。口座が無ければ 404。
+func (h *PaperPortfolioHandler) GetPaperPortfolio(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		writeError(w, h.logger, "paper portfolio invalid date range", err)
+		return
+	}
+
+	account := h.httpServer.GetQueryParam(r, "account")
+
+	portfolio, err := h.usecase.GetPaperPortfolio(r.Context(), account, from, to)
+	if err != nil {
+		if errors.Is(err, usecase.ErrPaperAccountNotFound) {
+			http.Error(w, "指定された模擬口座が見つかりません", http.StatusNotFound)
+			return
+		}
+		writeError(w, h.logger, "paper portfolio get failed", err)
+		return
+	}
+	respondJSON(w, h.logger, portfolio)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+func TestPaperPortfolioHandler_GetPaperPortfolio(t *testing.T) {
+	from, _ := time.ParseInLocation(util.DateLayout, "2026-04-01", time.Local)
+	to, _ := time.ParseInLocation(util.DateLayout, "2026-06-30", time.Local)
+
+	type fields struct {
+		usecase    func(ctrl *gomock.Controller) *mock_usecase.MockPaperPortfolioInteractor
+		httpServer func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: 口座と期間指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPaperPortfolioInteractor {
+					m := mock_usecase.NewMockPaperPortfolioInteractor(ctrl)
+					m.EXPECT().GetPaperPortfolio(gomock.Any(), "tp10", &from, &to).Return(&models.PaperPortfolio{}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "account").Return("tp10")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/paper-portfolio?account=tp10&from=2026-04-01&to=2026-06-30", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: 口座が無い",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPaperPortfolioInteractor {
+					m := mock_usecase.NewMockPaperPortfolioInteractor(ctrl)
+					m.EXPECT().GetPaperPortfolio(gomock.Any(), "", nil, nil).Return(nil, usecase.ErrPaperAccountNotFound)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "account").Return("")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/paper-portfolio", nil),
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "指定された模擬口座が見つかりません\n",
+		},
+		{
+			name: "異常系: fromがtoより後",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPaperPortfolioInteractor {
+					return mock_usecase.NewMockPaperPortfolioInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return mock_driver.NewMockHTTPServer(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/paper-portfolio?from=2026-07-01&to=2026-06-30", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "fromはto以前の日付である必要があります\n",
+		},
+		{
+			name: "異常系: usecaseエラー",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPaperPortfolioInteractor {
+					m := mock_usecase.NewMockPaperPortfolioInteractor(ctrl)
+					m.EXPECT().GetPaperPortfolio(gomock.Any(), "", nil, nil).Return(nil, errors.New("db error"))
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "account").Return("")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/paper-portfolio", nil),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewPaperPortfolioHandler(tt.fields.usecase(ctrl), tt.fields.httpServer(ctrl), zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetPaperPortfolio(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
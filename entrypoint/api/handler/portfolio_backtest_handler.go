@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+// ポートフォリオバックテストの既定値・上限
+var (
+	defaultPortfolioCapital      = decimal.NewFromInt(3_000_000)
+	defaultPortfolioMaxPositions = 5
+	maxPortfolioMaxPositions     = 50
+	maxPortfolioWatchlistSymbols = 200
+)
+
+type getPortfolioBacktestParams struct {
+	from   *time.Time
+	to     *time.Time
+	params models.PortfolioBacktestParams
+}
+
+// PortfolioBacktestHandler GET /backtest/portfolio のハンドラ。
+type PortfolioBacktestHandler struct {
+	usecase    usecase.PortfolioBacktestInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+}
+
+func NewPortfolioBacktestHandler(u usecase.PortfolioBacktestInteractor, h driver.HTTPServer, l *zap.Logger) *PortfolioBacktestHandler {
+	return &PortfolioBacktestHandler{
+		usecase:    u,
+		httpServer: h,
+		logger:     l,
+	}
+}
+
+// parsePortfolioUniverse universe / sector33Code / symbols クエリを解析する。universe 省略時は主要市場全銘柄。
+func parsePortfolioUniverse(s driver.HTTPServer, r *http.Request) (models.PortfolioUniverse, error) {
+	kind := s.GetQueryParam(r, "universe")
+	switch kind {
+	case "", models.PortfolioUniverseMainMarkets:
+		return models.PortfolioUniverse{Kind: models.PortfolioUniverseMainMarkets}, nil
+	case models.PortfolioUniverseSector:
+		code := s.GetQueryParam(r, "sector33Code")
+		if code == "" || !alphanumericRequiredRegex.MatchString(code) {
+			return models.PortfolioUniverse{}, &validationError{message: "universe=sectorの場合sector33Codeは必須です"}
+		}
+		return models.PortfolioUniverse{Kind: kind, Sector33Code: code}, nil
+	case models.PortfolioUniverseWatchlist:
+		raw := s.GetQueryParam(r, "symbols")
+		symbols := make([]string, 0)
+		for _, sym := range strings.Split(raw, ",") {
+			sym = strings.TrimSpace(sym)
+			if sym == "" {
+				continue
+			}
+			if len(sym) > 10 || !alphanumericRequiredRegex.MatchString(sym) {
+				return models.PortfolioUniverse{}, &validationError{message: "symbolsは英数字の銘柄コードをカンマ区切りで指定してください"}
+			}
+			symbols = append(symbols, sym)
+		}
+		if len(symbols) == 0 {
+			return models.PortfolioUniverse{}, &validationError{message: "universe=watchlistの場合symbolsは必須です"}
+		}
+		if len(symbols) > maxPortfolioWatchlistSymbols {
+			return models.PortfolioUniverse{}, &validationError{message: "symbolsは200銘柄以下で指定してください"}
+		}
+		return models.PortfolioUniverse{Kind: kind, Symbols: symbols}, nil
+	}
+	return models.PortfolioUniverse{}, &validationError{message: "universeはmain_markets, sector, watchlistのいずれかである必要があります"}
+}
+
+// parsePortfolioRankBy 優先順位ルールを解析する。空なら出来高倍率。
+func parsePortfolioRankBy(raw string) (string, bool) {
+	switch raw {
+	case "":
+		return models.PortfolioRankVolumeRatio, true
+	case models.PortfolioRankVolumeRatio, models.PortfolioRankTradingValue, models.PortfolioRankMomentum:
+		return raw, true
+	}
+	return "", false
+}
+
+func (h *PortfolioBacktestHandler) validateGetPortfolioBacktestParams(r *http.Request) (*getPortfolioBacktestParams, error) {
+	p := &getPortfolioBacktestParams{}
+
+	strategy := h.httpServer.GetQueryParam(r, "strategy")
+	if strategy == "" {
+		return nil, &validationError{message: "strategyは必須です"}
+	}
+	if !isValidStrategy(strategy) {
+		return nil, &validationError{message: "strategyが不正です"}
+	}
+	// ポートフォリオは現金ベースの買いのみを扱う
+	if domain_service.StrategySide(strategy) == models.PositionSideShort {
+		return nil, &validationError{message: "空売り戦略はポートフォリオバックテストに対応していません"}
+	}
+
+	universe, err := parsePortfolioUniverse(h.httpServer, r)
+	if err != nil {
+		return nil, err
+	}
+
+	capital := defaultPortfolioCapital
+	if raw := h.httpServer.GetQueryParam(r, "capital"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || v < 100_000 || v > 10_000_000_000 {
+			return nil, &validationError{message: "capitalは10万円以上100億円以下である必要があります"}
+		}
+		capital = decimal.NewFromInt(v)
+	}
+
+	maxPositions := defaultPortfolioMaxPositions
+	if raw := h.httpServer.GetQueryParam(r, "maxPositions"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v <= 0 || v > maxPortfolioMaxPositions {
+			return nil, &validationError{message: "maxPositionsは1〜50である必要があります"}
+		}
+		maxPositions = v
+	}
+
+	rankBy, ok := parsePortfolioRankBy(h.httpServer.GetQueryParam(r, "rankBy"))
+	if !ok {
+		return nil, &validationError{message: "rankByはvolume_ratio, trading_value, momentumのいずれかである必要があります"}
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		return nil, err
+	}
+	p.from = from
+	p.to = to
+
+	exit, err := parseBacktestParams(h.httpServer, r)
+	if err != nil {
+		return nil, err
+	}
+	if err := parseExecutionParams(h.httpServer, r, &exit); err != nil {
+		return nil, err
+	}
+
+	p.params = models.PortfolioBacktestParams{
+		Strategy:       strategy,
+		Universe:       universe,
+		InitialCapital: capital,
+		MaxPositions:   maxPositions,
+		RankBy:         rankBy,
+		Exit:           exit,
+	}
+	return p, nil
+}
+
+// GetPortfolioBacktest GET /backtest/portfolio?strategy=<id>&universe=<kind>&capital=<yen>&maxPositions=<n>
+func (h *PortfolioBacktestHandler) GetPortfolioBacktest(w http.ResponseWriter, r *http.Request) {
+	p, err := h.validateGetPortfolioBacktestParams(r)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate get portfolio backtest params", err)
+		return
+	}
+
+	result, err := h.usecase.RunPortfolioBacktest(r.Context(), p.params, p.from, p.to)
+	if err != nil {
+		writeError(w, h.logger, "failed to run portfolio backtest", err)
+		return
+	}
+
+	respondJSON(w, h.logger, result)
+}
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func expectDefaultExitQueryParams(m *mock_driver.MockHTTPServer) {
+	m.EXPECT().GetQueryParam(gomock.Any(), "takeProfit").Return("")
+	m.EXPECT().GetQueryParam(gomock.Any(), "stopLoss").Return("")
+	m.EXPECT().GetQueryParam(gomock.Any(), "maxHoldDays").Return("")
+	m.EXPECT().GetQueryParam(gomock.Any(), "commission").Return("")
+	m.EXPECT().GetQueryParam(gomock.Any(), "slippage").Return("")
+	m.EXPECT().GetQueryParam(gomock.Any(), "exitMode").Return("")
+}
+
+func TestPortfolioBacktestHandler_GetPortfolioBacktest(t *testing.T) {
+	defaultExit := models.BacktestParams{
+		TakeProfit:       decimal.NewFromFloat(0.10),
+		StopLoss:         decimal.NewFromFloat(0.05),
+		MaxHoldDays:      20,
+		CommissionRate:   decimal.Zero,
+		SlippageRate:     decimal.Zero,
+		ExitMode:         models.ExitModeCommon,
+		EntryTiming:      models.EntryTimingClose,
+		ExitFill:         models.ExitFillClose,
+		IntrabarPriority: models.IntrabarPriorityStopLoss,
+	}
+
+	type fields struct {
+		usecase    func(ctrl *gomock.Controller) *mock_usecase.MockPortfolioBacktestInteractor
+		httpServer func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: ウォッチリストで実行",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPortfolioBacktestInteractor {
+					m := mock_usecase.NewMockPortfolioBacktestInteractor(ctrl)
+					m.EXPECT().RunPortfolioBacktest(gomock.Any(), models.PortfolioBacktestParams{
+						Strategy:       "ma_cross",
+						Universe:       models.PortfolioUniverse{Kind: models.PortfolioUniverseWatchlist, Symbols: []string{"7203", "6758"}},
+						InitialCapital: decimal.NewFromInt(1_000_000),
+						MaxPositions:   2,
+						RankBy:         models.PortfolioRankMomentum,
+						Exit:           defaultExit,
+					}, nil, nil).Return(&models.PortfolioBacktestResult{Trades: 3}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("ma_cross")
+					m.EXPECT().GetQueryParam(gomock.Any(), "universe").Return("watchlist")
+					m.EXPECT().GetQueryParam(gomock.Any(), "symbols").Return("7203, 6758")
+					m.EXPECT().GetQueryParam(gomock.Any(), "capital").Return("1000000")
+					m.EXPECT().GetQueryParam(gomock.Any(), "maxPositions").Return("2")
+					m.EXPECT().GetQueryParam(gomock.Any(), "rankBy").Return("momentum")
+					expectDefaultExitQueryParams(m)
+					expectDefaultExecutionQueryParams(m)
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/backtest/portfolio", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "正常系: 約定モデルを単銘柄のバックテストと同じく受け付ける",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPortfolioBacktestInteractor {
+					exit := defaultExit
+					exit.EntryTiming = models.EntryTimingNextOpen
+					exit.ExitFill = models.ExitFillIntrabar
+					exit.IntrabarPriority = models.IntrabarPriorityTakeProfit
+					exit.TickRounding = true
+					m := mock_usecase.NewMockPortfolioBacktestInteractor(ctrl)
+					m.EXPECT().RunPortfolioBacktest(gomock.Any(), models.PortfolioBacktestParams{
+						Strategy:       "ma_cross",
+						Universe:       models.PortfolioUniverse{Kind: models.PortfolioUniverseMainMarkets},
+						InitialCapital: defaultPortfolioCapital,
+						MaxPositions:   defaultPortfolioMaxPositions,
+						RankBy:         models.PortfolioRankVolumeRatio,
+						Exit:           exit,
+					}, nil, nil).Return(&models.PortfolioBacktestResult{}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("ma_cross")
+					m.EXPECT().GetQueryParam(gomock.Any(), "universe").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "capital").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "maxPositions").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "rankBy").Return("")
+					expectDefaultExitQueryParams(m)
+					m.EXPECT().GetQueryParam(gomock.Any(), "entryTiming").Return("next_open")
+					m.EXPECT().GetQueryParam(gomock.Any(), "exitFill").Return("intrabar")
+					m.EXPECT().GetQueryParam(gomock.Any(), "intrabarPriority").Return("take_profit")
+					m.EXPECT().GetQueryParam(gomock.Any(), "tickRounding").Return("true")
+					m.EXPECT().GetQueryParam(gomock.Any(), "borrowRate").Return("")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/backtest/portfolio", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: exitFill不正",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPortfolioBacktestInteractor {
+					return mock_usecase.NewMockPortfolioBacktestInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("ma_cross")
+					m.EXPECT().GetQueryParam(gomock.Any(), "universe").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "capital").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "maxPositions").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "rankBy").Return("")
+					expectDefaultExitQueryParams(m)
+					m.EXPECT().GetQueryParam(gomock.Any(), "entryTiming").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "exitFill").Return("open")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/backtest/portfolio", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "exitFillはcloseまたはintrabarである必要があります\n",
+		},
+		{
+			name: "異常系: strategy不正",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPortfolioBacktestInteractor {
+					return mock_usecase.NewMockPortfolioBacktestInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("unknown")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/backtest/portfolio", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "strategyが不正です\n",
+		},
+		{
+			name: "異常系: 空売り戦略",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPortfolioBacktestInteractor {
+					return mock_usecase.NewMockPortfolioBacktestInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("macd_bearish")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/backtest/portfolio", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "空売り戦略はポートフォリオバックテストに対応していません\n",
+		},
+		{
+			name: "異常系: sector指定でsector33Code未指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPortfolioBacktestInteractor {
+					return mock_usecase.NewMockPortfolioBacktestInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("ma_cross")
+					m.EXPECT().GetQueryParam(gomock.Any(), "universe").Return("sector")
+					m.EXPECT().GetQueryParam(gomock.Any(), "sector33Code").Return("")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/backtest/portfolio", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "universe=sectorの場合sector33Codeは必須です\n",
+		},
+		{
+			name: "異常系: maxPositionsが範囲外",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPortfolioBacktestInteractor {
+					return mock_usecase.NewMockPortfolioBacktestInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("ma_cross")
+					m.EXPECT().GetQueryParam(gomock.Any(), "universe").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "capital").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "maxPositions").Return("51")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/backtest/portfolio", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "maxPositionsは1〜50である必要があります\n",
+		},
+		{
+			name: "異常系: usecaseエラー",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockPortfolioBacktestInteractor {
+					m := mock_usecase.NewMockPortfolioBacktestInteractor(ctrl)
+					m.EXPECT().RunPortfolioBacktest(gomock.Any(), gomock.Any(), nil, nil).Return(nil, errors.New("db error"))
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "strategy").Return("ma_cross")
+					m.EXPECT().GetQueryParam(gomock.Any(), "universe").Return("main_markets")
+					m.EXPECT().GetQueryParam(gomock.Any(), "capital").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "maxPositions").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "rankBy").Return("")
+					expectDefaultExitQueryParams(m)
+					expectDefaultExecutionQueryParams(m)
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/backtest/portfolio", nil),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewPortfolioBacktestHandler(tt.fields.usecase(ctrl), tt.fields.httpServer(ctrl), zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetPortfolioBacktest(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+type RelativeStrengthHandler struct {
+	usecase    usecase.RelativeStrengthInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+}
+
+func NewRelativeStrengthHandler(u usecase.RelativeStrengthInteractor, h driver.HTTPServer, l *zap.Logger) *RelativeStrengthHandler {
+	return &RelativeStrengthHandler{
+		usecase:    u,
+		httpServer: h,
+		logger:     l,
+	}
+}
+
+// GetRelativeStrength GET /relative-strength?date=YYYY-MM-DD&minRating=
+// date 省略時は最新の算出日。指定日に算出分が無ければそれ以前で最新の日を返す。
+func (h *RelativeStrengthHandler) GetRelativeStrength(w http.ResponseWriter, r *http.Request) {
+	date, err := h.httpServer.GetQueryParamDate(r, "date", util.DateLayout)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate relative strength params", &validationError{message: "dateの日付形式が不正です (YYYY-MM-DD)"})
+		return
+	}
+
+	minRating := 0
+	if raw := h.httpServer.GetQueryParam(r, "minRating"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > 99 {
+			writeError(w, h.logger, "failed to validate relative strength params", &validationError{message: "minRatingは1〜99である必要があります"})
+			return
+		}
+		minRating = v
+	}
+
+	result, err := h.usecase.GetRelativeStrength(r.Context(), date, minRating)
+	if err != nil {
+		writeError(w, h.logger, "failed to get relative strength", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+func TestRelativeStrengthHandler_GetRelativeStrength(t *testing.T) {
+	date, _ := time.ParseInLocation(util.DateLayout, "2024-03-29", time.Local)
+
+	type fields struct {
+		usecase    func(ctrl *gomock.Controller) *mock_usecase.MockRelativeStrengthInteractor
+		httpServer func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: date・minRating指定",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockRelativeStrengthInteractor {
+					m := mock_usecase.NewMockRelativeStrengthInteractor(ctrl)
+					m.EXPECT().GetRelativeStrength(gomock.Any(), &date, 80).Return(&models.RelativeStrengthRatings{Date: &date}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(&date, nil)
+					m.EXPECT().GetQueryParam(gomock.Any(), "minRating").Return("80")
+					return m
+				},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "正常系: 省略時は最新日・絞り込みなし",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockRelativeStrengthInteractor {
+					m := mock_usecase.NewMockRelativeStrengthInteractor(ctrl)
+					m.EXPECT().GetRelativeStrength(gomock.Any(), nil, 0).Return(&models.RelativeStrengthRatings{}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(nil, nil)
+					m.EXPECT().GetQueryParam(gomock.Any(), "minRating").Return("")
+					return m
+				},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: date形式不正",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockRelativeStrengthInteractor {
+					return mock_usecase.NewMockRelativeStrengthInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(nil, errors.New("parse error"))
+					return m
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "dateの日付形式が不正です (YYYY-MM-DD)\n",
+		},
+		{
+			name: "異常系: minRatingが範囲外",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockRelativeStrengthInteractor {
+					return mock_usecase.NewMockRelativeStrengthInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(nil, nil)
+					m.EXPECT().GetQueryParam(gomock.Any(), "minRating").Return("100")
+					return m
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "minRatingは1〜99である必要があります\n",
+		},
+		{
+			name: "異常系: usecaseエラー",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockRelativeStrengthInteractor {
+					m := mock_usecase.NewMockRelativeStrengthInteractor(ctrl)
+					m.EXPECT().GetRelativeStrength(gomock.Any(), nil, 0).Return(nil, errors.New("db error"))
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParamDate(gomock.Any(), "date", util.DateLayout).Return(nil, nil)
+					m.EXPECT().GetQueryParam(gomock.Any(), "minRating").Return("")
+					return m
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewRelativeStrengthHandler(tt.fields.usecase(ctrl), tt.fields.httpServer(ctrl), zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetRelativeStrength(w, httptest.NewRequest(http.MethodGet, "/relative-strength", nil))
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
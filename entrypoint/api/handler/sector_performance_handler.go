@@ -2,14 +2,26 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Code0716/stock-price-repository/driver"
 	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
 	"go.uber.org/zap"
 )
 
-// SectorPerformanceHandler GET /sector-performance のハンドラー
+const (
+	// sectorRotationDefaultWeeks / sectorRotationMaxWeeks RRG の軌跡（tail）の週数の既定値と上限。
+	sectorRotationDefaultWeeks = 8
+	sectorRotationMaxWeeks     = 52
+	// sectorRotationDefaultWindow / sectorRotationMinWindow / sectorRotationMaxWindow RS-Ratio・RS-Momentum の z スコアを取る週数。
+	sectorRotationDefaultWindow = 10
+	sectorRotationMinWindow     = 4
+	sectorRotationMaxWindow     = 52
+)
+
+// SectorPerformanceHandler GET /sector-performance, /sector-rotation のハンドラー
 type SectorPerformanceHandler struct {
 	usecase    usecase.SectorPerformanceInteractor
 	httpServer driver.HTTPServer
@@ -83,3 +95,68 @@ func (h *SectorPerformanceHandler) GetSectorPerformance(w http.ResponseWriter, r
 
 	respondJSON(w, h.logger, result)
 }
+
+type sectorRotationParams struct {
+	to     time.Time
+	level  string
+	weeks  int
+	window int
+}
+
+func (h *SectorPerformanceHandler) validateRotationParams(r *http.Request) (*sectorRotationParams, error) {
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if raw := r.URL.Query().Get("to"); raw != "" {
+		d, err := time.ParseInLocation(util.DateLayout, raw, time.Local)
+		if err != nil {
+			return nil, &validationError{message: "toの日付形式が不正です (YYYY-MM-DD)"}
+		}
+		to = d
+	}
+
+	level := r.URL.Query().Get("level")
+	if level == "" {
+		level = "33"
+	}
+	if level != "33" && level != "17" {
+		return nil, &validationError{message: "levelは\"33\"または\"17\"を指定してください"}
+	}
+
+	weeks := sectorRotationDefaultWeeks
+	if raw := r.URL.Query().Get("weeks"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > sectorRotationMaxWeeks {
+			return nil, &validationError{message: "weeksは1〜52である必要があります"}
+		}
+		weeks = v
+	}
+
+	window := sectorRotationDefaultWindow
+	if raw := r.URL.Query().Get("window"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < sectorRotationMinWindow || v > sectorRotationMaxWindow {
+			return nil, &validationError{message: "windowは4〜52である必要があります"}
+		}
+		window = v
+	}
+
+	return &sectorRotationParams{to: to, level: level, weeks: weeks, window: window}, nil
+}
+
+// GetSectorRotation GET /sector-rotation?level=33|17&weeks=&window=&to=
+// 業種別の RRG（TOPIX 比の RS-Ratio / RS-Momentum）の直近 weeks 週の軌跡と象限を返す。
+func (h *SectorPerformanceHandler) GetSectorRotation(w http.ResponseWriter, r *http.Request) {
+	params, err := h.validateRotationParams(r)
+	if err != nil {
+		writeError(w, h.logger, "failed to validate sector rotation params", err)
+		return
+	}
+
+	result, err := h.usecase.GetSectorRotation(r.Context(), params.to, params.level, params.weeks, params.window)
+	if err != nil {
+		writeError(w, h.logger, "failed to get sector rotation", err)
+		return
+	}
+
+	respondJSON(w, h.logger, result)
+}
@@ -266,3 +266,116 @@ func TestSectorPerformanceHandler_GetSectorPerformance(t *testing.T) {
 		})
 	}
 }
+
+func TestSectorPerformanceHandler_GetSectorRotation(t *testing.T) {
+	fixedTo, _ := time.ParseInLocation(util.DateLayout, "2024-03-29", time.Local)
+
+	type fields struct {
+		usecase func(ctrl *gomock.Controller) *mock_usecase.MockSectorPerformanceInteractor
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: level/weeks/window/to 指定 → usecase に渡る",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSectorPerformanceInteractor {
+					m := mock_usecase.NewMockSectorPerformanceInteractor(ctrl)
+					m.EXPECT().GetSectorRotation(gomock.Any(), fixedTo, "17", 12, 14).Return(&models.SectorRotation{Level: "17"}, nil)
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/sector-rotation?level=17&weeks=12&window=14&to=2024-03-29", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "正常系: 省略時は level=33・8週・window=10",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSectorPerformanceInteractor {
+					m := mock_usecase.NewMockSectorPerformanceInteractor(ctrl)
+					m.EXPECT().GetSectorRotation(gomock.Any(), gomock.Any(), "33", sectorRotationDefaultWeeks, sectorRotationDefaultWindow).Return(&models.SectorRotation{}, nil)
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/sector-rotation", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: 不正な level",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSectorPerformanceInteractor {
+					return mock_usecase.NewMockSectorPerformanceInteractor(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/sector-rotation?level=5", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "levelは\"33\"または\"17\"を指定してください\n",
+		},
+		{
+			name: "異常系: weeks が範囲外",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSectorPerformanceInteractor {
+					return mock_usecase.NewMockSectorPerformanceInteractor(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/sector-rotation?weeks=53", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "weeksは1〜52である必要があります\n",
+		},
+		{
+			name: "異常系: window が範囲外",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSectorPerformanceInteractor {
+					return mock_usecase.NewMockSectorPerformanceInteractor(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/sector-rotation?window=3", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "windowは4〜52である必要があります\n",
+		},
+		{
+			name: "異常系: to の形式不正",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSectorPerformanceInteractor {
+					return mock_usecase.NewMockSectorPerformanceInteractor(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/sector-rotation?to=2024/03/29", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "toの日付形式が不正です (YYYY-MM-DD)\n",
+		},
+		{
+			name: "異常系: usecase エラー → 500",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSectorPerformanceInteractor {
+					m := mock_usecase.NewMockSectorPerformanceInteractor(ctrl)
+					m.EXPECT().GetSectorRotation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/sector-rotation", nil),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewSectorPerformanceHandler(tt.fields.usecase(ctrl), mock_driver.NewMockHTTPServer(ctrl), zap.NewNop())
+			w := httptest.NewRecorder()
+			h.GetSectorRotation(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/config"
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+type SignalIngestHandler struct {
+	usecase    usecase.SignalIngestInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+	token      string
+}
+
+func NewSignalIngestHandler(u usecase.SignalIngestInteractor, h driver.HTTPServer, l *zap.Logger) *SignalIngestHandler {
+	return &SignalIngestHandler{
+		usecase:    u,
+		httpServer: h,
+		logger:     l,
+		token:      config.GetSignalIngest().SignalIngestToken,
+	}
+}
+
+type signalIngestRequest struct {
+	Histories []struct {
+		TickerSymbol string           `json:"tickerSymbol"`
+		Date         string           `json:"date"`
+		Method       string           `json:"method"`
+		Action       string           `json:"action"`
+		TradePrice   decimal.Decimal  `json:"tradePrice"`
+		Memo         *string          `json:"memo"`
+		Score        *decimal.Decimal `json:"score"`
+		SignalRank   *int             `json:"signalRank"`
+	} `json:"histories"`
+}
+
+// IngestSignals POST /analyze-stock-brand-price-histories
+// Authorization: Bearer <SIGNAL_INGEST_TOKEN> が必要。histories を (method, tickerSymbol, date, action) 単位で upsert する。
+func (h *SignalIngestHandler) IngestSignals(w http.ResponseWriter, r *http.Request) {
+	if !util.ValidBearerToken(r.Header.Get("Authorization"), h.token) {
+		http.Error(w, "認証に失敗しました", http.StatusUnauthorized)
+		return
+	}
+
+	var req signalIngestRequest
+	if err := h.httpServer.ParseJSONBody(r, &req); err != nil {
+		http.Error(w, "リクエストボディが不正です", http.StatusBadRequest)
+		return
+	}
+	inputs, err := parseSignalIngestRequest(req)
+	if err != nil {
+		writeError(w, h.logger, "signal ingest invalid request", err)
+		return
+	}
+
+	result, err := h.usecase.IngestSignals(r.Context(), inputs)
+	if err != nil {
+		var ierr *models.SignalIngestError
+		if errors.As(err, &ierr) {
+			http.Error(w, ierr.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, h.logger, "failed to ingest signals", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
+
+func parseSignalIngestRequest(req signalIngestRequest) ([]*models.SignalIngestInput, error) {
+	inputs := make([]*models.SignalIngestInput, 0, len(req.Histories))
+	for i, hist := range req.Histories {
+		date, err := time.ParseInLocation(util.DateLayout, hist.Date, time.Local)
+		if err != nil {
+			return nil, &validationError{message: fmt.Sprintf("histories[%d]: dateの日付形式が不正です (YYYY-MM-DD)", i)}
+		}
+		inputs = append(inputs, &models.SignalIngestInput{
+			TickerSymbol: hist.TickerSymbol,
+			Date:         date,
+			Method:       hist.Method,
+			Action:       hist.Action,
+			TradePrice:   hist.TradePrice,
+			Memo:         hist.Memo,
+			Score:        hist.Score,
+			SignalRank:   hist.SignalRank,
+		})
+	}
+	return inputs, nil
+}
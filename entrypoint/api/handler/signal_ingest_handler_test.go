@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestSignalIngestHandler_IngestSignals(t *testing.T) {
+	const token = "secret"
+	validBody := `{"histories":[{"tickerSymbol":"7203","date":"2024-05-10","method":"find_ml_ranked_stocks_v1","action":"Buy","tradePrice":"2500.5","score":"0.87","signalRank":1}]}`
+
+	tests := []struct {
+		name           string
+		authorization  string
+		body           string
+		usecase        func(ctrl *gomock.Controller) *mock_usecase.MockSignalIngestInteractor
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:          "正常系: シグナルを取り込む",
+			authorization: "Bearer " + token,
+			body:          validBody,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSignalIngestInteractor {
+				m := mock_usecase.NewMockSignalIngestInteractor(ctrl)
+				m.EXPECT().IngestSignals(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, inputs []*models.SignalIngestInput) (*models.SignalIngestResult, error) {
+						assert.Len(t, inputs, 1)
+						assert.Equal(t, "7203", inputs[0].TickerSymbol)
+						assert.Equal(t, time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local), inputs[0].Date)
+						assert.Equal(t, models.AnalyzeStockBrandPriceHistoryMethodFindMLRankedV1, inputs[0].Method)
+						assert.Equal(t, "Buy", inputs[0].Action)
+						assert.True(t, decimal.RequireFromString("2500.5").Equal(inputs[0].TradePrice))
+						assert.True(t, decimal.RequireFromString("0.87").Equal(*inputs[0].Score))
+						assert.Equal(t, 1, *inputs[0].SignalRank)
+						assert.Nil(t, inputs[0].Memo)
+						return &models.SignalIngestResult{Received: 1, Inserted: 1}, nil
+					})
+				return m
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"received":1,"inserted":1,"updated":0}` + "\n",
+		},
+		{
+			name:           "異常系: トークンなし",
+			body:           validBody,
+			usecase:        mock_usecase.NewMockSignalIngestInteractor,
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       "認証に失敗しました\n",
+		},
+		{
+			name:           "異常系: トークン不一致",
+			authorization:  "Bearer wrong",
+			body:           validBody,
+			usecase:        mock_usecase.NewMockSignalIngestInteractor,
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       "認証に失敗しました\n",
+		},
+		{
+			name:           "異常系: 不正な JSON",
+			authorization:  "Bearer " + token,
+			body:           `{`,
+			usecase:        mock_usecase.NewMockSignalIngestInteractor,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "リクエストボディが不正です\n",
+		},
+		{
+			name:           "異常系: 日付形式が不正",
+			authorization:  "Bearer " + token,
+			body:           `{"histories":[{"tickerSymbol":"7203","date":"2024/05/10","method":"find_ml_ranked_stocks_v1","action":"Buy","tradePrice":"2500"}]}`,
+			usecase:        mock_usecase.NewMockSignalIngestInteractor,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "histories[0]: dateの日付形式が不正です (YYYY-MM-DD)\n",
+		},
+		{
+			name:          "異常系: 内容の誤りは400",
+			authorization: "Bearer " + token,
+			body:          validBody,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSignalIngestInteractor {
+				m := mock_usecase.NewMockSignalIngestInteractor(ctrl)
+				m.EXPECT().IngestSignals(gomock.Any(), gomock.Any()).
+					Return(nil, &models.SignalIngestError{Index: 0, Message: "銘柄コード 7203 は登録されていません"})
+				return m
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "histories[0]: 銘柄コード 7203 は登録されていません\n",
+		},
+		{
+			name:          "異常系: usecase エラー",
+			authorization: "Bearer " + token,
+			body:          validBody,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSignalIngestInteractor {
+				m := mock_usecase.NewMockSignalIngestInteractor(ctrl)
+				m.EXPECT().IngestSignals(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				return m
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := mock_driver.NewMockHTTPServer(ctrl)
+			server.EXPECT().ParseJSONBody(gomock.Any(), gomock.Any()).DoAndReturn(decodeJSONBody).AnyTimes()
+			h := &SignalIngestHandler{usecase: tt.usecase(ctrl), httpServer: server, logger: zap.NewNop(), token: token}
+
+			req := httptest.NewRequest(http.MethodPost, "/analyze-stock-brand-price-histories", strings.NewReader(tt.body))
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			h.IngestSignals(w, req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Code0716/stock-price-repository/driver"
@@ -50,15 +51,34 @@ func (h *SignalPerformanceHandler) validateParams(r *http.Request) (*models.Sign
 		return nil, &validationError{message: "期間は最大366日以内で指定してください"}
 	}
 
+	benchmark := h.httpServer.GetQueryParam(r, "benchmark")
+	if benchmark == "" {
+		benchmark = models.EventStudyBenchmarkTopix
+	}
+	if benchmark != models.EventStudyBenchmarkTopix && benchmark != models.EventStudyBenchmarkNikkei {
+		return nil, &validationError{message: "benchmark は topix または nikkei を指定してください"}
+	}
+
+	var seed int64
+	if raw := h.httpServer.GetQueryParam(r, "seed"); raw != "" {
+		seed, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, &validationError{message: "seedは整数である必要があります"}
+		}
+	}
+
 	return &models.SignalPerformanceFilter{
-		From:   from,
-		To:     to,
-		Method: h.httpServer.GetQueryParam(r, "method"),
-		Action: h.httpServer.GetQueryParam(r, "action"),
+		From:      from,
+		To:        to,
+		Method:    h.httpServer.GetQueryParam(r, "method"),
+		Action:    h.httpServer.GetQueryParam(r, "action"),
+		Benchmark: benchmark,
+		Seed:      seed,
 	}, nil
 }
 
-// GetSignalPerformance GET /signal-performance
+// GetSignalPerformance GET /signal-performance?from=&to=&method=&action=&benchmark=topix|nikkei&seed=
+// benchmark は超過リターンの比較対象（既定 topix）。seed はブートストラップ・ランダムエントリーの乱数シード（省略時は毎回変わる）。
 func (h *SignalPerformanceHandler) GetSignalPerformance(w http.ResponseWriter, r *http.Request) {
 	filter, err := h.validateParams(r)
 	if err != nil {
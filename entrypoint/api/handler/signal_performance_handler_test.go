@@ -50,13 +50,16 @@ func TestSignalPerformanceHandler_GetSignalPerformance(t *testing.T) {
 					m := mock_usecase.NewMockSignalPerformanceInteractor(ctrl)
 					from := fixedDate.AddDate(0, 0, -90)
 					m.EXPECT().GetSignalPerformance(gomock.Any(), gomock.Eq(&models.SignalPerformanceFilter{
-						From: from,
-						To:   fixedDate,
+						From:      from,
+						To:        fixedDate,
+						Benchmark: models.EventStudyBenchmarkTopix,
 					})).Return(okResult, nil)
 					return m
 				},
 				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
 					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "benchmark").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "seed").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "method").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "action").Return("")
 					return m
@@ -94,6 +97,8 @@ func TestSignalPerformanceHandler_GetSignalPerformance(t *testing.T) {
 				},
 				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
 					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "benchmark").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "seed").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "method").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "action").Return("")
 					return m
@@ -109,9 +114,10 @@ func TestSignalPerformanceHandler_GetSignalPerformance(t *testing.T) {
 					m := mock_usecase.NewMockSignalPerformanceInteractor(ctrl)
 					from := fixedDate.AddDate(0, 0, -90)
 					m.EXPECT().GetSignalPerformance(gomock.Any(), gomock.Eq(&models.SignalPerformanceFilter{
-						From:   from,
-						To:     fixedDate,
-						Method: "find_macd_bullish_stock_v1",
+						From:      from,
+						To:        fixedDate,
+						Method:    "find_macd_bullish_stock_v1",
+						Benchmark: models.EventStudyBenchmarkTopix,
 					})).Return(&models.SignalPerformance{
 						From:     from,
 						To:       fixedDate,
@@ -133,6 +139,8 @@ func TestSignalPerformanceHandler_GetSignalPerformance(t *testing.T) {
 				},
 				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
 					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "benchmark").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "seed").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "method").Return("find_macd_bullish_stock_v1")
 					m.EXPECT().GetQueryParam(gomock.Any(), "action").Return("")
 					return m
@@ -141,6 +149,65 @@ func TestSignalPerformanceHandler_GetSignalPerformance(t *testing.T) {
 			req:            httptest.NewRequest(http.MethodGet, "/signal-performance?to=2024-03-31&method=find_macd_bullish_stock_v1", nil),
 			wantStatusCode: http.StatusOK,
 		},
+		{
+			name: "正常系: benchmark / seed 指定 → usecase に渡る",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSignalPerformanceInteractor {
+					m := mock_usecase.NewMockSignalPerformanceInteractor(ctrl)
+					m.EXPECT().GetSignalPerformance(gomock.Any(), gomock.Eq(&models.SignalPerformanceFilter{
+						From:      fixedDate.AddDate(0, 0, -90),
+						To:        fixedDate,
+						Benchmark: models.EventStudyBenchmarkNikkei,
+						Seed:      42,
+					})).Return(okResult, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "benchmark").Return("nikkei")
+					m.EXPECT().GetQueryParam(gomock.Any(), "seed").Return("42")
+					m.EXPECT().GetQueryParam(gomock.Any(), "method").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "action").Return("")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/signal-performance?to=2024-03-31&benchmark=nikkei&seed=42", nil),
+			wantStatusCode: http.StatusOK,
+			wantBody:       okResult,
+		},
+		{
+			name: "異常系: benchmark が不正 → 400",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSignalPerformanceInteractor {
+					return mock_usecase.NewMockSignalPerformanceInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "benchmark").Return("sp500")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/signal-performance?benchmark=sp500", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "benchmark は topix または nikkei を指定してください\n",
+		},
+		{
+			name: "異常系: seed が整数でない → 400",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockSignalPerformanceInteractor {
+					return mock_usecase.NewMockSignalPerformanceInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "benchmark").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "seed").Return("abc")
+					return m
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/signal-performance?seed=abc", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "seedは整数である必要があります\n",
+		},
 		{
 			name: "異常系: from > to → 400",
 			fields: fields{
@@ -209,6 +276,8 @@ func TestSignalPerformanceHandler_GetSignalPerformance(t *testing.T) {
 				},
 				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
 					m := mock_driver.NewMockHTTPServer(ctrl)
+					m.EXPECT().GetQueryParam(gomock.Any(), "benchmark").Return("")
+					m.EXPECT().GetQueryParam(gomock.Any(), "seed").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "method").Return("")
 					m.EXPECT().GetQueryParam(gomock.Any(), "action").Return("")
 					return m
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+const (
+	strategyOptimizationDefaultSamples           = 20
+	strategyOptimizationMaxSamples               = 200
+	strategyOptimizationDefaultYears             = 5
+	strategyOptimizationMaxYears                 = 20
+	strategyOptimizationDefaultInSampleMonths    = 24
+	strategyOptimizationDefaultOutOfSampleMonths = 6
+	strategyOptimizationMaxCostRate              = 0.05
+)
+
+// StrategyOptimizationHandler POST /strategy-optimizations および GET /strategy-optimizations/result のハンドラ。
+type StrategyOptimizationHandler struct {
+	usecase    usecase.StrategyOptimizationInteractor
+	httpServer driver.HTTPServer
+	logger     *zap.Logger
+}
+
+func NewStrategyOptimizationHandler(u usecase.StrategyOptimizationInteractor, s driver.HTTPServer, l *zap.Logger) *StrategyOptimizationHandler {
+	return &StrategyOptimizationHandler{usecase: u, httpServer: s, logger: l}
+}
+
+// startStrategyOptimizationRequest 省略（ゼロ値）の項目は既定値を使う。
+type startStrategyOptimizationRequest struct {
+	Strategy          string  `json:"strategy"`
+	Method            string  `json:"method"`
+	Samples           int     `json:"samples"`
+	Seed              int64   `json:"seed"`
+	Years             int     `json:"years"`
+	InSampleMonths    int     `json:"inSampleMonths"`
+	OutOfSampleMonths int     `json:"outOfSampleMonths"`
+	CommissionRate    float64 `json:"commissionRate"`
+	SlippageRate      float64 `json:"slippageRate"`
+}
+
+// StartOptimization POST /strategy-optimizations
+// 最適化ジョブを登録して 202 で返す。結果は GET /strategy-optimizations/result?jobId= で取得する。
+// 別のジョブが実行中なら 409 を返す。
+func (h *StrategyOptimizationHandler) StartOptimization(w http.ResponseWriter, r *http.Request) {
+	var req startStrategyOptimizationRequest
+	if err := h.httpServer.ParseJSONBody(r, &req); err != nil {
+		http.Error(w, "リクエストボディが不正です", http.StatusBadRequest)
+		return
+	}
+	params, err := parseStrategyOptimizationRequest(req)
+	if err != nil {
+		writeError(w, h.logger, "strategy optimization invalid request", err)
+		return
+	}
+
+	job, err := h.usecase.StartOptimization(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, usecase.ErrStrategyOptimizationRunning) {
+			http.Error(w, "別の最適化ジョブが実行中です", http.StatusConflict)
+			return
+		}
+		writeError(w, h.logger, "strategy optimization start failed", err)
+		return
+	}
+	respondJSONStatus(w, h.logger, http.StatusAccepted, job)
+}
+
+// GetOptimization GET /strategy-optimizations/result?jobId=<id>
+func (h *StrategyOptimizationHandler) GetOptimization(w http.ResponseWriter, r *http.Request) {
+	jobID := h.httpServer.GetQueryParam(r, "jobId")
+	if jobID == "" {
+		http.Error(w, "jobId パラメータは必須です", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.usecase.GetOptimization(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrStrategyOptimizationNotFound) {
+			http.Error(w, "指定されたジョブが見つかりません", http.StatusNotFound)
+			return
+		}
+		writeError(w, h.logger, "strategy optimization get failed", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
+
+func parseStrategyOptimizationRequest(req startStrategyOptimizationRequest) (models.StrategyOptimizationParams, error) {
+	if req.Strategy == "" {
+		return models.StrategyOptimizationParams{}, &validationError{message: "strategy は必須です"}
+	}
+	if !isValidStrategy(req.Strategy) {
+		return models.StrategyOptimizationParams{}, &validationError{message: "strategy が不正です"}
+	}
+
+	params := models.StrategyOptimizationParams{
+		Strategy:          req.Strategy,
+		Method:            models.OptimizationMethodGrid,
+		Samples:           strategyOptimizationDefaultSamples,
+		Seed:              req.Seed,
+		Years:             strategyOptimizationDefaultYears,
+		InSampleMonths:    strategyOptimizationDefaultInSampleMonths,
+		OutOfSampleMonths: strategyOptimizationDefaultOutOfSampleMonths,
+	}
+	switch req.Method {
+	case "", models.OptimizationMethodGrid:
+	case models.OptimizationMethodRandom:
+		params.Method = models.OptimizationMethodRandom
+	default:
+		return models.StrategyOptimizationParams{}, &validationError{message: "method は grid または random を指定してください"}
+	}
+	if req.Samples != 0 {
+		if req.Samples < 1 || req.Samples > strategyOptimizationMaxSamples {
+			return models.StrategyOptimizationParams{}, &validationError{message: "samples は 1 以上 200 以下で指定してください"}
+		}
+		params.Samples = req.Samples
+	}
+	if req.Years != 0 {
+		if req.Years < 1 || req.Years > strategyOptimizationMaxYears {
+			return models.StrategyOptimizationParams{}, &validationError{message: "years は 1 以上 20 以下で指定してください"}
+		}
+		params.Years = req.Years
+	}
+	if req.InSampleMonths != 0 {
+		if req.InSampleMonths < 1 {
+			return models.StrategyOptimizationParams{}, &validationError{message: "inSampleMonths は 1 以上で指定してください"}
+		}
+		params.InSampleMonths = req.InSampleMonths
+	}
+	if req.OutOfSampleMonths != 0 {
+		if req.OutOfSampleMonths < 1 {
+			return models.StrategyOptimizationParams{}, &validationError{message: "outOfSampleMonths は 1 以上で指定してください"}
+		}
+		params.OutOfSampleMonths = req.OutOfSampleMonths
+	}
+	if params.InSampleMonths+params.OutOfSampleMonths > params.Years*12 {
+		return models.StrategyOptimizationParams{}, &validationError{message: "inSampleMonths + outOfSampleMonths は years 以内で指定してください"}
+	}
+	if req.CommissionRate < 0 || req.CommissionRate > strategyOptimizationMaxCostRate {
+		return models.StrategyOptimizationParams{}, &validationError{message: "commissionRate は 0 以上 0.05 以下で指定してください"}
+	}
+	if req.SlippageRate < 0 || req.SlippageRate > strategyOptimizationMaxCostRate {
+		return models.StrategyOptimizationParams{}, &validationError{message: "slippageRate は 0 以上 0.05 以下で指定してください"}
+	}
+	params.CommissionRate = decimal.NewFromFloat(req.CommissionRate)
+	params.SlippageRate = decimal.NewFromFloat(req.SlippageRate)
+	return params, nil
+}
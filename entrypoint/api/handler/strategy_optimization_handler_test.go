@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
+	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+// decodeJSONBody ParseJSONBody のモックで実際のボディをデコードする。
+func decodeJSONBody(r *http.Request, v interface{}) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+func TestStrategyOptimizationHandler_StartOptimization(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		usecase        func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: 省略項目は既定値で 202 を返す",
+			body: `{"strategy":"macd_bullish","method":"random","samples":10,"seed":7,"commissionRate":0.0005}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor {
+				m := mock_usecase.NewMockStrategyOptimizationInteractor(ctrl)
+				m.EXPECT().StartOptimization(gomock.Any(), models.StrategyOptimizationParams{
+					Strategy:          "macd_bullish",
+					Method:            models.OptimizationMethodRandom,
+					Samples:           10,
+					Seed:              7,
+					Years:             5,
+					InSampleMonths:    24,
+					OutOfSampleMonths: 6,
+					CommissionRate:    decimal.NewFromFloat(0.0005),
+					SlippageRate:      decimal.NewFromFloat(0),
+				}).Return(&models.StrategyOptimizationResult{JobID: "job-1", Status: models.OptimizationStatusRunning}, nil)
+				return m
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "異常系: strategy不正",
+			body: `{"strategy":"unknown"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor {
+				return mock_usecase.NewMockStrategyOptimizationInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "strategy が不正です\n",
+		},
+		{
+			name: "異常系: 窓が検証期間に収まらない",
+			body: `{"strategy":"ma_cross","years":2,"inSampleMonths":24}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor {
+				return mock_usecase.NewMockStrategyOptimizationInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "inSampleMonths + outOfSampleMonths は years 以内で指定してください\n",
+		},
+		{
+			name: "異常系: 別のジョブが実行中",
+			body: `{"strategy":"ma_cross"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor {
+				m := mock_usecase.NewMockStrategyOptimizationInteractor(ctrl)
+				m.EXPECT().StartOptimization(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrStrategyOptimizationRunning)
+				return m
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       "別の最適化ジョブが実行中です\n",
+		},
+		{
+			name: "異常系: usecaseエラー",
+			body: `{"strategy":"ma_cross"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor {
+				m := mock_usecase.NewMockStrategyOptimizationInteractor(ctrl)
+				m.EXPECT().StartOptimization(gomock.Any(), gomock.Any()).Return(nil, errors.New("redis error"))
+				return m
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := mock_driver.NewMockHTTPServer(ctrl)
+			server.EXPECT().ParseJSONBody(gomock.Any(), gomock.Any()).DoAndReturn(decodeJSONBody)
+			h := NewStrategyOptimizationHandler(tt.usecase(ctrl), server, zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.StartOptimization(w, httptest.NewRequest(http.MethodPost, "/strategy-optimizations", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestStrategyOptimizationHandler_GetOptimization(t *testing.T) {
+	tests := []struct {
+		name           string
+		jobID          string
+		usecase        func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor
+		wantStatusCode int
+	}{
+		{
+			name:  "正常系",
+			jobID: "job-1",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor {
+				m := mock_usecase.NewMockStrategyOptimizationInteractor(ctrl)
+				m.EXPECT().GetOptimization(gomock.Any(), "job-1").Return(&models.StrategyOptimizationResult{JobID: "job-1"}, nil)
+				return m
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:  "異常系: jobId未指定",
+			jobID: "",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor {
+				return mock_usecase.NewMockStrategyOptimizationInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: ジョブが存在しない",
+			jobID: "missing",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyOptimizationInteractor {
+				m := mock_usecase.NewMockStrategyOptimizationInteractor(ctrl)
+				m.EXPECT().GetOptimization(gomock.Any(), "missing").Return(nil, usecase.ErrStrategyOptimizationNotFound)
+				return m
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := mock_driver.NewMockHTTPServer(ctrl)
+			server.EXPECT().GetQueryParam(gomock.Any(), "jobId").Return(tt.jobID)
+			h := NewStrategyOptimizationHandler(tt.usecase(ctrl), server, zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.GetOptimization(w, httptest.NewRequest(http.MethodGet, "/strategy-optimizations/result", nil))
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+		})
+	}
+}
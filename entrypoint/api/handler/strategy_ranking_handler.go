@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
 	"github.com/Code0716/stock-price-repository/domain_service"
 	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/models"
 	"github.com/Code0716/stock-price-repository/usecase"
 	"go.uber.org/zap"
 )
@@ -13,9 +18,16 @@ import (
 const (
 	strategyRankingStocksDefaultLimit = 100
 	strategyRankingStocksMaxLimit     = 2000
+	strategyRankingRunsDefaultLimit   = 50
+	strategyRankingRunsMaxLimit       = 500
+	strategyRankingDefaultYears       = 5
+	strategyRankingMaxYears           = 20
+	strategyRankingMaxCodes           = 50
+	strategyRankingMaxSymbols         = 500
 )
 
-// StrategyRankingHandler GET /strategy-ranking および GET /strategy-ranking-stocks のハンドラ。
+// StrategyRankingHandler GET /strategy-ranking, /strategy-ranking-stocks, /strategy-ranking/runs, /strategy-ranking/diff
+// および POST /strategy-ranking/compute のハンドラ。
 type StrategyRankingHandler struct {
 	usecase    usecase.StrategyRankingInteractor
 	httpServer driver.HTTPServer
@@ -30,17 +42,39 @@ func NewStrategyRankingHandler(u usecase.StrategyRankingInteractor, h driver.HTT
 	}
 }
 
+// isValidRunID run_id が uuid 形式か確認する。
+func isValidRunID(runID string) bool {
+	_, err := uuid.Parse(runID)
+	return err == nil
+}
+
+// GetStrategyRanking GET /strategy-ranking?runId=<id>（runId 省略時は最新実行）
 func (h *StrategyRankingHandler) GetStrategyRanking(w http.ResponseWriter, r *http.Request) {
-	result, err := h.usecase.GetStrategyRanking(r.Context())
+	runID := r.URL.Query().Get("runId")
+	if runID != "" && !isValidRunID(runID) {
+		http.Error(w, "runId が不正です", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.usecase.GetStrategyRanking(r.Context(), runID)
 	if err != nil {
+		if errors.Is(err, usecase.ErrStrategyRankingRunNotFound) {
+			http.Error(w, "指定された実行が見つかりません", http.StatusNotFound)
+			return
+		}
 		writeError(w, h.logger, "failed to get strategy ranking", err)
 		return
 	}
 	respondJSON(w, h.logger, result)
 }
 
-// GetStrategyRankingStocks GET /strategy-ranking-stocks?strategy=<id>&limit=<n>
+// GetStrategyRankingStocks GET /strategy-ranking-stocks?strategy=<id>&limit=<n>&runId=<id>
 func (h *StrategyRankingHandler) GetStrategyRankingStocks(w http.ResponseWriter, r *http.Request) {
+	runID := r.URL.Query().Get("runId")
+	if runID != "" && !isValidRunID(runID) {
+		http.Error(w, "runId が不正です", http.StatusBadRequest)
+		return
+	}
 	strategy := r.URL.Query().Get("strategy")
 	if strategy == "" {
 		http.Error(w, "strategy パラメータは必須です", http.StatusBadRequest)
@@ -65,8 +99,12 @@ func (h *StrategyRankingHandler) GetStrategyRankingStocks(w http.ResponseWriter,
 		limit = v
 	}
 
-	result, err := h.usecase.GetStrategyRankingStocks(r.Context(), strategy, limit)
+	result, err := h.usecase.GetStrategyRankingStocks(r.Context(), runID, strategy, limit)
 	if err != nil {
+		if errors.Is(err, usecase.ErrStrategyRankingRunNotFound) {
+			http.Error(w, "指定された実行が見つかりません", http.StatusNotFound)
+			return
+		}
 		h.logger.Error("failed to get strategy ranking stocks", zap.Error(err), zap.String("strategy", strategy))
 		http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
 		return
@@ -74,12 +112,234 @@ func (h *StrategyRankingHandler) GetStrategyRankingStocks(w http.ResponseWriter,
 	respondJSON(w, h.logger, result)
 }
 
-// isValidStrategy 戦略 ID が既知の5定数のいずれかか確認する。
-func isValidStrategy(strategy string) bool {
-	for _, s := range domain_service.StrategyOrder {
-		if s == strategy {
-			return true
+// GetStrategyRankingRuns GET /strategy-ranking/runs?limit=<n>
+func (h *StrategyRankingHandler) GetStrategyRankingRuns(w http.ResponseWriter, r *http.Request) {
+	limit := strategyRankingRunsDefaultLimit
+	if ls := r.URL.Query().Get("limit"); ls != "" {
+		v, err := strconv.Atoi(ls)
+		if err != nil || v <= 0 || v > strategyRankingRunsMaxLimit {
+			http.Error(w, "limit は 1 以上 500 以下の整数で指定してください", http.StatusBadRequest)
+			return
+		}
+		limit = v
+	}
+
+	result, err := h.usecase.ListStrategyRankingRuns(r.Context(), limit)
+	if err != nil {
+		writeError(w, h.logger, "failed to list strategy ranking runs", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
+
+// GetStrategyRankingDiff GET /strategy-ranking/diff?baseRunId=<id>&targetRunId=<id>&segment=<name>（segment 省略時は全期間）
+func (h *StrategyRankingHandler) GetStrategyRankingDiff(w http.ResponseWriter, r *http.Request) {
+	baseRunID := r.URL.Query().Get("baseRunId")
+	targetRunID := r.URL.Query().Get("targetRunId")
+	if baseRunID == "" || targetRunID == "" {
+		http.Error(w, "baseRunId と targetRunId は必須です", http.StatusBadRequest)
+		return
+	}
+	if !isValidRunID(baseRunID) || !isValidRunID(targetRunID) {
+		http.Error(w, "runId が不正です", http.StatusBadRequest)
+		return
+	}
+
+	segment := r.URL.Query().Get("segment")
+	if len(segment) > 32 {
+		http.Error(w, "segment が不正です", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.usecase.DiffStrategyRankingRuns(r.Context(), baseRunID, targetRunID, segment)
+	if err != nil {
+		if errors.Is(err, usecase.ErrStrategyRankingRunNotFound) {
+			http.Error(w, "指定された実行が見つかりません", http.StatusNotFound)
+			return
+		}
+		writeError(w, h.logger, "failed to diff strategy ranking runs", err)
+		return
+	}
+	respondJSON(w, h.logger, result)
+}
+
+// startStrategyRankingRequest 省略（ゼロ値）の項目は既定値を使う。
+type startStrategyRankingRequest struct {
+	Years          int     `json:"years"`
+	TakeProfit     float64 `json:"takeProfit"`
+	StopLoss       float64 `json:"stopLoss"`
+	MaxHoldDays    int     `json:"maxHoldDays"`
+	CommissionRate float64 `json:"commissionRate"`
+	SlippageRate   float64 `json:"slippageRate"`
+	ExitMode       string  `json:"exitMode"`
+	// EntryTiming / ExitFill / IntrabarPriority / TickRounding / BorrowRate は /backtest のクエリと同じ値・検証
+	EntryTiming      string  `json:"entryTiming"`
+	ExitFill         string  `json:"exitFill"`
+	IntrabarPriority string  `json:"intrabarPriority"`
+	TickRounding     bool    `json:"tickRounding"`
+	BorrowRate       float64 `json:"borrowRate"`
+	Universe         struct {
+		MarketCodes        []string `json:"marketCodes"`
+		Sector33Codes      []string `json:"sector33Codes"`
+		MinAvgTradingValue float64  `json:"minAvgTradingValue"`
+		WatchlistName      string   `json:"watchlistName"`
+		Symbols            []string `json:"symbols"`
+	} `json:"universe"`
+	Segments []struct {
+		Name   string                    `json:"name"`
+		Ranges []models.RankingDateRange `json:"ranges"`
+	} `json:"segments"`
+	NikkeiRegimes bool `json:"nikkeiRegimes"`
+}
+
+// StartStrategyRanking POST /strategy-ranking/compute
+// ユニバース・評価期間セグメントを指定した戦略ランキングの実行を登録して 202 で返す。
+// 結果は GET /strategy-ranking?runId= で取得する（完了まで computed=false, status=running）。
+// 別の実行が実行中なら 409 を返す。
+func (h *StrategyRankingHandler) StartStrategyRanking(w http.ResponseWriter, r *http.Request) {
+	var req startStrategyRankingRequest
+	if err := h.httpServer.ParseJSONBody(r, &req); err != nil {
+		http.Error(w, "リクエストボディが不正です", http.StatusBadRequest)
+		return
+	}
+	rankingReq, err := parseStartStrategyRankingRequest(req)
+	if err != nil {
+		writeError(w, h.logger, "strategy ranking invalid request", err)
+		return
+	}
+
+	run, err := h.usecase.StartStrategyRanking(r.Context(), rankingReq)
+	if err != nil {
+		if errors.Is(err, usecase.ErrStrategyRankingRunning) {
+			http.Error(w, "別の戦略ランキングが実行中です", http.StatusConflict)
+			return
+		}
+		writeError(w, h.logger, "strategy ranking start failed", err)
+		return
+	}
+	respondJSONStatus(w, h.logger, http.StatusAccepted, run)
+}
+
+// executionParam 約定モデルの項目をクエリと同じ文字列表現で返す（applyExecutionParams 用）。
+func (req startStrategyRankingRequest) executionParam(name string) string {
+	switch name {
+	case "entryTiming":
+		return req.EntryTiming
+	case "exitFill":
+		return req.ExitFill
+	case "intrabarPriority":
+		return req.IntrabarPriority
+	case "tickRounding":
+		return strconv.FormatBool(req.TickRounding)
+	case "borrowRate":
+		return strconv.FormatFloat(req.BorrowRate, 'f', -1, 64)
+	}
+	return ""
+}
+
+func parseStartStrategyRankingRequest(req startStrategyRankingRequest) (models.StrategyRankingRequest, error) {
+	params := models.BacktestParams{
+		TakeProfit:  defaultTakeProfit,
+		StopLoss:    defaultStopLoss,
+		MaxHoldDays: defaultMaxHoldDays,
+		ExitMode:    models.ExitModeCommon,
+	}
+	if req.TakeProfit != 0 {
+		if req.TakeProfit < 0 || req.TakeProfit > 1 {
+			return models.StrategyRankingRequest{}, &validationError{message: "takeProfit は 0 より大きく 1 以下で指定してください"}
+		}
+		params.TakeProfit = decimal.NewFromFloat(req.TakeProfit)
+	}
+	if req.StopLoss != 0 {
+		if req.StopLoss < 0 || req.StopLoss > 1 {
+			return models.StrategyRankingRequest{}, &validationError{message: "stopLoss は 0 より大きく 1 以下で指定してください"}
+		}
+		params.StopLoss = decimal.NewFromFloat(req.StopLoss)
+	}
+	if req.MaxHoldDays != 0 {
+		if req.MaxHoldDays < 1 || req.MaxHoldDays > 250 {
+			return models.StrategyRankingRequest{}, &validationError{message: "maxHoldDays は 1 以上 250 以下で指定してください"}
+		}
+		params.MaxHoldDays = req.MaxHoldDays
+	}
+	if req.CommissionRate < 0 || req.CommissionRate > strategyOptimizationMaxCostRate {
+		return models.StrategyRankingRequest{}, &validationError{message: "commissionRate は 0 以上 0.05 以下で指定してください"}
+	}
+	if req.SlippageRate < 0 || req.SlippageRate > strategyOptimizationMaxCostRate {
+		return models.StrategyRankingRequest{}, &validationError{message: "slippageRate は 0 以上 0.05 以下で指定してください"}
+	}
+	params.CommissionRate = decimal.NewFromFloat(req.CommissionRate)
+	params.SlippageRate = decimal.NewFromFloat(req.SlippageRate)
+	exitMode, ok := parseExitMode(req.ExitMode)
+	if !ok {
+		return models.StrategyRankingRequest{}, &validationError{message: "exitMode は common または signal を指定してください"}
+	}
+	params.ExitMode = exitMode
+	if err := applyExecutionParams(req.executionParam, &params); err != nil {
+		return models.StrategyRankingRequest{}, err
+	}
+
+	years := strategyRankingDefaultYears
+	if req.Years != 0 {
+		if req.Years < 1 || req.Years > strategyRankingMaxYears {
+			return models.StrategyRankingRequest{}, &validationError{message: "years は 1 以上 20 以下で指定してください"}
+		}
+		years = req.Years
+	}
+
+	u := req.Universe
+	if len(u.MarketCodes) > strategyRankingMaxCodes || len(u.Sector33Codes) > strategyRankingMaxCodes {
+		return models.StrategyRankingRequest{}, &validationError{message: "marketCodes と sector33Codes は 50 件以下で指定してください"}
+	}
+	for _, c := range append(append([]string{}, u.MarketCodes...), u.Sector33Codes...) {
+		if !alphanumericRequiredRegex.MatchString(c) {
+			return models.StrategyRankingRequest{}, &validationError{message: "marketCodes と sector33Codes は英数字で指定してください"}
+		}
+	}
+	if len(u.Symbols) > strategyRankingMaxSymbols {
+		return models.StrategyRankingRequest{}, &validationError{message: "symbols は 500 銘柄以下で指定してください"}
+	}
+	for _, sym := range u.Symbols {
+		if len(sym) > 10 || !alphanumericRequiredRegex.MatchString(sym) {
+			return models.StrategyRankingRequest{}, &validationError{message: "symbols は英数字の銘柄コードで指定してください"}
 		}
 	}
-	return false
+	if u.WatchlistName != "" && len(u.Symbols) == 0 {
+		return models.StrategyRankingRequest{}, &validationError{message: "watchlistName を指定する場合 symbols は必須です"}
+	}
+	if u.MinAvgTradingValue < 0 {
+		return models.StrategyRankingRequest{}, &validationError{message: "minAvgTradingValue は 0 以上で指定してください"}
+	}
+
+	segments := make([]models.RankingSegment, 0, len(req.Segments))
+	for _, seg := range req.Segments {
+		segments = append(segments, models.RankingSegment{
+			Name:   seg.Name,
+			Source: models.RankingSegmentSourceExplicit,
+			Ranges: seg.Ranges,
+		})
+	}
+	if err := domain_service.ValidateRankingSegments(segments); err != nil {
+		return models.StrategyRankingRequest{}, &validationError{message: err.Error()}
+	}
+
+	return models.StrategyRankingRequest{
+		Params: params,
+		Years:  years,
+		Universe: models.RankingUniverse{
+			MarketCodes:        u.MarketCodes,
+			Sector33Codes:      u.Sector33Codes,
+			MinAvgTradingValue: decimal.NewFromFloat(u.MinAvgTradingValue),
+			WatchlistName:      u.WatchlistName,
+			Symbols:            u.Symbols,
+		},
+		Segments:      segments,
+		NikkeiRegimes: req.NikkeiRegimes,
+	}, nil
+}
+
+// isValidStrategy 戦略 ID が登録済みの戦略か確認する。
+func isValidStrategy(strategy string) bool {
+	_, ok := domain_service.LookupStrategy(strategy)
+	return ok
 }
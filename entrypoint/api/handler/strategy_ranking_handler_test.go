@@ -5,17 +5,21 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	mock_driver "github.com/Code0716/stock-price-repository/mock/driver"
 	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
 	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 )
 
+const testRankingRunID = "11111111-1111-1111-1111-111111111111"
+
 func TestStrategyRankingHandler_GetStrategyRanking(t *testing.T) {
 	type fields struct {
 		usecase    func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor
@@ -33,7 +37,7 @@ func TestStrategyRankingHandler_GetStrategyRanking(t *testing.T) {
 			fields: fields{
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
 					m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
-					m.EXPECT().GetStrategyRanking(gomock.Any()).Return(&models.StrategyRanking{
+					m.EXPECT().GetStrategyRanking(gomock.Any(), "").Return(&models.StrategyRanking{
 						Computed:    true,
 						ComputedAt:  "2026-06-01T00:00:00Z",
 						Universe:    "main_markets",
@@ -85,7 +89,7 @@ func TestStrategyRankingHandler_GetStrategyRanking(t *testing.T) {
 			fields: fields{
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
 					m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
-					m.EXPECT().GetStrategyRanking(gomock.Any()).Return(&models.StrategyRanking{
+					m.EXPECT().GetStrategyRanking(gomock.Any(), "").Return(&models.StrategyRanking{
 						Computed: false,
 						Items:    []models.StrategyRankingItem{},
 					}, nil)
@@ -107,7 +111,7 @@ func TestStrategyRankingHandler_GetStrategyRanking(t *testing.T) {
 			fields: fields{
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
 					m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
-					m.EXPECT().GetStrategyRanking(gomock.Any()).Return(nil, errors.New("redis error"))
+					m.EXPECT().GetStrategyRanking(gomock.Any(), "").Return(nil, errors.New("redis error"))
 					return m
 				},
 				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
@@ -118,6 +122,60 @@ func TestStrategyRankingHandler_GetStrategyRanking(t *testing.T) {
 			wantStatusCode: http.StatusInternalServerError,
 			wantBody:       "内部サーバーエラー\n",
 		},
+		{
+			name: "正常系: runId 指定で過去の実行を返す",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+					m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+					m.EXPECT().GetStrategyRanking(gomock.Any(), testRankingRunID).Return(&models.StrategyRanking{
+						Computed: true,
+						RunID:    testRankingRunID,
+						Items:    []models.StrategyRankingItem{},
+					}, nil)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return mock_driver.NewMockHTTPServer(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking?runId="+testRankingRunID, nil),
+			wantStatusCode: http.StatusOK,
+			wantBody: &models.StrategyRanking{
+				Computed: true,
+				RunID:    testRankingRunID,
+				Items:    []models.StrategyRankingItem{},
+			},
+		},
+		{
+			name: "異常系: runId が uuid でない → 400",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+					return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return mock_driver.NewMockHTTPServer(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking?runId=latest", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "runId が不正です\n",
+		},
+		{
+			name: "異常系: runId の実行が存在しない → 404",
+			fields: fields{
+				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+					m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+					m.EXPECT().GetStrategyRanking(gomock.Any(), testRankingRunID).Return(nil, usecase.ErrStrategyRankingRunNotFound)
+					return m
+				},
+				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
+					return mock_driver.NewMockHTTPServer(ctrl)
+				},
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking?runId="+testRankingRunID, nil),
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "指定された実行が見つかりません\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -158,7 +216,7 @@ func TestStrategyRankingHandler_GetStrategyRankingStocks(t *testing.T) {
 			fields: fields{
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
 					m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
-					m.EXPECT().GetStrategyRankingStocks(gomock.Any(), gomock.Eq("macd_bullish"), gomock.Eq(10)).Return(&models.StrategyStocks{
+					m.EXPECT().GetStrategyRankingStocks(gomock.Any(), "", gomock.Eq("macd_bullish"), gomock.Eq(10)).Return(&models.StrategyStocks{
 						Computed:   true,
 						ComputedAt: "2026-06-10T00:00:00Z",
 						Strategy:   "macd_bullish",
@@ -192,7 +250,7 @@ func TestStrategyRankingHandler_GetStrategyRankingStocks(t *testing.T) {
 			fields: fields{
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
 					m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
-					m.EXPECT().GetStrategyRankingStocks(gomock.Any(), gomock.Eq("bollinger_breakout"), gomock.Eq(100)).Return(&models.StrategyStocks{
+					m.EXPECT().GetStrategyRankingStocks(gomock.Any(), "", gomock.Eq("bollinger_breakout"), gomock.Eq(100)).Return(&models.StrategyStocks{
 						Computed: false,
 						Strategy: "bollinger_breakout",
 						Items:    []*models.StrategyStockResult{},
@@ -286,7 +344,7 @@ func TestStrategyRankingHandler_GetStrategyRankingStocks(t *testing.T) {
 			fields: fields{
 				usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
 					m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
-					m.EXPECT().GetStrategyRankingStocks(gomock.Any(), gomock.Eq("macd_bullish"), gomock.Eq(100)).Return(nil, errors.New("redis error"))
+					m.EXPECT().GetStrategyRankingStocks(gomock.Any(), "", gomock.Eq("macd_bullish"), gomock.Eq(100)).Return(nil, errors.New("redis error"))
 					return m
 				},
 				httpServer: func(ctrl *gomock.Controller) *mock_driver.MockHTTPServer {
@@ -319,3 +377,306 @@ func TestStrategyRankingHandler_GetStrategyRankingStocks(t *testing.T) {
 		})
 	}
 }
+
+func TestStrategyRankingHandler_GetStrategyRankingRuns(t *testing.T) {
+	tests := []struct {
+		name           string
+		usecase        func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: limit 省略時デフォルト50",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				m.EXPECT().ListStrategyRankingRuns(gomock.Any(), 50).Return(&models.StrategyRankingRuns{
+					Items: []*models.StrategyRankingRun{{RunID: testRankingRunID}},
+				}, nil)
+				return m
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking/runs", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: limit が範囲外 → 400",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking/runs?limit=501", nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "limit は 1 以上 500 以下の整数で指定してください\n",
+		},
+		{
+			name: "異常系: UseCaseがエラーを返す → 500",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				m.EXPECT().ListStrategyRankingRuns(gomock.Any(), 10).Return(nil, errors.New("db error"))
+				return m
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking/runs?limit=10", nil),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewStrategyRankingHandler(tt.usecase(ctrl), mock_driver.NewMockHTTPServer(ctrl), zap.NewNop())
+			w := httptest.NewRecorder()
+			h.GetStrategyRankingRuns(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestStrategyRankingHandler_GetStrategyRankingDiff(t *testing.T) {
+	const otherRunID = "22222222-2222-2222-2222-222222222222"
+	tests := []struct {
+		name           string
+		usecase        func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor
+		req            *http.Request
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: 2回の実行の差分を返す",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				m.EXPECT().DiffStrategyRankingRuns(gomock.Any(), testRankingRunID, otherRunID, "").Return(&models.StrategyRankingDiff{}, nil)
+				return m
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking/diff?baseRunId="+testRankingRunID+"&targetRunId="+otherRunID, nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "正常系: セグメントを指定して比較",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				m.EXPECT().DiffStrategyRankingRuns(gomock.Any(), testRankingRunID, otherRunID, "nikkei_bull").Return(&models.StrategyRankingDiff{Segment: "nikkei_bull"}, nil)
+				return m
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking/diff?baseRunId="+testRankingRunID+"&targetRunId="+otherRunID+"&segment=nikkei_bull", nil),
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "異常系: targetRunId なし → 400",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking/diff?baseRunId="+testRankingRunID, nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "baseRunId と targetRunId は必須です\n",
+		},
+		{
+			name: "異常系: 実行が存在しない → 404",
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				m.EXPECT().DiffStrategyRankingRuns(gomock.Any(), testRankingRunID, otherRunID, "").Return(nil, usecase.ErrStrategyRankingRunNotFound)
+				return m
+			},
+			req:            httptest.NewRequest(http.MethodGet, "/strategy-ranking/diff?baseRunId="+testRankingRunID+"&targetRunId="+otherRunID, nil),
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "指定された実行が見つかりません\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := NewStrategyRankingHandler(tt.usecase(ctrl), mock_driver.NewMockHTTPServer(ctrl), zap.NewNop())
+			w := httptest.NewRecorder()
+			h.GetStrategyRankingDiff(w, tt.req)
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestStrategyRankingHandler_StartStrategyRanking(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		usecase        func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "正常系: ユニバースとセグメントを指定して登録",
+			body: `{"years":3,"universe":{"sector33Codes":["3700"],"minAvgTradingValue":100000000},` +
+				`"segments":[{"name":"trend_2023","ranges":[{"from":"2023-01-01","to":"2023-12-31"}]}],"nikkeiRegimes":true}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				m.EXPECT().StartStrategyRanking(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, req models.StrategyRankingRequest) (*models.StrategyRankingRun, error) {
+						assert.Equal(t, 3, req.Years)
+						assert.Equal(t, []string{"3700"}, req.Universe.Sector33Codes)
+						assert.True(t, req.Universe.MinAvgTradingValue.Equal(decimal.NewFromInt(100_000_000)))
+						assert.Equal(t, models.RankingSegmentSourceExplicit, req.Segments[0].Source)
+						assert.True(t, req.NikkeiRegimes)
+						// 省略した条件は既定値
+						assert.True(t, req.Params.TakeProfit.Equal(defaultTakeProfit))
+						assert.Equal(t, models.ExitModeCommon, req.Params.ExitMode)
+						assert.Equal(t, models.EntryTimingClose, req.Params.EntryTiming)
+						assert.Equal(t, models.ExitFillClose, req.Params.ExitFill)
+						assert.Equal(t, models.IntrabarPriorityStopLoss, req.Params.IntrabarPriority)
+						return &models.StrategyRankingRun{RunID: testRankingRunID, Status: models.RankingRunStatusRunning}, nil
+					})
+				return m
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "正常系: 約定モデルと貸株料を指定して登録",
+			body: `{"entryTiming":"next_open","exitFill":"intrabar","intrabarPriority":"take_profit","tickRounding":true,"borrowRate":0.0003}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				m.EXPECT().StartStrategyRanking(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, req models.StrategyRankingRequest) (*models.StrategyRankingRun, error) {
+						assert.Equal(t, models.EntryTimingNextOpen, req.Params.EntryTiming)
+						assert.Equal(t, models.ExitFillIntrabar, req.Params.ExitFill)
+						assert.Equal(t, models.IntrabarPriorityTakeProfit, req.Params.IntrabarPriority)
+						assert.True(t, req.Params.TickRounding)
+						assert.True(t, req.Params.BorrowRate.Equal(decimal.NewFromFloat(0.0003)))
+						return &models.StrategyRankingRun{RunID: testRankingRunID, Status: models.RankingRunStatusRunning}, nil
+					})
+				return m
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "異常系: entryTiming 不正",
+			body: `{"entryTiming":"open"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "entryTimingはcloseまたはnext_openである必要があります\n",
+		},
+		{
+			name: "異常系: exitFill 不正",
+			body: `{"exitFill":"open"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "exitFillはcloseまたはintrabarである必要があります\n",
+		},
+		{
+			name: "異常系: intrabarPriority 不正",
+			body: `{"exitFill":"intrabar","intrabarPriority":"close"}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "intrabarPriorityはstop_lossまたはtake_profitである必要があります\n",
+		},
+		{
+			name: "異常系: borrowRate が範囲外",
+			body: `{"borrowRate":0.01}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "borrowRateは0以上0.001以下（日率）である必要があります\n",
+		},
+		{
+			name: "異常系: borrowRate が負",
+			body: `{"borrowRate":-0.0001}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "borrowRateは0以上0.001以下（日率）である必要があります\n",
+		},
+		{
+			name: "異常系: セグメントの期間が逆順",
+			body: `{"segments":[{"name":"x","ranges":[{"from":"2023-12-31","to":"2023-01-01"}]}]}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "セグメント \"x\" の終了日は開始日以降で指定してください\n",
+		},
+		{
+			name: "異常系: watchlistName のみ",
+			body: `{"universe":{"watchlistName":"core"}}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "watchlistName を指定する場合 symbols は必須です\n",
+		},
+		{
+			name: "異常系: years が範囲外",
+			body: `{"years":21}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "years は 1 以上 20 以下で指定してください\n",
+		},
+		{
+			name: "異常系: 不正な JSON",
+			body: `{`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				return mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "リクエストボディが不正です\n",
+		},
+		{
+			name: "異常系: 別の実行が実行中 → 409",
+			body: `{}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				m.EXPECT().StartStrategyRanking(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrStrategyRankingRunning)
+				return m
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       "別の戦略ランキングが実行中です\n",
+		},
+		{
+			name: "異常系: usecase エラー",
+			body: `{}`,
+			usecase: func(ctrl *gomock.Controller) *mock_usecase.MockStrategyRankingInteractor {
+				m := mock_usecase.NewMockStrategyRankingInteractor(ctrl)
+				m.EXPECT().StartStrategyRanking(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				return m
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "内部サーバーエラー\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := mock_driver.NewMockHTTPServer(ctrl)
+			server.EXPECT().ParseJSONBody(gomock.Any(), gomock.Any()).DoAndReturn(decodeJSONBody)
+			h := NewStrategyRankingHandler(tt.usecase(ctrl), server, zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.StartStrategyRanking(w, httptest.NewRequest(http.MethodPost, "/strategy-ranking/compute", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
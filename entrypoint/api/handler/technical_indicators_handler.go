@@ -2,17 +2,21 @@ package handler
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Code0716/stock-price-repository/driver"
+	"github.com/Code0716/stock-price-repository/models"
 	"github.com/Code0716/stock-price-repository/usecase"
 	"go.uber.org/zap"
 )
 
 type getTechnicalIndicatorsParams struct {
-	symbol string
-	from   *time.Time
-	to     *time.Time
+	symbol     string
+	from       *time.Time
+	to         *time.Time
+	indicators []string
 }
 
 type TechnicalIndicatorsHandler struct {
@@ -50,9 +54,36 @@ func (h *TechnicalIndicatorsHandler) validateGetTechnicalIndicatorsParams(r *htt
 	params.from = from
 	params.to = to
 
+	indicators, err := parseTechnicalIndicatorNames(h.httpServer.GetQueryParam(r, "indicators"))
+	if err != nil {
+		return nil, err
+	}
+	params.indicators = indicators
+
 	return params, nil
 }
 
+// parseTechnicalIndicatorNames indicators= のカンマ区切り指標名を検証する。空なら nil（全指標）。重複は除く。
+func parseTechnicalIndicatorNames(raw string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	names := make([]string, 0)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(models.TechnicalIndicatorNames, name) {
+			return nil, &validationError{message: "indicatorsに不明な指標が含まれています: " + name}
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
 func (h *TechnicalIndicatorsHandler) GetTechnicalIndicators(w http.ResponseWriter, r *http.Request) {
 	params, err := h.validateGetTechnicalIndicatorsParams(r)
 	if err != nil {
@@ -60,7 +91,7 @@ func (h *TechnicalIndicatorsHandler) GetTechnicalIndicators(w http.ResponseWrite
 		return
 	}
 
-	result, err := h.usecase.GetTechnicalIndicators(r.Context(), params.symbol, params.from, params.to)
+	result, err := h.usecase.GetTechnicalIndicators(r.Context(), params.symbol, params.from, params.to, params.indicators)
 	if err != nil {
 		writeError(w, h.logger, "failed to get technical indicators", err)
 		return
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTechnicalIndicatorNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{name: "省略は nil（全指標）", raw: "", want: nil},
+		{name: "カンマ区切り・空白・重複を許容", raw: "donchian, supertrend,donchian,", want: []string{"donchian", "supertrend"}},
+		{name: "不明な指標", raw: "atr,unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTechnicalIndicatorNames(tt.raw)
+			if tt.wantErr {
+				var ve *validationError
+				assert.ErrorAs(t, err, &ve)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -28,12 +28,21 @@ func main() {
 
 	ctx := context.Background()
 
-	mux, cleanup, err := di.InitializeApiServer(ctx)
+	components, cleanup, err := di.InitializeApiServer(ctx)
 	if err != nil {
 		logger.Fatal("failed to initialize api server", zap.Error(err))
 	}
 	defer cleanup()
 
+	// 前回のプロセスで実行中のまま終了した最適化ジョブを failed にする（起動は止めない）
+	if err := components.StrategyOptimization.FailInterruptedOptimizations(ctx); err != nil {
+		logger.Error("failed to fail interrupted strategy optimizations", zap.Error(err))
+	}
+	// 同様に実行中のまま終了した戦略ランキングの実行を failed にする
+	if err := components.StrategyRanking.FailInterruptedStrategyRankings(ctx); err != nil {
+		logger.Error("failed to fail interrupted strategy rankings", zap.Error(err))
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -41,7 +50,7 @@ func main() {
 
 	srv := &http.Server{
 		Addr:    ":" + port,
-		Handler: mux,
+		Handler: components.Mux,
 	}
 
 	go func() {
@@ -23,6 +23,16 @@ func NewRouter(
 	sectorPerformanceHandler *handler.SectorPerformanceHandler,
 	quizHandler *handler.QuizHandler,
 	dailyStockPickHandler *handler.DailyStockPickHandler,
+	portfolioBacktestHandler *handler.PortfolioBacktestHandler,
+	strategyOptimizationHandler *handler.StrategyOptimizationHandler,
+	candlestickPatternHandler *handler.CandlestickPatternHandler,
+	relativeStrengthHandler *handler.RelativeStrengthHandler,
+	marketBreadthHandler *handler.MarketBreadthHandler,
+	marketRegimeHandler *handler.MarketRegimeHandler,
+	eventStudyHandler *handler.EventStudyHandler,
+	paperPortfolioHandler *handler.PaperPortfolioHandler,
+	calibrationHandler *handler.CalibrationHandler,
+	signalIngestHandler *handler.SignalIngestHandler,
 ) *http.ServeMux {
 	mux := http.NewServeMux()
 	if stockPriceHandler != nil {
@@ -34,10 +44,22 @@ func NewRouter(
 	}
 	if backtestHandler != nil {
 		mux.HandleFunc("/backtest", backtestHandler.GetBacktest)
+		mux.HandleFunc("/backtest/monte-carlo", backtestHandler.GetBacktestMonteCarlo)
+		mux.HandleFunc("/strategies", backtestHandler.GetStrategies)
+	}
+	if portfolioBacktestHandler != nil {
+		mux.HandleFunc("/backtest/portfolio", portfolioBacktestHandler.GetPortfolioBacktest)
 	}
 	if strategyRankingHandler != nil {
 		mux.HandleFunc("/strategy-ranking", strategyRankingHandler.GetStrategyRanking)
 		mux.HandleFunc("/strategy-ranking-stocks", strategyRankingHandler.GetStrategyRankingStocks)
+		mux.HandleFunc("/strategy-ranking/runs", strategyRankingHandler.GetStrategyRankingRuns)
+		mux.HandleFunc("/strategy-ranking/diff", strategyRankingHandler.GetStrategyRankingDiff)
+		mux.HandleFunc("/strategy-ranking/compute", strategyRankingHandler.StartStrategyRanking)
+	}
+	if strategyOptimizationHandler != nil {
+		mux.HandleFunc("/strategy-optimizations", strategyOptimizationHandler.StartOptimization)
+		mux.HandleFunc("/strategy-optimizations/result", strategyOptimizationHandler.GetOptimization)
 	}
 	if valuationHandler != nil {
 		mux.HandleFunc("/valuation", valuationHandler.GetValuation)
@@ -51,6 +73,9 @@ func NewRouter(
 	if analyzeStockBrandPriceHistoryHandler != nil {
 		mux.HandleFunc("/analyze-stock-brand-price-histories", analyzeStockBrandPriceHistoryHandler.GetAnalyzeStockBrandPriceHistories)
 	}
+	if signalIngestHandler != nil {
+		mux.HandleFunc("POST /analyze-stock-brand-price-histories", signalIngestHandler.IngestSignals)
+	}
 	if multipleSignalStocksHandler != nil {
 		mux.HandleFunc("/multiple-signal-stocks", multipleSignalStocksHandler.GetMultipleSignalStocks)
 	}
@@ -61,15 +86,38 @@ func NewRouter(
 	if finStatementHandler != nil {
 		mux.HandleFunc("/fin-statements", finStatementHandler.GetFinStatements)
 	}
+	if candlestickPatternHandler != nil {
+		mux.HandleFunc("/candlestick-patterns", candlestickPatternHandler.GetCandlestickPatterns)
+		mux.HandleFunc("/candlestick-patterns/screening", candlestickPatternHandler.GetCandlestickPatternScreening)
+	}
+	if relativeStrengthHandler != nil {
+		mux.HandleFunc("/relative-strength", relativeStrengthHandler.GetRelativeStrength)
+	}
+	if marketBreadthHandler != nil {
+		mux.HandleFunc("/market-breadth", marketBreadthHandler.GetMarketBreadth)
+	}
+	if marketRegimeHandler != nil {
+		mux.HandleFunc("/market-regime", marketRegimeHandler.GetMarketRegimes)
+	}
+	if eventStudyHandler != nil {
+		mux.HandleFunc("/event-study", eventStudyHandler.RunEventStudy)
+	}
 	if signalPerformanceHandler != nil {
 		mux.HandleFunc("/signal-performance", signalPerformanceHandler.GetSignalPerformance)
 	}
+	if calibrationHandler != nil {
+		mux.HandleFunc("/calibration", calibrationHandler.GetCalibration)
+	}
 	if sectorPerformanceHandler != nil {
 		mux.HandleFunc("/sector-performance", sectorPerformanceHandler.GetSectorPerformance)
+		mux.HandleFunc("/sector-rotation", sectorPerformanceHandler.GetSectorRotation)
 	}
 	registerQuizRoutes(mux, quizHandler)
 	registerDaytradeRoutes(mux, daytradeHandler)
 	registerDailyStockPickRoutes(mux, dailyStockPickHandler)
+	if paperPortfolioHandler != nil {
+		mux.HandleFunc("/paper-portfolio", paperPortfolioHandler.GetPaperPortfolio)
+	}
 	return mux
 }
 
@@ -80,6 +128,7 @@ func registerDailyStockPickRoutes(mux *http.ServeMux, dailyStockPickHandler *han
 	mux.HandleFunc("/daily-stock-picks", dailyStockPickHandler.GetDailyStockPicks)
 	mux.HandleFunc("/daily-stock-picks/dates", dailyStockPickHandler.GetDailyStockPickDates)
 	mux.HandleFunc("/daily-stock-picks/stats", dailyStockPickHandler.GetDailyStockPickStats)
+	mux.HandleFunc("/daily-stock-picks/stats/breakdown", dailyStockPickHandler.GetDailyStockPickStatsBreakdown)
 }
 
 func registerQuizRoutes(mux *http.ServeMux, quizHandler *handler.QuizHandler) {
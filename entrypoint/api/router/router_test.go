@@ -27,7 +27,7 @@ func TestNewRouter(t *testing.T) {
 
 	stockPriceHandler := handler.NewStockPriceHandler(mockDailyPriceUsecase, mockHTTPServer, zap.NewNop())
 	stockBrandHandler := handler.NewStockBrandHandler(mockStockBrandUsecase, mockHTTPServer, zap.NewNop())
-	mux := NewRouter(stockPriceHandler, stockBrandHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	mux := NewRouter(stockPriceHandler, stockBrandHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/daily-prices", nil)
 	w := httptest.NewRecorder()
@@ -46,7 +46,7 @@ func TestNewRouter_WithNilStockBrandHandler(t *testing.T) {
 	mockHTTPServer := mock_driver.NewMockHTTPServer(ctrl)
 
 	stockPriceHandler := handler.NewStockPriceHandler(mockDailyPriceUsecase, mockHTTPServer, zap.NewNop())
-	mux := NewRouter(stockPriceHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	mux := NewRouter(stockPriceHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	// /stock-brands エンドポイントにアクセスしても、404が返るはず（パニックしない）
 	req := httptest.NewRequest(http.MethodGet, "/stock-brands", nil)
@@ -65,7 +65,7 @@ func TestNewRouter_WithNilStockPriceHandler(t *testing.T) {
 	mockHTTPServer := mock_driver.NewMockHTTPServer(ctrl)
 
 	stockBrandHandler := handler.NewStockBrandHandler(mockStockBrandUsecase, mockHTTPServer, zap.NewNop())
-	mux := NewRouter(nil, stockBrandHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	mux := NewRouter(nil, stockBrandHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	// /daily-prices エンドポイントにアクセスしても、404が返るはず（パニックしない）
 	req := httptest.NewRequest(http.MethodGet, "/daily-prices", nil)
@@ -77,7 +77,7 @@ func TestNewRouter_WithNilStockPriceHandler(t *testing.T) {
 }
 
 func TestNewRouter_WithBothNil(t *testing.T) {
-	mux := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	mux := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	// どちらのエンドポイントにアクセスしても、404が返るはず（パニックしない）
 	tests := []struct {
@@ -99,3 +99,25 @@ func TestNewRouter_WithBothNil(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRouter_AnalyzeStockBrandPriceHistoriesByMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTPServer := mock_driver.NewMockHTTPServer(ctrl)
+	// GET は一覧取得ハンドラーに届き、長すぎる symbol で400になる
+	mockHTTPServer.EXPECT().GetQueryParam(gomock.Any(), "symbol").Return("12345678901").Times(1)
+
+	analyzeHandler := handler.NewAnalyzeStockBrandPriceHistoryHandler(mock_usecase.NewMockStockBrandInteractor(ctrl), mockHTTPServer, zap.NewNop())
+	ingestHandler := handler.NewSignalIngestHandler(mock_usecase.NewMockSignalIngestInteractor(ctrl), mockHTTPServer, zap.NewNop())
+	mux := NewRouter(nil, nil, analyzeHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, ingestHandler)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/analyze-stock-brand-price-histories?symbol=12345678901", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// POST は取り込みハンドラーに届き、トークン未設定なので401になる
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/analyze-stock-brand-price-histories", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -2,25 +2,38 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/Code0716/stock-price-repository/config"
+	"github.com/Code0716/stock-price-repository/models"
 	pb "github.com/Code0716/stock-price-repository/pb"
 	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
 )
 
 // StockServiceServer implements pb.StockServiceServer
 type StockServiceServer struct {
 	pb.UnimplementedStockServiceServer
 	getHighVolumeStockBrandsUseCase usecase.GetHighVolumeStockBrandsUseCase
+	signalIngestInteractor          usecase.SignalIngestInteractor
+	signalIngestToken               string
 }
 
 func NewStockServiceServer(
 	getHighVolumeStockBrandsUseCase usecase.GetHighVolumeStockBrandsUseCase,
+	signalIngestInteractor usecase.SignalIngestInteractor,
 ) *StockServiceServer {
 	return &StockServiceServer{
 		getHighVolumeStockBrandsUseCase: getHighVolumeStockBrandsUseCase,
+		signalIngestInteractor:          signalIngestInteractor,
+		signalIngestToken:               config.GetSignalIngest().SignalIngestToken,
 	}
 }
 
@@ -68,3 +81,77 @@ func (s *StockServiceServer) GetHighVolumeStockBrands(
 		Pagination: pagination,
 	}, nil
 }
+
+// UpsertAnalyzeStockBrandPriceHistories 外部シグナルを analyze_stock_brand_price_history に取り込む（POST /analyze-stock-brand-price-histories と同じ）。
+// authorization メタデータに "Bearer <SIGNAL_INGEST_TOKEN>" が必要。
+func (s *StockServiceServer) UpsertAnalyzeStockBrandPriceHistories(
+	ctx context.Context,
+	req *pb.UpsertAnalyzeStockBrandPriceHistoriesRequest,
+) (*pb.UpsertAnalyzeStockBrandPriceHistoriesResponse, error) {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			authorization = v[0]
+		}
+	}
+	if !util.ValidBearerToken(authorization, s.signalIngestToken) {
+		return nil, status.Error(codes.Unauthenticated, "認証に失敗しました")
+	}
+
+	inputs, err := toSignalIngestInputs(req.GetHistories())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := s.signalIngestInteractor.IngestSignals(ctx, inputs)
+	if err != nil {
+		var ierr *models.SignalIngestError
+		if errors.As(err, &ierr) {
+			return nil, status.Error(codes.InvalidArgument, ierr.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to ingest signals: %v", err)
+	}
+
+	return &pb.UpsertAnalyzeStockBrandPriceHistoriesResponse{
+		Received: int32(result.Received),
+		Inserted: int32(result.Inserted),
+		Updated:  int32(result.Updated),
+	}, nil
+}
+
+// toSignalIngestInputs proto のシグナルをドメインの入力に変換する。memo・score の空文字と signal_rank の 0 は未指定として扱う。
+func toSignalIngestInputs(histories []*pb.AnalyzeStockBrandPriceHistoryInput) ([]*models.SignalIngestInput, error) {
+	inputs := make([]*models.SignalIngestInput, 0, len(histories))
+	for i, h := range histories {
+		date, err := time.ParseInLocation(util.DateLayout, h.GetDate(), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("histories[%d]: dateの日付形式が不正です (YYYY-MM-DD)", i)
+		}
+		tradePrice, err := decimal.NewFromString(h.GetTradePrice())
+		if err != nil {
+			return nil, fmt.Errorf("histories[%d]: tradePriceは数値である必要があります", i)
+		}
+		in := &models.SignalIngestInput{
+			TickerSymbol: h.GetTickerSymbol(),
+			Date:         date,
+			Method:       h.GetMethod(),
+			Action:       h.GetAction(),
+			TradePrice:   tradePrice,
+		}
+		if h.GetMemo() != "" {
+			in.Memo = util.ToPtrGenerics(h.GetMemo())
+		}
+		if h.GetScore() != "" {
+			score, err := decimal.NewFromString(h.GetScore())
+			if err != nil {
+				return nil, fmt.Errorf("histories[%d]: scoreは数値である必要があります", i)
+			}
+			in.Score = &score
+		}
+		if h.GetSignalRank() != 0 {
+			in.SignalRank = util.ToPtrGenerics(int(h.GetSignalRank()))
+		}
+		inputs = append(inputs, in)
+	}
+	return inputs, nil
+}
@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	mock_usecase "github.com/Code0716/stock-price-repository/mock/usecase"
@@ -344,3 +346,119 @@ func TestStockServiceServer_GetHighVolumeStockBrands(t *testing.T) {
 		})
 	}
 }
+
+func TestStockServiceServer_UpsertAnalyzeStockBrandPriceHistories(t *testing.T) {
+	const token = "secret"
+	authCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	validReq := &pb.UpsertAnalyzeStockBrandPriceHistoriesRequest{
+		Histories: []*pb.AnalyzeStockBrandPriceHistoryInput{
+			{
+				TickerSymbol: "7203",
+				Date:         "2024-05-10",
+				Method:       models.AnalyzeStockBrandPriceHistoryMethodFindMLRankedV1,
+				Action:       models.AnalyzeStockBrandPriceHistoryActionBuy,
+				TradePrice:   "2500.5",
+				Score:        "0.87",
+				SignalRank:   1,
+			},
+		},
+	}
+
+	tests := []struct {
+		name                   string
+		signalIngestInteractor func(ctrl *gomock.Controller) *mock_usecase.MockSignalIngestInteractor
+		ctx                    context.Context
+		req                    *pb.UpsertAnalyzeStockBrandPriceHistoriesRequest
+		want                   *pb.UpsertAnalyzeStockBrandPriceHistoriesResponse
+		errCode                codes.Code
+	}{
+		{
+			name: "正常系: 取り込み件数を返す",
+			signalIngestInteractor: func(ctrl *gomock.Controller) *mock_usecase.MockSignalIngestInteractor {
+				mock := mock_usecase.NewMockSignalIngestInteractor(ctrl)
+				mock.EXPECT().IngestSignals(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, inputs []*models.SignalIngestInput) (*models.SignalIngestResult, error) {
+						if len(inputs) != 1 {
+							t.Fatalf("IngestSignals() got %d inputs, want 1", len(inputs))
+						}
+						in := inputs[0]
+						if in.TickerSymbol != "7203" || !in.Date.Equal(time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)) ||
+							!in.TradePrice.Equal(decimal.RequireFromString("2500.5")) ||
+							in.Score == nil || !in.Score.Equal(decimal.RequireFromString("0.87")) ||
+							in.SignalRank == nil || *in.SignalRank != 1 || in.Memo != nil {
+							t.Errorf("IngestSignals() input = %+v", in)
+						}
+						return &models.SignalIngestResult{Received: 1, Updated: 1}, nil
+					})
+				return mock
+			},
+			ctx:  authCtx,
+			req:  validReq,
+			want: &pb.UpsertAnalyzeStockBrandPriceHistoriesResponse{Received: 1, Updated: 1},
+		},
+		{
+			name:    "異常系: authorization メタデータなし",
+			ctx:     context.Background(),
+			req:     validReq,
+			errCode: codes.Unauthenticated,
+		},
+		{
+			name: "異常系: tradePrice が数値でない",
+			ctx:  authCtx,
+			req: &pb.UpsertAnalyzeStockBrandPriceHistoriesRequest{
+				Histories: []*pb.AnalyzeStockBrandPriceHistoryInput{{TickerSymbol: "7203", Date: "2024-05-10", TradePrice: "abc"}},
+			},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "異常系: 内容の誤り",
+			signalIngestInteractor: func(ctrl *gomock.Controller) *mock_usecase.MockSignalIngestInteractor {
+				mock := mock_usecase.NewMockSignalIngestInteractor(ctrl)
+				mock.EXPECT().IngestSignals(gomock.Any(), gomock.Any()).
+					Return(nil, &models.SignalIngestError{Index: 0, Message: "銘柄コード 7203 は登録されていません"})
+				return mock
+			},
+			ctx:     authCtx,
+			req:     validReq,
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "異常系: ユースケースエラー",
+			signalIngestInteractor: func(ctrl *gomock.Controller) *mock_usecase.MockSignalIngestInteractor {
+				mock := mock_usecase.NewMockSignalIngestInteractor(ctrl)
+				mock.EXPECT().IngestSignals(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				return mock
+			},
+			ctx:     authCtx,
+			req:     validReq,
+			errCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := &StockServiceServer{signalIngestToken: token}
+			if tt.signalIngestInteractor != nil {
+				s.signalIngestInteractor = tt.signalIngestInteractor(ctrl)
+			}
+
+			got, err := s.UpsertAnalyzeStockBrandPriceHistories(tt.ctx, tt.req)
+			if tt.errCode != codes.OK {
+				st, ok := status.FromError(err)
+				if !ok || st.Code() != tt.errCode {
+					t.Errorf("UpsertAnalyzeStockBrandPriceHistories() error = %v, want code %v", err, tt.errCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpsertAnalyzeStockBrandPriceHistories() error = %v", err)
+			}
+			if got.Received != tt.want.Received || got.Inserted != tt.want.Inserted || got.Updated != tt.want.Updated {
+				t.Errorf("UpsertAnalyzeStockBrandPriceHistories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,15 +1,19 @@
 package commands
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/urfave/cli/v2"
 
+	"github.com/Code0716/stock-price-repository/domain_service"
 	"github.com/Code0716/stock-price-repository/models"
 	"github.com/Code0716/stock-price-repository/usecase"
 )
 
-// BacktestAllStocksCommand 全主要市場銘柄を全戦略でバックテストし、集計をRedisに保存するコマンド。
+// BacktestAllStocksCommand ユニバースの全銘柄を全戦略でバックテストし、実行記録をMySQLに、最新集計をRedisに保存するコマンド。
+// ユニバース（市場・業種・流動性・ウォッチリスト）と評価期間セグメント（日付範囲・日経平均レジーム）を指定できる。
 type BacktestAllStocksCommand struct {
 	interactor usecase.StrategyRankingInteractor
 }
@@ -21,7 +25,7 @@ func NewBacktestAllStocksCommand(interactor usecase.StrategyRankingInteractor) *
 func (c *BacktestAllStocksCommand) Command() *Command {
 	return &Command{
 		Name:  "backtest_all_stocks_v1",
-		Usage: "全主要市場銘柄を全戦略でバックテストし、戦略ランキングをRedisに保存する。",
+		Usage: "ユニバースの全銘柄を全戦略でバックテストし、戦略ランキングを全期間・セグメント別に実行履歴として保存する。",
 		Flags: []cli.Flag{
 			&cli.Float64Flag{
 				Name:  "take-profit",
@@ -38,11 +42,79 @@ func (c *BacktestAllStocksCommand) Command() *Command {
 				Value: 20,
 				Usage: "最大保有営業日数",
 			},
+			&cli.Float64Flag{
+				Name:  "commission",
+				Value: 0,
+				Usage: "片道手数料率（例: 0.0005）",
+			},
+			&cli.Float64Flag{
+				Name:  "slippage",
+				Value: 0,
+				Usage: "片道スリッページ率（例: 0.001）",
+			},
+			&cli.StringFlag{
+				Name:  "exit-mode",
+				Value: models.ExitModeCommon,
+				Usage: "イグジットモード: common（利確・損切り・最大保有日数のみ）/ signal（戦略固有の反転シグナルでも手仕舞い）",
+			},
+			&cli.StringFlag{
+				Name:  "entry-timing",
+				Value: models.EntryTimingClose,
+				Usage: "エントリー約定: close（シグナル当日終値）/ next_open（翌営業日始値）",
+			},
+			&cli.StringFlag{
+				Name:  "exit-fill",
+				Value: models.ExitFillClose,
+				Usage: "利確・損切りの約定: close（終値判定）/ intrabar（高値・安値で判定、窓開けは始値）",
+			},
+			&cli.StringFlag{
+				Name:  "intrabar-priority",
+				Value: models.IntrabarPriorityStopLoss,
+				Usage: "exit-fill=intrabar で同一日に利確・損切りの両方に達したときの優先: stop_loss / take_profit",
+			},
+			&cli.BoolFlag{
+				Name:  "tick-rounding",
+				Usage: "利確・損切り水準を東証の呼値単位に丸める",
+			},
+			&cli.Float64Flag{
+				Name:  "borrow-rate",
+				Value: 0,
+				Usage: "空売り戦略の貸株料（日率。例: 0.00003 ≈ 年1.1%）",
+			},
 			&cli.IntFlag{
 				Name:  "years",
 				Value: 5,
 				Usage: "バックテスト対象の直近N年",
 			},
+			&cli.StringSliceFlag{
+				Name:  "market-codes",
+				Usage: "対象の市場コード（カンマ区切り。省略時は主要市場 111,112,113）",
+			},
+			&cli.StringSliceFlag{
+				Name:  "sector33-codes",
+				Usage: "対象の33業種コード（カンマ区切り。省略時は全業種）",
+			},
+			&cli.Float64Flag{
+				Name:  "min-trading-value",
+				Value: 0,
+				Usage: "直近20営業日の平均売買代金（円）の下限。0 で無制限",
+			},
+			&cli.StringFlag{
+				Name:  "watchlist-name",
+				Usage: "ウォッチリスト名（--symbols の表示名）",
+			},
+			&cli.StringSliceFlag{
+				Name:  "symbols",
+				Usage: "対象の銘柄コード（カンマ区切り）。指定時はこの銘柄のみ",
+			},
+			&cli.StringSliceFlag{
+				Name:  "segment",
+				Usage: "評価期間セグメント name=YYYY-MM-DD..YYYY-MM-DD（複数指定可。同名を繰り返すと期間を追加）",
+			},
+			&cli.BoolFlag{
+				Name:  "nikkei-regimes",
+				Usage: "日経平均の終値と200日移動平均で nikkei_bull / nikkei_bear セグメントを追加する",
+			},
 			&cli.IntFlag{
 				Name:  "concurrency",
 				Value: 0,
@@ -58,13 +130,82 @@ func (c *BacktestAllStocksCommand) Action(ctx *cli.Context) error {
 		TakeProfit:  decimal.NewFromFloat(ctx.Float64("take-profit")),
 		StopLoss:    decimal.NewFromFloat(ctx.Float64("stop-loss")),
 		MaxHoldDays: ctx.Int("max-hold-days"),
+
+		CommissionRate:   decimal.NewFromFloat(ctx.Float64("commission")),
+		SlippageRate:     decimal.NewFromFloat(ctx.Float64("slippage")),
+		ExitMode:         ctx.String("exit-mode"),
+		EntryTiming:      ctx.String("entry-timing"),
+		ExitFill:         ctx.String("exit-fill"),
+		IntrabarPriority: ctx.String("intrabar-priority"),
+		TickRounding:     ctx.Bool("tick-rounding"),
+		BorrowRate:       decimal.NewFromFloat(ctx.Float64("borrow-rate")),
 	}
-	years := ctx.Int("years")
-	concurrency := ctx.Int("concurrency")
-	n, err := c.interactor.ComputeAndSaveStrategyRanking(ctx.Context, params, years, concurrency)
+	if params.ExitMode != models.ExitModeCommon && params.ExitMode != models.ExitModeSignal {
+		return errors.Errorf("unknown exit-mode: %s", params.ExitMode)
+	}
+	if params.EntryTiming != models.EntryTimingClose && params.EntryTiming != models.EntryTimingNextOpen {
+		return errors.Errorf("unknown entry-timing: %s", params.EntryTiming)
+	}
+	if params.ExitFill != models.ExitFillClose && params.ExitFill != models.ExitFillIntrabar {
+		return errors.Errorf("unknown exit-fill: %s", params.ExitFill)
+	}
+	if params.IntrabarPriority != models.IntrabarPriorityStopLoss && params.IntrabarPriority != models.IntrabarPriorityTakeProfit {
+		return errors.Errorf("unknown intrabar-priority: %s", params.IntrabarPriority)
+	}
+	if ctx.Float64("min-trading-value") < 0 {
+		return errors.New("min-trading-value must be >= 0")
+	}
+	segments, err := parseRankingSegments(ctx.StringSlice("segment"))
 	if err != nil {
+		return err
+	}
+	if err := domain_service.ValidateRankingSegments(segments); err != nil {
+		return errors.Wrap(err, "invalid segment")
+	}
+
+	req := models.StrategyRankingRequest{
+		Params: params,
+		Years:  ctx.Int("years"),
+		Universe: models.RankingUniverse{
+			MarketCodes:        ctx.StringSlice("market-codes"),
+			Sector33Codes:      ctx.StringSlice("sector33-codes"),
+			MinAvgTradingValue: decimal.NewFromFloat(ctx.Float64("min-trading-value")),
+			WatchlistName:      ctx.String("watchlist-name"),
+			Symbols:            ctx.StringSlice("symbols"),
+		},
+		Segments:      segments,
+		NikkeiRegimes: ctx.Bool("nikkei-regimes"),
+	}
+	concurrency := ctx.Int("concurrency")
+	if _, err := c.interactor.ComputeAndSaveStrategyRanking(ctx.Context, req, concurrency); err != nil {
 		return errors.Wrap(err, "ComputeAndSaveStrategyRanking error")
 	}
-	_ = n
 	return nil
 }
+
+// parseRankingSegments "name=YYYY-MM-DD..YYYY-MM-DD" 形式のセグメント指定を解析する。
+// 同じ name が複数回現れた場合は1つのセグメントの期間として追加する（指定順を保持）。
+func parseRankingSegments(specs []string) ([]models.RankingSegment, error) {
+	segments := make([]models.RankingSegment, 0, len(specs))
+	index := make(map[string]int, len(specs))
+	for _, spec := range specs {
+		name, rng, ok := strings.Cut(spec, "=")
+		from, to, ok2 := strings.Cut(rng, "..")
+		if !ok || !ok2 {
+			return nil, errors.Errorf("invalid segment %q: want name=YYYY-MM-DD..YYYY-MM-DD", spec)
+		}
+		r := models.RankingDateRange{From: strings.TrimSpace(from), To: strings.TrimSpace(to)}
+		name = strings.TrimSpace(name)
+		if i, exists := index[name]; exists {
+			segments[i].Ranges = append(segments[i].Ranges, r)
+			continue
+		}
+		index[name] = len(segments)
+		segments = append(segments, models.RankingSegment{
+			Name:   name,
+			Source: models.RankingSegmentSourceExplicit,
+			Ranges: []models.RankingDateRange{r},
+		})
+	}
+	return segments, nil
+}
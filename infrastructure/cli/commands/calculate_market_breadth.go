@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// CalculateMarketBreadthV1Command calculate_market_breadth_v1
+// 当日の日足取得後、主要市場全体・市場コード別の騰落指標を算出して保存する。
+type CalculateMarketBreadthV1Command struct {
+	interactor usecase.MarketBreadthInteractor
+}
+
+func NewCalculateMarketBreadthV1Command(interactor usecase.MarketBreadthInteractor) *CalculateMarketBreadthV1Command {
+	return &CalculateMarketBreadthV1Command{interactor: interactor}
+}
+
+func (c *CalculateMarketBreadthV1Command) Command() *Command {
+	return &Command{
+		Name:  "calculate_market_breadth_v1",
+		Usage: "当日の日足取得後、主要市場全体・市場コード別の騰落指標（騰落数・騰落レシオ・新高値/新安値・移動平均線上の比率・マクレラン）を算出して保存する。",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "この日から当日までを算出する (YYYY-MM-DD)。省略時は当日のみ。過去分の初回投入用",
+			},
+		},
+		Action: c.Action,
+	}
+}
+
+func (c *CalculateMarketBreadthV1Command) Action(ctx *cli.Context) error {
+	to := time.Now()
+	from := to
+	if s := ctx.String("from"); s != "" {
+		d, err := util.FormatStringToDate(s)
+		if err != nil {
+			return errors.Wrap(err, "invalid from format. use YYYY-MM-DD")
+		}
+		from = d
+	}
+
+	if _, err := c.interactor.CalculateMarketBreadth(ctx.Context, from, to); err != nil {
+		return errors.Wrap(err, "Action error")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+// CalculateRelativeStrengthV1Command calculate_relative_strength_v1
+// 当日の日足取得後、主要市場全銘柄の RS レーティング（TOPIX 比の相対的な強さ 1〜99）を算出して保存する。
+type CalculateRelativeStrengthV1Command struct {
+	interactor usecase.RelativeStrengthInteractor
+}
+
+func NewCalculateRelativeStrengthV1Command(interactor usecase.RelativeStrengthInteractor) *CalculateRelativeStrengthV1Command {
+	return &CalculateRelativeStrengthV1Command{interactor: interactor}
+}
+
+func (c *CalculateRelativeStrengthV1Command) Command() *Command {
+	return &Command{
+		Name:   "calculate_relative_strength_v1",
+		Usage:  "当日の日足取得後、主要市場全銘柄の RS レーティング（TOPIX 比の相対的な強さ 1〜99）を算出して保存する。",
+		Action: c.Action,
+	}
+}
+
+func (c *CalculateRelativeStrengthV1Command) Action(ctx *cli.Context) error {
+	if _, err := c.interactor.CalculateRelativeStrength(ctx.Context, time.Now()); err != nil {
+		return errors.Wrap(err, "Action error")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// ClassifyMarketRegimeV1Command classify_market_regime_v1
+// TOPIX・日経平均・騰落指標から当日の市場局面を判定して保存する。
+type ClassifyMarketRegimeV1Command struct {
+	interactor usecase.MarketRegimeInteractor
+}
+
+func NewClassifyMarketRegimeV1Command(interactor usecase.MarketRegimeInteractor) *ClassifyMarketRegimeV1Command {
+	return &ClassifyMarketRegimeV1Command{interactor: interactor}
+}
+
+func (c *ClassifyMarketRegimeV1Command) Command() *Command {
+	return &Command{
+		Name:  "classify_market_regime_v1",
+		Usage: "TOPIX・日経平均の移動平均と ATR、騰落指標から市場局面（trend_up / trend_down / range / high_volatility）を判定して保存する。",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "この日から当日までを判定する (YYYY-MM-DD)。省略時は当日のみ。過去分の初回投入用",
+			},
+		},
+		Action: c.Action,
+	}
+}
+
+func (c *ClassifyMarketRegimeV1Command) Action(ctx *cli.Context) error {
+	to := time.Now()
+	from := to
+	if s := ctx.String("from"); s != "" {
+		d, err := util.FormatStringToDate(s)
+		if err != nil {
+			return errors.Wrap(err, "invalid from format. use YYYY-MM-DD")
+		}
+		from = d
+	}
+
+	if _, err := c.interactor.ClassifyMarketRegimes(ctx.Context, from, to); err != nil {
+		return errors.Wrap(err, "Action error")
+	}
+	return nil
+}
@@ -1,11 +1,13 @@
 package commands
 
 import (
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
 
+	"github.com/Code0716/stock-price-repository/models"
 	"github.com/Code0716/stock-price-repository/usecase"
 )
 
@@ -39,6 +41,15 @@ func (c *CreateDailyStockPicksV1Command) Command() *Command {
 				Value: 0,
 				Usage: "ワーカー数（0 で CPU コア数）",
 			},
+			&cli.StringSliceFlag{
+				Name:  "regimes",
+				Usage: "スクリーニングを行う市場局面（カンマ区切り。trend_up, range, trend_down, high_volatility）。省略時は局面で絞らない",
+			},
+			&cli.BoolFlag{
+				Name:  "confluence",
+				Value: false,
+				Usage: "分析シグナルの合流スコアを因子に使うスコア設定（v1-confluence）もシャドーとして走らせる",
+			},
 			&cli.BoolFlag{
 				Name:  "force",
 				Value: false,
@@ -50,12 +61,21 @@ func (c *CreateDailyStockPicksV1Command) Command() *Command {
 }
 
 func (c *CreateDailyStockPicksV1Command) Action(ctx *cli.Context) error {
+	regimes := ctx.StringSlice("regimes")
+	for _, r := range regimes {
+		if !slices.Contains(models.MarketRegimes, r) {
+			return errors.Errorf("invalid regime %q. use trend_up, range, trend_down or high_volatility", r)
+		}
+	}
+
 	err := c.interactor.CreateDailyStockPicks(
 		ctx.Context,
 		time.Now(),
 		ctx.Int("top-n"),
 		ctx.Int("max-per-sector"),
 		ctx.Int("concurrency"),
+		regimes,
+		ctx.Bool("confluence"),
 		ctx.Bool("force"),
 	)
 	if err != nil {
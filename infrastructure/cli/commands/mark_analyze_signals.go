@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// MarkAnalyzeSignalsV1Command mark_analyze_signals_v1
+// 当日の日足取得後、建玉中の分析シグナルを最新終値で値洗いする（分割・併合調整済み）。N 営業日経過したシグナルのクローズも行える。
+type MarkAnalyzeSignalsV1Command struct {
+	interactor usecase.MarkAnalyzeSignalsInteractor
+}
+
+func NewMarkAnalyzeSignalsV1Command(interactor usecase.MarkAnalyzeSignalsInteractor) *MarkAnalyzeSignalsV1Command {
+	return &MarkAnalyzeSignalsV1Command{interactor: interactor}
+}
+
+func (c *MarkAnalyzeSignalsV1Command) Command() *Command {
+	return &Command{
+		Name:  "mark_analyze_signals_v1",
+		Usage: "当日の日足取得後、建玉中の分析シグナルの現在値・差額を最新終値で更新する（分割・併合調整済み）。",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "close-after-days",
+				Usage: "シグナル日から N 営業日目の終値でクローズし、出口価格と保有リターンを記録する。0でクローズしない",
+			},
+			&cli.StringFlag{
+				Name:  "date",
+				Usage: "値洗いの基準日 (YYYY-MM-DD)。この日以前の日足を使う。省略時は当日",
+			},
+		},
+		Action: c.Action,
+	}
+}
+
+func (c *MarkAnalyzeSignalsV1Command) Action(ctx *cli.Context) error {
+	asOf := time.Now()
+	if s := ctx.String("date"); s != "" {
+		d, err := util.FormatStringToDate(s)
+		if err != nil {
+			return errors.Wrap(err, "invalid date format. use YYYY-MM-DD")
+		}
+		asOf = d
+	}
+
+	result, err := c.interactor.MarkAnalyzeSignals(ctx.Context, asOf, ctx.Int("close-after-days"))
+	if err != nil {
+		return errors.Wrap(err, "Action error")
+	}
+	log.Printf("mark analyze signals: open=%d marked=%d closed=%d skipped=%d",
+		result.Open, result.Marked, result.Closed, result.Skipped)
+	return nil
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+// NotifyPaperPortfolioV1Command notify_paper_portfolio_v1
+// 模擬口座の直近1週間の損益を Slack に通知する（週末の run_paper_trading_v1 の後に週1回実行する）。
+type NotifyPaperPortfolioV1Command struct {
+	interactor usecase.NotifyPaperPortfolioInteractor
+}
+
+func NewNotifyPaperPortfolioV1Command(interactor usecase.NotifyPaperPortfolioInteractor) *NotifyPaperPortfolioV1Command {
+	return &NotifyPaperPortfolioV1Command{interactor: interactor}
+}
+
+func (c *NotifyPaperPortfolioV1Command) Command() *Command {
+	return &Command{
+		Name:  "notify_paper_portfolio_v1",
+		Usage: "模擬口座の直近1週間の損益を Slack に通知する。",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "account",
+				Value: models.PaperAccountDefaultName,
+				Usage: "模擬口座名",
+			},
+		},
+		Action: c.Action,
+	}
+}
+
+func (c *NotifyPaperPortfolioV1Command) Action(ctx *cli.Context) error {
+	err := c.interactor.NotifyPaperPortfolioWeekly(ctx.Context, ctx.String("account"))
+	if err != nil {
+		return errors.Wrap(err, "Action error")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"log"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+)
+
+// OptimizeStrategyParamsV1Command optimize_strategy_params_v1
+// 戦略のイグジット（・三角持ち合い）パラメータをインサンプルで探索し、ウォークフォワードで検証する。
+type OptimizeStrategyParamsV1Command struct {
+	interactor usecase.StrategyOptimizationInteractor
+}
+
+func NewOptimizeStrategyParamsV1Command(interactor usecase.StrategyOptimizationInteractor) *OptimizeStrategyParamsV1Command {
+	return &OptimizeStrategyParamsV1Command{interactor: interactor}
+}
+
+func (c *OptimizeStrategyParamsV1Command) Command() *Command {
+	return &Command{
+		Name:  "optimize_strategy_params_v1",
+		Usage: "戦略パラメータをグリッド/ランダム探索し、ウォークフォワード検証の結果をRedisに保存する。",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "strategy",
+				Required: true,
+				Usage:    "戦略ID（例: macd_bullish, triangle_formation）",
+			},
+			&cli.StringFlag{
+				Name:  "method",
+				Value: models.OptimizationMethodGrid,
+				Usage: "探索方式: grid / random",
+			},
+			&cli.IntFlag{
+				Name:  "samples",
+				Value: 20,
+				Usage: "method=random の抽出数",
+			},
+			&cli.Int64Flag{
+				Name:  "seed",
+				Value: 1,
+				Usage: "method=random の乱数シード",
+			},
+			&cli.IntFlag{
+				Name:  "years",
+				Value: 5,
+				Usage: "検証対象の直近N年",
+			},
+			&cli.IntFlag{
+				Name:  "in-sample-months",
+				Value: 24,
+				Usage: "1窓のインサンプル期間（月）",
+			},
+			&cli.IntFlag{
+				Name:  "out-of-sample-months",
+				Value: 6,
+				Usage: "1窓のアウトオブサンプル期間（月）",
+			},
+			&cli.Float64Flag{
+				Name:  "commission",
+				Value: 0,
+				Usage: "片道手数料率（例: 0.0005）",
+			},
+			&cli.Float64Flag{
+				Name:  "slippage",
+				Value: 0,
+				Usage: "片道スリッページ率（例: 0.001）",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Value: 0,
+				Usage: "ワーカー数（0 で CPU コア数）",
+			},
+		},
+		Action: c.Action,
+	}
+}
+
+func (c *OptimizeStrategyParamsV1Command) Action(ctx *cli.Context) error {
+	strategy := ctx.String("strategy")
+	if _, ok := domain_service.LookupStrategy(strategy); !ok {
+		return errors.Errorf("unknown strategy: %s", strategy)
+	}
+	method := ctx.String("method")
+	if method != models.OptimizationMethodGrid && method != models.OptimizationMethodRandom {
+		return errors.Errorf("unknown method: %s", method)
+	}
+
+	params := models.StrategyOptimizationParams{
+		Strategy:          strategy,
+		Method:            method,
+		Samples:           ctx.Int("samples"),
+		Seed:              ctx.Int64("seed"),
+		Years:             ctx.Int("years"),
+		InSampleMonths:    ctx.Int("in-sample-months"),
+		OutOfSampleMonths: ctx.Int("out-of-sample-months"),
+		CommissionRate:    decimal.NewFromFloat(ctx.Float64("commission")),
+		SlippageRate:      decimal.NewFromFloat(ctx.Float64("slippage")),
+	}
+	result, err := c.interactor.Optimize(ctx.Context, params, ctx.Int("concurrency"))
+	if err != nil {
+		return errors.Wrap(err, "Optimize error")
+	}
+	log.Printf("strategy optimization: jobId=%s windows=%d avgIS=%s avgOOS=%s degradation=%s",
+		result.JobID, len(result.Windows), result.AvgInSampleReturn, result.AvgOutOfSampleReturn, result.Degradation)
+	return nil
+}
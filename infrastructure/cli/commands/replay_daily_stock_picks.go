@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// ReplayDailyStockPicksV1Command replay_daily_stock_picks_v1
+// 過去の営業日ごとに当時の日足だけで買い候補スクリーニングを再現し、replay/<version> として保存・答え合わせする。
+type ReplayDailyStockPicksV1Command struct {
+	interactor usecase.ReplayDailyStockPicksInteractor
+}
+
+func NewReplayDailyStockPicksV1Command(interactor usecase.ReplayDailyStockPicksInteractor) *ReplayDailyStockPicksV1Command {
+	return &ReplayDailyStockPicksV1Command{interactor: interactor}
+}
+
+func (c *ReplayDailyStockPicksV1Command) Command() *Command {
+	return &Command{
+		Name:  "replay_daily_stock_picks_v1",
+		Usage: "過去の営業日ごとに当時の日足だけで買い候補スクリーニングを再現し、replay/<version> として保存・答え合わせする（Slack 通知はしない）。",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "from",
+				Required: true,
+				Usage:    "リプレイ開始日 (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "リプレイ終了日 (YYYY-MM-DD)。省略時は当日",
+			},
+			&cli.StringSliceFlag{
+				Name:  "score-versions",
+				Usage: "リプレイするスコア設定のバージョン（カンマ区切り）。省略時は登録済みの全設定",
+			},
+			&cli.IntFlag{
+				Name:  "top-n",
+				Value: 25,
+				Usage: "1日あたりの推奨銘柄数",
+			},
+			&cli.IntFlag{
+				Name:  "max-per-sector",
+				Value: 4,
+				Usage: "同一33業種からの最大採用数（0で無制限）",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Value: 0,
+				Usage: "ワーカー数（0 で CPU コア数）",
+			},
+			&cli.StringSliceFlag{
+				Name:  "regimes",
+				Usage: "スクリーニングを行う市場局面（カンマ区切り。trend_up, range, trend_down, high_volatility）。省略時は局面で絞らない",
+			},
+		},
+		Action: c.Action,
+	}
+}
+
+func (c *ReplayDailyStockPicksV1Command) Action(ctx *cli.Context) error {
+	now := time.Now()
+	from, err := util.FormatStringToDate(ctx.String("from"))
+	if err != nil {
+		return errors.Wrap(err, "invalid from format. use YYYY-MM-DD")
+	}
+	to := now
+	if s := ctx.String("to"); s != "" {
+		d, err := util.FormatStringToDate(s)
+		if err != nil {
+			return errors.Wrap(err, "invalid to format. use YYYY-MM-DD")
+		}
+		to = d
+	}
+
+	regimes := ctx.StringSlice("regimes")
+	for _, r := range regimes {
+		if !slices.Contains(models.MarketRegimes, r) {
+			return errors.Errorf("invalid regime %q. use trend_up, range, trend_down or high_volatility", r)
+		}
+	}
+
+	err = c.interactor.ReplayDailyStockPicks(
+		ctx.Context,
+		now,
+		from,
+		to,
+		ctx.StringSlice("score-versions"),
+		ctx.Int("top-n"),
+		ctx.Int("max-per-sector"),
+		ctx.Int("concurrency"),
+		regimes,
+	)
+	if err != nil {
+		return errors.Wrap(err, "Action error")
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/urfave/cli/v2"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/usecase"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// RunPaperTradingV1Command run_paper_trading_v1
+// 当日の日足取得後、日次推奨に追随する模擬口座を最新営業日まで進める（寄りで買付・手仕舞い判定・終値で値洗い・NAV 保存）。
+type RunPaperTradingV1Command struct {
+	interactor usecase.RunPaperTradingInteractor
+}
+
+func NewRunPaperTradingV1Command(interactor usecase.RunPaperTradingInteractor) *RunPaperTradingV1Command {
+	return &RunPaperTradingV1Command{interactor: interactor}
+}
+
+func (c *RunPaperTradingV1Command) Command() *Command {
+	return &Command{
+		Name:  "run_paper_trading_v1",
+		Usage: "当日の日足取得後、日次推奨に追随する模擬口座を最新営業日まで進める。売買ルールのフラグは口座の初回作成時だけ使う。",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "account",
+				Value: models.PaperAccountDefaultName,
+				Usage: "模擬口座名",
+			},
+			&cli.StringFlag{
+				Name:  "score-version",
+				Value: domain_service.DailyPickScoreVersion,
+				Usage: "追随する推奨のスコア設定バージョン（replay/... のリプレイ推奨も可）",
+			},
+			&cli.IntFlag{
+				Name:  "top-n",
+				Value: 10,
+				Usage: "1日に買う推奨の上位件数",
+			},
+			&cli.Int64Flag{
+				Name:  "budget-per-pick",
+				Value: 500_000,
+				Usage: "1銘柄あたりの予算（円）。100株単位で予算内の最大株数を買う",
+			},
+			&cli.IntFlag{
+				Name:  "holding-days",
+				Value: 5,
+				Usage: "最長保有営業日数（買付日を1日目とし、N日目の終値で売却）",
+			},
+			&cli.Float64Flag{
+				Name:  "take-profit",
+				Usage: "利確幅（0.1 = +10%）。0で使わない",
+			},
+			&cli.Float64Flag{
+				Name:  "stop-loss",
+				Usage: "損切り幅（0.05 = -5%）。0で使わない",
+			},
+			&cli.Int64Flag{
+				Name:  "initial-cash",
+				Value: 30_000_000,
+				Usage: "初期資金（円）",
+			},
+			&cli.StringFlag{
+				Name:  "start",
+				Usage: "売買を始める日 (YYYY-MM-DD)。省略時は当日",
+			},
+		},
+		Action: c.Action,
+	}
+}
+
+func (c *RunPaperTradingV1Command) Action(ctx *cli.Context) error {
+	account := models.PaperAccount{
+		Name:          ctx.String("account"),
+		ScoreVersion:  ctx.String("score-version"),
+		TopN:          ctx.Int("top-n"),
+		BudgetPerPick: decimal.NewFromInt(ctx.Int64("budget-per-pick")),
+		HoldingDays:   ctx.Int("holding-days"),
+		TakeProfitPct: positiveDecimalPtr(ctx.Float64("take-profit")),
+		StopLossPct:   positiveDecimalPtr(ctx.Float64("stop-loss")),
+		InitialCash:   decimal.NewFromInt(ctx.Int64("initial-cash")),
+	}
+	if s := ctx.String("start"); s != "" {
+		d, err := util.FormatStringToDate(s)
+		if err != nil {
+			return errors.Wrap(err, "invalid start format. use YYYY-MM-DD")
+		}
+		account.StartDate = d
+	}
+
+	err := c.interactor.RunPaperTrading(ctx.Context, time.Now(), account)
+	if err != nil {
+		return errors.Wrap(err, "Action error")
+	}
+	return nil
+}
+
+// positiveDecimalPtr 正の値なら decimal のポインタ、0以下なら nil（未指定扱い）を返す。
+func positiveDecimalPtr(v float64) *decimal.Decimal {
+	if v <= 0 {
+		return nil
+	}
+	d := decimal.NewFromFloat(v)
+	return &d
+}
@@ -40,6 +40,14 @@ func NewRunner(
 	createQuizDailyUniverseV1Command *commands.CreateQuizDailyUniverseV1Command,
 	evaluateDailyStockPicksV1Command *commands.EvaluateDailyStockPicksV1Command,
 	createDailyStockPicksV1Command *commands.CreateDailyStockPicksV1Command,
+	optimizeStrategyParamsV1Command *commands.OptimizeStrategyParamsV1Command,
+	calculateRelativeStrengthV1Command *commands.CalculateRelativeStrengthV1Command,
+	calculateMarketBreadthV1Command *commands.CalculateMarketBreadthV1Command,
+	classifyMarketRegimeV1Command *commands.ClassifyMarketRegimeV1Command,
+	replayDailyStockPicksV1Command *commands.ReplayDailyStockPicksV1Command,
+	runPaperTradingV1Command *commands.RunPaperTradingV1Command,
+	notifyPaperPortfolioV1Command *commands.NotifyPaperPortfolioV1Command,
+	markAnalyzeSignalsV1Command *commands.MarkAnalyzeSignalsV1Command,
 	indexInteractor usecase.IndexInteractor,
 	slackAPIClient gateway.SlackAPIClient,
 ) *Runner {
@@ -66,6 +74,22 @@ func NewRunner(
 			evaluateDailyStockPicksV1Command.Command(),
 			// create_daily_stock_picks_v1 も create_daily_stock_price_v1 の後に実行すること（当日引け値の確定が前提）。
 			createDailyStockPicksV1Command.Command(),
+			optimizeStrategyParamsV1Command.Command(),
+			// calculate_relative_strength_v1 は create_daily_stock_price_v1 と create_nikkei_and_dji_historical_data_v1（TOPIX）の後に実行すること。
+			calculateRelativeStrengthV1Command.Command(),
+			// calculate_market_breadth_v1 も create_daily_stock_price_v1 の後に実行すること（当日の騰落は当日引け値の確定が前提）。
+			calculateMarketBreadthV1Command.Command(),
+			// classify_market_regime_v1 は create_nikkei_and_dji_historical_data_v1（TOPIX）と calculate_market_breadth_v1 の後、
+			// create_daily_stock_picks_v1 より先に実行すること（局面ゲートが当日の局面を参照する）。
+			classifyMarketRegimeV1Command.Command(),
+			// 過去日の買い候補スクリーニングを再現（リプレイ）
+			replayDailyStockPicksV1Command.Command(),
+			// 当日の日足取得後、日次推奨に追随する模擬口座を最新営業日まで進める
+			runPaperTradingV1Command.Command(),
+			// 模擬口座の直近1週間の損益を Slack に通知する
+			notifyPaperPortfolioV1Command.Command(),
+			// mark_analyze_signals_v1 は create_daily_stock_price_v1 の後に実行すること（当日引け値で値洗いする）。
+			markAnalyzeSignalsV1Command.Command(),
 		},
 		indexInteractor: indexInteractor,
 		slackAPIClient:  slackAPIClient,
@@ -10,12 +10,16 @@ import (
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	genModel "github.com/Code0716/stock-price-repository/infrastructure/database/gen_model"
 	genQuery "github.com/Code0716/stock-price-repository/infrastructure/database/gen_query"
 	"github.com/Code0716/stock-price-repository/models"
 	"github.com/Code0716/stock-price-repository/repositories"
 )
 
+const analyzeStockBrandPriceHistoryBatchSize = 500
+
 type AnalyzeStockBrandPriceHistoryRepositoryImpl struct {
 	query *genQuery.Query
 	db    *gorm.DB
@@ -29,18 +33,53 @@ func NewAnalyzeStockBrandPriceHistoryRepositoryImpl(db *gorm.DB) repositories.An
 }
 
 type analyzeStockBrandPriceHistoryRow struct {
-	ID           string
-	StockBrandID string
-	Name         string
-	TickerSymbol string
-	TradePrice   float64
-	CurrentPrice float64
-	Action       string
-	Method       string
-	Memo         *string
-	Score        *float64
-	SignalRank   *int32
-	CreatedAt    time.Time
+	ID            string
+	StockBrandID  string
+	Name          string
+	TickerSymbol  string
+	TradePrice    float64
+	CurrentPrice  float64
+	Action        string
+	Method        string
+	Memo          *string
+	Score         *float64
+	SignalRank    *int32
+	MarkedAt      *time.Time
+	ClosedAt      *time.Time
+	ExitPrice     *float64
+	HoldingReturn *float64
+	CreatedAt     time.Time
+}
+
+// analyzeCurrentPriceExpr 現在値。値洗い済み（分割・併合調整済み）の current_price を優先し、
+// 値洗い前のシグナルは最新日足の close_price、それも無ければ trade_price にフォールバックする。
+const analyzeCurrentPriceExpr = "COALESCE(h.current_price, d.close_price, h.trade_price)"
+
+// toDomainModel 一覧取得の行をドメインモデルに変換する
+func (row *analyzeStockBrandPriceHistoryRow) toDomainModel() *models.AnalyzeStockBrandPriceHistory {
+	h := models.NewAnalyzeStockBrandPriceHistory(
+		row.ID,
+		row.StockBrandID,
+		row.Name,
+		row.TickerSymbol,
+		decimal.NewFromFloat(row.TradePrice),
+		decimal.NewFromFloat(row.CurrentPrice),
+		row.Action,
+		row.Method,
+		row.Memo,
+		row.CreatedAt,
+	)
+	h.MarkedAt = row.MarkedAt
+	h.ClosedAt = row.ClosedAt
+	if row.ExitPrice != nil {
+		exitPrice := decimal.NewFromFloat(*row.ExitPrice)
+		h.ExitPrice = &exitPrice
+	}
+	if row.HoldingReturn != nil {
+		holdingReturn := decimal.NewFromFloat(*row.HoldingReturn)
+		h.HoldingReturn = &holdingReturn
+	}
+	return h
 }
 
 type signalHistoryRow struct {
@@ -78,16 +117,16 @@ func orderClause(sortBy, order string) string {
 
 	switch sortBy {
 	case models.AnalyzeStockBrandPriceHistorySortByProfit:
-		return fmt.Sprintf("(COALESCE(d.close_price, h.trade_price) - h.trade_price) %s, h.id %s", dir, dir)
+		return fmt.Sprintf("(%s - h.trade_price) %s, h.id %s", analyzeCurrentPriceExpr, dir, dir)
 	case models.AnalyzeStockBrandPriceHistorySortByProfitRate:
-		return fmt.Sprintf("((COALESCE(d.close_price, h.trade_price) - h.trade_price) / h.trade_price) %s, h.id %s", dir, dir)
+		return fmt.Sprintf("((%s - h.trade_price) / h.trade_price) %s, h.id %s", analyzeCurrentPriceExpr, dir, dir)
 	default:
 		return fmt.Sprintf("h.created_at %s, h.id %s", dir, dir)
 	}
 }
 
 // FindWithFilter 条件に一致する分析履歴を取得する
-// current_price は stock_brands_daily_price の最新 close_price を JOIN して都度算出する
+// current_price は値洗い済みの値を使い、値洗い前のシグナルは stock_brands_daily_price の最新 close_price を JOIN して都度算出する
 func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) FindWithFilter(ctx context.Context, filter *models.AnalyzeStockBrandPriceHistoryFilter) ([]*models.AnalyzeStockBrandPriceHistory, error) {
 	db := ai.db.WithContext(ctx).
 		Table("analyze_stock_brand_price_history AS h").
@@ -97,10 +136,14 @@ func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) FindWithFilter(ctx contex
 			COALESCE(s.name, '') AS name,
 			h.ticker_symbol,
 			h.trade_price,
-			COALESCE(d.close_price, h.trade_price) AS current_price,
+			` + analyzeCurrentPriceExpr + ` AS current_price,
 			h.action,
 			h.method,
 			h.memo,
+			h.marked_at,
+			h.closed_at,
+			h.exit_price,
+			h.holding_return,
 			h.created_at
 		`).
 		Joins("LEFT JOIN stock_brand AS s ON s.id = h.stock_brand_id AND s.deleted_at IS NULL").
@@ -131,18 +174,7 @@ func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) FindWithFilter(ctx contex
 
 	histories := make([]*models.AnalyzeStockBrandPriceHistory, 0, len(rows))
 	for _, row := range rows {
-		histories = append(histories, models.NewAnalyzeStockBrandPriceHistory(
-			row.ID,
-			row.StockBrandID,
-			row.Name,
-			row.TickerSymbol,
-			decimal.NewFromFloat(row.TradePrice),
-			decimal.NewFromFloat(row.CurrentPrice),
-			row.Action,
-			row.Method,
-			row.Memo,
-			row.CreatedAt,
-		))
+		histories = append(histories, row.toDomainModel())
 	}
 
 	return histories, nil
@@ -221,10 +253,14 @@ func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) FindByDates(ctx context.C
 			COALESCE(s.name, '') AS name,
 			h.ticker_symbol,
 			h.trade_price,
-			COALESCE(d.close_price, h.trade_price) AS current_price,
+			`+analyzeCurrentPriceExpr+` AS current_price,
 			h.action,
 			h.method,
 			h.memo,
+			h.marked_at,
+			h.closed_at,
+			h.exit_price,
+			h.holding_return,
 			h.created_at
 		`).
 		Joins("LEFT JOIN stock_brand AS s ON s.id = h.stock_brand_id AND s.deleted_at IS NULL").
@@ -245,18 +281,7 @@ func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) FindByDates(ctx context.C
 
 	histories := make([]*models.AnalyzeStockBrandPriceHistory, 0, len(rows))
 	for _, row := range rows {
-		histories = append(histories, models.NewAnalyzeStockBrandPriceHistory(
-			row.ID,
-			row.StockBrandID,
-			row.Name,
-			row.TickerSymbol,
-			decimal.NewFromFloat(row.TradePrice),
-			decimal.NewFromFloat(row.CurrentPrice),
-			row.Action,
-			row.Method,
-			row.Memo,
-			row.CreatedAt,
-		))
+		histories = append(histories, row.toDomainModel())
 	}
 
 	return histories, nil
@@ -344,6 +369,184 @@ func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) FindMultipleSignals(ctx c
 	return stocks, nil
 }
 
+// UpsertByKey 一意キー (method, ticker_symbol, created_at, action) が衝突した行は ID を残して上書きし、無い行は追加する。
+// trade_price が変わった行は、旧価格を基準にした値洗い（current_price / price_difference / marked_at）を NULL に戻す。
+// 戻り値の上書き件数は、書き込み前に同じキーの既存行を引いて数える。
+func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) UpsertByKey(ctx context.Context, histories []*models.AnalyzeStockBrandPriceHistory) (int, error) {
+	tx := TxOrDefault(ctx, ai.query)
+
+	if len(histories) == 0 {
+		return 0, nil
+	}
+
+	symbols := make([]string, 0, len(histories))
+	methods := make([]string, 0, len(histories))
+	dates := make([]time.Time, 0, len(histories))
+	for _, h := range histories {
+		symbols = append(symbols, h.TickerSymbol)
+		methods = append(methods, h.Method)
+		dates = append(dates, dateOnlyOf(h.CreatedAt))
+	}
+
+	q := tx.AnalyzeStockBrandPriceHistory
+	existing, err := q.WithContext(ctx).
+		Where(q.TickerSymbol.In(symbols...), q.Method.In(methods...), q.CreatedAt.In(dates...)).
+		Find()
+	if err != nil {
+		return 0, errors.Wrap(err, "AnalyzeStockBrandPriceHistoryRepositoryImpl.UpsertByKey find error")
+	}
+	existingKeys := make(map[string]struct{}, len(existing))
+	for _, e := range existing {
+		if e.CreatedAt == nil {
+			continue
+		}
+		existingKeys[analyzeStockBrandPriceHistoryKey(e.Method, e.TickerSymbol, *e.CreatedAt, e.Action)] = struct{}{}
+	}
+
+	updated := 0
+	rows := make([]*genModel.AnalyzeStockBrandPriceHistory, 0, len(histories))
+	for _, h := range histories {
+		if _, ok := existingKeys[analyzeStockBrandPriceHistoryKey(h.Method, h.TickerSymbol, h.CreatedAt, h.Action)]; ok {
+			updated++
+		}
+		rows = append(rows, ai.convertToDBModel(h))
+	}
+
+	// ON DUPLICATE KEY UPDATE は左から順に代入されるため、値洗いの無効化は trade_price を上書きする前に旧価格と比べる
+	clearIfRepriced := func(column string) clause.Assignment {
+		return clause.Assignment{
+			Column: clause.Column{Name: column},
+			Value:  gorm.Expr("IF(trade_price <=> VALUES(trade_price), " + column + ", NULL)"),
+		}
+	}
+	doUpdates := clause.Set{
+		clearIfRepriced("current_price"),
+		clearIfRepriced("price_difference"),
+		clearIfRepriced("marked_at"),
+	}
+	doUpdates = append(doUpdates, clause.AssignmentColumns([]string{
+		"stock_brand_id",
+		"trade_price",
+		"memo",
+		"score",
+		"signal_rank",
+	})...)
+
+	if err := q.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "method"},
+				{Name: "ticker_symbol"},
+				{Name: "created_at"},
+				{Name: "action"},
+			},
+			DoUpdates: doUpdates,
+		}).
+		CreateInBatches(rows, analyzeStockBrandPriceHistoryBatchSize); err != nil {
+		return 0, errors.Wrap(err, "AnalyzeStockBrandPriceHistoryRepositoryImpl.UpsertByKey error")
+	}
+	return updated, nil
+}
+
+func analyzeStockBrandPriceHistoryKey(method, tickerSymbol string, date time.Time, action string) string {
+	return method + "\x00" + tickerSymbol + "\x00" + date.Format("2006-01-02") + "\x00" + action
+}
+
+func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) convertToDBModel(h *models.AnalyzeStockBrandPriceHistory) *genModel.AnalyzeStockBrandPriceHistory {
+	createdAt := dateOnlyOf(h.CreatedAt)
+	row := &genModel.AnalyzeStockBrandPriceHistory{
+		ID:           h.ID,
+		StockBrandID: h.StockBrandID,
+		TickerSymbol: h.TickerSymbol,
+		TradePrice:   h.TradePrice.InexactFloat64(),
+		Action:       h.Action,
+		Method:       h.Method,
+		Memo:         h.Memo,
+		CreatedAt:    &createdAt,
+	}
+	if h.Score != nil {
+		score := h.Score.InexactFloat64()
+		row.Score = &score
+	}
+	if h.SignalRank != nil {
+		rank := int32(*h.SignalRank)
+		row.SignalRank = &rank
+	}
+	return row
+}
+
+// ListOpen クローズしていないシグナルを created_at, id 昇順で取得する（値洗い用）
+func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) ListOpen(ctx context.Context) ([]*models.AnalyzeStockBrandPriceHistory, error) {
+	tx := TxOrDefault(ctx, ai.query)
+	q := tx.AnalyzeStockBrandPriceHistory
+
+	rows, err := q.WithContext(ctx).
+		Where(q.ClosedAt.IsNull()).
+		Order(q.CreatedAt, q.ID).
+		Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "AnalyzeStockBrandPriceHistoryRepositoryImpl.ListOpen error")
+	}
+
+	histories := make([]*models.AnalyzeStockBrandPriceHistory, 0, len(rows))
+	for _, row := range rows {
+		h := &models.AnalyzeStockBrandPriceHistory{
+			ID:           row.ID,
+			StockBrandID: row.StockBrandID,
+			TickerSymbol: row.TickerSymbol,
+			TradePrice:   decimal.NewFromFloat(row.TradePrice),
+			Action:       row.Action,
+			Method:       row.Method,
+			Memo:         row.Memo,
+			MarkedAt:     row.MarkedAt,
+		}
+		if row.CreatedAt != nil {
+			h.CreatedAt = *row.CreatedAt
+		}
+		if row.CurrentPrice != nil {
+			h.CurrentPrice = decimal.NewFromFloat(*row.CurrentPrice)
+		}
+		if row.PriceDifference != nil {
+			h.PriceDifference = decimal.NewFromFloat(*row.PriceDifference)
+		}
+		histories = append(histories, h)
+	}
+	return histories, nil
+}
+
+// UpdateMarks 値洗い結果を id ごとに書き込む。クローズ列は設定されているときだけ更新する。
+func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) UpdateMarks(ctx context.Context, marks []*models.AnalyzeStockBrandPriceHistoryMark) error {
+	tx := TxOrDefault(ctx, ai.query)
+	q := tx.AnalyzeStockBrandPriceHistory
+
+	for _, m := range marks {
+		currentPrice := m.CurrentPrice.InexactFloat64()
+		priceDifference := m.PriceDifference.InexactFloat64()
+		markedAt := dateOnlyOf(m.MarkedAt)
+		row := &genModel.AnalyzeStockBrandPriceHistory{
+			CurrentPrice:    &currentPrice,
+			PriceDifference: &priceDifference,
+			MarkedAt:        &markedAt,
+		}
+		if m.ClosedAt != nil {
+			closedAt := dateOnlyOf(*m.ClosedAt)
+			row.ClosedAt = &closedAt
+		}
+		if m.ExitPrice != nil {
+			exitPrice := m.ExitPrice.InexactFloat64()
+			row.ExitPrice = &exitPrice
+		}
+		if m.HoldingReturn != nil {
+			holdingReturn := m.HoldingReturn.InexactFloat64()
+			row.HoldingReturn = &holdingReturn
+		}
+		if _, err := q.WithContext(ctx).Where(q.ID.Eq(m.ID)).Updates(row); err != nil {
+			return errors.Wrap(err, "AnalyzeStockBrandPriceHistoryRepositoryImpl.UpdateMarks error")
+		}
+	}
+	return nil
+}
+
 // DeleteByStockBrandIDs 銘柄IDと一致したものを削除する
 func (ai *AnalyzeStockBrandPriceHistoryRepositoryImpl) DeleteByStockBrandIDs(ctx context.Context, ids []string) error {
 	tx := TxOrDefault(ctx, ai.query)
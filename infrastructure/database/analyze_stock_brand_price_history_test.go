@@ -361,3 +361,153 @@ func TestAnalyzeStockBrandPriceHistoryRepositoryImpl_FindWithFilter_SortByProfit
 		assert.Len(t, results, 3)
 	})
 }
+
+func TestAnalyzeStockBrandPriceHistoryRepositoryImpl_UpsertByKey(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewAnalyzeStockBrandPriceHistoryRepositoryImpl(db)
+	stockBrandRepo := NewStockBrandRepositoryImpl(db)
+	ctx := context.Background()
+	now := time.Now().Truncate(24 * time.Hour)
+
+	err := stockBrandRepo.UpsertStockBrands(ctx, []*models.StockBrand{{
+		ID:           "brand-upsert-1",
+		TickerSymbol: "4001",
+		Name:         "Upsert Test Brand",
+		MarketCode:   "111",
+		MarketName:   "Prime",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}})
+	require.NoError(t, err)
+
+	newHistory := func(id string, action string, price int64) *models.AnalyzeStockBrandPriceHistory {
+		return models.NewAnalyzeStockBrandPriceHistory(
+			id, "brand-upsert-1", "", "4001",
+			decimal.NewFromInt(price), decimal.NewFromInt(price),
+			action, models.AnalyzeStockBrandPriceHistoryMethodFindMLRankedV1, nil, now,
+		)
+	}
+
+	updated, err := repo.UpsertByKey(ctx, []*models.AnalyzeStockBrandPriceHistory{
+		newHistory("upsert-uuid-1", models.AnalyzeStockBrandPriceHistoryActionBuy, 1000),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, updated)
+
+	// 同じキーの再送は既存行を上書きし、action が違う行は追加される
+	updated, err = repo.UpsertByKey(ctx, []*models.AnalyzeStockBrandPriceHistory{
+		newHistory("upsert-uuid-2", models.AnalyzeStockBrandPriceHistoryActionBuy, 1100),
+		newHistory("upsert-uuid-3", models.AnalyzeStockBrandPriceHistoryActionSell, 1200),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+
+	results, err := repo.FindWithFilter(ctx, &models.AnalyzeStockBrandPriceHistoryFilter{TickerSymbol: "4001"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	byID := make(map[string]*models.AnalyzeStockBrandPriceHistory, len(results))
+	for _, r := range results {
+		byID[r.ID] = r
+	}
+	require.Contains(t, byID, "upsert-uuid-1")
+	assert.True(t, byID["upsert-uuid-1"].TradePrice.Equal(decimal.NewFromInt(1100)))
+	assert.Contains(t, byID, "upsert-uuid-3")
+
+	t.Run("同じ trade_price の再送は値洗いを残し、trade_price が変われば値洗いを消す", func(t *testing.T) {
+		require.NoError(t, repo.UpdateMarks(ctx, []*models.AnalyzeStockBrandPriceHistoryMark{
+			{ID: "upsert-uuid-1", CurrentPrice: decimal.NewFromInt(1150), PriceDifference: decimal.NewFromInt(50), MarkedAt: now},
+		}))
+		markedAt := func() *time.Time {
+			var row struct{ MarkedAt *time.Time }
+			require.NoError(t, db.Raw("SELECT marked_at FROM analyze_stock_brand_price_history WHERE id = ?", "upsert-uuid-1").Scan(&row).Error)
+			return row.MarkedAt
+		}
+
+		_, err := repo.UpsertByKey(ctx, []*models.AnalyzeStockBrandPriceHistory{
+			newHistory("upsert-uuid-4", models.AnalyzeStockBrandPriceHistoryActionBuy, 1100),
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, markedAt())
+
+		_, err = repo.UpsertByKey(ctx, []*models.AnalyzeStockBrandPriceHistory{
+			newHistory("upsert-uuid-5", models.AnalyzeStockBrandPriceHistoryActionBuy, 1300),
+		})
+		require.NoError(t, err)
+		assert.Nil(t, markedAt())
+
+		var row struct {
+			CurrentPrice    *float64
+			PriceDifference *float64
+		}
+		require.NoError(t, db.Raw("SELECT current_price, price_difference FROM analyze_stock_brand_price_history WHERE id = ?", "upsert-uuid-1").Scan(&row).Error)
+		assert.Nil(t, row.CurrentPrice)
+		assert.Nil(t, row.PriceDifference)
+	})
+}
+
+func TestAnalyzeStockBrandPriceHistoryRepositoryImpl_ListOpenAndUpdateMarks(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewAnalyzeStockBrandPriceHistoryRepositoryImpl(db)
+	stockBrandRepo := NewStockBrandRepositoryImpl(db)
+	ctx := context.Background()
+	now := time.Now().Truncate(24 * time.Hour)
+
+	err := stockBrandRepo.UpsertStockBrands(ctx, []*models.StockBrand{{
+		ID:           "brand-mark-1",
+		TickerSymbol: "5001",
+		Name:         "Mark Test Brand",
+		MarketCode:   "111",
+		MarketName:   "Prime",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}})
+	require.NoError(t, err)
+
+	// (method, ticker_symbol, created_at, action) は一意なので手法を変えて2件入れる
+	for id, method := range map[string]string{
+		"mark-uuid-1": "analyze_stock_brand_price_by_sector: 25日",
+		"mark-uuid-2": "analyze_stock_brand_price_by_sector: 75日",
+	} {
+		err = db.Exec(
+			"INSERT INTO analyze_stock_brand_price_history (id, stock_brand_id, ticker_symbol, trade_price, action, method, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			id, "brand-mark-1", "5001", 1000.0, "Buy", method, now.Format("2006-01-02"),
+		).Error
+		require.NoError(t, err)
+	}
+
+	open, err := repo.ListOpen(ctx)
+	require.NoError(t, err)
+	assert.Len(t, open, 2)
+
+	exitPrice := decimal.NewFromInt(1100)
+	holdingReturn := decimal.RequireFromString("0.1")
+	err = repo.UpdateMarks(ctx, []*models.AnalyzeStockBrandPriceHistoryMark{
+		{ID: "mark-uuid-1", CurrentPrice: decimal.NewFromInt(1050), PriceDifference: decimal.NewFromInt(50), MarkedAt: now},
+		{ID: "mark-uuid-2", CurrentPrice: exitPrice, PriceDifference: decimal.NewFromInt(100), MarkedAt: now, ClosedAt: &now, ExitPrice: &exitPrice, HoldingReturn: &holdingReturn},
+	})
+	require.NoError(t, err)
+
+	// クローズしたシグナルは ListOpen から外れる
+	open, err = repo.ListOpen(ctx)
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	assert.Equal(t, "mark-uuid-1", open[0].ID)
+
+	// 保存した値洗い結果が日足より優先して返る
+	results, err := repo.FindWithFilter(ctx, &models.AnalyzeStockBrandPriceHistoryFilter{TickerSymbol: "5001"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	byID := make(map[string]*models.AnalyzeStockBrandPriceHistory, len(results))
+	for _, r := range results {
+		byID[r.ID] = r
+	}
+	assert.True(t, byID["mark-uuid-1"].CurrentPrice.Equal(decimal.NewFromInt(1050)))
+	assert.Nil(t, byID["mark-uuid-1"].ClosedAt)
+	require.NotNil(t, byID["mark-uuid-2"].ClosedAt)
+	require.NotNil(t, byID["mark-uuid-2"].HoldingReturn)
+	assert.True(t, byID["mark-uuid-2"].HoldingReturn.Equal(holdingReturn))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 
 	"github.com/Code0716/stock-price-repository/infrastructure/database/gen_model"
@@ -44,3 +46,32 @@ func (r *AppliedStockConsolidationsHistoryRepositoryImpl) Create(ctx context.Con
 	}
 	return tx.AppliedStockConsolidationsHistory.WithContext(ctx).Create(dbModel)
 }
+
+func (r *AppliedStockConsolidationsHistoryRepositoryImpl) ListByConsolidationDateRange(ctx context.Context, from, to time.Time) ([]*models.AppliedStockConsolidationHistory, error) {
+	tx := TxOrDefault(ctx, r.query)
+	h := tx.AppliedStockConsolidationsHistory
+
+	rows, err := h.WithContext(ctx).
+		Where(h.ConsolidationDate.Gte(dateOnlyOf(from))).
+		Where(h.ConsolidationDate.Lte(dateOnlyOf(to))).
+		Order(h.ConsolidationDate, h.Symbol).
+		Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "ListByConsolidationDateRange error")
+	}
+
+	out := make([]*models.AppliedStockConsolidationHistory, 0, len(rows))
+	for _, row := range rows {
+		m := &models.AppliedStockConsolidationHistory{
+			ID:                row.ID,
+			Symbol:            row.Symbol,
+			ConsolidationDate: row.ConsolidationDate,
+			Ratio:             decimal.NewFromFloat(row.Ratio),
+		}
+		if row.AppliedAt != nil {
+			m.AppliedAt = *row.AppliedAt
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 
 	"github.com/Code0716/stock-price-repository/infrastructure/database/gen_model"
@@ -44,3 +46,32 @@ func (r *AppliedStockSplitsHistoryRepositoryImpl) Create(ctx context.Context, hi
 	}
 	return tx.AppliedStockSplitsHistory.WithContext(ctx).Create(dbModel)
 }
+
+func (r *AppliedStockSplitsHistoryRepositoryImpl) ListBySplitDateRange(ctx context.Context, from, to time.Time) ([]*models.AppliedStockSplitHistory, error) {
+	tx := TxOrDefault(ctx, r.query)
+	h := tx.AppliedStockSplitsHistory
+
+	rows, err := h.WithContext(ctx).
+		Where(h.SplitDate.Gte(dateOnlyOf(from))).
+		Where(h.SplitDate.Lte(dateOnlyOf(to))).
+		Order(h.SplitDate, h.Symbol).
+		Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "ListBySplitDateRange error")
+	}
+
+	out := make([]*models.AppliedStockSplitHistory, 0, len(rows))
+	for _, row := range rows {
+		m := &models.AppliedStockSplitHistory{
+			ID:        row.ID,
+			Symbol:    row.Symbol,
+			SplitDate: row.SplitDate,
+			Ratio:     decimal.NewFromFloat(row.Ratio),
+		}
+		if row.AppliedAt != nil {
+			m.AppliedAt = *row.AppliedAt
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
@@ -129,3 +129,29 @@ func TestAppliedStockSplitsHistoryRepositoryImpl_Exists(t *testing.T) {
 		})
 	}
 }
+
+func TestAppliedStockSplitsHistoryRepositoryImpl_ListBySplitDateRange(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewAppliedStockSplitsHistoryRepositoryImpl(db)
+	ctx := context.Background()
+
+	d := func(day int) time.Time { return time.Date(2024, 4, day, 0, 0, 0, 0, time.Local) }
+	for _, h := range []*models.AppliedStockSplitHistory{
+		{Symbol: "2222", SplitDate: d(10), Ratio: decimal.NewFromFloat(2.0)},
+		{Symbol: "1111", SplitDate: d(10), Ratio: decimal.NewFromFloat(5.0)},
+		{Symbol: "3333", SplitDate: d(1), Ratio: decimal.NewFromFloat(3.0)},
+		{Symbol: "4444", SplitDate: d(20), Ratio: decimal.NewFromFloat(2.0)},
+	} {
+		require.NoError(t, repo.Create(ctx, h))
+	}
+
+	got, err := repo.ListBySplitDateRange(ctx, d(1), d(15))
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, "3333", got[0].Symbol)
+	assert.Equal(t, "1111", got[1].Symbol)
+	assert.Equal(t, "2222", got[2].Symbol)
+	assert.True(t, decimal.NewFromFloat(5.0).Equal(got[1].Ratio))
+}
@@ -3,6 +3,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"time"
 
@@ -33,40 +34,46 @@ func (di *DailyStockPickRepositoryImpl) BulkCreate(ctx context.Context, picks []
 		return nil
 	}
 
+	rows, err := di.convertToDBModels(picks)
+	if err != nil {
+		return errors.Wrap(err, "DailyStockPickRepositoryImpl.BulkCreate error")
+	}
 	if err := tx.DailyStockPick.WithContext(ctx).
-		Create(di.convertToDBModels(picks)...); err != nil {
+		Create(rows...); err != nil {
 		return errors.Wrap(err, "DailyStockPickRepositoryImpl.BulkCreate error")
 	}
 	return nil
 }
 
-func (di *DailyStockPickRepositoryImpl) DeleteByPickDate(ctx context.Context, pickDate time.Time) error {
+func (di *DailyStockPickRepositoryImpl) DeleteByPickDate(ctx context.Context, pickDate time.Time, scoreVersions []string) error {
 	tx := TxOrDefault(ctx, di.query)
 
+	if len(scoreVersions) == 0 {
+		return nil
+	}
+
 	if _, err := tx.DailyStockPick.WithContext(ctx).
 		Where(tx.DailyStockPick.PickDate.Eq(dateOnlyOf(pickDate))).
+		Where(tx.DailyStockPick.ScoreVersion.In(scoreVersions...)).
 		Delete(); err != nil {
 		return errors.Wrap(err, "DailyStockPickRepositoryImpl.DeleteByPickDate error")
 	}
 	return nil
 }
 
-func (di *DailyStockPickRepositoryImpl) ListByPickDate(ctx context.Context, pickDate time.Time) ([]*models.DailyStockPick, error) {
+func (di *DailyStockPickRepositoryImpl) ListByPickDate(ctx context.Context, pickDate time.Time, scoreVersion string) ([]*models.DailyStockPick, error) {
 	tx := TxOrDefault(ctx, di.query)
 
 	rows, err := tx.DailyStockPick.WithContext(ctx).
 		Where(tx.DailyStockPick.PickDate.Eq(dateOnlyOf(pickDate))).
+		Where(tx.DailyStockPick.ScoreVersion.Eq(scoreVersion)).
 		Order(tx.DailyStockPick.PickRank).
 		Find()
 	if err != nil {
 		return nil, errors.Wrap(err, "DailyStockPickRepositoryImpl.ListByPickDate error")
 	}
 
-	picks := make([]*models.DailyStockPick, 0, len(rows))
-	for _, r := range rows {
-		picks = append(picks, di.convertToDomainModel(r))
-	}
-	return picks, nil
+	return di.convertToDomainModels(rows)
 }
 
 func (di *DailyStockPickRepositoryImpl) ExistsByPickDate(ctx context.Context, pickDate time.Time) (bool, error) {
@@ -93,42 +100,45 @@ func (di *DailyStockPickRepositoryImpl) ListPendingEvaluation(ctx context.Contex
 		return nil, errors.Wrap(err, "DailyStockPickRepositoryImpl.ListPendingEvaluation error")
 	}
 
-	picks := make([]*models.DailyStockPick, 0, len(rows))
-	for _, r := range rows {
-		picks = append(picks, di.convertToDomainModel(r))
-	}
-	return picks, nil
+	return di.convertToDomainModels(rows)
 }
 
 func (di *DailyStockPickRepositoryImpl) UpdateEvaluations(ctx context.Context, picks []*models.DailyStockPick) error {
 	tx := TxOrDefault(ctx, di.query)
 
 	for _, p := range picks {
+		row, err := di.convertToDBModel(p)
+		if err != nil {
+			return errors.Wrap(err, "DailyStockPickRepositoryImpl.UpdateEvaluations error")
+		}
 		if _, err := tx.DailyStockPick.WithContext(ctx).
 			Where(tx.DailyStockPick.PickDate.Eq(dateOnlyOf(p.PickDate))).
+			Where(tx.DailyStockPick.ScoreVersion.Eq(p.ScoreVersion)).
 			Where(tx.DailyStockPick.StockBrandID.Eq(p.StockBrandID)).
-			Updates(di.convertToDBModel(p)); err != nil {
+			Updates(row); err != nil {
 			return errors.Wrap(err, "DailyStockPickRepositoryImpl.UpdateEvaluations error")
 		}
 	}
 	return nil
 }
 
-func (di *DailyStockPickRepositoryImpl) MarkNotified(ctx context.Context, pickDate time.Time, notifiedAt time.Time) error {
+func (di *DailyStockPickRepositoryImpl) MarkNotified(ctx context.Context, pickDate time.Time, scoreVersion string, notifiedAt time.Time) error {
 	tx := TxOrDefault(ctx, di.query)
 
 	if _, err := tx.DailyStockPick.WithContext(ctx).
 		Where(tx.DailyStockPick.PickDate.Eq(dateOnlyOf(pickDate))).
+		Where(tx.DailyStockPick.ScoreVersion.Eq(scoreVersion)).
 		Update(tx.DailyStockPick.NotifiedAt, notifiedAt); err != nil {
 		return errors.Wrap(err, "DailyStockPickRepositoryImpl.MarkNotified error")
 	}
 	return nil
 }
 
-func (di *DailyStockPickRepositoryImpl) FindLatestPickDate(ctx context.Context) (*time.Time, error) {
+func (di *DailyStockPickRepositoryImpl) FindLatestPickDate(ctx context.Context, scoreVersion string) (*time.Time, error) {
 	tx := TxOrDefault(ctx, di.query)
 
 	row, err := tx.DailyStockPick.WithContext(ctx).
+		Where(tx.DailyStockPick.ScoreVersion.Eq(scoreVersion)).
 		Order(tx.DailyStockPick.PickDate.Desc()).
 		First()
 	if err != nil {
@@ -141,10 +151,11 @@ func (di *DailyStockPickRepositoryImpl) FindLatestPickDate(ctx context.Context)
 	return &row.PickDate, nil
 }
 
-func (di *DailyStockPickRepositoryImpl) ListPickDates(ctx context.Context, limit int) ([]time.Time, error) {
+func (di *DailyStockPickRepositoryImpl) ListPickDates(ctx context.Context, limit int, scoreVersion string) ([]time.Time, error) {
 	tx := TxOrDefault(ctx, di.query)
 
 	q := tx.DailyStockPick.WithContext(ctx).
+		Where(tx.DailyStockPick.ScoreVersion.Eq(scoreVersion)).
 		Distinct(tx.DailyStockPick.PickDate).
 		Order(tx.DailyStockPick.PickDate.Desc())
 	if limit > 0 {
@@ -175,14 +186,10 @@ func (di *DailyStockPickRepositoryImpl) ListByDateRange(ctx context.Context, fro
 		return nil, errors.Wrap(err, "DailyStockPickRepositoryImpl.ListByDateRange error")
 	}
 
-	picks := make([]*models.DailyStockPick, 0, len(rows))
-	for _, r := range rows {
-		picks = append(picks, di.convertToDomainModel(r))
-	}
-	return picks, nil
+	return di.convertToDomainModels(rows)
 }
 
-func (di *DailyStockPickRepositoryImpl) convertToDomainModel(m *genModel.DailyStockPick) *models.DailyStockPick {
+func (di *DailyStockPickRepositoryImpl) convertToDomainModel(m *genModel.DailyStockPick) (*models.DailyStockPick, error) {
 	p := &models.DailyStockPick{
 		PickDate:          m.PickDate,
 		StockBrandID:      m.StockBrandID,
@@ -225,10 +232,27 @@ func (di *DailyStockPickRepositoryImpl) convertToDomainModel(m *genModel.DailySt
 		v := models.DailyStockPickOutcome(*m.Outcome)
 		p.Outcome = &v
 	}
-	return p
+	if m.ScoreComponents != nil {
+		if err := json.Unmarshal([]byte(*m.ScoreComponents), &p.ScoreComponents); err != nil {
+			return nil, errors.Wrap(err, "DailyStockPickRepositoryImpl json.Unmarshal score_components error")
+		}
+	}
+	return p, nil
+}
+
+func (di *DailyStockPickRepositoryImpl) convertToDomainModels(rows []*genModel.DailyStockPick) ([]*models.DailyStockPick, error) {
+	picks := make([]*models.DailyStockPick, 0, len(rows))
+	for _, r := range rows {
+		p, err := di.convertToDomainModel(r)
+		if err != nil {
+			return nil, err
+		}
+		picks = append(picks, p)
+	}
+	return picks, nil
 }
 
-func (di *DailyStockPickRepositoryImpl) convertToDBModel(p *models.DailyStockPick) *genModel.DailyStockPick {
+func (di *DailyStockPickRepositoryImpl) convertToDBModel(p *models.DailyStockPick) (*genModel.DailyStockPick, error) {
 	m := &genModel.DailyStockPick{
 		PickDate:          dateOnlyOf(p.PickDate),
 		StockBrandID:      p.StockBrandID,
@@ -270,15 +294,27 @@ func (di *DailyStockPickRepositoryImpl) convertToDBModel(p *models.DailyStockPic
 		v := string(*p.Outcome)
 		m.Outcome = &v
 	}
-	return m
+	if p.ScoreComponents != nil {
+		b, err := json.Marshal(p.ScoreComponents)
+		if err != nil {
+			return nil, errors.Wrap(err, "DailyStockPickRepositoryImpl json.Marshal score_components error")
+		}
+		v := string(b)
+		m.ScoreComponents = &v
+	}
+	return m, nil
 }
 
-func (di *DailyStockPickRepositoryImpl) convertToDBModels(picks []*models.DailyStockPick) []*genModel.DailyStockPick {
+func (di *DailyStockPickRepositoryImpl) convertToDBModels(picks []*models.DailyStockPick) ([]*genModel.DailyStockPick, error) {
 	out := make([]*genModel.DailyStockPick, 0, len(picks))
 	for _, p := range picks {
-		out = append(out, di.convertToDBModel(p))
+		m, err := di.convertToDBModel(p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
 	}
-	return out
+	return out, nil
 }
 
 func roundToFloat64(d decimal.Decimal, places int32) float64 {
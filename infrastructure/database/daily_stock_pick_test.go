@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestDailyStockPickRepositoryImpl_BulkCreate_SameStockAcrossScoreVersions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewDailyStockPickRepositoryImpl(db)
+	stockBrandRepo := NewStockBrandRepositoryImpl(db)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	pickDate := time.Date(2026, 7, 24, 0, 0, 0, 0, time.Local)
+
+	err := stockBrandRepo.UpsertStockBrands(ctx, []*models.StockBrand{{
+		ID:           "brand-pick-1",
+		TickerSymbol: "7203",
+		Name:         "Pick Test Brand",
+		MarketCode:   "111",
+		MarketName:   "Prime",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}})
+	require.NoError(t, err)
+
+	pick := func(scoreVersion string) *models.DailyStockPick {
+		return &models.DailyStockPick{
+			PickDate:          pickDate,
+			StockBrandID:      "brand-pick-1",
+			TickerSymbol:      "7203",
+			PickRank:          1,
+			Score:             decimal.RequireFromString("72.5"),
+			ScoreVersion:      scoreVersion,
+			SignalCount:       2,
+			Strategies:        []string{"macd_bullish", "ma_cross"},
+			Sector33CodeName:  "輸送用機器",
+			BaseClosePrice:    decimal.NewFromInt(3000),
+			BaseAdjClosePrice: decimal.NewFromInt(3000),
+			AvgTradingValue:   decimal.NewFromInt(5000000000),
+			VolumeRatio:       decimal.RequireFromString("1.8"),
+			ADX:               decimal.NewFromInt(28),
+			PlusDI:            decimal.NewFromInt(30),
+			MinusDI:           decimal.NewFromInt(15),
+			ATRRatio:          decimal.RequireFromString("0.02"),
+			RSI:               decimal.NewFromInt(60),
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		}
+	}
+
+	// live と影のスコアバージョンが同じ銘柄・同じ日を選んでも主キーが衝突しない
+	err = repo.BulkCreate(ctx, []*models.DailyStockPick{pick("v1"), pick("v1-liquid"), pick("replay/v1-confluence")})
+	require.NoError(t, err)
+
+	for _, version := range []string{"v1", "v1-liquid", "replay/v1-confluence"} {
+		got, err := repo.ListByPickDate(ctx, pickDate, version)
+		require.NoError(t, err)
+		require.Len(t, got, 1, version)
+		assert.Equal(t, version, got[0].ScoreVersion)
+		assert.Equal(t, "7203", got[0].TickerSymbol)
+	}
+
+	// 同じスコアバージョンの重複は従来どおり主キーで弾く
+	assert.Error(t, repo.BulkCreate(ctx, []*models.DailyStockPick{pick("v1")}))
+}
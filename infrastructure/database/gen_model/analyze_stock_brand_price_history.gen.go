@@ -12,16 +12,22 @@ const TableNameAnalyzeStockBrandPriceHistory = "analyze_stock_brand_price_histor
 
 // AnalyzeStockBrandPriceHistory mapped from table <analyze_stock_brand_price_history>
 type AnalyzeStockBrandPriceHistory struct {
-	ID           string     `gorm:"column:id;type:char(36);primaryKey;comment:uuid" json:"id"`                            // uuid
-	StockBrandID string     `gorm:"column:stock_brand_id;type:char(36);not null;comment:uuid" json:"stock_brand_id"`      // uuid
-	TickerSymbol string     `gorm:"column:ticker_symbol;type:varchar(36);not null;comment:証券コード" json:"ticker_symbol"`    // 証券コード
-	TradePrice   float64    `gorm:"column:trade_price;type:decimal(10,4);not null;comment:トレード金額" json:"trade_price"`     // トレード金額
-	Action       string     `gorm:"column:action;type:varchar(10);not null;comment:売り/買いの別" json:"action"`                // 売り/買いの別
-	Method       string     `gorm:"column:method;type:varchar(255);not null;comment:分析方法" json:"method"`                  // 分析方法
-	Memo         *string    `gorm:"column:memo;type:text;comment:メモ" json:"memo"`                                         // メモ
-	Score        *float64   `gorm:"column:score;type:decimal(10,4);comment:複合スコア（出す手法のみ。analyze_diamonds等）" json:"score"` // 複合スコア（出す手法のみ。analyze_diamonds等）
-	SignalRank   *int32     `gorm:"column:signal_rank;type:int;comment:手法内ランク（1始まり）" json:"signal_rank"`                  // 手法内ランク（1始まり）
-	CreatedAt    *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
+	ID              string     `gorm:"column:id;type:char(36);primaryKey;comment:uuid" json:"id"`                                                                    // uuid
+	StockBrandID    string     `gorm:"column:stock_brand_id;type:char(36);not null;comment:uuid" json:"stock_brand_id"`                                              // uuid
+	TickerSymbol    string     `gorm:"column:ticker_symbol;type:varchar(36);not null;comment:証券コード" json:"ticker_symbol"`                                            // 証券コード
+	TradePrice      float64    `gorm:"column:trade_price;type:decimal(10,4);not null;comment:トレード金額" json:"trade_price"`                                             // トレード金額
+	Action          string     `gorm:"column:action;type:varchar(10);not null;comment:売り/買いの別" json:"action"`                                                        // 売り/買いの別
+	Method          string     `gorm:"column:method;type:varchar(255);not null;comment:分析方法" json:"method"`                                                          // 分析方法
+	Memo            *string    `gorm:"column:memo;type:text;comment:メモ" json:"memo"`                                                                                 // メモ
+	Score           *float64   `gorm:"column:score;type:decimal(10,4);comment:複合スコア（出す手法のみ。analyze_diamonds等）" json:"score"`                                         // 複合スコア（出す手法のみ。analyze_diamonds等）
+	SignalRank      *int32     `gorm:"column:signal_rank;type:int;comment:手法内ランク（1始まり）" json:"signal_rank"`                                                          // 手法内ランク（1始まり）
+	CurrentPrice    *float64   `gorm:"column:current_price;type:decimal(10,4);comment:値洗い価格（marked_at の終値を分割・併合調整して trade_price と同じ株数基準にしたもの）" json:"current_price"` // 値洗い価格（marked_at の終値を分割・併合調整して trade_price と同じ株数基準にしたもの）
+	PriceDifference *float64   `gorm:"column:price_difference;type:decimal(10,4);comment:current_price - trade_price" json:"price_difference"`                       // current_price - trade_price
+	MarkedAt        *time.Time `gorm:"column:marked_at;type:date;comment:current_price の基準日（値洗いに使った日足の日付）。未値洗いは NULL" json:"marked_at"`                              // current_price の基準日（値洗いに使った日足の日付）。未値洗いは NULL
+	ClosedAt        *time.Time `gorm:"column:closed_at;type:date;comment:クローズ日（シグナル日から N 営業日目）。NULL なら建玉中" json:"closed_at"`                                         // クローズ日（シグナル日から N 営業日目）。NULL なら建玉中
+	ExitPrice       *float64   `gorm:"column:exit_price;type:decimal(10,4);comment:クローズ日の終値（分割・併合調整済み）" json:"exit_price"`                                           // クローズ日の終値（分割・併合調整済み）
+	HoldingReturn   *float64   `gorm:"column:holding_return;type:decimal(12,6);comment:保有リターン（Buy: exit/trade-1、Sell: 1-exit/trade）" json:"holding_return"`          // 保有リターン（Buy: exit/trade-1、Sell: 1-exit/trade）
+	CreatedAt       *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
 }
 
 // TableName AnalyzeStockBrandPriceHistory's table name
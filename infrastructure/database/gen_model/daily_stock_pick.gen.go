@@ -12,32 +12,33 @@ const TableNameDailyStockPick = "daily_stock_pick"
 
 // DailyStockPick mapped from table <daily_stock_pick>
 type DailyStockPick struct {
-	PickDate          time.Time  `gorm:"column:pick_date;type:date;primaryKey;comment:選定基準日（この日の引け値でスクリーニング。翌営業日の寄り〜引けで買う想定）" json:"pick_date"`                          // 選定基準日（この日の引け値でスクリーニング。翌営業日の寄り〜引けで買う想定）
-	StockBrandID      string     `gorm:"column:stock_brand_id;type:char(36);primaryKey;comment:stock_brand.id" json:"stock_brand_id"`                                    // stock_brand.id
-	TickerSymbol      string     `gorm:"column:ticker_symbol;type:varchar(10);not null;comment:銘柄コード" json:"ticker_symbol"`                                              // 銘柄コード
-	PickRank          uint32     `gorm:"column:pick_rank;type:int unsigned;not null;comment:スコア降順の順位 1..N（rank は MySQL8 予約語のため pick_rank）" json:"pick_rank"`             // スコア降順の順位 1..N（rank は MySQL8 予約語のため pick_rank）
-	Score             float64    `gorm:"column:score;type:decimal(5,2);not null;comment:複合スコア 0.00-100.00" json:"score"`                                                 // 複合スコア 0.00-100.00
-	ScoreVersion      string     `gorm:"column:score_version;type:varchar(16);not null;comment:スコア定義バージョン。重み変更時にインクリメントし過去分と混ぜて集計しない" json:"score_version"`              // スコア定義バージョン。重み変更時にインクリメントし過去分と混ぜて集計しない
-	SignalCount       uint32     `gorm:"column:signal_count;type:tinyint unsigned;not null;comment:最新営業日に点灯した基本4戦略の数 1-4" json:"signal_count"`                           // 最新営業日に点灯した基本4戦略の数 1-4
-	Strategies        string     `gorm:"column:strategies;type:varchar(255);not null;comment:点灯戦略キーのカンマ区切り 例: macd_bullish,ma_cross" json:"strategies"`                  // 点灯戦略キーのカンマ区切り 例: macd_bullish,ma_cross
-	Sector33CodeName  *string    `gorm:"column:sector_33_code_name;type:varchar(64);comment:33業種名。セクター分散上限の効き具合を後から検証するため保存" json:"sector_33_code_name"`                 // 33業種名。セクター分散上限の効き具合を後から検証するため保存
-	BaseClosePrice    float64    `gorm:"column:base_close_price;type:decimal(10,4);not null;comment:pick_date の終値（Slack表示・目視確認用のスナップショット）" json:"base_close_price"`      // pick_date の終値（Slack表示・目視確認用のスナップショット）
-	BaseAdjClosePrice float64    `gorm:"column:base_adj_close_price;type:decimal(10,4);not null;comment:pick_date の調整後終値。答え合わせリターンの基準価格 P0" json:"base_adj_close_price"` // pick_date の調整後終値。答え合わせリターンの基準価格 P0
-	AvgTradingValue   float64    `gorm:"column:avg_trading_value;type:decimal(24,4);not null;comment:直近20営業日平均売買代金 volume*close" json:"avg_trading_value"`               // 直近20営業日平均売買代金 volume*close
-	VolumeRatio       float64    `gorm:"column:volume_ratio;type:decimal(10,4);not null;comment:当日出来高 / 直近20営業日平均出来高" json:"volume_ratio"`                               // 当日出来高 / 直近20営業日平均出来高
-	Adx               float64    `gorm:"column:adx;type:decimal(8,4);not null;comment:ADX(14)" json:"adx"`                                                               // ADX(14)
-	PlusDi            float64    `gorm:"column:plus_di;type:decimal(8,4);not null;comment:+DI(14)" json:"plus_di"`                                                       // +DI(14)
-	MinusDi           float64    `gorm:"column:minus_di;type:decimal(8,4);not null;comment:-DI(14)" json:"minus_di"`                                                     // -DI(14)
-	AtrRatio          float64    `gorm:"column:atr_ratio;type:decimal(10,6);not null;comment:ATR(14) / 終値" json:"atr_ratio"`                                             // ATR(14) / 終値
-	Rsi               float64    `gorm:"column:rsi;type:decimal(8,4);not null;comment:RSI(14)" json:"rsi"`                                                               // RSI(14)
-	Return1D          *float64   `gorm:"column:return_1d;type:decimal(12,6);comment:1営業日後リターン（base_adj_close_price 基準）。未到来は NULL" json:"return_1d"`                      // 1営業日後リターン（base_adj_close_price 基準）。未到来は NULL
-	Return3D          *float64   `gorm:"column:return_3d;type:decimal(12,6);comment:3営業日後リターン。未到来は NULL" json:"return_3d"`                                               // 3営業日後リターン。未到来は NULL
-	Return5D          *float64   `gorm:"column:return_5d;type:decimal(12,6);comment:5営業日後リターン。未到来は NULL" json:"return_5d"`                                               // 5営業日後リターン。未到来は NULL
-	Outcome           *string    `gorm:"column:outcome;type:varchar(16);comment:勝敗判定: win/lose/draw/void。return_5d 基準" json:"outcome"`                                   // 勝敗判定: win/lose/draw/void。return_5d 基準
-	EvaluatedAt       *time.Time `gorm:"column:evaluated_at;type:datetime;comment:答え合わせ確定日時。NULL の行だけが答え合わせバッチの対象" json:"evaluated_at"`                                  // 答え合わせ確定日時。NULL の行だけが答え合わせバッチの対象
-	NotifiedAt        *time.Time `gorm:"column:notified_at;type:datetime;comment:Slack 通知日時。NULL なら未通知＝次回バッチで再通知する" json:"notified_at"`                                  // Slack 通知日時。NULL なら未通知＝次回バッチで再通知する
-	CreatedAt         time.Time  `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:created_at" json:"created_at"`                        // created_at
-	UpdatedAt         time.Time  `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:updated_at" json:"updated_at"`                        // updated_at
+	PickDate          time.Time  `gorm:"column:pick_date;type:date;primaryKey;comment:選定基準日（この日の引け値でスクリーニング。翌営業日の寄り〜引けで買う想定）" json:"pick_date"`                                      // 選定基準日（この日の引け値でスクリーニング。翌営業日の寄り〜引けで買う想定）
+	StockBrandID      string     `gorm:"column:stock_brand_id;type:char(36);primaryKey;comment:stock_brand.id" json:"stock_brand_id"`                                                // stock_brand.id
+	TickerSymbol      string     `gorm:"column:ticker_symbol;type:varchar(10);not null;comment:銘柄コード" json:"ticker_symbol"`                                                          // 銘柄コード
+	PickRank          uint32     `gorm:"column:pick_rank;type:int unsigned;not null;comment:スコア降順の順位 1..N（rank は MySQL8 予約語のため pick_rank）" json:"pick_rank"`                         // スコア降順の順位 1..N（rank は MySQL8 予約語のため pick_rank）
+	Score             float64    `gorm:"column:score;type:decimal(5,2);not null;comment:複合スコア 0.00-100.00" json:"score"`                                                             // 複合スコア 0.00-100.00
+	ScoreVersion      string     `gorm:"column:score_version;type:varchar(32);primaryKey;comment:スコア定義バージョン。重み変更時にインクリメントし過去分と混ぜて集計しない" json:"score_version"`                        // スコア定義バージョン。重み変更時にインクリメントし過去分と混ぜて集計しない
+	SignalCount       uint32     `gorm:"column:signal_count;type:tinyint unsigned;not null;comment:最新営業日に点灯した基本4戦略の数 1-4" json:"signal_count"`                                       // 最新営業日に点灯した基本4戦略の数 1-4
+	Strategies        string     `gorm:"column:strategies;type:varchar(255);not null;comment:点灯戦略キーのカンマ区切り 例: macd_bullish,ma_cross" json:"strategies"`                              // 点灯戦略キーのカンマ区切り 例: macd_bullish,ma_cross
+	Sector33CodeName  *string    `gorm:"column:sector_33_code_name;type:varchar(64);comment:33業種名。セクター分散上限の効き具合を後から検証するため保存" json:"sector_33_code_name"`                             // 33業種名。セクター分散上限の効き具合を後から検証するため保存
+	BaseClosePrice    float64    `gorm:"column:base_close_price;type:decimal(10,4);not null;comment:pick_date の終値（Slack表示・目視確認用のスナップショット）" json:"base_close_price"`                  // pick_date の終値（Slack表示・目視確認用のスナップショット）
+	BaseAdjClosePrice float64    `gorm:"column:base_adj_close_price;type:decimal(10,4);not null;comment:pick_date の調整後終値。答え合わせリターンの基準価格 P0" json:"base_adj_close_price"`             // pick_date の調整後終値。答え合わせリターンの基準価格 P0
+	AvgTradingValue   float64    `gorm:"column:avg_trading_value;type:decimal(24,4);not null;comment:直近20営業日平均売買代金 volume*close" json:"avg_trading_value"`                           // 直近20営業日平均売買代金 volume*close
+	VolumeRatio       float64    `gorm:"column:volume_ratio;type:decimal(10,4);not null;comment:当日出来高 / 直近20営業日平均出来高" json:"volume_ratio"`                                           // 当日出来高 / 直近20営業日平均出来高
+	Adx               float64    `gorm:"column:adx;type:decimal(8,4);not null;comment:ADX(14)" json:"adx"`                                                                           // ADX(14)
+	PlusDi            float64    `gorm:"column:plus_di;type:decimal(8,4);not null;comment:+DI(14)" json:"plus_di"`                                                                   // +DI(14)
+	MinusDi           float64    `gorm:"column:minus_di;type:decimal(8,4);not null;comment:-DI(14)" json:"minus_di"`                                                                 // -DI(14)
+	AtrRatio          float64    `gorm:"column:atr_ratio;type:decimal(10,6);not null;comment:ATR(14) / 終値" json:"atr_ratio"`                                                         // ATR(14) / 終値
+	Rsi               float64    `gorm:"column:rsi;type:decimal(8,4);not null;comment:RSI(14)" json:"rsi"`                                                                           // RSI(14)
+	ScoreComponents   *string    `gorm:"column:score_components;type:json;comment:因子ごとの正規化スコアと寄与（[]models.DailyStockPickScoreComponent の JSON）。導入前の行は NULL" json:"score_components"` // 因子ごとの正規化スコアと寄与（[]models.DailyStockPickScoreComponent の JSON）。導入前の行は NULL
+	Return1D          *float64   `gorm:"column:return_1d;type:decimal(12,6);comment:1営業日後リターン（base_adj_close_price 基準）。未到来は NULL" json:"return_1d"`                                  // 1営業日後リターン（base_adj_close_price 基準）。未到来は NULL
+	Return3D          *float64   `gorm:"column:return_3d;type:decimal(12,6);comment:3営業日後リターン。未到来は NULL" json:"return_3d"`                                                           // 3営業日後リターン。未到来は NULL
+	Return5D          *float64   `gorm:"column:return_5d;type:decimal(12,6);comment:5営業日後リターン。未到来は NULL" json:"return_5d"`                                                           // 5営業日後リターン。未到来は NULL
+	Outcome           *string    `gorm:"column:outcome;type:varchar(16);comment:勝敗判定: win/lose/draw/void。return_5d 基準" json:"outcome"`                                               // 勝敗判定: win/lose/draw/void。return_5d 基準
+	EvaluatedAt       *time.Time `gorm:"column:evaluated_at;type:datetime;comment:答え合わせ確定日時。NULL の行だけが答え合わせバッチの対象" json:"evaluated_at"`                                              // 答え合わせ確定日時。NULL の行だけが答え合わせバッチの対象
+	NotifiedAt        *time.Time `gorm:"column:notified_at;type:datetime;comment:Slack 通知日時。NULL なら未通知＝次回バッチで再通知する" json:"notified_at"`                                              // Slack 通知日時。NULL なら未通知＝次回バッチで再通知する
+	CreatedAt         time.Time  `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:created_at" json:"created_at"`                                    // created_at
+	UpdatedAt         time.Time  `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:updated_at" json:"updated_at"`                                    // updated_at
 }
 
 // TableName DailyStockPick's table name
@@ -37,6 +37,12 @@ func newAnalyzeStockBrandPriceHistory(db *gorm.DB, opts ...gen.DOOption) analyze
 	_analyzeStockBrandPriceHistory.Memo = field.NewString(tableName, "memo")
 	_analyzeStockBrandPriceHistory.Score = field.NewFloat64(tableName, "score")
 	_analyzeStockBrandPriceHistory.SignalRank = field.NewInt32(tableName, "signal_rank")
+	_analyzeStockBrandPriceHistory.CurrentPrice = field.NewFloat64(tableName, "current_price")
+	_analyzeStockBrandPriceHistory.PriceDifference = field.NewFloat64(tableName, "price_difference")
+	_analyzeStockBrandPriceHistory.MarkedAt = field.NewTime(tableName, "marked_at")
+	_analyzeStockBrandPriceHistory.ClosedAt = field.NewTime(tableName, "closed_at")
+	_analyzeStockBrandPriceHistory.ExitPrice = field.NewFloat64(tableName, "exit_price")
+	_analyzeStockBrandPriceHistory.HoldingReturn = field.NewFloat64(tableName, "holding_return")
 	_analyzeStockBrandPriceHistory.CreatedAt = field.NewTime(tableName, "created_at")
 
 	_analyzeStockBrandPriceHistory.fillFieldMap()
@@ -47,17 +53,23 @@ func newAnalyzeStockBrandPriceHistory(db *gorm.DB, opts ...gen.DOOption) analyze
 type analyzeStockBrandPriceHistory struct {
 	analyzeStockBrandPriceHistoryDo
 
-	ALL          field.Asterisk
-	ID           field.String  // uuid
-	StockBrandID field.String  // uuid
-	TickerSymbol field.String  // 証券コード
-	TradePrice   field.Float64 // トレード金額
-	Action       field.String  // 売り/買いの別
-	Method       field.String  // 分析方法
-	Memo         field.String  // メモ
-	Score        field.Float64 // 複合スコア（出す手法のみ。analyze_diamonds等）
-	SignalRank   field.Int32   // 手法内ランク（1始まり）
-	CreatedAt    field.Time
+	ALL             field.Asterisk
+	ID              field.String  // uuid
+	StockBrandID    field.String  // uuid
+	TickerSymbol    field.String  // 証券コード
+	TradePrice      field.Float64 // トレード金額
+	Action          field.String  // 売り/買いの別
+	Method          field.String  // 分析方法
+	Memo            field.String  // メモ
+	Score           field.Float64 // 複合スコア（出す手法のみ。analyze_diamonds等）
+	SignalRank      field.Int32   // 手法内ランク（1始まり）
+	CurrentPrice    field.Float64 // 値洗い価格（marked_at の終値を分割・併合調整して trade_price と同じ株数基準にしたもの）
+	PriceDifference field.Float64 // current_price - trade_price
+	MarkedAt        field.Time    // current_price の基準日（値洗いに使った日足の日付）。未値洗いは NULL
+	ClosedAt        field.Time    // クローズ日（シグナル日から N 営業日目）。NULL なら建玉中
+	ExitPrice       field.Float64 // クローズ日の終値（分割・併合調整済み）
+	HoldingReturn   field.Float64 // 保有リターン（Buy: exit/trade-1、Sell: 1-exit/trade）
+	CreatedAt       field.Time
 
 	fieldMap map[string]field.Expr
 }
@@ -83,6 +95,12 @@ func (a *analyzeStockBrandPriceHistory) updateTableName(table string) *analyzeSt
 	a.Memo = field.NewString(table, "memo")
 	a.Score = field.NewFloat64(table, "score")
 	a.SignalRank = field.NewInt32(table, "signal_rank")
+	a.CurrentPrice = field.NewFloat64(table, "current_price")
+	a.PriceDifference = field.NewFloat64(table, "price_difference")
+	a.MarkedAt = field.NewTime(table, "marked_at")
+	a.ClosedAt = field.NewTime(table, "closed_at")
+	a.ExitPrice = field.NewFloat64(table, "exit_price")
+	a.HoldingReturn = field.NewFloat64(table, "holding_return")
 	a.CreatedAt = field.NewTime(table, "created_at")
 
 	a.fillFieldMap()
@@ -100,7 +118,7 @@ func (a *analyzeStockBrandPriceHistory) GetFieldByName(fieldName string) (field.
 }
 
 func (a *analyzeStockBrandPriceHistory) fillFieldMap() {
-	a.fieldMap = make(map[string]field.Expr, 10)
+	a.fieldMap = make(map[string]field.Expr, 16)
 	a.fieldMap["id"] = a.ID
 	a.fieldMap["stock_brand_id"] = a.StockBrandID
 	a.fieldMap["ticker_symbol"] = a.TickerSymbol
@@ -110,6 +128,12 @@ func (a *analyzeStockBrandPriceHistory) fillFieldMap() {
 	a.fieldMap["memo"] = a.Memo
 	a.fieldMap["score"] = a.Score
 	a.fieldMap["signal_rank"] = a.SignalRank
+	a.fieldMap["current_price"] = a.CurrentPrice
+	a.fieldMap["price_difference"] = a.PriceDifference
+	a.fieldMap["marked_at"] = a.MarkedAt
+	a.fieldMap["closed_at"] = a.ClosedAt
+	a.fieldMap["exit_price"] = a.ExitPrice
+	a.fieldMap["holding_return"] = a.HoldingReturn
 	a.fieldMap["created_at"] = a.CreatedAt
 }
 
@@ -46,6 +46,7 @@ func newDailyStockPick(db *gorm.DB, opts ...gen.DOOption) dailyStockPick {
 	_dailyStockPick.MinusDi = field.NewFloat64(tableName, "minus_di")
 	_dailyStockPick.AtrRatio = field.NewFloat64(tableName, "atr_ratio")
 	_dailyStockPick.Rsi = field.NewFloat64(tableName, "rsi")
+	_dailyStockPick.ScoreComponents = field.NewString(tableName, "score_components")
 	_dailyStockPick.Return1D = field.NewFloat64(tableName, "return_1d")
 	_dailyStockPick.Return3D = field.NewFloat64(tableName, "return_3d")
 	_dailyStockPick.Return5D = field.NewFloat64(tableName, "return_5d")
@@ -82,6 +83,7 @@ type dailyStockPick struct {
 	MinusDi           field.Float64 // -DI(14)
 	AtrRatio          field.Float64 // ATR(14) / 終値
 	Rsi               field.Float64 // RSI(14)
+	ScoreComponents   field.String  // 因子ごとの正規化スコアと寄与（[]models.DailyStockPickScoreComponent の JSON）。導入前の行は NULL
 	Return1D          field.Float64 // 1営業日後リターン（base_adj_close_price 基準）。未到来は NULL
 	Return3D          field.Float64 // 3営業日後リターン。未到来は NULL
 	Return5D          field.Float64 // 5営業日後リターン。未到来は NULL
@@ -124,6 +126,7 @@ func (d *dailyStockPick) updateTableName(table string) *dailyStockPick {
 	d.MinusDi = field.NewFloat64(table, "minus_di")
 	d.AtrRatio = field.NewFloat64(table, "atr_ratio")
 	d.Rsi = field.NewFloat64(table, "rsi")
+	d.ScoreComponents = field.NewString(table, "score_components")
 	d.Return1D = field.NewFloat64(table, "return_1d")
 	d.Return3D = field.NewFloat64(table, "return_3d")
 	d.Return5D = field.NewFloat64(table, "return_5d")
@@ -148,7 +151,7 @@ func (d *dailyStockPick) GetFieldByName(fieldName string) (field.OrderExpr, bool
 }
 
 func (d *dailyStockPick) fillFieldMap() {
-	d.fieldMap = make(map[string]field.Expr, 26)
+	d.fieldMap = make(map[string]field.Expr, 27)
 	d.fieldMap["pick_date"] = d.PickDate
 	d.fieldMap["stock_brand_id"] = d.StockBrandID
 	d.fieldMap["ticker_symbol"] = d.TickerSymbol
@@ -167,6 +170,7 @@ func (d *dailyStockPick) fillFieldMap() {
 	d.fieldMap["minus_di"] = d.MinusDi
 	d.fieldMap["atr_ratio"] = d.AtrRatio
 	d.fieldMap["rsi"] = d.Rsi
+	d.fieldMap["score_components"] = d.ScoreComponents
 	d.fieldMap["return_1d"] = d.Return1D
 	d.fieldMap["return_3d"] = d.Return3D
 	d.fieldMap["return_5d"] = d.Return5D
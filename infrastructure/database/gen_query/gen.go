@@ -27,15 +27,25 @@ var (
 	FinAnnouncement                   *finAnnouncement
 	FinStatement                      *finStatement
 	HighVolumeStockBrand              *highVolumeStockBrand
+	MarketBreadth                     *marketBreadth
+	MarketRegime                      *marketRegime
 	NikkeiStockAverageDailyPrice      *nikkeiStockAverageDailyPrice
+	PaperAccount                      *paperAccount
+	PaperFill                         *paperFill
+	PaperNav                          *paperNav
+	PaperPosition                     *paperPosition
 	QuizAnswer                        *quizAnswer
 	QuizDailyUniverse                 *quizDailyUniverse
+	RelativeStrengthRating            *relativeStrengthRating
 	SchemaMigration                   *schemaMigration
 	Sector17AverageDailyPrice         *sector17AverageDailyPrice
 	Sector33AverageDailyPrice         *sector33AverageDailyPrice
 	StockBrand                        *stockBrand
 	StockBrandsDailyPrice             *stockBrandsDailyPrice
 	StockBrandsDailyPriceForAnalyze   *stockBrandsDailyPriceForAnalyze
+	StrategyRankingRun                *strategyRankingRun
+	StrategyRankingRunItem            *strategyRankingRunItem
+	StrategyRankingRunStock           *strategyRankingRunStock
 	TopixDailyPrice                   *topixDailyPrice
 )
 
@@ -51,15 +61,25 @@ func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	FinAnnouncement = &Q.FinAnnouncement
 	FinStatement = &Q.FinStatement
 	HighVolumeStockBrand = &Q.HighVolumeStockBrand
+	MarketBreadth = &Q.MarketBreadth
+	MarketRegime = &Q.MarketRegime
 	NikkeiStockAverageDailyPrice = &Q.NikkeiStockAverageDailyPrice
+	PaperAccount = &Q.PaperAccount
+	PaperFill = &Q.PaperFill
+	PaperNav = &Q.PaperNav
+	PaperPosition = &Q.PaperPosition
 	QuizAnswer = &Q.QuizAnswer
 	QuizDailyUniverse = &Q.QuizDailyUniverse
+	RelativeStrengthRating = &Q.RelativeStrengthRating
 	SchemaMigration = &Q.SchemaMigration
 	Sector17AverageDailyPrice = &Q.Sector17AverageDailyPrice
 	Sector33AverageDailyPrice = &Q.Sector33AverageDailyPrice
 	StockBrand = &Q.StockBrand
 	StockBrandsDailyPrice = &Q.StockBrandsDailyPrice
 	StockBrandsDailyPriceForAnalyze = &Q.StockBrandsDailyPriceForAnalyze
+	StrategyRankingRun = &Q.StrategyRankingRun
+	StrategyRankingRunItem = &Q.StrategyRankingRunItem
+	StrategyRankingRunStock = &Q.StrategyRankingRunStock
 	TopixDailyPrice = &Q.TopixDailyPrice
 }
 
@@ -76,15 +96,25 @@ func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 		FinAnnouncement:                   newFinAnnouncement(db, opts...),
 		FinStatement:                      newFinStatement(db, opts...),
 		HighVolumeStockBrand:              newHighVolumeStockBrand(db, opts...),
+		MarketBreadth:                     newMarketBreadth(db, opts...),
+		MarketRegime:                      newMarketRegime(db, opts...),
 		NikkeiStockAverageDailyPrice:      newNikkeiStockAverageDailyPrice(db, opts...),
+		PaperAccount:                      newPaperAccount(db, opts...),
+		PaperFill:                         newPaperFill(db, opts...),
+		PaperNav:                          newPaperNav(db, opts...),
+		PaperPosition:                     newPaperPosition(db, opts...),
 		QuizAnswer:                        newQuizAnswer(db, opts...),
 		QuizDailyUniverse:                 newQuizDailyUniverse(db, opts...),
+		RelativeStrengthRating:            newRelativeStrengthRating(db, opts...),
 		SchemaMigration:                   newSchemaMigration(db, opts...),
 		Sector17AverageDailyPrice:         newSector17AverageDailyPrice(db, opts...),
 		Sector33AverageDailyPrice:         newSector33AverageDailyPrice(db, opts...),
 		StockBrand:                        newStockBrand(db, opts...),
 		StockBrandsDailyPrice:             newStockBrandsDailyPrice(db, opts...),
 		StockBrandsDailyPriceForAnalyze:   newStockBrandsDailyPriceForAnalyze(db, opts...),
+		StrategyRankingRun:                newStrategyRankingRun(db, opts...),
+		StrategyRankingRunItem:            newStrategyRankingRunItem(db, opts...),
+		StrategyRankingRunStock:           newStrategyRankingRunStock(db, opts...),
 		TopixDailyPrice:                   newTopixDailyPrice(db, opts...),
 	}
 }
@@ -102,15 +132,25 @@ type Query struct {
 	FinAnnouncement                   finAnnouncement
 	FinStatement                      finStatement
 	HighVolumeStockBrand              highVolumeStockBrand
+	MarketBreadth                     marketBreadth
+	MarketRegime                      marketRegime
 	NikkeiStockAverageDailyPrice      nikkeiStockAverageDailyPrice
+	PaperAccount                      paperAccount
+	PaperFill                         paperFill
+	PaperNav                          paperNav
+	PaperPosition                     paperPosition
 	QuizAnswer                        quizAnswer
 	QuizDailyUniverse                 quizDailyUniverse
+	RelativeStrengthRating            relativeStrengthRating
 	SchemaMigration                   schemaMigration
 	Sector17AverageDailyPrice         sector17AverageDailyPrice
 	Sector33AverageDailyPrice         sector33AverageDailyPrice
 	StockBrand                        stockBrand
 	StockBrandsDailyPrice             stockBrandsDailyPrice
 	StockBrandsDailyPriceForAnalyze   stockBrandsDailyPriceForAnalyze
+	StrategyRankingRun                strategyRankingRun
+	StrategyRankingRunItem            strategyRankingRunItem
+	StrategyRankingRunStock           strategyRankingRunStock
 	TopixDailyPrice                   topixDailyPrice
 }
 
@@ -131,15 +171,25 @@ func (q *Query) clone(db *gorm.DB) *Query {
 		FinAnnouncement:                   q.FinAnnouncement.clone(db),
 		FinStatement:                      q.FinStatement.clone(db),
 		HighVolumeStockBrand:              q.HighVolumeStockBrand.clone(db),
+		MarketBreadth:                     q.MarketBreadth.clone(db),
+		MarketRegime:                      q.MarketRegime.clone(db),
 		NikkeiStockAverageDailyPrice:      q.NikkeiStockAverageDailyPrice.clone(db),
+		PaperAccount:                      q.PaperAccount.clone(db),
+		PaperFill:                         q.PaperFill.clone(db),
+		PaperNav:                          q.PaperNav.clone(db),
+		PaperPosition:                     q.PaperPosition.clone(db),
 		QuizAnswer:                        q.QuizAnswer.clone(db),
 		QuizDailyUniverse:                 q.QuizDailyUniverse.clone(db),
+		RelativeStrengthRating:            q.RelativeStrengthRating.clone(db),
 		SchemaMigration:                   q.SchemaMigration.clone(db),
 		Sector17AverageDailyPrice:         q.Sector17AverageDailyPrice.clone(db),
 		Sector33AverageDailyPrice:         q.Sector33AverageDailyPrice.clone(db),
 		StockBrand:                        q.StockBrand.clone(db),
 		StockBrandsDailyPrice:             q.StockBrandsDailyPrice.clone(db),
 		StockBrandsDailyPriceForAnalyze:   q.StockBrandsDailyPriceForAnalyze.clone(db),
+		StrategyRankingRun:                q.StrategyRankingRun.clone(db),
+		StrategyRankingRunItem:            q.StrategyRankingRunItem.clone(db),
+		StrategyRankingRunStock:           q.StrategyRankingRunStock.clone(db),
 		TopixDailyPrice:                   q.TopixDailyPrice.clone(db),
 	}
 }
@@ -165,15 +215,25 @@ func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 		FinAnnouncement:                   q.FinAnnouncement.replaceDB(db),
 		FinStatement:                      q.FinStatement.replaceDB(db),
 		HighVolumeStockBrand:              q.HighVolumeStockBrand.replaceDB(db),
+		MarketBreadth:                     q.MarketBreadth.replaceDB(db),
+		MarketRegime:                      q.MarketRegime.replaceDB(db),
 		NikkeiStockAverageDailyPrice:      q.NikkeiStockAverageDailyPrice.replaceDB(db),
+		PaperAccount:                      q.PaperAccount.replaceDB(db),
+		PaperFill:                         q.PaperFill.replaceDB(db),
+		PaperNav:                          q.PaperNav.replaceDB(db),
+		PaperPosition:                     q.PaperPosition.replaceDB(db),
 		QuizAnswer:                        q.QuizAnswer.replaceDB(db),
 		QuizDailyUniverse:                 q.QuizDailyUniverse.replaceDB(db),
+		RelativeStrengthRating:            q.RelativeStrengthRating.replaceDB(db),
 		SchemaMigration:                   q.SchemaMigration.replaceDB(db),
 		Sector17AverageDailyPrice:         q.Sector17AverageDailyPrice.replaceDB(db),
 		Sector33AverageDailyPrice:         q.Sector33AverageDailyPrice.replaceDB(db),
 		StockBrand:                        q.StockBrand.replaceDB(db),
 		StockBrandsDailyPrice:             q.StockBrandsDailyPrice.replaceDB(db),
 		StockBrandsDailyPriceForAnalyze:   q.StockBrandsDailyPriceForAnalyze.replaceDB(db),
+		StrategyRankingRun:                q.StrategyRankingRun.replaceDB(db),
+		StrategyRankingRunItem:            q.StrategyRankingRunItem.replaceDB(db),
+		StrategyRankingRunStock:           q.StrategyRankingRunStock.replaceDB(db),
 		TopixDailyPrice:                   q.TopixDailyPrice.replaceDB(db),
 	}
 }
@@ -189,15 +249,25 @@ type queryCtx struct {
 	FinAnnouncement                   IFinAnnouncementDo
 	FinStatement                      IFinStatementDo
 	HighVolumeStockBrand              IHighVolumeStockBrandDo
+	MarketBreadth                     IMarketBreadthDo
+	MarketRegime                      IMarketRegimeDo
 	NikkeiStockAverageDailyPrice      INikkeiStockAverageDailyPriceDo
+	PaperAccount                      IPaperAccountDo
+	PaperFill                         IPaperFillDo
+	PaperNav                          IPaperNavDo
+	PaperPosition                     IPaperPositionDo
 	QuizAnswer                        IQuizAnswerDo
 	QuizDailyUniverse                 IQuizDailyUniverseDo
+	RelativeStrengthRating            IRelativeStrengthRatingDo
 	SchemaMigration                   ISchemaMigrationDo
 	Sector17AverageDailyPrice         ISector17AverageDailyPriceDo
 	Sector33AverageDailyPrice         ISector33AverageDailyPriceDo
 	StockBrand                        IStockBrandDo
 	StockBrandsDailyPrice             IStockBrandsDailyPriceDo
 	StockBrandsDailyPriceForAnalyze   IStockBrandsDailyPriceForAnalyzeDo
+	StrategyRankingRun                IStrategyRankingRunDo
+	StrategyRankingRunItem            IStrategyRankingRunItemDo
+	StrategyRankingRunStock           IStrategyRankingRunStockDo
 	TopixDailyPrice                   ITopixDailyPriceDo
 }
 
@@ -213,15 +283,25 @@ func (q *Query) WithContext(ctx context.Context) *queryCtx {
 		FinAnnouncement:                   q.FinAnnouncement.WithContext(ctx),
 		FinStatement:                      q.FinStatement.WithContext(ctx),
 		HighVolumeStockBrand:              q.HighVolumeStockBrand.WithContext(ctx),
+		MarketBreadth:                     q.MarketBreadth.WithContext(ctx),
+		MarketRegime:                      q.MarketRegime.WithContext(ctx),
 		NikkeiStockAverageDailyPrice:      q.NikkeiStockAverageDailyPrice.WithContext(ctx),
+		PaperAccount:                      q.PaperAccount.WithContext(ctx),
+		PaperFill:                         q.PaperFill.WithContext(ctx),
+		PaperNav:                          q.PaperNav.WithContext(ctx),
+		PaperPosition:                     q.PaperPosition.WithContext(ctx),
 		QuizAnswer:                        q.QuizAnswer.WithContext(ctx),
 		QuizDailyUniverse:                 q.QuizDailyUniverse.WithContext(ctx),
+		RelativeStrengthRating:            q.RelativeStrengthRating.WithContext(ctx),
 		SchemaMigration:                   q.SchemaMigration.WithContext(ctx),
 		Sector17AverageDailyPrice:         q.Sector17AverageDailyPrice.WithContext(ctx),
 		Sector33AverageDailyPrice:         q.Sector33AverageDailyPrice.WithContext(ctx),
 		StockBrand:                        q.StockBrand.WithContext(ctx),
 		StockBrandsDailyPrice:             q.StockBrandsDailyPrice.WithContext(ctx),
 		StockBrandsDailyPriceForAnalyze:   q.StockBrandsDailyPriceForAnalyze.WithContext(ctx),
+		StrategyRankingRun:                q.StrategyRankingRun.WithContext(ctx),
+		StrategyRankingRunItem:            q.StrategyRankingRunItem.WithContext(ctx),
+		StrategyRankingRunStock:           q.StrategyRankingRunStock.WithContext(ctx),
 		TopixDailyPrice:                   q.TopixDailyPrice.WithContext(ctx),
 	}
 }
@@ -0,0 +1,149 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../../mock/$GOPACKAGE/$GOFILE
+package database
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	genModel "github.com/Code0716/stock-price-repository/infrastructure/database/gen_model"
+	genQuery "github.com/Code0716/stock-price-repository/infrastructure/database/gen_query"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// marketBreadthBatchSize UpsertMarketBreadths の1 INSERT あたりの行数。
+const marketBreadthBatchSize = 500
+
+type MarketBreadthRepositoryImpl struct {
+	query *genQuery.Query
+}
+
+func NewMarketBreadthRepositoryImpl(db *gorm.DB) repositories.MarketBreadthRepository {
+	return &MarketBreadthRepositoryImpl{
+		query: genQuery.Use(db),
+	}
+}
+
+func (mi *MarketBreadthRepositoryImpl) UpsertMarketBreadths(ctx context.Context, breadths []*models.MarketBreadth) error {
+	tx := TxOrDefault(ctx, mi.query)
+
+	if len(breadths) == 0 {
+		return nil
+	}
+
+	rows := make([]*genModel.MarketBreadth, 0, len(breadths))
+	for _, b := range breadths {
+		rows = append(rows, mi.convertToDBModel(b))
+	}
+	if err := tx.MarketBreadth.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "date"}, {Name: "market"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"advancers",
+				"decliners",
+				"unchanged",
+				"ad_line",
+				"ad_ratio_25",
+				"new_highs",
+				"new_lows",
+				"above_ma25_pct",
+				"above_ma75_pct",
+				"above_ma200_pct",
+				"mcclellan_oscillator",
+				"advance_ema_19",
+				"advance_ema_39",
+				"updated_at",
+			}),
+		}).
+		CreateInBatches(rows, marketBreadthBatchSize); err != nil {
+		return errors.Wrap(err, "MarketBreadthRepositoryImpl.UpsertMarketBreadths error")
+	}
+	return nil
+}
+
+func (mi *MarketBreadthRepositoryImpl) ListMarketBreadths(ctx context.Context, filter models.MarketBreadthFilter) ([]*models.MarketBreadth, error) {
+	tx := TxOrDefault(ctx, mi.query)
+
+	q := tx.MarketBreadth.WithContext(ctx).
+		Where(tx.MarketBreadth.Market.Eq(filter.Market))
+	if filter.From != nil {
+		q = q.Where(tx.MarketBreadth.Date.Gte(dateOnlyOf(*filter.From)))
+	}
+	if filter.To != nil {
+		q = q.Where(tx.MarketBreadth.Date.Lte(dateOnlyOf(*filter.To)))
+	}
+
+	rows, err := q.Order(tx.MarketBreadth.Date).Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "MarketBreadthRepositoryImpl.ListMarketBreadths error")
+	}
+	return mi.convertToDomainModels(rows), nil
+}
+
+func (mi *MarketBreadthRepositoryImpl) ListMarketBreadthsBefore(ctx context.Context, market string, before time.Time, limit int) ([]*models.MarketBreadth, error) {
+	tx := TxOrDefault(ctx, mi.query)
+
+	rows, err := tx.MarketBreadth.WithContext(ctx).
+		Where(
+			tx.MarketBreadth.Market.Eq(market),
+			tx.MarketBreadth.Date.Lt(dateOnlyOf(before)),
+		).
+		Order(tx.MarketBreadth.Date.Desc()).
+		Limit(limit).
+		Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "MarketBreadthRepositoryImpl.ListMarketBreadthsBefore error")
+	}
+	slices.Reverse(rows)
+	return mi.convertToDomainModels(rows), nil
+}
+
+func (mi *MarketBreadthRepositoryImpl) convertToDomainModels(rows []*genModel.MarketBreadth) []*models.MarketBreadth {
+	breadths := make([]*models.MarketBreadth, 0, len(rows))
+	for _, m := range rows {
+		breadths = append(breadths, &models.MarketBreadth{
+			Date:                m.Date,
+			Market:              m.Market,
+			Advancers:           int(m.Advancers),
+			Decliners:           int(m.Decliners),
+			Unchanged:           int(m.Unchanged),
+			ADLine:              m.AdLine,
+			ADRatio25:           float64PtrToDecimalPtr(m.AdRatio25),
+			NewHighs:            int(m.NewHighs),
+			NewLows:             int(m.NewLows),
+			AboveMA25Pct:        float64PtrToDecimalPtr(m.AboveMa25Pct),
+			AboveMA75Pct:        float64PtrToDecimalPtr(m.AboveMa75Pct),
+			AboveMA200Pct:       float64PtrToDecimalPtr(m.AboveMa200Pct),
+			McClellanOscillator: decimal.NewFromFloat(m.McclellanOscillator),
+			AdvanceEMA19:        decimal.NewFromFloat(m.AdvanceEma19),
+			AdvanceEMA39:        decimal.NewFromFloat(m.AdvanceEma39),
+		})
+	}
+	return breadths
+}
+
+func (mi *MarketBreadthRepositoryImpl) convertToDBModel(b *models.MarketBreadth) *genModel.MarketBreadth {
+	return &genModel.MarketBreadth{
+		Date:                dateOnlyOf(b.Date),
+		Market:              b.Market,
+		Advancers:           uint32(b.Advancers),
+		Decliners:           uint32(b.Decliners),
+		Unchanged:           uint32(b.Unchanged),
+		AdLine:              b.ADLine,
+		AdRatio25:           decimalPtrToFloat64Ptr(b.ADRatio25),
+		NewHighs:            uint32(b.NewHighs),
+		NewLows:             uint32(b.NewLows),
+		AboveMa25Pct:        decimalPtrToFloat64Ptr(b.AboveMA25Pct),
+		AboveMa75Pct:        decimalPtrToFloat64Ptr(b.AboveMA75Pct),
+		AboveMa200Pct:       decimalPtrToFloat64Ptr(b.AboveMA200Pct),
+		McclellanOscillator: roundToFloat64(b.McClellanOscillator, 2),
+		AdvanceEma19:        roundToFloat64(b.AdvanceEMA19, 4),
+		AdvanceEma39:        roundToFloat64(b.AdvanceEMA39, 4),
+	}
+}
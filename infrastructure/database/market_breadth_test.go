@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+func TestMarketBreadthRepositoryImpl(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewMarketBreadthRepositoryImpl(db)
+	ctx := context.Background()
+	day := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, time.Local) }
+
+	breadth := func(date time.Time, market string, advancers int) *models.MarketBreadth {
+		adRatio := decimal.RequireFromString("105.25")
+		aboveMA25 := decimal.RequireFromString("55.5")
+		return &models.MarketBreadth{
+			Date:                date,
+			Market:              market,
+			Advancers:           advancers,
+			Decliners:           100,
+			Unchanged:           10,
+			ADLine:              int64(advancers - 100),
+			ADRatio25:           &adRatio,
+			NewHighs:            5,
+			NewLows:             3,
+			AboveMA25Pct:        &aboveMA25,
+			McClellanOscillator: decimal.RequireFromString("-12.34"),
+			AdvanceEMA19:        decimal.RequireFromString("10.1234"),
+			AdvanceEMA39:        decimal.RequireFromString("22.4634"),
+		}
+	}
+
+	require.NoError(t, repo.UpsertMarketBreadths(ctx, []*models.MarketBreadth{
+		breadth(day(22), models.MarketBreadthMarketAll, 120),
+		breadth(day(23), models.MarketBreadthMarketAll, 130),
+		breadth(day(24), models.MarketBreadthMarketAll, 140),
+		breadth(day(24), "111", 60),
+	}))
+
+	t.Run("指定市場を date 昇順で返し、NULL の指標は nil", func(t *testing.T) {
+		from, to := day(23), day(24)
+		got, err := repo.ListMarketBreadths(ctx, models.MarketBreadthFilter{Market: models.MarketBreadthMarketAll, From: &from, To: &to})
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "2026-07-23", util.DatetimeToDateStr(got[0].Date))
+		assert.Equal(t, "2026-07-24", util.DatetimeToDateStr(got[1].Date))
+		assert.Equal(t, 140, got[1].Advancers)
+		assert.Equal(t, int64(40), got[1].ADLine)
+		assert.True(t, got[1].ADRatio25.Equal(decimal.RequireFromString("105.25")))
+		assert.True(t, got[1].AboveMA25Pct.Equal(decimal.RequireFromString("55.5")))
+		assert.Nil(t, got[1].AboveMA75Pct)
+		assert.Nil(t, got[1].AboveMA200Pct)
+		assert.True(t, got[1].McClellanOscillator.Equal(decimal.RequireFromString("-12.34")))
+		assert.True(t, got[1].AdvanceEMA39.Equal(decimal.RequireFromString("22.4634")))
+	})
+
+	t.Run("before より前の直近 limit 営業日を date 昇順で返す", func(t *testing.T) {
+		got, err := repo.ListMarketBreadthsBefore(ctx, models.MarketBreadthMarketAll, day(24), 1)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "2026-07-23", util.DatetimeToDateStr(got[0].Date))
+
+		got, err = repo.ListMarketBreadthsBefore(ctx, models.MarketBreadthMarketAll, day(25), 5)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, "2026-07-22", util.DatetimeToDateStr(got[0].Date))
+		assert.Equal(t, "2026-07-24", util.DatetimeToDateStr(got[2].Date))
+	})
+
+	t.Run("同じ日・市場は上書きする", func(t *testing.T) {
+		updated := breadth(day(24), "111", 70)
+		updated.AboveMA25Pct = nil
+		require.NoError(t, repo.UpsertMarketBreadths(ctx, []*models.MarketBreadth{updated}))
+
+		got, err := repo.ListMarketBreadths(ctx, models.MarketBreadthFilter{Market: "111"})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, 70, got[0].Advancers)
+		assert.Nil(t, got[0].AboveMA25Pct)
+	})
+}
@@ -0,0 +1,130 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../../mock/$GOPACKAGE/$GOFILE
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	genModel "github.com/Code0716/stock-price-repository/infrastructure/database/gen_model"
+	genQuery "github.com/Code0716/stock-price-repository/infrastructure/database/gen_query"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// marketRegimeBatchSize UpsertMarketRegimes の1 INSERT あたりの行数。
+const marketRegimeBatchSize = 500
+
+type MarketRegimeRepositoryImpl struct {
+	query *genQuery.Query
+}
+
+func NewMarketRegimeRepositoryImpl(db *gorm.DB) repositories.MarketRegimeRepository {
+	return &MarketRegimeRepositoryImpl{
+		query: genQuery.Use(db),
+	}
+}
+
+func (mi *MarketRegimeRepositoryImpl) UpsertMarketRegimes(ctx context.Context, regimes []*models.MarketRegime) error {
+	tx := TxOrDefault(ctx, mi.query)
+
+	if len(regimes) == 0 {
+		return nil
+	}
+
+	rows := make([]*genModel.MarketRegime, 0, len(regimes))
+	for _, r := range regimes {
+		rows = append(rows, mi.convertToDBModel(r))
+	}
+	if err := tx.MarketRegime.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"regime",
+				"topix_close",
+				"topix_ma25",
+				"topix_ma75",
+				"topix_ma75_slope",
+				"nikkei_above_ma75",
+				"atr_ratio",
+				"atr_ratio_baseline",
+				"breadth_above_ma75_pct",
+				"updated_at",
+			}),
+		}).
+		CreateInBatches(rows, marketRegimeBatchSize); err != nil {
+		return errors.Wrap(err, "MarketRegimeRepositoryImpl.UpsertMarketRegimes error")
+	}
+	return nil
+}
+
+func (mi *MarketRegimeRepositoryImpl) ListMarketRegimes(ctx context.Context, filter models.MarketRegimeFilter) ([]*models.MarketRegime, error) {
+	tx := TxOrDefault(ctx, mi.query)
+
+	q := tx.MarketRegime.WithContext(ctx)
+	if filter.From != nil {
+		q = q.Where(tx.MarketRegime.Date.Gte(dateOnlyOf(*filter.From)))
+	}
+	if filter.To != nil {
+		q = q.Where(tx.MarketRegime.Date.Lte(dateOnlyOf(*filter.To)))
+	}
+
+	rows, err := q.Order(tx.MarketRegime.Date).Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "MarketRegimeRepositoryImpl.ListMarketRegimes error")
+	}
+	regimes := make([]*models.MarketRegime, 0, len(rows))
+	for _, m := range rows {
+		regimes = append(regimes, mi.convertToDomainModel(m))
+	}
+	return regimes, nil
+}
+
+func (mi *MarketRegimeRepositoryImpl) FindMarketRegimeByDate(ctx context.Context, date time.Time) (*models.MarketRegime, error) {
+	tx := TxOrDefault(ctx, mi.query)
+
+	row, err := tx.MarketRegime.WithContext(ctx).
+		Where(tx.MarketRegime.Date.Eq(dateOnlyOf(date))).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "MarketRegimeRepositoryImpl.FindMarketRegimeByDate error")
+	}
+	return mi.convertToDomainModel(row), nil
+}
+
+func (mi *MarketRegimeRepositoryImpl) convertToDomainModel(m *genModel.MarketRegime) *models.MarketRegime {
+	return &models.MarketRegime{
+		Date:                m.Date,
+		Regime:              m.Regime,
+		TopixClose:          decimal.NewFromFloat(m.TopixClose),
+		TopixMA25:           decimal.NewFromFloat(m.TopixMa25),
+		TopixMA75:           decimal.NewFromFloat(m.TopixMa75),
+		TopixMA75Slope:      decimal.NewFromFloat(m.TopixMa75Slope),
+		NikkeiAboveMA75:     m.NikkeiAboveMa75,
+		ATRRatio:            decimal.NewFromFloat(m.AtrRatio),
+		ATRRatioBaseline:    decimal.NewFromFloat(m.AtrRatioBaseline),
+		BreadthAboveMA75Pct: float64PtrToDecimalPtr(m.BreadthAboveMa75Pct),
+	}
+}
+
+func (mi *MarketRegimeRepositoryImpl) convertToDBModel(r *models.MarketRegime) *genModel.MarketRegime {
+	return &genModel.MarketRegime{
+		Date:                dateOnlyOf(r.Date),
+		Regime:              r.Regime,
+		TopixClose:          roundToFloat64(r.TopixClose, 2),
+		TopixMa25:           roundToFloat64(r.TopixMA25, 4),
+		TopixMa75:           roundToFloat64(r.TopixMA75, 4),
+		TopixMa75Slope:      roundToFloat64(r.TopixMA75Slope, 6),
+		NikkeiAboveMa75:     r.NikkeiAboveMA75,
+		AtrRatio:            roundToFloat64(r.ATRRatio, 6),
+		AtrRatioBaseline:    roundToFloat64(r.ATRRatioBaseline, 6),
+		BreadthAboveMa75Pct: decimalPtrToFloat64Ptr(r.BreadthAboveMA75Pct),
+	}
+}
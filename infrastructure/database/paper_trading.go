@@ -0,0 +1,311 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../../mock/$GOPACKAGE/$GOFILE
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	genModel "github.com/Code0716/stock-price-repository/infrastructure/database/gen_model"
+	genQuery "github.com/Code0716/stock-price-repository/infrastructure/database/gen_query"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+type PaperTradingRepositoryImpl struct {
+	query *genQuery.Query
+}
+
+func NewPaperTradingRepositoryImpl(db *gorm.DB) repositories.PaperTradingRepository {
+	return &PaperTradingRepositoryImpl{
+		query: genQuery.Use(db),
+	}
+}
+
+func (pi *PaperTradingRepositoryImpl) FindAccount(ctx context.Context, name string) (*models.PaperAccount, error) {
+	tx := TxOrDefault(ctx, pi.query)
+
+	row, err := tx.PaperAccount.WithContext(ctx).
+		Where(tx.PaperAccount.Name.Eq(name)).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PaperTradingRepositoryImpl.FindAccount error")
+	}
+	return &models.PaperAccount{
+		Name:          row.Name,
+		ScoreVersion:  row.ScoreVersion,
+		TopN:          int(row.TopN),
+		BudgetPerPick: decimal.NewFromFloat(row.BudgetPerPick),
+		HoldingDays:   int(row.HoldingDays),
+		TakeProfitPct: float64PtrToDecimalPtr(row.TakeProfitPct),
+		StopLossPct:   float64PtrToDecimalPtr(row.StopLossPct),
+		InitialCash:   decimal.NewFromFloat(row.InitialCash),
+		StartDate:     row.StartDate,
+	}, nil
+}
+
+func (pi *PaperTradingRepositoryImpl) CreateAccount(ctx context.Context, account *models.PaperAccount) error {
+	tx := TxOrDefault(ctx, pi.query)
+
+	if err := tx.PaperAccount.WithContext(ctx).Create(&genModel.PaperAccount{
+		Name:          account.Name,
+		ScoreVersion:  account.ScoreVersion,
+		TopN:          uint32(account.TopN),
+		BudgetPerPick: roundToFloat64(account.BudgetPerPick, 2),
+		HoldingDays:   uint32(account.HoldingDays),
+		TakeProfitPct: decimalPtrToFloat64Ptr(account.TakeProfitPct),
+		StopLossPct:   decimalPtrToFloat64Ptr(account.StopLossPct),
+		InitialCash:   roundToFloat64(account.InitialCash, 2),
+		StartDate:     dateOnlyOf(account.StartDate),
+	}); err != nil {
+		return errors.Wrap(err, "PaperTradingRepositoryImpl.CreateAccount error")
+	}
+	return nil
+}
+
+func (pi *PaperTradingRepositoryImpl) CreatePositions(ctx context.Context, positions []*models.PaperPosition) error {
+	tx := TxOrDefault(ctx, pi.query)
+
+	if len(positions) == 0 {
+		return nil
+	}
+	rows := make([]*genModel.PaperPosition, 0, len(positions))
+	for _, p := range positions {
+		rows = append(rows, pi.convertPositionToDBModel(p))
+	}
+	if err := tx.PaperPosition.WithContext(ctx).Create(rows...); err != nil {
+		return errors.Wrap(err, "PaperTradingRepositoryImpl.CreatePositions error")
+	}
+	for i, r := range rows {
+		positions[i].ID = r.ID
+	}
+	return nil
+}
+
+func (pi *PaperTradingRepositoryImpl) UpdatePositions(ctx context.Context, positions []*models.PaperPosition) error {
+	tx := TxOrDefault(ctx, pi.query)
+
+	for _, p := range positions {
+		m := pi.convertPositionToDBModel(p)
+		if _, err := tx.PaperPosition.WithContext(ctx).
+			Where(tx.PaperPosition.ID.Eq(p.ID)).
+			Updates(map[string]any{
+				"status":      m.Status,
+				"held_days":   m.HeldDays,
+				"mark_date":   m.MarkDate,
+				"mark_price":  m.MarkPrice,
+				"exit_date":   m.ExitDate,
+				"exit_price":  m.ExitPrice,
+				"exit_reason": m.ExitReason,
+			}); err != nil {
+			return errors.Wrap(err, "PaperTradingRepositoryImpl.UpdatePositions error")
+		}
+	}
+	return nil
+}
+
+func (pi *PaperTradingRepositoryImpl) ListPositions(ctx context.Context, filter models.PaperPositionFilter) ([]*models.PaperPosition, error) {
+	tx := TxOrDefault(ctx, pi.query)
+
+	q := tx.PaperPosition.WithContext(ctx).
+		Where(tx.PaperPosition.Account.Eq(filter.Account))
+	if filter.Status != nil {
+		q = q.Where(tx.PaperPosition.Status.Eq(*filter.Status))
+	}
+	if filter.ExitFrom != nil {
+		q = q.Where(tx.PaperPosition.ExitDate.Gte(dateOnlyOf(*filter.ExitFrom)))
+	}
+	if filter.ExitTo != nil {
+		q = q.Where(tx.PaperPosition.ExitDate.Lte(dateOnlyOf(*filter.ExitTo)))
+	}
+
+	rows, err := q.Order(tx.PaperPosition.EntryDate, tx.PaperPosition.PickRank).Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "PaperTradingRepositoryImpl.ListPositions error")
+	}
+	positions := make([]*models.PaperPosition, 0, len(rows))
+	for _, r := range rows {
+		positions = append(positions, pi.convertPositionToDomainModel(r))
+	}
+	return positions, nil
+}
+
+func (pi *PaperTradingRepositoryImpl) CreateFills(ctx context.Context, fills []*models.PaperFill) error {
+	tx := TxOrDefault(ctx, pi.query)
+
+	if len(fills) == 0 {
+		return nil
+	}
+	rows := make([]*genModel.PaperFill, 0, len(fills))
+	for _, f := range fills {
+		rows = append(rows, &genModel.PaperFill{
+			Account:      f.Account,
+			PositionID:   f.PositionID,
+			FillDate:     dateOnlyOf(f.FillDate),
+			TickerSymbol: f.TickerSymbol,
+			Side:         f.Side,
+			Quantity:     uint32(f.Quantity),
+			Price:        roundToFloat64(f.Price, 4),
+			Amount:       roundToFloat64(f.Amount, 2),
+			Reason:       f.Reason,
+		})
+	}
+	if err := tx.PaperFill.WithContext(ctx).Create(rows...); err != nil {
+		return errors.Wrap(err, "PaperTradingRepositoryImpl.CreateFills error")
+	}
+	return nil
+}
+
+func (pi *PaperTradingRepositoryImpl) ListFills(ctx context.Context, account string, from, to *time.Time) ([]*models.PaperFill, error) {
+	tx := TxOrDefault(ctx, pi.query)
+
+	q := tx.PaperFill.WithContext(ctx).
+		Where(tx.PaperFill.Account.Eq(account))
+	if from != nil {
+		q = q.Where(tx.PaperFill.FillDate.Gte(dateOnlyOf(*from)))
+	}
+	if to != nil {
+		q = q.Where(tx.PaperFill.FillDate.Lte(dateOnlyOf(*to)))
+	}
+
+	rows, err := q.Order(tx.PaperFill.FillDate, tx.PaperFill.ID).Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "PaperTradingRepositoryImpl.ListFills error")
+	}
+	fills := make([]*models.PaperFill, 0, len(rows))
+	for _, r := range rows {
+		fills = append(fills, &models.PaperFill{
+			ID:           r.ID,
+			Account:      r.Account,
+			PositionID:   r.PositionID,
+			FillDate:     r.FillDate,
+			TickerSymbol: r.TickerSymbol,
+			Side:         r.Side,
+			Quantity:     int(r.Quantity),
+			Price:        decimal.NewFromFloat(r.Price),
+			Amount:       decimal.NewFromFloat(r.Amount),
+			Reason:       r.Reason,
+		})
+	}
+	return fills, nil
+}
+
+func (pi *PaperTradingRepositoryImpl) CreateNav(ctx context.Context, nav *models.PaperNav) error {
+	tx := TxOrDefault(ctx, pi.query)
+
+	if err := tx.PaperNav.WithContext(ctx).Create(&genModel.PaperNav{
+		Account:       nav.Account,
+		Date:          dateOnlyOf(nav.Date),
+		Cash:          roundToFloat64(nav.Cash, 2),
+		MarketValue:   roundToFloat64(nav.MarketValue, 2),
+		Nav:           roundToFloat64(nav.Nav, 2),
+		OpenPositions: uint32(nav.OpenPositions),
+		RealizedPnl:   roundToFloat64(nav.RealizedPnL, 2),
+	}); err != nil {
+		return errors.Wrap(err, "PaperTradingRepositoryImpl.CreateNav error")
+	}
+	return nil
+}
+
+func (pi *PaperTradingRepositoryImpl) FindLatestNav(ctx context.Context, account string) (*models.PaperNav, error) {
+	tx := TxOrDefault(ctx, pi.query)
+
+	row, err := tx.PaperNav.WithContext(ctx).
+		Where(tx.PaperNav.Account.Eq(account)).
+		Order(tx.PaperNav.Date.Desc()).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "PaperTradingRepositoryImpl.FindLatestNav error")
+	}
+	return pi.convertNavToDomainModel(row), nil
+}
+
+func (pi *PaperTradingRepositoryImpl) ListNavs(ctx context.Context, account string, from, to *time.Time) ([]*models.PaperNav, error) {
+	tx := TxOrDefault(ctx, pi.query)
+
+	q := tx.PaperNav.WithContext(ctx).
+		Where(tx.PaperNav.Account.Eq(account))
+	if from != nil {
+		q = q.Where(tx.PaperNav.Date.Gte(dateOnlyOf(*from)))
+	}
+	if to != nil {
+		q = q.Where(tx.PaperNav.Date.Lte(dateOnlyOf(*to)))
+	}
+
+	rows, err := q.Order(tx.PaperNav.Date).Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "PaperTradingRepositoryImpl.ListNavs error")
+	}
+	navs := make([]*models.PaperNav, 0, len(rows))
+	for _, r := range rows {
+		navs = append(navs, pi.convertNavToDomainModel(r))
+	}
+	return navs, nil
+}
+
+func (pi *PaperTradingRepositoryImpl) convertPositionToDomainModel(m *genModel.PaperPosition) *models.PaperPosition {
+	return &models.PaperPosition{
+		ID:           m.ID,
+		Account:      m.Account,
+		PickDate:     m.PickDate,
+		StockBrandID: m.StockBrandID,
+		TickerSymbol: m.TickerSymbol,
+		PickRank:     int(m.PickRank),
+		Status:       m.Status,
+		EntryDate:    m.EntryDate,
+		EntryPrice:   decimal.NewFromFloat(m.EntryPrice),
+		Quantity:     int(m.Quantity),
+		HeldDays:     int(m.HeldDays),
+		MarkDate:     m.MarkDate,
+		MarkPrice:    decimal.NewFromFloat(m.MarkPrice),
+		ExitDate:     m.ExitDate,
+		ExitPrice:    float64PtrToDecimalPtr(m.ExitPrice),
+		ExitReason:   m.ExitReason,
+	}
+}
+
+func (pi *PaperTradingRepositoryImpl) convertPositionToDBModel(p *models.PaperPosition) *genModel.PaperPosition {
+	m := &genModel.PaperPosition{
+		ID:           p.ID,
+		Account:      p.Account,
+		PickDate:     dateOnlyOf(p.PickDate),
+		StockBrandID: p.StockBrandID,
+		TickerSymbol: p.TickerSymbol,
+		PickRank:     uint32(p.PickRank),
+		Status:       p.Status,
+		EntryDate:    dateOnlyOf(p.EntryDate),
+		EntryPrice:   roundToFloat64(p.EntryPrice, 4),
+		Quantity:     uint32(p.Quantity),
+		HeldDays:     uint32(p.HeldDays),
+		MarkDate:     dateOnlyOf(p.MarkDate),
+		MarkPrice:    roundToFloat64(p.MarkPrice, 4),
+		ExitPrice:    decimalPtrToFloat64Ptr(p.ExitPrice),
+		ExitReason:   p.ExitReason,
+	}
+	if p.ExitDate != nil {
+		d := dateOnlyOf(*p.ExitDate)
+		m.ExitDate = &d
+	}
+	return m
+}
+
+func (pi *PaperTradingRepositoryImpl) convertNavToDomainModel(m *genModel.PaperNav) *models.PaperNav {
+	return &models.PaperNav{
+		Account:       m.Account,
+		Date:          m.Date,
+		Cash:          decimal.NewFromFloat(m.Cash),
+		MarketValue:   decimal.NewFromFloat(m.MarketValue),
+		Nav:           decimal.NewFromFloat(m.Nav),
+		OpenPositions: int(m.OpenPositions),
+		RealizedPnL:   decimal.NewFromFloat(m.RealizedPnl),
+	}
+}
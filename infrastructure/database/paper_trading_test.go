@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+func TestPaperTradingRepositoryImpl_Account(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewPaperTradingRepositoryImpl(db)
+	ctx := context.Background()
+
+	notFound, err := repo.FindAccount(ctx, models.PaperAccountDefaultName)
+	require.NoError(t, err)
+	assert.Nil(t, notFound)
+
+	stopLoss := decimal.RequireFromString("0.05")
+	require.NoError(t, repo.CreateAccount(ctx, &models.PaperAccount{
+		Name:          models.PaperAccountDefaultName,
+		ScoreVersion:  "v1",
+		TopN:          5,
+		BudgetPerPick: decimal.RequireFromString("300000"),
+		HoldingDays:   5,
+		StopLossPct:   &stopLoss,
+		InitialCash:   decimal.RequireFromString("3000000"),
+		StartDate:     time.Date(2026, 7, 21, 0, 0, 0, 0, time.Local),
+	}))
+
+	got, err := repo.FindAccount(ctx, models.PaperAccountDefaultName)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "v1", got.ScoreVersion)
+	assert.Equal(t, 5, got.TopN)
+	assert.Equal(t, 5, got.HoldingDays)
+	assert.True(t, got.BudgetPerPick.Equal(decimal.RequireFromString("300000")))
+	assert.True(t, got.InitialCash.Equal(decimal.RequireFromString("3000000")))
+	assert.Nil(t, got.TakeProfitPct)
+	assert.True(t, got.StopLossPct.Equal(stopLoss))
+	assert.Equal(t, "2026-07-21", util.DatetimeToDateStr(got.StartDate))
+}
+
+func TestPaperTradingRepositoryImpl_PositionsAndFills(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewPaperTradingRepositoryImpl(db)
+	ctx := context.Background()
+	account := models.PaperAccountDefaultName
+	pickDate := time.Date(2026, 7, 21, 0, 0, 0, 0, time.Local)
+	entryDate := time.Date(2026, 7, 22, 0, 0, 0, 0, time.Local)
+	exitDate := time.Date(2026, 7, 24, 0, 0, 0, 0, time.Local)
+
+	newPosition := func(symbol string, rank int) *models.PaperPosition {
+		return &models.PaperPosition{
+			Account:      account,
+			PickDate:     pickDate,
+			StockBrandID: "00000000-0000-0000-0000-00000000" + symbol,
+			TickerSymbol: symbol,
+			PickRank:     rank,
+			Status:       models.PaperPositionStatusOpen,
+			EntryDate:    entryDate,
+			EntryPrice:   decimal.RequireFromString("2500.5"),
+			Quantity:     100,
+			HeldDays:     1,
+			MarkDate:     entryDate,
+			MarkPrice:    decimal.RequireFromString("2510"),
+		}
+	}
+	// 推奨順位の逆順で作成しても ListPositions は推奨順位の昇順で返す
+	positions := []*models.PaperPosition{newPosition("6758", 2), newPosition("7203", 1)}
+	require.NoError(t, repo.CreatePositions(ctx, positions))
+	require.NotZero(t, positions[0].ID)
+	require.NotZero(t, positions[1].ID)
+
+	t.Run("作成したポジションを買付日・推奨順位の昇順で返す", func(t *testing.T) {
+		got, err := repo.ListPositions(ctx, models.PaperPositionFilter{Account: account})
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "7203", got[0].TickerSymbol)
+		assert.Equal(t, positions[1].ID, got[0].ID)
+		assert.True(t, got[0].EntryPrice.Equal(decimal.RequireFromString("2500.5")))
+		assert.Nil(t, got[0].ExitDate)
+		assert.Nil(t, got[0].ExitPrice)
+		assert.Nil(t, got[0].ExitReason)
+	})
+
+	t.Run("手仕舞いを反映し、状態と売却日で絞り込む", func(t *testing.T) {
+		closed := positions[0]
+		exitPrice := decimal.RequireFromString("2400")
+		reason := models.PaperExitReasonStopLoss
+		closed.Status = models.PaperPositionStatusClosed
+		closed.HeldDays = 3
+		closed.MarkDate = exitDate
+		closed.MarkPrice = exitPrice
+		closed.ExitDate = &exitDate
+		closed.ExitPrice = &exitPrice
+		closed.ExitReason = &reason
+		require.NoError(t, repo.UpdatePositions(ctx, []*models.PaperPosition{closed}))
+
+		status := models.PaperPositionStatusClosed
+		got, err := repo.ListPositions(ctx, models.PaperPositionFilter{Account: account, Status: &status, ExitFrom: &exitDate, ExitTo: &exitDate})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "6758", got[0].TickerSymbol)
+		assert.Equal(t, 3, got[0].HeldDays)
+		require.NotNil(t, got[0].ExitDate)
+		assert.Equal(t, "2026-07-24", util.DatetimeToDateStr(*got[0].ExitDate))
+		assert.True(t, got[0].ExitPrice.Equal(exitPrice))
+		assert.Equal(t, models.PaperExitReasonStopLoss, *got[0].ExitReason)
+
+		status = models.PaperPositionStatusOpen
+		open, err := repo.ListPositions(ctx, models.PaperPositionFilter{Account: account, Status: &status})
+		require.NoError(t, err)
+		require.Len(t, open, 1)
+		assert.Equal(t, "7203", open[0].TickerSymbol)
+	})
+
+	t.Run("約定を約定日・ID の昇順で返し、期間で絞り込む", func(t *testing.T) {
+		require.NoError(t, repo.CreateFills(ctx, []*models.PaperFill{
+			{Account: account, PositionID: positions[1].ID, FillDate: entryDate, TickerSymbol: "7203", Side: models.PaperFillSideBuy,
+				Quantity: 100, Price: decimal.RequireFromString("2500.5"), Amount: decimal.RequireFromString("250050")},
+			{Account: account, PositionID: positions[0].ID, FillDate: exitDate, TickerSymbol: "6758", Side: models.PaperFillSideSell,
+				Quantity: 100, Price: decimal.RequireFromString("2400"), Amount: decimal.RequireFromString("240000"), Reason: models.PaperExitReasonStopLoss},
+			{Account: account, PositionID: positions[0].ID, FillDate: entryDate, TickerSymbol: "6758", Side: models.PaperFillSideBuy,
+				Quantity: 100, Price: decimal.RequireFromString("2500.5"), Amount: decimal.RequireFromString("250050")},
+		}))
+
+		got, err := repo.ListFills(ctx, account, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, "7203", got[0].TickerSymbol)
+		assert.Equal(t, "6758", got[1].TickerSymbol)
+		assert.Equal(t, models.PaperFillSideSell, got[2].Side)
+		assert.Equal(t, models.PaperExitReasonStopLoss, got[2].Reason)
+		assert.True(t, got[2].Amount.Equal(decimal.RequireFromString("240000")))
+
+		got, err = repo.ListFills(ctx, account, &exitDate, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, positions[0].ID, got[0].PositionID)
+	})
+}
+
+func TestPaperTradingRepositoryImpl_Navs(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewPaperTradingRepositoryImpl(db)
+	ctx := context.Background()
+	account := models.PaperAccountDefaultName
+	day := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, time.Local) }
+
+	latest, err := repo.FindLatestNav(ctx, account)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	for i, d := range []int{22, 23, 24} {
+		require.NoError(t, repo.CreateNav(ctx, &models.PaperNav{
+			Account:       account,
+			Date:          day(d),
+			Cash:          decimal.RequireFromString("2500000"),
+			MarketValue:   decimal.NewFromInt(int64(500000 + i*10000)),
+			Nav:           decimal.NewFromInt(int64(3000000 + i*10000)),
+			OpenPositions: 2,
+			RealizedPnL:   decimal.RequireFromString("-10050.5"),
+		}))
+	}
+	// 別口座の NAV は混ざらない
+	require.NoError(t, repo.CreateNav(ctx, &models.PaperNav{Account: "other", Date: day(25), Nav: decimal.NewFromInt(1)}))
+
+	latest, err = repo.FindLatestNav(ctx, account)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "2026-07-24", util.DatetimeToDateStr(latest.Date))
+	assert.True(t, latest.Nav.Equal(decimal.NewFromInt(3020000)))
+	assert.True(t, latest.RealizedPnL.Equal(decimal.RequireFromString("-10050.5")))
+	assert.Equal(t, 2, latest.OpenPositions)
+
+	from, to := day(23), day(24)
+	navs, err := repo.ListNavs(ctx, account, &from, &to)
+	require.NoError(t, err)
+	require.Len(t, navs, 2)
+	assert.Equal(t, "2026-07-23", util.DatetimeToDateStr(navs[0].Date))
+	assert.Equal(t, "2026-07-24", util.DatetimeToDateStr(navs[1].Date))
+
+	// 同じ口座・日の NAV は主キー重複で作成できない
+	assert.Error(t, repo.CreateNav(ctx, &models.PaperNav{Account: account, Date: day(24)}))
+}
@@ -0,0 +1,161 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../../mock/$GOPACKAGE/$GOFILE
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	genModel "github.com/Code0716/stock-price-repository/infrastructure/database/gen_model"
+	genQuery "github.com/Code0716/stock-price-repository/infrastructure/database/gen_query"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// relativeStrengthBatchSize UpsertRatings の1 INSERT あたりの行数（主要市場全銘柄で約4000行）。
+const relativeStrengthBatchSize = 500
+
+type RelativeStrengthRepositoryImpl struct {
+	query *genQuery.Query
+}
+
+func NewRelativeStrengthRepositoryImpl(db *gorm.DB) repositories.RelativeStrengthRepository {
+	return &RelativeStrengthRepositoryImpl{
+		query: genQuery.Use(db),
+	}
+}
+
+func (ri *RelativeStrengthRepositoryImpl) UpsertRatings(ctx context.Context, ratings []*models.RelativeStrengthRating) error {
+	tx := TxOrDefault(ctx, ri.query)
+
+	if len(ratings) == 0 {
+		return nil
+	}
+
+	rows := make([]*genModel.RelativeStrengthRating, 0, len(ratings))
+	for _, r := range ratings {
+		rows = append(rows, ri.convertToDBModel(r))
+	}
+	if err := tx.RelativeStrengthRating.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "date"}, {Name: "ticker_symbol"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"name",
+				"rating",
+				"score",
+				"relative_3m",
+				"relative_6m",
+				"relative_9m",
+				"relative_12m",
+				"updated_at",
+			}),
+		}).
+		CreateInBatches(rows, relativeStrengthBatchSize); err != nil {
+		return errors.Wrap(err, "RelativeStrengthRepositoryImpl.UpsertRatings error")
+	}
+	return nil
+}
+
+func (ri *RelativeStrengthRepositoryImpl) DeleteByDate(ctx context.Context, date time.Time) error {
+	tx := TxOrDefault(ctx, ri.query)
+
+	if _, err := tx.RelativeStrengthRating.WithContext(ctx).
+		Where(tx.RelativeStrengthRating.Date.Eq(dateOnlyOf(date))).
+		Delete(); err != nil {
+		return errors.Wrap(err, "RelativeStrengthRepositoryImpl.DeleteByDate error")
+	}
+	return nil
+}
+
+func (ri *RelativeStrengthRepositoryImpl) FindLatestDate(ctx context.Context, onOrBefore *time.Time) (*time.Time, error) {
+	tx := TxOrDefault(ctx, ri.query)
+
+	q := tx.RelativeStrengthRating.WithContext(ctx)
+	if onOrBefore != nil {
+		q = q.Where(tx.RelativeStrengthRating.Date.Lte(dateOnlyOf(*onOrBefore)))
+	}
+	row, err := q.Order(tx.RelativeStrengthRating.Date.Desc()).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "RelativeStrengthRepositoryImpl.FindLatestDate error")
+	}
+	return &row.Date, nil
+}
+
+func (ri *RelativeStrengthRepositoryImpl) List(ctx context.Context, filter models.RelativeStrengthFilter) ([]*models.RelativeStrengthRating, error) {
+	tx := TxOrDefault(ctx, ri.query)
+
+	q := tx.RelativeStrengthRating.WithContext(ctx).
+		Where(tx.RelativeStrengthRating.Date.Eq(dateOnlyOf(filter.Date)))
+	if filter.MinRating > 0 {
+		q = q.Where(tx.RelativeStrengthRating.Rating.Gte(uint32(filter.MinRating)))
+	}
+	if len(filter.Symbols) > 0 {
+		q = q.Where(tx.RelativeStrengthRating.TickerSymbol.In(filter.Symbols...))
+	}
+
+	rows, err := q.Order(tx.RelativeStrengthRating.Rating.Desc(), tx.RelativeStrengthRating.TickerSymbol).Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "RelativeStrengthRepositoryImpl.List error")
+	}
+
+	ratings := make([]*models.RelativeStrengthRating, 0, len(rows))
+	for _, r := range rows {
+		ratings = append(ratings, ri.convertToDomainModel(r))
+	}
+	return ratings, nil
+}
+
+func (ri *RelativeStrengthRepositoryImpl) convertToDomainModel(m *genModel.RelativeStrengthRating) *models.RelativeStrengthRating {
+	relative3M := decimal.NewFromFloat(m.Relative3m)
+	return &models.RelativeStrengthRating{
+		TickerSymbol: m.TickerSymbol,
+		Name:         m.Name,
+		Date:         m.Date,
+		Rating:       int(m.Rating),
+		Score:        decimal.NewFromFloat(m.Score),
+		Relative3M:   &relative3M,
+		Relative6M:   float64PtrToDecimalPtr(m.Relative6m),
+		Relative9M:   float64PtrToDecimalPtr(m.Relative9m),
+		Relative12M:  float64PtrToDecimalPtr(m.Relative12m),
+	}
+}
+
+func (ri *RelativeStrengthRepositoryImpl) convertToDBModel(r *models.RelativeStrengthRating) *genModel.RelativeStrengthRating {
+	m := &genModel.RelativeStrengthRating{
+		Date:         dateOnlyOf(r.Date),
+		TickerSymbol: r.TickerSymbol,
+		Name:         r.Name,
+		Rating:       uint32(r.Rating),
+		Score:        roundToFloat64(r.Score, 6),
+		Relative6m:   decimalPtrToFloat64Ptr(r.Relative6M),
+		Relative9m:   decimalPtrToFloat64Ptr(r.Relative9M),
+		Relative12m:  decimalPtrToFloat64Ptr(r.Relative12M),
+	}
+	if r.Relative3M != nil {
+		m.Relative3m = roundToFloat64(*r.Relative3M, 6)
+	}
+	return m
+}
+
+func float64PtrToDecimalPtr(v *float64) *decimal.Decimal {
+	if v == nil {
+		return nil
+	}
+	d := decimal.NewFromFloat(*v)
+	return &d
+}
+
+func decimalPtrToFloat64Ptr(d *decimal.Decimal) *float64 {
+	if d == nil {
+		return nil
+	}
+	v := roundToFloat64(*d, 6)
+	return &v
+}
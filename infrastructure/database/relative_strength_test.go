@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+func TestRelativeStrengthRepositoryImpl(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewRelativeStrengthRepositoryImpl(db)
+	ctx := context.Background()
+	prevDate := time.Date(2026, 7, 23, 0, 0, 0, 0, time.Local)
+	date := time.Date(2026, 7, 24, 0, 0, 0, 0, time.Local)
+
+	rating := func(d time.Time, symbol string, r int) *models.RelativeStrengthRating {
+		relative3M := decimal.RequireFromString("0.12")
+		relative6M := decimal.RequireFromString("0.2")
+		return &models.RelativeStrengthRating{
+			TickerSymbol: symbol,
+			Name:         "銘柄" + symbol,
+			Date:         d,
+			Rating:       r,
+			Score:        decimal.RequireFromString("0.15"),
+			Relative3M:   &relative3M,
+			Relative6M:   &relative6M,
+		}
+	}
+
+	require.NoError(t, repo.UpsertRatings(ctx, []*models.RelativeStrengthRating{
+		rating(prevDate, "7203", 50),
+		rating(date, "7203", 90),
+		rating(date, "6758", 40),
+		rating(date, "9984", 90),
+	}))
+
+	t.Run("rating 降順・同値は銘柄コード昇順で返し、NULL の期間は nil", func(t *testing.T) {
+		got, err := repo.List(ctx, models.RelativeStrengthFilter{Date: date})
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, "7203", got[0].TickerSymbol)
+		assert.Equal(t, "9984", got[1].TickerSymbol)
+		assert.Equal(t, "6758", got[2].TickerSymbol)
+		assert.Equal(t, 90, got[0].Rating)
+		assert.True(t, got[0].Relative6M.Equal(decimal.RequireFromString("0.2")))
+		assert.Nil(t, got[0].Relative9M)
+		assert.Nil(t, got[0].Relative12M)
+	})
+
+	t.Run("minRating と銘柄で絞り込む", func(t *testing.T) {
+		got, err := repo.List(ctx, models.RelativeStrengthFilter{Date: date, MinRating: 80, Symbols: []string{"9984", "6758"}})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "9984", got[0].TickerSymbol)
+	})
+
+	t.Run("指定日以前で最新の算出日を返す", func(t *testing.T) {
+		latest, err := repo.FindLatestDate(ctx, nil)
+		require.NoError(t, err)
+		require.NotNil(t, latest)
+		assert.Equal(t, "2026-07-24", util.DatetimeToDateStr(*latest))
+
+		latest, err = repo.FindLatestDate(ctx, &prevDate)
+		require.NoError(t, err)
+		require.NotNil(t, latest)
+		assert.Equal(t, "2026-07-23", util.DatetimeToDateStr(*latest))
+
+		before := prevDate.AddDate(0, 0, -1)
+		latest, err = repo.FindLatestDate(ctx, &before)
+		require.NoError(t, err)
+		assert.Nil(t, latest)
+	})
+
+	t.Run("同日分を削除してから保存すると前回だけ対象だった銘柄は残らない", func(t *testing.T) {
+		err := NewTransaction(db).DoInTx(ctx, func(ctx context.Context) error {
+			if err := repo.DeleteByDate(ctx, date); err != nil {
+				return err
+			}
+			return repo.UpsertRatings(ctx, []*models.RelativeStrengthRating{rating(date, "7203", 99)})
+		})
+		require.NoError(t, err)
+
+		got, err := repo.List(ctx, models.RelativeStrengthFilter{Date: date})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "7203", got[0].TickerSymbol)
+		assert.Equal(t, 99, got[0].Rating)
+
+		// 他の日は消さない
+		prev, err := repo.List(ctx, models.RelativeStrengthFilter{Date: prevDate})
+		require.NoError(t, err)
+		assert.Len(t, prev, 1)
+	})
+}
@@ -166,6 +166,27 @@ func (si *StockBrandRepositoryImpl) FindByIDs(ctx context.Context, ids []string)
 	return result, nil
 }
 
+func (si *StockBrandRepositoryImpl) FindByTickerSymbols(ctx context.Context, tickerSymbols []string) ([]*models.StockBrand, error) {
+	tx := TxOrDefault(ctx, si.query)
+
+	if len(tickerSymbols) == 0 {
+		return []*models.StockBrand{}, nil
+	}
+
+	resultRow, err := tx.StockBrand.WithContext(ctx).
+		Where(tx.StockBrand.TickerSymbol.In(tickerSymbols...)).
+		Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "StockBrandRepositoryImpl.FindByTickerSymbols error")
+	}
+
+	result := make([]*models.StockBrand, 0, len(resultRow))
+	for _, v := range resultRow {
+		result = append(result, si.convertToDomainModel(v))
+	}
+	return result, nil
+}
+
 func (si *StockBrandRepositoryImpl) FindDelistingStockBrandsFromUpdateTime(ctx context.Context, now time.Time) ([]string, error) {
 	tx := TxOrDefault(ctx, si.query)
 
@@ -0,0 +1,310 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../../mock/$GOPACKAGE/$GOFILE
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	genModel "github.com/Code0716/stock-price-repository/infrastructure/database/gen_model"
+	genQuery "github.com/Code0716/stock-price-repository/infrastructure/database/gen_query"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// strategyRankingStockBatchSize 銘柄別結果の一括 INSERT 1回あたりの行数。
+const strategyRankingStockBatchSize = 1000
+
+type StrategyRankingRunRepositoryImpl struct {
+	query *genQuery.Query
+}
+
+func NewStrategyRankingRunRepositoryImpl(db *gorm.DB) repositories.StrategyRankingRunRepository {
+	return &StrategyRankingRunRepositoryImpl{
+		query: genQuery.Use(db),
+	}
+}
+
+func (si *StrategyRankingRunRepositoryImpl) Create(ctx context.Context, run *models.StrategyRankingRun) error {
+	tx := TxOrDefault(ctx, si.query)
+
+	m, err := si.convertToDBModel(run)
+	if err != nil {
+		return err
+	}
+	if err := tx.StrategyRankingRun.WithContext(ctx).Create(m); err != nil {
+		return errors.Wrap(err, "StrategyRankingRunRepositoryImpl.Create error")
+	}
+	return nil
+}
+
+func (si *StrategyRankingRunRepositoryImpl) UpdateRun(ctx context.Context, run *models.StrategyRankingRun) error {
+	tx := TxOrDefault(ctx, si.query)
+
+	m, err := si.convertToDBModel(run)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.StrategyRankingRun.WithContext(ctx).
+		Where(tx.StrategyRankingRun.RunID.Eq(run.RunID)).
+		Updates(map[string]any{
+			"status":           m.Status,
+			"error":            m.Error,
+			"computed_at":      m.ComputedAt,
+			"total_stocks":     m.TotalStocks,
+			"processed_stocks": m.ProcessedStocks,
+			"segments":         m.Segments,
+		}); err != nil {
+		return errors.Wrap(err, "StrategyRankingRunRepositoryImpl.UpdateRun error")
+	}
+	return nil
+}
+
+func (si *StrategyRankingRunRepositoryImpl) BulkCreateItems(ctx context.Context, runID, segment string, items []models.StrategyRankingItem) error {
+	tx := TxOrDefault(ctx, si.query)
+
+	if len(items) == 0 {
+		return nil
+	}
+	rows := make([]*genModel.StrategyRankingRunItem, 0, len(items))
+	for i, item := range items {
+		rows = append(rows, &genModel.StrategyRankingRunItem{
+			RunID:           runID,
+			Segment:         segment,
+			Strategy:        item.Strategy,
+			RankPosition:    uint32(i + 1),
+			Label:           item.Label,
+			StockCount:      uint32(item.StockCount),
+			TradedStocks:    uint32(item.TradedStocks),
+			TotalTrades:     uint32(item.TotalTrades),
+			BestCount:       uint32(item.BestCount),
+			AvgTotalReturn:  roundToFloat64(item.AvgTotalReturn, 6),
+			PositiveRate:    roundToFloat64(item.PositiveRate, 6),
+			AvgWinRate:      roundToFloat64(item.AvgWinRate, 6),
+			AvgProfitFactor: roundToFloat64(item.AvgProfitFactor, 6),
+		})
+	}
+	if err := tx.StrategyRankingRunItem.WithContext(ctx).Create(rows...); err != nil {
+		return errors.Wrap(err, "StrategyRankingRunRepositoryImpl.BulkCreateItems error")
+	}
+	return nil
+}
+
+func (si *StrategyRankingRunRepositoryImpl) BulkCreateStocks(ctx context.Context, runID, strategy string, stocks []*models.StrategyStockResult) error {
+	tx := TxOrDefault(ctx, si.query)
+
+	if len(stocks) == 0 {
+		return nil
+	}
+
+	rows := make([]*genModel.StrategyRankingRunStock, 0, len(stocks))
+	for _, s := range stocks {
+		rows = append(rows, &genModel.StrategyRankingRunStock{
+			RunID:        runID,
+			Strategy:     strategy,
+			TickerSymbol: s.TickerSymbol,
+			Name:         s.Name,
+			TotalReturn:  roundToFloat64(s.TotalReturn, 6),
+			Trades:       uint32(s.Trades),
+			WinRate:      roundToFloat64(s.WinRate, 6),
+			ProfitFactor: roundToFloat64(s.ProfitFactor, 6),
+			MaxDrawdown:  roundToFloat64(s.MaxDrawdown, 6),
+			PayoffRatio:  roundToFloat64(s.PayoffRatio, 6),
+			AvgHoldDays:  s.AvgHoldDays,
+		})
+	}
+	if err := tx.StrategyRankingRunStock.WithContext(ctx).
+		CreateInBatches(rows, strategyRankingStockBatchSize); err != nil {
+		return errors.Wrap(err, "StrategyRankingRunRepositoryImpl.BulkCreateStocks error")
+	}
+	return nil
+}
+
+func (si *StrategyRankingRunRepositoryImpl) FindByRunID(ctx context.Context, runID string) (*models.StrategyRankingRun, error) {
+	tx := TxOrDefault(ctx, si.query)
+
+	row, err := tx.StrategyRankingRun.WithContext(ctx).
+		Where(tx.StrategyRankingRun.RunID.Eq(runID)).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl.FindByRunID error")
+	}
+	return si.convertToDomainModel(row)
+}
+
+func (si *StrategyRankingRunRepositoryImpl) FindLatest(ctx context.Context, universe string) (*models.StrategyRankingRun, error) {
+	tx := TxOrDefault(ctx, si.query)
+
+	row, err := tx.StrategyRankingRun.WithContext(ctx).
+		Where(tx.StrategyRankingRun.Universe.Eq(universe)).
+		Where(tx.StrategyRankingRun.Status.Eq(models.RankingRunStatusCompleted)).
+		Order(tx.StrategyRankingRun.ComputedAt.Desc()).
+		First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl.FindLatest error")
+	}
+	return si.convertToDomainModel(row)
+}
+
+func (si *StrategyRankingRunRepositoryImpl) List(ctx context.Context, limit int) ([]*models.StrategyRankingRun, error) {
+	tx := TxOrDefault(ctx, si.query)
+
+	q := tx.StrategyRankingRun.WithContext(ctx).
+		Order(tx.StrategyRankingRun.ComputedAt.Desc())
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	rows, err := q.Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl.List error")
+	}
+
+	runs := make([]*models.StrategyRankingRun, 0, len(rows))
+	for _, r := range rows {
+		run, err := si.convertToDomainModel(r)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+func (si *StrategyRankingRunRepositoryImpl) ListItems(ctx context.Context, runID, segment string) ([]models.StrategyRankingItem, error) {
+	tx := TxOrDefault(ctx, si.query)
+
+	rows, err := tx.StrategyRankingRunItem.WithContext(ctx).
+		Where(tx.StrategyRankingRunItem.RunID.Eq(runID)).
+		Where(tx.StrategyRankingRunItem.Segment.Eq(segment)).
+		Order(tx.StrategyRankingRunItem.RankPosition).
+		Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl.ListItems error")
+	}
+
+	items := make([]models.StrategyRankingItem, 0, len(rows))
+	for _, r := range rows {
+		items = append(items, models.StrategyRankingItem{
+			Strategy:        r.Strategy,
+			Label:           r.Label,
+			StockCount:      int(r.StockCount),
+			TradedStocks:    int(r.TradedStocks),
+			AvgTotalReturn:  decimal.NewFromFloat(r.AvgTotalReturn),
+			PositiveRate:    decimal.NewFromFloat(r.PositiveRate),
+			AvgWinRate:      decimal.NewFromFloat(r.AvgWinRate),
+			AvgProfitFactor: decimal.NewFromFloat(r.AvgProfitFactor),
+			TotalTrades:     int(r.TotalTrades),
+			BestCount:       int(r.BestCount),
+		})
+	}
+	return items, nil
+}
+
+func (si *StrategyRankingRunRepositoryImpl) ListStocks(ctx context.Context, runID, strategy string, limit int) ([]*models.StrategyStockResult, error) {
+	tx := TxOrDefault(ctx, si.query)
+
+	q := tx.StrategyRankingRunStock.WithContext(ctx).
+		Where(tx.StrategyRankingRunStock.RunID.Eq(runID)).
+		Where(tx.StrategyRankingRunStock.Strategy.Eq(strategy)).
+		Order(tx.StrategyRankingRunStock.TotalReturn.Desc(), tx.StrategyRankingRunStock.TickerSymbol)
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	rows, err := q.Find()
+	if err != nil {
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl.ListStocks error")
+	}
+
+	stocks := make([]*models.StrategyStockResult, 0, len(rows))
+	for _, r := range rows {
+		stocks = append(stocks, &models.StrategyStockResult{
+			TickerSymbol: r.TickerSymbol,
+			Name:         r.Name,
+			TotalReturn:  decimal.NewFromFloat(r.TotalReturn),
+			Trades:       int(r.Trades),
+			WinRate:      decimal.NewFromFloat(r.WinRate),
+			ProfitFactor: decimal.NewFromFloat(r.ProfitFactor),
+			MaxDrawdown:  decimal.NewFromFloat(r.MaxDrawdown),
+			PayoffRatio:  decimal.NewFromFloat(r.PayoffRatio),
+			AvgHoldDays:  r.AvgHoldDays,
+		})
+	}
+	return stocks, nil
+}
+
+func (si *StrategyRankingRunRepositoryImpl) CountStocks(ctx context.Context, runID, strategy string) (int, error) {
+	tx := TxOrDefault(ctx, si.query)
+
+	count, err := tx.StrategyRankingRunStock.WithContext(ctx).
+		Where(tx.StrategyRankingRunStock.RunID.Eq(runID)).
+		Where(tx.StrategyRankingRunStock.Strategy.Eq(strategy)).
+		Count()
+	if err != nil {
+		return 0, errors.Wrap(err, "StrategyRankingRunRepositoryImpl.CountStocks error")
+	}
+	return int(count), nil
+}
+
+func (si *StrategyRankingRunRepositoryImpl) convertToDomainModel(m *genModel.StrategyRankingRun) (*models.StrategyRankingRun, error) {
+	run := &models.StrategyRankingRun{
+		RunID:           m.RunID,
+		Status:          m.Status,
+		Error:           m.Error,
+		ComputedAt:      m.ComputedAt,
+		Universe:        m.Universe,
+		Years:           int(m.Years),
+		TotalStocks:     int(m.TotalStocks),
+		ProcessedStocks: int(m.ProcessedStocks),
+	}
+	if err := json.Unmarshal([]byte(m.Params), &run.Params); err != nil {
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl json.Unmarshal params error")
+	}
+	if err := json.Unmarshal([]byte(m.UniverseSpec), &run.UniverseSpec); err != nil {
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl json.Unmarshal universe_spec error")
+	}
+	if err := json.Unmarshal([]byte(m.Segments), &run.Segments); err != nil {
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl json.Unmarshal segments error")
+	}
+	return run, nil
+}
+
+func (si *StrategyRankingRunRepositoryImpl) convertToDBModel(run *models.StrategyRankingRun) (*genModel.StrategyRankingRun, error) {
+	params, err := json.Marshal(run.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl json.Marshal params error")
+	}
+	universeSpec, err := json.Marshal(run.UniverseSpec)
+	if err != nil {
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl json.Marshal universe_spec error")
+	}
+	segments := run.Segments
+	if segments == nil {
+		segments = []models.RankingSegment{}
+	}
+	segmentsJSON, err := json.Marshal(segments)
+	if err != nil {
+		return nil, errors.Wrap(err, "StrategyRankingRunRepositoryImpl json.Marshal segments error")
+	}
+	return &genModel.StrategyRankingRun{
+		RunID:           run.RunID,
+		Status:          run.Status,
+		Error:           run.Error,
+		ComputedAt:      run.ComputedAt,
+		Universe:        run.Universe,
+		UniverseSpec:    string(universeSpec),
+		Years:           uint32(run.Years),
+		TotalStocks:     uint32(run.TotalStocks),
+		ProcessedStocks: uint32(run.ProcessedStocks),
+		Params:          string(params),
+		Segments:        string(segmentsJSON),
+	}, nil
+}
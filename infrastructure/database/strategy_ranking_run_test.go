@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestStrategyRankingRunRepositoryImpl_RunLifecycle(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewStrategyRankingRunRepositoryImpl(db)
+	ctx := context.Background()
+	startedAt := time.Date(2026, 7, 24, 9, 0, 0, 0, time.Local)
+
+	newRun := func(runID, status string, computedAt time.Time) *models.StrategyRankingRun {
+		return &models.StrategyRankingRun{
+			RunID:      runID,
+			Status:     status,
+			ComputedAt: computedAt,
+			Universe:   models.RankingUniverseMainMarkets,
+			Years:      3,
+			Params: models.BacktestParams{
+				TakeProfit:     decimal.RequireFromString("0.1"),
+				StopLoss:       decimal.RequireFromString("0.05"),
+				MaxHoldDays:    20,
+				CommissionRate: decimal.Zero,
+				SlippageRate:   decimal.Zero,
+				ExitMode:       models.ExitModeCommon,
+				EntryTiming:    models.EntryTimingNextOpen,
+			},
+		}
+	}
+
+	// 実行中で登録し、完了時に集計結果で更新する
+	running := newRun("00000000-0000-0000-0000-000000000001", models.RankingRunStatusRunning, startedAt)
+	require.NoError(t, repo.Create(ctx, running))
+
+	latest, err := repo.FindLatest(ctx, models.RankingUniverseMainMarkets)
+	require.NoError(t, err)
+	assert.Nil(t, latest, "実行中の run は最新の完了結果として返さない")
+
+	running.Status = models.RankingRunStatusCompleted
+	running.ComputedAt = startedAt.Add(time.Hour)
+	running.TotalStocks = 120
+	running.ProcessedStocks = 100
+	running.Segments = []models.RankingSegment{{
+		Name:   "trend_2023",
+		Source: models.RankingSegmentSourceExplicit,
+		Ranges: []models.RankingDateRange{{From: "2023-01-01", To: "2023-12-31"}},
+	}}
+	require.NoError(t, repo.UpdateRun(ctx, running))
+
+	got, err := repo.FindByRunID(ctx, running.RunID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, models.RankingRunStatusCompleted, got.Status)
+	assert.Equal(t, 120, got.TotalStocks)
+	assert.Equal(t, 100, got.ProcessedStocks)
+	assert.True(t, got.ComputedAt.Equal(running.ComputedAt))
+	assert.Equal(t, running.Segments, got.Segments)
+	assert.Equal(t, models.EntryTimingNextOpen, got.Params.EntryTiming)
+	assert.True(t, got.Params.TakeProfit.Equal(decimal.RequireFromString("0.1")))
+
+	// 後から失敗した run は FindLatest の対象外、List には含める
+	failed := newRun("00000000-0000-0000-0000-000000000002", models.RankingRunStatusFailed, startedAt.Add(2*time.Hour))
+	failed.Error = "db error"
+	require.NoError(t, repo.Create(ctx, failed))
+
+	latest, err = repo.FindLatest(ctx, models.RankingUniverseMainMarkets)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, running.RunID, latest.RunID)
+
+	runs, err := repo.List(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, failed.RunID, runs[0].RunID)
+	assert.Equal(t, "db error", runs[0].Error)
+	assert.Equal(t, []models.RankingSegment{}, runs[0].Segments)
+
+	notFound, err := repo.FindByRunID(ctx, "00000000-0000-0000-0000-000000000099")
+	require.NoError(t, err)
+	assert.Nil(t, notFound)
+}
+
+func TestStrategyRankingRunRepositoryImpl_ItemsAndStocks(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewStrategyRankingRunRepositoryImpl(db)
+	ctx := context.Background()
+	runID := "00000000-0000-0000-0000-000000000001"
+
+	items := []models.StrategyRankingItem{
+		{Strategy: "macd_bullish", Label: "MACD", StockCount: 100, TradedStocks: 90, TotalTrades: 300, BestCount: 40,
+			AvgTotalReturn: decimal.RequireFromString("0.123456"), PositiveRate: decimal.RequireFromString("0.6"),
+			AvgWinRate: decimal.RequireFromString("0.55"), AvgProfitFactor: decimal.RequireFromString("1.4")},
+		{Strategy: "ma_cross", Label: "移動平均クロス", StockCount: 100, TradedStocks: 80, TotalTrades: 200, BestCount: 30,
+			AvgTotalReturn: decimal.RequireFromString("0.05"), PositiveRate: decimal.RequireFromString("0.5"),
+			AvgWinRate: decimal.RequireFromString("0.5"), AvgProfitFactor: decimal.RequireFromString("1.1")},
+	}
+	require.NoError(t, repo.BulkCreateItems(ctx, runID, "", items))
+	require.NoError(t, repo.BulkCreateItems(ctx, runID, "trend_2023", items[1:]))
+
+	gotItems, err := repo.ListItems(ctx, runID, "")
+	require.NoError(t, err)
+	require.Len(t, gotItems, 2)
+	// 渡した順が順位になる
+	assert.Equal(t, "macd_bullish", gotItems[0].Strategy)
+	assert.Equal(t, "ma_cross", gotItems[1].Strategy)
+	assert.True(t, gotItems[0].AvgTotalReturn.Equal(decimal.RequireFromString("0.123456")))
+	assert.Equal(t, 40, gotItems[0].BestCount)
+
+	segmentItems, err := repo.ListItems(ctx, runID, "trend_2023")
+	require.NoError(t, err)
+	require.Len(t, segmentItems, 1)
+	assert.Equal(t, "ma_cross", segmentItems[0].Strategy)
+
+	stocks := []*models.StrategyStockResult{
+		{TickerSymbol: "7203", Name: "トヨタ自動車", TotalReturn: decimal.RequireFromString("0.1"), Trades: 3,
+			WinRate: decimal.RequireFromString("0.666667"), ProfitFactor: decimal.RequireFromString("2"),
+			MaxDrawdown: decimal.RequireFromString("0.08"), PayoffRatio: decimal.RequireFromString("1.5"), AvgHoldDays: 7.5},
+		{TickerSymbol: "6758", Name: "ソニーグループ", TotalReturn: decimal.RequireFromString("0.2"), Trades: 2},
+		{TickerSymbol: "9984", Name: "ソフトバンクグループ", TotalReturn: decimal.RequireFromString("-0.1"), Trades: 1},
+	}
+	require.NoError(t, repo.BulkCreateStocks(ctx, runID, "macd_bullish", stocks))
+
+	gotStocks, err := repo.ListStocks(ctx, runID, "macd_bullish", 2)
+	require.NoError(t, err)
+	require.Len(t, gotStocks, 2)
+	// total_return 降順
+	assert.Equal(t, "6758", gotStocks[0].TickerSymbol)
+	assert.Equal(t, "7203", gotStocks[1].TickerSymbol)
+	assert.True(t, gotStocks[1].WinRate.Equal(decimal.RequireFromString("0.666667")))
+	assert.Equal(t, 7.5, gotStocks[1].AvgHoldDays)
+
+	count, err := repo.CountStocks(ctx, runID, "macd_bullish")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = repo.CountStocks(ctx, runID, "ma_cross")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
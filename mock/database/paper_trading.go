@@ -0,0 +1,10 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: paper_trading.go
+//
+// Generated by this command:
+//
+//	mockgen -source=paper_trading.go -package=mock_database -destination=../../mock/database/paper_trading.go
+//
+
+// Package mock_database is a generated GoMock package.
+package mock_database
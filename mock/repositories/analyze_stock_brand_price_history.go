@@ -159,3 +159,47 @@ func (mr *MockAnalyzeStockBrandPriceHistoryRepositoryMockRecorder) FindWithFilte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithFilter", reflect.TypeOf((*MockAnalyzeStockBrandPriceHistoryRepository)(nil).FindWithFilter), ctx, filter)
 }
+
+// ListOpen mocks base method.
+func (m *MockAnalyzeStockBrandPriceHistoryRepository) ListOpen(ctx context.Context) ([]*models.AnalyzeStockBrandPriceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpen", ctx)
+	ret0, _ := ret[0].([]*models.AnalyzeStockBrandPriceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpen indicates an expected call of ListOpen.
+func (mr *MockAnalyzeStockBrandPriceHistoryRepositoryMockRecorder) ListOpen(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpen", reflect.TypeOf((*MockAnalyzeStockBrandPriceHistoryRepository)(nil).ListOpen), ctx)
+}
+
+// UpdateMarks mocks base method.
+func (m *MockAnalyzeStockBrandPriceHistoryRepository) UpdateMarks(ctx context.Context, marks []*models.AnalyzeStockBrandPriceHistoryMark) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMarks", ctx, marks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMarks indicates an expected call of UpdateMarks.
+func (mr *MockAnalyzeStockBrandPriceHistoryRepositoryMockRecorder) UpdateMarks(ctx, marks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMarks", reflect.TypeOf((*MockAnalyzeStockBrandPriceHistoryRepository)(nil).UpdateMarks), ctx, marks)
+}
+
+// UpsertByKey mocks base method.
+func (m *MockAnalyzeStockBrandPriceHistoryRepository) UpsertByKey(ctx context.Context, histories []*models.AnalyzeStockBrandPriceHistory) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByKey", ctx, histories)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertByKey indicates an expected call of UpsertByKey.
+func (mr *MockAnalyzeStockBrandPriceHistoryRepositoryMockRecorder) UpsertByKey(ctx, histories any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByKey", reflect.TypeOf((*MockAnalyzeStockBrandPriceHistoryRepository)(nil).UpsertByKey), ctx, histories)
+}
@@ -70,3 +70,18 @@ func (mr *MockAppliedStockConsolidationsHistoryRepositoryMockRecorder) Exists(ct
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockAppliedStockConsolidationsHistoryRepository)(nil).Exists), ctx, symbol, consolidationDate)
 }
+
+// ListByConsolidationDateRange mocks base method.
+func (m *MockAppliedStockConsolidationsHistoryRepository) ListByConsolidationDateRange(ctx context.Context, from, to time.Time) ([]*models.AppliedStockConsolidationHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByConsolidationDateRange", ctx, from, to)
+	ret0, _ := ret[0].([]*models.AppliedStockConsolidationHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByConsolidationDateRange indicates an expected call of ListByConsolidationDateRange.
+func (mr *MockAppliedStockConsolidationsHistoryRepositoryMockRecorder) ListByConsolidationDateRange(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByConsolidationDateRange", reflect.TypeOf((*MockAppliedStockConsolidationsHistoryRepository)(nil).ListByConsolidationDateRange), ctx, from, to)
+}
@@ -70,3 +70,18 @@ func (mr *MockAppliedStockSplitsHistoryRepositoryMockRecorder) Exists(ctx, symbo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockAppliedStockSplitsHistoryRepository)(nil).Exists), ctx, symbol, splitDate)
 }
+
+// ListBySplitDateRange mocks base method.
+func (m *MockAppliedStockSplitsHistoryRepository) ListBySplitDateRange(ctx context.Context, from, to time.Time) ([]*models.AppliedStockSplitHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySplitDateRange", ctx, from, to)
+	ret0, _ := ret[0].([]*models.AppliedStockSplitHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySplitDateRange indicates an expected call of ListBySplitDateRange.
+func (mr *MockAppliedStockSplitsHistoryRepositoryMockRecorder) ListBySplitDateRange(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySplitDateRange", reflect.TypeOf((*MockAppliedStockSplitsHistoryRepository)(nil).ListBySplitDateRange), ctx, from, to)
+}
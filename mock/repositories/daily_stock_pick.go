@@ -57,17 +57,17 @@ func (mr *MockDailyStockPickRepositoryMockRecorder) BulkCreate(ctx, picks any) *
 }
 
 // DeleteByPickDate mocks base method.
-func (m *MockDailyStockPickRepository) DeleteByPickDate(ctx context.Context, pickDate time.Time) error {
+func (m *MockDailyStockPickRepository) DeleteByPickDate(ctx context.Context, pickDate time.Time, scoreVersions []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByPickDate", ctx, pickDate)
+	ret := m.ctrl.Call(m, "DeleteByPickDate", ctx, pickDate, scoreVersions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteByPickDate indicates an expected call of DeleteByPickDate.
-func (mr *MockDailyStockPickRepositoryMockRecorder) DeleteByPickDate(ctx, pickDate any) *gomock.Call {
+func (mr *MockDailyStockPickRepositoryMockRecorder) DeleteByPickDate(ctx, pickDate, scoreVersions any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPickDate", reflect.TypeOf((*MockDailyStockPickRepository)(nil).DeleteByPickDate), ctx, pickDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPickDate", reflect.TypeOf((*MockDailyStockPickRepository)(nil).DeleteByPickDate), ctx, pickDate, scoreVersions)
 }
 
 // ExistsByPickDate mocks base method.
@@ -86,18 +86,18 @@ func (mr *MockDailyStockPickRepositoryMockRecorder) ExistsByPickDate(ctx, pickDa
 }
 
 // FindLatestPickDate mocks base method.
-func (m *MockDailyStockPickRepository) FindLatestPickDate(ctx context.Context) (*time.Time, error) {
+func (m *MockDailyStockPickRepository) FindLatestPickDate(ctx context.Context, scoreVersion string) (*time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindLatestPickDate", ctx)
+	ret := m.ctrl.Call(m, "FindLatestPickDate", ctx, scoreVersion)
 	ret0, _ := ret[0].(*time.Time)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindLatestPickDate indicates an expected call of FindLatestPickDate.
-func (mr *MockDailyStockPickRepositoryMockRecorder) FindLatestPickDate(ctx any) *gomock.Call {
+func (mr *MockDailyStockPickRepositoryMockRecorder) FindLatestPickDate(ctx, scoreVersion any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestPickDate", reflect.TypeOf((*MockDailyStockPickRepository)(nil).FindLatestPickDate), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestPickDate", reflect.TypeOf((*MockDailyStockPickRepository)(nil).FindLatestPickDate), ctx, scoreVersion)
 }
 
 // ListByDateRange mocks base method.
@@ -116,18 +116,18 @@ func (mr *MockDailyStockPickRepositoryMockRecorder) ListByDateRange(ctx, from, t
 }
 
 // ListByPickDate mocks base method.
-func (m *MockDailyStockPickRepository) ListByPickDate(ctx context.Context, pickDate time.Time) ([]*models.DailyStockPick, error) {
+func (m *MockDailyStockPickRepository) ListByPickDate(ctx context.Context, pickDate time.Time, scoreVersion string) ([]*models.DailyStockPick, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByPickDate", ctx, pickDate)
+	ret := m.ctrl.Call(m, "ListByPickDate", ctx, pickDate, scoreVersion)
 	ret0, _ := ret[0].([]*models.DailyStockPick)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByPickDate indicates an expected call of ListByPickDate.
-func (mr *MockDailyStockPickRepositoryMockRecorder) ListByPickDate(ctx, pickDate any) *gomock.Call {
+func (mr *MockDailyStockPickRepositoryMockRecorder) ListByPickDate(ctx, pickDate, scoreVersion any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPickDate", reflect.TypeOf((*MockDailyStockPickRepository)(nil).ListByPickDate), ctx, pickDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPickDate", reflect.TypeOf((*MockDailyStockPickRepository)(nil).ListByPickDate), ctx, pickDate, scoreVersion)
 }
 
 // ListPendingEvaluation mocks base method.
@@ -146,32 +146,32 @@ func (mr *MockDailyStockPickRepositoryMockRecorder) ListPendingEvaluation(ctx, o
 }
 
 // ListPickDates mocks base method.
-func (m *MockDailyStockPickRepository) ListPickDates(ctx context.Context, limit int) ([]time.Time, error) {
+func (m *MockDailyStockPickRepository) ListPickDates(ctx context.Context, limit int, scoreVersion string) ([]time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPickDates", ctx, limit)
+	ret := m.ctrl.Call(m, "ListPickDates", ctx, limit, scoreVersion)
 	ret0, _ := ret[0].([]time.Time)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListPickDates indicates an expected call of ListPickDates.
-func (mr *MockDailyStockPickRepositoryMockRecorder) ListPickDates(ctx, limit any) *gomock.Call {
+func (mr *MockDailyStockPickRepositoryMockRecorder) ListPickDates(ctx, limit, scoreVersion any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPickDates", reflect.TypeOf((*MockDailyStockPickRepository)(nil).ListPickDates), ctx, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPickDates", reflect.TypeOf((*MockDailyStockPickRepository)(nil).ListPickDates), ctx, limit, scoreVersion)
 }
 
 // MarkNotified mocks base method.
-func (m *MockDailyStockPickRepository) MarkNotified(ctx context.Context, pickDate, notifiedAt time.Time) error {
+func (m *MockDailyStockPickRepository) MarkNotified(ctx context.Context, pickDate time.Time, scoreVersion string, notifiedAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotified", ctx, pickDate, notifiedAt)
+	ret := m.ctrl.Call(m, "MarkNotified", ctx, pickDate, scoreVersion, notifiedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MarkNotified indicates an expected call of MarkNotified.
-func (mr *MockDailyStockPickRepositoryMockRecorder) MarkNotified(ctx, pickDate, notifiedAt any) *gomock.Call {
+func (mr *MockDailyStockPickRepositoryMockRecorder) MarkNotified(ctx, pickDate, scoreVersion, notifiedAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockDailyStockPickRepository)(nil).MarkNotified), ctx, pickDate, notifiedAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockDailyStockPickRepository)(nil).MarkNotified), ctx, pickDate, scoreVersion, notifiedAt)
 }
 
 // UpdateEvaluations mocks base method.
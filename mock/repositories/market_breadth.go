@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: market_breadth.go
+//
+// Generated by this command:
+//
+//	mockgen -source=market_breadth.go -package=mock_repositories -destination=../mock/repositories/market_breadth.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMarketBreadthRepository is a mock of MarketBreadthRepository interface.
+type MockMarketBreadthRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketBreadthRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMarketBreadthRepositoryMockRecorder is the mock recorder for MockMarketBreadthRepository.
+type MockMarketBreadthRepositoryMockRecorder struct {
+	mock *MockMarketBreadthRepository
+}
+
+// NewMockMarketBreadthRepository creates a new mock instance.
+func NewMockMarketBreadthRepository(ctrl *gomock.Controller) *MockMarketBreadthRepository {
+	mock := &MockMarketBreadthRepository{ctrl: ctrl}
+	mock.recorder = &MockMarketBreadthRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketBreadthRepository) EXPECT() *MockMarketBreadthRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListMarketBreadths mocks base method.
+func (m *MockMarketBreadthRepository) ListMarketBreadths(ctx context.Context, filter models.MarketBreadthFilter) ([]*models.MarketBreadth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMarketBreadths", ctx, filter)
+	ret0, _ := ret[0].([]*models.MarketBreadth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMarketBreadths indicates an expected call of ListMarketBreadths.
+func (mr *MockMarketBreadthRepositoryMockRecorder) ListMarketBreadths(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMarketBreadths", reflect.TypeOf((*MockMarketBreadthRepository)(nil).ListMarketBreadths), ctx, filter)
+}
+
+// ListMarketBreadthsBefore mocks base method.
+func (m *MockMarketBreadthRepository) ListMarketBreadthsBefore(ctx context.Context, market string, before time.Time, limit int) ([]*models.MarketBreadth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMarketBreadthsBefore", ctx, market, before, limit)
+	ret0, _ := ret[0].([]*models.MarketBreadth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMarketBreadthsBefore indicates an expected call of ListMarketBreadthsBefore.
+func (mr *MockMarketBreadthRepositoryMockRecorder) ListMarketBreadthsBefore(ctx, market, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMarketBreadthsBefore", reflect.TypeOf((*MockMarketBreadthRepository)(nil).ListMarketBreadthsBefore), ctx, market, before, limit)
+}
+
+// UpsertMarketBreadths mocks base method.
+func (m *MockMarketBreadthRepository) UpsertMarketBreadths(ctx context.Context, breadths []*models.MarketBreadth) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMarketBreadths", ctx, breadths)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertMarketBreadths indicates an expected call of UpsertMarketBreadths.
+func (mr *MockMarketBreadthRepositoryMockRecorder) UpsertMarketBreadths(ctx, breadths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMarketBreadths", reflect.TypeOf((*MockMarketBreadthRepository)(nil).UpsertMarketBreadths), ctx, breadths)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: market_regime.go
+//
+// Generated by this command:
+//
+//	mockgen -source=market_regime.go -package=mock_repositories -destination=../mock/repositories/market_regime.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMarketRegimeRepository is a mock of MarketRegimeRepository interface.
+type MockMarketRegimeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketRegimeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMarketRegimeRepositoryMockRecorder is the mock recorder for MockMarketRegimeRepository.
+type MockMarketRegimeRepositoryMockRecorder struct {
+	mock *MockMarketRegimeRepository
+}
+
+// NewMockMarketRegimeRepository creates a new mock instance.
+func NewMockMarketRegimeRepository(ctrl *gomock.Controller) *MockMarketRegimeRepository {
+	mock := &MockMarketRegimeRepository{ctrl: ctrl}
+	mock.recorder = &MockMarketRegimeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketRegimeRepository) EXPECT() *MockMarketRegimeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindMarketRegimeByDate mocks base method.
+func (m *MockMarketRegimeRepository) FindMarketRegimeByDate(ctx context.Context, date time.Time) (*models.MarketRegime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMarketRegimeByDate", ctx, date)
+	ret0, _ := ret[0].(*models.MarketRegime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMarketRegimeByDate indicates an expected call of FindMarketRegimeByDate.
+func (mr *MockMarketRegimeRepositoryMockRecorder) FindMarketRegimeByDate(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMarketRegimeByDate", reflect.TypeOf((*MockMarketRegimeRepository)(nil).FindMarketRegimeByDate), ctx, date)
+}
+
+// ListMarketRegimes mocks base method.
+func (m *MockMarketRegimeRepository) ListMarketRegimes(ctx context.Context, filter models.MarketRegimeFilter) ([]*models.MarketRegime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMarketRegimes", ctx, filter)
+	ret0, _ := ret[0].([]*models.MarketRegime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMarketRegimes indicates an expected call of ListMarketRegimes.
+func (mr *MockMarketRegimeRepositoryMockRecorder) ListMarketRegimes(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMarketRegimes", reflect.TypeOf((*MockMarketRegimeRepository)(nil).ListMarketRegimes), ctx, filter)
+}
+
+// UpsertMarketRegimes mocks base method.
+func (m *MockMarketRegimeRepository) UpsertMarketRegimes(ctx context.Context, regimes []*models.MarketRegime) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMarketRegimes", ctx, regimes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertMarketRegimes indicates an expected call of UpsertMarketRegimes.
+func (mr *MockMarketRegimeRepositoryMockRecorder) UpsertMarketRegimes(ctx, regimes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMarketRegimes", reflect.TypeOf((*MockMarketRegimeRepository)(nil).UpsertMarketRegimes), ctx, regimes)
+}
@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: paper_trading.go
+//
+// Generated by this command:
+//
+//	mockgen -source=paper_trading.go -package=mock_repositories -destination=../mock/repositories/paper_trading.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaperTradingRepository is a mock of PaperTradingRepository interface.
+type MockPaperTradingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaperTradingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPaperTradingRepositoryMockRecorder is the mock recorder for MockPaperTradingRepository.
+type MockPaperTradingRepositoryMockRecorder struct {
+	mock *MockPaperTradingRepository
+}
+
+// NewMockPaperTradingRepository creates a new mock instance.
+func NewMockPaperTradingRepository(ctrl *gomock.Controller) *MockPaperTradingRepository {
+	mock := &MockPaperTradingRepository{ctrl: ctrl}
+	mock.recorder = &MockPaperTradingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaperTradingRepository) EXPECT() *MockPaperTradingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAccount mocks base method.
+func (m *MockPaperTradingRepository) CreateAccount(ctx context.Context, account *models.PaperAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockPaperTradingRepositoryMockRecorder) CreateAccount(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockPaperTradingRepository)(nil).CreateAccount), ctx, account)
+}
+
+// CreateFills mocks base method.
+func (m *MockPaperTradingRepository) CreateFills(ctx context.Context, fills []*models.PaperFill) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFills", ctx, fills)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFills indicates an expected call of CreateFills.
+func (mr *MockPaperTradingRepositoryMockRecorder) CreateFills(ctx, fills any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFills", reflect.TypeOf((*MockPaperTradingRepository)(nil).CreateFills), ctx, fills)
+}
+
+// CreateNav mocks base method.
+func (m *MockPaperTradingRepository) CreateNav(ctx context.Context, nav *models.PaperNav) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNav", ctx, nav)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNav indicates an expected call of CreateNav.
+func (mr *MockPaperTradingRepositoryMockRecorder) CreateNav(ctx, nav any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNav", reflect.TypeOf((*MockPaperTradingRepository)(nil).CreateNav), ctx, nav)
+}
+
+// CreatePositions mocks base method.
+func (m *MockPaperTradingRepository) CreatePositions(ctx context.Context, positions []*models.PaperPosition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePositions", ctx, positions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePositions indicates an expected call of CreatePositions.
+func (mr *MockPaperTradingRepositoryMockRecorder) CreatePositions(ctx, positions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePositions", reflect.TypeOf((*MockPaperTradingRepository)(nil).CreatePositions), ctx, positions)
+}
+
+// FindAccount mocks base method.
+func (m *MockPaperTradingRepository) FindAccount(ctx context.Context, name string) (*models.PaperAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAccount", ctx, name)
+	ret0, _ := ret[0].(*models.PaperAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAccount indicates an expected call of FindAccount.
+func (mr *MockPaperTradingRepositoryMockRecorder) FindAccount(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAccount", reflect.TypeOf((*MockPaperTradingRepository)(nil).FindAccount), ctx, name)
+}
+
+// FindLatestNav mocks base method.
+func (m *MockPaperTradingRepository) FindLatestNav(ctx context.Context, account string) (*models.PaperNav, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestNav", ctx, account)
+	ret0, _ := ret[0].(*models.PaperNav)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestNav indicates an expected call of FindLatestNav.
+func (mr *MockPaperTradingRepositoryMockRecorder) FindLatestNav(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestNav", reflect.TypeOf((*MockPaperTradingRepository)(nil).FindLatestNav), ctx, account)
+}
+
+// ListFills mocks base method.
+func (m *MockPaperTradingRepository) ListFills(ctx context.Context, account string, from, to *time.Time) ([]*models.PaperFill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFills", ctx, account, from, to)
+	ret0, _ := ret[0].([]*models.PaperFill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFills indicates an expected call of ListFills.
+func (mr *MockPaperTradingRepositoryMockRecorder) ListFills(ctx, account, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFills", reflect.TypeOf((*MockPaperTradingRepository)(nil).ListFills), ctx, account, from, to)
+}
+
+// ListNavs mocks base method.
+func (m *MockPaperTradingRepository) ListNavs(ctx context.Context, account string, from, to *time.Time) ([]*models.PaperNav, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNavs", ctx, account, from, to)
+	ret0, _ := ret[0].([]*models.PaperNav)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNavs indicates an expected call of ListNavs.
+func (mr *MockPaperTradingRepositoryMockRecorder) ListNavs(ctx, account, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNavs", reflect.TypeOf((*MockPaperTradingRepository)(nil).ListNavs), ctx, account, from, to)
+}
+
+// ListPositions mocks base method.
+func (m *MockPaperTradingRepository) ListPositions(ctx context.Context, filter models.PaperPositionFilter) ([]*models.PaperPosition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPositions", ctx, filter)
+	ret0, _ := ret[0].([]*models.PaperPosition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPositions indicates an expected call of ListPositions.
+func (mr *MockPaperTradingRepositoryMockRecorder) ListPositions(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPositions", reflect.TypeOf((*MockPaperTradingRepository)(nil).ListPositions), ctx, filter)
+}
+
+// UpdatePositions mocks base method.
+func (m *MockPaperTradingRepository) UpdatePositions(ctx context.Context, positions []*models.PaperPosition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePositions", ctx, positions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePositions indicates an expected call of UpdatePositions.
+func (mr *MockPaperTradingRepositoryMockRecorder) UpdatePositions(ctx, positions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePositions", reflect.TypeOf((*MockPaperTradingRepository)(nil).UpdatePositions), ctx, positions)
+}
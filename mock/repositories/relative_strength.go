@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: relative_strength.go
+//
+// Generated by this command:
+//
+//	mockgen -source=relative_strength.go -package=mock_repositories -destination=../mock/repositories/relative_strength.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRelativeStrengthRepository is a mock of RelativeStrengthRepository interface.
+type MockRelativeStrengthRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelativeStrengthRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRelativeStrengthRepositoryMockRecorder is the mock recorder for MockRelativeStrengthRepository.
+type MockRelativeStrengthRepositoryMockRecorder struct {
+	mock *MockRelativeStrengthRepository
+}
+
+// NewMockRelativeStrengthRepository creates a new mock instance.
+func NewMockRelativeStrengthRepository(ctrl *gomock.Controller) *MockRelativeStrengthRepository {
+	mock := &MockRelativeStrengthRepository{ctrl: ctrl}
+	mock.recorder = &MockRelativeStrengthRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelativeStrengthRepository) EXPECT() *MockRelativeStrengthRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByDate mocks base method.
+func (m *MockRelativeStrengthRepository) DeleteByDate(ctx context.Context, date time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByDate", ctx, date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByDate indicates an expected call of DeleteByDate.
+func (mr *MockRelativeStrengthRepositoryMockRecorder) DeleteByDate(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByDate", reflect.TypeOf((*MockRelativeStrengthRepository)(nil).DeleteByDate), ctx, date)
+}
+
+// FindLatestDate mocks base method.
+func (m *MockRelativeStrengthRepository) FindLatestDate(ctx context.Context, onOrBefore *time.Time) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestDate", ctx, onOrBefore)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestDate indicates an expected call of FindLatestDate.
+func (mr *MockRelativeStrengthRepositoryMockRecorder) FindLatestDate(ctx, onOrBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestDate", reflect.TypeOf((*MockRelativeStrengthRepository)(nil).FindLatestDate), ctx, onOrBefore)
+}
+
+// List mocks base method.
+func (m *MockRelativeStrengthRepository) List(ctx context.Context, filter models.RelativeStrengthFilter) ([]*models.RelativeStrengthRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*models.RelativeStrengthRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRelativeStrengthRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRelativeStrengthRepository)(nil).List), ctx, filter)
+}
+
+// UpsertRatings mocks base method.
+func (m *MockRelativeStrengthRepository) UpsertRatings(ctx context.Context, ratings []*models.RelativeStrengthRating) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRatings", ctx, ratings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertRatings indicates an expected call of UpsertRatings.
+func (mr *MockRelativeStrengthRepositoryMockRecorder) UpsertRatings(ctx, ratings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRatings", reflect.TypeOf((*MockRelativeStrengthRepository)(nil).UpsertRatings), ctx, ratings)
+}
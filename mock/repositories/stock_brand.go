@@ -102,6 +102,21 @@ func (mr *MockStockBrandRepositoryMockRecorder) FindByIDs(ctx, ids any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockStockBrandRepository)(nil).FindByIDs), ctx, ids)
 }
 
+// FindByTickerSymbols mocks base method.
+func (m *MockStockBrandRepository) FindByTickerSymbols(ctx context.Context, tickerSymbols []string) ([]*models.StockBrand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTickerSymbols", ctx, tickerSymbols)
+	ret0, _ := ret[0].([]*models.StockBrand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTickerSymbols indicates an expected call of FindByTickerSymbols.
+func (mr *MockStockBrandRepositoryMockRecorder) FindByTickerSymbols(ctx, tickerSymbols any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTickerSymbols", reflect.TypeOf((*MockStockBrandRepository)(nil).FindByTickerSymbols), ctx, tickerSymbols)
+}
+
 // FindDelistingStockBrandsFromUpdateTime mocks base method.
 func (m *MockStockBrandRepository) FindDelistingStockBrandsFromUpdateTime(ctx context.Context, now time.Time) ([]string, error) {
 	m.ctrl.T.Helper()
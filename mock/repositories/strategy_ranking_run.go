@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: strategy_ranking_run.go
+//
+// Generated by this command:
+//
+//	mockgen -source=strategy_ranking_run.go -package=mock_repositories -destination=../mock/repositories/strategy_ranking_run.go
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStrategyRankingRunRepository is a mock of StrategyRankingRunRepository interface.
+type MockStrategyRankingRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStrategyRankingRunRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStrategyRankingRunRepositoryMockRecorder is the mock recorder for MockStrategyRankingRunRepository.
+type MockStrategyRankingRunRepositoryMockRecorder struct {
+	mock *MockStrategyRankingRunRepository
+}
+
+// NewMockStrategyRankingRunRepository creates a new mock instance.
+func NewMockStrategyRankingRunRepository(ctrl *gomock.Controller) *MockStrategyRankingRunRepository {
+	mock := &MockStrategyRankingRunRepository{ctrl: ctrl}
+	mock.recorder = &MockStrategyRankingRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStrategyRankingRunRepository) EXPECT() *MockStrategyRankingRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BulkCreateItems mocks base method.
+func (m *MockStrategyRankingRunRepository) BulkCreateItems(ctx context.Context, runID, segment string, items []models.StrategyRankingItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreateItems", ctx, runID, segment, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkCreateItems indicates an expected call of BulkCreateItems.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) BulkCreateItems(ctx, runID, segment, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateItems", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).BulkCreateItems), ctx, runID, segment, items)
+}
+
+// BulkCreateStocks mocks base method.
+func (m *MockStrategyRankingRunRepository) BulkCreateStocks(ctx context.Context, runID, strategy string, stocks []*models.StrategyStockResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreateStocks", ctx, runID, strategy, stocks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkCreateStocks indicates an expected call of BulkCreateStocks.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) BulkCreateStocks(ctx, runID, strategy, stocks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateStocks", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).BulkCreateStocks), ctx, runID, strategy, stocks)
+}
+
+// CountStocks mocks base method.
+func (m *MockStrategyRankingRunRepository) CountStocks(ctx context.Context, runID, strategy string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStocks", ctx, runID, strategy)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStocks indicates an expected call of CountStocks.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) CountStocks(ctx, runID, strategy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStocks", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).CountStocks), ctx, runID, strategy)
+}
+
+// Create mocks base method.
+func (m *MockStrategyRankingRunRepository) Create(ctx context.Context, run *models.StrategyRankingRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) Create(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).Create), ctx, run)
+}
+
+// FindByRunID mocks base method.
+func (m *MockStrategyRankingRunRepository) FindByRunID(ctx context.Context, runID string) (*models.StrategyRankingRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByRunID", ctx, runID)
+	ret0, _ := ret[0].(*models.StrategyRankingRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByRunID indicates an expected call of FindByRunID.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) FindByRunID(ctx, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByRunID", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).FindByRunID), ctx, runID)
+}
+
+// FindLatest mocks base method.
+func (m *MockStrategyRankingRunRepository) FindLatest(ctx context.Context, universe string) (*models.StrategyRankingRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatest", ctx, universe)
+	ret0, _ := ret[0].(*models.StrategyRankingRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatest indicates an expected call of FindLatest.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) FindLatest(ctx, universe any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatest", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).FindLatest), ctx, universe)
+}
+
+// List mocks base method.
+func (m *MockStrategyRankingRunRepository) List(ctx context.Context, limit int) ([]*models.StrategyRankingRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit)
+	ret0, _ := ret[0].([]*models.StrategyRankingRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) List(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).List), ctx, limit)
+}
+
+// ListItems mocks base method.
+func (m *MockStrategyRankingRunRepository) ListItems(ctx context.Context, runID, segment string) ([]models.StrategyRankingItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, runID, segment)
+	ret0, _ := ret[0].([]models.StrategyRankingItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) ListItems(ctx, runID, segment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).ListItems), ctx, runID, segment)
+}
+
+// ListStocks mocks base method.
+func (m *MockStrategyRankingRunRepository) ListStocks(ctx context.Context, runID, strategy string, limit int) ([]*models.StrategyStockResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStocks", ctx, runID, strategy, limit)
+	ret0, _ := ret[0].([]*models.StrategyStockResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStocks indicates an expected call of ListStocks.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) ListStocks(ctx, runID, strategy, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStocks", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).ListStocks), ctx, runID, strategy, limit)
+}
+
+// UpdateRun mocks base method.
+func (m *MockStrategyRankingRunRepository) UpdateRun(ctx context.Context, run *models.StrategyRankingRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRun indicates an expected call of UpdateRun.
+func (mr *MockStrategyRankingRunRepositoryMockRecorder) UpdateRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRun", reflect.TypeOf((*MockStrategyRankingRunRepository)(nil).UpdateRun), ctx, run)
+}
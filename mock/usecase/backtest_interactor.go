@@ -56,3 +56,32 @@ func (mr *MockBacktestInteractorMockRecorder) GetBacktestComparison(ctx, symbol,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacktestComparison", reflect.TypeOf((*MockBacktestInteractor)(nil).GetBacktestComparison), ctx, symbol, from, to, params)
 }
+
+// GetBacktestMonteCarlo mocks base method.
+func (m *MockBacktestInteractor) GetBacktestMonteCarlo(ctx context.Context, symbol, strategy string, from, to *time.Time, params models.BacktestParams, mc models.BacktestMonteCarloParams) (*models.BacktestMonteCarlo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacktestMonteCarlo", ctx, symbol, strategy, from, to, params, mc)
+	ret0, _ := ret[0].(*models.BacktestMonteCarlo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacktestMonteCarlo indicates an expected call of GetBacktestMonteCarlo.
+func (mr *MockBacktestInteractorMockRecorder) GetBacktestMonteCarlo(ctx, symbol, strategy, from, to, params, mc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacktestMonteCarlo", reflect.TypeOf((*MockBacktestInteractor)(nil).GetBacktestMonteCarlo), ctx, symbol, strategy, from, to, params, mc)
+}
+
+// ListStrategies mocks base method.
+func (m *MockBacktestInteractor) ListStrategies(ctx context.Context) []models.StrategyInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStrategies", ctx)
+	ret0, _ := ret[0].([]models.StrategyInfo)
+	return ret0
+}
+
+// ListStrategies indicates an expected call of ListStrategies.
+func (mr *MockBacktestInteractorMockRecorder) ListStrategies(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStrategies", reflect.TypeOf((*MockBacktestInteractor)(nil).ListStrategies), ctx)
+}
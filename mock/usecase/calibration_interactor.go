@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: calibration_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=calibration_interactor.go -package=mock_usecase -destination=../mock/usecase/calibration_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCalibrationInteractor is a mock of CalibrationInteractor interface.
+type MockCalibrationInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalibrationInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockCalibrationInteractorMockRecorder is the mock recorder for MockCalibrationInteractor.
+type MockCalibrationInteractorMockRecorder struct {
+	mock *MockCalibrationInteractor
+}
+
+// NewMockCalibrationInteractor creates a new mock instance.
+func NewMockCalibrationInteractor(ctrl *gomock.Controller) *MockCalibrationInteractor {
+	mock := &MockCalibrationInteractor{ctrl: ctrl}
+	mock.recorder = &MockCalibrationInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalibrationInteractor) EXPECT() *MockCalibrationInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetCalibration mocks base method.
+func (m *MockCalibrationInteractor) GetCalibration(ctx context.Context, filter *models.CalibrationFilter) (*models.Calibration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalibration", ctx, filter)
+	ret0, _ := ret[0].(*models.Calibration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalibration indicates an expected call of GetCalibration.
+func (mr *MockCalibrationInteractorMockRecorder) GetCalibration(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalibration", reflect.TypeOf((*MockCalibrationInteractor)(nil).GetCalibration), ctx, filter)
+}
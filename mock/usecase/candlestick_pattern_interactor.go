@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: candlestick_pattern_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=candlestick_pattern_interactor.go -package=mock_usecase -destination=../mock/usecase/candlestick_pattern_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCandlestickPatternInteractor is a mock of CandlestickPatternInteractor interface.
+type MockCandlestickPatternInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockCandlestickPatternInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockCandlestickPatternInteractorMockRecorder is the mock recorder for MockCandlestickPatternInteractor.
+type MockCandlestickPatternInteractorMockRecorder struct {
+	mock *MockCandlestickPatternInteractor
+}
+
+// NewMockCandlestickPatternInteractor creates a new mock instance.
+func NewMockCandlestickPatternInteractor(ctrl *gomock.Controller) *MockCandlestickPatternInteractor {
+	mock := &MockCandlestickPatternInteractor{ctrl: ctrl}
+	mock.recorder = &MockCandlestickPatternInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCandlestickPatternInteractor) EXPECT() *MockCandlestickPatternInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetCandlestickPatterns mocks base method.
+func (m *MockCandlestickPatternInteractor) GetCandlestickPatterns(ctx context.Context, symbol string, from, to *time.Time) (*models.CandlestickPatterns, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandlestickPatterns", ctx, symbol, from, to)
+	ret0, _ := ret[0].(*models.CandlestickPatterns)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandlestickPatterns indicates an expected call of GetCandlestickPatterns.
+func (mr *MockCandlestickPatternInteractorMockRecorder) GetCandlestickPatterns(ctx, symbol, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandlestickPatterns", reflect.TypeOf((*MockCandlestickPatternInteractor)(nil).GetCandlestickPatterns), ctx, symbol, from, to)
+}
+
+// ScreenCandlestickPatterns mocks base method.
+func (m *MockCandlestickPatternInteractor) ScreenCandlestickPatterns(ctx context.Context, date *time.Time) (*models.CandlestickPatternScreening, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScreenCandlestickPatterns", ctx, date)
+	ret0, _ := ret[0].(*models.CandlestickPatternScreening)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScreenCandlestickPatterns indicates an expected call of ScreenCandlestickPatterns.
+func (mr *MockCandlestickPatternInteractorMockRecorder) ScreenCandlestickPatterns(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScreenCandlestickPatterns", reflect.TypeOf((*MockCandlestickPatternInteractor)(nil).ScreenCandlestickPatterns), ctx, date)
+}
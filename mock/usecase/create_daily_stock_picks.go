@@ -42,15 +42,15 @@ func (m *MockCreateDailyStockPicksInteractor) EXPECT() *MockCreateDailyStockPick
 }
 
 // CreateDailyStockPicks mocks base method.
-func (m *MockCreateDailyStockPicksInteractor) CreateDailyStockPicks(ctx context.Context, now time.Time, topN, maxPerSector, concurrency int, force bool) error {
+func (m *MockCreateDailyStockPicksInteractor) CreateDailyStockPicks(ctx context.Context, now time.Time, topN, maxPerSector, concurrency int, regimes []string, withConfluence, force bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDailyStockPicks", ctx, now, topN, maxPerSector, concurrency, force)
+	ret := m.ctrl.Call(m, "CreateDailyStockPicks", ctx, now, topN, maxPerSector, concurrency, regimes, withConfluence, force)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateDailyStockPicks indicates an expected call of CreateDailyStockPicks.
-func (mr *MockCreateDailyStockPicksInteractorMockRecorder) CreateDailyStockPicks(ctx, now, topN, maxPerSector, concurrency, force any) *gomock.Call {
+func (mr *MockCreateDailyStockPicksInteractorMockRecorder) CreateDailyStockPicks(ctx, now, topN, maxPerSector, concurrency, regimes, withConfluence, force any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDailyStockPicks", reflect.TypeOf((*MockCreateDailyStockPicksInteractor)(nil).CreateDailyStockPicks), ctx, now, topN, maxPerSector, concurrency, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDailyStockPicks", reflect.TypeOf((*MockCreateDailyStockPicksInteractor)(nil).CreateDailyStockPicks), ctx, now, topN, maxPerSector, concurrency, regimes, withConfluence, force)
 }
@@ -43,18 +43,18 @@ func (m *MockDailyStockPickInteractor) EXPECT() *MockDailyStockPickInteractorMoc
 }
 
 // GetDay mocks base method.
-func (m *MockDailyStockPickInteractor) GetDay(ctx context.Context, date *time.Time) (*models.DailyStockPickDay, error) {
+func (m *MockDailyStockPickInteractor) GetDay(ctx context.Context, date *time.Time, scoreVersion string) (*models.DailyStockPickDay, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDay", ctx, date)
+	ret := m.ctrl.Call(m, "GetDay", ctx, date, scoreVersion)
 	ret0, _ := ret[0].(*models.DailyStockPickDay)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDay indicates an expected call of GetDay.
-func (mr *MockDailyStockPickInteractorMockRecorder) GetDay(ctx, date any) *gomock.Call {
+func (mr *MockDailyStockPickInteractorMockRecorder) GetDay(ctx, date, scoreVersion any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDay", reflect.TypeOf((*MockDailyStockPickInteractor)(nil).GetDay), ctx, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDay", reflect.TypeOf((*MockDailyStockPickInteractor)(nil).GetDay), ctx, date, scoreVersion)
 }
 
 // GetPickDates mocks base method.
@@ -86,3 +86,18 @@ func (mr *MockDailyStockPickInteractorMockRecorder) GetStats(ctx, from, to, scor
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockDailyStockPickInteractor)(nil).GetStats), ctx, from, to, scoreVersion)
 }
+
+// GetStatsBreakdown mocks base method.
+func (m *MockDailyStockPickInteractor) GetStatsBreakdown(ctx context.Context, from, to *time.Time, scoreVersion string) (*models.DailyStockPickStatsBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatsBreakdown", ctx, from, to, scoreVersion)
+	ret0, _ := ret[0].(*models.DailyStockPickStatsBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatsBreakdown indicates an expected call of GetStatsBreakdown.
+func (mr *MockDailyStockPickInteractorMockRecorder) GetStatsBreakdown(ctx, from, to, scoreVersion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatsBreakdown", reflect.TypeOf((*MockDailyStockPickInteractor)(nil).GetStatsBreakdown), ctx, from, to, scoreVersion)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event_study_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=event_study_interactor.go -package=mock_usecase -destination=../mock/usecase/event_study_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventStudyInteractor is a mock of EventStudyInteractor interface.
+type MockEventStudyInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventStudyInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockEventStudyInteractorMockRecorder is the mock recorder for MockEventStudyInteractor.
+type MockEventStudyInteractorMockRecorder struct {
+	mock *MockEventStudyInteractor
+}
+
+// NewMockEventStudyInteractor creates a new mock instance.
+func NewMockEventStudyInteractor(ctrl *gomock.Controller) *MockEventStudyInteractor {
+	mock := &MockEventStudyInteractor{ctrl: ctrl}
+	mock.recorder = &MockEventStudyInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventStudyInteractor) EXPECT() *MockEventStudyInteractorMockRecorder {
+	return m.recorder
+}
+
+// RunEventStudy mocks base method.
+func (m *MockEventStudyInteractor) RunEventStudy(ctx context.Context, req *models.EventStudyRequest) (*models.EventStudyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunEventStudy", ctx, req)
+	ret0, _ := ret[0].(*models.EventStudyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunEventStudy indicates an expected call of RunEventStudy.
+func (mr *MockEventStudyInteractorMockRecorder) RunEventStudy(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunEventStudy", reflect.TypeOf((*MockEventStudyInteractor)(nil).RunEventStudy), ctx, req)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mark_analyze_signals.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mark_analyze_signals.go -package=mock_usecase -destination=../mock/usecase/mark_analyze_signals.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMarkAnalyzeSignalsInteractor is a mock of MarkAnalyzeSignalsInteractor interface.
+type MockMarkAnalyzeSignalsInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarkAnalyzeSignalsInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockMarkAnalyzeSignalsInteractorMockRecorder is the mock recorder for MockMarkAnalyzeSignalsInteractor.
+type MockMarkAnalyzeSignalsInteractorMockRecorder struct {
+	mock *MockMarkAnalyzeSignalsInteractor
+}
+
+// NewMockMarkAnalyzeSignalsInteractor creates a new mock instance.
+func NewMockMarkAnalyzeSignalsInteractor(ctrl *gomock.Controller) *MockMarkAnalyzeSignalsInteractor {
+	mock := &MockMarkAnalyzeSignalsInteractor{ctrl: ctrl}
+	mock.recorder = &MockMarkAnalyzeSignalsInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarkAnalyzeSignalsInteractor) EXPECT() *MockMarkAnalyzeSignalsInteractorMockRecorder {
+	return m.recorder
+}
+
+// MarkAnalyzeSignals mocks base method.
+func (m *MockMarkAnalyzeSignalsInteractor) MarkAnalyzeSignals(ctx context.Context, asOf time.Time, closeAfterDays int) (*models.AnalyzeSignalMarkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAnalyzeSignals", ctx, asOf, closeAfterDays)
+	ret0, _ := ret[0].(*models.AnalyzeSignalMarkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAnalyzeSignals indicates an expected call of MarkAnalyzeSignals.
+func (mr *MockMarkAnalyzeSignalsInteractorMockRecorder) MarkAnalyzeSignals(ctx, asOf, closeAfterDays any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAnalyzeSignals", reflect.TypeOf((*MockMarkAnalyzeSignalsInteractor)(nil).MarkAnalyzeSignals), ctx, asOf, closeAfterDays)
+}
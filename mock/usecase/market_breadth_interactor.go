@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: market_breadth_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=market_breadth_interactor.go -package=mock_usecase -destination=../mock/usecase/market_breadth_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMarketBreadthInteractor is a mock of MarketBreadthInteractor interface.
+type MockMarketBreadthInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketBreadthInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockMarketBreadthInteractorMockRecorder is the mock recorder for MockMarketBreadthInteractor.
+type MockMarketBreadthInteractorMockRecorder struct {
+	mock *MockMarketBreadthInteractor
+}
+
+// NewMockMarketBreadthInteractor creates a new mock instance.
+func NewMockMarketBreadthInteractor(ctrl *gomock.Controller) *MockMarketBreadthInteractor {
+	mock := &MockMarketBreadthInteractor{ctrl: ctrl}
+	mock.recorder = &MockMarketBreadthInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketBreadthInteractor) EXPECT() *MockMarketBreadthInteractorMockRecorder {
+	return m.recorder
+}
+
+// CalculateMarketBreadth mocks base method.
+func (m *MockMarketBreadthInteractor) CalculateMarketBreadth(ctx context.Context, from, to time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateMarketBreadth", ctx, from, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateMarketBreadth indicates an expected call of CalculateMarketBreadth.
+func (mr *MockMarketBreadthInteractorMockRecorder) CalculateMarketBreadth(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateMarketBreadth", reflect.TypeOf((*MockMarketBreadthInteractor)(nil).CalculateMarketBreadth), ctx, from, to)
+}
+
+// GetMarketBreadth mocks base method.
+func (m *MockMarketBreadthInteractor) GetMarketBreadth(ctx context.Context, market string, from, to *time.Time) (*models.MarketBreadthSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarketBreadth", ctx, market, from, to)
+	ret0, _ := ret[0].(*models.MarketBreadthSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarketBreadth indicates an expected call of GetMarketBreadth.
+func (mr *MockMarketBreadthInteractorMockRecorder) GetMarketBreadth(ctx, market, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarketBreadth", reflect.TypeOf((*MockMarketBreadthInteractor)(nil).GetMarketBreadth), ctx, market, from, to)
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: market_regime_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=market_regime_interactor.go -package=mock_usecase -destination=../mock/usecase/market_regime_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMarketRegimeInteractor is a mock of MarketRegimeInteractor interface.
+type MockMarketRegimeInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketRegimeInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockMarketRegimeInteractorMockRecorder is the mock recorder for MockMarketRegimeInteractor.
+type MockMarketRegimeInteractorMockRecorder struct {
+	mock *MockMarketRegimeInteractor
+}
+
+// NewMockMarketRegimeInteractor creates a new mock instance.
+func NewMockMarketRegimeInteractor(ctrl *gomock.Controller) *MockMarketRegimeInteractor {
+	mock := &MockMarketRegimeInteractor{ctrl: ctrl}
+	mock.recorder = &MockMarketRegimeInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketRegimeInteractor) EXPECT() *MockMarketRegimeInteractorMockRecorder {
+	return m.recorder
+}
+
+// ClassifyMarketRegimes mocks base method.
+func (m *MockMarketRegimeInteractor) ClassifyMarketRegimes(ctx context.Context, from, to time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClassifyMarketRegimes", ctx, from, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClassifyMarketRegimes indicates an expected call of ClassifyMarketRegimes.
+func (mr *MockMarketRegimeInteractorMockRecorder) ClassifyMarketRegimes(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClassifyMarketRegimes", reflect.TypeOf((*MockMarketRegimeInteractor)(nil).ClassifyMarketRegimes), ctx, from, to)
+}
+
+// GetMarketRegimes mocks base method.
+func (m *MockMarketRegimeInteractor) GetMarketRegimes(ctx context.Context, from, to *time.Time) (*models.MarketRegimeSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarketRegimes", ctx, from, to)
+	ret0, _ := ret[0].(*models.MarketRegimeSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarketRegimes indicates an expected call of GetMarketRegimes.
+func (mr *MockMarketRegimeInteractorMockRecorder) GetMarketRegimes(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarketRegimes", reflect.TypeOf((*MockMarketRegimeInteractor)(nil).GetMarketRegimes), ctx, from, to)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notify_paper_portfolio.go
+//
+// Generated by this command:
+//
+//	mockgen -source=notify_paper_portfolio.go -package=mock_usecase -destination=../mock/usecase/notify_paper_portfolio.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotifyPaperPortfolioInteractor is a mock of NotifyPaperPortfolioInteractor interface.
+type MockNotifyPaperPortfolioInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifyPaperPortfolioInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockNotifyPaperPortfolioInteractorMockRecorder is the mock recorder for MockNotifyPaperPortfolioInteractor.
+type MockNotifyPaperPortfolioInteractorMockRecorder struct {
+	mock *MockNotifyPaperPortfolioInteractor
+}
+
+// NewMockNotifyPaperPortfolioInteractor creates a new mock instance.
+func NewMockNotifyPaperPortfolioInteractor(ctrl *gomock.Controller) *MockNotifyPaperPortfolioInteractor {
+	mock := &MockNotifyPaperPortfolioInteractor{ctrl: ctrl}
+	mock.recorder = &MockNotifyPaperPortfolioInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifyPaperPortfolioInteractor) EXPECT() *MockNotifyPaperPortfolioInteractorMockRecorder {
+	return m.recorder
+}
+
+// NotifyPaperPortfolioWeekly mocks base method.
+func (m *MockNotifyPaperPortfolioInteractor) NotifyPaperPortfolioWeekly(ctx context.Context, account string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyPaperPortfolioWeekly", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyPaperPortfolioWeekly indicates an expected call of NotifyPaperPortfolioWeekly.
+func (mr *MockNotifyPaperPortfolioInteractorMockRecorder) NotifyPaperPortfolioWeekly(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPaperPortfolioWeekly", reflect.TypeOf((*MockNotifyPaperPortfolioInteractor)(nil).NotifyPaperPortfolioWeekly), ctx, account)
+}
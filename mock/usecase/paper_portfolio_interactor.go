@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: paper_portfolio_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=paper_portfolio_interactor.go -package=mock_usecase -destination=../mock/usecase/paper_portfolio_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaperPortfolioInteractor is a mock of PaperPortfolioInteractor interface.
+type MockPaperPortfolioInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaperPortfolioInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockPaperPortfolioInteractorMockRecorder is the mock recorder for MockPaperPortfolioInteractor.
+type MockPaperPortfolioInteractorMockRecorder struct {
+	mock *MockPaperPortfolioInteractor
+}
+
+// NewMockPaperPortfolioInteractor creates a new mock instance.
+func NewMockPaperPortfolioInteractor(ctrl *gomock.Controller) *MockPaperPortfolioInteractor {
+	mock := &MockPaperPortfolioInteractor{ctrl: ctrl}
+	mock.recorder = &MockPaperPortfolioInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaperPortfolioInteractor) EXPECT() *MockPaperPortfolioInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetPaperPortfolio mocks base method.
+func (m *MockPaperPortfolioInteractor) GetPaperPortfolio(ctx context.Context, account string, from, to *time.Time) (*models.PaperPortfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaperPortfolio", ctx, account, from, to)
+	ret0, _ := ret[0].(*models.PaperPortfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaperPortfolio indicates an expected call of GetPaperPortfolio.
+func (mr *MockPaperPortfolioInteractorMockRecorder) GetPaperPortfolio(ctx, account, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaperPortfolio", reflect.TypeOf((*MockPaperPortfolioInteractor)(nil).GetPaperPortfolio), ctx, account, from, to)
+}
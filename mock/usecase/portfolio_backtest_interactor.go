@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: portfolio_backtest_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=portfolio_backtest_interactor.go -package=mock_usecase -destination=../mock/usecase/portfolio_backtest_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPortfolioBacktestInteractor is a mock of PortfolioBacktestInteractor interface.
+type MockPortfolioBacktestInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockPortfolioBacktestInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockPortfolioBacktestInteractorMockRecorder is the mock recorder for MockPortfolioBacktestInteractor.
+type MockPortfolioBacktestInteractorMockRecorder struct {
+	mock *MockPortfolioBacktestInteractor
+}
+
+// NewMockPortfolioBacktestInteractor creates a new mock instance.
+func NewMockPortfolioBacktestInteractor(ctrl *gomock.Controller) *MockPortfolioBacktestInteractor {
+	mock := &MockPortfolioBacktestInteractor{ctrl: ctrl}
+	mock.recorder = &MockPortfolioBacktestInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPortfolioBacktestInteractor) EXPECT() *MockPortfolioBacktestInteractorMockRecorder {
+	return m.recorder
+}
+
+// RunPortfolioBacktest mocks base method.
+func (m *MockPortfolioBacktestInteractor) RunPortfolioBacktest(ctx context.Context, params models.PortfolioBacktestParams, from, to *time.Time) (*models.PortfolioBacktestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunPortfolioBacktest", ctx, params, from, to)
+	ret0, _ := ret[0].(*models.PortfolioBacktestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunPortfolioBacktest indicates an expected call of RunPortfolioBacktest.
+func (mr *MockPortfolioBacktestInteractorMockRecorder) RunPortfolioBacktest(ctx, params, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunPortfolioBacktest", reflect.TypeOf((*MockPortfolioBacktestInteractor)(nil).RunPortfolioBacktest), ctx, params, from, to)
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: relative_strength_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=relative_strength_interactor.go -package=mock_usecase -destination=../mock/usecase/relative_strength_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRelativeStrengthInteractor is a mock of RelativeStrengthInteractor interface.
+type MockRelativeStrengthInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelativeStrengthInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockRelativeStrengthInteractorMockRecorder is the mock recorder for MockRelativeStrengthInteractor.
+type MockRelativeStrengthInteractorMockRecorder struct {
+	mock *MockRelativeStrengthInteractor
+}
+
+// NewMockRelativeStrengthInteractor creates a new mock instance.
+func NewMockRelativeStrengthInteractor(ctrl *gomock.Controller) *MockRelativeStrengthInteractor {
+	mock := &MockRelativeStrengthInteractor{ctrl: ctrl}
+	mock.recorder = &MockRelativeStrengthInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelativeStrengthInteractor) EXPECT() *MockRelativeStrengthInteractorMockRecorder {
+	return m.recorder
+}
+
+// CalculateRelativeStrength mocks base method.
+func (m *MockRelativeStrengthInteractor) CalculateRelativeStrength(ctx context.Context, date time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateRelativeStrength", ctx, date)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateRelativeStrength indicates an expected call of CalculateRelativeStrength.
+func (mr *MockRelativeStrengthInteractorMockRecorder) CalculateRelativeStrength(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateRelativeStrength", reflect.TypeOf((*MockRelativeStrengthInteractor)(nil).CalculateRelativeStrength), ctx, date)
+}
+
+// GetRelativeStrength mocks base method.
+func (m *MockRelativeStrengthInteractor) GetRelativeStrength(ctx context.Context, date *time.Time, minRating int) (*models.RelativeStrengthRatings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelativeStrength", ctx, date, minRating)
+	ret0, _ := ret[0].(*models.RelativeStrengthRatings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelativeStrength indicates an expected call of GetRelativeStrength.
+func (mr *MockRelativeStrengthInteractorMockRecorder) GetRelativeStrength(ctx, date, minRating any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelativeStrength", reflect.TypeOf((*MockRelativeStrengthInteractor)(nil).GetRelativeStrength), ctx, date, minRating)
+}
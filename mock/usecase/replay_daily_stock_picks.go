@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: replay_daily_stock_picks.go
+//
+// Generated by this command:
+//
+//	mockgen -source=replay_daily_stock_picks.go -package=mock_usecase -destination=../mock/usecase/replay_daily_stock_picks.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReplayDailyStockPicksInteractor is a mock of ReplayDailyStockPicksInteractor interface.
+type MockReplayDailyStockPicksInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockReplayDailyStockPicksInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockReplayDailyStockPicksInteractorMockRecorder is the mock recorder for MockReplayDailyStockPicksInteractor.
+type MockReplayDailyStockPicksInteractorMockRecorder struct {
+	mock *MockReplayDailyStockPicksInteractor
+}
+
+// NewMockReplayDailyStockPicksInteractor creates a new mock instance.
+func NewMockReplayDailyStockPicksInteractor(ctrl *gomock.Controller) *MockReplayDailyStockPicksInteractor {
+	mock := &MockReplayDailyStockPicksInteractor{ctrl: ctrl}
+	mock.recorder = &MockReplayDailyStockPicksInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReplayDailyStockPicksInteractor) EXPECT() *MockReplayDailyStockPicksInteractorMockRecorder {
+	return m.recorder
+}
+
+// ReplayDailyStockPicks mocks base method.
+func (m *MockReplayDailyStockPicksInteractor) ReplayDailyStockPicks(ctx context.Context, now, from, to time.Time, versions []string, topN, maxPerSector, concurrency int, regimes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDailyStockPicks", ctx, now, from, to, versions, topN, maxPerSector, concurrency, regimes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplayDailyStockPicks indicates an expected call of ReplayDailyStockPicks.
+func (mr *MockReplayDailyStockPicksInteractorMockRecorder) ReplayDailyStockPicks(ctx, now, from, to, versions, topN, maxPerSector, concurrency, regimes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDailyStockPicks", reflect.TypeOf((*MockReplayDailyStockPicksInteractor)(nil).ReplayDailyStockPicks), ctx, now, from, to, versions, topN, maxPerSector, concurrency, regimes)
+}
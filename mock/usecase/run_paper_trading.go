@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: run_paper_trading.go
+//
+// Generated by this command:
+//
+//	mockgen -source=run_paper_trading.go -package=mock_usecase -destination=../mock/usecase/run_paper_trading.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRunPaperTradingInteractor is a mock of RunPaperTradingInteractor interface.
+type MockRunPaperTradingInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockRunPaperTradingInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockRunPaperTradingInteractorMockRecorder is the mock recorder for MockRunPaperTradingInteractor.
+type MockRunPaperTradingInteractorMockRecorder struct {
+	mock *MockRunPaperTradingInteractor
+}
+
+// NewMockRunPaperTradingInteractor creates a new mock instance.
+func NewMockRunPaperTradingInteractor(ctrl *gomock.Controller) *MockRunPaperTradingInteractor {
+	mock := &MockRunPaperTradingInteractor{ctrl: ctrl}
+	mock.recorder = &MockRunPaperTradingInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRunPaperTradingInteractor) EXPECT() *MockRunPaperTradingInteractorMockRecorder {
+	return m.recorder
+}
+
+// RunPaperTrading mocks base method.
+func (m *MockRunPaperTradingInteractor) RunPaperTrading(ctx context.Context, now time.Time, account models.PaperAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunPaperTrading", ctx, now, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunPaperTrading indicates an expected call of RunPaperTrading.
+func (mr *MockRunPaperTradingInteractorMockRecorder) RunPaperTrading(ctx, now, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunPaperTrading", reflect.TypeOf((*MockRunPaperTradingInteractor)(nil).RunPaperTrading), ctx, now, account)
+}
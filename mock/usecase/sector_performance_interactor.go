@@ -56,3 +56,18 @@ func (mr *MockSectorPerformanceInteractorMockRecorder) GetSectorPerformance(ctx,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSectorPerformance", reflect.TypeOf((*MockSectorPerformanceInteractor)(nil).GetSectorPerformance), ctx, from, to, granularity)
 }
+
+// GetSectorRotation mocks base method.
+func (m *MockSectorPerformanceInteractor) GetSectorRotation(ctx context.Context, to time.Time, level string, weeks, window int) (*models.SectorRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSectorRotation", ctx, to, level, weeks, window)
+	ret0, _ := ret[0].(*models.SectorRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSectorRotation indicates an expected call of GetSectorRotation.
+func (mr *MockSectorPerformanceInteractorMockRecorder) GetSectorRotation(ctx, to, level, weeks, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSectorRotation", reflect.TypeOf((*MockSectorPerformanceInteractor)(nil).GetSectorRotation), ctx, to, level, weeks, window)
+}
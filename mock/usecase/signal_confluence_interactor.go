@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: signal_confluence_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=signal_confluence_interactor.go -package=mock_usecase -destination=../mock/usecase/signal_confluence_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSignalConfluenceInteractor is a mock of SignalConfluenceInteractor interface.
+type MockSignalConfluenceInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignalConfluenceInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockSignalConfluenceInteractorMockRecorder is the mock recorder for MockSignalConfluenceInteractor.
+type MockSignalConfluenceInteractorMockRecorder struct {
+	mock *MockSignalConfluenceInteractor
+}
+
+// NewMockSignalConfluenceInteractor creates a new mock instance.
+func NewMockSignalConfluenceInteractor(ctrl *gomock.Controller) *MockSignalConfluenceInteractor {
+	mock := &MockSignalConfluenceInteractor{ctrl: ctrl}
+	mock.recorder = &MockSignalConfluenceInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignalConfluenceInteractor) EXPECT() *MockSignalConfluenceInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetWeightedSignalStocks mocks base method.
+func (m *MockSignalConfluenceInteractor) GetWeightedSignalStocks(ctx context.Context, filter *models.WeightedSignalStockFilter) (*models.WeightedSignalStocks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeightedSignalStocks", ctx, filter)
+	ret0, _ := ret[0].(*models.WeightedSignalStocks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWeightedSignalStocks indicates an expected call of GetWeightedSignalStocks.
+func (mr *MockSignalConfluenceInteractorMockRecorder) GetWeightedSignalStocks(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeightedSignalStocks", reflect.TypeOf((*MockSignalConfluenceInteractor)(nil).GetWeightedSignalStocks), ctx, filter)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: signal_ingest_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=signal_ingest_interactor.go -package=mock_usecase -destination=../mock/usecase/signal_ingest_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSignalIngestInteractor is a mock of SignalIngestInteractor interface.
+type MockSignalIngestInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignalIngestInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockSignalIngestInteractorMockRecorder is the mock recorder for MockSignalIngestInteractor.
+type MockSignalIngestInteractorMockRecorder struct {
+	mock *MockSignalIngestInteractor
+}
+
+// NewMockSignalIngestInteractor creates a new mock instance.
+func NewMockSignalIngestInteractor(ctrl *gomock.Controller) *MockSignalIngestInteractor {
+	mock := &MockSignalIngestInteractor{ctrl: ctrl}
+	mock.recorder = &MockSignalIngestInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignalIngestInteractor) EXPECT() *MockSignalIngestInteractorMockRecorder {
+	return m.recorder
+}
+
+// IngestSignals mocks base method.
+func (m *MockSignalIngestInteractor) IngestSignals(ctx context.Context, inputs []*models.SignalIngestInput) (*models.SignalIngestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IngestSignals", ctx, inputs)
+	ret0, _ := ret[0].(*models.SignalIngestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IngestSignals indicates an expected call of IngestSignals.
+func (mr *MockSignalIngestInteractorMockRecorder) IngestSignals(ctx, inputs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestSignals", reflect.TypeOf((*MockSignalIngestInteractor)(nil).IngestSignals), ctx, inputs)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: strategy_optimization_interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=strategy_optimization_interactor.go -package=mock_usecase -destination=../mock/usecase/strategy_optimization_interactor.go
+//
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Code0716/stock-price-repository/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStrategyOptimizationInteractor is a mock of StrategyOptimizationInteractor interface.
+type MockStrategyOptimizationInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockStrategyOptimizationInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockStrategyOptimizationInteractorMockRecorder is the mock recorder for MockStrategyOptimizationInteractor.
+type MockStrategyOptimizationInteractorMockRecorder struct {
+	mock *MockStrategyOptimizationInteractor
+}
+
+// NewMockStrategyOptimizationInteractor creates a new mock instance.
+func NewMockStrategyOptimizationInteractor(ctrl *gomock.Controller) *MockStrategyOptimizationInteractor {
+	mock := &MockStrategyOptimizationInteractor{ctrl: ctrl}
+	mock.recorder = &MockStrategyOptimizationInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStrategyOptimizationInteractor) EXPECT() *MockStrategyOptimizationInteractorMockRecorder {
+	return m.recorder
+}
+
+// FailInterruptedOptimizations mocks base method.
+func (m *MockStrategyOptimizationInteractor) FailInterruptedOptimizations(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailInterruptedOptimizations", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailInterruptedOptimizations indicates an expected call of FailInterruptedOptimizations.
+func (mr *MockStrategyOptimizationInteractorMockRecorder) FailInterruptedOptimizations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailInterruptedOptimizations", reflect.TypeOf((*MockStrategyOptimizationInteractor)(nil).FailInterruptedOptimizations), ctx)
+}
+
+// GetOptimization mocks base method.
+func (m *MockStrategyOptimizationInteractor) GetOptimization(ctx context.Context, jobID string) (*models.StrategyOptimizationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptimization", ctx, jobID)
+	ret0, _ := ret[0].(*models.StrategyOptimizationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptimization indicates an expected call of GetOptimization.
+func (mr *MockStrategyOptimizationInteractorMockRecorder) GetOptimization(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptimization", reflect.TypeOf((*MockStrategyOptimizationInteractor)(nil).GetOptimization), ctx, jobID)
+}
+
+// Optimize mocks base method.
+func (m *MockStrategyOptimizationInteractor) Optimize(ctx context.Context, params models.StrategyOptimizationParams, concurrency int) (*models.StrategyOptimizationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Optimize", ctx, params, concurrency)
+	ret0, _ := ret[0].(*models.StrategyOptimizationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Optimize indicates an expected call of Optimize.
+func (mr *MockStrategyOptimizationInteractorMockRecorder) Optimize(ctx, params, concurrency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Optimize", reflect.TypeOf((*MockStrategyOptimizationInteractor)(nil).Optimize), ctx, params, concurrency)
+}
+
+// StartOptimization mocks base method.
+func (m *MockStrategyOptimizationInteractor) StartOptimization(ctx context.Context, params models.StrategyOptimizationParams) (*models.StrategyOptimizationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOptimization", ctx, params)
+	ret0, _ := ret[0].(*models.StrategyOptimizationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOptimization indicates an expected call of StartOptimization.
+func (mr *MockStrategyOptimizationInteractorMockRecorder) StartOptimization(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOptimization", reflect.TypeOf((*MockStrategyOptimizationInteractor)(nil).StartOptimization), ctx, params)
+}
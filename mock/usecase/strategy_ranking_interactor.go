@@ -42,46 +42,105 @@ func (m *MockStrategyRankingInteractor) EXPECT() *MockStrategyRankingInteractorM
 }
 
 // ComputeAndSaveStrategyRanking mocks base method.
-func (m *MockStrategyRankingInteractor) ComputeAndSaveStrategyRanking(ctx context.Context, params models.BacktestParams, years, concurrency int) (int, error) {
+func (m *MockStrategyRankingInteractor) ComputeAndSaveStrategyRanking(ctx context.Context, req models.StrategyRankingRequest, concurrency int) (*models.StrategyRankingRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ComputeAndSaveStrategyRanking", ctx, params, years, concurrency)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "ComputeAndSaveStrategyRanking", ctx, req, concurrency)
+	ret0, _ := ret[0].(*models.StrategyRankingRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ComputeAndSaveStrategyRanking indicates an expected call of ComputeAndSaveStrategyRanking.
-func (mr *MockStrategyRankingInteractorMockRecorder) ComputeAndSaveStrategyRanking(ctx, params, years, concurrency any) *gomock.Call {
+func (mr *MockStrategyRankingInteractorMockRecorder) ComputeAndSaveStrategyRanking(ctx, req, concurrency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeAndSaveStrategyRanking", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).ComputeAndSaveStrategyRanking), ctx, params, years, concurrency)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeAndSaveStrategyRanking", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).ComputeAndSaveStrategyRanking), ctx, req, concurrency)
+}
+
+// DiffStrategyRankingRuns mocks base method.
+func (m *MockStrategyRankingInteractor) DiffStrategyRankingRuns(ctx context.Context, baseRunID, targetRunID, segment string) (*models.StrategyRankingDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffStrategyRankingRuns", ctx, baseRunID, targetRunID, segment)
+	ret0, _ := ret[0].(*models.StrategyRankingDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffStrategyRankingRuns indicates an expected call of DiffStrategyRankingRuns.
+func (mr *MockStrategyRankingInteractorMockRecorder) DiffStrategyRankingRuns(ctx, baseRunID, targetRunID, segment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffStrategyRankingRuns", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).DiffStrategyRankingRuns), ctx, baseRunID, targetRunID, segment)
+}
+
+// FailInterruptedStrategyRankings mocks base method.
+func (m *MockStrategyRankingInteractor) FailInterruptedStrategyRankings(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailInterruptedStrategyRankings", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailInterruptedStrategyRankings indicates an expected call of FailInterruptedStrategyRankings.
+func (mr *MockStrategyRankingInteractorMockRecorder) FailInterruptedStrategyRankings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailInterruptedStrategyRankings", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).FailInterruptedStrategyRankings), ctx)
 }
 
 // GetStrategyRanking mocks base method.
-func (m *MockStrategyRankingInteractor) GetStrategyRanking(ctx context.Context) (*models.StrategyRanking, error) {
+func (m *MockStrategyRankingInteractor) GetStrategyRanking(ctx context.Context, runID string) (*models.StrategyRanking, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStrategyRanking", ctx)
+	ret := m.ctrl.Call(m, "GetStrategyRanking", ctx, runID)
 	ret0, _ := ret[0].(*models.StrategyRanking)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStrategyRanking indicates an expected call of GetStrategyRanking.
-func (mr *MockStrategyRankingInteractorMockRecorder) GetStrategyRanking(ctx any) *gomock.Call {
+func (mr *MockStrategyRankingInteractorMockRecorder) GetStrategyRanking(ctx, runID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrategyRanking", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).GetStrategyRanking), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrategyRanking", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).GetStrategyRanking), ctx, runID)
 }
 
 // GetStrategyRankingStocks mocks base method.
-func (m *MockStrategyRankingInteractor) GetStrategyRankingStocks(ctx context.Context, strategy string, limit int) (*models.StrategyStocks, error) {
+func (m *MockStrategyRankingInteractor) GetStrategyRankingStocks(ctx context.Context, runID, strategy string, limit int) (*models.StrategyStocks, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStrategyRankingStocks", ctx, strategy, limit)
+	ret := m.ctrl.Call(m, "GetStrategyRankingStocks", ctx, runID, strategy, limit)
 	ret0, _ := ret[0].(*models.StrategyStocks)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStrategyRankingStocks indicates an expected call of GetStrategyRankingStocks.
-func (mr *MockStrategyRankingInteractorMockRecorder) GetStrategyRankingStocks(ctx, strategy, limit any) *gomock.Call {
+func (mr *MockStrategyRankingInteractorMockRecorder) GetStrategyRankingStocks(ctx, runID, strategy, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrategyRankingStocks", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).GetStrategyRankingStocks), ctx, runID, strategy, limit)
+}
+
+// ListStrategyRankingRuns mocks base method.
+func (m *MockStrategyRankingInteractor) ListStrategyRankingRuns(ctx context.Context, limit int) (*models.StrategyRankingRuns, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStrategyRankingRuns", ctx, limit)
+	ret0, _ := ret[0].(*models.StrategyRankingRuns)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStrategyRankingRuns indicates an expected call of ListStrategyRankingRuns.
+func (mr *MockStrategyRankingInteractorMockRecorder) ListStrategyRankingRuns(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStrategyRankingRuns", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).ListStrategyRankingRuns), ctx, limit)
+}
+
+// StartStrategyRanking mocks base method.
+func (m *MockStrategyRankingInteractor) StartStrategyRanking(ctx context.Context, req models.StrategyRankingRequest) (*models.StrategyRankingRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartStrategyRanking", ctx, req)
+	ret0, _ := ret[0].(*models.StrategyRankingRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartStrategyRanking indicates an expected call of StartStrategyRanking.
+func (mr *MockStrategyRankingInteractorMockRecorder) StartStrategyRanking(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrategyRankingStocks", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).GetStrategyRankingStocks), ctx, strategy, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartStrategyRanking", reflect.TypeOf((*MockStrategyRankingInteractor)(nil).StartStrategyRanking), ctx, req)
 }
@@ -43,16 +43,16 @@ func (m *MockTechnicalIndicatorsInteractor) EXPECT() *MockTechnicalIndicatorsInt
 }
 
 // GetTechnicalIndicators mocks base method.
-func (m *MockTechnicalIndicatorsInteractor) GetTechnicalIndicators(ctx context.Context, symbol string, from, to *time.Time) (*models.TechnicalIndicators, error) {
+func (m *MockTechnicalIndicatorsInteractor) GetTechnicalIndicators(ctx context.Context, symbol string, from, to *time.Time, indicators []string) (*models.TechnicalIndicators, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTechnicalIndicators", ctx, symbol, from, to)
+	ret := m.ctrl.Call(m, "GetTechnicalIndicators", ctx, symbol, from, to, indicators)
 	ret0, _ := ret[0].(*models.TechnicalIndicators)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTechnicalIndicators indicates an expected call of GetTechnicalIndicators.
-func (mr *MockTechnicalIndicatorsInteractorMockRecorder) GetTechnicalIndicators(ctx, symbol, from, to any) *gomock.Call {
+func (mr *MockTechnicalIndicatorsInteractorMockRecorder) GetTechnicalIndicators(ctx, symbol, from, to, indicators any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTechnicalIndicators", reflect.TypeOf((*MockTechnicalIndicatorsInteractor)(nil).GetTechnicalIndicators), ctx, symbol, from, to)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTechnicalIndicators", reflect.TypeOf((*MockTechnicalIndicatorsInteractor)(nil).GetTechnicalIndicators), ctx, symbol, from, to, indicators)
 }
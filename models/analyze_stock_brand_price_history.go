@@ -25,6 +25,19 @@ const (
 	AnalyzeStockBrandPriceHistoryOrderDesc        string = "desc"
 )
 
+// AnalyzeStockBrandPriceHistoryMethods 分析履歴に書き込める手法の登録簿。外部からの取り込みはここにない手法を拒否する。
+// 手法を追加するときは定数と合わせてここにも登録する。
+var AnalyzeStockBrandPriceHistoryMethods = []string{
+	AnalyzeStockBrandPriceHistoryMethodSector25,
+	AnalyzeStockBrandPriceHistoryMethodSector75,
+	AnalyzeStockBrandPriceHistoryMethodNikkei25,
+	AnalyzeStockBrandPriceHistoryMethodNikkei75,
+	AnalyzeStockBrandPriceHistoryMethodFindMACDBullishV1,
+	AnalyzeStockBrandPriceHistoryMethodFindTriangleV1,
+	AnalyzeStockBrandPriceHistoryMethodFindBollingerBreakoutV1,
+	AnalyzeStockBrandPriceHistoryMethodFindMLRankedV1,
+}
+
 type AnalyzeStockBrandPriceHistory struct {
 	ID              string           `json:"id"`
 	StockBrandID    string           `json:"stockBrandId"`
@@ -38,9 +51,33 @@ type AnalyzeStockBrandPriceHistory struct {
 	Memo            *string          `json:"memo"`
 	Score           *decimal.Decimal `json:"score"`
 	SignalRank      *int             `json:"signalRank"`
+	MarkedAt        *time.Time       `json:"markedAt"`      // CurrentPrice の基準日（値洗い前は nil）
+	ClosedAt        *time.Time       `json:"closedAt"`      // クローズ日（建玉中は nil）
+	ExitPrice       *decimal.Decimal `json:"exitPrice"`     // クローズ日の終値（分割・併合調整済み）
+	HoldingReturn   *decimal.Decimal `json:"holdingReturn"` // 保有リターン（Buy: exit/trade-1、Sell: 1-exit/trade）
 	CreatedAt       time.Time        `json:"createdAt"`
 }
 
+// AnalyzeStockBrandPriceHistoryMark 建玉中のシグナル1件の値洗い結果。
+// 価格はすべてシグナル日の株数基準（その後の分割・併合を調整済み）で TradePrice と比べられる。
+type AnalyzeStockBrandPriceHistoryMark struct {
+	ID              string
+	CurrentPrice    decimal.Decimal
+	PriceDifference decimal.Decimal
+	MarkedAt        time.Time
+	ClosedAt        *time.Time // クローズしたときだけ設定
+	ExitPrice       *decimal.Decimal
+	HoldingReturn   *decimal.Decimal
+}
+
+// AnalyzeSignalMarkResult 値洗いバッチの結果
+type AnalyzeSignalMarkResult struct {
+	Open    int // 値洗い対象（建玉中）のシグナル数
+	Marked  int // 値洗いしたシグナル数（クローズしたものを含む）
+	Closed  int // 今回クローズしたシグナル数
+	Skipped int // 日足が無く値洗いできなかったシグナル数
+}
+
 type AnalyzeStockBrandPriceHistoryFilter struct {
 	TickerSymbol string
 	Action       string
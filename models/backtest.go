@@ -12,8 +12,21 @@ type BacktestParams struct {
 	// ExitMode イグジットモード: "common"（デフォルト・従来動作）/ "signal"（戦略固有シグナルで手仕舞い）。
 	// "common" はリクエスト省略時のデフォルト。
 	ExitMode string `json:"exitMode"`
+	// 約定モデル。ゼロ値はいずれも従来動作（シグナル当日終値でエントリー、終値で TP/SL 判定）。
+	EntryTiming      string `json:"entryTiming"`      // "close" / "next_open"
+	ExitFill         string `json:"exitFill"`         // "close" / "intrabar"
+	IntrabarPriority string `json:"intrabarPriority"` // 同一バーで TP/SL の両方に達したときの優先: "stop_loss" / "take_profit"
+	TickRounding     bool   `json:"tickRounding"`     // 利確・損切り水準を東証の呼値単位に丸める
+	// BorrowRate 空売り戦略の貸株料（日率。例: 0.00003 ≈ 年1.1%）。暦日数で日割りする。買い戦略では無視。
+	BorrowRate decimal.Decimal `json:"borrowRate"`
 }
 
+// 売買方向。
+const (
+	PositionSideLong  = "long"
+	PositionSideShort = "short" // 信用売り
+)
+
 // ExitModeCommon 共通ルール（TakeProfit/StopLoss/MaxHoldDays）のみで手仕舞い。デフォルト動作。
 const ExitModeCommon = "common"
 
@@ -21,6 +34,24 @@ const ExitModeCommon = "common"
 // 共通ルールが同日に成立した場合は共通ルールが優先される。
 const ExitModeSignal = "signal"
 
+// エントリー約定タイミング。
+const (
+	EntryTimingClose    = "close"     // シグナル当日の終値（従来動作）
+	EntryTimingNextOpen = "next_open" // シグナル翌営業日の始値
+)
+
+// 利確・損切りの約定方式。
+const (
+	ExitFillClose    = "close"    // 終値が水準を超えた日の終値で手仕舞い（従来動作）
+	ExitFillIntrabar = "intrabar" // 高値・安値が水準に達した時点で水準価格（寄りで跨いだ場合は始値）で手仕舞い
+)
+
+// 同一バーで利確・損切りの両方に達したときの優先。高安の前後は日足から分からないため既定は保守的に損切り。
+const (
+	IntrabarPriorityStopLoss   = "stop_loss"
+	IntrabarPriorityTakeProfit = "take_profit"
+)
+
 // BacktestEquityPoint エクイティカーブの1点（初期資金=1.0 を起点とした倍率）。
 type BacktestEquityPoint struct {
 	Date   string          `json:"date"`
@@ -58,6 +89,7 @@ type BacktestResult struct {
 type StrategyBacktest struct {
 	Strategy string         `json:"strategy"` // 識別子
 	Label    string         `json:"label"`    // 日本語表示名
+	Side     string         `json:"side"`     // long / short
 	Result   BacktestResult `json:"result"`
 }
 
@@ -0,0 +1,66 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// モンテカルロの再標本化方式。
+const (
+	MonteCarloMethodBootstrap   = "bootstrap"   // トレードリターンを復元抽出（同じ件数）
+	MonteCarloMethodPermutation = "permutation" // トレード順序のみ入れ替え（リターンの集合は不変）
+)
+
+// BacktestMonteCarloParams モンテカルロ分析の条件。
+type BacktestMonteCarloParams struct {
+	Iterations int   `json:"iterations"`
+	Seed       int64 `json:"seed"` // 乱数シード（再現用）。0 はリクエスト時に自動採番
+	// RuinThreshold 破産とみなす初期資金からの下落率（例: 0.5 = 資金が半分以下）。
+	RuinThreshold decimal.Decimal `json:"ruinThreshold"`
+}
+
+// MonteCarloDistribution 1指標の試行分布の要約。
+type MonteCarloDistribution struct {
+	Mean   decimal.Decimal `json:"mean"`
+	StdDev decimal.Decimal `json:"stdDev"`
+	Min    decimal.Decimal `json:"min"`
+	P5     decimal.Decimal `json:"p5"`
+	P25    decimal.Decimal `json:"p25"`
+	P50    decimal.Decimal `json:"p50"`
+	P75    decimal.Decimal `json:"p75"`
+	P95    decimal.Decimal `json:"p95"`
+	Max    decimal.Decimal `json:"max"`
+}
+
+// MonteCarloSimulation 1方式の試行結果。
+type MonteCarloSimulation struct {
+	Method          string                 `json:"method"`
+	TotalReturn     MonteCarloDistribution `json:"totalReturn"`     // 複利トータルリターン
+	MaxDrawdown     MonteCarloDistribution `json:"maxDrawdown"`     // 決済ベースのエクイティの最大下落率（負値）
+	MaxLosingStreak MonteCarloDistribution `json:"maxLosingStreak"` // 最大連敗数
+	// ProbabilityOfLoss トータルリターンが負になった試行の割合。
+	ProbabilityOfLoss decimal.Decimal `json:"probabilityOfLoss"`
+	// RiskOfRuin 途中でエクイティが 1 - RuinThreshold 以下に落ちた試行の割合。
+	RiskOfRuin decimal.Decimal `json:"riskOfRuin"`
+	// OriginalPercentile 実際の順序でのトータルリターンが分布の何%点にあたるか（0〜1）。
+	OriginalPercentile decimal.Decimal `json:"originalPercentile"`
+}
+
+// BacktestMonteCarlo 1銘柄×1戦略のトレードリストに対するモンテカルロ分析。
+type BacktestMonteCarlo struct {
+	Symbol     string                   `json:"symbol"`
+	Strategy   string                   `json:"strategy"`
+	Label      string                   `json:"label"`
+	From       string                   `json:"from"`
+	To         string                   `json:"to"`
+	Params     BacktestParams           `json:"params"`
+	MonteCarlo BacktestMonteCarloParams `json:"monteCarlo"`
+	Trades     int                      `json:"trades"`
+	// Original* 実際の取引順での決済ベース指標（試行分布と同じ基準で比較するため日次エクイティではなくトレード単位で計算）。
+	OriginalTotalReturn     decimal.Decimal `json:"originalTotalReturn"`
+	OriginalMaxDrawdown     decimal.Decimal `json:"originalMaxDrawdown"`
+	OriginalMaxLosingStreak int             `json:"originalMaxLosingStreak"`
+	// ReturnWithoutTopTrades 上位 TopTradesExcluded 件の勝ちトレードを除いた複利トータルリターン。
+	// 少数の大勝ちに依存しているかの目安。
+	ReturnWithoutTopTrades decimal.Decimal      `json:"returnWithoutTopTrades"`
+	TopTradesExcluded      int                  `json:"topTradesExcluded"`
+	Bootstrap              MonteCarloSimulation `json:"bootstrap"`
+	Permutation            MonteCarloSimulation `json:"permutation"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// CalibrationSourcePicks 毎晩の買い候補（daily_stock_pick）のスコアを検証する。
+	CalibrationSourcePicks = "picks"
+	// CalibrationSourceSignals analyze_stock_brand_price_history のシグナルのスコアを検証する。
+	CalibrationSourceSignals = "signals"
+)
+
+// CalibrationFilter スコア較正の検索条件
+type CalibrationFilter struct {
+	Source       string // picks / signals
+	From         time.Time
+	To           time.Time
+	ScoreVersion string // picks のみ。空文字なら現行バージョン
+	Method       string // signals のみ（必須）。手法ごとにスコアの尺度が異なるため1手法ずつ検証する
+}
+
+// CalibrationSample スコア較正の1標本（推奨・シグナル1件 × 1 horizon）
+type CalibrationSample struct {
+	Date   time.Time
+	Score  decimal.Decimal
+	Return decimal.Decimal
+}
+
+// CalibrationBucket スコア十分位（件数ベースの等分割）1つぶんの成績
+type CalibrationBucket struct {
+	Bucket    int             `json:"bucket"` // 1=スコア下位 .. 10=スコア上位
+	MinScore  decimal.Decimal `json:"minScore"`
+	MaxScore  decimal.Decimal `json:"maxScore"`
+	Count     int             `json:"count"`
+	AvgReturn decimal.Decimal `json:"avgReturn"`
+	HitRate   decimal.Decimal `json:"hitRate"` // リターンがプラスの割合
+}
+
+// CalibrationMonotonicity 十分位の平均リターンがスコア順に並んでいるか
+type CalibrationMonotonicity struct {
+	Rho             *decimal.Decimal `json:"rho"`             // 十分位番号と平均リターンの順位相関（十分位が3未満なら null）
+	IncreasingSteps int              `json:"increasingSteps"` // 隣り合う十分位で平均リターンが上がった数
+	Steps           int              `json:"steps"`           // 隣り合う十分位の組の数
+	Monotonic       bool             `json:"monotonic"`       // すべての組で上がっていれば true
+}
+
+// CalibrationHitRatePoint スコアが MinScore 以上の標本だけを残したときの成績（足切りの目安）
+type CalibrationHitRatePoint struct {
+	MinScore  decimal.Decimal `json:"minScore"`
+	Count     int             `json:"count"`
+	HitRate   decimal.Decimal `json:"hitRate"`
+	AvgReturn decimal.Decimal `json:"avgReturn"`
+}
+
+// CalibrationICPoint 1日ぶんの順位 IC（同じ日の標本内でのスコアとリターンのスピアマン順位相関）
+type CalibrationICPoint struct {
+	Date  string          `json:"date"`
+	IC    decimal.Decimal `json:"ic"`
+	Count int             `json:"count"`
+}
+
+// CalibrationIC 日次順位 IC の時系列と要約
+type CalibrationIC struct {
+	Days         int                   `json:"days"`
+	Mean         *decimal.Decimal      `json:"mean"`
+	StdDev       *decimal.Decimal      `json:"stdDev"`
+	TStat        *decimal.Decimal      `json:"tStat"` // mean / (stdDev / √days)。2日未満または stdDev=0 なら null
+	PositiveRate *decimal.Decimal      `json:"positiveRate"`
+	Series       []*CalibrationICPoint `json:"series"`
+}
+
+// CalibrationHorizon 1 horizon ぶんの較正結果
+type CalibrationHorizon struct {
+	Horizon      int                        `json:"horizon"` // 営業日
+	SampleCount  int                        `json:"sampleCount"`
+	Deciles      []*CalibrationBucket       `json:"deciles"`
+	Monotonicity CalibrationMonotonicity    `json:"monotonicity"`
+	TopReturn    *decimal.Decimal           `json:"topReturn"`    // 最上位十分位の平均リターン
+	BottomReturn *decimal.Decimal           `json:"bottomReturn"` // 最下位十分位の平均リターン
+	DecileSpread *decimal.Decimal           `json:"decileSpread"` // topReturn - bottomReturn
+	HitRateCurve []*CalibrationHitRatePoint `json:"hitRateCurve"`
+	IC           CalibrationIC              `json:"ic"`
+}
+
+// Calibration API レスポンス全体
+type Calibration struct {
+	Source       string                `json:"source"`
+	ScoreVersion string                `json:"scoreVersion,omitempty"`
+	Method       string                `json:"method,omitempty"`
+	From         time.Time             `json:"from"`
+	To           time.Time             `json:"to"`
+	Horizons     []*CalibrationHorizon `json:"horizons"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ローソク足パターンの方向
+const (
+	CandlestickDirectionBullish = "bullish"
+	CandlestickDirectionBearish = "bearish"
+	CandlestickDirectionNeutral = "neutral"
+)
+
+// ローソク足パターン ID
+const (
+	CandlestickPatternBullishEngulfing   = "bullish_engulfing"
+	CandlestickPatternBearishEngulfing   = "bearish_engulfing"
+	CandlestickPatternBullishHarami      = "bullish_harami"
+	CandlestickPatternBearishHarami      = "bearish_harami"
+	CandlestickPatternHammer             = "hammer"
+	CandlestickPatternShootingStar       = "shooting_star"
+	CandlestickPatternDoji               = "doji"
+	CandlestickPatternDragonflyDoji      = "dragonfly_doji"
+	CandlestickPatternGravestoneDoji     = "gravestone_doji"
+	CandlestickPatternLongLeggedDoji     = "long_legged_doji"
+	CandlestickPatternMorningStar        = "morning_star"
+	CandlestickPatternEveningStar        = "evening_star"
+	CandlestickPatternThreeWhiteSoldiers = "three_white_soldiers"
+	CandlestickPatternGapUp              = "gap_up"
+	CandlestickPatternGapDown            = "gap_down"
+)
+
+// CandlestickPatternHit 1銘柄・1日で検出されたローソク足パターン
+type CandlestickPatternHit struct {
+	Date      time.Time       `json:"date"`
+	Pattern   string          `json:"pattern"`
+	Label     string          `json:"label"`
+	Direction string          `json:"direction"` // bullish / bearish / neutral
+	Strength  decimal.Decimal `json:"strength"`  // 0〜100。実体・ヒゲ・窓の大きさから算出
+}
+
+// CandlestickPatternStats 1パターンの過去の的中率統計（bearish は売り方向のリターン）
+type CandlestickPatternStats struct {
+	Pattern   string                `json:"pattern"`
+	Label     string                `json:"label"`
+	Direction string                `json:"direction"`
+	Count     int                   `json:"count"`
+	Stats     map[int]*HorizonStats `json:"stats"` // key: 5 / 10 / 20
+}
+
+// CandlestickPatterns GET /candlestick-patterns のレスポンス
+type CandlestickPatterns struct {
+	Symbol   string                     `json:"symbol"`
+	From     time.Time                  `json:"from"`
+	To       time.Time                  `json:"to"`
+	Horizons []int                      `json:"horizons"`
+	Patterns []*CandlestickPatternHit   `json:"patterns"`
+	Stats    []*CandlestickPatternStats `json:"stats"`
+}
+
+// CandlestickPatternScreeningItem ユニバース横断で検出された1銘柄・1パターン
+type CandlestickPatternScreeningItem struct {
+	TickerSymbol string          `json:"tickerSymbol"`
+	Name         string          `json:"name"`
+	Pattern      string          `json:"pattern"`
+	Label        string          `json:"label"`
+	Direction    string          `json:"direction"`
+	Strength     decimal.Decimal `json:"strength"`
+}
+
+// CandlestickPatternScreening GET /candlestick-patterns/screening のレスポンス
+type CandlestickPatternScreening struct {
+	Date  time.Time                          `json:"date"`
+	Items []*CandlestickPatternScreeningItem `json:"items"`
+}
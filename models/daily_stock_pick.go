@@ -37,6 +37,7 @@ type DailyStockPick struct {
 	MinusDI           decimal.Decimal
 	ATRRatio          decimal.Decimal
 	RSI               decimal.Decimal
+	ScoreComponents   []DailyStockPickScoreComponent // DBへは JSON で保存。導入前の行は nil
 	Return1D          *decimal.Decimal
 	Return3D          *decimal.Decimal
 	Return5D          *decimal.Decimal
@@ -47,6 +48,14 @@ type DailyStockPick struct {
 	UpdatedAt         time.Time
 }
 
+// DailyStockPickScoreComponent 複合スコアの因子1つ分の内訳。Contribution を全因子で足すと Score になる（丸め誤差を除く）。
+type DailyStockPickScoreComponent struct {
+	Key          string          `json:"key"`          // 因子キー（signal / volume / trend / volatility / liquidity / overheat）
+	Normalized   decimal.Decimal `json:"normalized"`   // 正規化カーブ適用後の値 0..1
+	Weight       decimal.Decimal `json:"weight"`       // スコア設定の重み
+	Contribution decimal.Decimal `json:"contribution"` // Weight × Normalized（スコアへの寄与点）
+}
+
 // Evaluated 答え合わせ済みか。
 func (p *DailyStockPick) Evaluated() bool {
 	return p.EvaluatedAt != nil
@@ -96,6 +105,15 @@ type DailyStockPickStatSummary struct {
 	AvgReturn5D    decimal.Decimal `json:"avgReturn5d"`
 }
 
+// DailyStockPickComponentItem API レスポンス用の因子内訳（日本語表示名付き）。
+type DailyStockPickComponentItem struct {
+	Key          string          `json:"key"`
+	Label        string          `json:"label"`
+	Normalized   decimal.Decimal `json:"normalized"`
+	Weight       decimal.Decimal `json:"weight"`
+	Contribution decimal.Decimal `json:"contribution"`
+}
+
 // DailyStockPickItem API レスポンス用の推奨銘柄1件。答え合わせ前は Return*/Outcome/EvaluatedAt が null。
 type DailyStockPickItem struct {
 	PickRank         int                       `json:"pickRank"`
@@ -120,6 +138,8 @@ type DailyStockPickItem struct {
 	Return5D         *decimal.Decimal          `json:"return5d"`
 	Outcome          *DailyStockPickOutcome    `json:"outcome"`
 	EvaluatedAt      *string                   `json:"evaluatedAt"`
+	// ScoreComponents 因子ごとのスコア内訳（寄与の大きい順）。内訳導入前の推奨は空配列
+	ScoreComponents []*DailyStockPickComponentItem `json:"scoreComponents"`
 }
 
 // DailyStockPickDay GET /daily-stock-picks のレスポンス。
@@ -152,12 +172,81 @@ type DailyStockPickScoreBand struct {
 	DailyStockPickStatSummary
 }
 
+// DailyStockPickRegimeStat 市場局面別の成績。Regime は推奨日の市場局面（未算出の日は unknown）。
+type DailyStockPickRegimeStat struct {
+	Regime string `json:"regime"`
+	DailyStockPickStatSummary
+}
+
+// DailyStockPickVersionStat スコア設定（バージョン）ごとの成績。live 以外は live にも推奨がある日だけで集計し、
+// 同じ日の live の成績を Baseline に並べて直接比べられるようにする。
+type DailyStockPickVersionStat struct {
+	ScoreVersion string `json:"scoreVersion"`
+	Live         bool   `json:"live"`
+	Days         int    `json:"days"` // 集計に使った推奨日数
+	DailyStockPickStatSummary
+	// Baseline 同じ日の live の成績（live 自身は null）
+	Baseline *DailyStockPickStatSummary `json:"baseline"`
+	// BeatLiveDays 5営業日後の平均リターンが live を上回った日数（両方に5営業日後リターンがある日のみ数える）
+	BeatLiveDays int `json:"beatLiveDays"`
+	// OverlapRate 選んだ銘柄のうち live も選んだ銘柄の割合の日平均（live 自身は 1）
+	OverlapRate decimal.Decimal `json:"overlapRate"`
+}
+
+// DailyStockPickComponentStat 因子ごとの寄与と5営業日後リターンの関係。5営業日後リターンと内訳の両方がある推奨だけで集計する。
+type DailyStockPickComponentStat struct {
+	Key             string          `json:"key"`
+	Label           string          `json:"label"`
+	Count           int             `json:"count"`
+	AvgNormalized   decimal.Decimal `json:"avgNormalized"`
+	AvgContribution decimal.Decimal `json:"avgContribution"`
+	// Correlation 正規化値と5営業日後リターンの相関係数（件数が少ない・値が一定で算出できなければ null）
+	Correlation *decimal.Decimal `json:"correlation"`
+	// HighAvgReturn5D / LowAvgReturn5D 正規化値の上位半分・下位半分の平均5営業日後リターン
+	HighAvgReturn5D decimal.Decimal `json:"highAvgReturn5d"`
+	LowAvgReturn5D  decimal.Decimal `json:"lowAvgReturn5d"`
+}
+
 // DailyStockPickStats GET /daily-stock-picks/stats のレスポンス。
 type DailyStockPickStats struct {
-	From         *string                    `json:"from"`
-	To           *string                    `json:"to"`
-	ScoreVersion string                     `json:"scoreVersion"`
-	Totals       DailyStockPickStatSummary  `json:"totals"`
-	Daily        []*DailyStockPickDailyStat `json:"daily"`
-	ScoreBands   []*DailyStockPickScoreBand `json:"scoreBands"`
+	From         *string                     `json:"from"`
+	To           *string                     `json:"to"`
+	ScoreVersion string                      `json:"scoreVersion"`
+	Totals       DailyStockPickStatSummary   `json:"totals"`
+	Daily        []*DailyStockPickDailyStat  `json:"daily"`
+	ScoreBands   []*DailyStockPickScoreBand  `json:"scoreBands"`
+	ByRegime     []*DailyStockPickRegimeStat `json:"byRegime"`
+	// Versions 並走させているスコア設定の live との比較（live が先頭）
+	Versions []*DailyStockPickVersionStat `json:"versions"`
+	// Components 因子ごとの寄与と実現リターンの相関
+	Components []*DailyStockPickComponentStat `json:"components"`
+}
+
+// DailyStockPickBreakdownStat 切り口（業種・市場・戦略・流動性帯・曜日）ごとの成績。
+// Key は集計キー（業種名・市場コード・戦略キーなど。値が無いものは unknown）、Label は表示名。
+type DailyStockPickBreakdownStat struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	DailyStockPickStatSummary
+}
+
+// DailyStockPickBreakdown 切り口別の成績一式。業種・市場・戦略は件数の多い順、流動性帯・曜日は固定順で、該当0件のキーは返さない。
+type DailyStockPickBreakdown struct {
+	BySector33 []*DailyStockPickBreakdownStat `json:"bySector33"`
+	ByMarket   []*DailyStockPickBreakdownStat `json:"byMarket"`
+	// ByStrategy 1件が複数の戦略を持つ場合はそれぞれの戦略に数える（合計は Total を超えうる）
+	ByStrategy  []*DailyStockPickBreakdownStat `json:"byStrategy"`
+	ByLiquidity []*DailyStockPickBreakdownStat `json:"byLiquidity"`
+	ByWeekday   []*DailyStockPickBreakdownStat `json:"byWeekday"`
+}
+
+// DailyStockPickStatsBreakdown GET /daily-stock-picks/stats/breakdown のレスポンス。
+// From/To は集計に使った期間（クエリ未指定側は推奨の実データの範囲で補う）。
+type DailyStockPickStatsBreakdown struct {
+	From         *string                 `json:"from"`
+	To           *string                 `json:"to"`
+	ScoreVersion string                  `json:"scoreVersion"`
+	Picks        DailyStockPickBreakdown `json:"picks"`
+	// Signals 同じ期間の分析シグナル（Buy/Sell）の成績。リターンは方向込み（Sell は符号反転）で、勝敗は5営業日後リターンで判定する
+	Signals DailyStockPickBreakdown `json:"signals"`
 }
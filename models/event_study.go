@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// イベントスタディの市場ポートフォリオ（ベンチマーク）。
+const (
+	EventStudyBenchmarkTopix  = "topix"
+	EventStudyBenchmarkNikkei = "nikkei"
+)
+
+// イベントスタディのイベントの取り出し元。
+const (
+	EventStudySourceCustom          = "custom"           // リクエストで (symbol, date) を直接指定
+	EventStudySourceFinAnnouncement = "fin_announcement" // 決算発表日
+	EventStudySourceAnalyzeSignal   = "analyze_signal"   // 分析履歴のシグナル（method で絞り込み可）
+	EventStudySourceDailyPick       = "daily_pick"       // 翌営業日の買い候補
+	EventStudySourceStockSplit      = "stock_split"      // 適用済みの株式分割
+)
+
+// EventStudySources 指定できるイベントの取り出し元。
+var EventStudySources = []string{
+	EventStudySourceCustom,
+	EventStudySourceFinAnnouncement,
+	EventStudySourceAnalyzeSignal,
+	EventStudySourceDailyPick,
+	EventStudySourceStockSplit,
+}
+
+// EventStudyEvent 1件のイベント（銘柄とイベント日）。
+type EventStudyEvent struct {
+	TickerSymbol string    `json:"tickerSymbol"`
+	Date         time.Time `json:"date"`
+	Label        string    `json:"label,omitempty"` // 決算期・シグナル手法など表示用
+	Short        bool      `json:"short,omitempty"` // 売り方向のイベント。異常リターンの符号を反転して集計する
+}
+
+// EventStudyRequest POST /event-study の条件。
+// Source が custom 以外のときは From〜To のイベントを Source から取り出し、custom のときは Events をそのまま使う。
+type EventStudyRequest struct {
+	Source         string
+	Benchmark      string
+	From           time.Time
+	To             time.Time
+	Method         string // analyze_signal のみ：手法の完全一致。空文字なら全手法
+	Events         []*EventStudyEvent
+	PreDays        int // イベント窓の開始（イベント日の何営業日前から）
+	PostDays       int // イベント窓の終了（イベント日の何営業日後まで）
+	EstimationDays int // 市場モデルの推定期間（営業日）
+}
+
+// EventStudyDay イベント日からの相対営業日ごとの平均異常リターン（AAR）と累積平均異常リターン（CAAR）。
+// t 値はイベント間のばらつき（クロスセクション）から求める。
+type EventStudyDay struct {
+	Offset    int             `json:"offset"`
+	AAR       decimal.Decimal `json:"aar"`
+	AARTStat  decimal.Decimal `json:"aarTStat"`
+	CAAR      decimal.Decimal `json:"caar"`
+	CAARTStat decimal.Decimal `json:"caarTStat"`
+}
+
+// EventStudyEventResult 1イベントの市場モデルと累積異常リターン（CAR）。
+type EventStudyEventResult struct {
+	TickerSymbol string          `json:"tickerSymbol"`
+	Date         time.Time       `json:"date"`      // 指定されたイベント日
+	EventDate    time.Time       `json:"eventDate"` // イベント日以降の最初の営業日（offset 0）
+	Label        string          `json:"label,omitempty"`
+	Alpha        decimal.Decimal `json:"alpha"`
+	Beta         decimal.Decimal `json:"beta"`
+	CAR          decimal.Decimal `json:"car"`
+	CARTStat     decimal.Decimal `json:"carTStat"` // 推定期間の残差の標準偏差から求める t 値
+}
+
+// EventStudyResult イベントスタディの結果。
+type EventStudyResult struct {
+	Benchmark      string                   `json:"benchmark"`
+	PreDays        int                      `json:"preDays"`
+	PostDays       int                      `json:"postDays"`
+	EstimationDays int                      `json:"estimationDays"`
+	EventCount     int                      `json:"eventCount"`
+	EvaluatedCount int                      `json:"evaluatedCount"`
+	SkippedCount   int                      `json:"skippedCount"` // 推定期間・イベント窓の日足が足りないイベント数
+	Truncated      bool                     `json:"truncated"`    // イベントが上限を超え、古いものを切り捨てた
+	Days           []*EventStudyDay         `json:"days"`
+	Events         []*EventStudyEventResult `json:"events"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MarketBreadthMarketAll 主要市場（プライム・スタンダード・グロース）全体を表す market。
+const MarketBreadthMarketAll = "all"
+
+// MarketBreadthMarkets 騰落指標を算出する market（主要市場全体と市場コード別）。
+var MarketBreadthMarkets = []string{MarketBreadthMarketAll, "111", "112", "113"}
+
+// MarketBreadth 1営業日・1市場の騰落指標。騰落・新高値/新安値・移動平均線は調整後終値で判定する。
+type MarketBreadth struct {
+	Date      time.Time `json:"date"`
+	Market    string    `json:"market"` // all / 市場コード
+	Advancers int       `json:"advancers"`
+	Decliners int       `json:"decliners"`
+	Unchanged int       `json:"unchanged"`
+	// ADLine 騰落ライン（値上がり − 値下がりの累計。算出を始めた日が起点）
+	ADLine int64 `json:"adLine"`
+	// ADRatio25 騰落レシオ（25日間の値上がり合計 / 値下がり合計 × 100）。25日分に満たない・値下がり 0 なら null
+	ADRatio25 *decimal.Decimal `json:"adRatio25"`
+	// NewHighs / NewLows 終値が過去52週（250営業日）の最高値/最安値を更新した銘柄数
+	NewHighs int `json:"newHighs"`
+	NewLows  int `json:"newLows"`
+	// AboveMA25Pct / AboveMA75Pct / AboveMA200Pct 終値が移動平均線を上回る銘柄の割合(%)。対象銘柄が無ければ null
+	AboveMA25Pct  *decimal.Decimal `json:"aboveMa25Pct"`
+	AboveMA75Pct  *decimal.Decimal `json:"aboveMa75Pct"`
+	AboveMA200Pct *decimal.Decimal `json:"aboveMa200Pct"`
+	// McClellanOscillator 純騰落数（値上がり − 値下がり）の19日EMA − 39日EMA
+	McClellanOscillator decimal.Decimal `json:"mcclellanOscillator"`
+	// AdvanceEMA19 / AdvanceEMA39 翌営業日以降のマクレラン・オシレーターを継続計算するための EMA
+	AdvanceEMA19 decimal.Decimal `json:"-"`
+	AdvanceEMA39 decimal.Decimal `json:"-"`
+}
+
+// MarketBreadthFilter 騰落指標の検索条件
+type MarketBreadthFilter struct {
+	Market string
+	From   *time.Time // nil なら制限なし
+	To     *time.Time // nil なら制限なし
+}
+
+// MarketBreadthSeries GET /market-breadth のレスポンス
+type MarketBreadthSeries struct {
+	Market string           `json:"market"`
+	From   time.Time        `json:"from"`
+	To     time.Time        `json:"to"`
+	Items  []*MarketBreadth `json:"items"` // date 昇順
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// 市場局面。高ボラティリティはトレンド判定より優先する。
+const (
+	MarketRegimeTrendUp        = "trend_up"
+	MarketRegimeTrendDown      = "trend_down"
+	MarketRegimeRange          = "range"
+	MarketRegimeHighVolatility = "high_volatility"
+	// MarketRegimeUnknown 局面が未算出の日（成績の局面別集計でのみ使う）。
+	MarketRegimeUnknown = "unknown"
+)
+
+// MarketRegimes 判定される市場局面（表示・集計順）。
+var MarketRegimes = []string{
+	MarketRegimeTrendUp,
+	MarketRegimeRange,
+	MarketRegimeTrendDown,
+	MarketRegimeHighVolatility,
+}
+
+// MarketRegime 1営業日の市場局面と判定に使った値。
+type MarketRegime struct {
+	Date   time.Time `json:"date"`
+	Regime string    `json:"regime"`
+	// TopixClose / TopixMA25 / TopixMA75 TOPIX の終値と25日・75日移動平均
+	TopixClose decimal.Decimal `json:"topixClose"`
+	TopixMA25  decimal.Decimal `json:"topixMa25"`
+	TopixMA75  decimal.Decimal `json:"topixMa75"`
+	// TopixMA75Slope 75日移動平均の20営業日前比
+	TopixMA75Slope decimal.Decimal `json:"topixMa75Slope"`
+	// NikkeiAboveMA75 日経平均の終値が75日移動平均を上回るか（同日の日経平均が無ければ null）
+	NikkeiAboveMA75 *bool `json:"nikkeiAboveMa75"`
+	// ATRRatio TOPIX の ATR(14) / 終値。ATRRatioBaseline は直近250営業日の平均
+	ATRRatio         decimal.Decimal `json:"atrRatio"`
+	ATRRatioBaseline decimal.Decimal `json:"atrRatioBaseline"`
+	// BreadthAboveMA75Pct 主要市場全体で75日移動平均を上回る銘柄の割合(%)（騰落指標が無い日は null）
+	BreadthAboveMA75Pct *decimal.Decimal `json:"breadthAboveMa75Pct"`
+}
+
+// MarketRegimeFilter 市場局面の検索条件
+type MarketRegimeFilter struct {
+	From *time.Time // nil なら制限なし
+	To   *time.Time // nil なら制限なし
+}
+
+// MarketRegimeSeries GET /market-regime のレスポンス
+type MarketRegimeSeries struct {
+	From  time.Time       `json:"from"`
+	To    time.Time       `json:"to"`
+	Items []*MarketRegime `json:"items"` // date 昇順
+}
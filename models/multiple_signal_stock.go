@@ -27,3 +27,45 @@ type PaginatedMultipleSignalStocks struct {
 	NextCursor *string
 	Limit      int
 }
+
+// WeightedSignalStockFilter GET /multiple-signal-stocks?mode=weighted のクエリパラメータ
+type WeightedSignalStockFilter struct {
+	Date  *time.Time // 基準日。nil なら最新営業日
+	Limit int        // 0 以下なら全件
+}
+
+// SignalConfluenceContribution 合流スコアに寄与した1シグナル（手法 × 売買方向ごとに直近の1件）
+type SignalConfluenceContribution struct {
+	Method         string          `json:"method"`
+	Action         string          `json:"action"`
+	Date           time.Time       `json:"date"`
+	AgeDays        int             `json:"ageDays"`        // 基準日から何営業日前のシグナルか（当日=0）
+	HitRate        decimal.Decimal `json:"hitRate"`        // 手法の的中率（件数の少ない手法は 0.5 に縮めた値）
+	EvaluatedCount int             `json:"evaluatedCount"` // 的中率の算出に使った評価済みシグナル数
+	Decay          decimal.Decimal `json:"decay"`          // 経過営業日による減衰（当日=1）
+	Contribution   decimal.Decimal `json:"contribution"`   // max(HitRate - 0.5, 0) × Decay（5割以下の手法は 0）
+}
+
+// WeightedSignalStock 手法の的中率で重み付けした合流スコア付きの銘柄
+type WeightedSignalStock struct {
+	StockBrandID  string                          `json:"stockBrandId"`
+	Name          string                          `json:"name"`
+	TickerSymbol  string                          `json:"tickerSymbol"`
+	Date          time.Time                       `json:"date"`          // 直近のシグナル日
+	Action        string                          `json:"action"`        // 優勢な方向（Buy / Sell）。拮抗していれば空
+	Score         decimal.Decimal                 `json:"score"`         // |BuyScore - SellScore|
+	BuyScore      decimal.Decimal                 `json:"buyScore"`      // Buy シグナルの寄与の合計
+	SellScore     decimal.Decimal                 `json:"sellScore"`     // Sell シグナルの寄与の合計
+	Conflict      bool                            `json:"conflict"`      // Buy と Sell の両方が出ている
+	SignalCount   int                             `json:"signalCount"`   // 寄与した手法 × 方向の数
+	Contributions []*SignalConfluenceContribution `json:"contributions"` // 寄与の大きい順
+}
+
+// WeightedSignalStocks 合流スコアの一覧（Score 降順）
+type WeightedSignalStocks struct {
+	Date         time.Time              `json:"date"`         // 基準日
+	LookbackDays int                    `json:"lookbackDays"` // 合流に数えた営業日数（基準日含む）
+	HalfLifeDays decimal.Decimal        `json:"halfLifeDays"` // 減衰の半減期（営業日）
+	Horizon      int                    `json:"horizon"`      // 的中率を測った保有営業日数
+	Stocks       []*WeightedSignalStock `json:"stocks"`
+}
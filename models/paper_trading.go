@@ -0,0 +1,183 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PaperAccountDefaultName 口座名を省略したときに使う模擬売買口座。
+const PaperAccountDefaultName = "default"
+
+// 模擬売買ポジションの状態
+const (
+	PaperPositionStatusOpen   = "open"
+	PaperPositionStatusClosed = "closed"
+)
+
+// 模擬売買ポジションの手仕舞い理由
+const (
+	// PaperExitReasonHoldingPeriod 保有営業日数に達したので最終日の終値で売却
+	PaperExitReasonHoldingPeriod = "holding_period"
+	// PaperExitReasonTakeProfit 利確ラインに達した
+	PaperExitReasonTakeProfit = "take_profit"
+	// PaperExitReasonStopLoss 損切りラインに達した
+	PaperExitReasonStopLoss = "stop_loss"
+	// PaperExitReasonCorporateAction 保有中に株式分割・併合が適用されたため前日の評価額で手仕舞い
+	PaperExitReasonCorporateAction = "corporate_action"
+)
+
+// 模擬約定の売買区分
+const (
+	PaperFillSideBuy  = "buy"
+	PaperFillSideSell = "sell"
+)
+
+// PaperAccount 日次推奨に追随する模擬売買口座と、その売買ルール。
+// ルールは口座作成時に固定し、変えたい場合は別名の口座を作る（途中でルールが変わった成績を混ぜないため）。
+type PaperAccount struct {
+	Name string `json:"name"`
+	// ScoreVersion 追随する推奨のスコア設定バージョン
+	ScoreVersion string `json:"scoreVersion"`
+	// TopN 1日に買う推奨の上位件数（pick_rank <= TopN）
+	TopN int `json:"topN"`
+	// BudgetPerPick 1銘柄あたりの予算（円）。100株単位で予算内に収まる最大株数を買う
+	BudgetPerPick decimal.Decimal `json:"budgetPerPick"`
+	// HoldingDays 最長保有営業日数（買付日を1日目とし、N日目の終値で売却）
+	HoldingDays int `json:"holdingDays"`
+	// TakeProfitPct / StopLossPct 取得単価からの利確・損切り幅（0.10 = 10%）。nil なら使わない
+	TakeProfitPct *decimal.Decimal `json:"takeProfitPct"`
+	StopLossPct   *decimal.Decimal `json:"stopLossPct"`
+	// InitialCash 初期資金（円）
+	InitialCash decimal.Decimal `json:"initialCash"`
+	// StartDate この日以降の営業日から売買する
+	StartDate time.Time `json:"startDate"`
+}
+
+// PaperPosition 1推奨ぶんの模擬ポジション。
+type PaperPosition struct {
+	ID           uint64    `json:"id"`
+	Account      string    `json:"account"`
+	PickDate     time.Time `json:"pickDate"`
+	StockBrandID string    `json:"stockBrandId"`
+	TickerSymbol string    `json:"tickerSymbol"`
+	PickRank     int       `json:"pickRank"`
+	Status       string    `json:"status"`
+	// EntryDate / EntryPrice 買付日（推奨日の翌営業日）と始値
+	EntryDate  time.Time       `json:"entryDate"`
+	EntryPrice decimal.Decimal `json:"entryPrice"`
+	Quantity   int             `json:"quantity"`
+	// HeldDays 買付日を1日目とした保有営業日数
+	HeldDays int `json:"heldDays"`
+	// MarkDate / MarkPrice 直近の値洗いに使った日と終値（売却済みなら売却日・売却単価）
+	MarkDate   time.Time        `json:"markDate"`
+	MarkPrice  decimal.Decimal  `json:"markPrice"`
+	ExitDate   *time.Time       `json:"exitDate"`
+	ExitPrice  *decimal.Decimal `json:"exitPrice"`
+	ExitReason *string          `json:"exitReason"`
+}
+
+// Open 未決済か。
+func (p *PaperPosition) Open() bool {
+	return p.Status == PaperPositionStatusOpen
+}
+
+// CostBasis 取得金額（円）。
+func (p *PaperPosition) CostBasis() decimal.Decimal {
+	return p.EntryPrice.Mul(decimal.NewFromInt(int64(p.Quantity)))
+}
+
+// MarketValue 直近の値洗い単価での評価額（円）。
+func (p *PaperPosition) MarketValue() decimal.Decimal {
+	return p.MarkPrice.Mul(decimal.NewFromInt(int64(p.Quantity)))
+}
+
+// PnL 評価損益（売却済みなら確定損益。円）。
+func (p *PaperPosition) PnL() decimal.Decimal {
+	return p.MarketValue().Sub(p.CostBasis())
+}
+
+// PaperFill 模擬約定。
+type PaperFill struct {
+	ID           uint64          `json:"id"`
+	Account      string          `json:"account"`
+	PositionID   uint64          `json:"positionId"`
+	FillDate     time.Time       `json:"fillDate"`
+	TickerSymbol string          `json:"tickerSymbol"`
+	Side         string          `json:"side"`
+	Quantity     int             `json:"quantity"`
+	Price        decimal.Decimal `json:"price"`
+	Amount       decimal.Decimal `json:"amount"`
+	// Reason 売却なら手仕舞い理由（PaperExitReason*）。買付は空
+	Reason string `json:"reason"`
+}
+
+// PaperNav 1営業日の引け時点の口座評価。
+type PaperNav struct {
+	Account       string          `json:"account"`
+	Date          time.Time       `json:"date"`
+	Cash          decimal.Decimal `json:"cash"`
+	MarketValue   decimal.Decimal `json:"marketValue"`
+	Nav           decimal.Decimal `json:"nav"`
+	OpenPositions int             `json:"openPositions"`
+	// RealizedPnL 開始からの確定損益の累計（円）
+	RealizedPnL decimal.Decimal `json:"realizedPnl"`
+}
+
+// PaperPositionFilter 模擬ポジションの検索条件
+type PaperPositionFilter struct {
+	Account  string
+	Status   *string    // nil なら全状態
+	ExitFrom *time.Time // 売却日の下限（nil なら制限なし）
+	ExitTo   *time.Time // 売却日の上限（nil なら制限なし）
+}
+
+// PaperPortfolioSummary 模擬口座の成績サマリ。
+type PaperPortfolioSummary struct {
+	AsOf          *string         `json:"asOf"` // 直近の評価日。未稼働なら null
+	Nav           decimal.Decimal `json:"nav"`
+	Cash          decimal.Decimal `json:"cash"`
+	MarketValue   decimal.Decimal `json:"marketValue"`
+	TotalReturn   decimal.Decimal `json:"totalReturn"` // 初期資金比
+	RealizedPnL   decimal.Decimal `json:"realizedPnl"`
+	UnrealizedPnL decimal.Decimal `json:"unrealizedPnl"`
+	MaxDrawdown   decimal.Decimal `json:"maxDrawdown"` // 期間内の NAV の最大下落率（正の値）
+	OpenPositions int             `json:"openPositions"`
+	ClosedTrades  int             `json:"closedTrades"` // 期間内に売却した件数
+	WinRate       decimal.Decimal `json:"winRate"`      // 期間内の売却のうち損益 > 0 の割合
+	AvgReturn     decimal.Decimal `json:"avgReturn"`    // 期間内の売却の平均リターン（取得金額比）
+}
+
+// PaperPositionItem 画面表示用の模擬ポジション。
+type PaperPositionItem struct {
+	*PaperPosition
+	Name       string          `json:"name"`
+	ProfitLoss decimal.Decimal `json:"pnl"`
+	Return     decimal.Decimal `json:"return"` // 取得金額比
+}
+
+// PaperPortfolio GET /paper-portfolio のレスポンス
+type PaperPortfolio struct {
+	Account         *PaperAccount          `json:"account"`
+	Summary         *PaperPortfolioSummary `json:"summary"`
+	Navs            []*PaperNav            `json:"navs"`            // date 昇順
+	OpenPositions   []*PaperPositionItem   `json:"openPositions"`   // 買付日・推奨順位の昇順
+	ClosedPositions []*PaperPositionItem   `json:"closedPositions"` // 期間内に売却したもの。売却日の新しい順
+	Fills           []*PaperFill           `json:"fills"`           // 期間内の約定。約定日の新しい順
+}
+
+// PaperPortfolioWeeklyReport 週次 Slack 通知用の集計。
+type PaperPortfolioWeeklyReport struct {
+	Account       string
+	From          time.Time // 週初の評価日（前週末の NAV が無ければ口座開始後の最初の評価日）
+	To            time.Time
+	StartNav      decimal.Decimal
+	EndNav        decimal.Decimal
+	WeekPnL       decimal.Decimal
+	WeekReturn    decimal.Decimal
+	TotalReturn   decimal.Decimal // 初期資金比
+	Bought        int
+	Closed        []*PaperPositionItem // 週内に売却したもの（損益の大きい順）
+	OpenPositions int
+	UnrealizedPnL decimal.Decimal
+}
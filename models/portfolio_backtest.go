@@ -0,0 +1,86 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// PortfolioUniverseMainMarkets 主要市場（プライム・スタンダード・グロース）全銘柄。
+const PortfolioUniverseMainMarkets = "main_markets"
+
+// PortfolioUniverseSector 主要市場のうち指定した33業種コードの銘柄。
+const PortfolioUniverseSector = "sector"
+
+// PortfolioUniverseWatchlist 明示指定した銘柄コードのリスト。
+const PortfolioUniverseWatchlist = "watchlist"
+
+// 同日にスロット数を超えるシグナルが点灯した場合の優先順位ルール。
+const (
+	// PortfolioRankVolumeRatio 当日出来高 / 直近20営業日平均出来高 の降順（デフォルト）。
+	PortfolioRankVolumeRatio = "volume_ratio"
+	// PortfolioRankTradingValue 直近20営業日平均売買代金の降順（流動性優先）。
+	PortfolioRankTradingValue = "trading_value"
+	// PortfolioRankMomentum 直近20営業日リターンの降順。
+	PortfolioRankMomentum = "momentum"
+)
+
+// PortfolioLotSize 売買単位（単元株数）。
+const PortfolioLotSize = 100
+
+// PortfolioUniverse ポートフォリオバックテストの対象銘柄群。
+type PortfolioUniverse struct {
+	Kind         string   `json:"kind"`                   // main_markets / sector / watchlist
+	Sector33Code string   `json:"sector33Code,omitempty"` // Kind=sector のとき必須
+	Symbols      []string `json:"symbols,omitempty"`      // Kind=watchlist のとき必須
+}
+
+// PortfolioBacktestParams ポートフォリオバックテストの条件。
+type PortfolioBacktestParams struct {
+	Strategy       string            `json:"strategy"`
+	Universe       PortfolioUniverse `json:"universe"`
+	InitialCapital decimal.Decimal   `json:"initialCapital"` // 初期資金（円）
+	MaxPositions   int               `json:"maxPositions"`   // 同時保有上限
+	RankBy         string            `json:"rankBy"`         // シグナル過多時の優先順位ルール
+	Exit           BacktestParams    `json:"exit"`           // 利確/損切り/最大保有/コスト/イグジットモード
+}
+
+// PortfolioEquityPoint ポートフォリオの日次評価額（円）。
+type PortfolioEquityPoint struct {
+	Date          string          `json:"date"`
+	Equity        decimal.Decimal `json:"equity"`        // 現金 + 保有時価
+	Cash          decimal.Decimal `json:"cash"`          // 現金残高
+	PositionValue decimal.Decimal `json:"positionValue"` // 保有時価（終値評価）
+	Exposure      decimal.Decimal `json:"exposure"`      // 保有時価 / 評価額
+	OpenPositions int             `json:"openPositions"`
+}
+
+// PortfolioTrade ポートフォリオ内の1回の売買。
+type PortfolioTrade struct {
+	TickerSymbol string          `json:"tickerSymbol"`
+	EntryDate    string          `json:"entryDate"`
+	ExitDate     string          `json:"exitDate"`
+	Shares       int64           `json:"shares"`
+	EntryPrice   decimal.Decimal `json:"entryPrice"` // コスト込み実効取得単価
+	ExitPrice    decimal.Decimal `json:"exitPrice"`  // 手仕舞い日の終値
+	ProfitLoss   decimal.Decimal `json:"profitLoss"` // 実現損益（円、コスト込み）
+	Return       decimal.Decimal `json:"return"`
+	HoldDays     int             `json:"holdDays"`
+	// Reason: take_profit / stop_loss / max_hold / signal_exit / end_of_data
+	Reason string `json:"reason"`
+}
+
+// PortfolioBacktestResult ポートフォリオバックテストの結果。
+type PortfolioBacktestResult struct {
+	Params         PortfolioBacktestParams `json:"params"`
+	Label          string                  `json:"label"`
+	From           string                  `json:"from"`
+	To             string                  `json:"to"`
+	UniverseSize   int                     `json:"universeSize"` // 検証対象になった銘柄数
+	FinalEquity    decimal.Decimal         `json:"finalEquity"`  // 最終評価額（円）
+	TotalReturn    decimal.Decimal         `json:"totalReturn"`  // 最終評価額 / 初期資金 - 1
+	MaxDrawdown    decimal.Decimal         `json:"maxDrawdown"`  // 評価額の最大下落率（負値）
+	AvgExposure    decimal.Decimal         `json:"avgExposure"`  // 日次エクスポージャーの平均
+	Turnover       decimal.Decimal         `json:"turnover"`     // (買付代金+売却代金)/2 ÷ 平均評価額
+	Trades         int                     `json:"trades"`
+	WinRate        decimal.Decimal         `json:"winRate"`
+	SkippedSignals int                     `json:"skippedSignals"` // スロット不足・資金不足で見送ったシグナル数
+	Equity         []PortfolioEquityPoint  `json:"equity"`
+	TradeList      []PortfolioTrade        `json:"tradeList"`
+}
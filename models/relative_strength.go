@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RelativeStrengthRating 1銘柄・1日の RS レーティング（IBD 方式）。
+// 3/6/9/12か月の TOPIX 比相対リターンを 40/20/20/20% で加重した Score を、同日のユニバース内でパーセンタイル化した 1〜99 の値。
+type RelativeStrengthRating struct {
+	TickerSymbol string           `json:"tickerSymbol"`
+	Name         string           `json:"name"`
+	Date         time.Time        `json:"date"`
+	Rating       int              `json:"rating"` // 1〜99。99 が最も強い
+	Score        decimal.Decimal  `json:"score"`  // 加重相対リターン
+	Relative3M   *decimal.Decimal `json:"relative3m"`
+	Relative6M   *decimal.Decimal `json:"relative6m"`  // 履歴不足なら null
+	Relative9M   *decimal.Decimal `json:"relative9m"`  // 履歴不足なら null
+	Relative12M  *decimal.Decimal `json:"relative12m"` // 履歴不足なら null
+}
+
+// RelativeStrengthFilter RS レーティングの検索条件
+type RelativeStrengthFilter struct {
+	Date      time.Time
+	MinRating int      // 0 なら絞り込まない
+	Symbols   []string // 空なら全銘柄
+}
+
+// RelativeStrengthRatings GET /relative-strength のレスポンス
+type RelativeStrengthRatings struct {
+	Date  *time.Time                `json:"date"` // 算出済みの日が無ければ null
+	Items []*RelativeStrengthRating `json:"items"`
+}
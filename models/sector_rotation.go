@@ -0,0 +1,35 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// RRG（相対回転グラフ）の象限。RS-Ratio・RS-Momentum とも 100 が基準。
+const (
+	SectorQuadrantLeading   = "leading"   // RS-Ratio >= 100 かつ RS-Momentum >= 100
+	SectorQuadrantWeakening = "weakening" // RS-Ratio >= 100 かつ RS-Momentum < 100
+	SectorQuadrantLagging   = "lagging"   // RS-Ratio < 100 かつ RS-Momentum < 100
+	SectorQuadrantImproving = "improving" // RS-Ratio < 100 かつ RS-Momentum >= 100
+)
+
+// SectorRotationPoint RRG 上の週次の1点。Date はその週の最終営業日。
+type SectorRotationPoint struct {
+	Date       string          `json:"date"`
+	RSRatio    decimal.Decimal `json:"rsRatio"`
+	RSMomentum decimal.Decimal `json:"rsMomentum"`
+}
+
+// SectorRotationItem 1業種の RRG の軌跡（tail は古い順、末尾が最新週）と最新週の象限。
+type SectorRotationItem struct {
+	SectorCode string                 `json:"sectorCode"`
+	SectorName string                 `json:"sectorName"`
+	Quadrant   string                 `json:"quadrant"`
+	Tail       []*SectorRotationPoint `json:"tail"`
+}
+
+// SectorRotation GET /sector-rotation のレスポンス全体
+type SectorRotation struct {
+	Level   string                `json:"level"`
+	Weeks   int                   `json:"weeks"`
+	Window  int                   `json:"window"`
+	To      string                `json:"to"`
+	Sectors []*SectorRotationItem `json:"sectors"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SignalIngestMaxBatch 1回の取り込みで受け付けるシグナルの上限件数
+const SignalIngestMaxBatch = 1000
+
+// SignalIngestInput 外部のプロデューサー（ML コンテナ等）から取り込むシグナル1件。
+// (Method, TickerSymbol, Date, Action) が同じ行は1件とみなし、再送されたら上書きする。
+type SignalIngestInput struct {
+	TickerSymbol string
+	Date         time.Time // シグナル日（analyze_stock_brand_price_history.created_at）
+	Method       string    // AnalyzeStockBrandPriceHistoryMethods のいずれか
+	Action       string    // Buy / Sell
+	TradePrice   decimal.Decimal
+	Memo         *string
+	Score        *decimal.Decimal
+	SignalRank   *int // 手法内ランク（1始まり）
+}
+
+// SignalIngestResult 取り込み結果
+type SignalIngestResult struct {
+	Received int `json:"received"`
+	Inserted int `json:"inserted"`
+	Updated  int `json:"updated"`
+}
+
+// SignalIngestError 取り込むシグナルの内容が不正（1件でもあればバッチ全体を取り込まない）。
+// Index は入力の何件目か（0始まり）。件数など特定の1件によらない誤りは -1。
+type SignalIngestError struct {
+	Index   int
+	Message string
+}
+
+func (e *SignalIngestError) Error() string {
+	if e.Index < 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("histories[%d]: %s", e.Index, e.Message)
+}
@@ -12,6 +12,10 @@ type SignalPerformanceFilter struct {
 	To     time.Time
 	Method string // 任意：完全一致。空文字なら全手法
 	Action string // 任意："Buy" / "Sell"。空文字なら両方
+	// Benchmark 任意：超過リターンの基準 "topix" / "nikkei"。空文字なら topix
+	Benchmark string
+	// Seed 任意：ブートストラップとランダムエントリーの乱数シード。0 なら現在時刻から採番する
+	Seed int64
 }
 
 // HorizonStats 1手法 × 1 horizon の統計
@@ -27,10 +31,31 @@ type HorizonStats struct {
 
 // SignalPerformanceSummary 1手法の集計サマリ
 type SignalPerformanceSummary struct {
-	Method       string                `json:"method"`
-	SignalCount  int                   `json:"signalCount"`
-	SkippedCount int                   `json:"skippedCount"`
-	Stats        map[int]*HorizonStats `json:"stats"` // key: 5 / 10 / 20
+	Method       string                     `json:"method"`
+	SignalCount  int                        `json:"signalCount"`
+	SkippedCount int                        `json:"skippedCount"`
+	Stats        map[int]*HorizonStats      `json:"stats"` // key: 5 / 10 / 20
+	Tests        map[int]*SignalHorizonTest `json:"tests"` // key: 5 / 10 / 20
+}
+
+// SignalHorizonTest 1手法 × 1 horizon の超過リターンと統計的検定
+// 件数が足りず計算できない項目は null。
+type SignalHorizonTest struct {
+	ExcessCount     int              `json:"excessCount"`     // ベンチマークの日足が揃い超過リターンを出せた件数
+	AvgExcessReturn *decimal.Decimal `json:"avgExcessReturn"` // ベンチマークに対する平均超過リターン
+	ExcessWinRate   *decimal.Decimal `json:"excessWinRate"`   // 超過リターンがプラスの割合
+
+	CILow       *decimal.Decimal `json:"ciLow"`       // 平均リターンのブートストラップ95%信頼区間（下限）
+	CIHigh      *decimal.Decimal `json:"ciHigh"`      // 同（上限）
+	PValue      *decimal.Decimal `json:"pValue"`      // 平均リターン=0 を帰無仮説とする両側ブートストラップ p 値
+	QValue      *decimal.Decimal `json:"qValue"`      // 同じ horizon の手法間で Benjamini–Hochberg 補正した p 値
+	Significant bool             `json:"significant"` // qValue <= 0.05
+
+	BaselineCount     int              `json:"baselineCount"`     // ランダムエントリーと比べられた件数
+	BaselineAvgReturn *decimal.Decimal `json:"baselineAvgReturn"` // 同じ日に主要市場の全銘柄からランダムに選んだ場合の平均リターン（期待値）
+	BaselinePValue    *decimal.Decimal `json:"baselinePValue"`    // ランダムエントリーの平均が手法の平均以上になった割合（片側）
+	BaselineQValue    *decimal.Decimal `json:"baselineQValue"`    // 同じ horizon の手法間で Benjamini–Hochberg 補正した baselinePValue
+	BeatsBaseline     bool             `json:"beatsBaseline"`     // baselineQValue <= 0.05
 }
 
 // EvaluatedSignal 1シグナルの明細
@@ -45,6 +70,8 @@ type EvaluatedSignal struct {
 	SignalRank   *int                     `json:"signalRank"`
 	Memo         *string                  `json:"memo"`
 	Returns      map[int]*decimal.Decimal `json:"returns"` // key: 5/10/20, nil=未到来
+	// ExcessReturns ベンチマークに対する超過リターン。key: 5/10/20、nil=未到来またはベンチマークの日足なし
+	ExcessReturns map[int]*decimal.Decimal `json:"excessReturns"`
 }
 
 // BandSummary rank帯・score四分位など、シグナルの帯別集計
@@ -56,11 +83,15 @@ type BandSummary struct {
 
 // SignalPerformance API レスポンス全体
 type SignalPerformance struct {
-	From           time.Time                  `json:"from"`
-	To             time.Time                  `json:"to"`
-	Horizons       []int                      `json:"horizons"`
+	From           time.Time                   `json:"from"`
+	To             time.Time                   `json:"to"`
+	Horizons       []int                       `json:"horizons"`
+	Benchmark      string                      `json:"benchmark"`  // 超過リターンの基準（topix / nikkei）
+	Iterations     int                         `json:"iterations"` // ブートストラップ・ランダムエントリーの試行回数
+	Seed           int64                       `json:"seed"`       // 使った乱数シード（同じ値を渡せば再現できる）
 	Summaries      []*SignalPerformanceSummary `json:"summaries"`
-	Signals        []*EvaluatedSignal         `json:"signals"`        // method 指定時のみ、非 nil
-	RankBands      []*BandSummary             `json:"rankBands"`      // method 指定時のみ非nil
-	ScoreQuartiles []*BandSummary             `json:"scoreQuartiles"` // method 指定時のみ非nil
+	Signals        []*EvaluatedSignal          `json:"signals"`              // method 指定時のみ、非 nil
+	RankBands      []*BandSummary              `json:"rankBands"`            // method 指定時のみ非nil
+	ScoreQuartiles []*BandSummary              `json:"scoreQuartiles"`       // method 指定時のみ非nil
+	EventStudy     *EventStudyResult           `json:"eventStudy,omitempty"` // method 指定時のみ。TOPIX に対する市場モデルの異常リターン
 }
@@ -23,6 +23,8 @@ type StockBrand struct {
 	Sector17CodeName string    `json:"sector17CodeName"`
 	CreatedAt        time.Time `json:"createdAt"`
 	UpdatedAt        time.Time `json:"updatedAt"`
+	// RelativeStrengthRating 最新の算出日の RS レーティング（1〜99）。/stock-brands でのみ付与し、未算出なら省略。
+	RelativeStrengthRating *int `json:"relativeStrengthRating,omitempty"`
 }
 
 // PaginatedStockBrands ページネーション付き銘柄一覧
@@ -0,0 +1,31 @@
+package models
+
+// 戦略パラメータの値の型。
+const (
+	StrategyParamTypeInt     = "int"
+	StrategyParamTypeDecimal = "decimal"
+)
+
+// StrategyParamSpec 戦略が公開するチューニング可能なパラメータの定義。
+type StrategyParamSpec struct {
+	Name         string   `json:"name"`
+	Label        string   `json:"label"`
+	Type         string   `json:"type"`         // int / decimal
+	Default      string   `json:"default"`      // 既定値（文字列表現）
+	SearchValues []string `json:"searchValues"` // パラメータ最適化の既定探索値
+}
+
+// StrategyInfo 戦略のメタデータ（GET /strategies の要素）。
+type StrategyInfo struct {
+	ID         string              `json:"id"`
+	Label      string              `json:"label"`
+	Side       string              `json:"side"`       // long / short
+	WarmupBars int                 `json:"warmupBars"` // 最初のシグナル判定に必要な日足本数
+	DailyPick  bool                `json:"dailyPick"`  // 買い候補スクリーニングの点灯数カウント対象か
+	Params     []StrategyParamSpec `json:"params"`
+}
+
+// StrategyList GET /strategies のレスポンス。
+type StrategyList struct {
+	Strategies []StrategyInfo `json:"strategies"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// パラメータ探索の方式。
+const (
+	OptimizationMethodGrid   = "grid"   // 探索空間の全組み合わせ
+	OptimizationMethodRandom = "random" // 探索空間から Samples 件を非復元抽出
+)
+
+// 最適化ジョブの状態。
+const (
+	OptimizationStatusRunning   = "running"
+	OptimizationStatusCompleted = "completed"
+	OptimizationStatusFailed    = "failed"
+)
+
+// StrategyOptimizationParams パラメータ最適化（ウォークフォワード）の条件。
+type StrategyOptimizationParams struct {
+	Strategy          string          `json:"strategy"`
+	Method            string          `json:"method"`            // grid / random
+	Samples           int             `json:"samples"`           // method=random の抽出数
+	Seed              int64           `json:"seed"`              // method=random の乱数シード（再現用）
+	Years             int             `json:"years"`             // 直近N年を検証対象とする
+	InSampleMonths    int             `json:"inSampleMonths"`    // 1窓のインサンプル期間（月）
+	OutOfSampleMonths int             `json:"outOfSampleMonths"` // 1窓のアウトオブサンプル期間（月）。窓はこの幅ずつ前進する
+	CommissionRate    decimal.Decimal `json:"commissionRate"`
+	SlippageRate      decimal.Decimal `json:"slippageRate"`
+}
+
+// OptimizationParamSet 1組の候補パラメータ。
+type OptimizationParamSet struct {
+	TakeProfit  decimal.Decimal `json:"takeProfit"`
+	StopLoss    decimal.Decimal `json:"stopLoss"`
+	MaxHoldDays int             `json:"maxHoldDays"`
+	// Params 戦略のシグナルパラメータ（名前 → 値。GET /strategies の params）。探索するパラメータを持たない戦略では省略
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// WalkForwardWindowResult 1窓のインサンプル最良パラメータとアウトオブサンプル成績。
+type WalkForwardWindowResult struct {
+	InSampleFrom         string               `json:"inSampleFrom"`
+	InSampleTo           string               `json:"inSampleTo"`
+	OutOfSampleFrom      string               `json:"outOfSampleFrom"`
+	OutOfSampleTo        string               `json:"outOfSampleTo"`
+	Best                 OptimizationParamSet `json:"best"`
+	InSampleAvgReturn    decimal.Decimal      `json:"inSampleAvgReturn"`    // 銘柄平均トータルリターン
+	OutOfSampleAvgReturn decimal.Decimal      `json:"outOfSampleAvgReturn"` // 同パラメータでの OOS 銘柄平均
+	InSampleTrades       int                  `json:"inSampleTrades"`
+	OutOfSampleTrades    int                  `json:"outOfSampleTrades"`
+	StockCount           int                  `json:"stockCount"` // 窓内で検証できた銘柄数
+}
+
+// ParameterStability 窓ごとに選ばれたパラメータ値のばらつき。
+type ParameterStability struct {
+	Param                  string          `json:"param"`
+	Mean                   decimal.Decimal `json:"mean"`
+	StdDev                 decimal.Decimal `json:"stdDev"`
+	CoefficientOfVariation decimal.Decimal `json:"coefficientOfVariation"` // StdDev / |Mean|。小さいほど安定
+	Min                    decimal.Decimal `json:"min"`
+	Max                    decimal.Decimal `json:"max"`
+}
+
+// StrategyOptimizationResult 最適化ジョブの状態と結果（Redis に JSON で保存する）。
+type StrategyOptimizationResult struct {
+	JobID       string                     `json:"jobId"`
+	Status      string                     `json:"status"`
+	Error       string                     `json:"error,omitempty"`
+	StartedAt   string                     `json:"startedAt"`
+	CompletedAt string                     `json:"completedAt,omitempty"`
+	Params      StrategyOptimizationParams `json:"params"`
+	Label       string                     `json:"label"`
+	Candidates  int                        `json:"candidates"`
+	TotalStocks int                        `json:"totalStocks"`
+	Windows     []WalkForwardWindowResult  `json:"windows"`
+	Stability   []ParameterStability       `json:"stability"`
+	// MostFrequent 最も多くの窓で最良に選ばれたパラメータと、その選出率。
+	MostFrequent     *OptimizationParamSet `json:"mostFrequent,omitempty"`
+	MostFrequentRate decimal.Decimal       `json:"mostFrequentRate"`
+	// AvgInSampleReturn / AvgOutOfSampleReturn 全窓の平均。
+	AvgInSampleReturn    decimal.Decimal `json:"avgInSampleReturn"`
+	AvgOutOfSampleReturn decimal.Decimal `json:"avgOutOfSampleReturn"`
+	// Degradation OOS 平均 − IS 平均（負値ほど過剰最適化の疑い）。
+	Degradation decimal.Decimal `json:"degradation"`
+	// Efficiency OOS 平均 ÷ IS 平均（ウォークフォワード効率）。IS 平均が0以下なら0。
+	Efficiency decimal.Decimal `json:"efficiency"`
+}
@@ -1,12 +1,17 @@
 package models
 
-import "github.com/shopspring/decimal"
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 // StrategyRankingItem 1戦略の全銘柄横断集計。
 type StrategyRankingItem struct {
 	Strategy        string          `json:"strategy"`
 	Label           string          `json:"label"`
-	StockCount      int             `json:"stockCount"`      // 検証できた銘柄数（>=minBacktestDays）
+	StockCount      int             `json:"stockCount"`      // 検証できた銘柄数（>=全戦略の最長ウォームアップ本数）
 	TradedStocks    int             `json:"tradedStocks"`    // 取引が1回以上発生した銘柄数
 	AvgTotalReturn  decimal.Decimal `json:"avgTotalReturn"`  // 全検証銘柄平均（0取引=0%含む）
 	PositiveRate    decimal.Decimal `json:"positiveRate"`    // totalReturn>0 の銘柄割合
@@ -16,14 +21,25 @@ type StrategyRankingItem struct {
 	BestCount       int             `json:"bestCount"` // その銘柄で最高totalReturnだった戦略として選ばれた回数
 }
 
+// StrategyRankingSegmentResult 評価期間セグメント1つ分の戦略別集計。
+// 全期間でバックテストし、エントリー日がセグメント内のトレードだけで指標を再計算する。
+type StrategyRankingSegmentResult struct {
+	Segment RankingSegment        `json:"segment"`
+	Items   []StrategyRankingItem `json:"items"` // AvgTotalReturn 降順
+}
+
 // StrategyRanking 全戦略の横断集計（Redis に JSON で保存する）。
 type StrategyRanking struct {
-	Computed    bool                  `json:"computed"`    // バッチ未実行なら false
-	ComputedAt  string                `json:"computedAt"`  // RFC3339 or ""
-	Universe    string                `json:"universe"`    // "main_markets"
-	TotalStocks int                   `json:"totalStocks"` // ユニバースの銘柄数
-	Params      BacktestParams        `json:"params"`
-	Items       []StrategyRankingItem `json:"items"` // AvgTotalReturn 降順
+	Computed     bool                           `json:"computed"`     // バッチ未実行（または実行中）なら false
+	RunID        string                         `json:"runId"`        // 実行履歴（strategy_ranking_run）の run_id。未計算なら ""
+	Status       string                         `json:"status"`       // running / completed / failed。未計算なら ""
+	ComputedAt   string                         `json:"computedAt"`   // RFC3339 or ""
+	Universe     string                         `json:"universe"`     // ユニバースキー（RankingUniverse.Key）
+	UniverseSpec RankingUniverse                `json:"universeSpec"` // ユニバース条件
+	TotalStocks  int                            `json:"totalStocks"`  // ユニバースの銘柄数
+	Params       BacktestParams                 `json:"params"`
+	Items        []StrategyRankingItem          `json:"items"`    // 全期間。AvgTotalReturn 降順
+	Segments     []StrategyRankingSegmentResult `json:"segments"` // 評価期間セグメント別（指定順）
 }
 
 // StrategyStockResult 1戦略×1銘柄のバックテスト結果（ドリルダウン用）。
@@ -42,9 +58,141 @@ type StrategyStockResult struct {
 // StrategyStocks ドリルダウン API レスポンス（Redis に JSON で保存する）。
 type StrategyStocks struct {
 	Computed   bool                   `json:"computed"`   // バッチ未実行なら false
+	RunID      string                 `json:"runId"`      // 実行履歴の run_id。未計算なら ""
 	ComputedAt string                 `json:"computedAt"` // RFC3339 or ""
 	Strategy   string                 `json:"strategy"`
 	Label      string                 `json:"label"`
 	TotalCount int                    `json:"totalCount"` // limit 適用前の全件数
 	Items      []*StrategyStockResult `json:"items"`      // TotalReturn 降順
 }
+
+// 戦略ランキング実行の状態。
+const (
+	RankingRunStatusRunning   = "running"
+	RankingRunStatusCompleted = "completed"
+	RankingRunStatusFailed    = "failed"
+)
+
+// RankingUniverseMainMarkets ユニバース条件が無指定のときのキー（主要市場全銘柄）。
+const RankingUniverseMainMarkets = "main_markets"
+
+// RankingUniverse 戦略ランキングの対象銘柄条件。指定した条件はすべて AND で適用する。
+type RankingUniverse struct {
+	MarketCodes   []string `json:"marketCodes,omitempty"`   // 市場コード。空なら主要市場（111, 112, 113）
+	Sector33Codes []string `json:"sector33Codes,omitempty"` // 33業種コード。空なら全業種
+	// MinAvgTradingValue 直近20営業日の平均売買代金（円）の下限。ゼロなら無制限
+	MinAvgTradingValue decimal.Decimal `json:"minAvgTradingValue"`
+	WatchlistName      string          `json:"watchlistName,omitempty"` // ウォッチリスト名（Symbols の表示名）
+	Symbols            []string        `json:"symbols,omitempty"`       // 指定時はこの銘柄のみ
+}
+
+// Key ユニバース条件を一意に表す文字列。無指定なら "main_markets"。
+// 例: "market:111+sector:3050,3100+liquidity:100000000"
+func (u RankingUniverse) Key() string {
+	parts := make([]string, 0, 4)
+	if len(u.MarketCodes) > 0 {
+		parts = append(parts, "market:"+strings.Join(u.MarketCodes, ","))
+	}
+	if len(u.Sector33Codes) > 0 {
+		parts = append(parts, "sector:"+strings.Join(u.Sector33Codes, ","))
+	}
+	if u.MinAvgTradingValue.IsPositive() {
+		parts = append(parts, "liquidity:"+u.MinAvgTradingValue.String())
+	}
+	if len(u.Symbols) > 0 {
+		name := u.WatchlistName
+		if name == "" {
+			name = strings.Join(u.Symbols, ",")
+		}
+		parts = append(parts, "watchlist:"+name)
+	}
+	if len(parts) == 0 {
+		return RankingUniverseMainMarkets
+	}
+	return strings.Join(parts, "+")
+}
+
+// 評価期間セグメントの種類。
+const (
+	// RankingSegmentSourceExplicit 日付範囲を明示指定したセグメント。
+	RankingSegmentSourceExplicit = "explicit"
+	// RankingSegmentSourceNikkeiRegime 日経平均のトレンド（終値と200日移動平均の位置関係）で判定したセグメント。
+	RankingSegmentSourceNikkeiRegime = "nikkei_regime"
+)
+
+// 日経平均レジームのセグメント名。
+const (
+	RankingSegmentNikkeiBull = "nikkei_bull"
+	RankingSegmentNikkeiBear = "nikkei_bear"
+)
+
+// RankingDateRange 両端を含む日付範囲（YYYY-MM-DD）。
+type RankingDateRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// RankingSegment 名前付きの評価期間。日経レジームのように飛び飛びの期間は複数の Ranges を持つ。
+type RankingSegment struct {
+	Name   string             `json:"name"`
+	Source string             `json:"source"` // explicit / nikkei_regime
+	Ranges []RankingDateRange `json:"ranges"`
+}
+
+// StrategyRankingRequest 戦略ランキング実行の条件。
+type StrategyRankingRequest struct {
+	Params   BacktestParams   `json:"params"`
+	Years    int              `json:"years"` // 直近N年
+	Universe RankingUniverse  `json:"universe"`
+	Segments []RankingSegment `json:"segments"` // 明示指定のセグメント
+	// NikkeiRegimes true なら日経平均のトレンドで nikkei_bull / nikkei_bear セグメントを追加する
+	NikkeiRegimes bool `json:"nikkeiRegimes"`
+}
+
+// StrategyRankingRun 戦略ランキングバッチ1回分の実行記録（MySQL に保存する）。
+// 同じ run_id で戦略別集計（StrategyRankingItem）と銘柄別結果（StrategyStockResult）を保持する。
+type StrategyRankingRun struct {
+	RunID           string           `json:"runId"`
+	Status          string           `json:"status"` // running / completed / failed
+	Error           string           `json:"error,omitempty"`
+	ComputedAt      time.Time        `json:"computedAt"` // 開始日時（完了時に完了日時で更新）
+	Universe        string           `json:"universe"`   // ユニバースキー（RankingUniverse.Key）
+	UniverseSpec    RankingUniverse  `json:"universeSpec"`
+	Years           int              `json:"years"`           // 対象期間（直近N年）
+	TotalStocks     int              `json:"totalStocks"`     // ユニバースの銘柄数
+	ProcessedStocks int              `json:"processedStocks"` // データ十分で検証できた銘柄数
+	Params          BacktestParams   `json:"params"`
+	Segments        []RankingSegment `json:"segments"` // 評価期間セグメントの定義（日経レジームは判定済みの期間）
+}
+
+// StrategyRankingRuns 実行履歴一覧 API レスポンス。
+type StrategyRankingRuns struct {
+	Items []*StrategyRankingRun `json:"items"` // ComputedAt 降順
+}
+
+// StrategyRankingDiffItem 2回の実行間での1戦略の順位・指標の変化。
+// 片方の実行にしか存在しない戦略は、存在しない側の Rank/値が nil になる。
+type StrategyRankingDiffItem struct {
+	Strategy   string               `json:"strategy"`
+	Label      string               `json:"label"`
+	BaseRank   *int                 `json:"baseRank"`   // 1始まり
+	TargetRank *int                 `json:"targetRank"` // 1始まり
+	RankChange *int                 `json:"rankChange"` // baseRank - targetRank（正なら順位上昇）
+	Base       *StrategyRankingItem `json:"base"`
+	Target     *StrategyRankingItem `json:"target"`
+	// 以下は target - base。どちらかが欠けていればゼロ
+	AvgTotalReturnChange  decimal.Decimal `json:"avgTotalReturnChange"`
+	PositiveRateChange    decimal.Decimal `json:"positiveRateChange"`
+	AvgWinRateChange      decimal.Decimal `json:"avgWinRateChange"`
+	AvgProfitFactorChange decimal.Decimal `json:"avgProfitFactorChange"`
+}
+
+// StrategyRankingDiff 2回の実行の比較結果。
+type StrategyRankingDiff struct {
+	Base    *StrategyRankingRun `json:"base"`
+	Target  *StrategyRankingRun `json:"target"`
+	Segment string              `json:"segment"` // 比較したセグメント名。"" は全期間
+	// ChangedParams 両実行で値が異なる条件のキー（years, universe, BacktestParams の JSON キー）。昇順
+	ChangedParams []string                  `json:"changedParams"`
+	Items         []StrategyRankingDiffItem `json:"items"` // target の順位順。target に無い戦略は末尾
+}
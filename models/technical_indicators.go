@@ -2,6 +2,48 @@ package models
 
 import "github.com/shopspring/decimal"
 
+// GET /technical-indicators の indicators= で選択できる指標名。
+const (
+	TechnicalIndicatorATR               = "atr"
+	TechnicalIndicatorStochastics       = "stochastics"
+	TechnicalIndicatorADX               = "adx"
+	TechnicalIndicatorOBV               = "obv"
+	TechnicalIndicatorVWAP              = "vwap"
+	TechnicalIndicatorIchimoku          = "ichimoku"
+	TechnicalIndicatorSupportResistance = "support_resistance"
+	TechnicalIndicatorParabolicSAR      = "psar"
+	TechnicalIndicatorDonchian          = "donchian"
+	TechnicalIndicatorKeltner           = "keltner"
+	TechnicalIndicatorCCI               = "cci"
+	TechnicalIndicatorWilliamsR         = "williams_r"
+	TechnicalIndicatorMFI               = "mfi"
+	TechnicalIndicatorCMF               = "cmf"
+	TechnicalIndicatorAroon             = "aroon"
+	TechnicalIndicatorSupertrend        = "supertrend"
+	TechnicalIndicatorMADeviation       = "ma_deviation"
+)
+
+// TechnicalIndicatorNames 選択可能な全指標（indicators= 省略時はすべて計算する）。
+var TechnicalIndicatorNames = []string{
+	TechnicalIndicatorATR,
+	TechnicalIndicatorStochastics,
+	TechnicalIndicatorADX,
+	TechnicalIndicatorOBV,
+	TechnicalIndicatorVWAP,
+	TechnicalIndicatorIchimoku,
+	TechnicalIndicatorSupportResistance,
+	TechnicalIndicatorParabolicSAR,
+	TechnicalIndicatorDonchian,
+	TechnicalIndicatorKeltner,
+	TechnicalIndicatorCCI,
+	TechnicalIndicatorWilliamsR,
+	TechnicalIndicatorMFI,
+	TechnicalIndicatorCMF,
+	TechnicalIndicatorAroon,
+	TechnicalIndicatorSupertrend,
+	TechnicalIndicatorMADeviation,
+}
+
 // TechnicalIndicatorPoint 1日分のテクニカル指標値。
 // 確定していない（ウォームアップ期間中）の指標は nil。
 type TechnicalIndicatorPoint struct {
@@ -20,6 +62,23 @@ type TechnicalIndicatorPoint struct {
 	SenkouA *decimal.Decimal `json:"senkouA"`
 	SenkouB *decimal.Decimal `json:"senkouB"`
 	Chikou  *decimal.Decimal `json:"chikou"`
+
+	PSAR          *decimal.Decimal `json:"psar"`
+	DonchianUpper *decimal.Decimal `json:"donchianUpper"`
+	DonchianMid   *decimal.Decimal `json:"donchianMid"`
+	DonchianLower *decimal.Decimal `json:"donchianLower"`
+	KeltnerUpper  *decimal.Decimal `json:"keltnerUpper"`
+	KeltnerMid    *decimal.Decimal `json:"keltnerMid"`
+	KeltnerLower  *decimal.Decimal `json:"keltnerLower"`
+	CCI           *decimal.Decimal `json:"cci"`
+	WilliamsR     *decimal.Decimal `json:"williamsR"`
+	MFI           *decimal.Decimal `json:"mfi"`
+	CMF           *decimal.Decimal `json:"cmf"`
+	AroonUp       *decimal.Decimal `json:"aroonUp"`
+	AroonDown     *decimal.Decimal `json:"aroonDown"`
+	Supertrend    *decimal.Decimal `json:"supertrend"`
+	SupertrendUp  *bool            `json:"supertrendUp"` // true: 上昇トレンド
+	MADeviation   *decimal.Decimal `json:"maDeviation"`  // 25日移動平均乖離率（%）
 }
 
 // SupportResistanceLevel サポート/レジスタンスレベル。
@@ -36,6 +95,7 @@ type TechnicalIndicators struct {
 	From                    string                    `json:"from"`
 	To                      string                    `json:"to"`
 	TradingDays             int                       `json:"tradingDays"`
+	Indicators              []string                  `json:"indicators"` // 計算した指標名
 	Points                  []TechnicalIndicatorPoint `json:"points"`
 	FuturePoints            []TechnicalIndicatorPoint `json:"futurePoints"`
 	SupportResistanceLevels []SupportResistanceLevel  `json:"supportResistanceLevels"`
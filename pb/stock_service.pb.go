@@ -257,6 +257,213 @@ func (x *PaginationInfo) GetLimit() int32 {
 	return 0
 }
 
+// UpsertAnalyzeStockBrandPriceHistoriesRequest is a batch of externally produced signals
+type UpsertAnalyzeStockBrandPriceHistoriesRequest struct {
+	state         protoimpl.MessageState                `protogen:"open.v1"`
+	Histories     []*AnalyzeStockBrandPriceHistoryInput `protobuf:"bytes,1,rep,name=histories,proto3" json:"histories,omitempty"` // 1 to 1000 signals. The whole batch is rejected if any of them is invalid.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesRequest) Reset() {
+	*x = UpsertAnalyzeStockBrandPriceHistoriesRequest{}
+	mi := &file_stock_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertAnalyzeStockBrandPriceHistoriesRequest) ProtoMessage() {}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertAnalyzeStockBrandPriceHistoriesRequest.ProtoReflect.Descriptor instead.
+func (*UpsertAnalyzeStockBrandPriceHistoriesRequest) Descriptor() ([]byte, []int) {
+	return file_stock_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesRequest) GetHistories() []*AnalyzeStockBrandPriceHistoryInput {
+	if x != nil {
+		return x.Histories
+	}
+	return nil
+}
+
+// AnalyzeStockBrandPriceHistoryInput is one signal. (method, ticker_symbol, date, action) identifies the row.
+type AnalyzeStockBrandPriceHistoryInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TickerSymbol  string                 `protobuf:"bytes,1,opt,name=ticker_symbol,json=tickerSymbol,proto3" json:"ticker_symbol,omitempty"` // Must be a listed stock brand
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`                                     // Signal date (YYYY-MM-DD)
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`                                 // Must be a registered analysis method (e.g., "find_ml_ranked_stocks_v1")
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                                 // "Buy" or "Sell"
+	TradePrice    string                 `protobuf:"bytes,5,opt,name=trade_price,json=tradePrice,proto3" json:"trade_price,omitempty"`       // Decimal string (e.g., "2890.5")
+	Memo          string                 `protobuf:"bytes,6,opt,name=memo,proto3" json:"memo,omitempty"`                                     // Empty string means no memo
+	Score         string                 `protobuf:"bytes,7,opt,name=score,proto3" json:"score,omitempty"`                                   // Decimal string. Empty string means no score
+	SignalRank    int32                  `protobuf:"varint,8,opt,name=signal_rank,json=signalRank,proto3" json:"signal_rank,omitempty"`      // Rank within the method (1-based). 0 means no rank
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) Reset() {
+	*x = AnalyzeStockBrandPriceHistoryInput{}
+	mi := &file_stock_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeStockBrandPriceHistoryInput) ProtoMessage() {}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeStockBrandPriceHistoryInput.ProtoReflect.Descriptor instead.
+func (*AnalyzeStockBrandPriceHistoryInput) Descriptor() ([]byte, []int) {
+	return file_stock_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) GetTickerSymbol() string {
+	if x != nil {
+		return x.TickerSymbol
+	}
+	return ""
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) GetTradePrice() string {
+	if x != nil {
+		return x.TradePrice
+	}
+	return ""
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) GetScore() string {
+	if x != nil {
+		return x.Score
+	}
+	return ""
+}
+
+func (x *AnalyzeStockBrandPriceHistoryInput) GetSignalRank() int32 {
+	if x != nil {
+		return x.SignalRank
+	}
+	return 0
+}
+
+// UpsertAnalyzeStockBrandPriceHistoriesResponse reports how many rows were inserted or updated
+type UpsertAnalyzeStockBrandPriceHistoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Received      int32                  `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	Inserted      int32                  `protobuf:"varint,2,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	Updated       int32                  `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesResponse) Reset() {
+	*x = UpsertAnalyzeStockBrandPriceHistoriesResponse{}
+	mi := &file_stock_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertAnalyzeStockBrandPriceHistoriesResponse) ProtoMessage() {}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertAnalyzeStockBrandPriceHistoriesResponse.ProtoReflect.Descriptor instead.
+func (*UpsertAnalyzeStockBrandPriceHistoriesResponse) Descriptor() ([]byte, []int) {
+	return file_stock_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesResponse) GetReceived() int32 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesResponse) GetInserted() int32 {
+	if x != nil {
+		return x.Inserted
+	}
+	return 0
+}
+
+func (x *UpsertAnalyzeStockBrandPriceHistoriesResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
 var File_stock_service_proto protoreflect.FileDescriptor
 
 const file_stock_service_proto_rawDesc = "" +
@@ -281,9 +488,27 @@ const file_stock_service_proto_rawDesc = "" +
 	"\x0ePaginationInfo\x12\x1f\n" +
 	"\vnext_cursor\x18\x01 \x01(\tR\n" +
 	"nextCursor\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit2{\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"w\n" +
+	",UpsertAnalyzeStockBrandPriceHistoriesRequest\x12G\n" +
+	"\thistories\x18\x01 \x03(\v2).stock.AnalyzeStockBrandPriceHistoryInputR\thistories\"\xf9\x01\n" +
+	"\"AnalyzeStockBrandPriceHistoryInput\x12#\n" +
+	"\rticker_symbol\x18\x01 \x01(\tR\ftickerSymbol\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1f\n" +
+	"\vtrade_price\x18\x05 \x01(\tR\n" +
+	"tradePrice\x12\x12\n" +
+	"\x04memo\x18\x06 \x01(\tR\x04memo\x12\x14\n" +
+	"\x05score\x18\a \x01(\tR\x05score\x12\x1f\n" +
+	"\vsignal_rank\x18\b \x01(\x05R\n" +
+	"signalRank\"\x81\x01\n" +
+	"-UpsertAnalyzeStockBrandPriceHistoriesResponse\x12\x1a\n" +
+	"\breceived\x18\x01 \x01(\x05R\breceived\x12\x1a\n" +
+	"\binserted\x18\x02 \x01(\x05R\binserted\x12\x18\n" +
+	"\aupdated\x18\x03 \x01(\x05R\aupdated2\x90\x02\n" +
 	"\fStockService\x12k\n" +
-	"\x18GetHighVolumeStockBrands\x12&.stock.GetHighVolumeStockBrandsRequest\x1a'.stock.GetHighVolumeStockBrandsResponseB2Z0github.com/Code0716/stock-price-repository/pb;pbb\x06proto3"
+	"\x18GetHighVolumeStockBrands\x12&.stock.GetHighVolumeStockBrandsRequest\x1a'.stock.GetHighVolumeStockBrandsResponse\x12\x92\x01\n" +
+	"%UpsertAnalyzeStockBrandPriceHistories\x123.stock.UpsertAnalyzeStockBrandPriceHistoriesRequest\x1a4.stock.UpsertAnalyzeStockBrandPriceHistoriesResponseB2Z0github.com/Code0716/stock-price-repository/pb;pbb\x06proto3"
 
 var (
 	file_stock_service_proto_rawDescOnce sync.Once
@@ -297,23 +522,29 @@ func file_stock_service_proto_rawDescGZIP() []byte {
 	return file_stock_service_proto_rawDescData
 }
 
-var file_stock_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_stock_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_stock_service_proto_goTypes = []any{
-	(*GetHighVolumeStockBrandsRequest)(nil),  // 0: stock.GetHighVolumeStockBrandsRequest
-	(*GetHighVolumeStockBrandsResponse)(nil), // 1: stock.GetHighVolumeStockBrandsResponse
-	(*HighVolumeStockBrand)(nil),             // 2: stock.HighVolumeStockBrand
-	(*PaginationInfo)(nil),                   // 3: stock.PaginationInfo
+	(*GetHighVolumeStockBrandsRequest)(nil),               // 0: stock.GetHighVolumeStockBrandsRequest
+	(*GetHighVolumeStockBrandsResponse)(nil),              // 1: stock.GetHighVolumeStockBrandsResponse
+	(*HighVolumeStockBrand)(nil),                          // 2: stock.HighVolumeStockBrand
+	(*PaginationInfo)(nil),                                // 3: stock.PaginationInfo
+	(*UpsertAnalyzeStockBrandPriceHistoriesRequest)(nil),  // 4: stock.UpsertAnalyzeStockBrandPriceHistoriesRequest
+	(*AnalyzeStockBrandPriceHistoryInput)(nil),            // 5: stock.AnalyzeStockBrandPriceHistoryInput
+	(*UpsertAnalyzeStockBrandPriceHistoriesResponse)(nil), // 6: stock.UpsertAnalyzeStockBrandPriceHistoriesResponse
 }
 var file_stock_service_proto_depIdxs = []int32{
 	2, // 0: stock.GetHighVolumeStockBrandsResponse.brands:type_name -> stock.HighVolumeStockBrand
 	3, // 1: stock.GetHighVolumeStockBrandsResponse.pagination:type_name -> stock.PaginationInfo
-	0, // 2: stock.StockService.GetHighVolumeStockBrands:input_type -> stock.GetHighVolumeStockBrandsRequest
-	1, // 3: stock.StockService.GetHighVolumeStockBrands:output_type -> stock.GetHighVolumeStockBrandsResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	5, // 2: stock.UpsertAnalyzeStockBrandPriceHistoriesRequest.histories:type_name -> stock.AnalyzeStockBrandPriceHistoryInput
+	0, // 3: stock.StockService.GetHighVolumeStockBrands:input_type -> stock.GetHighVolumeStockBrandsRequest
+	4, // 4: stock.StockService.UpsertAnalyzeStockBrandPriceHistories:input_type -> stock.UpsertAnalyzeStockBrandPriceHistoriesRequest
+	1, // 5: stock.StockService.GetHighVolumeStockBrands:output_type -> stock.GetHighVolumeStockBrandsResponse
+	6, // 6: stock.StockService.UpsertAnalyzeStockBrandPriceHistories:output_type -> stock.UpsertAnalyzeStockBrandPriceHistoriesResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_stock_service_proto_init() }
@@ -327,7 +558,7 @@ func file_stock_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stock_service_proto_rawDesc), len(file_stock_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
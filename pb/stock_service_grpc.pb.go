@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StockService_GetHighVolumeStockBrands_FullMethodName = "/stock.StockService/GetHighVolumeStockBrands"
+	StockService_GetHighVolumeStockBrands_FullMethodName              = "/stock.StockService/GetHighVolumeStockBrands"
+	StockService_UpsertAnalyzeStockBrandPriceHistories_FullMethodName = "/stock.StockService/UpsertAnalyzeStockBrandPriceHistories"
 )
 
 // StockServiceClient is the client API for StockService service.
@@ -30,6 +31,9 @@ const (
 type StockServiceClient interface {
 	// GetHighVolumeStockBrands retrieves high volume stock brands with pagination support
 	GetHighVolumeStockBrands(ctx context.Context, in *GetHighVolumeStockBrandsRequest, opts ...grpc.CallOption) (*GetHighVolumeStockBrandsResponse, error)
+	// UpsertAnalyzeStockBrandPriceHistories upserts externally produced signals into analyze_stock_brand_price_history.
+	// Requires "authorization: Bearer <token>" metadata.
+	UpsertAnalyzeStockBrandPriceHistories(ctx context.Context, in *UpsertAnalyzeStockBrandPriceHistoriesRequest, opts ...grpc.CallOption) (*UpsertAnalyzeStockBrandPriceHistoriesResponse, error)
 }
 
 type stockServiceClient struct {
@@ -50,6 +54,16 @@ func (c *stockServiceClient) GetHighVolumeStockBrands(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *stockServiceClient) UpsertAnalyzeStockBrandPriceHistories(ctx context.Context, in *UpsertAnalyzeStockBrandPriceHistoriesRequest, opts ...grpc.CallOption) (*UpsertAnalyzeStockBrandPriceHistoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertAnalyzeStockBrandPriceHistoriesResponse)
+	err := c.cc.Invoke(ctx, StockService_UpsertAnalyzeStockBrandPriceHistories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StockServiceServer is the server API for StockService service.
 // All implementations must embed UnimplementedStockServiceServer
 // for forward compatibility.
@@ -58,6 +72,9 @@ func (c *stockServiceClient) GetHighVolumeStockBrands(ctx context.Context, in *G
 type StockServiceServer interface {
 	// GetHighVolumeStockBrands retrieves high volume stock brands with pagination support
 	GetHighVolumeStockBrands(context.Context, *GetHighVolumeStockBrandsRequest) (*GetHighVolumeStockBrandsResponse, error)
+	// UpsertAnalyzeStockBrandPriceHistories upserts externally produced signals into analyze_stock_brand_price_history.
+	// Requires "authorization: Bearer <token>" metadata.
+	UpsertAnalyzeStockBrandPriceHistories(context.Context, *UpsertAnalyzeStockBrandPriceHistoriesRequest) (*UpsertAnalyzeStockBrandPriceHistoriesResponse, error)
 	mustEmbedUnimplementedStockServiceServer()
 }
 
@@ -71,6 +88,9 @@ type UnimplementedStockServiceServer struct{}
 func (UnimplementedStockServiceServer) GetHighVolumeStockBrands(context.Context, *GetHighVolumeStockBrandsRequest) (*GetHighVolumeStockBrandsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetHighVolumeStockBrands not implemented")
 }
+func (UnimplementedStockServiceServer) UpsertAnalyzeStockBrandPriceHistories(context.Context, *UpsertAnalyzeStockBrandPriceHistoriesRequest) (*UpsertAnalyzeStockBrandPriceHistoriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertAnalyzeStockBrandPriceHistories not implemented")
+}
 func (UnimplementedStockServiceServer) mustEmbedUnimplementedStockServiceServer() {}
 func (UnimplementedStockServiceServer) testEmbeddedByValue()                      {}
 
@@ -110,6 +130,24 @@ func _StockService_GetHighVolumeStockBrands_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StockService_UpsertAnalyzeStockBrandPriceHistories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertAnalyzeStockBrandPriceHistoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).UpsertAnalyzeStockBrandPriceHistories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_UpsertAnalyzeStockBrandPriceHistories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).UpsertAnalyzeStockBrandPriceHistories(ctx, req.(*UpsertAnalyzeStockBrandPriceHistoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StockService_ServiceDesc is the grpc.ServiceDesc for StockService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,6 +159,10 @@ var StockService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetHighVolumeStockBrands",
 			Handler:    _StockService_GetHighVolumeStockBrands_Handler,
 		},
+		{
+			MethodName: "UpsertAnalyzeStockBrandPriceHistories",
+			Handler:    _StockService_UpsertAnalyzeStockBrandPriceHistories_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stock_service.proto",
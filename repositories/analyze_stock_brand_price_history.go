@@ -22,6 +22,13 @@ type AnalyzeStockBrandPriceHistoryRepository interface {
 	FindMultipleSignals(ctx context.Context, filter *models.MultipleSignalStockFilter) ([]*models.MultipleSignalStock, error)
 	// FindByCreatedAtRange 期間内のシグナル履歴を取得する（シグナル精度評価用）
 	FindByCreatedAtRange(ctx context.Context, filter *models.SignalPerformanceFilter) ([]*models.AnalyzeStockBrandPriceHistory, error)
+	// UpsertByKey 一意キー (method, ticker_symbol, created_at, action) が同じ行があれば ID を残して上書きし、無ければ追加する。
+	// trade_price が変わった行は値洗い（current_price / price_difference / marked_at）を NULL に戻す。上書きした件数を返す。
+	UpsertByKey(ctx context.Context, histories []*models.AnalyzeStockBrandPriceHistory) (int, error)
+	// ListOpen クローズしていない（closed_at が NULL の）シグナルを created_at, id 昇順で取得する（値洗い用）
+	ListOpen(ctx context.Context) ([]*models.AnalyzeStockBrandPriceHistory, error)
+	// UpdateMarks 値洗い結果（現在値・差額・基準日、クローズ時は出口価格・保有リターン）を書き込む
+	UpdateMarks(ctx context.Context, marks []*models.AnalyzeStockBrandPriceHistoryMark) error
 	// DeleteByStockBrandIDs 銘柄IDで一致したものを削除する
 	DeleteByStockBrandIDs(ctx context.Context, ids []string) error
 }
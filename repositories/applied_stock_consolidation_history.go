@@ -11,4 +11,6 @@ import (
 type AppliedStockConsolidationsHistoryRepository interface {
 	Exists(ctx context.Context, symbol string, consolidationDate time.Time) (bool, error)
 	Create(ctx context.Context, history *models.AppliedStockConsolidationHistory) error
+	// ListByConsolidationDateRange 併合実施日が from〜to の適用履歴を consolidation_date, symbol 昇順で返す。
+	ListByConsolidationDateRange(ctx context.Context, from, to time.Time) ([]*models.AppliedStockConsolidationHistory, error)
 }
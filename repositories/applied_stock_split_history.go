@@ -11,4 +11,6 @@ import (
 type AppliedStockSplitsHistoryRepository interface {
 	Exists(ctx context.Context, symbol string, splitDate time.Time) (bool, error)
 	Create(ctx context.Context, history *models.AppliedStockSplitHistory) error
+	// ListBySplitDateRange 分割実施日が from〜to の適用履歴を split_date, symbol 昇順で返す。
+	ListBySplitDateRange(ctx context.Context, from, to time.Time) ([]*models.AppliedStockSplitHistory, error)
 }
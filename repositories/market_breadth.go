@@ -0,0 +1,19 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+type MarketBreadthRepository interface {
+	// UpsertMarketBreadths 騰落指標を (date, market) 単位で作成・上書きする（再実行時の洗い替え用）。
+	UpsertMarketBreadths(ctx context.Context, breadths []*models.MarketBreadth) error
+	// ListMarketBreadths 指定市場の騰落指標を date 昇順で取得する。
+	ListMarketBreadths(ctx context.Context, filter models.MarketBreadthFilter) ([]*models.MarketBreadth, error)
+	// ListMarketBreadthsBefore 指定市場の before より前の直近 limit 営業日分を date 昇順で取得する（累積指標の継続計算用）。
+	ListMarketBreadthsBefore(ctx context.Context, market string, before time.Time, limit int) ([]*models.MarketBreadth, error)
+}
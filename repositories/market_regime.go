@@ -0,0 +1,19 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+type MarketRegimeRepository interface {
+	// UpsertMarketRegimes 市場局面を date 単位で作成・上書きする（再実行時の洗い替え用）。
+	UpsertMarketRegimes(ctx context.Context, regimes []*models.MarketRegime) error
+	// ListMarketRegimes 市場局面を date 昇順で取得する。
+	ListMarketRegimes(ctx context.Context, filter models.MarketRegimeFilter) ([]*models.MarketRegime, error)
+	// FindMarketRegimeByDate 指定日の市場局面を取得する。未算出なら nil を返す。
+	FindMarketRegimeByDate(ctx context.Context, date time.Time) (*models.MarketRegime, error)
+}
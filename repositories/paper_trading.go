@@ -0,0 +1,33 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+type PaperTradingRepository interface {
+	// FindAccount 模擬売買口座を取得する。未作成なら nil を返す。
+	FindAccount(ctx context.Context, name string) (*models.PaperAccount, error)
+	// CreateAccount 模擬売買口座を作成する。
+	CreateAccount(ctx context.Context, account *models.PaperAccount) error
+	// CreatePositions ポジションをまとめて作成し、採番した ID を各要素の ID に反映する。
+	CreatePositions(ctx context.Context, positions []*models.PaperPosition) error
+	// UpdatePositions 値洗い・手仕舞いの結果を ID ごとに反映する。
+	UpdatePositions(ctx context.Context, positions []*models.PaperPosition) error
+	// ListPositions 条件に合うポジションを買付日・推奨順位の昇順で取得する。
+	ListPositions(ctx context.Context, filter models.PaperPositionFilter) ([]*models.PaperPosition, error)
+	// CreateFills 約定をまとめて作成する。
+	CreateFills(ctx context.Context, fills []*models.PaperFill) error
+	// ListFills from/to（いずれも nil 可）の約定を約定日・ID の昇順で取得する。
+	ListFills(ctx context.Context, account string, from, to *time.Time) ([]*models.PaperFill, error)
+	// CreateNav 1営業日ぶんの NAV を作成する。
+	CreateNav(ctx context.Context, nav *models.PaperNav) error
+	// FindLatestNav 最新の NAV を取得する。1件も無ければ nil を返す。
+	FindLatestNav(ctx context.Context, account string) (*models.PaperNav, error)
+	// ListNavs from/to（いずれも nil 可）の NAV を date 昇順で取得する。
+	ListNavs(ctx context.Context, account string, from, to *time.Time) ([]*models.PaperNav, error)
+}
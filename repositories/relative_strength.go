@@ -0,0 +1,21 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+type RelativeStrengthRepository interface {
+	// UpsertRatings RS レーティングを (date, ticker_symbol) 単位で作成・上書きする。
+	UpsertRatings(ctx context.Context, ratings []*models.RelativeStrengthRating) error
+	// DeleteByDate 指定日の RS レーティングを全銘柄分削除する（再実行時の洗い替えで、その日に対象外になった銘柄を残さないため）。
+	DeleteByDate(ctx context.Context, date time.Time) error
+	// FindLatestDate onOrBefore（nil なら無制限）以前で最新の算出日を取得する（1件も無ければ nil を返す）。
+	FindLatestDate(ctx context.Context, onOrBefore *time.Time) (*time.Time, error)
+	// List 指定日の RS レーティングを rating 降順（同値は銘柄コード昇順）で取得する。
+	List(ctx context.Context, filter models.RelativeStrengthFilter) ([]*models.RelativeStrengthRating, error)
+}
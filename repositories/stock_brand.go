@@ -24,6 +24,8 @@ type StockBrandRepository interface {
 	FindWithFilter(ctx context.Context, filter *models.StockBrandFilter) ([]*models.StockBrand, error)
 	// IDのリストから銘柄を取得する（クイズ結果画面での銘柄名解決用）。
 	FindByIDs(ctx context.Context, ids []string) ([]*models.StockBrand, error)
+	// 銘柄コードのリストから銘柄を取得する（外部シグナル取り込みでの銘柄ID解決用）。
+	FindByTickerSymbols(ctx context.Context, tickerSymbols []string) ([]*models.StockBrand, error)
 	// 上場廃止銘柄の取得
 	// upsertされたタイミングで利用。upsertされてなかったら上場廃止と判断する
 	FindDelistingStockBrandsFromUpdateTime(ctx context.Context, now time.Time) ([]string, error)
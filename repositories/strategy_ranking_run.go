@@ -0,0 +1,32 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+type StrategyRankingRunRepository interface {
+	// Create 実行記録を作成する（開始時に status=running で登録する）。
+	Create(ctx context.Context, run *models.StrategyRankingRun) error
+	// UpdateRun 実行記録の状態・完了日時・銘柄数・セグメント定義を更新する。
+	UpdateRun(ctx context.Context, run *models.StrategyRankingRun) error
+	// BulkCreateItems 1セグメント分の戦略別集計（items の並び順を順位として保存）を作成する。segment="" は全期間。
+	BulkCreateItems(ctx context.Context, runID, segment string, items []models.StrategyRankingItem) error
+	// BulkCreateStocks 1戦略分の銘柄別結果をまとめて作成する。
+	BulkCreateStocks(ctx context.Context, runID, strategy string, stocks []*models.StrategyStockResult) error
+	// FindByRunID 実行記録を取得する。存在しなければ nil を返す。
+	FindByRunID(ctx context.Context, runID string) (*models.StrategyRankingRun, error)
+	// FindLatest universe の完了済み実行のうち最新（computed_at 最大）の実行記録を取得する。1件も無ければ nil を返す。
+	FindLatest(ctx context.Context, universe string) (*models.StrategyRankingRun, error)
+	// List 実行記録を computed_at 降順に最大 limit 件取得する。limit<=0 なら無制限。
+	List(ctx context.Context, limit int) ([]*models.StrategyRankingRun, error)
+	// ListItems 1セグメントの戦略別集計を順位昇順で取得する。segment="" は全期間。
+	ListItems(ctx context.Context, runID, segment string) ([]models.StrategyRankingItem, error)
+	// ListStocks 1戦略の銘柄別結果を total_return 降順に最大 limit 件取得する。limit<=0 なら無制限。
+	ListStocks(ctx context.Context, runID, strategy string, limit int) ([]*models.StrategyStockResult, error)
+	// CountStocks 1戦略の銘柄別結果の件数を返す。
+	CountStocks(ctx context.Context, runID, strategy string) (int, error)
+}
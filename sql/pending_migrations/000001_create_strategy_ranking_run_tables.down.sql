@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `strategy_ranking_run_stock`;
+DROP TABLE IF EXISTS `strategy_ranking_run_item`;
+DROP TABLE IF EXISTS `strategy_ranking_run`;
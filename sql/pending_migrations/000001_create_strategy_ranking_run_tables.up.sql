@@ -0,0 +1,57 @@
+-- strategy_ranking_run 戦略ランキングの実行（1回の集計 = 1行）
+CREATE TABLE IF NOT EXISTS `strategy_ranking_run` (
+  `run_id` CHAR(36) NOT NULL COMMENT '実行ID（uuid）',
+  `status` VARCHAR(16) NOT NULL DEFAULT 'completed' COMMENT '実行状態 running / completed / failed',
+  `error` TEXT NOT NULL COMMENT '失敗時のエラーメッセージ',
+  `computed_at` DATETIME NOT NULL COMMENT '集計日時',
+  `universe` VARCHAR(255) NOT NULL COMMENT '対象ユニバースのキー 例: main_markets',
+  `universe_spec` JSON NOT NULL COMMENT 'ユニバース条件（models.RankingUniverse の JSON）',
+  `years` INT UNSIGNED NOT NULL COMMENT '対象期間（直近N年）',
+  `total_stocks` INT UNSIGNED NOT NULL COMMENT 'ユニバースの銘柄数',
+  `processed_stocks` INT UNSIGNED NOT NULL COMMENT 'データ十分で検証できた銘柄数',
+  `params` JSON NOT NULL COMMENT 'バックテスト条件（models.BacktestParams の JSON）',
+  `segments` JSON NOT NULL COMMENT '評価期間セグメント（[]models.RankingSegment の JSON）',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`run_id`),
+  INDEX idx_strategy_ranking_run_universe_status_computed_at (`universe`, `status`, `computed_at`),
+  INDEX idx_strategy_ranking_run_computed_at (`computed_at`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- strategy_ranking_run_item 実行ごと・セグメントごとの戦略ランキング
+CREATE TABLE IF NOT EXISTS `strategy_ranking_run_item` (
+  `run_id` CHAR(36) NOT NULL COMMENT 'strategy_ranking_run.run_id',
+  `segment` VARCHAR(32) NOT NULL COMMENT '評価期間セグメント名（空文字は全期間）',
+  `strategy` VARCHAR(64) NOT NULL COMMENT '戦略キー',
+  `rank_position` INT UNSIGNED NOT NULL COMMENT 'avg_total_return 降順の順位 1..N（rank は MySQL8 予約語のため rank_position）',
+  `label` VARCHAR(255) NOT NULL COMMENT '戦略の表示名',
+  `stock_count` INT UNSIGNED NOT NULL COMMENT '検証できた銘柄数',
+  `traded_stocks` INT UNSIGNED NOT NULL COMMENT '取引が1回以上発生した銘柄数',
+  `total_trades` INT UNSIGNED NOT NULL COMMENT '総取引数',
+  `best_count` INT UNSIGNED NOT NULL COMMENT '銘柄別で最高リターンだった回数',
+  `avg_total_return` DECIMAL(16, 6) NOT NULL COMMENT '全検証銘柄の平均トータルリターン',
+  `positive_rate` DECIMAL(8, 6) NOT NULL COMMENT 'トータルリターンがプラスの銘柄割合',
+  `avg_win_rate` DECIMAL(8, 6) NOT NULL COMMENT '取引のある銘柄での平均勝率',
+  `avg_profit_factor` DECIMAL(16, 6) NOT NULL COMMENT '取引のある銘柄での平均プロフィットファクター',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`run_id`, `segment`, `strategy`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- strategy_ranking_run_stock 実行ごと・戦略ごとの銘柄別結果
+CREATE TABLE IF NOT EXISTS `strategy_ranking_run_stock` (
+  `run_id` CHAR(36) NOT NULL COMMENT 'strategy_ranking_run.run_id',
+  `strategy` VARCHAR(64) NOT NULL COMMENT '戦略キー',
+  `ticker_symbol` VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+  `name` VARCHAR(255) NOT NULL COMMENT '銘柄名',
+  `total_return` DECIMAL(16, 6) NOT NULL COMMENT 'トータルリターン',
+  `trades` INT UNSIGNED NOT NULL COMMENT '取引数',
+  `win_rate` DECIMAL(8, 6) NOT NULL COMMENT '勝率',
+  `profit_factor` DECIMAL(16, 6) NOT NULL COMMENT 'プロフィットファクター',
+  `max_drawdown` DECIMAL(8, 6) NOT NULL COMMENT '最大ドローダウン',
+  `payoff_ratio` DECIMAL(16, 6) NOT NULL COMMENT 'ペイオフレシオ',
+  `avg_hold_days` DECIMAL(8, 2) NOT NULL COMMENT '平均保有日数',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`run_id`, `strategy`, `ticker_symbol`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
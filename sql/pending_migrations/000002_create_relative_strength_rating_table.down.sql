@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `relative_strength_rating`;
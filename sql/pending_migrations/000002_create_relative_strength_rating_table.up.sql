@@ -0,0 +1,15 @@
+-- relative_strength_rating 主要市場全銘柄の RS レーティング（TOPIX 比の相対的な強さ）
+CREATE TABLE IF NOT EXISTS `relative_strength_rating` (
+  `date` DATE NOT NULL COMMENT '算出日（この日の引け値時点）',
+  `ticker_symbol` VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+  `name` VARCHAR(255) NOT NULL COMMENT '銘柄名（算出時点のスナップショット）',
+  `rating` TINYINT UNSIGNED NOT NULL COMMENT 'RSレーティング 1-99（同日のユニバース内パーセンタイル）',
+  `score` DECIMAL(16, 6) NOT NULL COMMENT '3/6/9/12か月のTOPIX比相対リターンの加重平均（40/20/20/20%）',
+  `relative_3m` DECIMAL(16, 6) NOT NULL COMMENT '3か月（63営業日）のTOPIX比相対リターン',
+  `relative_6m` DECIMAL(16, 6) DEFAULT NULL COMMENT '6か月（126営業日）のTOPIX比相対リターン。履歴不足は NULL',
+  `relative_9m` DECIMAL(16, 6) DEFAULT NULL COMMENT '9か月（189営業日）のTOPIX比相対リターン。履歴不足は NULL',
+  `relative_12m` DECIMAL(16, 6) DEFAULT NULL COMMENT '12か月（252営業日）のTOPIX比相対リターン。履歴不足は NULL',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`date`, `ticker_symbol`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
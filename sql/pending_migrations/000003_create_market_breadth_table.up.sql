@@ -0,0 +1,22 @@
+-- market_breadth 主要市場の騰落指標（日次）
+CREATE TABLE IF NOT EXISTS `market_breadth` (
+  `date` DATE NOT NULL COMMENT '営業日',
+  `market` VARCHAR(8) NOT NULL COMMENT 'all（主要市場全体）/ 市場コード',
+  `advancers` INT UNSIGNED NOT NULL COMMENT '値上がり銘柄数',
+  `decliners` INT UNSIGNED NOT NULL COMMENT '値下がり銘柄数',
+  `unchanged` INT UNSIGNED NOT NULL COMMENT '変わらず銘柄数',
+  `ad_line` BIGINT NOT NULL COMMENT '騰落ライン（値上がり−値下がりの累計）',
+  `ad_ratio_25` DECIMAL(10, 2) DEFAULT NULL COMMENT '25日騰落レシオ(%)。25日分に満たない・値下がり0は NULL',
+  `new_highs` INT UNSIGNED NOT NULL COMMENT '52週高値更新銘柄数（終値ベース）',
+  `new_lows` INT UNSIGNED NOT NULL COMMENT '52週安値更新銘柄数（終値ベース）',
+  `above_ma25_pct` DECIMAL(5, 2) DEFAULT NULL COMMENT '25日移動平均線を上回る銘柄の割合(%)',
+  `above_ma75_pct` DECIMAL(5, 2) DEFAULT NULL COMMENT '75日移動平均線を上回る銘柄の割合(%)',
+  `above_ma200_pct` DECIMAL(5, 2) DEFAULT NULL COMMENT '200日移動平均線を上回る銘柄の割合(%)',
+  `mcclellan_oscillator` DECIMAL(12, 2) NOT NULL COMMENT 'マクレラン・オシレーター（純騰落数の19日EMA−39日EMA）',
+  `advance_ema_19` DECIMAL(14, 4) NOT NULL COMMENT '純騰落数の19日EMA（翌日以降の継続計算用）',
+  `advance_ema_39` DECIMAL(14, 4) NOT NULL COMMENT '純騰落数の39日EMA（翌日以降の継続計算用）',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`date`, `market`),
+  INDEX idx_market_breadth_market_date (`market`, `date`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
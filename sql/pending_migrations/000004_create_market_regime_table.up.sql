@@ -0,0 +1,16 @@
+-- market_regime TOPIX・日経平均・騰落指標から判定した市場局面（日次）
+CREATE TABLE IF NOT EXISTS `market_regime` (
+  `date` DATE NOT NULL COMMENT '営業日',
+  `regime` VARCHAR(16) NOT NULL COMMENT '市場局面（trend_up / trend_down / range / high_volatility）',
+  `topix_close` DECIMAL(10, 2) NOT NULL COMMENT 'TOPIX 終値',
+  `topix_ma25` DECIMAL(12, 4) NOT NULL COMMENT 'TOPIX 25日移動平均',
+  `topix_ma75` DECIMAL(12, 4) NOT NULL COMMENT 'TOPIX 75日移動平均',
+  `topix_ma75_slope` DECIMAL(10, 6) NOT NULL COMMENT 'TOPIX 75日移動平均の20営業日前比',
+  `nikkei_above_ma75` TINYINT(1) DEFAULT NULL COMMENT '日経平均の終値が75日移動平均を上回るか。同日の日経平均が無ければ NULL',
+  `atr_ratio` DECIMAL(10, 6) NOT NULL COMMENT 'TOPIX の ATR(14) / 終値',
+  `atr_ratio_baseline` DECIMAL(10, 6) NOT NULL COMMENT 'ATR 比率の直近250営業日平均',
+  `breadth_above_ma75_pct` DECIMAL(5, 2) DEFAULT NULL COMMENT '主要市場全体で75日移動平均線を上回る銘柄の割合(%)。騰落指標が無い日は NULL',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`date`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,7 @@
+-- 旧主キー (pick_date, stock_brand_id) では1銘柄1日1行しか持てないため、live（v1）以外の行を消してから戻す。
+DELETE FROM daily_stock_pick WHERE score_version <> 'v1';
+
+ALTER TABLE daily_stock_pick
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (pick_date, stock_brand_id),
+    MODIFY COLUMN score_version varchar(16) NOT NULL COMMENT 'スコア定義バージョン。重み変更時にインクリメントし過去分と混ぜて集計しない';
@@ -0,0 +1,6 @@
+-- 影の score_version（v1-liquid など）が live と同じ銘柄・同じ日を選べるよう、主キーに score_version を含める。
+-- リプレイ版（replay/v1-confluence など）も収まるよう varchar(32) に広げる。
+ALTER TABLE daily_stock_pick
+    MODIFY COLUMN score_version varchar(32) NOT NULL COMMENT 'スコア定義バージョン。重み変更時にインクリメントし過去分と混ぜて集計しない',
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (pick_date, stock_brand_id, score_version);
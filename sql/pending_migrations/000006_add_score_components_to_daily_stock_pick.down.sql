@@ -0,0 +1,2 @@
+ALTER TABLE `daily_stock_pick`
+  DROP COLUMN `score_components`;
@@ -0,0 +1,3 @@
+-- 推奨スコアの内訳（因子ごとの正規化スコア・重み・寄与）を保存する。導入前に作られた行は NULL のまま
+ALTER TABLE `daily_stock_pick`
+  ADD COLUMN `score_components` JSON DEFAULT NULL COMMENT '因子ごとの正規化スコアと寄与（[]models.DailyStockPickScoreComponent の JSON）。導入前の行は NULL' AFTER `rsi`;
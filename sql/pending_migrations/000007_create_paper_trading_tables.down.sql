@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `paper_nav`;
+DROP TABLE IF EXISTS `paper_fill`;
+DROP TABLE IF EXISTS `paper_position`;
+DROP TABLE IF EXISTS `paper_account`;
@@ -0,0 +1,72 @@
+-- paper_account 日次推奨に追随する模擬売買口座と売買ルール
+CREATE TABLE IF NOT EXISTS `paper_account` (
+  `name` VARCHAR(32) NOT NULL COMMENT '口座名',
+  `score_version` VARCHAR(32) NOT NULL COMMENT '追随する daily_stock_pick.score_version',
+  `top_n` INT UNSIGNED NOT NULL COMMENT '1日に買う推奨の上位件数',
+  `budget_per_pick` DECIMAL(16, 2) NOT NULL COMMENT '1銘柄あたりの予算（円）',
+  `holding_days` TINYINT UNSIGNED NOT NULL COMMENT '最長保有営業日数（買付日を1日目とする）',
+  `take_profit_pct` DECIMAL(6, 4) DEFAULT NULL COMMENT '利確幅（0.1000 = 10%）。NULL なら使わない',
+  `stop_loss_pct` DECIMAL(6, 4) DEFAULT NULL COMMENT '損切り幅（0.0500 = 5%）。NULL なら使わない',
+  `initial_cash` DECIMAL(16, 2) NOT NULL COMMENT '初期資金（円）',
+  `start_date` DATE NOT NULL COMMENT 'この日以降の営業日から売買する',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`name`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- paper_position 1推奨ぶんの模擬ポジション
+CREATE TABLE IF NOT EXISTS `paper_position` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  `account` VARCHAR(32) NOT NULL COMMENT 'paper_account.name',
+  `pick_date` DATE NOT NULL COMMENT '追随した推奨の pick_date',
+  `stock_brand_id` CHAR(36) NOT NULL COMMENT 'stock_brand.id',
+  `ticker_symbol` VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+  `pick_rank` INT UNSIGNED NOT NULL COMMENT '推奨順位',
+  `status` VARCHAR(16) NOT NULL COMMENT 'open / closed',
+  `entry_date` DATE NOT NULL COMMENT '買付日（推奨日の翌営業日）',
+  `entry_price` DECIMAL(10, 4) NOT NULL COMMENT '買付単価（買付日の始値）',
+  `quantity` INT UNSIGNED NOT NULL COMMENT '株数（100株単位）',
+  `held_days` INT UNSIGNED NOT NULL COMMENT '買付日を1日目とした保有営業日数',
+  `mark_date` DATE NOT NULL COMMENT '直近の値洗い日',
+  `mark_price` DECIMAL(10, 4) NOT NULL COMMENT '直近の値洗い単価（終値。手仕舞い済みなら売却単価）',
+  `exit_date` DATE DEFAULT NULL COMMENT '売却日。保有中は NULL',
+  `exit_price` DECIMAL(10, 4) DEFAULT NULL COMMENT '売却単価。保有中は NULL',
+  `exit_reason` VARCHAR(32) DEFAULT NULL COMMENT '手仕舞い理由 holding_period / take_profit / stop_loss / corporate_action',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`id`),
+  INDEX idx_paper_position_account_status (`account`, `status`),
+  INDEX idx_paper_position_account_exit_date (`account`, `exit_date`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- paper_fill 模擬約定
+CREATE TABLE IF NOT EXISTS `paper_fill` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  `account` VARCHAR(32) NOT NULL COMMENT 'paper_account.name',
+  `position_id` BIGINT UNSIGNED NOT NULL COMMENT 'paper_position.id',
+  `fill_date` DATE NOT NULL COMMENT '約定日',
+  `ticker_symbol` VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+  `side` VARCHAR(8) NOT NULL COMMENT 'buy / sell',
+  `quantity` INT UNSIGNED NOT NULL COMMENT '株数',
+  `price` DECIMAL(10, 4) NOT NULL COMMENT '約定単価',
+  `amount` DECIMAL(16, 2) NOT NULL COMMENT '約定代金（円）',
+  `reason` VARCHAR(32) NOT NULL COMMENT '売却の手仕舞い理由。買付は空',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`id`),
+  INDEX idx_paper_fill_account_fill_date (`account`, `fill_date`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- paper_nav 1営業日の引け時点の口座評価
+CREATE TABLE IF NOT EXISTS `paper_nav` (
+  `account` VARCHAR(32) NOT NULL COMMENT 'paper_account.name',
+  `date` DATE NOT NULL COMMENT '営業日',
+  `cash` DECIMAL(16, 2) NOT NULL COMMENT '引け時点の現金（円）',
+  `market_value` DECIMAL(16, 2) NOT NULL COMMENT '保有ポジションの終値評価額（円）',
+  `nav` DECIMAL(16, 2) NOT NULL COMMENT '現金 + 評価額（円）',
+  `open_positions` INT UNSIGNED NOT NULL COMMENT '保有ポジション数',
+  `realized_pnl` DECIMAL(16, 2) NOT NULL COMMENT '開始からの確定損益の累計（円）',
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created_at',
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'updated_at',
+  PRIMARY KEY (`account`, `date`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,2 @@
+ALTER TABLE analyze_stock_brand_price_history
+    DROP INDEX uk_analyze_stock_brand_price_history_signal_key;
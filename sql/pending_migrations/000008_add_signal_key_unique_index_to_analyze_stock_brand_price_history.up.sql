@@ -0,0 +1,4 @@
+-- シグナル取り込み（UpsertByKey）が (method, ticker_symbol, created_at, action) で衝突を判定できるよう一意制約を張る。
+-- 既に同じキーの行が複数あると Duplicate entry で失敗する。適用前に readme の確認クエリで重複を洗い出し、残す行を決めて整理しておくこと。
+ALTER TABLE analyze_stock_brand_price_history
+    ADD UNIQUE INDEX uk_analyze_stock_brand_price_history_signal_key (method, ticker_symbol, created_at, action);
@@ -0,0 +1,8 @@
+ALTER TABLE `analyze_stock_brand_price_history`
+  DROP INDEX idx_analyze_stock_brand_price_history_closed_at,
+  DROP COLUMN `holding_return`,
+  DROP COLUMN `exit_price`,
+  DROP COLUMN `closed_at`,
+  DROP COLUMN `marked_at`,
+  DROP COLUMN `price_difference`,
+  DROP COLUMN `current_price`;
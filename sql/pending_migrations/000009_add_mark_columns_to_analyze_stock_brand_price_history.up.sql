@@ -0,0 +1,9 @@
+-- mark_analyze_signals_v1 の値洗い・N 営業日クローズの結果を保存する。既存の行はすべて NULL（未値洗い・建玉中）になる
+ALTER TABLE `analyze_stock_brand_price_history`
+  ADD COLUMN `current_price` DECIMAL(10, 4) DEFAULT NULL COMMENT '値洗い価格（marked_at の終値を分割・併合調整して trade_price と同じ株数基準にしたもの）' AFTER `signal_rank`,
+  ADD COLUMN `price_difference` DECIMAL(10, 4) DEFAULT NULL COMMENT 'current_price - trade_price' AFTER `current_price`,
+  ADD COLUMN `marked_at` DATE DEFAULT NULL COMMENT 'current_price の基準日（値洗いに使った日足の日付）。未値洗いは NULL' AFTER `price_difference`,
+  ADD COLUMN `closed_at` DATE DEFAULT NULL COMMENT 'クローズ日（シグナル日から N 営業日目）。NULL なら建玉中' AFTER `marked_at`,
+  ADD COLUMN `exit_price` DECIMAL(10, 4) DEFAULT NULL COMMENT 'クローズ日の終値（分割・併合調整済み）' AFTER `closed_at`,
+  ADD COLUMN `holding_return` DECIMAL(12, 6) DEFAULT NULL COMMENT '保有リターン（Buy: exit/trade-1、Sell: 1-exit/trade）' AFTER `exit_price`,
+  ADD INDEX idx_analyze_stock_brand_price_history_closed_at (`closed_at`, `created_at`);
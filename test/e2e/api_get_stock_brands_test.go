@@ -63,6 +63,7 @@ func TestE2E_GetStockBrands(t *testing.T) {
 		dailyPriceForAnalyzeRepo,
 		database.NewFinAnnouncementRepositoryImpl(db),
 		database.NewFinStatementRepositoryImpl(db),
+		database.NewRelativeStrengthRepositoryImpl(db),
 		mockStockAPI,
 		redisClient,
 	)
@@ -80,7 +81,7 @@ func TestE2E_GetStockBrands(t *testing.T) {
 	httpServer := driver.NewHTTPServer()
 	stockBrandHandler := handler.NewStockBrandHandler(stockBrandInteractor, httpServer, zap.NewNop())
 	stockPriceHandler := handler.NewStockPriceHandler(dailyPriceInteractor, httpServer, zap.NewNop())
-	mux := router.NewRouter(stockPriceHandler, stockBrandHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	mux := router.NewRouter(stockPriceHandler, stockBrandHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	ts := httptest.NewServer(mux)
 	defer ts.Close()
 
@@ -91,7 +91,7 @@ func TestE2E_DailyStockPicks(t *testing.T) {
 		Return("1234.5678", nil).
 		AnyTimes()
 
-	createInteractor := usecase.NewCreateDailyStockPicksInteractor(tx, priceRepo, stockBrandRepo, pickRepo, mockSlackAPI)
+	createInteractor := usecase.NewCreateDailyStockPicksInteractor(tx, priceRepo, stockBrandRepo, pickRepo, database.NewMarketRegimeRepositoryImpl(db), database.NewAnalyzeStockBrandPriceHistoryRepositoryImpl(db), mockSlackAPI)
 	createCmd := commands.NewCreateDailyStockPicksV1Command(createInteractor)
 	evaluateInteractor := usecase.NewEvaluateDailyStockPicksInteractor(tx, pickRepo, priceRepo, splitRepo, consolidationRepo)
 	evaluateCmd := commands.NewEvaluateDailyStockPicksV1Command(evaluateInteractor)
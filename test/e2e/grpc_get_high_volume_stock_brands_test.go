@@ -32,7 +32,7 @@ func TestE2E_GetHighVolumeStockBrands(t *testing.T) {
 	// 2. Setup Server Components
 	repo := database.NewHighVolumeStockBrandRepositoryImpl(db)
 	uc := usecase.NewGetHighVolumeStockBrandsUseCase(repo)
-	srv := server.NewStockServiceServer(uc)
+	srv := server.NewStockServiceServer(uc, nil)
 
 	// 3. Setup gRPC Server with bufconn
 	lis := bufconn.Listen(bufSize)
@@ -123,6 +123,7 @@ func TestE2E_UpdateStockBrands(t *testing.T) {
 				sbDailyAnalyzeRepo,
 				database.NewFinAnnouncementRepositoryImpl(db),
 				database.NewFinStatementRepositoryImpl(db),
+				database.NewRelativeStrengthRepositoryImpl(db),
 				mockStockAPI,
 				redisClient,
 			)
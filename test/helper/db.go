@@ -74,6 +74,18 @@ func SetupTestDB(t *testing.T) (*gorm.DB, func()) {
 		executeSQLFile(t, testDB, migrationFile, dbName)
 	}
 
+	// stock-price-db へ未反映の sql/pending_migrations を番号順に適用（反映済みのものは submodule 側を使う）
+	pendingFiles, err := filepath.Glob(filepath.Join(baseDir, "../../sql/pending_migrations", "*.up.sql"))
+	require.NoError(t, err)
+	sort.Strings(pendingFiles)
+
+	for _, pendingFile := range pendingFiles {
+		if migrationApplied(migrationFiles, pendingFile) {
+			continue
+		}
+		executeSQLFile(t, testDB, pendingFile, dbName)
+	}
+
 	cleanup := func() {
 		// DB接続を閉じる
 		sqlDB, err := testDB.DB()
@@ -136,6 +148,20 @@ func TruncateAllTables(t *testing.T, db *gorm.DB) {
 	require.NoError(t, err)
 }
 
+// migrationApplied pendingFile と同じ名前（連番を除いた部分）の migration が migrationFiles にあるか。
+func migrationApplied(migrationFiles []string, pendingFile string) bool {
+	_, name, ok := strings.Cut(filepath.Base(pendingFile), "_")
+	if !ok {
+		return false
+	}
+	for _, f := range migrationFiles {
+		if strings.HasSuffix(filepath.Base(f), "_"+name) {
+			return true
+		}
+	}
+	return false
+}
+
 func executeSQLFile(t *testing.T, db *gorm.DB, filePath string, dbName string) {
 	sqlBytes, err := os.ReadFile(filePath)
 	require.NoError(t, err)
@@ -25,6 +25,14 @@ type TestRunnerOptions struct {
 	CreateQuizDailyUniverseV1Command                 *commands.CreateQuizDailyUniverseV1Command
 	EvaluateDailyStockPicksV1Command                 *commands.EvaluateDailyStockPicksV1Command
 	CreateDailyStockPicksV1Command                   *commands.CreateDailyStockPicksV1Command
+	OptimizeStrategyParamsV1Command                  *commands.OptimizeStrategyParamsV1Command
+	CalculateRelativeStrengthV1Command               *commands.CalculateRelativeStrengthV1Command
+	CalculateMarketBreadthV1Command                  *commands.CalculateMarketBreadthV1Command
+	ClassifyMarketRegimeV1Command                    *commands.ClassifyMarketRegimeV1Command
+	ReplayDailyStockPicksV1Command                   *commands.ReplayDailyStockPicksV1Command
+	RunPaperTradingV1Command                         *commands.RunPaperTradingV1Command
+	NotifyPaperPortfolioV1Command                    *commands.NotifyPaperPortfolioV1Command
+	MarkAnalyzeSignalsV1Command                      *commands.MarkAnalyzeSignalsV1Command
 	IndexInteractor                                  usecase.IndexInteractor
 	SlackAPIClient                                   gateway.SlackAPIClient
 	MySQLDumpClient                                  gateway.MySQLDumpClient
@@ -72,6 +80,7 @@ func NewTestRunner(opts TestRunnerOptions) *cli.Runner {
 		opts.SyncFinStatementsAllStocksCommand = commands.NewSyncFinStatementsAllStocksCommand(nil)
 	}
 	applyQuizCommandDefaults(&opts)
+	applyStrategyCommandDefaults(&opts)
 
 	return cli.NewRunner(
 		opts.HealthCheckCommand,
@@ -91,6 +100,14 @@ func NewTestRunner(opts TestRunnerOptions) *cli.Runner {
 		opts.CreateQuizDailyUniverseV1Command,
 		opts.EvaluateDailyStockPicksV1Command,
 		opts.CreateDailyStockPicksV1Command,
+		opts.OptimizeStrategyParamsV1Command,
+		opts.CalculateRelativeStrengthV1Command,
+		opts.CalculateMarketBreadthV1Command,
+		opts.ClassifyMarketRegimeV1Command,
+		opts.ReplayDailyStockPicksV1Command,
+		opts.RunPaperTradingV1Command,
+		opts.NotifyPaperPortfolioV1Command,
+		opts.MarkAnalyzeSignalsV1Command,
 		opts.IndexInteractor,
 		opts.SlackAPIClient,
 	)
@@ -110,3 +127,30 @@ func applyQuizCommandDefaults(opts *TestRunnerOptions) {
 		opts.CreateDailyStockPicksV1Command = commands.NewCreateDailyStockPicksV1Command(nil)
 	}
 }
+
+func applyStrategyCommandDefaults(opts *TestRunnerOptions) {
+	if opts.OptimizeStrategyParamsV1Command == nil {
+		opts.OptimizeStrategyParamsV1Command = commands.NewOptimizeStrategyParamsV1Command(nil)
+	}
+	if opts.CalculateRelativeStrengthV1Command == nil {
+		opts.CalculateRelativeStrengthV1Command = commands.NewCalculateRelativeStrengthV1Command(nil)
+	}
+	if opts.CalculateMarketBreadthV1Command == nil {
+		opts.CalculateMarketBreadthV1Command = commands.NewCalculateMarketBreadthV1Command(nil)
+	}
+	if opts.ClassifyMarketRegimeV1Command == nil {
+		opts.ClassifyMarketRegimeV1Command = commands.NewClassifyMarketRegimeV1Command(nil)
+	}
+	if opts.ReplayDailyStockPicksV1Command == nil {
+		opts.ReplayDailyStockPicksV1Command = commands.NewReplayDailyStockPicksV1Command(nil)
+	}
+	if opts.RunPaperTradingV1Command == nil {
+		opts.RunPaperTradingV1Command = commands.NewRunPaperTradingV1Command(nil)
+	}
+	if opts.NotifyPaperPortfolioV1Command == nil {
+		opts.NotifyPaperPortfolioV1Command = commands.NewNotifyPaperPortfolioV1Command(nil)
+	}
+	if opts.MarkAnalyzeSignalsV1Command == nil {
+		opts.MarkAnalyzeSignalsV1Command = commands.NewMarkAnalyzeSignalsV1Command(nil)
+	}
+}
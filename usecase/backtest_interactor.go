@@ -3,6 +3,7 @@ package usecase
 
 import (
 	"context"
+	"math/rand"
 	"sort"
 	"time"
 
@@ -14,10 +15,6 @@ import (
 	"github.com/Code0716/stock-price-repository/util"
 )
 
-// minBacktestDays 全戦略の指標ウォームアップに必要な最低日数の目安。
-// これ未満は取引0件の結果（空）を返す。
-const minBacktestDays = 80
-
 type backtestInteractorImpl struct {
 	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
 }
@@ -25,6 +22,11 @@ type backtestInteractorImpl struct {
 type BacktestInteractor interface {
 	// GetBacktestComparison 指定銘柄・期間で全戦略をバックテストし、トータルリターン降順で返す。
 	GetBacktestComparison(ctx context.Context, symbol string, from, to *time.Time, params models.BacktestParams) (*models.BacktestComparison, error)
+	// GetBacktestMonteCarlo 指定銘柄・戦略のトレードリストをモンテカルロで再標本化し、成績のばらつきを返す。
+	// mc.Seed が 0 の場合は現在時刻から採番し、結果の MonteCarlo.Seed に記録する。
+	GetBacktestMonteCarlo(ctx context.Context, symbol, strategy string, from, to *time.Time, params models.BacktestParams, mc models.BacktestMonteCarloParams) (*models.BacktestMonteCarlo, error)
+	// ListStrategies 登録済み戦略のメタデータを登録順で返す。
+	ListStrategies(ctx context.Context) []models.StrategyInfo
 }
 
 func NewBacktestInteractor(
@@ -36,15 +38,9 @@ func NewBacktestInteractor(
 }
 
 func (b *backtestInteractorImpl) GetBacktestComparison(ctx context.Context, symbol string, from, to *time.Time, params models.BacktestParams) (*models.BacktestComparison, error) {
-	order := models.SortOrderAsc
-	prices, err := b.stockBrandsDailyStockPriceRepository.ListDailyPricesBySymbol(ctx, models.ListDailyPricesBySymbolFilter{
-		TickerSymbol: symbol,
-		DateFrom:     from,
-		DateTo:       to,
-		DateOrder:    &order,
-	})
+	prices, err := b.listPrices(ctx, symbol, from, to)
 	if err != nil {
-		return nil, errors.Wrap(err, "ListDailyPricesBySymbol error")
+		return nil, err
 	}
 
 	comparison := &models.BacktestComparison{
@@ -58,32 +54,12 @@ func (b *backtestInteractorImpl) GetBacktestComparison(ctx context.Context, symb
 		comparison.To = prices[len(prices)-1].Date.Format(util.DateLayout)
 	}
 
-	exitParams := domain_service.ExitParams{
-		TakeProfit:     params.TakeProfit,
-		StopLoss:       params.StopLoss,
-		MaxHoldDays:    params.MaxHoldDays,
-		CommissionRate: params.CommissionRate,
-		SlippageRate:   params.SlippageRate,
-	}
-
-	for _, strategy := range domain_service.StrategyOrder {
-		var result models.BacktestResult
-		if len(prices) >= minBacktestDays {
-			signals := domain_service.EntrySignalsByStrategy(strategy, prices)
-			// exitMode=signal のとき戦略固有の反転シグナルを渡す。それ以外は nil（従来動作）。
-			var exitSignals []bool
-			if params.ExitMode == models.ExitModeSignal {
-				exitSignals = domain_service.ExitSignalsByStrategy(strategy, prices)
-			}
-			result = domain_service.RunBacktest(prices, signals, exitSignals, exitParams)
-		} else {
-			// データ不足時は空結果（取引0件）
-			result = models.BacktestResult{Equity: []models.BacktestEquityPoint{}, TradeList: []models.BacktestTrade{}}
-		}
+	for _, strategy := range domain_service.RegisteredStrategies() {
 		comparison.Strategies = append(comparison.Strategies, models.StrategyBacktest{
-			Strategy: strategy,
-			Label:    domain_service.StrategyLabels[strategy],
-			Result:   result,
+			Strategy: strategy.ID(),
+			Label:    strategy.Label(),
+			Side:     strategy.Side(),
+			Result:   runStrategyBacktest(strategy.ID(), prices, params),
 		})
 	}
 
@@ -94,3 +70,80 @@ func (b *backtestInteractorImpl) GetBacktestComparison(ctx context.Context, symb
 
 	return comparison, nil
 }
+
+func (b *backtestInteractorImpl) GetBacktestMonteCarlo(ctx context.Context, symbol, strategy string, from, to *time.Time, params models.BacktestParams, mc models.BacktestMonteCarloParams) (*models.BacktestMonteCarlo, error) {
+	prices, err := b.listPrices(ctx, symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if mc.Seed == 0 {
+		mc.Seed = time.Now().UnixNano()
+	}
+	backtest := runStrategyBacktest(strategy, prices, params)
+	result := domain_service.RunMonteCarlo(backtest.TradeList, mc, rand.New(rand.NewSource(mc.Seed)))
+	result.Symbol = symbol
+	result.Strategy = strategy
+	result.Label = domain_service.StrategyLabel(strategy)
+	result.Params = params
+	if len(prices) > 0 {
+		result.From = prices[0].Date.Format(util.DateLayout)
+		result.To = prices[len(prices)-1].Date.Format(util.DateLayout)
+	}
+	return &result, nil
+}
+
+func (b *backtestInteractorImpl) ListStrategies(ctx context.Context) []models.StrategyInfo {
+	return domain_service.StrategyInfos()
+}
+
+func (b *backtestInteractorImpl) listPrices(ctx context.Context, symbol string, from, to *time.Time) ([]*models.StockBrandDailyPrice, error) {
+	order := models.SortOrderAsc
+	prices, err := b.stockBrandsDailyStockPriceRepository.ListDailyPricesBySymbol(ctx, models.ListDailyPricesBySymbolFilter{
+		TickerSymbol: symbol,
+		DateFrom:     from,
+		DateTo:       to,
+		DateOrder:    &order,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListDailyPricesBySymbol error")
+	}
+	return prices, nil
+}
+
+// newExitParams BacktestParams から strategy 用の ExitParams を組み立てる（売買方向は戦略から決まる）。
+func newExitParams(strategy string, params models.BacktestParams) domain_service.ExitParams {
+	return domain_service.ExitParams{
+		TakeProfit:     params.TakeProfit,
+		StopLoss:       params.StopLoss,
+		MaxHoldDays:    params.MaxHoldDays,
+		CommissionRate: params.CommissionRate,
+		SlippageRate:   params.SlippageRate,
+
+		EntryTiming:      params.EntryTiming,
+		ExitFill:         params.ExitFill,
+		IntrabarPriority: params.IntrabarPriority,
+		TickRounding:     params.TickRounding,
+		Side:             domain_service.StrategySide(strategy),
+		BorrowRate:       params.BorrowRate,
+	}
+}
+
+// exitSignalsFor exitMode=signal のとき戦略固有の反転シグナルを返す。それ以外は nil（従来動作）。
+func exitSignalsFor(strategy string, prices []*models.StockBrandDailyPrice, params models.BacktestParams) []bool {
+	if params.ExitMode == models.ExitModeSignal {
+		return domain_service.ExitSignalsByStrategy(strategy, prices)
+	}
+	return nil
+}
+
+// runStrategyBacktest 1戦略のバックテストを実行する。戦略のウォームアップ本数に満たなければ空結果（取引0件）。
+func runStrategyBacktest(strategy string, prices []*models.StockBrandDailyPrice, params models.BacktestParams) models.BacktestResult {
+	if len(prices) < domain_service.StrategyMinHistoryBars(strategy) {
+		return models.BacktestResult{Equity: []models.BacktestEquityPoint{}, TradeList: []models.BacktestTrade{}}
+	}
+	exitParams := newExitParams(strategy, params)
+	signals := domain_service.EntrySignalsByStrategy(strategy, prices)
+	exitSignals := exitSignalsFor(strategy, prices, params)
+	return domain_service.RunBacktest(prices, signals, exitSignals, exitParams)
+}
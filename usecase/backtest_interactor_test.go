@@ -60,7 +60,7 @@ func TestBacktestInteractor_GetBacktestComparison(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "7203", got.Symbol)
 		assert.Equal(t, 120, got.TradingDays)
-		assert.Len(t, got.Strategies, len(domain_service.StrategyOrder))
+		assert.Len(t, got.Strategies, len(domain_service.StrategyIDs()))
 		assert.Equal(t, "2021-01-04", got.From)
 		// ランキング: 隣接でトータルリターンが降順
 		for i := 1; i < len(got.Strategies); i++ {
@@ -77,12 +77,12 @@ func TestBacktestInteractor_GetBacktestComparison(t *testing.T) {
 		defer ctrl.Finish()
 
 		repo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
-		repo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), wantFilter).Return(genPrices(30), nil)
+		repo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), wantFilter).Return(genPrices(10), nil)
 
 		interactor := NewBacktestInteractor(repo)
 		got, err := interactor.GetBacktestComparison(context.Background(), "7203", &from, &to, params)
 		assert.NoError(t, err)
-		assert.Equal(t, 30, got.TradingDays)
+		assert.Equal(t, 10, got.TradingDays)
 		for _, s := range got.Strategies {
 			assert.Equal(t, 0, s.Result.Trades)
 		}
@@ -100,3 +100,55 @@ func TestBacktestInteractor_GetBacktestComparison(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestBacktestInteractor_GetBacktestMonteCarlo(t *testing.T) {
+	params := models.BacktestParams{
+		TakeProfit:  decimal.NewFromFloat(0.05),
+		StopLoss:    decimal.NewFromFloat(0.05),
+		MaxHoldDays: 5,
+	}
+	mc := models.BacktestMonteCarloParams{Iterations: 200, Seed: 1, RuinThreshold: decimal.NewFromFloat(0.5)}
+
+	t.Run("正常系: 戦略のトレードリストを再標本化する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		repo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(genPrices(300), nil)
+
+		got, err := NewBacktestInteractor(repo).GetBacktestMonteCarlo(context.Background(), "7203", domain_service.StrategyMovingAverageCross, nil, nil, params, mc)
+		assert.NoError(t, err)
+		assert.Equal(t, "7203", got.Symbol)
+		assert.Equal(t, domain_service.StrategyLabel(domain_service.StrategyMovingAverageCross), got.Label)
+		assert.Equal(t, "2021-01-04", got.From)
+		assert.Equal(t, int64(1), got.MonteCarlo.Seed)
+		assert.Equal(t, models.MonteCarloMethodBootstrap, got.Bootstrap.Method)
+		assert.Equal(t, models.MonteCarloMethodPermutation, got.Permutation.Method)
+	})
+
+	t.Run("シード未指定は採番して記録する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		repo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(genPrices(30), nil)
+
+		noSeed := mc
+		noSeed.Seed = 0
+		got, err := NewBacktestInteractor(repo).GetBacktestMonteCarlo(context.Background(), "7203", domain_service.StrategyMACDBullish, nil, nil, params, noSeed)
+		assert.NoError(t, err)
+		assert.NotZero(t, got.MonteCarlo.Seed)
+		assert.Equal(t, 0, got.Trades)
+	})
+
+	t.Run("異常系: 日足取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		repo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewBacktestInteractor(repo).GetBacktestMonteCarlo(context.Background(), "7203", domain_service.StrategyMACDBullish, nil, nil, params, mc)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,98 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// ErrInvalidCalibrationSource source が picks / signals のいずれでもない。
+var ErrInvalidCalibrationSource = errors.New("invalid calibration source")
+
+// ErrCalibrationMethodRequired source=signals で method が指定されていない。手法ごとにスコアの尺度が異なるため混ぜて検証しない。
+var ErrCalibrationMethodRequired = errors.New("calibration method is required for signals")
+
+// CalibrationInteractor スコア較正（スコアが高いほど成績が良いか）の検証
+type CalibrationInteractor interface {
+	// GetCalibration 期間内の推奨またはシグナルについて、horizon ごとにスコア十分位の成績・単調性・十分位スプレッド・
+	// 足切り別の的中率・日次順位 IC を返す。picks は保存済みの1/3/5営業日後リターン、signals は 5/10/20 営業日後リターンを使う。
+	// signals は手法ごとにスコアの尺度が異なるため filter.Method が必須。
+	GetCalibration(ctx context.Context, filter *models.CalibrationFilter) (*models.Calibration, error)
+}
+
+type calibrationInteractorImpl struct {
+	dailyStockPickRepository repositories.DailyStockPickRepository
+	analyzeRepo              repositories.AnalyzeStockBrandPriceHistoryRepository
+	priceRepo                repositories.StockBrandsDailyPriceRepository
+}
+
+// NewCalibrationInteractor コンストラクタ
+func NewCalibrationInteractor(
+	dailyStockPickRepository repositories.DailyStockPickRepository,
+	analyzeRepo repositories.AnalyzeStockBrandPriceHistoryRepository,
+	priceRepo repositories.StockBrandsDailyPriceRepository,
+) CalibrationInteractor {
+	return &calibrationInteractorImpl{
+		dailyStockPickRepository: dailyStockPickRepository,
+		analyzeRepo:              analyzeRepo,
+		priceRepo:                priceRepo,
+	}
+}
+
+func (ci *calibrationInteractorImpl) GetCalibration(ctx context.Context, filter *models.CalibrationFilter) (*models.Calibration, error) {
+	out := &models.Calibration{
+		Source:   filter.Source,
+		From:     filter.From,
+		To:       filter.To,
+		Horizons: []*models.CalibrationHorizon{},
+	}
+
+	switch filter.Source {
+	case models.CalibrationSourcePicks:
+		scoreVersion := filter.ScoreVersion
+		if scoreVersion == "" {
+			scoreVersion = domain_service.DailyPickScoreVersion
+		}
+		out.ScoreVersion = scoreVersion
+		picks, err := ci.dailyStockPickRepository.ListByDateRange(ctx, &filter.From, &filter.To, scoreVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "ListByDateRange error")
+		}
+		for _, h := range domain_service.DailyPickCalibrationHorizons {
+			out.Horizons = append(out.Horizons, domain_service.AnalyzeScoreCalibration(domain_service.DailyPickCalibrationSamples(picks, h), h))
+		}
+
+	case models.CalibrationSourceSignals:
+		if filter.Method == "" {
+			return nil, ErrCalibrationMethodRequired
+		}
+		out.Method = filter.Method
+		signals, err := ci.analyzeRepo.FindByCreatedAtRange(ctx, &models.SignalPerformanceFilter{
+			From:   filter.From,
+			To:     filter.To,
+			Method: filter.Method,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "FindByCreatedAtRange error")
+		}
+		var evaluated []*models.EvaluatedSignal
+		if len(signals) > 0 {
+			evaluated, _, err = evaluateSignals(ctx, ci.priceRepo, signals, filter.From, filter.To)
+			if err != nil {
+				return nil, errors.Wrap(err, "evaluateSignals error")
+			}
+		}
+		for _, h := range signalPerformanceHorizons {
+			out.Horizons = append(out.Horizons, domain_service.AnalyzeScoreCalibration(domain_service.SignalCalibrationSamples(evaluated, h), h))
+		}
+
+	default:
+		return nil, errors.Wrapf(ErrInvalidCalibrationSource, "source=%s", filter.Source)
+	}
+	return out, nil
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestCalibrationInteractorImpl_GetCalibration(t *testing.T) {
+	from := spDate(2026, 4, 1)
+	to := spDate(2026, 6, 30)
+
+	type fields struct {
+		pickRepo    func(ctrl *gomock.Controller) *mock_repositories.MockDailyStockPickRepository
+		analyzeRepo func(ctrl *gomock.Controller) *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository
+		priceRepo   func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandsDailyPriceRepository
+	}
+	tests := []struct {
+		name    string
+		filter  *models.CalibrationFilter
+		fields  fields
+		wantErr error
+		check   func(t *testing.T, got *models.Calibration)
+	}{
+		{
+			name:   "正常系: picks は現行バージョンの推奨を1/3/5日で検証する",
+			filter: &models.CalibrationFilter{Source: models.CalibrationSourcePicks, From: from, To: to},
+			fields: fields{
+				pickRepo: func(ctrl *gomock.Controller) *mock_repositories.MockDailyStockPickRepository {
+					picks := make([]*models.DailyStockPick, 0, 10)
+					for i := 0; i < 10; i++ {
+						r := decimal.NewFromInt(int64(i - 3)).Div(decimal.NewFromInt(100))
+						picks = append(picks, &models.DailyStockPick{PickDate: spDate(2026, 5, 1), Score: decimal.NewFromInt(int64(50 + i)), Return5D: &r})
+					}
+					m := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+					m.EXPECT().ListByDateRange(gomock.Any(), &from, &to, domain_service.DailyPickScoreVersion).Return(picks, nil)
+					return m
+				},
+			},
+			check: func(t *testing.T, got *models.Calibration) {
+				assert.Equal(t, domain_service.DailyPickScoreVersion, got.ScoreVersion)
+				if assert.Len(t, got.Horizons, 3) {
+					assert.Equal(t, 0, got.Horizons[0].SampleCount, "1日後リターンは未確定")
+					h5 := got.Horizons[2]
+					assert.Equal(t, 5, h5.Horizon)
+					assert.Len(t, h5.Deciles, 10)
+					assert.True(t, h5.Monotonicity.Monotonic)
+					assert.Equal(t, "1", h5.IC.Mean.String())
+				}
+			},
+		},
+		{
+			name:   "正常系: signals はシグナル日の終値から5/10/20営業日後リターンを出して検証する",
+			filter: &models.CalibrationFilter{Source: models.CalibrationSourceSignals, From: from, To: to, Method: "m"},
+			fields: fields{
+				analyzeRepo: func(ctrl *gomock.Controller) *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository {
+					signals := make([]*models.AnalyzeStockBrandPriceHistory, 0, 10)
+					for i := 0; i < 10; i++ {
+						sg := spSignal(fmt.Sprintf("%d", 1000+i), "m", models.AnalyzeStockBrandPriceHistoryActionBuy, spDate(2026, 5, 1))
+						score := decimal.NewFromInt(int64(i))
+						sg.Score = &score
+						signals = append(signals, sg)
+					}
+					m := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+					m.EXPECT().FindByCreatedAtRange(gomock.Any(), &models.SignalPerformanceFilter{From: from, To: to, Method: "m"}).Return(signals, nil)
+					return m
+				},
+				priceRepo: func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandsDailyPriceRepository {
+					// スコアが高い銘柄ほど値上がりが大きい
+					var prices []*models.StockBrandDailyPrice
+					for i := 0; i < 10; i++ {
+						for d := 0; d <= 20; d++ {
+							prices = append(prices, spPrice(fmt.Sprintf("%d", 1000+i), spDate(2026, 5, 1+d), 1000+float64(i*d)))
+						}
+					}
+					m := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+					m.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(prices, nil)
+					return m
+				},
+			},
+			check: func(t *testing.T, got *models.Calibration) {
+				assert.Equal(t, "m", got.Method)
+				assert.Empty(t, got.ScoreVersion)
+				if assert.Len(t, got.Horizons, 3) {
+					for _, h := range got.Horizons {
+						assert.Equal(t, 10, h.SampleCount)
+						assert.Equal(t, 1, h.IC.Days)
+						assert.True(t, h.DecileSpread.IsPositive())
+					}
+					assert.Equal(t, []int{5, 10, 20}, []int{got.Horizons[0].Horizon, got.Horizons[1].Horizon, got.Horizons[2].Horizon})
+				}
+			},
+		},
+		{
+			name:   "正常系: signals が0件なら価格を取りに行かない",
+			filter: &models.CalibrationFilter{Source: models.CalibrationSourceSignals, From: from, To: to, Method: "m"},
+			fields: fields{
+				analyzeRepo: func(ctrl *gomock.Controller) *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository {
+					m := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+					m.EXPECT().FindByCreatedAtRange(gomock.Any(), gomock.Any()).Return(nil, nil)
+					return m
+				},
+			},
+			check: func(t *testing.T, got *models.Calibration) {
+				if assert.Len(t, got.Horizons, 3) {
+					assert.Equal(t, 0, got.Horizons[0].SampleCount)
+				}
+			},
+		},
+		{
+			name:    "異常系: source が不正",
+			filter:  &models.CalibrationFilter{Source: "foo", From: from, To: to},
+			wantErr: ErrInvalidCalibrationSource,
+		},
+		{
+			name:    "異常系: signals で method が未指定",
+			filter:  &models.CalibrationFilter{Source: models.CalibrationSourceSignals, From: from, To: to},
+			wantErr: ErrCalibrationMethodRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+			if tt.fields.pickRepo != nil {
+				pickRepo = tt.fields.pickRepo(ctrl)
+			}
+			analyzeRepo := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+			if tt.fields.analyzeRepo != nil {
+				analyzeRepo = tt.fields.analyzeRepo(ctrl)
+			}
+			priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+			if tt.fields.priceRepo != nil {
+				priceRepo = tt.fields.priceRepo(ctrl)
+			}
+
+			got, err := NewCalibrationInteractor(pickRepo, analyzeRepo, priceRepo).GetCalibration(context.Background(), tt.filter)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.filter.Source, got.Source)
+			tt.check(t, got)
+		})
+	}
+}
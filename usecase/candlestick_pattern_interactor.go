@@ -0,0 +1,148 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+const (
+	// candlestickPatternDefaultYears from 省略時の対象期間（to から遡る年数）。
+	candlestickPatternDefaultYears = 1
+	// candlestickPatternWarmupDays 期間初日から判定できるよう前に余分に取得する暦日数（平均実体・トレンド判定用）。
+	candlestickPatternWarmupDays = 40
+)
+
+type candlestickPatternInteractorImpl struct {
+	stockBrandRepository                 repositories.StockBrandRepository
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
+}
+
+// CandlestickPatternInteractor ローソク足パターン検出のインターフェース。
+type CandlestickPatternInteractor interface {
+	// GetCandlestickPatterns 指定銘柄の期間内に出現したローソク足パターンと、パターン別の出現後リターン統計を返す。
+	// from 省略時は to（省略時は現在）から1年前を起点とする。
+	GetCandlestickPatterns(ctx context.Context, symbol string, from, to *time.Time) (*models.CandlestickPatterns, error)
+	// ScreenCandlestickPatterns date（省略時は当日）の日足で成立したパターンをユニバース（主要市場の全銘柄）から探す。
+	ScreenCandlestickPatterns(ctx context.Context, date *time.Time) (*models.CandlestickPatternScreening, error)
+}
+
+func NewCandlestickPatternInteractor(
+	stockBrandRepository repositories.StockBrandRepository,
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
+) CandlestickPatternInteractor {
+	return &candlestickPatternInteractorImpl{
+		stockBrandRepository:                 stockBrandRepository,
+		stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
+	}
+}
+
+func (c *candlestickPatternInteractorImpl) GetCandlestickPatterns(ctx context.Context, symbol string, from, to *time.Time) (*models.CandlestickPatterns, error) {
+	dateTo := time.Now()
+	if to != nil {
+		dateTo = *to
+	}
+	dateFrom := dateTo.AddDate(-candlestickPatternDefaultYears, 0, 0)
+	if from != nil {
+		dateFrom = *from
+	}
+
+	// 期間末のパターンも horizon 後のリターンを評価できるよう、シグナル成績と同じだけ先まで取得する
+	priceFrom := dateFrom.AddDate(0, 0, -candlestickPatternWarmupDays)
+	priceTo := dateTo.AddDate(0, 0, signalPerformancePriceLookAhead)
+	order := models.SortOrderAsc
+	prices, err := c.stockBrandsDailyStockPriceRepository.ListDailyPricesBySymbol(ctx, models.ListDailyPricesBySymbolFilter{
+		TickerSymbol: symbol,
+		DateFrom:     &priceFrom,
+		DateTo:       &priceTo,
+		DateOrder:    &order,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListDailyPricesBySymbol error")
+	}
+
+	fromDay, toDay := dateFrom.Format(util.DateLayout), dateTo.Format(util.DateLayout)
+	hits := make([]*models.CandlestickPatternHit, 0)
+	for _, hit := range domain_service.DetectCandlestickPatterns(prices) {
+		day := hit.Date.Format(util.DateLayout)
+		if day < fromDay || day > toDay {
+			continue
+		}
+		hits = append(hits, hit)
+	}
+
+	return &models.CandlestickPatterns{
+		Symbol:   symbol,
+		From:     dateFrom,
+		To:       dateTo,
+		Horizons: signalPerformanceHorizons,
+		Patterns: hits,
+		Stats:    domain_service.CandlestickPatternStatistics(prices, hits, signalPerformanceHorizons),
+	}, nil
+}
+
+func (c *candlestickPatternInteractorImpl) ScreenCandlestickPatterns(ctx context.Context, date *time.Time) (*models.CandlestickPatternScreening, error) {
+	target := time.Now()
+	if date != nil {
+		target = *date
+	}
+	targetDay := target.Format(util.DateLayout)
+
+	brands, err := c.stockBrandRepository.FindAllMainMarkets(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindAllMainMarkets error")
+	}
+	names := make(map[string]string, len(brands))
+	symbols := make([]string, 0, len(brands))
+	for _, b := range brands {
+		names[b.TickerSymbol] = b.Name
+		symbols = append(symbols, b.TickerSymbol)
+	}
+
+	pricesBySymbol, err := listPricesBySymbols(ctx, c.stockBrandsDailyStockPriceRepository, symbols, target.AddDate(0, 0, -candlestickPatternWarmupDays), target)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*models.CandlestickPatternScreeningItem, 0)
+	for _, symbol := range symbols {
+		prices := pricesBySymbol[symbol]
+		if len(prices) == 0 || prices[len(prices)-1].Date.Format(util.DateLayout) != targetDay {
+			continue
+		}
+		for _, hit := range domain_service.DetectCandlestickPatterns(prices) {
+			if hit.Date.Format(util.DateLayout) != targetDay {
+				continue
+			}
+			items = append(items, &models.CandlestickPatternScreeningItem{
+				TickerSymbol: symbol,
+				Name:         names[symbol],
+				Pattern:      hit.Pattern,
+				Label:        hit.Label,
+				Direction:    hit.Direction,
+				Strength:     hit.Strength,
+			})
+		}
+	}
+
+	// 強度の高い順（同値は銘柄コード順）
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].Strength.Equal(items[j].Strength) {
+			return items[i].Strength.GreaterThan(items[j].Strength)
+		}
+		return items[i].TickerSymbol < items[j].TickerSymbol
+	})
+
+	return &models.CandlestickPatternScreening{
+		Date:  target,
+		Items: items,
+	}, nil
+}
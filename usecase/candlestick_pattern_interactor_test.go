@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestCandlestickPatternInteractor_GetCandlestickPatterns(t *testing.T) {
+	// genPrices は始値=終値（十字線）が続き、10日ごとに終値が 109 → 100 へ下放れする
+	base := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	from := base.AddDate(0, 0, 20)
+	to := base.AddDate(0, 0, 29)
+
+	t.Run("正常系: 期間内のパターンと統計を返す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		priceFrom := from.AddDate(0, 0, -candlestickPatternWarmupDays)
+		priceTo := to.AddDate(0, 0, signalPerformancePriceLookAhead)
+		order := models.SortOrderAsc
+		prices := genPrices(70)
+		for _, p := range prices {
+			p.Adjclose = p.Close
+		}
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), models.ListDailyPricesBySymbolFilter{
+			TickerSymbol: "7203",
+			DateFrom:     &priceFrom,
+			DateTo:       &priceTo,
+			DateOrder:    &order,
+		}).Return(prices, nil)
+
+		got, err := NewCandlestickPatternInteractor(mock_repositories.NewMockStockBrandRepository(ctrl), priceRepo).
+			GetCandlestickPatterns(context.Background(), "7203", &from, &to)
+		require.NoError(t, err)
+
+		assert.Equal(t, signalPerformanceHorizons, got.Horizons)
+		counts := make(map[string]int)
+		for _, hit := range got.Patterns {
+			assert.False(t, hit.Date.Before(from) || hit.Date.After(to), hit.Date)
+			counts[hit.Pattern]++
+		}
+		assert.Equal(t, map[string]int{
+			models.CandlestickPatternLongLeggedDoji: 10,
+			models.CandlestickPatternGapDown:        1,
+		}, counts)
+
+		require.Len(t, got.Stats, 2)
+		assert.Equal(t, models.CandlestickPatternLongLeggedDoji, got.Stats[0].Pattern)
+		assert.Equal(t, 10, got.Stats[0].Count)
+		assert.Equal(t, 10, got.Stats[0].Stats[20].EvaluatedCount)
+		assert.Equal(t, models.CandlestickPatternGapDown, got.Stats[1].Pattern)
+	})
+
+	t.Run("異常系: 日足取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewCandlestickPatternInteractor(mock_repositories.NewMockStockBrandRepository(ctrl), priceRepo).
+			GetCandlestickPatterns(context.Background(), "7203", nil, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestCandlestickPatternInteractor_ScreenCandlestickPatterns(t *testing.T) {
+	date := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 29)
+
+	t.Run("正常系: 指定日に日足がある銘柄だけを対象にする", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return([]*models.StockBrand{
+			{TickerSymbol: "7203", Name: "トヨタ自動車"},
+			{TickerSymbol: "7267", Name: "本田技研工業"},
+		}, nil)
+		priceFrom := date.AddDate(0, 0, -candlestickPatternWarmupDays)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), models.ListRangePricesBySymbolsFilter{
+			Symbols:  []string{"7203", "7267"},
+			DateFrom: &priceFrom,
+			DateTo:   &date,
+		}).Return(append(genPricesFor("7203", 30), genPricesFor("7267", 20)...), nil)
+
+		got, err := NewCandlestickPatternInteractor(brandRepo, priceRepo).ScreenCandlestickPatterns(context.Background(), &date)
+		require.NoError(t, err)
+		assert.Equal(t, date, got.Date)
+		require.Len(t, got.Items, 1)
+		assert.Equal(t, "7203", got.Items[0].TickerSymbol)
+		assert.Equal(t, "トヨタ自動車", got.Items[0].Name)
+		assert.Equal(t, models.CandlestickPatternLongLeggedDoji, got.Items[0].Pattern)
+		assert.Equal(t, "100", got.Items[0].Strength.String())
+	})
+
+	t.Run("異常系: 銘柄取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewCandlestickPatternInteractor(brandRepo, mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)).
+			ScreenCandlestickPatterns(context.Background(), &date)
+		assert.Error(t, err)
+	})
+}
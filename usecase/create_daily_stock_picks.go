@@ -14,6 +14,7 @@ import (
 	"github.com/Code0716/stock-price-repository/infrastructure/gateway"
 	"github.com/Code0716/stock-price-repository/models"
 	"github.com/Code0716/stock-price-repository/repositories"
+	"github.com/Code0716/stock-price-repository/util"
 )
 
 const (
@@ -27,20 +28,25 @@ const (
 )
 
 type CreateDailyStockPicksInteractor interface {
-	// CreateDailyStockPicks 最新営業日の引け値でスクリーニングし、上位 topN を保存して Slack に通知する。
-	// 既に当日分が作成済みかつ全件通知済みなら何もせず正常終了する（冪等）。
+	// CreateDailyStockPicks 最新営業日の引け値で domain_service.DailyPickScoringConfigs の各スコア設定ごとにスクリーニングし、
+	// それぞれの上位 topN を保存する。Slack に通知するのは live（DailyPickScoreVersion）の推奨だけで、残りはシャドーとして保存のみ行う。
+	// 既に当日分の live が作成済みかつ全件通知済みなら何もせず正常終了する（冪等）。
 	// 作成済みだが未通知（前回 Slack 失敗）の場合は再通知のみ行う。force=true のときは既存を削除して作り直す。
 	// topN<=0 は dailyStockPickDefaultTopN、maxPerSector<0 は dailyStockPickDefaultMaxPerSector、
 	// concurrency<=0 は runtime.NumCPU() を使う。
-	CreateDailyStockPicks(ctx context.Context, now time.Time, topN, maxPerSector, concurrency int, force bool) error
+	// regimes を指定すると、最新営業日の市場局面がそのいずれかの日だけスクリーニングする（局面が未算出の日はエラーにする）。
+	// withConfluence=true のときだけ、分析シグナルの合流スコアを因子に使うスコア設定（シャドー）も走らせる。
+	CreateDailyStockPicks(ctx context.Context, now time.Time, topN, maxPerSector, concurrency int, regimes []string, withConfluence, force bool) error
 }
 
 type createDailyStockPicksInteractorImpl struct {
-	tx                                   repositories.Transaction
-	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
-	stockBrandRepository                 repositories.StockBrandRepository
-	dailyStockPickRepository             repositories.DailyStockPickRepository
-	slackAPIClient                       gateway.SlackAPIClient
+	tx                                      repositories.Transaction
+	stockBrandsDailyStockPriceRepository    repositories.StockBrandsDailyPriceRepository
+	stockBrandRepository                    repositories.StockBrandRepository
+	dailyStockPickRepository                repositories.DailyStockPickRepository
+	marketRegimeRepository                  repositories.MarketRegimeRepository
+	analyzeStockBrandPriceHistoryRepository repositories.AnalyzeStockBrandPriceHistoryRepository
+	slackAPIClient                          gateway.SlackAPIClient
 }
 
 func NewCreateDailyStockPicksInteractor(
@@ -48,18 +54,22 @@ func NewCreateDailyStockPicksInteractor(
 	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
 	stockBrandRepository repositories.StockBrandRepository,
 	dailyStockPickRepository repositories.DailyStockPickRepository,
+	marketRegimeRepository repositories.MarketRegimeRepository,
+	analyzeStockBrandPriceHistoryRepository repositories.AnalyzeStockBrandPriceHistoryRepository,
 	slackAPIClient gateway.SlackAPIClient,
 ) CreateDailyStockPicksInteractor {
 	return &createDailyStockPicksInteractorImpl{
-		tx:                                   tx,
-		stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
-		stockBrandRepository:                 stockBrandRepository,
-		dailyStockPickRepository:             dailyStockPickRepository,
-		slackAPIClient:                       slackAPIClient,
+		tx:                                      tx,
+		stockBrandsDailyStockPriceRepository:    stockBrandsDailyStockPriceRepository,
+		stockBrandRepository:                    stockBrandRepository,
+		dailyStockPickRepository:                dailyStockPickRepository,
+		marketRegimeRepository:                  marketRegimeRepository,
+		analyzeStockBrandPriceHistoryRepository: analyzeStockBrandPriceHistoryRepository,
+		slackAPIClient:                          slackAPIClient,
 	}
 }
 
-func (ci *createDailyStockPicksInteractorImpl) CreateDailyStockPicks(ctx context.Context, now time.Time, topN, maxPerSector, concurrency int, force bool) error {
+func (ci *createDailyStockPicksInteractorImpl) CreateDailyStockPicks(ctx context.Context, now time.Time, topN, maxPerSector, concurrency int, regimes []string, withConfluence, force bool) error {
 	if topN <= 0 {
 		topN = dailyStockPickDefaultTopN
 	}
@@ -79,7 +89,7 @@ func (ci *createDailyStockPicksInteractorImpl) CreateDailyStockPicks(ctx context
 	from := dates[len(dates)-1]
 
 	if !force {
-		existing, err := ci.dailyStockPickRepository.ListByPickDate(ctx, pickDate)
+		existing, err := ci.dailyStockPickRepository.ListByPickDate(ctx, pickDate, domain_service.DailyPickScoreVersion)
 		if err != nil {
 			return errors.Wrap(err, "ListByPickDate error")
 		}
@@ -88,16 +98,39 @@ func (ci *createDailyStockPicksInteractorImpl) CreateDailyStockPicks(ctx context
 		}
 	}
 
-	picks, err := ci.screen(ctx, pickDate, from, topN, maxPerSector, concurrency)
+	var configs []domain_service.DailyPickScoringConfig
+	for _, c := range domain_service.DailyPickScoringConfigs() {
+		if c.Weights.UsesConfluence() && !withConfluence {
+			continue
+		}
+		c.Filter.AllowedRegimes = regimes
+		configs = append(configs, c)
+	}
+	// 局面ゲートは live の設定で判定し、シャドーも同じ日だけ走らせる（live と同じ日同士で比べるため）
+	allowed, err := ci.allowedByRegime(ctx, pickDate, configs[0].Filter)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return nil
+	}
+
+	picksByVersion, err := ci.screen(ctx, pickDate, from, configs, topN, maxPerSector, concurrency)
 	if err != nil {
 		return err
 	}
+	var picks []*models.DailyStockPick
+	versions := make([]string, 0, len(configs))
+	for _, c := range configs {
+		picks = append(picks, picksByVersion[c.Version]...)
+		versions = append(versions, c.Version)
+	}
 	if len(picks) == 0 {
 		return nil
 	}
 
 	if err := ci.tx.DoInTx(ctx, func(ctx context.Context) error {
-		if err := ci.dailyStockPickRepository.DeleteByPickDate(ctx, pickDate); err != nil {
+		if err := ci.dailyStockPickRepository.DeleteByPickDate(ctx, pickDate, versions); err != nil {
 			return errors.Wrap(err, "DeleteByPickDate error")
 		}
 		if err := ci.dailyStockPickRepository.BulkCreate(ctx, picks); err != nil {
@@ -108,7 +141,11 @@ func (ci *createDailyStockPicksInteractorImpl) CreateDailyStockPicks(ctx context
 		return errors.Wrap(err, "DoInTx error")
 	}
 
-	return ci.notify(ctx, pickDate, picks)
+	livePicks := picksByVersion[domain_service.DailyPickScoreVersion]
+	if len(livePicks) == 0 {
+		return nil
+	}
+	return ci.notify(ctx, pickDate, livePicks)
 }
 
 // notifyIfPending 既存の推奨のうち未通知が1件でもあれば再通知する。全件通知済みなら何もしない。
@@ -121,48 +158,106 @@ func (ci *createDailyStockPicksInteractorImpl) notifyIfPending(ctx context.Conte
 	return nil
 }
 
-// screen 主要市場銘柄を並列にスクリーニングし、上位 topN の推奨を返す。
+// allowedByRegime 局面ゲートが指定されていれば pickDate の市場局面で判定する。
+// 局面が未算出の日に黙ってゲートを外すと指定と違う局面で推奨を出してしまうため、エラーにする。
+func (ci *createDailyStockPicksInteractorImpl) allowedByRegime(ctx context.Context, pickDate time.Time, filter domain_service.DailyPickFilterParams) (bool, error) {
+	if len(filter.AllowedRegimes) == 0 {
+		return true, nil
+	}
+	regime, err := ci.marketRegimeRepository.FindMarketRegimeByDate(ctx, pickDate)
+	if err != nil {
+		return false, errors.Wrap(err, "FindMarketRegimeByDate error")
+	}
+	if regime == nil {
+		return false, errors.Errorf("market regime not found for regime gate. run classify_market_regime_v1 first. pickDate=%s", util.DatetimeToDateStr(pickDate))
+	}
+	if !filter.AllowsRegime(regime.Regime) {
+		log.Printf("daily stock picks: skipped by regime gate. pickDate=%s regime=%s", util.DatetimeToDateStr(pickDate), regime.Regime)
+		return false, nil
+	}
+	return true, nil
+}
+
+// screen 主要市場銘柄を並列にスクリーニングし、スコア設定のバージョンごとに上位 topN の推奨を返す。
 func (ci *createDailyStockPicksInteractorImpl) screen(
 	ctx context.Context,
 	pickDate, from time.Time,
+	configs []domain_service.DailyPickScoringConfig,
 	topN, maxPerSector, concurrency int,
-) ([]*models.DailyStockPick, error) {
+) (map[string][]*models.DailyStockPick, error) {
 	brands, err := ci.stockBrandRepository.FindAllMainMarkets(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "FindAllMainMarkets error")
 	}
 
-	candidates, err := ci.runScreeningWorkers(ctx, brands, from, pickDate, concurrency)
+	candidates, err := ci.runScreeningWorkers(ctx, brands, from, pickDate, configs, concurrency)
 	if err != nil {
 		return nil, err
 	}
+	if err := ci.applyConfluence(ctx, pickDate, configs, candidates); err != nil {
+		return nil, err
+	}
 
-	return domain_service.RankDailyPickCandidates(candidates, pickDate, topN, maxPerSector), nil
+	picksByVersion := make(map[string][]*models.DailyStockPick, len(configs))
+	for i, c := range configs {
+		picksByVersion[c.Version] = domain_service.RankDailyPickCandidates(candidates[i], pickDate, c.Version, topN, maxPerSector)
+	}
+	return picksByVersion, nil
+}
+
+// applyConfluence 合流スコアを因子に使うスコア設定の候補に、pickDate 時点の合流スコアの買い優勢分を入れてスコアを算出し直す。
+func (ci *createDailyStockPicksInteractorImpl) applyConfluence(
+	ctx context.Context,
+	pickDate time.Time,
+	configs []domain_service.DailyPickScoringConfig,
+	candidates [][]*domain_service.DailyPickCandidate,
+) error {
+	var strength map[string]*models.WeightedSignalStock
+	for i, cfg := range configs {
+		if !cfg.Weights.UsesConfluence() {
+			continue
+		}
+		if strength == nil {
+			confluence, err := scoreSignalConfluence(ctx, ci.analyzeStockBrandPriceHistoryRepository, ci.stockBrandsDailyStockPriceRepository,
+				pickDate, domain_service.DefaultSignalConfluenceParams())
+			if err != nil {
+				return errors.Wrap(err, "scoreSignalConfluence error")
+			}
+			strength = make(map[string]*models.WeightedSignalStock, len(confluence.Stocks))
+			for _, s := range confluence.Stocks {
+				strength[s.TickerSymbol] = s
+			}
+		}
+		for j, c := range candidates[i] {
+			candidates[i][j] = domain_service.ApplyDailyPickConfluence(c, domain_service.SignalConfluenceBuyStrength(strength[c.Brand.TickerSymbol]), cfg.Weights)
+		}
+	}
+	return nil
 }
 
 // runScreeningWorkers 固定 concurrency 個のワーカーで全銘柄を並列に評価する（strategy_ranking_interactor.runWorkers と同じ設計）。
 // 各銘柄の日足は ListDailyPricesBySymbol で銘柄単位にストリーム取得し、120営業日×全銘柄の一括取得によるメモリ膨張を避ける。
+// 日足は1銘柄1回だけ取得し、configs の各スコア設定で評価する。戻り値は configs と同じ並びの候補。
 func (ci *createDailyStockPicksInteractorImpl) runScreeningWorkers(
 	ctx context.Context,
 	brands []*models.StockBrand,
 	from, to time.Time,
+	configs []domain_service.DailyPickScoringConfig,
 	concurrency int,
-) ([]*domain_service.DailyPickCandidate, error) {
+) ([][]*domain_service.DailyPickCandidate, error) {
 	if concurrency <= 0 {
 		concurrency = runtime.NumCPU()
 	}
 	asc := models.SortOrderAsc
-	filter := domain_service.DefaultDailyPickFilterParams()
-	weights := domain_service.DefaultDailyPickScoreWeights()
 
-	workerResults := make([][]*domain_service.DailyPickCandidate, concurrency)
+	workerResults := make([][][]*domain_service.DailyPickCandidate, concurrency)
 	jobs := make(chan *models.StockBrand)
 	g, gctx := errgroup.WithContext(ctx)
 
 	for w := 0; w < concurrency; w++ {
 		w := w
 		g.Go(func() error {
-			var local []*domain_service.DailyPickCandidate
+			local := make([][]*domain_service.DailyPickCandidate, len(configs))
 			for brand := range jobs {
 				prices, err := ci.stockBrandsDailyStockPriceRepository.ListDailyPricesBySymbol(gctx, models.ListDailyPricesBySymbolFilter{
 					TickerSymbol: brand.TickerSymbol,
@@ -173,8 +268,10 @@ func (ci *createDailyStockPicksInteractorImpl) runScreeningWorkers(
 				if err != nil {
 					return errors.Wrapf(err, "ListDailyPricesBySymbol error symbol=%s", brand.TickerSymbol)
 				}
-				if c := domain_service.EvaluateDailyPickCandidate(brand, prices, filter, weights); c != nil {
-					local = append(local, c)
+				for i, cfg := range configs {
+					if c := domain_service.EvaluateDailyPickCandidate(brand, prices, cfg.Filter, cfg.Weights); c != nil {
+						local[i] = append(local[i], c)
+					}
 				}
 			}
 			workerResults[w] = local
@@ -198,9 +295,11 @@ func (ci *createDailyStockPicksInteractorImpl) runScreeningWorkers(
 		return nil, errors.Wrap(err, "runScreeningWorkers error")
 	}
 
-	var candidates []*domain_service.DailyPickCandidate
+	candidates := make([][]*domain_service.DailyPickCandidate, len(configs))
 	for _, r := range workerResults {
-		candidates = append(candidates, r...)
+		for i := range r {
+			candidates[i] = append(candidates[i], r[i]...)
+		}
 	}
 	return candidates, nil
 }
@@ -223,10 +322,10 @@ func (ci *createDailyStockPicksInteractorImpl) notify(ctx context.Context, pickD
 		}
 	}
 
-	if err := ci.dailyStockPickRepository.MarkNotified(ctx, pickDate, time.Now()); err != nil {
+	if err := ci.dailyStockPickRepository.MarkNotified(ctx, pickDate, domain_service.DailyPickScoreVersion, time.Now()); err != nil {
 		return errors.Wrap(err, "MarkNotified error")
 	}
 
-	log.Printf("daily stock picks: notified. pickDate=%s count=%d", pickDate.Format("2006-01-02"), len(picks))
+	log.Printf("daily stock picks: notified. pickDate=%s count=%d", util.DatetimeToDateStr(pickDate), len(picks))
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"github.com/Code0716/stock-price-repository/domain_service"
 	"github.com/Code0716/stock-price-repository/infrastructure/gateway"
 	mock_gateway "github.com/Code0716/stock-price-repository/mock/gateway"
 	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
@@ -77,7 +78,7 @@ func TestCreateDailyStockPicksInteractorImpl_CreateDailyStockPicks(t *testing.T)
 				pickRepo: func(ctrl *gomock.Controller) repositories.DailyStockPickRepository {
 					mock := mock_repositories.NewMockDailyStockPickRepository(ctrl)
 					notifiedAt := now
-					mock.EXPECT().ListByPickDate(gomock.Any(), now).Return([]*models.DailyStockPick{
+					mock.EXPECT().ListByPickDate(gomock.Any(), now, domain_service.DailyPickScoreVersion).Return([]*models.DailyStockPick{
 						{TickerSymbol: "1000", NotifiedAt: &notifiedAt},
 					}, nil)
 					return mock
@@ -105,10 +106,10 @@ func TestCreateDailyStockPicksInteractorImpl_CreateDailyStockPicks(t *testing.T)
 				},
 				pickRepo: func(ctrl *gomock.Controller) repositories.DailyStockPickRepository {
 					mock := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-					mock.EXPECT().ListByPickDate(gomock.Any(), now).Return([]*models.DailyStockPick{
+					mock.EXPECT().ListByPickDate(gomock.Any(), now, domain_service.DailyPickScoreVersion).Return([]*models.DailyStockPick{
 						{PickDate: now, TickerSymbol: "1000", PickRank: 1, Score: decimal.NewFromInt(80)},
 					}, nil)
-					mock.EXPECT().MarkNotified(gomock.Any(), now, gomock.Any()).Return(nil)
+					mock.EXPECT().MarkNotified(gomock.Any(), now, domain_service.DailyPickScoreVersion, gomock.Any()).Return(nil)
 					return mock
 				},
 				slackAPI: func(ctrl *gomock.Controller) gateway.SlackAPIClient {
@@ -140,7 +141,7 @@ func TestCreateDailyStockPicksInteractorImpl_CreateDailyStockPicks(t *testing.T)
 				},
 				pickRepo: func(ctrl *gomock.Controller) repositories.DailyStockPickRepository {
 					mock := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-					mock.EXPECT().ListByPickDate(gomock.Any(), now).Return(nil, nil)
+					mock.EXPECT().ListByPickDate(gomock.Any(), now, domain_service.DailyPickScoreVersion).Return(nil, nil)
 					return mock
 				},
 				slackAPI: func(ctrl *gomock.Controller) gateway.SlackAPIClient {
@@ -163,9 +164,11 @@ func TestCreateDailyStockPicksInteractorImpl_CreateDailyStockPicks(t *testing.T)
 				tt.fields.priceRepo(ctrl),
 				tt.fields.brandRepo(ctrl),
 				tt.fields.pickRepo(ctrl),
+				nil,
+				nil,
 				tt.fields.slackAPI(ctrl),
 			)
-			err := interactor.CreateDailyStockPicks(context.Background(), now, 0, -1, 1, false)
+			err := interactor.CreateDailyStockPicks(context.Background(), now, 0, -1, 1, nil, false, false)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -199,15 +202,47 @@ func TestCreateDailyStockPicksInteractorImpl_CreateDailyStockPicks_SaveAndNotify
 		DateOrder:    dateOrderPtr(models.SortOrderAsc),
 	}).Return(prices, nil)
 
+	// 合流スコア: 当日に Buy シグナルが1つ（6月に5戦5勝の手法 → 的中率 (5 + 0.5*20) / (5 + 20) = 0.6、減衰なし）
+	priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), pickDate, domain_service.DefaultSignalConfluenceParams().LookbackDays).Return(dates[:5], nil)
+	june := func(d int) time.Time { return time.Date(2026, 6, d, 0, 0, 0, 0, time.UTC) }
+	juneBars := make([]*models.StockBrandDailyPrice, 0, 10)
+	for d := 1; d <= 10; d++ {
+		juneBars = append(juneBars, &models.StockBrandDailyPrice{TickerSymbol: "1000", Date: june(d), Adjclose: decimal.NewFromInt(int64(100 + d))})
+	}
+	priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(juneBars, nil)
+	history := make([]*models.AnalyzeStockBrandPriceHistory, 0, 6)
+	for d := 1; d <= 5; d++ {
+		history = append(history, &models.AnalyzeStockBrandPriceHistory{TickerSymbol: "1000", Method: models.AnalyzeStockBrandPriceHistoryMethodFindMLRankedV1, Action: models.AnalyzeStockBrandPriceHistoryActionBuy, CreatedAt: june(d)})
+	}
+	history = append(history, &models.AnalyzeStockBrandPriceHistory{TickerSymbol: "1000", Method: models.AnalyzeStockBrandPriceHistoryMethodFindMLRankedV1, Action: models.AnalyzeStockBrandPriceHistoryActionBuy, CreatedAt: pickDate})
+	analyzeRepo := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+	analyzeRepo.EXPECT().FindByCreatedAtRange(gomock.Any(), gomock.Any()).Return(history, nil)
+
 	pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-	pickRepo.EXPECT().ListByPickDate(gomock.Any(), pickDate).Return(nil, nil)
+	pickRepo.EXPECT().ListByPickDate(gomock.Any(), pickDate, domain_service.DailyPickScoreVersion).Return(nil, nil)
 
 	gomock.InOrder(
-		pickRepo.EXPECT().DeleteByPickDate(gomock.Any(), pickDate).Return(nil),
+		pickRepo.EXPECT().DeleteByPickDate(gomock.Any(), pickDate, domain_service.DailyPickScoreVersions()).Return(nil),
 		pickRepo.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(ctx context.Context, picks []*models.DailyStockPick) error {
-				assert.Len(t, picks, 1)
-				assert.Equal(t, "1000", picks[0].TickerSymbol)
+				// live と shadow の全スコア設定の推奨を保存する
+				versions := domain_service.DailyPickScoreVersions()
+				assert.Len(t, picks, len(versions))
+				saved := make([]string, 0, len(picks))
+				for _, p := range picks {
+					assert.Equal(t, "1000", p.TickerSymbol)
+					saved = append(saved, p.ScoreVersion)
+
+					confluence := p.ScoreComponents[len(p.ScoreComponents)-1]
+					assert.Equal(t, domain_service.DailyPickComponentConfluence, confluence.Key)
+					if p.ScoreVersion == "v1-confluence" {
+						assert.Equal(t, "0.7", confluence.Normalized.String(), "BuyScore 0.6-0.5=0.1 → 0.70")
+						assert.Equal(t, "10.5", confluence.Contribution.String())
+					} else {
+						assert.True(t, confluence.Contribution.IsZero(), "合流スコアを使わない設定は寄与0")
+					}
+				}
+				assert.ElementsMatch(t, versions, saved)
 				return nil
 			}),
 	)
@@ -217,15 +252,15 @@ func TestCreateDailyStockPicksInteractorImpl_CreateDailyStockPicks_SaveAndNotify
 		SendMessageByStrings(gomock.Any(), gateway.SlackChannelNameExchangeStockInfo, gomock.Any(), gomock.Any(), (*string)(nil)).
 		Return("1234.5678", nil)
 
-	pickRepo.EXPECT().MarkNotified(gomock.Any(), pickDate, gomock.Any()).Return(nil)
+	pickRepo.EXPECT().MarkNotified(gomock.Any(), pickDate, domain_service.DailyPickScoreVersion, gomock.Any()).Return(nil)
 
 	tx := mock_repositories.NewMockTransaction(ctrl)
 	tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 		return fn(ctx)
 	})
 
-	interactor := NewCreateDailyStockPicksInteractor(tx, priceRepo, brandRepo, pickRepo, slackAPI)
-	err := interactor.CreateDailyStockPicks(context.Background(), now, 25, 4, 1, false)
+	interactor := NewCreateDailyStockPicksInteractor(tx, priceRepo, brandRepo, pickRepo, nil, analyzeRepo, slackAPI)
+	err := interactor.CreateDailyStockPicks(context.Background(), now, 25, 4, 1, nil, true, false)
 	assert.NoError(t, err)
 }
 
@@ -244,7 +279,7 @@ func TestCreateDailyStockPicksInteractorImpl_CreateDailyStockPicks_SlackFailureD
 	brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 
 	pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-	pickRepo.EXPECT().ListByPickDate(gomock.Any(), pickDate).Return([]*models.DailyStockPick{
+	pickRepo.EXPECT().ListByPickDate(gomock.Any(), pickDate, domain_service.DailyPickScoreVersion).Return([]*models.DailyStockPick{
 		{PickDate: pickDate, TickerSymbol: "1000", PickRank: 1, Score: decimal.NewFromInt(80)},
 	}, nil)
 	// MarkNotified は呼ばれない
@@ -256,11 +291,71 @@ func TestCreateDailyStockPicksInteractorImpl_CreateDailyStockPicks_SlackFailureD
 
 	tx := mock_repositories.NewMockTransaction(ctrl)
 
-	interactor := NewCreateDailyStockPicksInteractor(tx, priceRepo, brandRepo, pickRepo, slackAPI)
-	err := interactor.CreateDailyStockPicks(context.Background(), now, 25, 4, 1, false)
+	interactor := NewCreateDailyStockPicksInteractor(tx, priceRepo, brandRepo, pickRepo, nil, nil, slackAPI)
+	err := interactor.CreateDailyStockPicks(context.Background(), now, 25, 4, 1, nil, false, false)
 	assert.Error(t, err)
 }
 
+func TestCreateDailyStockPicksInteractorImpl_CreateDailyStockPicks_RegimeGate(t *testing.T) {
+	now := time.Date(2026, 7, 24, 0, 0, 0, 0, time.UTC)
+	dates := dailyPickTestDates(now)
+	pickDate := dates[0]
+	regimes := []string{models.MarketRegimeTrendUp, models.MarketRegimeRange}
+
+	t.Run("許可されていない局面の日はスクリーニングしない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), now, dailyStockPickWindowDays).Return(dates, nil)
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), pickDate, domain_service.DailyPickScoreVersion).Return(nil, nil)
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().FindMarketRegimeByDate(gomock.Any(), pickDate).
+			Return(&models.MarketRegime{Date: pickDate, Regime: models.MarketRegimeTrendDown}, nil)
+		// FindAllMainMarkets（スクリーニング）は呼ばれない
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+
+		interactor := NewCreateDailyStockPicksInteractor(mock_repositories.NewMockTransaction(ctrl), priceRepo, brandRepo, pickRepo, regimeRepo, nil, mock_gateway.NewMockSlackAPIClient(ctrl))
+		err := interactor.CreateDailyStockPicks(context.Background(), now, 25, 4, 1, regimes, false, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 局面が未算出ならスクリーニングせずにエラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), now, dailyStockPickWindowDays).Return(dates, nil)
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), pickDate, domain_service.DailyPickScoreVersion).Return(nil, nil)
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().FindMarketRegimeByDate(gomock.Any(), pickDate).Return(nil, nil)
+		// FindAllMainMarkets（スクリーニング）は呼ばれない
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+
+		interactor := NewCreateDailyStockPicksInteractor(mock_repositories.NewMockTransaction(ctrl), priceRepo, brandRepo, pickRepo, regimeRepo, nil, mock_gateway.NewMockSlackAPIClient(ctrl))
+		err := interactor.CreateDailyStockPicks(context.Background(), now, 25, 4, 1, regimes, false, false)
+		assert.ErrorContains(t, err, "market regime not found")
+	})
+
+	t.Run("異常系: 局面取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), now, dailyStockPickWindowDays).Return(dates, nil)
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), pickDate, domain_service.DailyPickScoreVersion).Return(nil, nil)
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().FindMarketRegimeByDate(gomock.Any(), pickDate).Return(nil, assert.AnError)
+
+		interactor := NewCreateDailyStockPicksInteractor(mock_repositories.NewMockTransaction(ctrl), priceRepo, mock_repositories.NewMockStockBrandRepository(ctrl), pickRepo, regimeRepo, nil, mock_gateway.NewMockSlackAPIClient(ctrl))
+		err := interactor.CreateDailyStockPicks(context.Background(), now, 25, 4, 1, regimes, false, false)
+		assert.Error(t, err)
+	})
+}
+
 func dateOrderPtr(o models.SortOrder) *models.SortOrder {
 	return &o
 }
@@ -3,6 +3,7 @@ package usecase
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
@@ -16,41 +17,67 @@ import (
 // dailyStockPickDatesDefaultLimit 日付一覧の既定件数。
 const dailyStockPickDatesDefaultLimit = 90
 
+const (
+	// dailyStockPickBreakdownPriceLookBack シグナル日以前の平均売買代金（20営業日）を測るために遡る暦日数。
+	dailyStockPickBreakdownPriceLookBack = 40 // 20営業日 ≈ 29.4暦日 + 祝日・連休バッファ
+	// dailyStockPickBreakdownPriceLookAhead シグナル日から5営業日後までの日足を取るための暦日数。
+	dailyStockPickBreakdownPriceLookAhead = 14 // 5営業日 ≈ 7暦日 + 祝日・連休バッファ
+)
+
 // DailyStockPickInteractor 買い候補の閲覧用（読み取り専用）ユースケース。
 // 書き込みバッチ（CreateDailyStockPicksInteractor / EvaluateDailyStockPicksInteractor）とは
 // 別インターフェースにする。バッチ側は Slack 依存を持つため API から使い回さない。
 type DailyStockPickInteractor interface {
-	// GetDay 指定 pick_date の推奨一覧とサマリを返す。date が nil なら最新 pick_date を使う。
+	// GetDay 指定 pick_date・スコアバージョンの推奨一覧とサマリを返す。date が nil なら最新 pick_date、scoreVersion が空なら live を使う。
 	// 該当日が無い場合は PickDate=nil / Items=[] を返す（エラーにしない）。
-	GetDay(ctx context.Context, date *time.Time) (*models.DailyStockPickDay, error)
-	// GetPickDates pick_date を新しい順に最大 limit 件返す。limit<=0 なら既定値を使う。
+	GetDay(ctx context.Context, date *time.Time, scoreVersion string) (*models.DailyStockPickDay, error)
+	// GetPickDates live の pick_date を新しい順に最大 limit 件返す。limit<=0 なら既定値を使う。
 	GetPickDates(ctx context.Context, limit int) (*models.DailyStockPickDates, error)
 	// GetStats 期間とスコアバージョンで絞った累計成績を返す。scoreVersion が空なら現行バージョンを使う。
+	// 推奨日の市場局面別の成績（ByRegime）と、並走させている各スコア設定の live との比較（Versions）も併せて返す。
+	// 因子ごとの寄与と5営業日後リターンの相関（Components）も返す。
+	// リプレイの score_version（replay/...）を指定すると、リプレイの推奨で同じ集計を返す。
 	GetStats(ctx context.Context, from, to *time.Time, scoreVersion string) (*models.DailyStockPickStats, error)
+	// GetStatsBreakdown 推奨と同じ期間の分析シグナルの成績を、業種・市場・戦略・流動性帯・曜日の切り口で返す。
+	// from/to の未指定側は推奨の実データの範囲で補い、それでも決まらなければ（推奨0件）シグナルは集計しない。
+	GetStatsBreakdown(ctx context.Context, from, to *time.Time, scoreVersion string) (*models.DailyStockPickStatsBreakdown, error)
 }
 
 type dailyStockPickInteractorImpl struct {
 	dailyStockPickRepository repositories.DailyStockPickRepository
 	stockBrandRepository     repositories.StockBrandRepository
+	marketRegimeRepository   repositories.MarketRegimeRepository
+	analyzeRepository        repositories.AnalyzeStockBrandPriceHistoryRepository
+	dailyPriceRepository     repositories.StockBrandsDailyPriceRepository
 }
 
 func NewDailyStockPickInteractor(
 	dailyStockPickRepository repositories.DailyStockPickRepository,
 	stockBrandRepository repositories.StockBrandRepository,
+	marketRegimeRepository repositories.MarketRegimeRepository,
+	analyzeRepository repositories.AnalyzeStockBrandPriceHistoryRepository,
+	dailyPriceRepository repositories.StockBrandsDailyPriceRepository,
 ) DailyStockPickInteractor {
 	return &dailyStockPickInteractorImpl{
 		dailyStockPickRepository: dailyStockPickRepository,
 		stockBrandRepository:     stockBrandRepository,
+		marketRegimeRepository:   marketRegimeRepository,
+		analyzeRepository:        analyzeRepository,
+		dailyPriceRepository:     dailyPriceRepository,
 	}
 }
 
-func (di *dailyStockPickInteractorImpl) GetDay(ctx context.Context, date *time.Time) (*models.DailyStockPickDay, error) {
-	pickDate, err := di.resolvePickDate(ctx, date)
+func (di *dailyStockPickInteractorImpl) GetDay(ctx context.Context, date *time.Time, scoreVersion string) (*models.DailyStockPickDay, error) {
+	if scoreVersion == "" {
+		scoreVersion = domain_service.DailyPickScoreVersion
+	}
+
+	pickDate, err := di.resolvePickDate(ctx, date, scoreVersion)
 	if err != nil {
 		return nil, errors.Wrap(err, "resolvePickDate error")
 	}
 	empty := &models.DailyStockPickDay{
-		ScoreVersion: domain_service.DailyPickScoreVersion,
+		ScoreVersion: scoreVersion,
 		Items:        []*models.DailyStockPickItem{},
 	}
 	if pickDate == nil {
@@ -58,7 +85,7 @@ func (di *dailyStockPickInteractorImpl) GetDay(ctx context.Context, date *time.T
 		return empty, nil
 	}
 
-	picks, err := di.dailyStockPickRepository.ListByPickDate(ctx, *pickDate)
+	picks, err := di.dailyStockPickRepository.ListByPickDate(ctx, *pickDate, scoreVersion)
 	if err != nil {
 		return nil, errors.Wrap(err, "ListByPickDate error")
 	}
@@ -97,7 +124,7 @@ func (di *dailyStockPickInteractorImpl) GetPickDates(ctx context.Context, limit
 		limit = dailyStockPickDatesDefaultLimit
 	}
 
-	dates, err := di.dailyStockPickRepository.ListPickDates(ctx, limit)
+	dates, err := di.dailyStockPickRepository.ListPickDates(ctx, limit, domain_service.DailyPickScoreVersion)
 	if err != nil {
 		return nil, errors.Wrap(err, "ListPickDates error")
 	}
@@ -124,23 +151,179 @@ func (di *dailyStockPickInteractorImpl) GetStats(ctx context.Context, from, to *
 		Totals:       domain_service.SummarizeDailyPicksForView(picks),
 		Daily:        domain_service.AggregateDailyPicksByDate(picks),
 		ScoreBands:   domain_service.AggregateDailyPicksByScoreBand(picks),
+		ByRegime:     []*models.DailyStockPickRegimeStat{},
+		Components:   domain_service.AggregateDailyPicksByComponent(picks),
+	}
+	versions, err := di.compareVersions(ctx, from, to, scoreVersion, picks)
+	if err != nil {
+		return nil, err
 	}
+	stats.Versions = versions
+
 	// from/to は実データの範囲を返す（クエリ未指定でも軸が分かるようにする）。
 	if len(stats.Daily) > 0 {
 		first := stats.Daily[0].PickDate
 		last := stats.Daily[len(stats.Daily)-1].PickDate
 		stats.From = &first
 		stats.To = &last
+
+		regimeByDate, err := di.regimesByDate(ctx, picks)
+		if err != nil {
+			return nil, err
+		}
+		stats.ByRegime = domain_service.AggregateDailyPicksByRegime(picks, regimeByDate)
 	}
 	return stats, nil
 }
 
-// resolvePickDate date が nil なら最新 pick_date を引く。1件も無ければ nil を返す。
-func (di *dailyStockPickInteractorImpl) resolvePickDate(ctx context.Context, date *time.Time) (*time.Time, error) {
+func (di *dailyStockPickInteractorImpl) GetStatsBreakdown(ctx context.Context, from, to *time.Time, scoreVersion string) (*models.DailyStockPickStatsBreakdown, error) {
+	if scoreVersion == "" {
+		scoreVersion = domain_service.DailyPickScoreVersion
+	}
+
+	picks, err := di.dailyStockPickRepository.ListByDateRange(ctx, from, to, scoreVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListByDateRange error")
+	}
+
+	ids := make([]string, 0, len(picks))
+	for _, p := range picks {
+		ids = append(ids, p.StockBrandID)
+	}
+	brands, err := di.findBrandsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.DailyStockPickStatsBreakdown{
+		ScoreVersion: scoreVersion,
+		Picks:        domain_service.AggregateDailyPickBreakdown(domain_service.DailyPickBreakdownSamplesFromPicks(picks, brands)),
+		Signals:      domain_service.AggregateDailyPickBreakdown(nil),
+	}
+
+	// 未指定側は推奨の実データの範囲で補う（シグナルを全期間なめないため）。
+	for _, p := range picks {
+		if from == nil || p.PickDate.Before(*from) {
+			d := p.PickDate
+			from = &d
+		}
+		if to == nil || p.PickDate.After(*to) {
+			d := p.PickDate
+			to = &d
+		}
+	}
+	if from == nil || to == nil {
+		return out, nil
+	}
+	f, t := from.Format(util.DateLayout), to.Format(util.DateLayout)
+	out.From, out.To = &f, &t
+
+	samples, err := di.signalBreakdownSamples(ctx, *from, *to)
+	if err != nil {
+		return nil, err
+	}
+	out.Signals = domain_service.AggregateDailyPickBreakdown(samples)
+	return out, nil
+}
+
+// signalBreakdownSamples 期間内の Buy/Sell シグナルに銘柄の業種・市場と日足を突き合わせて集計用の形にする。
+func (di *dailyStockPickInteractorImpl) signalBreakdownSamples(ctx context.Context, from, to time.Time) ([]domain_service.DailyPickBreakdownSample, error) {
+	all, err := di.analyzeRepository.FindByCreatedAtRange(ctx, &models.SignalPerformanceFilter{From: from, To: to})
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByCreatedAtRange error")
+	}
+
+	signals := make([]*models.AnalyzeStockBrandPriceHistory, 0, len(all))
+	ids := make([]string, 0, len(all))
+	symbolSet := make(map[string]struct{})
+	symbols := make([]string, 0)
+	for _, sg := range all {
+		if sg.Action != models.AnalyzeStockBrandPriceHistoryActionBuy && sg.Action != models.AnalyzeStockBrandPriceHistoryActionSell {
+			continue
+		}
+		signals = append(signals, sg)
+		ids = append(ids, sg.StockBrandID)
+		if _, ok := symbolSet[sg.TickerSymbol]; !ok {
+			symbolSet[sg.TickerSymbol] = struct{}{}
+			symbols = append(symbols, sg.TickerSymbol)
+		}
+	}
+	if len(signals) == 0 {
+		return nil, nil
+	}
+
+	brands, err := di.findBrandsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	priceFrom := from.AddDate(0, 0, -dailyStockPickBreakdownPriceLookBack)
+	priceTo := to.AddDate(0, 0, dailyStockPickBreakdownPriceLookAhead)
+	pricesBySymbol, err := listPricesBySymbols(ctx, di.dailyPriceRepository, symbols, priceFrom, priceTo)
+	if err != nil {
+		return nil, err
+	}
+
+	window := domain_service.DefaultDailyPickFilterParams().MetricsWindowDays
+	samples := make([]domain_service.DailyPickBreakdownSample, 0, len(signals))
+	for _, sg := range signals {
+		samples = append(samples, domain_service.DailyPickBreakdownSampleFromSignal(sg, brands[sg.StockBrandID], pricesBySymbol[sg.TickerSymbol], window))
+	}
+	return samples, nil
+}
+
+// compareVersions 並走させているスコア設定ごとに期間内の推奨を取得して live と比べる。
+// 取得済みの scoreVersion の推奨は使い回す。リプレイの score_version ならリプレイ同士で比べる。
+func (di *dailyStockPickInteractorImpl) compareVersions(ctx context.Context, from, to *time.Time, scoreVersion string, picks []*models.DailyStockPick) ([]*models.DailyStockPickVersionStat, error) {
+	versions := domain_service.DailyPickScoreVersions()
+	live := domain_service.DailyPickScoreVersion
+	if domain_service.IsDailyPickReplayScoreVersion(scoreVersion) {
+		versions = domain_service.DailyPickReplayScoreVersions()
+		live = domain_service.DailyPickReplayScoreVersion(domain_service.DailyPickScoreVersion)
+	}
+	picksByVersion := map[string][]*models.DailyStockPick{scoreVersion: picks}
+	for _, v := range versions {
+		if v == scoreVersion {
+			continue
+		}
+		vp, err := di.dailyStockPickRepository.ListByDateRange(ctx, from, to, v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "ListByDateRange error scoreVersion=%s", v)
+		}
+		picksByVersion[v] = vp
+	}
+	return domain_service.CompareDailyPickVersions(picksByVersion, versions, live), nil
+}
+
+// regimesByDate 推奨日の範囲の市場局面を "2006-01-02" → 局面 で返す。
+func (di *dailyStockPickInteractorImpl) regimesByDate(ctx context.Context, picks []*models.DailyStockPick) (map[string]string, error) {
+	from, to := picks[0].PickDate, picks[0].PickDate
+	for _, p := range picks[1:] {
+		if p.PickDate.Before(from) {
+			from = p.PickDate
+		}
+		if p.PickDate.After(to) {
+			to = p.PickDate
+		}
+	}
+
+	regimes, err := di.marketRegimeRepository.ListMarketRegimes(ctx, models.MarketRegimeFilter{From: &from, To: &to})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListMarketRegimes error")
+	}
+	regimeByDate := make(map[string]string, len(regimes))
+	for _, r := range regimes {
+		regimeByDate[r.Date.Format(util.DateLayout)] = r.Regime
+	}
+	return regimeByDate, nil
+}
+
+// resolvePickDate date が nil なら scoreVersion の最新 pick_date を引く。1件も無ければ nil を返す。
+func (di *dailyStockPickInteractorImpl) resolvePickDate(ctx context.Context, date *time.Time, scoreVersion string) (*time.Time, error) {
 	if date != nil {
 		return date, nil
 	}
-	return di.dailyStockPickRepository.FindLatestPickDate(ctx)
+	return di.dailyStockPickRepository.FindLatestPickDate(ctx, scoreVersion)
 }
 
 // resolveBrandNames 推奨銘柄の名称を stock_brand から解決する（Name は daily_stock_pick に保存していないため）。
@@ -167,6 +350,24 @@ func (di *dailyStockPickInteractorImpl) resolveBrandNames(ctx context.Context, p
 	return names, nil
 }
 
+// findBrandsByIDs 銘柄を stock_brand.id → 銘柄 で返す（重複 ID はまとめて1回で引く）。ids が空なら引かない。
+func (di *dailyStockPickInteractorImpl) findBrandsByIDs(ctx context.Context, ids []string) (map[string]*models.StockBrand, error) {
+	if len(ids) == 0 {
+		return map[string]*models.StockBrand{}, nil
+	}
+	slices.Sort(ids)
+	brands, err := di.stockBrandRepository.FindByIDs(ctx, slices.Compact(ids))
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByIDs error")
+	}
+
+	byID := make(map[string]*models.StockBrand, len(brands))
+	for _, b := range brands {
+		byID[b.ID] = b
+	}
+	return byID, nil
+}
+
 // toDailyStockPickItem ドメインモデルを API レスポンス用に詰め替える。
 func toDailyStockPickItem(p *models.DailyStockPick, name string) *models.DailyStockPickItem {
 	item := &models.DailyStockPickItem{
@@ -191,6 +392,7 @@ func toDailyStockPickItem(p *models.DailyStockPick, name string) *models.DailySt
 		Return3D:         p.Return3D,
 		Return5D:         p.Return5D,
 		Outcome:          p.Outcome,
+		ScoreComponents:  toDailyStockPickComponentItems(p.ScoreComponents),
 	}
 	if p.EvaluatedAt != nil {
 		evaluatedAt := p.EvaluatedAt.Format(time.RFC3339)
@@ -199,14 +401,32 @@ func toDailyStockPickItem(p *models.DailyStockPick, name string) *models.DailySt
 	return item
 }
 
+// toDailyStockPickComponentItems 因子内訳に日本語表示名を添え、寄与の大きい順に並べる。
+func toDailyStockPickComponentItems(components []models.DailyStockPickScoreComponent) []*models.DailyStockPickComponentItem {
+	out := make([]*models.DailyStockPickComponentItem, 0, len(components))
+	for _, c := range components {
+		out = append(out, &models.DailyStockPickComponentItem{
+			Key:          c.Key,
+			Label:        domain_service.DailyPickComponentLabel(c.Key),
+			Normalized:   c.Normalized,
+			Weight:       c.Weight,
+			Contribution: c.Contribution,
+		})
+	}
+	slices.SortStableFunc(out, func(a, b *models.DailyStockPickComponentItem) int {
+		return b.Contribution.Cmp(a.Contribution)
+	})
+	return out
+}
+
 // toDailyStockPickStrategies 戦略キーに日本語表示名を添える（フロントに日本語辞書を重複させないため）。
 // 未知のキーはラベルにキーをそのまま使う。
 func toDailyStockPickStrategies(keys []string) []*models.DailyStockPickStrategy {
 	out := make([]*models.DailyStockPickStrategy, 0, len(keys))
 	for _, k := range keys {
-		label, ok := domain_service.StrategyLabels[k]
-		if !ok {
-			label = k
+		label := k
+		if s, ok := domain_service.LookupStrategy(k); ok {
+			label = s.Label()
 		}
 		out = append(out, &models.DailyStockPickStrategy{Key: k, Label: label})
 	}
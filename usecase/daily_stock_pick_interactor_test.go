@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -35,8 +37,8 @@ func TestDailyStockPickInteractorImpl_GetDay(t *testing.T) {
 		defer ctrl.Finish()
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().FindLatestPickDate(gomock.Any()).Return(&pickDate, nil)
-		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate)).Return([]*models.DailyStockPick{
+		pickRepo.EXPECT().FindLatestPickDate(gomock.Any(), domain_service.DailyPickScoreVersion).Return(&pickDate, nil)
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate), gomock.Eq(domain_service.DailyPickScoreVersion)).Return([]*models.DailyStockPick{
 			viewTestPick(pickDate, 1, "b1", "1000", "82.5", []string{"macd_bullish"}),
 		}, nil)
 
@@ -45,7 +47,7 @@ func TestDailyStockPickInteractorImpl_GetDay(t *testing.T) {
 			{ID: "b1", Name: "テスト銘柄"},
 		}, nil)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetDay(context.Background(), nil)
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetDay(context.Background(), nil, "")
 		assert.NoError(t, err)
 		assert.NotNil(t, got.PickDate)
 		assert.Equal(t, "2026-07-24", *got.PickDate)
@@ -58,12 +60,12 @@ func TestDailyStockPickInteractorImpl_GetDay(t *testing.T) {
 		defer ctrl.Finish()
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().FindLatestPickDate(gomock.Any()).Return(nil, nil)
+		pickRepo.EXPECT().FindLatestPickDate(gomock.Any(), domain_service.DailyPickScoreVersion).Return(nil, nil)
 		// ListByPickDate は呼ばれない
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetDay(context.Background(), nil)
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetDay(context.Background(), nil, "")
 		assert.NoError(t, err)
 		assert.Nil(t, got.PickDate)
 		assert.NotNil(t, got.Items, "nilではなく空スライスを返す（JSONがnullにならないように）")
@@ -75,12 +77,12 @@ func TestDailyStockPickInteractorImpl_GetDay(t *testing.T) {
 		defer ctrl.Finish()
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate)).Return(nil, nil)
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate), gomock.Eq(domain_service.DailyPickScoreVersion)).Return(nil, nil)
 		// date が指定されているので FindLatestPickDate は呼ばれない
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetDay(context.Background(), &pickDate)
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetDay(context.Background(), &pickDate, "")
 		assert.NoError(t, err)
 		assert.Nil(t, got.PickDate)
 		assert.Empty(t, got.Items)
@@ -91,7 +93,7 @@ func TestDailyStockPickInteractorImpl_GetDay(t *testing.T) {
 		defer ctrl.Finish()
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate)).Return([]*models.DailyStockPick{
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate), gomock.Eq(domain_service.DailyPickScoreVersion)).Return([]*models.DailyStockPick{
 			viewTestPick(pickDate, 1, "b1", "1000", "82.5", []string{"macd_bullish"}),
 			viewTestPick(pickDate, 2, "b2", "2000", "70.0", []string{"ma_cross"}),
 		}, nil)
@@ -101,7 +103,7 @@ func TestDailyStockPickInteractorImpl_GetDay(t *testing.T) {
 			{ID: "b1", Name: "テスト銘柄"},
 		}, nil)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetDay(context.Background(), &pickDate)
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetDay(context.Background(), &pickDate, "")
 		assert.NoError(t, err)
 		assert.Equal(t, "テスト銘柄", got.Items[0].Name)
 		assert.Equal(t, "", got.Items[1].Name)
@@ -112,20 +114,45 @@ func TestDailyStockPickInteractorImpl_GetDay(t *testing.T) {
 		defer ctrl.Finish()
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate)).Return([]*models.DailyStockPick{
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate), gomock.Eq(domain_service.DailyPickScoreVersion)).Return([]*models.DailyStockPick{
 			viewTestPick(pickDate, 1, "b1", "1000", "82.5", []string{"macd_bullish", "unknown_strategy"}),
 		}, nil)
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 		brandRepo.EXPECT().FindByIDs(gomock.Any(), gomock.Any()).Return([]*models.StockBrand{{ID: "b1", Name: "テスト銘柄"}}, nil)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetDay(context.Background(), &pickDate)
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetDay(context.Background(), &pickDate, "")
 		assert.NoError(t, err)
 		assert.Equal(t, "macd_bullish", got.Items[0].Strategies[0].Key)
 		assert.Equal(t, "MACD強気", got.Items[0].Strategies[0].Label)
 		assert.Equal(t, "unknown_strategy", got.Items[0].Strategies[1].Label, "未知キーはキーをそのままラベルにする")
 	})
 
+	t.Run("スコアの内訳は寄与の大きい順にラベル付きで返す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		p := viewTestPick(pickDate, 1, "b1", "1000", "82.5", []string{"macd_bullish"})
+		p.ScoreComponents = []models.DailyStockPickScoreComponent{
+			{Key: domain_service.DailyPickComponentSignal, Weight: decimal.NewFromInt(30), Contribution: decimal.NewFromInt(10)},
+			{Key: domain_service.DailyPickComponentTrend, Weight: decimal.NewFromInt(20), Contribution: decimal.NewFromInt(18)},
+		}
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate), gomock.Eq(domain_service.DailyPickScoreVersion)).
+			Return([]*models.DailyStockPick{p}, nil)
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindByIDs(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetDay(context.Background(), &pickDate, "")
+		assert.NoError(t, err)
+		components := got.Items[0].ScoreComponents
+		if assert.Len(t, components, 2) {
+			assert.Equal(t, domain_service.DailyPickComponentTrend, components[0].Key)
+			assert.Equal(t, "ADX", components[0].Label)
+			assert.Equal(t, domain_service.DailyPickComponentSignal, components[1].Key)
+		}
+	})
+
 	t.Run("全件答え合わせ済みならEvaluated=true", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -137,12 +164,12 @@ func TestDailyStockPickInteractorImpl_GetDay(t *testing.T) {
 		p.Outcome = &outcome
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate)).Return([]*models.DailyStockPick{p}, nil)
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate), gomock.Eq(domain_service.DailyPickScoreVersion)).Return([]*models.DailyStockPick{p}, nil)
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 		brandRepo.EXPECT().FindByIDs(gomock.Any(), gomock.Any()).Return([]*models.StockBrand{{ID: "b1", Name: "テスト銘柄"}}, nil)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetDay(context.Background(), &pickDate)
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetDay(context.Background(), &pickDate, "")
 		assert.NoError(t, err)
 		assert.True(t, got.Evaluated)
 		assert.NotNil(t, got.Items[0].EvaluatedAt)
@@ -153,14 +180,14 @@ func TestDailyStockPickInteractorImpl_GetDay(t *testing.T) {
 		defer ctrl.Finish()
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate)).Return([]*models.DailyStockPick{
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), gomock.Eq(pickDate), gomock.Eq(domain_service.DailyPickScoreVersion)).Return([]*models.DailyStockPick{
 			viewTestPick(pickDate, 1, "b1", "1000", "82.5", []string{"macd_bullish"}),
 		}, nil)
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 		brandRepo.EXPECT().FindByIDs(gomock.Any(), gomock.Any()).Return([]*models.StockBrand{{ID: "b1", Name: "テスト銘柄"}}, nil)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetDay(context.Background(), &pickDate)
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetDay(context.Background(), &pickDate, "")
 		assert.NoError(t, err)
 		assert.False(t, got.Evaluated)
 		assert.Equal(t, 1, got.Summary.PendingCount)
@@ -174,14 +201,14 @@ func TestDailyStockPickInteractorImpl_GetPickDates(t *testing.T) {
 		defer ctrl.Finish()
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().ListPickDates(gomock.Any(), gomock.Eq(30)).Return([]time.Time{
+		pickRepo.EXPECT().ListPickDates(gomock.Any(), gomock.Eq(30), domain_service.DailyPickScoreVersion).Return([]time.Time{
 			time.Date(2026, 7, 24, 0, 0, 0, 0, time.UTC),
 			time.Date(2026, 7, 23, 0, 0, 0, 0, time.UTC),
 		}, nil)
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetPickDates(context.Background(), 30)
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetPickDates(context.Background(), 30)
 		assert.NoError(t, err)
 		assert.Equal(t, []string{"2026-07-24", "2026-07-23"}, got.Dates)
 	})
@@ -191,11 +218,11 @@ func TestDailyStockPickInteractorImpl_GetPickDates(t *testing.T) {
 		defer ctrl.Finish()
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().ListPickDates(gomock.Any(), gomock.Eq(dailyStockPickDatesDefaultLimit)).Return(nil, nil)
+		pickRepo.EXPECT().ListPickDates(gomock.Any(), gomock.Eq(dailyStockPickDatesDefaultLimit), domain_service.DailyPickScoreVersion).Return(nil, nil)
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetPickDates(context.Background(), 0)
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetPickDates(context.Background(), 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, got.Dates, "nilではなく空スライス")
 		assert.Empty(t, got.Dates)
@@ -214,10 +241,13 @@ func TestDailyStockPickInteractorImpl_GetStats(t *testing.T) {
 		pickRepo.EXPECT().
 			ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq(domain_service.DailyPickScoreVersion)).
 			Return(nil, nil)
+		pickRepo.EXPECT().
+			ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Not(domain_service.DailyPickScoreVersion)).
+			Return(nil, nil).Times(len(domain_service.DailyPickScoreVersions()) - 1)
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetStats(context.Background(), nil, nil, "")
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetStats(context.Background(), nil, nil, "")
 		assert.NoError(t, err)
 		assert.Equal(t, domain_service.DailyPickScoreVersion, got.ScoreVersion)
 		assert.NotNil(t, got.Daily)
@@ -226,6 +256,7 @@ func TestDailyStockPickInteractorImpl_GetStats(t *testing.T) {
 		assert.Empty(t, got.ScoreBands)
 		assert.Nil(t, got.From, "データ0件ならfrom/toはnull")
 		assert.Nil(t, got.To)
+		assert.Len(t, got.Versions, len(domain_service.DailyPickScoreVersions()))
 	})
 
 	t.Run("指定されたscoreVersionをそのまま使う", func(t *testing.T) {
@@ -236,32 +267,239 @@ func TestDailyStockPickInteractorImpl_GetStats(t *testing.T) {
 		pickRepo.EXPECT().
 			ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq("v2")).
 			Return(nil, nil)
+		pickRepo.EXPECT().
+			ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Not("v2")).
+			Return(nil, nil).Times(len(domain_service.DailyPickScoreVersions()))
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetStats(context.Background(), nil, nil, "v2")
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, nil, nil).GetStats(context.Background(), nil, nil, "v2")
 		assert.NoError(t, err)
 		assert.Equal(t, "v2", got.ScoreVersion)
 	})
 
+	t.Run("リプレイのscoreVersionならリプレイ同士で比べる", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		replayVersions := domain_service.DailyPickReplayScoreVersions()
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		for _, v := range replayVersions {
+			pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq(v)).Return(nil, nil)
+		}
+
+		got, err := NewDailyStockPickInteractor(pickRepo, mock_repositories.NewMockStockBrandRepository(ctrl), nil, nil, nil).
+			GetStats(context.Background(), nil, nil, replayVersions[0])
+		assert.NoError(t, err)
+		assert.Equal(t, replayVersions[0], got.ScoreVersion)
+		assert.Len(t, got.Versions, len(replayVersions))
+		assert.Equal(t, replayVersions[0], got.Versions[0].ScoreVersion)
+		assert.True(t, got.Versions[0].Live)
+	})
+
 	t.Run("from/toは実データの範囲を返す", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
-		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Any()).Return([]*models.DailyStockPick{
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Not(domain_service.DailyPickScoreVersion)).
+			Return(nil, nil).AnyTimes()
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq(domain_service.DailyPickScoreVersion)).Return([]*models.DailyStockPick{
 			viewTestPick(d1, 1, "b1", "1000", "82.5", []string{"macd_bullish"}),
 			viewTestPick(d2, 1, "b2", "2000", "65.0", []string{"ma_cross"}),
 		}, nil)
 
 		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().ListMarketRegimes(gomock.Any(), models.MarketRegimeFilter{From: &d1, To: &d2}).
+			Return([]*models.MarketRegime{{Date: d2, Regime: models.MarketRegimeTrendUp}}, nil)
 
-		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo).GetStats(context.Background(), nil, nil, "")
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, regimeRepo, nil, nil).GetStats(context.Background(), nil, nil, "")
 		assert.NoError(t, err)
 		assert.Equal(t, "2026-07-23", *got.From)
 		assert.Equal(t, "2026-07-24", *got.To)
 		assert.Len(t, got.Daily, 2)
 		assert.Len(t, got.ScoreBands, 2, "60帯と80帯")
 		assert.Equal(t, 2, got.Totals.Total)
+		assert.Len(t, got.ByRegime, 2, "trend_up と未算出")
+		assert.Equal(t, models.MarketRegimeTrendUp, got.ByRegime[0].Regime)
+		assert.Equal(t, models.MarketRegimeUnknown, got.ByRegime[1].Regime)
+		assert.Len(t, got.Components, len(domain_service.DailyPickComponentKeys))
+	})
+
+	t.Run("並走中のスコア設定をliveと比較する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		dec := func(v string) *decimal.Decimal { d := decimal.RequireFromString(v); return &d }
+		versions := domain_service.DailyPickScoreVersions()
+		shadow := versions[1]
+		live1 := viewTestPick(d1, 1, "b1", "1000", "82.5", []string{"macd_bullish"})
+		live1.Return5D = dec("0.01")
+		shadow1 := viewTestPick(d1, 1, "b1", "1000", "80.0", []string{"macd_bullish"})
+		shadow1.ScoreVersion = shadow
+		shadow1.Return5D = dec("0.03")
+
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq(domain_service.DailyPickScoreVersion)).
+			Return([]*models.DailyStockPick{live1}, nil)
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq(shadow)).
+			Return([]*models.DailyStockPick{shadow1}, nil)
+		for _, v := range versions[2:] {
+			pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq(v)).Return(nil, nil)
+		}
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().ListMarketRegimes(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		got, err := NewDailyStockPickInteractor(pickRepo, mock_repositories.NewMockStockBrandRepository(ctrl), regimeRepo, nil, nil).GetStats(context.Background(), nil, nil, "")
+		assert.NoError(t, err)
+		assert.Len(t, got.Versions, len(versions))
+		assert.True(t, got.Versions[0].Live)
+		assert.Equal(t, shadow, got.Versions[1].ScoreVersion)
+		assert.Equal(t, 1, got.Versions[1].Days)
+		assert.Equal(t, 1, got.Versions[1].BeatLiveDays)
+		assert.NotNil(t, got.Versions[1].Baseline)
+	})
+
+	t.Run("異常系: 市場局面の取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Not(domain_service.DailyPickScoreVersion)).
+			Return(nil, nil).AnyTimes()
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq(domain_service.DailyPickScoreVersion)).Return([]*models.DailyStockPick{
+			viewTestPick(d1, 1, "b1", "1000", "82.5", []string{"macd_bullish"}),
+		}, nil)
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().ListMarketRegimes(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+
+		_, err := NewDailyStockPickInteractor(pickRepo, mock_repositories.NewMockStockBrandRepository(ctrl), regimeRepo, nil, nil).GetStats(context.Background(), nil, nil, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestDailyStockPickInteractorImpl_GetStatsBreakdown(t *testing.T) {
+	d1 := time.Date(2026, 7, 23, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2026, 7, 24, 0, 0, 0, 0, time.UTC)
+	picks := []*models.DailyStockPick{
+		viewTestPick(d1, 1, "b1", "1000", "82.5", []string{"macd_bullish"}),
+		viewTestPick(d2, 1, "b2", "2000", "65.0", []string{"ma_cross"}),
+	}
+
+	t.Run("推奨の範囲で同じ期間のシグナルを集計する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq(domain_service.DailyPickScoreVersion)).Return(picks, nil)
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindByIDs(gomock.Any(), []string{"b1", "b2"}).Return([]*models.StockBrand{
+			{ID: "b1", MarketCode: "111", MarketName: "プライム", Sector33CodeName: "輸送用機器"},
+		}, nil)
+		brandRepo.EXPECT().FindByIDs(gomock.Any(), []string{"b1"}).Return([]*models.StockBrand{
+			{ID: "b1", MarketCode: "111", MarketName: "プライム", Sector33CodeName: "輸送用機器"},
+		}, nil)
+
+		analyzeRepo := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+		analyzeRepo.EXPECT().FindByCreatedAtRange(gomock.Any(), &models.SignalPerformanceFilter{From: d1, To: d2}).Return([]*models.AnalyzeStockBrandPriceHistory{
+			{StockBrandID: "b1", TickerSymbol: "1000", Method: "a", Action: models.AnalyzeStockBrandPriceHistoryActionBuy, CreatedAt: d1},
+			{StockBrandID: "b1", TickerSymbol: "1000", Method: "a", Action: "Hold", CreatedAt: d1},
+		}, nil)
+
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, filter models.ListRangePricesBySymbolsFilter) ([]*models.StockBrandDailyPrice, error) {
+				assert.Equal(t, []string{"1000"}, filter.Symbols)
+				assert.Equal(t, d1.AddDate(0, 0, -dailyStockPickBreakdownPriceLookBack), *filter.DateFrom)
+				assert.Equal(t, d2.AddDate(0, 0, dailyStockPickBreakdownPriceLookAhead), *filter.DateTo)
+				out := make([]*models.StockBrandDailyPrice, 0, 6)
+				for i, c := range []int64{100, 101, 102, 103, 104, 110} {
+					out = append(out, &models.StockBrandDailyPrice{TickerSymbol: "1000", Date: d1.AddDate(0, 0, i), Close: decimal.NewFromInt(c), Adjclose: decimal.NewFromInt(c), Volume: 1000000})
+				}
+				return out, nil
+			})
+
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, analyzeRepo, priceRepo).GetStatsBreakdown(context.Background(), nil, nil, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "2026-07-23", *got.From)
+		assert.Equal(t, "2026-07-24", *got.To)
+
+		assert.Len(t, got.Picks.ByMarket, 2)
+		assert.Equal(t, "111", got.Picks.ByMarket[0].Key)
+		assert.Equal(t, "プライム", got.Picks.ByMarket[0].Label)
+		assert.Equal(t, domain_service.DailyPickBreakdownUnknownKey, got.Picks.ByMarket[1].Key, "銘柄が見つからなければ unknown")
+		assert.Len(t, got.Picks.ByStrategy, 2)
+
+		assert.Len(t, got.Signals.ByStrategy, 1, "Buy/Sell 以外は集計しない")
+		assert.Equal(t, 1, got.Signals.ByStrategy[0].Total)
+		assert.Equal(t, 1, got.Signals.ByStrategy[0].Win)
+		assert.Equal(t, "0.1", got.Signals.ByStrategy[0].AvgReturn5D.String())
+		assert.Equal(t, "輸送用機器", got.Signals.BySector33[0].Key)
+	})
+
+	t.Run("シグナルの銘柄は重複を除き、チャンクに分けて日足を取得する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Eq(&d1), gomock.Eq(&d2), gomock.Any()).Return(nil, nil)
+
+		signals := make([]*models.AnalyzeStockBrandPriceHistory, 0, (pricesBySymbolsChunk+1)*2)
+		for i := 0; i <= pricesBySymbolsChunk; i++ {
+			symbol := fmt.Sprintf("%d", 1000+i)
+			for _, d := range []time.Time{d1, d2} {
+				signals = append(signals, &models.AnalyzeStockBrandPriceHistory{StockBrandID: "b" + symbol, TickerSymbol: symbol, Method: "a", Action: models.AnalyzeStockBrandPriceHistoryActionBuy, CreatedAt: d})
+			}
+		}
+		analyzeRepo := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+		analyzeRepo.EXPECT().FindByCreatedAtRange(gomock.Any(), gomock.Any()).Return(signals, nil)
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindByIDs(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		var requested []string
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, filter models.ListRangePricesBySymbolsFilter) ([]*models.StockBrandDailyPrice, error) {
+				assert.LessOrEqual(t, len(filter.Symbols), pricesBySymbolsChunk)
+				requested = append(requested, filter.Symbols...)
+				return nil, nil
+			}).Times(2)
+
+		got, err := NewDailyStockPickInteractor(pickRepo, brandRepo, nil, analyzeRepo, priceRepo).GetStatsBreakdown(context.Background(), &d1, &d2, "")
+		assert.NoError(t, err)
+		assert.Len(t, requested, pricesBySymbolsChunk+1, "同じ銘柄は1回だけ取得する")
+		assert.Equal(t, (pricesBySymbolsChunk+1)*2, got.Signals.ByStrategy[0].Total)
+	})
+
+	t.Run("推奨0件かつ期間未指定ならシグナルは集計しない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Nil(), gomock.Nil(), gomock.Eq("v2")).Return(nil, nil)
+
+		got, err := NewDailyStockPickInteractor(pickRepo, mock_repositories.NewMockStockBrandRepository(ctrl), nil, nil, nil).
+			GetStatsBreakdown(context.Background(), nil, nil, "v2")
+		assert.NoError(t, err)
+		assert.Equal(t, "v2", got.ScoreVersion)
+		assert.Nil(t, got.From)
+		assert.NotNil(t, got.Signals.ByStrategy)
+		assert.Empty(t, got.Signals.ByStrategy)
+	})
+
+	t.Run("シグナル取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByDateRange(gomock.Any(), gomock.Eq(&d1), gomock.Eq(&d2), gomock.Any()).Return(nil, nil)
+		analyzeRepo := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+		analyzeRepo.EXPECT().FindByCreatedAtRange(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewDailyStockPickInteractor(pickRepo, mock_repositories.NewMockStockBrandRepository(ctrl), nil, analyzeRepo, nil).
+			GetStatsBreakdown(context.Background(), &d1, &d2, "")
+		assert.EqualError(t, err, "FindByCreatedAtRange error: db error")
 	})
 }
@@ -0,0 +1,231 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// eventStudyMaxEvents 1回のイベントスタディで扱うイベント数の上限。超えた分は古いものから切り捨てる。
+const eventStudyMaxEvents = 2000
+
+// EventStudyInteractor イベントスタディ（市場モデルによる異常リターン）
+type EventStudyInteractor interface {
+	// RunEventStudy req.Source からイベントを取り出し（custom なら req.Events）、req.Benchmark に対する AAR・CAAR を求める。
+	// PreDays/PostDays/EstimationDays が 0 の項目は domain_service.DefaultEventStudyParams の値を使う。
+	RunEventStudy(ctx context.Context, req *models.EventStudyRequest) (*models.EventStudyResult, error)
+}
+
+type eventStudyInteractorImpl struct {
+	priceRepo           repositories.StockBrandsDailyPriceRepository
+	topixRepo           repositories.TopixRepository
+	nikkeiRepo          repositories.NikkeiRepository
+	finAnnouncementRepo repositories.FinAnnouncementRepository
+	analyzeRepo         repositories.AnalyzeStockBrandPriceHistoryRepository
+	dailyStockPickRepo  repositories.DailyStockPickRepository
+	stockSplitRepo      repositories.AppliedStockSplitsHistoryRepository
+}
+
+// NewEventStudyInteractor コンストラクタ
+func NewEventStudyInteractor(
+	priceRepo repositories.StockBrandsDailyPriceRepository,
+	topixRepo repositories.TopixRepository,
+	nikkeiRepo repositories.NikkeiRepository,
+	finAnnouncementRepo repositories.FinAnnouncementRepository,
+	analyzeRepo repositories.AnalyzeStockBrandPriceHistoryRepository,
+	dailyStockPickRepo repositories.DailyStockPickRepository,
+	stockSplitRepo repositories.AppliedStockSplitsHistoryRepository,
+) EventStudyInteractor {
+	return &eventStudyInteractorImpl{
+		priceRepo:           priceRepo,
+		topixRepo:           topixRepo,
+		nikkeiRepo:          nikkeiRepo,
+		finAnnouncementRepo: finAnnouncementRepo,
+		analyzeRepo:         analyzeRepo,
+		dailyStockPickRepo:  dailyStockPickRepo,
+		stockSplitRepo:      stockSplitRepo,
+	}
+}
+
+func (e *eventStudyInteractorImpl) RunEventStudy(ctx context.Context, req *models.EventStudyRequest) (*models.EventStudyResult, error) {
+	params := domain_service.DefaultEventStudyParams()
+	if req.PreDays > 0 {
+		params.PreDays = req.PreDays
+	}
+	if req.PostDays > 0 {
+		params.PostDays = req.PostDays
+	}
+	if req.EstimationDays > 0 {
+		params.EstimationDays = req.EstimationDays
+	}
+
+	loaded, err := e.loadEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	events, truncated := normalizeEvents(loaded)
+
+	listBenchmark := e.topixRepo.ListTopixDailyPrices
+	benchmark := models.EventStudyBenchmarkTopix
+	if req.Benchmark == models.EventStudyBenchmarkNikkei {
+		listBenchmark = e.nikkeiRepo.ListNikkeiStockAverageDailyPrices
+		benchmark = models.EventStudyBenchmarkNikkei
+	}
+	result, err := runEventStudy(ctx, e.priceRepo, listBenchmark, benchmark, events, params)
+	if err != nil {
+		return nil, err
+	}
+	result.Truncated = truncated
+	return result, nil
+}
+
+// loadEvents req.Source に応じてイベントを取り出す。
+func (e *eventStudyInteractorImpl) loadEvents(ctx context.Context, req *models.EventStudyRequest) ([]*models.EventStudyEvent, error) {
+	var events []*models.EventStudyEvent
+	switch req.Source {
+	case models.EventStudySourceCustom:
+		events = req.Events
+	case models.EventStudySourceFinAnnouncement:
+		// 発表日の昇順で返るので、上限で新しい側を落とさないよう期間内を全ページ読む
+		for page := 1; ; page++ {
+			anns, err := e.finAnnouncementRepo.FindWithFilter(ctx, &models.FinAnnouncementFilter{
+				From:  req.From,
+				To:    req.To,
+				Page:  page,
+				Limit: eventStudyMaxEvents,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "FindWithFilter error")
+			}
+			for _, a := range anns {
+				events = append(events, &models.EventStudyEvent{
+					TickerSymbol: a.TickerSymbol,
+					Date:         a.AnnouncementDate,
+					Label:        fmt.Sprintf("%s %s", a.FiscalYear, a.FiscalQuarter),
+				})
+			}
+			if len(anns) < eventStudyMaxEvents {
+				break
+			}
+		}
+	case models.EventStudySourceAnalyzeSignal:
+		signals, err := e.analyzeRepo.FindByCreatedAtRange(ctx, &models.SignalPerformanceFilter{
+			From:   req.From,
+			To:     req.To,
+			Method: req.Method,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "FindByCreatedAtRange error")
+		}
+		events = signalEvents(signals)
+	case models.EventStudySourceDailyPick:
+		picks, err := e.dailyStockPickRepo.ListByDateRange(ctx, &req.From, &req.To, domain_service.DailyPickScoreVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "ListByDateRange error")
+		}
+		for _, p := range picks {
+			events = append(events, &models.EventStudyEvent{
+				TickerSymbol: p.TickerSymbol,
+				Date:         p.PickDate,
+				Label:        fmt.Sprintf("rank %d", p.PickRank),
+			})
+		}
+	case models.EventStudySourceStockSplit:
+		splits, err := e.stockSplitRepo.ListBySplitDateRange(ctx, req.From, req.To)
+		if err != nil {
+			return nil, errors.Wrap(err, "ListBySplitDateRange error")
+		}
+		for _, s := range splits {
+			events = append(events, &models.EventStudyEvent{
+				TickerSymbol: s.Symbol,
+				Date:         s.SplitDate,
+				Label:        "ratio " + s.Ratio.String(),
+			})
+		}
+	default:
+		return nil, errors.Errorf("unknown event study source: %s", req.Source)
+	}
+	return events, nil
+}
+
+// signalEvents 分析履歴のシグナルをイベントにする（ラベルは手法とアクション）。Sell は売り方向として異常リターンの符号を反転させる。
+func signalEvents(signals []*models.AnalyzeStockBrandPriceHistory) []*models.EventStudyEvent {
+	events := make([]*models.EventStudyEvent, 0, len(signals))
+	for _, sg := range signals {
+		events = append(events, &models.EventStudyEvent{
+			TickerSymbol: sg.TickerSymbol,
+			Date:         sg.CreatedAt,
+			Label:        fmt.Sprintf("%s %s", sg.Method, sg.Action),
+			Short:        sg.Action == models.AnalyzeStockBrandPriceHistoryActionSell,
+		})
+	}
+	return events
+}
+
+// normalizeEvents 同じ銘柄・同じ日・同じ方向の重複を除いて日付・銘柄の昇順に並べ、eventStudyMaxEvents 件を超えれば
+// 古いものから切り捨てる。切り捨てたかどうかも返す。
+func normalizeEvents(events []*models.EventStudyEvent) ([]*models.EventStudyEvent, bool) {
+	seen := make(map[string]struct{}, len(events))
+	out := make([]*models.EventStudyEvent, 0, len(events))
+	for _, ev := range events {
+		key := fmt.Sprintf("%s|%s|%t", ev.TickerSymbol, ev.Date.Format(util.DateLayout), ev.Short)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, ev)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].Date.Equal(out[j].Date) {
+			return out[i].Date.Before(out[j].Date)
+		}
+		return out[i].TickerSymbol < out[j].TickerSymbol
+	})
+	if len(out) > eventStudyMaxEvents {
+		return out[len(out)-eventStudyMaxEvents:], true
+	}
+	return out, false
+}
+
+// runEventStudy イベントの前後に必要な日足とベンチマークを取得してイベントスタディを行う（シグナル精度評価からも使う）。
+func runEventStudy(
+	ctx context.Context,
+	priceRepo repositories.StockBrandsDailyPriceRepository,
+	listBenchmark func(ctx context.Context, from, to *time.Time) (models.IndexStockAverageDailyPrices, error),
+	benchmark string,
+	events []*models.EventStudyEvent,
+	params domain_service.EventStudyParams,
+) (*models.EventStudyResult, error) {
+	from, to, ok := domain_service.EventStudyPriceRange(events, params)
+	if !ok {
+		return domain_service.RunEventStudy(nil, nil, nil, benchmark, params), nil
+	}
+
+	symbolSet := make(map[string]struct{}, len(events))
+	symbols := make([]string, 0, len(events))
+	for _, ev := range events {
+		if _, ok := symbolSet[ev.TickerSymbol]; !ok {
+			symbolSet[ev.TickerSymbol] = struct{}{}
+			symbols = append(symbols, ev.TickerSymbol)
+		}
+	}
+
+	pricesBySymbol, err := listPricesBySymbols(ctx, priceRepo, symbols, from, to)
+	if err != nil {
+		return nil, err
+	}
+	benchmarkPrices, err := listBenchmark(ctx, &from, &to)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list %s daily prices error", benchmark)
+	}
+	return domain_service.RunEventStudy(events, pricesBySymbol, benchmarkPrices, benchmark, params), nil
+}
@@ -0,0 +1,312 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// genEventStudyPrices index の各日に、指数に小さな揺らぎを乗せた調整後終値の日足を作る。
+func genEventStudyPrices(symbol string, index models.IndexStockAverageDailyPrices) []*models.StockBrandDailyPrice {
+	out := make([]*models.StockBrandDailyPrice, 0, len(index))
+	for i, p := range index {
+		c := p.Close.Mul(decimal.NewFromFloat(1 + float64(i%3-1)*0.002))
+		out = append(out, &models.StockBrandDailyPrice{TickerSymbol: symbol, Date: p.Date, Close: c, Adjclose: c})
+	}
+	return out
+}
+
+func TestEventStudyInteractor_RunEventStudy(t *testing.T) {
+	base := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	index := genIndexPrices(260)
+	eventDate := base.AddDate(0, 0, 200)
+	from := base.AddDate(0, 0, 190)
+	to := base.AddDate(0, 0, 210)
+	params := domain_service.DefaultEventStudyParams()
+	priceFrom := eventDate.AddDate(0, 0, -params.EventStudyLookbackDays())
+	priceTo := eventDate.AddDate(0, 0, params.EventStudyLookaheadDays())
+
+	type fields struct {
+		priceRepo           func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandsDailyPriceRepository
+		topixRepo           func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository
+		nikkeiRepo          func(ctrl *gomock.Controller) *mock_repositories.MockNikkeiRepository
+		finAnnouncementRepo func(ctrl *gomock.Controller) *mock_repositories.MockFinAnnouncementRepository
+		analyzeRepo         func(ctrl *gomock.Controller) *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository
+		dailyStockPickRepo  func(ctrl *gomock.Controller) *mock_repositories.MockDailyStockPickRepository
+		stockSplitRepo      func(ctrl *gomock.Controller) *mock_repositories.MockAppliedStockSplitsHistoryRepository
+	}
+	pricesOK := func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandsDailyPriceRepository {
+		m := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		m.EXPECT().ListRangePricesBySymbols(gomock.Any(), models.ListRangePricesBySymbolsFilter{
+			Symbols:  []string{"7203"},
+			DateFrom: &priceFrom,
+			DateTo:   &priceTo,
+		}).Return(genEventStudyPrices("7203", index), nil)
+		return m
+	}
+	topixOK := func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository {
+		m := mock_repositories.NewMockTopixRepository(ctrl)
+		m.EXPECT().ListTopixDailyPrices(gomock.Any(), &priceFrom, &priceTo).Return(index, nil)
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		req     *models.EventStudyRequest
+		fields  fields
+		want    func(t *testing.T, got *models.EventStudyResult)
+		wantErr bool
+	}{
+		{
+			name: "正常系: custom のイベントを TOPIX に対して評価する（重複は1件にまとめる）",
+			req: &models.EventStudyRequest{
+				Source: models.EventStudySourceCustom,
+				Events: []*models.EventStudyEvent{
+					{TickerSymbol: "7203", Date: eventDate},
+					{TickerSymbol: "7203", Date: eventDate},
+				},
+			},
+			fields: fields{priceRepo: pricesOK, topixRepo: topixOK},
+			want: func(t *testing.T, got *models.EventStudyResult) {
+				assert.Equal(t, models.EventStudyBenchmarkTopix, got.Benchmark)
+				assert.Equal(t, 1, got.EventCount)
+				assert.Equal(t, 1, got.EvaluatedCount)
+				assert.Len(t, got.Days, params.PreDays+params.PostDays+1)
+				require.Len(t, got.Events, 1)
+				assert.InDelta(t, 1.0, got.Events[0].Beta.InexactFloat64(), 0.2)
+			},
+		},
+		{
+			name: "正常系: 決算発表を日経平均に対して評価する",
+			req: &models.EventStudyRequest{
+				Source:    models.EventStudySourceFinAnnouncement,
+				Benchmark: models.EventStudyBenchmarkNikkei,
+				From:      from,
+				To:        to,
+			},
+			fields: fields{
+				priceRepo: pricesOK,
+				nikkeiRepo: func(ctrl *gomock.Controller) *mock_repositories.MockNikkeiRepository {
+					m := mock_repositories.NewMockNikkeiRepository(ctrl)
+					m.EXPECT().ListNikkeiStockAverageDailyPrices(gomock.Any(), &priceFrom, &priceTo).Return(index, nil)
+					return m
+				},
+				finAnnouncementRepo: func(ctrl *gomock.Controller) *mock_repositories.MockFinAnnouncementRepository {
+					m := mock_repositories.NewMockFinAnnouncementRepository(ctrl)
+					m.EXPECT().FindWithFilter(gomock.Any(), &models.FinAnnouncementFilter{From: from, To: to, Page: 1, Limit: eventStudyMaxEvents}).
+						Return([]*models.FinAnnouncement{{TickerSymbol: "7203", AnnouncementDate: eventDate, FiscalYear: "2021", FiscalQuarter: "Q2"}}, nil)
+					return m
+				},
+			},
+			want: func(t *testing.T, got *models.EventStudyResult) {
+				assert.Equal(t, models.EventStudyBenchmarkNikkei, got.Benchmark)
+				assert.False(t, got.Truncated)
+				require.Len(t, got.Events, 1)
+				assert.Equal(t, "2021 Q2", got.Events[0].Label)
+			},
+		},
+		{
+			name: "正常系: 分析履歴のシグナルを手法で絞って評価する",
+			req: &models.EventStudyRequest{
+				Source: models.EventStudySourceAnalyzeSignal,
+				From:   from,
+				To:     to,
+				Method: models.AnalyzeStockBrandPriceHistoryMethodFindMACDBullishV1,
+			},
+			fields: fields{
+				priceRepo: pricesOK,
+				topixRepo: topixOK,
+				analyzeRepo: func(ctrl *gomock.Controller) *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository {
+					m := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+					m.EXPECT().FindByCreatedAtRange(gomock.Any(), &models.SignalPerformanceFilter{
+						From:   from,
+						To:     to,
+						Method: models.AnalyzeStockBrandPriceHistoryMethodFindMACDBullishV1,
+					}).Return([]*models.AnalyzeStockBrandPriceHistory{
+						spSignal("7203", models.AnalyzeStockBrandPriceHistoryMethodFindMACDBullishV1, models.AnalyzeStockBrandPriceHistoryActionBuy, eventDate),
+					}, nil)
+					return m
+				},
+			},
+			want: func(t *testing.T, got *models.EventStudyResult) {
+				assert.Equal(t, 1, got.EvaluatedCount)
+			},
+		},
+		{
+			name: "正常系: 買い候補を現行スコアバージョンで評価する",
+			req:  &models.EventStudyRequest{Source: models.EventStudySourceDailyPick, From: from, To: to},
+			fields: fields{
+				priceRepo: pricesOK,
+				topixRepo: topixOK,
+				dailyStockPickRepo: func(ctrl *gomock.Controller) *mock_repositories.MockDailyStockPickRepository {
+					m := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+					m.EXPECT().ListByDateRange(gomock.Any(), &from, &to, domain_service.DailyPickScoreVersion).
+						Return([]*models.DailyStockPick{{TickerSymbol: "7203", PickDate: eventDate, PickRank: 1}}, nil)
+					return m
+				},
+			},
+			want: func(t *testing.T, got *models.EventStudyResult) {
+				require.Len(t, got.Events, 1)
+				assert.Equal(t, "rank 1", got.Events[0].Label)
+			},
+		},
+		{
+			name: "正常系: 株式分割を評価する",
+			req:  &models.EventStudyRequest{Source: models.EventStudySourceStockSplit, From: from, To: to},
+			fields: fields{
+				priceRepo: pricesOK,
+				topixRepo: topixOK,
+				stockSplitRepo: func(ctrl *gomock.Controller) *mock_repositories.MockAppliedStockSplitsHistoryRepository {
+					m := mock_repositories.NewMockAppliedStockSplitsHistoryRepository(ctrl)
+					m.EXPECT().ListBySplitDateRange(gomock.Any(), from, to).
+						Return([]*models.AppliedStockSplitHistory{{Symbol: "7203", SplitDate: eventDate, Ratio: decimal.NewFromInt(2)}}, nil)
+					return m
+				},
+			},
+			want: func(t *testing.T, got *models.EventStudyResult) {
+				require.Len(t, got.Events, 1)
+				assert.Equal(t, "ratio 2", got.Events[0].Label)
+			},
+		},
+		{
+			name: "正常系: イベントが無ければ日足を取得せず空の結果",
+			req:  &models.EventStudyRequest{Source: models.EventStudySourceStockSplit, From: from, To: to, PostDays: 10},
+			fields: fields{
+				stockSplitRepo: func(ctrl *gomock.Controller) *mock_repositories.MockAppliedStockSplitsHistoryRepository {
+					m := mock_repositories.NewMockAppliedStockSplitsHistoryRepository(ctrl)
+					m.EXPECT().ListBySplitDateRange(gomock.Any(), from, to).Return(nil, nil)
+					return m
+				},
+			},
+			want: func(t *testing.T, got *models.EventStudyResult) {
+				assert.Equal(t, 0, got.EventCount)
+				assert.Equal(t, 10, got.PostDays)
+				assert.Empty(t, got.Days)
+			},
+		},
+		{
+			name: "異常系: 決算発表の取得エラー",
+			req:  &models.EventStudyRequest{Source: models.EventStudySourceFinAnnouncement, From: from, To: to},
+			fields: fields{
+				finAnnouncementRepo: func(ctrl *gomock.Controller) *mock_repositories.MockFinAnnouncementRepository {
+					m := mock_repositories.NewMockFinAnnouncementRepository(ctrl)
+					m.EXPECT().FindWithFilter(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+					return m
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系: ベンチマークの取得エラー",
+			req: &models.EventStudyRequest{
+				Source: models.EventStudySourceCustom,
+				Events: []*models.EventStudyEvent{{TickerSymbol: "7203", Date: eventDate}},
+			},
+			fields: fields{
+				priceRepo: pricesOK,
+				topixRepo: func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository {
+					m := mock_repositories.NewMockTopixRepository(ctrl)
+					m.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+					return m
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+			if tt.fields.priceRepo != nil {
+				priceRepo = tt.fields.priceRepo(ctrl)
+			}
+			topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+			if tt.fields.topixRepo != nil {
+				topixRepo = tt.fields.topixRepo(ctrl)
+			}
+			nikkeiRepo := mock_repositories.NewMockNikkeiRepository(ctrl)
+			if tt.fields.nikkeiRepo != nil {
+				nikkeiRepo = tt.fields.nikkeiRepo(ctrl)
+			}
+			finAnnouncementRepo := mock_repositories.NewMockFinAnnouncementRepository(ctrl)
+			if tt.fields.finAnnouncementRepo != nil {
+				finAnnouncementRepo = tt.fields.finAnnouncementRepo(ctrl)
+			}
+			analyzeRepo := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+			if tt.fields.analyzeRepo != nil {
+				analyzeRepo = tt.fields.analyzeRepo(ctrl)
+			}
+			dailyStockPickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+			if tt.fields.dailyStockPickRepo != nil {
+				dailyStockPickRepo = tt.fields.dailyStockPickRepo(ctrl)
+			}
+			stockSplitRepo := mock_repositories.NewMockAppliedStockSplitsHistoryRepository(ctrl)
+			if tt.fields.stockSplitRepo != nil {
+				stockSplitRepo = tt.fields.stockSplitRepo(ctrl)
+			}
+
+			interactor := NewEventStudyInteractor(priceRepo, topixRepo, nikkeiRepo, finAnnouncementRepo, analyzeRepo, dailyStockPickRepo, stockSplitRepo)
+			got, err := interactor.RunEventStudy(context.Background(), tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.want(t, got)
+		})
+	}
+}
+
+func TestNormalizeEvents(t *testing.T) {
+	d := func(day int) time.Time { return time.Date(2024, 4, day, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("重複を除いて日付・銘柄の昇順に並べる", func(t *testing.T) {
+		got, truncated := normalizeEvents([]*models.EventStudyEvent{
+			{TickerSymbol: "2222", Date: d(2)},
+			{TickerSymbol: "1111", Date: d(2)},
+			{TickerSymbol: "3333", Date: d(1)},
+			{TickerSymbol: "1111", Date: d(2).Add(9 * time.Hour)}, // 同じ日の重複
+		})
+		assert.False(t, truncated)
+		require.Len(t, got, 3)
+		assert.Equal(t, "3333", got[0].TickerSymbol)
+		assert.Equal(t, "1111", got[1].TickerSymbol)
+		assert.Equal(t, "2222", got[2].TickerSymbol)
+	})
+
+	t.Run("同じ銘柄・同じ日でも Buy と Sell は別のイベント", func(t *testing.T) {
+		got, _ := normalizeEvents(signalEvents([]*models.AnalyzeStockBrandPriceHistory{
+			spSignal("7203", models.AnalyzeStockBrandPriceHistoryMethodFindMACDBullishV1, models.AnalyzeStockBrandPriceHistoryActionBuy, d(1)),
+			spSignal("7203", models.AnalyzeStockBrandPriceHistoryMethodFindMACDBullishV1, models.AnalyzeStockBrandPriceHistoryActionSell, d(1)),
+		}))
+		require.Len(t, got, 2)
+		assert.False(t, got[0].Short)
+		assert.True(t, got[1].Short)
+	})
+
+	t.Run("上限を超えたら古いものから切り捨てる", func(t *testing.T) {
+		base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		events := make([]*models.EventStudyEvent, 0, eventStudyMaxEvents+2)
+		for i := range eventStudyMaxEvents + 2 {
+			events = append(events, &models.EventStudyEvent{TickerSymbol: "7203", Date: base.AddDate(0, 0, i)})
+		}
+		got, truncated := normalizeEvents(events)
+		assert.True(t, truncated)
+		require.Len(t, got, eventStudyMaxEvents)
+		assert.Equal(t, base.AddDate(0, 0, 2), got[0].Date)
+		assert.Equal(t, base.AddDate(0, 0, eventStudyMaxEvents+1), got[len(got)-1].Date)
+	})
+}
@@ -3,6 +3,7 @@ package usecase
 
 import (
 	"context"
+	"log"
 
 	"github.com/Code0716/stock-price-repository/models"
 	"github.com/pkg/errors"
@@ -48,5 +49,47 @@ func (si *stockBrandInteractorImpl) GetStockBrands(ctx context.Context, keyword
 		result.Brands = brands[:limit]
 	}
 
+	// RS レーティングは付加情報なので、取得できなくても銘柄一覧は返す
+	if err := si.attachRelativeStrengthRatings(ctx, result.Brands); err != nil {
+		log.Printf("GetStockBrands: RSレーティングを付与せずに返します: %v", err)
+	}
+
 	return result, nil
 }
+
+// attachRelativeStrengthRatings 最新の算出日の RS レーティングを銘柄に付与する（未算出の銘柄は nil のまま）。
+func (si *stockBrandInteractorImpl) attachRelativeStrengthRatings(ctx context.Context, brands []*models.StockBrand) error {
+	if len(brands) == 0 {
+		return nil
+	}
+	latest, err := si.relativeStrengthRepository.FindLatestDate(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "RSレーティング算出日の取得に失敗しました")
+	}
+	if latest == nil {
+		return nil
+	}
+
+	symbols := make([]string, 0, len(brands))
+	for _, b := range brands {
+		symbols = append(symbols, b.TickerSymbol)
+	}
+	ratings, err := si.relativeStrengthRepository.List(ctx, models.RelativeStrengthFilter{
+		Date:    *latest,
+		Symbols: symbols,
+	})
+	if err != nil {
+		return errors.Wrap(err, "RSレーティングの取得に失敗しました")
+	}
+
+	bySymbol := make(map[string]int, len(ratings))
+	for _, r := range ratings {
+		bySymbol[r.TickerSymbol] = r.Rating
+	}
+	for _, b := range brands {
+		if rating, ok := bySymbol[b.TickerSymbol]; ok {
+			b.RelativeStrengthRating = &rating
+		}
+	}
+	return nil
+}
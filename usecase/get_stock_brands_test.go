@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -15,7 +16,8 @@ import (
 
 func TestStockBrandInteractorImpl_GetStockBrands(t *testing.T) {
 	type fields struct {
-		stockBrandRepository func(ctrl *gomock.Controller) repositories.StockBrandRepository
+		stockBrandRepository       func(ctrl *gomock.Controller) repositories.StockBrandRepository
+		relativeStrengthRepository func(ctrl *gomock.Controller) repositories.RelativeStrengthRepository // nil なら RS 未算出
 	}
 	type args struct {
 		ctx             context.Context
@@ -24,6 +26,8 @@ func TestStockBrandInteractorImpl_GetStockBrands(t *testing.T) {
 		limit           int
 		onlyMainMarkets bool
 	}
+	rsDate := time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)
+	rating87 := 87
 
 	tests := []struct {
 		name    string
@@ -609,6 +613,83 @@ func TestStockBrandInteractorImpl_GetStockBrands(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "正常系: 最新のRSレーティングを付与する",
+			fields: fields{
+				stockBrandRepository: func(ctrl *gomock.Controller) repositories.StockBrandRepository {
+					m := mock_repositories.NewMockStockBrandRepository(ctrl)
+					m.EXPECT().FindWithFilter(gomock.Any(), gomock.Any()).Return([]*models.StockBrand{
+						{ID: "1", TickerSymbol: "7203"},
+						{ID: "2", TickerSymbol: "7267"},
+					}, nil)
+					return m
+				},
+				relativeStrengthRepository: func(ctrl *gomock.Controller) repositories.RelativeStrengthRepository {
+					m := mock_repositories.NewMockRelativeStrengthRepository(ctrl)
+					m.EXPECT().FindLatestDate(gomock.Any(), nil).Return(&rsDate, nil)
+					m.EXPECT().List(gomock.Any(), models.RelativeStrengthFilter{
+						Date:    rsDate,
+						Symbols: []string{"7203", "7267"},
+					}).Return([]*models.RelativeStrengthRating{{TickerSymbol: "7203", Rating: 87}}, nil)
+					return m
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &models.PaginatedStockBrands{
+				Brands: []*models.StockBrand{
+					{ID: "1", TickerSymbol: "7203", RelativeStrengthRating: &rating87},
+					{ID: "2", TickerSymbol: "7267"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: RS算出日の取得エラーでもRSなしで銘柄一覧を返す",
+			fields: fields{
+				stockBrandRepository: func(ctrl *gomock.Controller) repositories.StockBrandRepository {
+					m := mock_repositories.NewMockStockBrandRepository(ctrl)
+					m.EXPECT().FindWithFilter(gomock.Any(), gomock.Any()).Return([]*models.StockBrand{{ID: "1", TickerSymbol: "7203"}}, nil)
+					return m
+				},
+				relativeStrengthRepository: func(ctrl *gomock.Controller) repositories.RelativeStrengthRepository {
+					m := mock_repositories.NewMockRelativeStrengthRepository(ctrl)
+					m.EXPECT().FindLatestDate(gomock.Any(), nil).Return(nil, errors.New("db error"))
+					return m
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &models.PaginatedStockBrands{
+				Brands: []*models.StockBrand{{ID: "1", TickerSymbol: "7203"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: RSレーティングの取得エラーでもRSなしで銘柄一覧を返す",
+			fields: fields{
+				stockBrandRepository: func(ctrl *gomock.Controller) repositories.StockBrandRepository {
+					m := mock_repositories.NewMockStockBrandRepository(ctrl)
+					m.EXPECT().FindWithFilter(gomock.Any(), gomock.Any()).Return([]*models.StockBrand{{ID: "1", TickerSymbol: "7203"}}, nil)
+					return m
+				},
+				relativeStrengthRepository: func(ctrl *gomock.Controller) repositories.RelativeStrengthRepository {
+					m := mock_repositories.NewMockRelativeStrengthRepository(ctrl)
+					m.EXPECT().FindLatestDate(gomock.Any(), nil).Return(&rsDate, nil)
+					m.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+					return m
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &models.PaginatedStockBrands{
+				Brands: []*models.StockBrand{{ID: "1", TickerSymbol: "7203"}},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -617,7 +698,15 @@ func TestStockBrandInteractorImpl_GetStockBrands(t *testing.T) {
 			defer ctrl.Finish()
 
 			r := tt.fields.stockBrandRepository(ctrl)
-			si := NewStockBrandInteractor(nil, r, nil, nil, nil, nil, nil, nil, nil)
+			var rs repositories.RelativeStrengthRepository
+			if tt.fields.relativeStrengthRepository != nil {
+				rs = tt.fields.relativeStrengthRepository(ctrl)
+			} else {
+				m := mock_repositories.NewMockRelativeStrengthRepository(ctrl)
+				m.EXPECT().FindLatestDate(gomock.Any(), nil).Return(nil, nil).AnyTimes()
+				rs = m
+			}
+			si := NewStockBrandInteractor(nil, r, nil, nil, nil, nil, nil, rs, nil, nil)
 
 			got, err := si.GetStockBrands(tt.args.ctx, tt.args.keyword, tt.args.symbolFrom, tt.args.limit, tt.args.onlyMainMarkets)
 			if (err != nil) != tt.wantErr {
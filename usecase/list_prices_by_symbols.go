@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// pricesBySymbolsChunk 複数銘柄の日足を一括取得する際の1クエリあたりの銘柄数。
+const pricesBySymbolsChunk = 200
+
+// listPricesBySymbols 銘柄をチャンクに分けて日足を一括取得し、銘柄ごとの日付昇順スライスにまとめる。
+func listPricesBySymbols(ctx context.Context, repo repositories.StockBrandsDailyPriceRepository, symbols []string, from, to time.Time) (map[string][]*models.StockBrandDailyPrice, error) {
+	pricesBySymbol := make(map[string][]*models.StockBrandDailyPrice, len(symbols))
+	for start := 0; start < len(symbols); start += pricesBySymbolsChunk {
+		end := start + pricesBySymbolsChunk
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+		prices, err := repo.ListRangePricesBySymbols(ctx, models.ListRangePricesBySymbolsFilter{
+			Symbols:  symbols[start:end],
+			DateFrom: &from,
+			DateTo:   &to,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "ListRangePricesBySymbols error")
+		}
+		for _, pr := range prices {
+			pricesBySymbol[pr.TickerSymbol] = append(pricesBySymbol[pr.TickerSymbol], pr)
+		}
+	}
+	return pricesBySymbol, nil
+}
@@ -0,0 +1,142 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// markAnalyzeSignalsSymbolChunk 日足を一括取得する銘柄数の単位（古いシグナルが多い初回でも取得量を抑える）。
+const markAnalyzeSignalsSymbolChunk = 100
+
+type MarkAnalyzeSignalsInteractor interface {
+	// MarkAnalyzeSignals 建玉中の分析シグナルを asOf 以前の最新終値で値洗いし、current_price・price_difference を保存する。
+	// 価格はシグナル日以降に適用された分割・併合を調整し、trade_price と同じ株数基準にする。
+	// closeAfterDays > 0 なら、シグナル日から closeAfterDays 営業日目に達したシグナルをその日の終値でクローズし、出口価格と保有リターンを記録する。
+	MarkAnalyzeSignals(ctx context.Context, asOf time.Time, closeAfterDays int) (*models.AnalyzeSignalMarkResult, error)
+}
+
+type markAnalyzeSignalsInteractorImpl struct {
+	tx                                          repositories.Transaction
+	analyzeStockBrandPriceHistoryRepository     repositories.AnalyzeStockBrandPriceHistoryRepository
+	stockBrandsDailyStockPriceRepository        repositories.StockBrandsDailyPriceRepository
+	appliedStockSplitsHistoryRepository         repositories.AppliedStockSplitsHistoryRepository
+	appliedStockConsolidationsHistoryRepository repositories.AppliedStockConsolidationsHistoryRepository
+}
+
+func NewMarkAnalyzeSignalsInteractor(
+	tx repositories.Transaction,
+	analyzeStockBrandPriceHistoryRepository repositories.AnalyzeStockBrandPriceHistoryRepository,
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
+	appliedStockSplitsHistoryRepository repositories.AppliedStockSplitsHistoryRepository,
+	appliedStockConsolidationsHistoryRepository repositories.AppliedStockConsolidationsHistoryRepository,
+) MarkAnalyzeSignalsInteractor {
+	return &markAnalyzeSignalsInteractorImpl{
+		tx:                                          tx,
+		analyzeStockBrandPriceHistoryRepository:     analyzeStockBrandPriceHistoryRepository,
+		stockBrandsDailyStockPriceRepository:        stockBrandsDailyStockPriceRepository,
+		appliedStockSplitsHistoryRepository:         appliedStockSplitsHistoryRepository,
+		appliedStockConsolidationsHistoryRepository: appliedStockConsolidationsHistoryRepository,
+	}
+}
+
+func (mi *markAnalyzeSignalsInteractorImpl) MarkAnalyzeSignals(ctx context.Context, asOf time.Time, closeAfterDays int) (*models.AnalyzeSignalMarkResult, error) {
+	if closeAfterDays < 0 {
+		return nil, errors.New("closeAfterDays must be non-negative")
+	}
+
+	open, err := mi.analyzeStockBrandPriceHistoryRepository.ListOpen(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListOpen error")
+	}
+	result := &models.AnalyzeSignalMarkResult{Open: len(open)}
+	if len(open) == 0 {
+		return result, nil
+	}
+
+	// ListOpen は created_at 昇順なので先頭が最古のシグナル日
+	oldest := open[0].CreatedAt
+	splits, err := mi.appliedStockSplitsHistoryRepository.ListBySplitDateRange(ctx, oldest, asOf)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListBySplitDateRange error")
+	}
+	consolidations, err := mi.appliedStockConsolidationsHistoryRepository.ListByConsolidationDateRange(ctx, oldest, asOf)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListByConsolidationDateRange error")
+	}
+	splitsBySymbol := make(map[string][]*models.AppliedStockSplitHistory)
+	for _, s := range splits {
+		splitsBySymbol[s.Symbol] = append(splitsBySymbol[s.Symbol], s)
+	}
+	consolidationsBySymbol := make(map[string][]*models.AppliedStockConsolidationHistory)
+	for _, c := range consolidations {
+		consolidationsBySymbol[c.Symbol] = append(consolidationsBySymbol[c.Symbol], c)
+	}
+
+	bySymbol := make(map[string][]*models.AnalyzeStockBrandPriceHistory)
+	symbols := make([]string, 0)
+	for _, h := range open {
+		if _, ok := bySymbol[h.TickerSymbol]; !ok {
+			symbols = append(symbols, h.TickerSymbol)
+		}
+		bySymbol[h.TickerSymbol] = append(bySymbol[h.TickerSymbol], h)
+	}
+
+	for start := 0; start < len(symbols); start += markAnalyzeSignalsSymbolChunk {
+		end := min(start+markAnalyzeSignalsSymbolChunk, len(symbols))
+		chunk := symbols[start:end]
+
+		from := bySymbol[chunk[0]][0].CreatedAt
+		for _, symbol := range chunk[1:] {
+			if d := bySymbol[symbol][0].CreatedAt; d.Before(from) {
+				from = d
+			}
+		}
+		prices, err := mi.stockBrandsDailyStockPriceRepository.ListRangePricesBySymbols(ctx, models.ListRangePricesBySymbolsFilter{
+			Symbols:  chunk,
+			DateFrom: &from,
+			DateTo:   &asOf,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "ListRangePricesBySymbols error")
+		}
+		// 返り値は ticker_symbol, date 昇順のため、シンボルごとに振り分けるだけで日付昇順が保たれる
+		pricesBySymbol := make(map[string][]*models.StockBrandDailyPrice, len(chunk))
+		for _, p := range prices {
+			pricesBySymbol[p.TickerSymbol] = append(pricesBySymbol[p.TickerSymbol], p)
+		}
+
+		marks := make([]*models.AnalyzeStockBrandPriceHistoryMark, 0)
+		for _, symbol := range chunk {
+			for _, h := range bySymbol[symbol] {
+				mark := domain_service.MarkAnalyzeSignal(h, pricesBySymbol[symbol], splitsBySymbol[symbol], consolidationsBySymbol[symbol], closeAfterDays)
+				if mark == nil {
+					result.Skipped++
+					continue
+				}
+				marks = append(marks, mark)
+				result.Marked++
+				if mark.ClosedAt != nil {
+					result.Closed++
+				}
+			}
+		}
+		if len(marks) == 0 {
+			continue
+		}
+
+		if err := mi.tx.DoInTx(ctx, func(ctx context.Context) error {
+			return mi.analyzeStockBrandPriceHistoryRepository.UpdateMarks(ctx, marks)
+		}); err != nil {
+			return nil, errors.Wrap(err, "UpdateMarks error")
+		}
+	}
+
+	return result, nil
+}
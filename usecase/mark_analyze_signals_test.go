@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestMarkAnalyzeSignalsInteractorImpl_MarkAnalyzeSignals(t *testing.T) {
+	asOf := time.Date(2024, 5, 20, 0, 0, 0, 0, time.Local)
+	d1 := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+	d2 := time.Date(2024, 5, 16, 0, 0, 0, 0, time.Local)
+	bar := func(symbol string, date time.Time, closePrice int64) *models.StockBrandDailyPrice {
+		return &models.StockBrandDailyPrice{TickerSymbol: symbol, Date: date, Close: decimal.NewFromInt(closePrice)}
+	}
+	open := []*models.AnalyzeStockBrandPriceHistory{
+		{ID: "h1", TickerSymbol: "7203", TradePrice: decimal.NewFromInt(1000), Action: models.AnalyzeStockBrandPriceHistoryActionBuy, CreatedAt: d1},
+		{ID: "h2", TickerSymbol: "6758", TradePrice: decimal.NewFromInt(2000), Action: models.AnalyzeStockBrandPriceHistoryActionBuy, CreatedAt: d2},
+		{ID: "h3", TickerSymbol: "9999", TradePrice: decimal.NewFromInt(500), Action: models.AnalyzeStockBrandPriceHistoryActionBuy, CreatedAt: d2},
+	}
+
+	tests := []struct {
+		name           string
+		closeAfterDays int
+		setup          func(
+			analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository,
+			priceRepo *mock_repositories.MockStockBrandsDailyPriceRepository,
+			splitRepo *mock_repositories.MockAppliedStockSplitsHistoryRepository,
+			consolidationRepo *mock_repositories.MockAppliedStockConsolidationsHistoryRepository,
+			tx *mock_repositories.MockTransaction,
+		)
+		want    *models.AnalyzeSignalMarkResult
+		wantErr string
+	}{
+		{
+			name:           "正常系: 分割を調整して値洗いし、N営業日経過したシグナルをクローズする",
+			closeAfterDays: 2,
+			setup: func(
+				analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository,
+				priceRepo *mock_repositories.MockStockBrandsDailyPriceRepository,
+				splitRepo *mock_repositories.MockAppliedStockSplitsHistoryRepository,
+				consolidationRepo *mock_repositories.MockAppliedStockConsolidationsHistoryRepository,
+				tx *mock_repositories.MockTransaction,
+			) {
+				analyzeRepo.EXPECT().ListOpen(gomock.Any()).Return(open, nil)
+				splitRepo.EXPECT().ListBySplitDateRange(gomock.Any(), d1, asOf).Return([]*models.AppliedStockSplitHistory{
+					{Symbol: "6758", SplitDate: d2.AddDate(0, 0, 1), Ratio: decimal.NewFromInt(2)},
+				}, nil)
+				consolidationRepo.EXPECT().ListByConsolidationDateRange(gomock.Any(), d1, asOf).Return(nil, nil)
+				priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), models.ListRangePricesBySymbolsFilter{
+					Symbols:  []string{"7203", "6758", "9999"},
+					DateFrom: &d1,
+					DateTo:   &asOf,
+				}).Return([]*models.StockBrandDailyPrice{
+					bar("6758", d2, 2000),
+					bar("6758", d2.AddDate(0, 0, 1), 1050),
+					bar("7203", d1, 1000),
+					bar("7203", d1.AddDate(0, 0, 3), 1020),
+					bar("7203", d1.AddDate(0, 0, 4), 1080),
+					bar("7203", d1.AddDate(0, 0, 5), 1200),
+				}, nil)
+				tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				analyzeRepo.EXPECT().UpdateMarks(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, marks []*models.AnalyzeStockBrandPriceHistoryMark) error {
+						require.Len(t, marks, 2)
+						assert.Equal(t, "h1", marks[0].ID)
+						require.NotNil(t, marks[0].ClosedAt)
+						assert.Equal(t, d1.AddDate(0, 0, 4), *marks[0].ClosedAt)
+						assert.True(t, decimal.NewFromInt(1080).Equal(*marks[0].ExitPrice))
+						assert.True(t, decimal.RequireFromString("0.08").Equal(*marks[0].HoldingReturn))
+
+						assert.Equal(t, "h2", marks[1].ID)
+						assert.Nil(t, marks[1].ClosedAt)
+						assert.True(t, decimal.NewFromInt(2100).Equal(marks[1].CurrentPrice))
+						assert.True(t, decimal.NewFromInt(100).Equal(marks[1].PriceDifference))
+						return nil
+					})
+			},
+			want: &models.AnalyzeSignalMarkResult{Open: 3, Marked: 2, Closed: 1, Skipped: 1},
+		},
+		{
+			name: "正常系: 建玉中のシグナルが無ければ何もしない",
+			setup: func(
+				analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository,
+				_ *mock_repositories.MockStockBrandsDailyPriceRepository,
+				_ *mock_repositories.MockAppliedStockSplitsHistoryRepository,
+				_ *mock_repositories.MockAppliedStockConsolidationsHistoryRepository,
+				_ *mock_repositories.MockTransaction,
+			) {
+				analyzeRepo.EXPECT().ListOpen(gomock.Any()).Return(nil, nil)
+			},
+			want: &models.AnalyzeSignalMarkResult{},
+		},
+		{
+			name:           "異常系: closeAfterDays が負",
+			closeAfterDays: -1,
+			wantErr:        "closeAfterDays must be non-negative",
+		},
+		{
+			name: "異常系: 書き込みエラー",
+			setup: func(
+				analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository,
+				priceRepo *mock_repositories.MockStockBrandsDailyPriceRepository,
+				splitRepo *mock_repositories.MockAppliedStockSplitsHistoryRepository,
+				consolidationRepo *mock_repositories.MockAppliedStockConsolidationsHistoryRepository,
+				tx *mock_repositories.MockTransaction,
+			) {
+				analyzeRepo.EXPECT().ListOpen(gomock.Any()).Return(open[:1], nil)
+				splitRepo.EXPECT().ListBySplitDateRange(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				consolidationRepo.EXPECT().ListByConsolidationDateRange(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return([]*models.StockBrandDailyPrice{bar("7203", d1, 1000)}, nil)
+				tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				analyzeRepo.EXPECT().UpdateMarks(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			},
+			wantErr: "UpdateMarks error: db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			analyzeRepo := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+			priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+			splitRepo := mock_repositories.NewMockAppliedStockSplitsHistoryRepository(ctrl)
+			consolidationRepo := mock_repositories.NewMockAppliedStockConsolidationsHistoryRepository(ctrl)
+			tx := mock_repositories.NewMockTransaction(ctrl)
+			if tt.setup != nil {
+				tt.setup(analyzeRepo, priceRepo, splitRepo, consolidationRepo, tx)
+			}
+
+			interactor := NewMarkAnalyzeSignalsInteractor(tx, analyzeRepo, priceRepo, splitRepo, consolidationRepo)
+			got, err := interactor.MarkAnalyzeSignals(context.Background(), asOf, tt.closeAfterDays)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
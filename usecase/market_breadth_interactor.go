@@ -0,0 +1,149 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+const (
+	// marketBreadthPriceLookbackDays 52週高値・安値と200日線を判定するために対象期間より前に取得する暦日数。
+	marketBreadthPriceLookbackDays = 400
+	// marketBreadthChainDays 騰落レシオ（25日）の継続計算に使う保存済みの直近営業日数。
+	marketBreadthChainDays = 24
+	// marketBreadthDefaultYears from 省略時の対象期間（to から遡る年数）。
+	marketBreadthDefaultYears = 1
+)
+
+type marketBreadthInteractorImpl struct {
+	stockBrandRepository                 repositories.StockBrandRepository
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
+	marketBreadthRepository              repositories.MarketBreadthRepository
+}
+
+// MarketBreadthInteractor 騰落指標（マーケットブレッドス）のインターフェース。
+type MarketBreadthInteractor interface {
+	// CalculateMarketBreadth from〜to の各営業日（主要市場の日足が1件でもある日）について、主要市場全体と市場コード別の騰落指標を算出して保存し、算出した営業日数を返す。
+	// A/D ライン・騰落レシオ・マクレラン・オシレーターは from より前の保存済みの値から継続するため、過去分は古い日から順に算出すること。
+	CalculateMarketBreadth(ctx context.Context, from, to time.Time) (int, error)
+	// GetMarketBreadth 指定市場（all / 市場コード）の騰落指標を date 昇順で返す。
+	// from 省略時は to（省略時は現在）から1年前を起点とする。
+	GetMarketBreadth(ctx context.Context, market string, from, to *time.Time) (*models.MarketBreadthSeries, error)
+}
+
+func NewMarketBreadthInteractor(
+	stockBrandRepository repositories.StockBrandRepository,
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
+	marketBreadthRepository repositories.MarketBreadthRepository,
+) MarketBreadthInteractor {
+	return &marketBreadthInteractorImpl{
+		stockBrandRepository:                 stockBrandRepository,
+		stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
+		marketBreadthRepository:              marketBreadthRepository,
+	}
+}
+
+func (mi *marketBreadthInteractorImpl) CalculateMarketBreadth(ctx context.Context, from, to time.Time) (int, error) {
+	brands, err := mi.stockBrandRepository.FindAllMainMarkets(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "FindAllMainMarkets error")
+	}
+	symbols := make([]string, 0, len(brands))
+	for _, b := range brands {
+		symbols = append(symbols, b.TickerSymbol)
+	}
+
+	pricesBySymbol, err := listPricesBySymbols(ctx, mi.stockBrandsDailyStockPriceRepository, symbols, from.AddDate(0, 0, -marketBreadthPriceLookbackDays), to)
+	if err != nil {
+		return 0, err
+	}
+
+	days := tradingDaysBetween(pricesBySymbol, from, to)
+	if len(days) == 0 {
+		return 0, nil
+	}
+
+	pricesByMarket := make(map[string][][]*models.StockBrandDailyPrice, len(models.MarketBreadthMarkets))
+	for _, b := range brands {
+		prices := pricesBySymbol[b.TickerSymbol]
+		if len(prices) == 0 {
+			continue
+		}
+		pricesByMarket[models.MarketBreadthMarketAll] = append(pricesByMarket[models.MarketBreadthMarketAll], prices)
+		pricesByMarket[b.MarketCode] = append(pricesByMarket[b.MarketCode], prices)
+	}
+
+	breadths := make([]*models.MarketBreadth, 0, len(days)*len(models.MarketBreadthMarkets))
+	for _, market := range models.MarketBreadthMarkets {
+		rows := domain_service.CountMarketBreadth(market, pricesByMarket[market], days)
+		prev, err := mi.marketBreadthRepository.ListMarketBreadthsBefore(ctx, market, days[0], marketBreadthChainDays)
+		if err != nil {
+			return 0, errors.Wrap(err, "ListMarketBreadthsBefore error")
+		}
+		domain_service.ChainMarketBreadth(prev, rows)
+		breadths = append(breadths, rows...)
+	}
+
+	if err := mi.marketBreadthRepository.UpsertMarketBreadths(ctx, breadths); err != nil {
+		return 0, errors.Wrap(err, "UpsertMarketBreadths error")
+	}
+	return len(days), nil
+}
+
+func (mi *marketBreadthInteractorImpl) GetMarketBreadth(ctx context.Context, market string, from, to *time.Time) (*models.MarketBreadthSeries, error) {
+	dateTo := time.Now()
+	if to != nil {
+		dateTo = *to
+	}
+	dateFrom := dateTo.AddDate(-marketBreadthDefaultYears, 0, 0)
+	if from != nil {
+		dateFrom = *from
+	}
+
+	items, err := mi.marketBreadthRepository.ListMarketBreadths(ctx, models.MarketBreadthFilter{
+		Market: market,
+		From:   &dateFrom,
+		To:     &dateTo,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListMarketBreadths error")
+	}
+	return &models.MarketBreadthSeries{
+		Market: market,
+		From:   dateFrom,
+		To:     dateTo,
+		Items:  items,
+	}, nil
+}
+
+// tradingDaysBetween from〜to のうち、いずれかの銘柄に日足がある日を昇順で返す。
+func tradingDaysBetween(pricesBySymbol map[string][]*models.StockBrandDailyPrice, from, to time.Time) []time.Time {
+	fromDay, toDay := from.Format(util.DateLayout), to.Format(util.DateLayout)
+	seen := make(map[string]time.Time)
+	for _, prices := range pricesBySymbol {
+		for _, p := range prices {
+			day := p.Date.Format(util.DateLayout)
+			if day < fromDay || day > toDay {
+				continue
+			}
+			if _, ok := seen[day]; !ok {
+				seen[day] = p.Date
+			}
+		}
+	}
+
+	days := make([]time.Time, 0, len(seen))
+	for _, d := range seen {
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestMarketBreadthInteractor_CalculateMarketBreadth(t *testing.T) {
+	base := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	from := base.AddDate(0, 0, 28)
+	to := base.AddDate(0, 0, 29)
+
+	t.Run("正常系: 主要市場全体と市場コード別に算出して保存する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return([]*models.StockBrand{
+			{TickerSymbol: "7203", MarketCode: "111"},
+			{TickerSymbol: "6758", MarketCode: "112"},
+		}, nil)
+		prices := append(genPricesFor("7203", 30), genPricesFor("6758", 30)...)
+		for _, p := range prices {
+			p.Adjclose = p.Close
+		}
+		priceFrom := from.AddDate(0, 0, -marketBreadthPriceLookbackDays)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), models.ListRangePricesBySymbolsFilter{
+			Symbols:  []string{"7203", "6758"},
+			DateFrom: &priceFrom,
+			DateTo:   &to,
+		}).Return(prices, nil)
+
+		breadthRepo := mock_repositories.NewMockMarketBreadthRepository(ctrl)
+		for _, market := range models.MarketBreadthMarkets {
+			var prev []*models.MarketBreadth
+			if market == models.MarketBreadthMarketAll {
+				prev = []*models.MarketBreadth{{Market: market, ADLine: 100}}
+			}
+			breadthRepo.EXPECT().ListMarketBreadthsBefore(gomock.Any(), market, from, marketBreadthChainDays).Return(prev, nil)
+		}
+		var saved []*models.MarketBreadth
+		breadthRepo.EXPECT().UpsertMarketBreadths(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, breadths []*models.MarketBreadth) error {
+				saved = breadths
+				return nil
+			})
+
+		got, err := NewMarketBreadthInteractor(brandRepo, priceRepo, breadthRepo).CalculateMarketBreadth(context.Background(), from, to)
+		require.NoError(t, err)
+		assert.Equal(t, 2, got)
+
+		require.Len(t, saved, 8)
+		byKey := make(map[string]*models.MarketBreadth)
+		for _, b := range saved {
+			byKey[b.Market+"/"+b.Date.Format("2006-01-02")] = b
+		}
+		all := byKey["all/2021-02-02"]
+		require.NotNil(t, all)
+		assert.Equal(t, 2, all.Advancers)
+		assert.Equal(t, int64(104), all.ADLine)
+		assert.Equal(t, 1, byKey["111/2021-02-02"].Advancers)
+		assert.Equal(t, 1, byKey["112/2021-02-02"].Advancers)
+		assert.Zero(t, byKey["113/2021-02-02"].Advancers)
+		assert.Nil(t, byKey["113/2021-02-02"].AboveMA25Pct)
+	})
+
+	t.Run("正常系: 期間内に日足が無ければ保存しない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return([]*models.StockBrand{{TickerSymbol: "7203", MarketCode: "111"}}, nil)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(genPricesFor("7203", 10), nil)
+
+		got, err := NewMarketBreadthInteractor(brandRepo, priceRepo, mock_repositories.NewMockMarketBreadthRepository(ctrl)).
+			CalculateMarketBreadth(context.Background(), from, to)
+		require.NoError(t, err)
+		assert.Zero(t, got)
+	})
+
+	t.Run("異常系: 銘柄取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewMarketBreadthInteractor(brandRepo, nil, nil).CalculateMarketBreadth(context.Background(), from, to)
+		assert.Error(t, err)
+	})
+}
+
+func TestMarketBreadthInteractor_GetMarketBreadth(t *testing.T) {
+	to := time.Date(2024, 6, 28, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(-1, 0, 0)
+
+	t.Run("正常系: from 省略時は1年前から", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		items := []*models.MarketBreadth{{Date: to, Market: "111", Advancers: 900}}
+		breadthRepo := mock_repositories.NewMockMarketBreadthRepository(ctrl)
+		breadthRepo.EXPECT().ListMarketBreadths(gomock.Any(), models.MarketBreadthFilter{
+			Market: "111",
+			From:   &from,
+			To:     &to,
+		}).Return(items, nil)
+
+		got, err := NewMarketBreadthInteractor(nil, nil, breadthRepo).GetMarketBreadth(context.Background(), "111", nil, &to)
+		require.NoError(t, err)
+		assert.Equal(t, "111", got.Market)
+		assert.Equal(t, from, got.From)
+		assert.Equal(t, to, got.To)
+		assert.Equal(t, items, got.Items)
+	})
+
+	t.Run("異常系: 取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		breadthRepo := mock_repositories.NewMockMarketBreadthRepository(ctrl)
+		breadthRepo.EXPECT().ListMarketBreadths(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewMarketBreadthInteractor(nil, nil, breadthRepo).GetMarketBreadth(context.Background(), models.MarketBreadthMarketAll, nil, nil)
+		assert.Error(t, err)
+	})
+}
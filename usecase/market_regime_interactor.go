@@ -0,0 +1,113 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+const (
+	// marketRegimeIndexLookbackDays 75日線の傾きと ATR 比率の250営業日平均を出すために対象期間より前に取得する暦日数。
+	marketRegimeIndexLookbackDays = 520
+	// marketRegimeDefaultYears from 省略時の対象期間（to から遡る年数）。
+	marketRegimeDefaultYears = 1
+)
+
+type marketRegimeInteractorImpl struct {
+	topixRepository         repositories.TopixRepository
+	nikkeiRepository        repositories.NikkeiRepository
+	marketBreadthRepository repositories.MarketBreadthRepository
+	marketRegimeRepository  repositories.MarketRegimeRepository
+}
+
+// MarketRegimeInteractor 市場局面（上昇トレンド・下降トレンド・レンジ・高ボラティリティ）のインターフェース。
+type MarketRegimeInteractor interface {
+	// ClassifyMarketRegimes from〜to の各営業日（TOPIX 日足がある日）の市場局面を判定して保存し、判定した営業日数を返す。
+	// 騰落指標（主要市場全体）は保存済みのものを使うため、calculate_market_breadth_v1 の後に実行すること。
+	ClassifyMarketRegimes(ctx context.Context, from, to time.Time) (int, error)
+	// GetMarketRegimes 市場局面を date 昇順で返す。from 省略時は to（省略時は現在）から1年前を起点とする。
+	GetMarketRegimes(ctx context.Context, from, to *time.Time) (*models.MarketRegimeSeries, error)
+}
+
+func NewMarketRegimeInteractor(
+	topixRepository repositories.TopixRepository,
+	nikkeiRepository repositories.NikkeiRepository,
+	marketBreadthRepository repositories.MarketBreadthRepository,
+	marketRegimeRepository repositories.MarketRegimeRepository,
+) MarketRegimeInteractor {
+	return &marketRegimeInteractorImpl{
+		topixRepository:         topixRepository,
+		nikkeiRepository:        nikkeiRepository,
+		marketBreadthRepository: marketBreadthRepository,
+		marketRegimeRepository:  marketRegimeRepository,
+	}
+}
+
+func (mi *marketRegimeInteractorImpl) ClassifyMarketRegimes(ctx context.Context, from, to time.Time) (int, error) {
+	indexFrom := from.AddDate(0, 0, -marketRegimeIndexLookbackDays)
+	topix, err := mi.topixRepository.ListTopixDailyPrices(ctx, &indexFrom, &to)
+	if err != nil {
+		return 0, errors.Wrap(err, "ListTopixDailyPrices error")
+	}
+	nikkei, err := mi.nikkeiRepository.ListNikkeiStockAverageDailyPrices(ctx, &indexFrom, &to)
+	if err != nil {
+		return 0, errors.Wrap(err, "ListNikkeiStockAverageDailyPrices error")
+	}
+	breadth, err := mi.marketBreadthRepository.ListMarketBreadths(ctx, models.MarketBreadthFilter{
+		Market: models.MarketBreadthMarketAll,
+		From:   &from,
+		To:     &to,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "ListMarketBreadths error")
+	}
+
+	fromDay, toDay := from.Format(util.DateLayout), to.Format(util.DateLayout)
+	regimes := make([]*models.MarketRegime, 0)
+	for _, r := range domain_service.ClassifyMarketRegimes(topix, nikkei, breadth) {
+		day := r.Date.Format(util.DateLayout)
+		if day < fromDay || day > toDay {
+			continue
+		}
+		regimes = append(regimes, r)
+	}
+	if len(regimes) == 0 {
+		return 0, nil
+	}
+
+	if err := mi.marketRegimeRepository.UpsertMarketRegimes(ctx, regimes); err != nil {
+		return 0, errors.Wrap(err, "UpsertMarketRegimes error")
+	}
+	return len(regimes), nil
+}
+
+func (mi *marketRegimeInteractorImpl) GetMarketRegimes(ctx context.Context, from, to *time.Time) (*models.MarketRegimeSeries, error) {
+	dateTo := time.Now()
+	if to != nil {
+		dateTo = *to
+	}
+	dateFrom := dateTo.AddDate(-marketRegimeDefaultYears, 0, 0)
+	if from != nil {
+		dateFrom = *from
+	}
+
+	items, err := mi.marketRegimeRepository.ListMarketRegimes(ctx, models.MarketRegimeFilter{
+		From: &dateFrom,
+		To:   &dateTo,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListMarketRegimes error")
+	}
+	return &models.MarketRegimeSeries{
+		From:  dateFrom,
+		To:    dateTo,
+		Items: items,
+	}, nil
+}
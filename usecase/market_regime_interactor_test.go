@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// genIndexPrices 2021-01-04 から days 日分、終値が 1000 から 2 ずつ上がる指数日足。
+func genIndexPrices(days int) models.IndexStockAverageDailyPrices {
+	base := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	out := make(models.IndexStockAverageDailyPrices, 0, days)
+	for i := range days {
+		c := decimal.NewFromInt(int64(1000 + 2*i))
+		out = append(out, &models.IndexStockAverageDailyPrice{
+			Date:  base.AddDate(0, 0, i),
+			Open:  c,
+			High:  c.Add(decimal.NewFromInt(5)),
+			Low:   c.Sub(decimal.NewFromInt(5)),
+			Close: c,
+		})
+	}
+	return out
+}
+
+func TestMarketRegimeInteractor_ClassifyMarketRegimes(t *testing.T) {
+	base := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	from := base.AddDate(0, 0, 118)
+	to := base.AddDate(0, 0, 119)
+	indexFrom := from.AddDate(0, 0, -marketRegimeIndexLookbackDays)
+
+	t.Run("正常系: 対象期間の局面だけを保存する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+		topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), &indexFrom, &to).Return(genIndexPrices(120), nil)
+		nikkeiRepo := mock_repositories.NewMockNikkeiRepository(ctrl)
+		nikkeiRepo.EXPECT().ListNikkeiStockAverageDailyPrices(gomock.Any(), &indexFrom, &to).Return(genIndexPrices(120), nil)
+		pct := decimal.NewFromInt(70)
+		breadthRepo := mock_repositories.NewMockMarketBreadthRepository(ctrl)
+		breadthRepo.EXPECT().ListMarketBreadths(gomock.Any(), models.MarketBreadthFilter{
+			Market: models.MarketBreadthMarketAll,
+			From:   &from,
+			To:     &to,
+		}).Return([]*models.MarketBreadth{{Date: to, Market: models.MarketBreadthMarketAll, AboveMA75Pct: &pct}}, nil)
+
+		var saved []*models.MarketRegime
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().UpsertMarketRegimes(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, regimes []*models.MarketRegime) error {
+				saved = regimes
+				return nil
+			})
+
+		got, err := NewMarketRegimeInteractor(topixRepo, nikkeiRepo, breadthRepo, regimeRepo).ClassifyMarketRegimes(context.Background(), from, to)
+		require.NoError(t, err)
+		assert.Equal(t, 2, got)
+		require.Len(t, saved, 2)
+		assert.Equal(t, from, saved[0].Date)
+		assert.Equal(t, models.MarketRegimeTrendUp, saved[1].Regime)
+		assert.Nil(t, saved[0].BreadthAboveMA75Pct)
+		assert.Equal(t, "70", saved[1].BreadthAboveMA75Pct.String())
+	})
+
+	t.Run("正常系: TOPIX の本数が足りなければ保存しない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+		topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(genIndexPrices(50), nil)
+		nikkeiRepo := mock_repositories.NewMockNikkeiRepository(ctrl)
+		nikkeiRepo.EXPECT().ListNikkeiStockAverageDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		breadthRepo := mock_repositories.NewMockMarketBreadthRepository(ctrl)
+		breadthRepo.EXPECT().ListMarketBreadths(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		got, err := NewMarketRegimeInteractor(topixRepo, nikkeiRepo, breadthRepo, mock_repositories.NewMockMarketRegimeRepository(ctrl)).
+			ClassifyMarketRegimes(context.Background(), from, to)
+		require.NoError(t, err)
+		assert.Zero(t, got)
+	})
+
+	t.Run("異常系: TOPIX 取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+		topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewMarketRegimeInteractor(topixRepo, nil, nil, nil).ClassifyMarketRegimes(context.Background(), from, to)
+		assert.Error(t, err)
+	})
+}
+
+func TestMarketRegimeInteractor_GetMarketRegimes(t *testing.T) {
+	to := time.Date(2024, 6, 28, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(-1, 0, 0)
+
+	t.Run("正常系: from 省略時は1年前から", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		items := []*models.MarketRegime{{Date: to, Regime: models.MarketRegimeRange}}
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().ListMarketRegimes(gomock.Any(), models.MarketRegimeFilter{From: &from, To: &to}).Return(items, nil)
+
+		got, err := NewMarketRegimeInteractor(nil, nil, nil, regimeRepo).GetMarketRegimes(context.Background(), nil, &to)
+		require.NoError(t, err)
+		assert.Equal(t, from, got.From)
+		assert.Equal(t, to, got.To)
+		assert.Equal(t, items, got.Items)
+	})
+
+	t.Run("異常系: 取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().ListMarketRegimes(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewMarketRegimeInteractor(nil, nil, nil, regimeRepo).GetMarketRegimes(context.Background(), nil, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,114 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/infrastructure/gateway"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// paperPortfolioWeekDays 週次通知で集計する暦日数（最新の評価日を含む）。
+const paperPortfolioWeekDays = 7
+
+type NotifyPaperPortfolioInteractor interface {
+	// NotifyPaperPortfolioWeekly 最新の評価日までの直近1週間の模擬売買損益を Slack に通知する。
+	// 週初の基準は週の開始日より前の最新 NAV（無ければ初期資金）。まだ NAV が1件も無ければ何もしない。
+	NotifyPaperPortfolioWeekly(ctx context.Context, account string) error
+}
+
+type notifyPaperPortfolioInteractorImpl struct {
+	paperTradingRepository repositories.PaperTradingRepository
+	stockBrandRepository   repositories.StockBrandRepository
+	slackAPIClient         gateway.SlackAPIClient
+}
+
+func NewNotifyPaperPortfolioInteractor(
+	paperTradingRepository repositories.PaperTradingRepository,
+	stockBrandRepository repositories.StockBrandRepository,
+	slackAPIClient gateway.SlackAPIClient,
+) NotifyPaperPortfolioInteractor {
+	return &notifyPaperPortfolioInteractorImpl{
+		paperTradingRepository: paperTradingRepository,
+		stockBrandRepository:   stockBrandRepository,
+		slackAPIClient:         slackAPIClient,
+	}
+}
+
+func (ni *notifyPaperPortfolioInteractorImpl) NotifyPaperPortfolioWeekly(ctx context.Context, account string) error {
+	if account == "" {
+		account = models.PaperAccountDefaultName
+	}
+	acc, err := ni.paperTradingRepository.FindAccount(ctx, account)
+	if err != nil {
+		return errors.Wrap(err, "FindAccount error")
+	}
+	if acc == nil {
+		return errors.Wrapf(ErrPaperAccountNotFound, "account=%s", account)
+	}
+	latest, err := ni.paperTradingRepository.FindLatestNav(ctx, acc.Name)
+	if err != nil {
+		return errors.Wrap(err, "FindLatestNav error")
+	}
+	if latest == nil {
+		log.Printf("paper portfolio: no nav yet, weekly summary skipped. account=%s", acc.Name)
+		return nil
+	}
+
+	to := latest.Date
+	weekFrom := to.AddDate(0, 0, -(paperPortfolioWeekDays - 1))
+	// 週初の基準 NAV を拾うため、連休を挟んでも届くよう1週間ぶん余分に遡る。
+	navFrom := weekFrom.AddDate(0, 0, -paperPortfolioWeekDays)
+	navs, err := ni.paperTradingRepository.ListNavs(ctx, acc.Name, &navFrom, &to)
+	if err != nil {
+		return errors.Wrap(err, "ListNavs error")
+	}
+	fills, err := ni.paperTradingRepository.ListFills(ctx, acc.Name, &weekFrom, &to)
+	if err != nil {
+		return errors.Wrap(err, "ListFills error")
+	}
+	bought := 0
+	for _, f := range fills {
+		if f.Side == models.PaperFillSideBuy {
+			bought++
+		}
+	}
+
+	openStatus := models.PaperPositionStatusOpen
+	open, err := ni.paperTradingRepository.ListPositions(ctx, models.PaperPositionFilter{Account: acc.Name, Status: &openStatus})
+	if err != nil {
+		return errors.Wrap(err, "ListPositions open error")
+	}
+	closedStatus := models.PaperPositionStatusClosed
+	closed, err := ni.paperTradingRepository.ListPositions(ctx, models.PaperPositionFilter{
+		Account:  acc.Name,
+		Status:   &closedStatus,
+		ExitFrom: &weekFrom,
+		ExitTo:   &to,
+	})
+	if err != nil {
+		return errors.Wrap(err, "ListPositions closed error")
+	}
+	openItems, err := paperPositionItems(ctx, ni.stockBrandRepository, open)
+	if err != nil {
+		return err
+	}
+	closedItems, err := paperPositionItems(ctx, ni.stockBrandRepository, closed)
+	if err != nil {
+		return err
+	}
+
+	report := domain_service.BuildPaperPortfolioWeeklyReport(acc, navs, weekFrom, bought, closedItems, openItems)
+	title, body := domain_service.FormatPaperPortfolioWeeklyMessage(report)
+	if _, err := ni.slackAPIClient.SendMessageByStrings(ctx, gateway.SlackChannelNameExchangeStockInfo, title, &body, nil); err != nil {
+		return errors.Wrap(err, "SendMessageByStrings error")
+	}
+
+	log.Printf("paper portfolio: weekly summary notified. account=%s from=%s to=%s", acc.Name, weekFrom.Format("2006-01-02"), to.Format("2006-01-02"))
+	return nil
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Code0716/stock-price-repository/infrastructure/gateway"
+	mock_gateway "github.com/Code0716/stock-price-repository/mock/gateway"
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestNotifyPaperPortfolioInteractorImpl_NotifyPaperPortfolioWeekly(t *testing.T) {
+	to := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	weekFrom := to.AddDate(0, 0, -6)
+	navFrom := weekFrom.AddDate(0, 0, -7)
+
+	t.Run("直近1週間の損益をSlackに通知する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		acc := paperTestAccount()
+		exit := to.AddDate(0, 0, -1)
+		paperRepo := mock_repositories.NewMockPaperTradingRepository(ctrl)
+		paperRepo.EXPECT().FindAccount(gomock.Any(), acc.Name).Return(acc, nil)
+		paperRepo.EXPECT().FindLatestNav(gomock.Any(), acc.Name).Return(&models.PaperNav{Account: acc.Name, Date: to}, nil)
+		paperRepo.EXPECT().ListNavs(gomock.Any(), acc.Name, &navFrom, &to).Return([]*models.PaperNav{
+			{Account: acc.Name, Date: weekFrom.AddDate(0, 0, -1), Nav: decimal.NewFromInt(10_000_000)},
+			{Account: acc.Name, Date: to, Nav: decimal.NewFromInt(10_100_000)},
+		}, nil)
+		paperRepo.EXPECT().ListFills(gomock.Any(), acc.Name, &weekFrom, &to).Return([]*models.PaperFill{
+			{Side: models.PaperFillSideBuy},
+			{Side: models.PaperFillSideBuy},
+			{Side: models.PaperFillSideSell},
+		}, nil)
+		openStatus, closedStatus := models.PaperPositionStatusOpen, models.PaperPositionStatusClosed
+		paperRepo.EXPECT().ListPositions(gomock.Any(), models.PaperPositionFilter{Account: acc.Name, Status: &openStatus}).Return(nil, nil)
+		paperRepo.EXPECT().ListPositions(gomock.Any(), models.PaperPositionFilter{Account: acc.Name, Status: &closedStatus, ExitFrom: &weekFrom, ExitTo: &to}).
+			Return([]*models.PaperPosition{paperTestPosition(1, "1000", &exit)}, nil)
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindByIDs(gomock.Any(), []string{"brand-1000"}).Return([]*models.StockBrand{{ID: "brand-1000", Name: "銘柄A"}}, nil)
+
+		slackAPI := mock_gateway.NewMockSlackAPIClient(ctrl)
+		slackAPI.EXPECT().
+			SendMessageByStrings(gomock.Any(), gateway.SlackChannelNameExchangeStockInfo, gomock.Any(), gomock.Any(), (*string)(nil)).
+			DoAndReturn(func(ctx context.Context, channel gateway.SlackChannelName, title string, body *string, threadTS *string) (string, error) {
+				assert.Equal(t, "模擬売買 週次損益 2026-07-04〜2026-07-10 [default] / 週 +100,000円 (+1.0%)", title)
+				assert.Contains(t, *body, "買付 2件 / 売却 1件")
+				assert.Contains(t, *body, "`1000` 銘柄A +10,000円 (+10.0%) 期間満了")
+				return "1234.5678", nil
+			})
+
+		interactor := NewNotifyPaperPortfolioInteractor(paperRepo, brandRepo, slackAPI)
+		assert.NoError(t, interactor.NotifyPaperPortfolioWeekly(context.Background(), ""))
+	})
+
+	t.Run("NAVがまだ無ければ通知しない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		acc := paperTestAccount()
+		paperRepo := mock_repositories.NewMockPaperTradingRepository(ctrl)
+		paperRepo.EXPECT().FindAccount(gomock.Any(), acc.Name).Return(acc, nil)
+		paperRepo.EXPECT().FindLatestNav(gomock.Any(), acc.Name).Return(nil, nil)
+
+		interactor := NewNotifyPaperPortfolioInteractor(paperRepo, mock_repositories.NewMockStockBrandRepository(ctrl), mock_gateway.NewMockSlackAPIClient(ctrl))
+		assert.NoError(t, interactor.NotifyPaperPortfolioWeekly(context.Background(), ""))
+	})
+}
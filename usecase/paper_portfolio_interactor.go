@@ -0,0 +1,129 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// paperPortfolioDefaultMonths from 省略時に遡る月数。
+const paperPortfolioDefaultMonths = 3
+
+// ErrPaperAccountNotFound 指定された模擬売買口座が存在しない。
+var ErrPaperAccountNotFound = errors.New("paper account not found")
+
+// PaperPortfolioInteractor 模擬売買口座の閲覧用（読み取り専用）ユースケース。
+type PaperPortfolioInteractor interface {
+	// GetPaperPortfolio 口座の売買ルール・成績サマリ・NAV 推移・保有中のポジション・期間内に売却したポジションと約定を返す。
+	// account が空なら既定の口座を使う。from 省略時は to（省略時は現在）の3か月前から。口座が無ければ ErrPaperAccountNotFound。
+	GetPaperPortfolio(ctx context.Context, account string, from, to *time.Time) (*models.PaperPortfolio, error)
+}
+
+type paperPortfolioInteractorImpl struct {
+	paperTradingRepository repositories.PaperTradingRepository
+	stockBrandRepository   repositories.StockBrandRepository
+}
+
+func NewPaperPortfolioInteractor(
+	paperTradingRepository repositories.PaperTradingRepository,
+	stockBrandRepository repositories.StockBrandRepository,
+) PaperPortfolioInteractor {
+	return &paperPortfolioInteractorImpl{
+		paperTradingRepository: paperTradingRepository,
+		stockBrandRepository:   stockBrandRepository,
+	}
+}
+
+func (pi *paperPortfolioInteractorImpl) GetPaperPortfolio(ctx context.Context, account string, from, to *time.Time) (*models.PaperPortfolio, error) {
+	if account == "" {
+		account = models.PaperAccountDefaultName
+	}
+	acc, err := pi.paperTradingRepository.FindAccount(ctx, account)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindAccount error")
+	}
+	if acc == nil {
+		return nil, ErrPaperAccountNotFound
+	}
+	if from == nil {
+		base := time.Now()
+		if to != nil {
+			base = *to
+		}
+		f := base.AddDate(0, -paperPortfolioDefaultMonths, 0)
+		from = &f
+	}
+
+	navs, err := pi.paperTradingRepository.ListNavs(ctx, acc.Name, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListNavs error")
+	}
+	openStatus := models.PaperPositionStatusOpen
+	open, err := pi.paperTradingRepository.ListPositions(ctx, models.PaperPositionFilter{Account: acc.Name, Status: &openStatus})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListPositions open error")
+	}
+	closedStatus := models.PaperPositionStatusClosed
+	closed, err := pi.paperTradingRepository.ListPositions(ctx, models.PaperPositionFilter{
+		Account:  acc.Name,
+		Status:   &closedStatus,
+		ExitFrom: from,
+		ExitTo:   to,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListPositions closed error")
+	}
+	fills, err := pi.paperTradingRepository.ListFills(ctx, acc.Name, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListFills error")
+	}
+
+	items, err := paperPositionItems(ctx, pi.stockBrandRepository, slices.Concat(open, closed))
+	if err != nil {
+		return nil, err
+	}
+	openItems, closedItems := items[:len(open)], items[len(open):]
+	slices.SortStableFunc(closedItems, func(a, b *models.PaperPositionItem) int { return b.ExitDate.Compare(*a.ExitDate) })
+	slices.Reverse(fills)
+
+	return &models.PaperPortfolio{
+		Account:         acc,
+		Summary:         domain_service.SummarizePaperPortfolio(acc, navs, openItems, closedItems),
+		Navs:            navs,
+		OpenPositions:   openItems,
+		ClosedPositions: closedItems,
+		Fills:           fills,
+	}, nil
+}
+
+// paperPositionItems 銘柄名と損益を付けた表示用ポジションを positions と同じ並びで返す（銘柄が見つからなければ名前は空）。
+func paperPositionItems(ctx context.Context, stockBrandRepository repositories.StockBrandRepository, positions []*models.PaperPosition) ([]*models.PaperPositionItem, error) {
+	items := make([]*models.PaperPositionItem, 0, len(positions))
+	if len(positions) == 0 {
+		return items, nil
+	}
+	ids := make([]string, 0, len(positions))
+	for _, p := range positions {
+		ids = append(ids, p.StockBrandID)
+	}
+	slices.Sort(ids)
+	brands, err := stockBrandRepository.FindByIDs(ctx, slices.Compact(ids))
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByIDs error")
+	}
+	names := make(map[string]string, len(brands))
+	for _, b := range brands {
+		names[b.ID] = b.Name
+	}
+	for _, p := range positions {
+		items = append(items, domain_service.PaperPositionItemOf(p, names[p.StockBrandID]))
+	}
+	return items, nil
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func paperTestAccount() *models.PaperAccount {
+	return &models.PaperAccount{
+		Name:          models.PaperAccountDefaultName,
+		ScoreVersion:  "v1",
+		TopN:          10,
+		BudgetPerPick: decimal.NewFromInt(500_000),
+		HoldingDays:   5,
+		InitialCash:   decimal.NewFromInt(10_000_000),
+		StartDate:     time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func paperTestPosition(id uint64, symbol string, exitDate *time.Time) *models.PaperPosition {
+	p := &models.PaperPosition{
+		ID:           id,
+		Account:      models.PaperAccountDefaultName,
+		StockBrandID: "brand-" + symbol,
+		TickerSymbol: symbol,
+		Status:       models.PaperPositionStatusOpen,
+		EntryDate:    time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+		EntryPrice:   decimal.NewFromInt(1000),
+		Quantity:     100,
+		MarkPrice:    decimal.NewFromInt(1100),
+	}
+	if exitDate != nil {
+		price := decimal.NewFromInt(1100)
+		reason := models.PaperExitReasonHoldingPeriod
+		p.Status, p.ExitDate, p.ExitPrice, p.ExitReason = models.PaperPositionStatusClosed, exitDate, &price, &reason
+	}
+	return p
+}
+
+func TestPaperPortfolioInteractorImpl_GetPaperPortfolio(t *testing.T) {
+	t.Run("口座が無ければErrPaperAccountNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		paperRepo := mock_repositories.NewMockPaperTradingRepository(ctrl)
+		paperRepo.EXPECT().FindAccount(gomock.Any(), "missing").Return(nil, nil)
+
+		interactor := NewPaperPortfolioInteractor(paperRepo, mock_repositories.NewMockStockBrandRepository(ctrl))
+		_, err := interactor.GetPaperPortfolio(context.Background(), "missing", nil, nil)
+		assert.True(t, errors.Is(err, ErrPaperAccountNotFound))
+	})
+
+	t.Run("既定の口座のNAV・保有・売却済み・約定を銘柄名付きで返す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		to := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+		from := to.AddDate(0, -paperPortfolioDefaultMonths, 0)
+		acc := paperTestAccount()
+		early, late := to.AddDate(0, 0, -3), to.AddDate(0, 0, -1)
+		open := []*models.PaperPosition{paperTestPosition(1, "1000", nil)}
+		closed := []*models.PaperPosition{paperTestPosition(2, "2000", &early), paperTestPosition(3, "1000", &late)}
+		fills := []*models.PaperFill{{ID: 1}, {ID: 2}}
+		navs := []*models.PaperNav{{Account: acc.Name, Date: to, Nav: decimal.NewFromInt(10_050_000)}}
+
+		paperRepo := mock_repositories.NewMockPaperTradingRepository(ctrl)
+		paperRepo.EXPECT().FindAccount(gomock.Any(), models.PaperAccountDefaultName).Return(acc, nil)
+		paperRepo.EXPECT().ListNavs(gomock.Any(), acc.Name, &from, &to).Return(navs, nil)
+		openStatus, closedStatus := models.PaperPositionStatusOpen, models.PaperPositionStatusClosed
+		paperRepo.EXPECT().ListPositions(gomock.Any(), models.PaperPositionFilter{Account: acc.Name, Status: &openStatus}).Return(open, nil)
+		paperRepo.EXPECT().ListPositions(gomock.Any(), models.PaperPositionFilter{Account: acc.Name, Status: &closedStatus, ExitFrom: &from, ExitTo: &to}).Return(closed, nil)
+		paperRepo.EXPECT().ListFills(gomock.Any(), acc.Name, &from, &to).Return(fills, nil)
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindByIDs(gomock.Any(), []string{"brand-1000", "brand-2000"}).Return([]*models.StockBrand{
+			{ID: "brand-1000", Name: "銘柄A"},
+			{ID: "brand-2000", Name: "銘柄B"},
+		}, nil)
+
+		interactor := NewPaperPortfolioInteractor(paperRepo, brandRepo)
+		got, err := interactor.GetPaperPortfolio(context.Background(), "", nil, &to)
+		assert.NoError(t, err)
+		assert.Equal(t, acc, got.Account)
+		if assert.Len(t, got.OpenPositions, 1) {
+			assert.Equal(t, "銘柄A", got.OpenPositions[0].Name)
+		}
+		if assert.Len(t, got.ClosedPositions, 2) {
+			assert.Equal(t, uint64(3), got.ClosedPositions[0].ID, "売却日の新しい順")
+			assert.Equal(t, "銘柄B", got.ClosedPositions[1].Name)
+		}
+		assert.Equal(t, uint64(2), got.Fills[0].ID, "約定は新しい順")
+		assert.Equal(t, 1, got.Summary.OpenPositions)
+		assert.Equal(t, 2, got.Summary.ClosedTrades)
+		assert.Equal(t, "2026-07-10", *got.Summary.AsOf)
+	})
+}
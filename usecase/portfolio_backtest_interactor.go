@@ -0,0 +1,119 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+const (
+	// portfolioBacktestDefaultYears from 省略時の対象期間（to から遡る年数）。
+	portfolioBacktestDefaultYears = 3
+)
+
+// ErrPortfolioShortStrategy ポートフォリオは現金ベースの買いのみを扱うため空売り戦略は受け付けない。
+var ErrPortfolioShortStrategy = errors.New("short strategies are not supported in portfolio backtests")
+
+type portfolioBacktestInteractorImpl struct {
+	stockBrandRepository                 repositories.StockBrandRepository
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
+}
+
+// PortfolioBacktestInteractor 複数銘柄を共通資金で運用するポートフォリオバックテストのインターフェース。
+type PortfolioBacktestInteractor interface {
+	// RunPortfolioBacktest ユニバース内の全銘柄に戦略を適用し、資金・同時保有上限・単元株を考慮して
+	// 売買をシミュレーションする。from 省略時は to（省略時は現在）から3年前を起点とする。
+	// 約定モデル（params.Exit の EntryTiming / ExitFill / IntrabarPriority / TickRounding）は単銘柄のバックテストと同じ。
+	// 空売り戦略は ErrPortfolioShortStrategy。
+	RunPortfolioBacktest(ctx context.Context, params models.PortfolioBacktestParams, from, to *time.Time) (*models.PortfolioBacktestResult, error)
+}
+
+func NewPortfolioBacktestInteractor(
+	stockBrandRepository repositories.StockBrandRepository,
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
+) PortfolioBacktestInteractor {
+	return &portfolioBacktestInteractorImpl{
+		stockBrandRepository:                 stockBrandRepository,
+		stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
+	}
+}
+
+func (p *portfolioBacktestInteractorImpl) RunPortfolioBacktest(ctx context.Context, params models.PortfolioBacktestParams, from, to *time.Time) (*models.PortfolioBacktestResult, error) {
+	if domain_service.StrategySide(params.Strategy) == models.PositionSideShort {
+		return nil, ErrPortfolioShortStrategy
+	}
+	symbols, err := p.resolveUniverse(ctx, params.Universe)
+	if err != nil {
+		return nil, err
+	}
+
+	dateTo := time.Now()
+	if to != nil {
+		dateTo = *to
+	}
+	dateFrom := dateTo.AddDate(-portfolioBacktestDefaultYears, 0, 0)
+	if from != nil {
+		dateFrom = *from
+	}
+
+	pricesBySymbol, err := listPricesBySymbols(ctx, p.stockBrandsDailyStockPriceRepository, symbols, dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	minBars := domain_service.StrategyMinHistoryBars(params.Strategy)
+	series := make([]domain_service.PortfolioSeries, 0, len(symbols))
+	for _, symbol := range symbols {
+		prices := pricesBySymbol[symbol]
+		if len(prices) < minBars {
+			continue
+		}
+		s := domain_service.PortfolioSeries{
+			TickerSymbol: symbol,
+			Prices:       prices,
+			EntrySignals: domain_service.EntrySignalsByStrategy(params.Strategy, prices),
+		}
+		if params.Exit.ExitMode == models.ExitModeSignal {
+			s.ExitSignals = domain_service.ExitSignalsByStrategy(params.Strategy, prices)
+		}
+		series = append(series, s)
+	}
+
+	result := domain_service.RunPortfolioBacktest(series, domain_service.PortfolioParams{
+		InitialCapital: params.InitialCapital,
+		MaxPositions:   params.MaxPositions,
+		LotSize:        models.PortfolioLotSize,
+		RankBy:         params.RankBy,
+		Exit:           newExitParams(params.Strategy, params.Exit),
+	})
+	result.Params = params
+	result.Label = domain_service.StrategyLabel(params.Strategy)
+	result.UniverseSize = len(series)
+	return &result, nil
+}
+
+// resolveUniverse ユニバース指定から銘柄コードの一覧を返す。
+func (p *portfolioBacktestInteractorImpl) resolveUniverse(ctx context.Context, universe models.PortfolioUniverse) ([]string, error) {
+	if universe.Kind == models.PortfolioUniverseWatchlist {
+		return universe.Symbols, nil
+	}
+
+	brands, err := p.stockBrandRepository.FindAllMainMarkets(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindAllMainMarkets error")
+	}
+	symbols := make([]string, 0, len(brands))
+	for _, b := range brands {
+		if universe.Kind == models.PortfolioUniverseSector && b.Sector33Code != universe.Sector33Code {
+			continue
+		}
+		symbols = append(symbols, b.TickerSymbol)
+	}
+	return symbols, nil
+}
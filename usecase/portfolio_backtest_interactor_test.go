@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func genPricesFor(symbol string, days int) []*models.StockBrandDailyPrice {
+	prices := genPrices(days)
+	for _, p := range prices {
+		p.TickerSymbol = symbol
+	}
+	return prices
+}
+
+func TestPortfolioBacktestInteractor_RunPortfolioBacktest(t *testing.T) {
+	from := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	params := models.PortfolioBacktestParams{
+		Strategy:       "ma_cross",
+		InitialCapital: decimal.NewFromInt(3_000_000),
+		MaxPositions:   5,
+		RankBy:         models.PortfolioRankVolumeRatio,
+		Exit: models.BacktestParams{
+			TakeProfit:  decimal.NewFromFloat(0.10),
+			StopLoss:    decimal.NewFromFloat(0.05),
+			MaxHoldDays: 20,
+		},
+	}
+
+	t.Run("正常系: セクター指定で対象銘柄を絞り込みデータ不足銘柄を除外する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return([]*models.StockBrand{
+			{TickerSymbol: "7203", Sector33Code: "3700"},
+			{TickerSymbol: "7267", Sector33Code: "3700"},
+			{TickerSymbol: "8306", Sector33Code: "7050"},
+		}, nil)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), models.ListRangePricesBySymbolsFilter{
+			Symbols:  []string{"7203", "7267"},
+			DateFrom: &from,
+			DateTo:   &to,
+		}).Return(append(genPricesFor("7203", 120), genPricesFor("7267", 30)...), nil)
+
+		p := params
+		p.Universe = models.PortfolioUniverse{Kind: models.PortfolioUniverseSector, Sector33Code: "3700"}
+		interactor := NewPortfolioBacktestInteractor(brandRepo, priceRepo)
+		got, err := interactor.RunPortfolioBacktest(context.Background(), p, &from, &to)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.UniverseSize)
+		assert.Equal(t, "2021-01-04", got.From)
+		assert.Len(t, got.Equity, 120)
+		assert.Equal(t, domain_service.StrategyLabel(domain_service.StrategyMovingAverageCross), got.Label)
+		assert.Equal(t, p, got.Params)
+	})
+
+	t.Run("正常系: ウォッチリストは銘柄マスタを引かない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(genPricesFor("7203", 120), nil)
+
+		p := params
+		p.Universe = models.PortfolioUniverse{Kind: models.PortfolioUniverseWatchlist, Symbols: []string{"7203"}}
+		got, err := NewPortfolioBacktestInteractor(brandRepo, priceRepo).RunPortfolioBacktest(context.Background(), p, &from, &to)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.UniverseSize)
+	})
+
+	t.Run("異常系: 空売り戦略は受け付けない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		p := params
+		p.Strategy = "macd_bearish"
+		p.Universe = models.PortfolioUniverse{Kind: models.PortfolioUniverseWatchlist, Symbols: []string{"7203"}}
+		got, err := NewPortfolioBacktestInteractor(mock_repositories.NewMockStockBrandRepository(ctrl), mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)).
+			RunPortfolioBacktest(context.Background(), p, &from, &to)
+		assert.ErrorIs(t, err, ErrPortfolioShortStrategy)
+		assert.Nil(t, got)
+	})
+
+	t.Run("異常系: 日足取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return([]*models.StockBrand{{TickerSymbol: "7203"}}, nil)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		p := params
+		p.Universe = models.PortfolioUniverse{Kind: models.PortfolioUniverseMainMarkets}
+		got, err := NewPortfolioBacktestInteractor(brandRepo, priceRepo).RunPortfolioBacktest(context.Background(), p, &from, &to)
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
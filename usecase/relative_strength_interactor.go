@@ -0,0 +1,131 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// relativeStrengthPriceLookbackDays 12か月（252営業日）分の日足を確実に含めるために遡る暦日数。
+const relativeStrengthPriceLookbackDays = 400
+
+type relativeStrengthInteractorImpl struct {
+	tx                                   repositories.Transaction
+	stockBrandRepository                 repositories.StockBrandRepository
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
+	topixRepository                      repositories.TopixRepository
+	relativeStrengthRepository           repositories.RelativeStrengthRepository
+}
+
+// RelativeStrengthInteractor 主要市場全銘柄の RS レーティング（TOPIX 比の相対的な強さ）のインターフェース。
+type RelativeStrengthInteractor interface {
+	// CalculateRelativeStrength date 以前で最新の TOPIX 営業日について主要市場全銘柄の RS レーティングを算出して保存し、保存件数を返す。
+	// その日の日足が無い銘柄（売買停止など）と、3か月分の履歴が無い銘柄は対象外。
+	// 再実行すると同日分をトランザクション内で全銘柄入れ替える（前回だけ対象だった銘柄は残さない）。
+	CalculateRelativeStrength(ctx context.Context, date time.Time) (int, error)
+	// GetRelativeStrength date（省略時は制限なし）以前で最新の算出日の RS レーティングを rating 降順で返す。
+	// minRating > 0 のときは rating がそれ以上の銘柄に絞る。
+	GetRelativeStrength(ctx context.Context, date *time.Time, minRating int) (*models.RelativeStrengthRatings, error)
+}
+
+func NewRelativeStrengthInteractor(
+	tx repositories.Transaction,
+	stockBrandRepository repositories.StockBrandRepository,
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
+	topixRepository repositories.TopixRepository,
+	relativeStrengthRepository repositories.RelativeStrengthRepository,
+) RelativeStrengthInteractor {
+	return &relativeStrengthInteractorImpl{
+		tx:                                   tx,
+		stockBrandRepository:                 stockBrandRepository,
+		stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
+		topixRepository:                      topixRepository,
+		relativeStrengthRepository:           relativeStrengthRepository,
+	}
+}
+
+func (ri *relativeStrengthInteractorImpl) CalculateRelativeStrength(ctx context.Context, date time.Time) (int, error) {
+	from := date.AddDate(0, 0, -relativeStrengthPriceLookbackDays)
+	topix, err := ri.topixRepository.ListTopixDailyPrices(ctx, &from, &date)
+	if err != nil {
+		return 0, errors.Wrap(err, "ListTopixDailyPrices error")
+	}
+	if len(topix) == 0 {
+		return 0, errors.New("TOPIX の日足がありません")
+	}
+	asOf := topix[len(topix)-1].Date
+	asOfDay := asOf.Format(util.DateLayout)
+
+	brands, err := ri.stockBrandRepository.FindAllMainMarkets(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "FindAllMainMarkets error")
+	}
+	symbols := make([]string, 0, len(brands))
+	for _, b := range brands {
+		symbols = append(symbols, b.TickerSymbol)
+	}
+
+	pricesBySymbol, err := listPricesBySymbols(ctx, ri.stockBrandsDailyStockPriceRepository, symbols, from, asOf)
+	if err != nil {
+		return 0, err
+	}
+
+	ratings := make([]*models.RelativeStrengthRating, 0, len(brands))
+	for _, b := range brands {
+		prices := pricesBySymbol[b.TickerSymbol]
+		if len(prices) == 0 || prices[len(prices)-1].Date.Format(util.DateLayout) != asOfDay {
+			continue
+		}
+		rating, ok := domain_service.CalculateRelativeStrengthScore(prices, topix)
+		if !ok {
+			continue
+		}
+		rating.TickerSymbol = b.TickerSymbol
+		rating.Name = b.Name
+		rating.Date = asOf
+		ratings = append(ratings, rating)
+	}
+	domain_service.AssignRelativeStrengthRatings(ratings)
+
+	if err := ri.tx.DoInTx(ctx, func(ctx context.Context) error {
+		if err := ri.relativeStrengthRepository.DeleteByDate(ctx, asOf); err != nil {
+			return errors.Wrap(err, "DeleteByDate error")
+		}
+		if err := ri.relativeStrengthRepository.UpsertRatings(ctx, ratings); err != nil {
+			return errors.Wrap(err, "UpsertRatings error")
+		}
+		return nil
+	}); err != nil {
+		return 0, errors.Wrap(err, "DoInTx error")
+	}
+	return len(ratings), nil
+}
+
+func (ri *relativeStrengthInteractorImpl) GetRelativeStrength(ctx context.Context, date *time.Time, minRating int) (*models.RelativeStrengthRatings, error) {
+	latest, err := ri.relativeStrengthRepository.FindLatestDate(ctx, date)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindLatestDate error")
+	}
+	if latest == nil {
+		return &models.RelativeStrengthRatings{Items: []*models.RelativeStrengthRating{}}, nil
+	}
+
+	items, err := ri.relativeStrengthRepository.List(ctx, models.RelativeStrengthFilter{
+		Date:      *latest,
+		MinRating: minRating,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "List error")
+	}
+	return &models.RelativeStrengthRatings{
+		Date:  latest,
+		Items: items,
+	}, nil
+}
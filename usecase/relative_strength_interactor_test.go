@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// rsPricesFor 調整後終値 100 が days 本続き、末尾だけ last の日足。
+func rsPricesFor(symbol string, days int, last float64) []*models.StockBrandDailyPrice {
+	prices := genPricesFor(symbol, days)
+	for _, p := range prices {
+		p.Adjclose = decimal.NewFromInt(100)
+	}
+	prices[days-1].Adjclose = decimal.NewFromFloat(last)
+	return prices
+}
+
+func TestRelativeStrengthInteractor_CalculateRelativeStrength(t *testing.T) {
+	base := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	date := base.AddDate(0, 0, 80) // TOPIX の最終日より後でも直近の営業日で算出する
+	asOf := base.AddDate(0, 0, 69)
+	from := date.AddDate(0, 0, -relativeStrengthPriceLookbackDays)
+
+	topix := make(models.IndexStockAverageDailyPrices, 0, 70)
+	for i := range 70 {
+		topix = append(topix, &models.IndexStockAverageDailyPrice{Date: base.AddDate(0, 0, i), Adjclose: decimal.NewFromInt(1000)})
+	}
+
+	t.Run("正常系: 当日の日足がある銘柄を順位付けして保存する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+		topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), &from, &date).Return(topix, nil)
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return([]*models.StockBrand{
+			{TickerSymbol: "7203", Name: "トヨタ自動車"},
+			{TickerSymbol: "7267", Name: "本田技研工業"},
+			{TickerSymbol: "6758", Name: "ソニーグループ"},
+			{TickerSymbol: "9984", Name: "ソフトバンクグループ"},
+		}, nil)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), models.ListRangePricesBySymbolsFilter{
+			Symbols:  []string{"7203", "7267", "6758", "9984"},
+			DateFrom: &from,
+			DateTo:   &asOf,
+		}).Return(append(append(append(
+			rsPricesFor("7203", 70, 120),
+			rsPricesFor("7267", 70, 90)...),
+			rsPricesFor("6758", 69, 150)...), // 当日の日足が無い
+			rsPricesFor("9984", 40, 150)...), // 3か月分の履歴が無い
+			nil)
+
+		tx := mock_repositories.NewMockTransaction(ctrl)
+		tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+		var saved []*models.RelativeStrengthRating
+		rsRepo := mock_repositories.NewMockRelativeStrengthRepository(ctrl)
+		// 前回だけ対象だった銘柄を残さないよう同日分を消してから保存する
+		gomock.InOrder(
+			rsRepo.EXPECT().DeleteByDate(gomock.Any(), asOf).Return(nil),
+			rsRepo.EXPECT().UpsertRatings(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, ratings []*models.RelativeStrengthRating) error {
+					saved = ratings
+					return nil
+				}),
+		)
+
+		got, err := NewRelativeStrengthInteractor(tx, brandRepo, priceRepo, topixRepo, rsRepo).
+			CalculateRelativeStrength(context.Background(), date)
+		require.NoError(t, err)
+		assert.Equal(t, 2, got)
+
+		require.Len(t, saved, 2)
+		assert.Equal(t, "7203", saved[0].TickerSymbol)
+		assert.Equal(t, "トヨタ自動車", saved[0].Name)
+		assert.Equal(t, asOf, saved[0].Date)
+		assert.Equal(t, 99, saved[0].Rating)
+		assert.Equal(t, "0.2", saved[0].Score.String())
+		assert.Equal(t, "7267", saved[1].TickerSymbol)
+		assert.Equal(t, 1, saved[1].Rating)
+	})
+
+	t.Run("異常系: TOPIX の日足が無い", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+		topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		_, err := NewRelativeStrengthInteractor(
+			mock_repositories.NewMockTransaction(ctrl),
+			mock_repositories.NewMockStockBrandRepository(ctrl),
+			mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl),
+			topixRepo,
+			mock_repositories.NewMockRelativeStrengthRepository(ctrl),
+		).CalculateRelativeStrength(context.Background(), date)
+		assert.Error(t, err)
+	})
+}
+
+func TestRelativeStrengthInteractor_GetRelativeStrength(t *testing.T) {
+	date := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	latest := time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 指定日以前で最新の算出日を返す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		items := []*models.RelativeStrengthRating{{TickerSymbol: "7203", Date: latest, Rating: 95}}
+		rsRepo := mock_repositories.NewMockRelativeStrengthRepository(ctrl)
+		rsRepo.EXPECT().FindLatestDate(gomock.Any(), &date).Return(&latest, nil)
+		rsRepo.EXPECT().List(gomock.Any(), models.RelativeStrengthFilter{Date: latest, MinRating: 80}).Return(items, nil)
+
+		got, err := NewRelativeStrengthInteractor(nil, nil, nil, nil, rsRepo).GetRelativeStrength(context.Background(), &date, 80)
+		require.NoError(t, err)
+		assert.Equal(t, &latest, got.Date)
+		assert.Equal(t, items, got.Items)
+	})
+
+	t.Run("正常系: 算出済みの日が無ければ空", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		rsRepo := mock_repositories.NewMockRelativeStrengthRepository(ctrl)
+		rsRepo.EXPECT().FindLatestDate(gomock.Any(), nil).Return(nil, nil)
+
+		got, err := NewRelativeStrengthInteractor(nil, nil, nil, nil, rsRepo).GetRelativeStrength(context.Background(), nil, 0)
+		require.NoError(t, err)
+		assert.Nil(t, got.Date)
+		assert.Empty(t, got.Items)
+	})
+
+	t.Run("異常系: 取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		rsRepo := mock_repositories.NewMockRelativeStrengthRepository(ctrl)
+		rsRepo.EXPECT().FindLatestDate(gomock.Any(), nil).Return(&latest, nil)
+		rsRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewRelativeStrengthInteractor(nil, nil, nil, nil, rsRepo).GetRelativeStrength(context.Background(), nil, 0)
+		assert.Error(t, err)
+	})
+}
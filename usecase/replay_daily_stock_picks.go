@@ -0,0 +1,324 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+type ReplayDailyStockPicksInteractor interface {
+	// ReplayDailyStockPicks from〜to の各営業日について、その日までの日足だけで create_daily_stock_picks と同じスクリーニング
+	// （EvaluateDailyPickCandidate → RankDailyPickCandidates）を再現し、答え合わせまで行う。
+	// 推奨は score_version を replay/<version> にして保存するため、毎晩の live / shadow の推奨や通知とは混ざらない。
+	// /daily-stock-picks/stats?score_version=replay/<version> で live と同じ集計を見られる。同じ日を再実行すると洗い替える。
+	// versions が空なら domain_service.DailyPickScoringConfigs の全設定（合流スコアを使う設定を除く）をリプレイする。
+	// 合流スコアを使う設定は日ごとに1年分のシグナルを評価し直す必要があるためリプレイできない。
+	// topN / maxPerSector / concurrency / regimes の扱いは CreateDailyStockPicks と同じ。
+	// 銘柄ユニバースは現在の主要市場銘柄を使うため、上場廃止銘柄を含まない（生存者バイアスがある）点に注意。
+	ReplayDailyStockPicks(ctx context.Context, now, from, to time.Time, versions []string, topN, maxPerSector, concurrency int, regimes []string) error
+}
+
+type replayDailyStockPicksInteractorImpl struct {
+	tx                                   repositories.Transaction
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
+	stockBrandRepository                 repositories.StockBrandRepository
+	dailyStockPickRepository             repositories.DailyStockPickRepository
+	marketRegimeRepository               repositories.MarketRegimeRepository
+	// evaluator 答え合わせは毎晩の EvaluateDailyStockPicks と同じ判定を使う。
+	evaluator *evaluateDailyStockPicksInteractorImpl
+}
+
+func NewReplayDailyStockPicksInteractor(
+	tx repositories.Transaction,
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
+	stockBrandRepository repositories.StockBrandRepository,
+	dailyStockPickRepository repositories.DailyStockPickRepository,
+	marketRegimeRepository repositories.MarketRegimeRepository,
+	appliedStockSplitsHistoryRepository repositories.AppliedStockSplitsHistoryRepository,
+	appliedStockConsolidationsHistoryRepository repositories.AppliedStockConsolidationsHistoryRepository,
+) ReplayDailyStockPicksInteractor {
+	return &replayDailyStockPicksInteractorImpl{
+		tx:                                   tx,
+		stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
+		stockBrandRepository:                 stockBrandRepository,
+		dailyStockPickRepository:             dailyStockPickRepository,
+		marketRegimeRepository:               marketRegimeRepository,
+		evaluator: &evaluateDailyStockPicksInteractorImpl{
+			tx:                                   tx,
+			dailyStockPickRepository:             dailyStockPickRepository,
+			stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
+			appliedStockSplitsHistoryRepository:  appliedStockSplitsHistoryRepository,
+			appliedStockConsolidationsHistoryRepository: appliedStockConsolidationsHistoryRepository,
+		},
+	}
+}
+
+func (ri *replayDailyStockPicksInteractorImpl) ReplayDailyStockPicks(
+	ctx context.Context,
+	now, from, to time.Time,
+	versions []string,
+	topN, maxPerSector, concurrency int,
+	regimes []string,
+) error {
+	if to.Before(from) {
+		return errors.Errorf("from must be on or before to. from=%s to=%s", from.Format(util.DateLayout), to.Format(util.DateLayout))
+	}
+	if topN <= 0 {
+		topN = dailyStockPickDefaultTopN
+	}
+	if maxPerSector < 0 {
+		maxPerSector = dailyStockPickDefaultMaxPerSector
+	}
+
+	configs, err := replayScoringConfigs(versions, regimes)
+	if err != nil {
+		return err
+	}
+
+	// 各リプレイ日のウォームアップ分まで遡れるよう、暦日数＋ウォームアップ日数を上限に営業日を取る（営業日数は暦日数を超えない）。
+	limit := int(to.Sub(from).Hours()/24) + 1 + dailyStockPickWindowDays
+	dates, err := ri.stockBrandsDailyStockPriceRepository.ListRecentTradingDates(ctx, to, limit)
+	if err != nil {
+		return errors.Wrap(err, "ListRecentTradingDates error")
+	}
+	dates = replayTradingDatesAsc(dates)
+	days := replayDayIndexes(dates, from, dailyStockPickWindowDays)
+	if len(days) == 0 {
+		log.Printf("daily stock picks replay: no trading day with enough history. from=%s to=%s", from.Format(util.DateLayout), to.Format(util.DateLayout))
+		return nil
+	}
+
+	days, err = ri.filterDaysByRegime(ctx, dates, days, configs[0].Filter)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		return nil
+	}
+
+	brands, err := ri.stockBrandRepository.FindAllMainMarkets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "FindAllMainMarkets error")
+	}
+
+	candidates, err := ri.runReplayWorkers(ctx, brands, dates, days, configs, concurrency)
+	if err != nil {
+		return err
+	}
+
+	replayVersions := make([]string, 0, len(configs))
+	for _, c := range configs {
+		replayVersions = append(replayVersions, domain_service.DailyPickReplayScoreVersion(c.Version))
+	}
+
+	picksByVersion := make(map[string][]*models.DailyStockPick, len(configs))
+	for d, k := range days {
+		pickDate := dates[k]
+		var picks []*models.DailyStockPick
+		for i := range configs {
+			vp := domain_service.RankDailyPickCandidates(candidates[d][i], pickDate, replayVersions[i], topN, maxPerSector)
+			picks = append(picks, vp...)
+			picksByVersion[replayVersions[i]] = append(picksByVersion[replayVersions[i]], vp...)
+		}
+		if err := ri.savePickDate(ctx, now, pickDate, replayVersions, picks); err != nil {
+			return errors.Wrapf(err, "savePickDate error pickDate=%s", pickDate.Format(util.DateLayout))
+		}
+	}
+
+	for _, v := range replayVersions {
+		s := domain_service.SummarizeDailyPicksForView(picksByVersion[v])
+		log.Printf("daily stock picks replay: completed. scoreVersion=%s days=%d picks=%d evaluated=%d winRate=%s avgReturn5d=%s",
+			v, len(days), s.Total, s.EvaluatedCount, s.WinRate.StringFixed(4), s.AvgReturn5D.StringFixed(4))
+	}
+	return nil
+}
+
+// savePickDate 1日分のリプレイ推奨を洗い替えて保存し、その場で答え合わせする。
+// 答え合わせは evaluatePickDate をそのまま使うため、5営業日後が未到来の直近分は部分的に埋まり、毎晩の EvaluateDailyStockPicks が引き継ぐ。
+func (ri *replayDailyStockPicksInteractorImpl) savePickDate(ctx context.Context, now, pickDate time.Time, replayVersions []string, picks []*models.DailyStockPick) error {
+	return ri.tx.DoInTx(ctx, func(ctx context.Context) error {
+		if err := ri.dailyStockPickRepository.DeleteByPickDate(ctx, pickDate, replayVersions); err != nil {
+			return errors.Wrap(err, "DeleteByPickDate error")
+		}
+		if len(picks) == 0 {
+			return nil
+		}
+		if err := ri.dailyStockPickRepository.BulkCreate(ctx, picks); err != nil {
+			return errors.Wrap(err, "BulkCreate error")
+		}
+		if err := ri.evaluator.evaluatePickDate(ctx, pickDate, picks, now); err != nil {
+			return errors.Wrap(err, "evaluatePickDate error")
+		}
+		return nil
+	})
+}
+
+// filterDaysByRegime 局面ゲートが指定されていれば、各リプレイ日の市場局面で絞る。局面が未算出の日はゲートせずに通す（live と同じ扱い）。
+func (ri *replayDailyStockPicksInteractorImpl) filterDaysByRegime(ctx context.Context, dates []time.Time, days []int, filter domain_service.DailyPickFilterParams) ([]int, error) {
+	if len(filter.AllowedRegimes) == 0 {
+		return days, nil
+	}
+	first, last := dates[days[0]], dates[days[len(days)-1]]
+	regimes, err := ri.marketRegimeRepository.ListMarketRegimes(ctx, models.MarketRegimeFilter{From: &first, To: &last})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListMarketRegimes error")
+	}
+	regimeByDate := make(map[string]string, len(regimes))
+	for _, r := range regimes {
+		regimeByDate[r.Date.Format(util.DateLayout)] = r.Regime
+	}
+
+	out := make([]int, 0, len(days))
+	for _, k := range days {
+		regime, ok := regimeByDate[dates[k].Format(util.DateLayout)]
+		if ok && !filter.AllowsRegime(regime) {
+			continue
+		}
+		out = append(out, k)
+	}
+	return out, nil
+}
+
+// runReplayWorkers 固定 concurrency 個のワーカーで全銘柄を並列に評価する（runScreeningWorkers と同じ設計）。
+// 各銘柄の日足はリプレイ期間全体を1回だけ取得し、リプレイ日ごとに「その日までの dailyStockPickWindowDays 営業日」だけを切り出して評価する。
+// 戻り値は [days の添字][configs の添字] の候補。
+func (ri *replayDailyStockPicksInteractorImpl) runReplayWorkers(
+	ctx context.Context,
+	brands []*models.StockBrand,
+	dates []time.Time,
+	days []int,
+	configs []domain_service.DailyPickScoringConfig,
+	concurrency int,
+) ([][][]*domain_service.DailyPickCandidate, error) {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	asc := models.SortOrderAsc
+	from := dates[days[0]-dailyStockPickWindowDays+1]
+	to := dates[days[len(days)-1]]
+
+	newResult := func() [][][]*domain_service.DailyPickCandidate {
+		r := make([][][]*domain_service.DailyPickCandidate, len(days))
+		for d := range r {
+			r[d] = make([][]*domain_service.DailyPickCandidate, len(configs))
+		}
+		return r
+	}
+
+	workerResults := make([][][][]*domain_service.DailyPickCandidate, concurrency)
+	jobs := make(chan *models.StockBrand)
+	g, gctx := errgroup.WithContext(ctx)
+
+	for w := 0; w < concurrency; w++ {
+		w := w
+		g.Go(func() error {
+			local := newResult()
+			for brand := range jobs {
+				prices, err := ri.stockBrandsDailyStockPriceRepository.ListDailyPricesBySymbol(gctx, models.ListDailyPricesBySymbolFilter{
+					TickerSymbol: brand.TickerSymbol,
+					DateFrom:     &from,
+					DateTo:       &to,
+					DateOrder:    &asc,
+				})
+				if err != nil {
+					return errors.Wrapf(err, "ListDailyPricesBySymbol error symbol=%s", brand.TickerSymbol)
+				}
+				for d, k := range days {
+					window := domain_service.DailyPickPricesAsOf(prices, dates[k-dailyStockPickWindowDays+1], dates[k])
+					for i, cfg := range configs {
+						if c := domain_service.EvaluateDailyPickCandidate(brand, window, cfg.Filter, cfg.Weights); c != nil {
+							local[d][i] = append(local[d][i], c)
+						}
+					}
+				}
+			}
+			workerResults[w] = local
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		defer close(jobs)
+		for _, brand := range brands {
+			select {
+			case jobs <- brand:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, errors.Wrap(err, "runReplayWorkers error")
+	}
+
+	candidates := newResult()
+	for _, r := range workerResults {
+		for d := range r {
+			for i := range r[d] {
+				candidates[d][i] = append(candidates[d][i], r[d][i]...)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// replayScoringConfigs versions に対応するスコア設定を返す（空なら合流スコアを使う設定を除く全設定）。
+// 未登録のバージョンと合流スコアを使うバージョンはエラー。
+func replayScoringConfigs(versions []string, regimes []string) ([]domain_service.DailyPickScoringConfig, error) {
+	var configs []domain_service.DailyPickScoringConfig
+	if len(versions) == 0 {
+		for _, c := range domain_service.DailyPickScoringConfigs() {
+			if !c.Weights.UsesConfluence() {
+				configs = append(configs, c)
+			}
+		}
+	} else {
+		for _, v := range versions {
+			c, ok := domain_service.FindDailyPickScoringConfig(v)
+			if !ok {
+				return nil, errors.Errorf("unknown score version %q", v)
+			}
+			if c.Weights.UsesConfluence() {
+				return nil, errors.Errorf("score version %q uses signal confluence and cannot be replayed", v)
+			}
+			configs = append(configs, c)
+		}
+	}
+	for i := range configs {
+		configs[i].Filter.AllowedRegimes = regimes
+	}
+	return configs, nil
+}
+
+// replayTradingDatesAsc ListRecentTradingDates の結果（新しい順）を古い順に並べ替えた新しいスライスを返す。
+func replayTradingDatesAsc(desc []time.Time) []time.Time {
+	asc := make([]time.Time, len(desc))
+	for i, d := range desc {
+		asc[len(desc)-1-i] = d
+	}
+	return asc
+}
+
+// replayDayIndexes 古い順の営業日 dates のうち、from 以降かつ windowDays 営業日分のウォームアップを遡れる日の添字を返す。
+func replayDayIndexes(dates []time.Time, from time.Time, windowDays int) []int {
+	var days []int
+	for k := windowDays - 1; k < len(dates); k++ {
+		if dates[k].Before(from) {
+			continue
+		}
+		days = append(days, k)
+	}
+	return days
+}
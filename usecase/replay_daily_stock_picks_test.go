@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestReplayDailyStockPicksInteractorImpl_ReplayDailyStockPicks(t *testing.T) {
+	// 120本目でブレイクし、その後5本の日足が続く銘柄。リプレイ日は120本目の日だけ。
+	prices := makeDailyPickUsecasePrices(dailyStockPickWindowDays, decimal.NewFromInt(1000), decimal.NewFromInt(2000))
+	for _, p := range prices {
+		p.TickerSymbol = "1000"
+	}
+	pickDate := prices[len(prices)-1].Date
+	for i := 1; i <= 5; i++ {
+		prices = append(prices, &models.StockBrandDailyPrice{
+			TickerSymbol: "1000",
+			Date:         pickDate.AddDate(0, 0, i),
+			Close:        decimal.NewFromInt(2100),
+			High:         decimal.NewFromInt(2110),
+			Low:          decimal.NewFromInt(2090),
+			Volume:       2_000_000,
+			Adjclose:     decimal.NewFromInt(2100),
+		})
+	}
+	datesDesc := make([]time.Time, 0, dailyStockPickWindowDays)
+	for i := dailyStockPickWindowDays - 1; i >= 0; i-- {
+		datesDesc = append(datesDesc, prices[i].Date)
+	}
+	now := pickDate.AddDate(0, 0, 40)
+	brand := &models.StockBrand{ID: "brand-1", TickerSymbol: "1000", Name: "テスト銘柄"}
+
+	t.Run("その日までの日足だけでスクリーニングしreplay名前空間に保存して答え合わせする", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), pickDate, 1+dailyStockPickWindowDays).Return(datesDesc, nil)
+		// 未来のバーが混ざって返っても、リプレイ日より後は評価に使わない
+		priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), models.ListDailyPricesBySymbolFilter{
+			TickerSymbol: "1000",
+			DateFrom:     &datesDesc[len(datesDesc)-1],
+			DateTo:       &pickDate,
+			DateOrder:    dateOrderPtr(models.SortOrderAsc),
+		}).Return(prices, nil)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), models.ListRangePricesBySymbolsFilter{
+			Symbols:  []string{"1000"},
+			DateFrom: &pickDate,
+			DateTo:   &now,
+		}).Return(prices[dailyStockPickWindowDays-1:], nil)
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return([]*models.StockBrand{brand}, nil)
+
+		versions := domain_service.DailyPickReplayScoreVersions()
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		gomock.InOrder(
+			pickRepo.EXPECT().DeleteByPickDate(gomock.Any(), pickDate, versions).Return(nil),
+			pickRepo.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, picks []*models.DailyStockPick) error {
+					saved := make([]string, 0, len(picks))
+					for _, p := range picks {
+						assert.Equal(t, pickDate, p.PickDate)
+						assert.True(t, p.BaseClosePrice.Equal(decimal.NewFromInt(2000)), "基準値はリプレイ日の終値")
+						saved = append(saved, p.ScoreVersion)
+					}
+					assert.ElementsMatch(t, versions, saved)
+					return nil
+				}),
+			pickRepo.EXPECT().UpdateEvaluations(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, picks []*models.DailyStockPick) error {
+					assert.Len(t, picks, len(versions))
+					for _, p := range picks {
+						assert.True(t, p.Evaluated())
+						assert.Equal(t, models.DailyStockPickOutcomeWin, *p.Outcome)
+						assert.Equal(t, "0.05", p.Return5D.String())
+					}
+					return nil
+				}),
+		)
+
+		splitRepo := mock_repositories.NewMockAppliedStockSplitsHistoryRepository(ctrl)
+		splitRepo.EXPECT().Exists(gomock.Any(), "1000", gomock.Any()).Return(false, nil).Times(5 * len(versions))
+		consolidationRepo := mock_repositories.NewMockAppliedStockConsolidationsHistoryRepository(ctrl)
+		consolidationRepo.EXPECT().Exists(gomock.Any(), "1000", gomock.Any()).Return(false, nil).Times(5 * len(versions))
+
+		tx := mock_repositories.NewMockTransaction(ctrl)
+		tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+		interactor := NewReplayDailyStockPicksInteractor(tx, priceRepo, brandRepo, pickRepo, nil, splitRepo, consolidationRepo)
+		err := interactor.ReplayDailyStockPicks(context.Background(), now, pickDate, pickDate, nil, 25, 4, 1, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("ウォームアップ分の履歴が無い日はリプレイしない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		from := datesDesc[len(datesDesc)-1]
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), from, 1+dailyStockPickWindowDays).Return(datesDesc[len(datesDesc)-1:], nil)
+		// FindAllMainMarkets / 保存は呼ばれない
+
+		interactor := NewReplayDailyStockPicksInteractor(nil, priceRepo, mock_repositories.NewMockStockBrandRepository(ctrl),
+			mock_repositories.NewMockDailyStockPickRepository(ctrl), nil, nil, nil)
+		assert.NoError(t, interactor.ReplayDailyStockPicks(context.Background(), now, from, from, nil, 25, 4, 1, nil))
+	})
+
+	t.Run("局面ゲートに掛かった日はスクリーニングしない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), pickDate, 1+dailyStockPickWindowDays).Return(datesDesc, nil)
+		regimeRepo := mock_repositories.NewMockMarketRegimeRepository(ctrl)
+		regimeRepo.EXPECT().ListMarketRegimes(gomock.Any(), models.MarketRegimeFilter{From: &pickDate, To: &pickDate}).
+			Return([]*models.MarketRegime{{Date: pickDate, Regime: models.MarketRegimeTrendDown}}, nil)
+
+		interactor := NewReplayDailyStockPicksInteractor(nil, priceRepo, mock_repositories.NewMockStockBrandRepository(ctrl),
+			mock_repositories.NewMockDailyStockPickRepository(ctrl), regimeRepo, nil, nil)
+		err := interactor.ReplayDailyStockPicks(context.Background(), now, pickDate, pickDate, nil, 25, 4, 1, []string{models.MarketRegimeTrendUp})
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 未登録のスコアバージョン", func(t *testing.T) {
+		interactor := NewReplayDailyStockPicksInteractor(nil, nil, nil, nil, nil, nil, nil)
+		err := interactor.ReplayDailyStockPicks(context.Background(), now, pickDate, pickDate, []string{"v0"}, 25, 4, 1, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: fromがtoより後", func(t *testing.T) {
+		interactor := NewReplayDailyStockPicksInteractor(nil, nil, nil, nil, nil, nil, nil)
+		err := interactor.ReplayDailyStockPicks(context.Background(), now, pickDate, pickDate.AddDate(0, 0, -1), nil, 25, 4, 1, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestReplayScoringConfigs(t *testing.T) {
+	configs, err := replayScoringConfigs(nil, []string{models.MarketRegimeTrendUp})
+	assert.NoError(t, err)
+	versions := make([]string, 0, len(configs))
+	for _, c := range configs {
+		versions = append(versions, domain_service.DailyPickReplayScoreVersion(c.Version))
+		assert.Equal(t, []string{models.MarketRegimeTrendUp}, c.Filter.AllowedRegimes)
+	}
+	assert.Equal(t, domain_service.DailyPickReplayScoreVersions(), versions, "省略時は合流スコアを使う設定を除く")
+
+	_, err = replayScoringConfigs([]string{"v1-confluence"}, nil)
+	assert.EqualError(t, err, `score version "v1-confluence" uses signal confluence and cannot be replayed`)
+	_, err = replayScoringConfigs([]string{"v0"}, nil)
+	assert.EqualError(t, err, `unknown score version "v0"`)
+}
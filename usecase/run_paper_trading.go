@@ -0,0 +1,263 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+type RunPaperTradingInteractor interface {
+	// RunPaperTrading 模擬口座を、最後に NAV を付けた営業日の翌営業日から now 以前の最新営業日まで1営業日ずつ進める。
+	// 各営業日に前営業日の推奨の上位を寄りで買い付け、手仕舞い判定と終値での値洗いを行い NAV を保存する。
+	// 口座が未作成なら account の内容で作成する（作成済みなら保存済みの売買ルールを使い、account の売買ルールは使わない）。
+	// 1営業日ぶんの約定・ポジション・NAV は1トランザクションで保存するため、途中で失敗しても再実行で続きから進む。
+	RunPaperTrading(ctx context.Context, now time.Time, account models.PaperAccount) error
+}
+
+type runPaperTradingInteractorImpl struct {
+	tx                                          repositories.Transaction
+	paperTradingRepository                      repositories.PaperTradingRepository
+	dailyStockPickRepository                    repositories.DailyStockPickRepository
+	stockBrandsDailyStockPriceRepository        repositories.StockBrandsDailyPriceRepository
+	appliedStockSplitsHistoryRepository         repositories.AppliedStockSplitsHistoryRepository
+	appliedStockConsolidationsHistoryRepository repositories.AppliedStockConsolidationsHistoryRepository
+}
+
+func NewRunPaperTradingInteractor(
+	tx repositories.Transaction,
+	paperTradingRepository repositories.PaperTradingRepository,
+	dailyStockPickRepository repositories.DailyStockPickRepository,
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
+	appliedStockSplitsHistoryRepository repositories.AppliedStockSplitsHistoryRepository,
+	appliedStockConsolidationsHistoryRepository repositories.AppliedStockConsolidationsHistoryRepository,
+) RunPaperTradingInteractor {
+	return &runPaperTradingInteractorImpl{
+		tx:                                   tx,
+		paperTradingRepository:               paperTradingRepository,
+		dailyStockPickRepository:             dailyStockPickRepository,
+		stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
+		appliedStockSplitsHistoryRepository:  appliedStockSplitsHistoryRepository,
+		appliedStockConsolidationsHistoryRepository: appliedStockConsolidationsHistoryRepository,
+	}
+}
+
+func (ri *runPaperTradingInteractorImpl) RunPaperTrading(ctx context.Context, now time.Time, account models.PaperAccount) error {
+	acc, err := ri.findOrCreateAccount(ctx, now, account)
+	if err != nil {
+		return err
+	}
+
+	latest, err := ri.paperTradingRepository.FindLatestNav(ctx, acc.Name)
+	if err != nil {
+		return errors.Wrap(err, "FindLatestNav error")
+	}
+	since := acc.StartDate
+	cash := acc.InitialCash
+	realizedPnL := decimal.Zero
+	if latest != nil {
+		since = latest.Date.AddDate(0, 0, 1)
+		cash = latest.Cash
+		realizedPnL = latest.RealizedPnL
+	}
+	if since.After(now) {
+		return nil
+	}
+
+	days, err := ri.tradingDaysSince(ctx, since, now)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		return nil
+	}
+
+	openStatus := models.PaperPositionStatusOpen
+	open, err := ri.paperTradingRepository.ListPositions(ctx, models.PaperPositionFilter{Account: acc.Name, Status: &openStatus})
+	if err != nil {
+		return errors.Wrap(err, "ListPositions error")
+	}
+
+	for _, d := range days {
+		step, err := ri.stepDay(ctx, acc, d, cash, realizedPnL, open)
+		if err != nil {
+			return errors.Wrapf(err, "stepDay error date=%s", d.day.Format("2006-01-02"))
+		}
+		cash = step.Nav.Cash
+		realizedPnL = step.Nav.RealizedPnL
+		open = slices.DeleteFunc(slices.Concat(step.Updated, step.Opened), func(p *models.PaperPosition) bool { return !p.Open() })
+
+		log.Printf("paper trading: account=%s date=%s bought=%d skipped=%d open=%d nav=%s",
+			acc.Name, d.day.Format("2006-01-02"), len(step.Opened), step.Skipped, step.Nav.OpenPositions, step.Nav.Nav.StringFixed(0))
+	}
+	return nil
+}
+
+// findOrCreateAccount 口座を取得し、未作成なら account の内容で作成する。開始日を省略したら now の日付から始める。
+func (ri *runPaperTradingInteractorImpl) findOrCreateAccount(ctx context.Context, now time.Time, account models.PaperAccount) (*models.PaperAccount, error) {
+	acc, err := ri.paperTradingRepository.FindAccount(ctx, account.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindAccount error")
+	}
+	if acc != nil {
+		return acc, nil
+	}
+
+	if account.Name == "" || account.ScoreVersion == "" {
+		return nil, errors.New("paper account name and score version are required")
+	}
+	if account.TopN <= 0 || account.HoldingDays <= 0 || !account.BudgetPerPick.IsPositive() || !account.InitialCash.IsPositive() {
+		return nil, errors.New("paper account top-n, holding-days, budget-per-pick and initial-cash must be positive")
+	}
+	if account.StartDate.IsZero() {
+		account.StartDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+	if err := ri.paperTradingRepository.CreateAccount(ctx, &account); err != nil {
+		return nil, errors.Wrap(err, "CreateAccount error")
+	}
+	log.Printf("paper trading: account created. account=%s scoreVersion=%s start=%s", account.Name, account.ScoreVersion, account.StartDate.Format("2006-01-02"))
+	return &account, nil
+}
+
+// paperTradingDay 処理する営業日と、買い付ける推奨の推奨日（直前の営業日。不明なら nil）。
+type paperTradingDay struct {
+	day      time.Time
+	pickDate *time.Time
+}
+
+// tradingDaysSince since〜now の営業日を昇順に返す。各営業日の推奨日として直前の営業日も添える。
+func (ri *runPaperTradingInteractorImpl) tradingDaysSince(ctx context.Context, since, now time.Time) ([]paperTradingDay, error) {
+	// 営業日数は暦日数を超えないため、暦日数+1件取れば since の直前の営業日まで含まれる。
+	calendarDays := int(now.Sub(since).Hours()/24) + 1
+	desc, err := ri.stockBrandsDailyStockPriceRepository.ListRecentTradingDates(ctx, now, calendarDays+1)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListRecentTradingDates error")
+	}
+	dates := slices.Clone(desc)
+	slices.Reverse(dates)
+
+	var days []paperTradingDay
+	for i, d := range dates {
+		if d.Before(since) {
+			continue
+		}
+		day := paperTradingDay{day: d}
+		if i > 0 {
+			prev := dates[i-1]
+			day.pickDate = &prev
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// stepDay 1営業日ぶんの推奨・日足・分割併合を集めて模擬売買を進め、結果を1トランザクションで保存する。
+func (ri *runPaperTradingInteractorImpl) stepDay(
+	ctx context.Context,
+	acc *models.PaperAccount,
+	d paperTradingDay,
+	cash, realizedPnL decimal.Decimal,
+	open []*models.PaperPosition,
+) (*domain_service.PaperTradingDay, error) {
+	var picks []*models.DailyStockPick
+	if d.pickDate != nil {
+		var err error
+		picks, err = ri.dailyStockPickRepository.ListByPickDate(ctx, *d.pickDate, acc.ScoreVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "ListByPickDate error")
+		}
+	}
+
+	symbols := make([]string, 0, len(open)+len(picks))
+	for _, p := range open {
+		symbols = append(symbols, p.TickerSymbol)
+	}
+	for _, p := range picks {
+		if p.PickRank <= acc.TopN {
+			symbols = append(symbols, p.TickerSymbol)
+		}
+	}
+	slices.Sort(symbols)
+	symbols = slices.Compact(symbols)
+
+	bars := make(map[string]*models.StockBrandDailyPrice, len(symbols))
+	if len(symbols) > 0 {
+		prices, err := ri.stockBrandsDailyStockPriceRepository.ListRangePricesBySymbols(ctx, models.ListRangePricesBySymbolsFilter{
+			Symbols:  symbols,
+			DateFrom: &d.day,
+			DateTo:   &d.day,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "ListRangePricesBySymbols error")
+		}
+		for _, p := range prices {
+			bars[p.TickerSymbol] = p
+		}
+	}
+
+	corporateActions, err := ri.corporateActions(ctx, d.day, open)
+	if err != nil {
+		return nil, err
+	}
+
+	step := domain_service.StepPaperTradingDay(acc, d.day, cash, realizedPnL, open, picks, bars, corporateActions)
+
+	if err := ri.tx.DoInTx(ctx, func(ctx context.Context) error {
+		if err := ri.paperTradingRepository.CreatePositions(ctx, step.Opened); err != nil {
+			return errors.Wrap(err, "CreatePositions error")
+		}
+		if err := ri.paperTradingRepository.UpdatePositions(ctx, step.Updated); err != nil {
+			return errors.Wrap(err, "UpdatePositions error")
+		}
+		fills := make([]*models.PaperFill, 0, len(step.Opened)*2)
+		for _, p := range step.Opened {
+			fills = append(fills, domain_service.PaperBuyFill(p))
+		}
+		for _, p := range slices.Concat(step.Opened, step.Updated) {
+			if f := domain_service.PaperSellFill(p); f != nil && f.FillDate.Equal(d.day) {
+				fills = append(fills, f)
+			}
+		}
+		if err := ri.paperTradingRepository.CreateFills(ctx, fills); err != nil {
+			return errors.Wrap(err, "CreateFills error")
+		}
+		if err := ri.paperTradingRepository.CreateNav(ctx, step.Nav); err != nil {
+			return errors.Wrap(err, "CreateNav error")
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "DoInTx error")
+	}
+	return step, nil
+}
+
+// corporateActions day に株式分割・併合が適用された保有銘柄の銘柄コード。
+func (ri *runPaperTradingInteractorImpl) corporateActions(ctx context.Context, day time.Time, open []*models.PaperPosition) (map[string]bool, error) {
+	out := make(map[string]bool)
+	for _, p := range open {
+		if _, ok := out[p.TickerSymbol]; ok {
+			continue
+		}
+		split, err := ri.appliedStockSplitsHistoryRepository.Exists(ctx, p.TickerSymbol, day)
+		if err != nil {
+			return nil, errors.Wrap(err, "AppliedStockSplitsHistoryRepository.Exists error")
+		}
+		consolidation := false
+		if !split {
+			consolidation, err = ri.appliedStockConsolidationsHistoryRepository.Exists(ctx, p.TickerSymbol, day)
+			if err != nil {
+				return nil, errors.Wrap(err, "AppliedStockConsolidationsHistoryRepository.Exists error")
+			}
+		}
+		out[p.TickerSymbol] = split || consolidation
+	}
+	return out, nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestRunPaperTradingInteractorImpl_RunPaperTrading(t *testing.T) {
+	now := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+	prev := now.AddDate(0, 0, -1)
+	account := models.PaperAccount{
+		Name:          models.PaperAccountDefaultName,
+		ScoreVersion:  domain_service.DailyPickScoreVersion,
+		TopN:          1,
+		BudgetPerPick: decimal.NewFromInt(500_000),
+		HoldingDays:   5,
+		InitialCash:   decimal.NewFromInt(1_000_000),
+	}
+
+	t.Run("未作成の口座を作成し、前営業日の推奨を寄りで買ってNAVを保存する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		paperRepo := mock_repositories.NewMockPaperTradingRepository(ctrl)
+		paperRepo.EXPECT().FindAccount(gomock.Any(), account.Name).Return(nil, nil)
+		paperRepo.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, a *models.PaperAccount) error {
+			assert.Equal(t, now, a.StartDate, "開始日の省略時は now の日付")
+			return nil
+		})
+		paperRepo.EXPECT().FindLatestNav(gomock.Any(), account.Name).Return(nil, nil)
+		openStatus := models.PaperPositionStatusOpen
+		paperRepo.EXPECT().ListPositions(gomock.Any(), models.PaperPositionFilter{Account: account.Name, Status: &openStatus}).Return(nil, nil)
+		paperRepo.EXPECT().CreatePositions(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, positions []*models.PaperPosition) error {
+			if assert.Len(t, positions, 1) {
+				assert.Equal(t, "1000", positions[0].TickerSymbol)
+				assert.Equal(t, 400, positions[0].Quantity)
+				positions[0].ID = 1
+			}
+			return nil
+		})
+		paperRepo.EXPECT().UpdatePositions(gomock.Any(), gomock.Len(0)).Return(nil)
+		paperRepo.EXPECT().CreateFills(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fills []*models.PaperFill) error {
+			if assert.Len(t, fills, 1) {
+				assert.Equal(t, models.PaperFillSideBuy, fills[0].Side)
+				assert.Equal(t, uint64(1), fills[0].PositionID)
+			}
+			return nil
+		})
+		paperRepo.EXPECT().CreateNav(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, nav *models.PaperNav) error {
+			assert.Equal(t, now, nav.Date)
+			assert.Equal(t, "520000", nav.Cash.String())
+			assert.Equal(t, "1008000", nav.Nav.String())
+			return nil
+		})
+
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), now, 2).Return([]time.Time{now, prev}, nil)
+		priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), models.ListRangePricesBySymbolsFilter{
+			Symbols:  []string{"1000"},
+			DateFrom: &now,
+			DateTo:   &now,
+		}).Return([]*models.StockBrandDailyPrice{{
+			TickerSymbol: "1000",
+			Date:         now,
+			Open:         decimal.NewFromInt(1200),
+			High:         decimal.NewFromInt(1230),
+			Low:          decimal.NewFromInt(1190),
+			Close:        decimal.NewFromInt(1220),
+		}}, nil)
+
+		pickRepo := mock_repositories.NewMockDailyStockPickRepository(ctrl)
+		pickRepo.EXPECT().ListByPickDate(gomock.Any(), prev, account.ScoreVersion).Return([]*models.DailyStockPick{
+			{PickDate: prev, PickRank: 1, StockBrandID: "brand-1", TickerSymbol: "1000"},
+			{PickDate: prev, PickRank: 2, StockBrandID: "brand-2", TickerSymbol: "2000"},
+		}, nil)
+
+		tx := mock_repositories.NewMockTransaction(ctrl)
+		tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+		interactor := NewRunPaperTradingInteractor(tx, paperRepo, pickRepo, priceRepo,
+			mock_repositories.NewMockAppliedStockSplitsHistoryRepository(ctrl),
+			mock_repositories.NewMockAppliedStockConsolidationsHistoryRepository(ctrl))
+		assert.NoError(t, interactor.RunPaperTrading(context.Background(), now, account))
+	})
+
+	t.Run("最新の営業日までNAVを付け済みなら何もしない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		saved := account
+		saved.StartDate = prev
+		paperRepo := mock_repositories.NewMockPaperTradingRepository(ctrl)
+		paperRepo.EXPECT().FindAccount(gomock.Any(), account.Name).Return(&saved, nil)
+		paperRepo.EXPECT().FindLatestNav(gomock.Any(), account.Name).Return(&models.PaperNav{Account: account.Name, Date: now}, nil)
+
+		interactor := NewRunPaperTradingInteractor(
+			mock_repositories.NewMockTransaction(ctrl),
+			paperRepo,
+			mock_repositories.NewMockDailyStockPickRepository(ctrl),
+			mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl),
+			mock_repositories.NewMockAppliedStockSplitsHistoryRepository(ctrl),
+			mock_repositories.NewMockAppliedStockConsolidationsHistoryRepository(ctrl))
+		assert.NoError(t, interactor.RunPaperTrading(context.Background(), now, account))
+	})
+
+	t.Run("売買ルールが不正なら口座を作らない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		paperRepo := mock_repositories.NewMockPaperTradingRepository(ctrl)
+		paperRepo.EXPECT().FindAccount(gomock.Any(), account.Name).Return(nil, nil)
+
+		invalid := account
+		invalid.TopN = 0
+		interactor := NewRunPaperTradingInteractor(
+			mock_repositories.NewMockTransaction(ctrl),
+			paperRepo,
+			mock_repositories.NewMockDailyStockPickRepository(ctrl),
+			mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl),
+			mock_repositories.NewMockAppliedStockSplitsHistoryRepository(ctrl),
+			mock_repositories.NewMockAppliedStockConsolidationsHistoryRepository(ctrl))
+		assert.Error(t, interactor.RunPaperTrading(context.Background(), now, invalid))
+	})
+}
@@ -13,9 +13,13 @@ import (
 	"github.com/Code0716/stock-price-repository/util"
 )
 
+// sectorRotationSlackWeeks 祝日週や週足の欠けに備えて余分に取得する週数。
+const sectorRotationSlackWeeks = 2
+
 type sectorPerformanceInteractorImpl struct {
 	sector33Repo repositories.Sector33AverageDailyPriceRepository
 	sector17Repo repositories.Sector17AverageDailyPriceRepository
+	topixRepo    repositories.TopixRepository
 }
 
 // SectorPerformanceInteractor セクターパフォーマンス API のユースケース
@@ -23,16 +27,21 @@ type SectorPerformanceInteractor interface {
 	// GetSectorPerformance 指定期間の業種別パフォーマンスを算出する。
 	// granularity は "33"（デフォルト）または "17" を受け取る。
 	GetSectorPerformance(ctx context.Context, from, to time.Time, granularity string) (*models.SectorPerformance, error)
+	// GetSectorRotation to 時点の業種別 RRG（TOPIX 比の RS-Ratio / RS-Momentum）を直近 weeks 週分の軌跡で返す。
+	// level は "33"（デフォルト）または "17"、window は z スコアを取る週数。
+	GetSectorRotation(ctx context.Context, to time.Time, level string, weeks, window int) (*models.SectorRotation, error)
 }
 
 // NewSectorPerformanceInteractor コンストラクタ
 func NewSectorPerformanceInteractor(
 	sector33Repo repositories.Sector33AverageDailyPriceRepository,
 	sector17Repo repositories.Sector17AverageDailyPriceRepository,
+	topixRepo repositories.TopixRepository,
 ) SectorPerformanceInteractor {
 	return &sectorPerformanceInteractorImpl{
 		sector33Repo: sector33Repo,
 		sector17Repo: sector17Repo,
+		topixRepo:    topixRepo,
 	}
 }
 
@@ -62,3 +71,37 @@ func (s *sectorPerformanceInteractorImpl) GetSectorPerformance(ctx context.Conte
 		Sectors:     items,
 	}, nil
 }
+
+func (s *sectorPerformanceInteractorImpl) GetSectorRotation(ctx context.Context, to time.Time, level string, weeks, window int) (*models.SectorRotation, error) {
+	from := to.AddDate(0, 0, -7*(domain_service.SectorRotationWeeklyBars(window)+weeks+sectorRotationSlackWeeks))
+
+	topix, err := s.topixRepo.ListTopixDailyPrices(ctx, &from, &to)
+	if err != nil {
+		return nil, errors.Wrap(err, "sectorPerformanceInteractorImpl.GetSectorRotation: ListTopixDailyPrices")
+	}
+
+	var items []*models.SectorRotationItem
+	switch level {
+	case "17":
+		rows, err := s.sector17Repo.ListRangeAll(ctx, from, to)
+		if err != nil {
+			return nil, errors.Wrap(err, "sectorPerformanceInteractorImpl.GetSectorRotation: sector17 ListRangeAll")
+		}
+		items = domain_service.CalcSector17Rotation(rows, topix, models.Sector17Codes, window, weeks)
+	default:
+		// "33" またはデフォルト
+		rows, err := s.sector33Repo.ListRangeAll(ctx, from, to)
+		if err != nil {
+			return nil, errors.Wrap(err, "sectorPerformanceInteractorImpl.GetSectorRotation: sector33 ListRangeAll")
+		}
+		items = domain_service.CalcSector33Rotation(rows, topix, models.Sector33Codes, window, weeks)
+	}
+
+	return &models.SectorRotation{
+		Level:   level,
+		Weeks:   weeks,
+		Window:  window,
+		To:      to.Format(util.DateLayout),
+		Sectors: items,
+	}, nil
+}
@@ -190,3 +190,72 @@ func TestSectorPerformanceInteractor_GetSectorPerformance(t *testing.T) {
 		})
 	}
 }
+
+func TestSectorPerformanceInteractor_GetSectorRotation(t *testing.T) {
+	to := time.Date(2024, 7, 26, 0, 0, 0, 0, time.UTC)
+	const weeks, window = 4, 5
+	from := to.AddDate(0, 0, -7*(2*window+weeks+sectorRotationSlackWeeks))
+
+	// 2024-01-01(月) から30週分、業種 3700 は20週横ばいの後に TOPIX(1000) を上回り続ける。
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var rows33 []*models.Sector33AverageDailyPrice
+	var topix models.IndexStockAverageDailyPrices
+	for w := range 30 {
+		c := 1000.0
+		if w >= 20 {
+			c += float64((w-19)*(w-19)) * 5
+		}
+		for d := range 5 {
+			date := base.AddDate(0, 0, w*7+d)
+			rows33 = append(rows33, &models.Sector33AverageDailyPrice{Date: date, SectorCode: "3700", Adjclose: decimal.NewFromFloat(c)})
+			topix = append(topix, &models.IndexStockAverageDailyPrice{Date: date, Close: decimal.NewFromInt(1000)})
+		}
+	}
+
+	t.Run("正常系（level=33）: 業種別の軌跡と象限を返す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+		topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), &from, &to).Return(topix, nil)
+		sector33Repo := mock_repositories.NewMockSector33AverageDailyPriceRepository(ctrl)
+		sector33Repo.EXPECT().ListRangeAll(gomock.Any(), from, to).Return(rows33, nil)
+
+		got, err := NewSectorPerformanceInteractor(sector33Repo, mock_repositories.NewMockSector17AverageDailyPriceRepository(ctrl), topixRepo).
+			GetSectorRotation(context.Background(), to, "33", weeks, window)
+		assert.NoError(t, err)
+		assert.Equal(t, "33", got.Level)
+		assert.Equal(t, "2024-07-26", got.To)
+		assert.Len(t, got.Sectors, 1)
+		assert.Equal(t, "輸送用機器", got.Sectors[0].SectorName)
+		assert.Len(t, got.Sectors[0].Tail, weeks)
+		assert.Equal(t, models.SectorQuadrantLeading, got.Sectors[0].Quadrant)
+	})
+
+	t.Run("正常系（level=17）: 17業種リポジトリが呼ばれる", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+		topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(topix, nil)
+		sector17Repo := mock_repositories.NewMockSector17AverageDailyPriceRepository(ctrl)
+		sector17Repo.EXPECT().ListRangeAll(gomock.Any(), from, to).Return(nil, nil)
+
+		got, err := NewSectorPerformanceInteractor(mock_repositories.NewMockSector33AverageDailyPriceRepository(ctrl), sector17Repo, topixRepo).
+			GetSectorRotation(context.Background(), to, "17", weeks, window)
+		assert.NoError(t, err)
+		assert.Equal(t, "17", got.Level)
+		assert.Empty(t, got.Sectors)
+	})
+
+	t.Run("異常系: TOPIX 取得エラー", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+		topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := NewSectorPerformanceInteractor(nil, nil, topixRepo).GetSectorRotation(context.Background(), to, "33", weeks, window)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,161 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+// signalConfluenceHitRateWindowDays 手法の的中率を測るシグナルの期間（基準日から遡る暦日数）。
+const signalConfluenceHitRateWindowDays = 365
+
+// SignalConfluenceInteractor 手法の的中率で重み付けしたシグナルの合流スコア
+type SignalConfluenceInteractor interface {
+	// GetWeightedSignalStocks 基準日（nil なら最新営業日）までの直近のシグナルを銘柄ごとに合流させ、合流スコアの降順で返す。
+	GetWeightedSignalStocks(ctx context.Context, filter *models.WeightedSignalStockFilter) (*models.WeightedSignalStocks, error)
+}
+
+type signalConfluenceInteractorImpl struct {
+	analyzeRepo repositories.AnalyzeStockBrandPriceHistoryRepository
+	priceRepo   repositories.StockBrandsDailyPriceRepository
+}
+
+// NewSignalConfluenceInteractor コンストラクタ
+func NewSignalConfluenceInteractor(
+	analyzeRepo repositories.AnalyzeStockBrandPriceHistoryRepository,
+	priceRepo repositories.StockBrandsDailyPriceRepository,
+) SignalConfluenceInteractor {
+	return &signalConfluenceInteractorImpl{
+		analyzeRepo: analyzeRepo,
+		priceRepo:   priceRepo,
+	}
+}
+
+func (si *signalConfluenceInteractorImpl) GetWeightedSignalStocks(ctx context.Context, filter *models.WeightedSignalStockFilter) (*models.WeightedSignalStocks, error) {
+	if filter == nil {
+		filter = &models.WeightedSignalStockFilter{}
+	}
+	asOf := time.Now()
+	if filter.Date != nil {
+		asOf = *filter.Date
+	}
+
+	result, err := scoreSignalConfluence(ctx, si.analyzeRepo, si.priceRepo, asOf, domain_service.DefaultSignalConfluenceParams())
+	if err != nil {
+		return nil, errors.Wrap(err, "signalConfluenceInteractorImpl.GetWeightedSignalStocks")
+	}
+	if filter.Limit > 0 && len(result.Stocks) > filter.Limit {
+		result.Stocks = result.Stocks[:filter.Limit]
+	}
+	return result, nil
+}
+
+// scoreSignalConfluence asOf 以前の直近 params.LookbackDays 営業日のシグナルを合流させる（買い推奨のスコアリングからも使う）。
+// 手法の的中率は asOf から signalConfluenceHitRateWindowDays 遡ったシグナルを asOf までの日足だけで評価して求めるため、
+// 過去日を基準にしても先の日足は見ない（horizon が未到来のシグナルは的中率に数えない）。
+func scoreSignalConfluence(
+	ctx context.Context,
+	analyzeRepo repositories.AnalyzeStockBrandPriceHistoryRepository,
+	priceRepo repositories.StockBrandsDailyPriceRepository,
+	asOf time.Time,
+	params domain_service.SignalConfluenceParams,
+) (*models.WeightedSignalStocks, error) {
+	result := &models.WeightedSignalStocks{
+		LookbackDays: params.LookbackDays,
+		HalfLifeDays: params.HalfLifeDays,
+		Horizon:      params.Horizon,
+		Stocks:       []*models.WeightedSignalStock{},
+	}
+
+	dates, err := priceRepo.ListRecentTradingDates(ctx, asOf, params.LookbackDays)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListRecentTradingDates error")
+	}
+	if len(dates) == 0 {
+		return result, nil
+	}
+	result.Date = dates[0]
+
+	history, err := analyzeRepo.FindByCreatedAtRange(ctx, &models.SignalPerformanceFilter{
+		From: dates[0].AddDate(0, 0, -signalConfluenceHitRateWindowDays),
+		To:   dates[0],
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByCreatedAtRange error")
+	}
+	if len(history) == 0 {
+		return result, nil
+	}
+
+	// 合流の対象は直近 LookbackDays 営業日のシグナル（的中率の評価期間に含まれる）
+	oldest := dates[len(dates)-1]
+	recent := make([]*models.AnalyzeStockBrandPriceHistory, 0)
+	for _, h := range history {
+		if !h.CreatedAt.Before(oldest) {
+			recent = append(recent, h)
+		}
+	}
+	if len(recent) == 0 {
+		return result, nil
+	}
+
+	hitRates, err := signalMethodHitRatesAsOf(ctx, priceRepo, history, dates[0], params)
+	if err != nil {
+		return nil, err
+	}
+	result.Stocks = domain_service.ScoreSignalConfluence(recent, dates, hitRates, params)
+	return result, nil
+}
+
+// signalMethodHitRatesAsOf history の各シグナルを asOf までの日足で評価し、手法別の的中率を返す。
+func signalMethodHitRatesAsOf(
+	ctx context.Context,
+	priceRepo repositories.StockBrandsDailyPriceRepository,
+	history []*models.AnalyzeStockBrandPriceHistory,
+	asOf time.Time,
+	params domain_service.SignalConfluenceParams,
+) (map[string]domain_service.SignalMethodHitRate, error) {
+	symbolSet := make(map[string]struct{})
+	symbols := make([]string, 0)
+	from := history[0].CreatedAt
+	for _, h := range history {
+		if _, ok := symbolSet[h.TickerSymbol]; !ok {
+			symbolSet[h.TickerSymbol] = struct{}{}
+			symbols = append(symbols, h.TickerSymbol)
+		}
+		if h.CreatedAt.Before(from) {
+			from = h.CreatedAt
+		}
+	}
+
+	prices, err := priceRepo.ListRangePricesBySymbols(ctx, models.ListRangePricesBySymbolsFilter{
+		Symbols:  symbols,
+		DateFrom: &from,
+		DateTo:   &asOf,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListRangePricesBySymbols error")
+	}
+	pricesBySymbol := make(map[string][]*models.StockBrandDailyPrice, len(symbols))
+	for _, p := range prices {
+		pricesBySymbol[p.TickerSymbol] = append(pricesBySymbol[p.TickerSymbol], p)
+	}
+
+	horizons := []int{params.Horizon}
+	evaluated := make([]*models.EvaluatedSignal, 0, len(history))
+	for _, h := range history {
+		ev := &models.EvaluatedSignal{Method: h.Method, Action: h.Action, Date: h.CreatedAt}
+		if returns, found := domain_service.ForwardReturns(filterPricesFrom(pricesBySymbol[h.TickerSymbol], h.CreatedAt), h.Action, horizons); found {
+			ev.Returns = returns
+		}
+		evaluated = append(evaluated, ev)
+	}
+
+	return domain_service.SignalMethodHitRates(domain_service.AggregateSignalPerformance(evaluated, horizons), params.Horizon, params.PriorCount), nil
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestSignalConfluenceInteractorImpl_GetWeightedSignalStocks(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, time.UTC) }
+	asOf := day(10)
+	tradingDates := []time.Time{day(10), day(9), day(8), day(7), day(6)}
+	buy := models.AnalyzeStockBrandPriceHistoryActionBuy
+
+	// 手法 a は 6/1 のシグナルが5営業日後に上昇（的中）、手法 b は下落（外れ）
+	bars := func(symbol string, closes ...int64) []*models.StockBrandDailyPrice {
+		out := make([]*models.StockBrandDailyPrice, 0, len(closes))
+		for i, c := range closes {
+			out = append(out, &models.StockBrandDailyPrice{TickerSymbol: symbol, Date: time.Date(2026, 6, 1+i, 0, 0, 0, 0, time.UTC), Adjclose: decimal.NewFromInt(c)})
+		}
+		return out
+	}
+	history := []*models.AnalyzeStockBrandPriceHistory{
+		{TickerSymbol: "1000", Method: "a", Action: buy, CreatedAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{TickerSymbol: "2000", Method: "b", Action: buy, CreatedAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{TickerSymbol: "1000", Name: "銘柄1000", Method: "a", Action: buy, CreatedAt: day(10)},
+		{TickerSymbol: "2000", Name: "銘柄2000", Method: "b", Action: buy, CreatedAt: day(10)},
+	}
+
+	tests := []struct {
+		name    string
+		filter  *models.WeightedSignalStockFilter
+		setup   func(analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository, priceRepo *mock_repositories.MockStockBrandsDailyPriceRepository)
+		check   func(t *testing.T, got *models.WeightedSignalStocks)
+		wantErr string
+	}{
+		{
+			name:   "正常系: 基準日までの日足で測った的中率で重み付けし、上位 limit 件を返す",
+			filter: &models.WeightedSignalStockFilter{Date: &asOf, Limit: 1},
+			setup: func(analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository, priceRepo *mock_repositories.MockStockBrandsDailyPriceRepository) {
+				priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), asOf, 5).Return(tradingDates, nil)
+				analyzeRepo.EXPECT().FindByCreatedAtRange(gomock.Any(), &models.SignalPerformanceFilter{
+					From: asOf.AddDate(0, 0, -signalConfluenceHitRateWindowDays),
+					To:   asOf,
+				}).Return(history, nil)
+				priceRepo.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, filter models.ListRangePricesBySymbolsFilter) ([]*models.StockBrandDailyPrice, error) {
+						assert.ElementsMatch(t, []string{"1000", "2000"}, filter.Symbols)
+						assert.Equal(t, asOf, *filter.DateTo, "基準日より先の日足は見ない")
+						return append(bars("1000", 100, 101, 102, 103, 104, 110), bars("2000", 100, 99, 98, 97, 96, 90)...), nil
+					})
+			},
+			check: func(t *testing.T, got *models.WeightedSignalStocks) {
+				assert.Equal(t, asOf, got.Date)
+				assert.Equal(t, 5, got.LookbackDays)
+				assert.Equal(t, 5, got.Horizon)
+				require.Len(t, got.Stocks, 1)
+				assert.Equal(t, "1000", got.Stocks[0].TickerSymbol)
+				assert.Equal(t, "銘柄1000", got.Stocks[0].Name)
+				require.Len(t, got.Stocks[0].Contributions, 1)
+				assert.Equal(t, 1, got.Stocks[0].Contributions[0].EvaluatedCount)
+				assert.Equal(t, "0.5238", got.Stocks[0].Contributions[0].HitRate.String(), "(1 + 0.5*20) / (1 + 20)")
+				assert.Equal(t, "0.0238", got.Stocks[0].Contributions[0].Contribution.String(), "コイン投げを上回る分だけ寄与する")
+			},
+		},
+		{
+			name: "正常系: 日足が無ければ空",
+			setup: func(_ *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository, priceRepo *mock_repositories.MockStockBrandsDailyPriceRepository) {
+				priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), gomock.Any(), 5).Return(nil, nil)
+			},
+			check: func(t *testing.T, got *models.WeightedSignalStocks) {
+				assert.Empty(t, got.Stocks)
+				assert.NotNil(t, got.Stocks)
+			},
+		},
+		{
+			name:   "正常系: 直近のシグナルが無ければ的中率を測らない",
+			filter: &models.WeightedSignalStockFilter{Date: &asOf},
+			setup: func(analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository, priceRepo *mock_repositories.MockStockBrandsDailyPriceRepository) {
+				priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), asOf, 5).Return(tradingDates, nil)
+				analyzeRepo.EXPECT().FindByCreatedAtRange(gomock.Any(), gomock.Any()).Return(history[:2], nil)
+			},
+			check: func(t *testing.T, got *models.WeightedSignalStocks) {
+				assert.Empty(t, got.Stocks)
+			},
+		},
+		{
+			name:   "異常系: シグナル取得エラー",
+			filter: &models.WeightedSignalStockFilter{Date: &asOf},
+			setup: func(analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository, priceRepo *mock_repositories.MockStockBrandsDailyPriceRepository) {
+				priceRepo.EXPECT().ListRecentTradingDates(gomock.Any(), asOf, 5).Return(tradingDates, nil)
+				analyzeRepo.EXPECT().FindByCreatedAtRange(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr: "signalConfluenceInteractorImpl.GetWeightedSignalStocks: FindByCreatedAtRange error: db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			analyzeRepo := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+			priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+			tt.setup(analyzeRepo, priceRepo)
+
+			got, err := NewSignalConfluenceInteractor(analyzeRepo, priceRepo).GetWeightedSignalStocks(context.Background(), tt.filter)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, got)
+		})
+	}
+}
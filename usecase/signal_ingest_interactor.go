@@ -0,0 +1,101 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+	"github.com/Code0716/stock-price-repository/util"
+)
+
+// SignalIngestInteractor 外部のプロデューサー（ML コンテナ等）が出したシグナルを analyze_stock_brand_price_history に取り込む
+type SignalIngestInteractor interface {
+	// IngestSignals シグナルを検証し、銘柄コードから銘柄IDを引いて (method, tickerSymbol, date, action) 単位で upsert する。
+	// 内容の誤りや未登録の銘柄コードがあれば *models.SignalIngestError を返し、1件も書き込まない。
+	IngestSignals(ctx context.Context, inputs []*models.SignalIngestInput) (*models.SignalIngestResult, error)
+}
+
+type signalIngestInteractorImpl struct {
+	tx             repositories.Transaction
+	stockBrandRepo repositories.StockBrandRepository
+	analyzeRepo    repositories.AnalyzeStockBrandPriceHistoryRepository
+}
+
+// NewSignalIngestInteractor コンストラクタ
+func NewSignalIngestInteractor(
+	tx repositories.Transaction,
+	stockBrandRepo repositories.StockBrandRepository,
+	analyzeRepo repositories.AnalyzeStockBrandPriceHistoryRepository,
+) SignalIngestInteractor {
+	return &signalIngestInteractorImpl{
+		tx:             tx,
+		stockBrandRepo: stockBrandRepo,
+		analyzeRepo:    analyzeRepo,
+	}
+}
+
+func (si *signalIngestInteractorImpl) IngestSignals(ctx context.Context, inputs []*models.SignalIngestInput) (*models.SignalIngestResult, error) {
+	if err := domain_service.ValidateSignalIngestInputs(inputs); err != nil {
+		return nil, err
+	}
+
+	symbolSet := make(map[string]struct{}, len(inputs))
+	symbols := make([]string, 0, len(inputs))
+	for _, in := range inputs {
+		if _, ok := symbolSet[in.TickerSymbol]; !ok {
+			symbolSet[in.TickerSymbol] = struct{}{}
+			symbols = append(symbols, in.TickerSymbol)
+		}
+	}
+	brands, err := si.stockBrandRepo.FindByTickerSymbols(ctx, symbols)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindByTickerSymbols error")
+	}
+	brandIDs := make(map[string]string, len(brands))
+	for _, b := range brands {
+		brandIDs[b.TickerSymbol] = b.ID
+	}
+
+	histories := make([]*models.AnalyzeStockBrandPriceHistory, 0, len(inputs))
+	for i, in := range inputs {
+		brandID, ok := brandIDs[in.TickerSymbol]
+		if !ok {
+			return nil, &models.SignalIngestError{Index: i, Message: fmt.Sprintf("銘柄コード %s は登録されていません", in.TickerSymbol)}
+		}
+		h := models.NewAnalyzeStockBrandPriceHistory(
+			util.GenerateUUID(),
+			brandID,
+			"",
+			in.TickerSymbol,
+			in.TradePrice,
+			in.TradePrice,
+			in.Action,
+			in.Method,
+			in.Memo,
+			in.Date,
+		)
+		h.Score = in.Score
+		h.SignalRank = in.SignalRank
+		histories = append(histories, h)
+	}
+
+	var updated int
+	if err := si.tx.DoInTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = si.analyzeRepo.UpsertByKey(ctx, histories)
+		return err
+	}); err != nil {
+		return nil, errors.Wrap(err, "UpsertByKey error")
+	}
+
+	return &models.SignalIngestResult{
+		Received: len(inputs),
+		Inserted: len(inputs) - updated,
+		Updated:  updated,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+func TestSignalIngestInteractorImpl_IngestSignals(t *testing.T) {
+	date := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+	score := decimal.RequireFromString("0.87")
+	rank := 1
+	newInputs := func() []*models.SignalIngestInput {
+		return []*models.SignalIngestInput{
+			{
+				TickerSymbol: "7203", Date: date, Method: models.AnalyzeStockBrandPriceHistoryMethodFindMLRankedV1,
+				Action: models.AnalyzeStockBrandPriceHistoryActionBuy, TradePrice: decimal.NewFromInt(2500), Score: &score, SignalRank: &rank,
+			},
+			{
+				TickerSymbol: "6758", Date: date, Method: models.AnalyzeStockBrandPriceHistoryMethodFindMLRankedV1,
+				Action: models.AnalyzeStockBrandPriceHistoryActionBuy, TradePrice: decimal.NewFromInt(13000),
+			},
+		}
+	}
+	brands := []*models.StockBrand{
+		{ID: "brand-7203", TickerSymbol: "7203"},
+		{ID: "brand-6758", TickerSymbol: "6758"},
+	}
+
+	tests := []struct {
+		name    string
+		inputs  []*models.SignalIngestInput
+		setup   func(brandRepo *mock_repositories.MockStockBrandRepository, analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository, tx *mock_repositories.MockTransaction)
+		want    *models.SignalIngestResult
+		wantErr string
+	}{
+		{
+			name:   "正常系: 銘柄IDを引いて upsert し、新規と更新の件数を返す",
+			inputs: newInputs(),
+			setup: func(brandRepo *mock_repositories.MockStockBrandRepository, analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository, tx *mock_repositories.MockTransaction) {
+				brandRepo.EXPECT().FindByTickerSymbols(gomock.Any(), []string{"7203", "6758"}).Return(brands, nil)
+				tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				analyzeRepo.EXPECT().UpsertByKey(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, histories []*models.AnalyzeStockBrandPriceHistory) (int, error) {
+						assert.Len(t, histories, 2)
+						assert.Equal(t, "brand-7203", histories[0].StockBrandID)
+						assert.Equal(t, "7203", histories[0].TickerSymbol)
+						assert.Equal(t, date, histories[0].CreatedAt)
+						assert.Equal(t, &score, histories[0].Score)
+						assert.Equal(t, &rank, histories[0].SignalRank)
+						assert.Equal(t, "brand-6758", histories[1].StockBrandID)
+						assert.Nil(t, histories[1].Score)
+						return 1, nil
+					})
+			},
+			want: &models.SignalIngestResult{Received: 2, Inserted: 1, Updated: 1},
+		},
+		{
+			name: "異常系: 検証エラーはリポジトリを呼ばずに返す",
+			inputs: []*models.SignalIngestInput{
+				{TickerSymbol: "7203", Date: date, Method: "unknown_method", Action: models.AnalyzeStockBrandPriceHistoryActionBuy, TradePrice: decimal.NewFromInt(1)},
+			},
+			wantErr: `histories[0]: method "unknown_method" は登録されていません`,
+		},
+		{
+			name:   "異常系: 未登録の銘柄コード",
+			inputs: newInputs(),
+			setup: func(brandRepo *mock_repositories.MockStockBrandRepository, _ *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository, _ *mock_repositories.MockTransaction) {
+				brandRepo.EXPECT().FindByTickerSymbols(gomock.Any(), gomock.Any()).Return(brands[:1], nil)
+			},
+			wantErr: "histories[1]: 銘柄コード 6758 は登録されていません",
+		},
+		{
+			name:   "異常系: upsert エラー",
+			inputs: newInputs(),
+			setup: func(brandRepo *mock_repositories.MockStockBrandRepository, analyzeRepo *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository, tx *mock_repositories.MockTransaction) {
+				brandRepo.EXPECT().FindByTickerSymbols(gomock.Any(), gomock.Any()).Return(brands, nil)
+				tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				analyzeRepo.EXPECT().UpsertByKey(gomock.Any(), gomock.Any()).Return(0, errors.New("db error"))
+			},
+			wantErr: "UpsertByKey error: db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+			analyzeRepo := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+			tx := mock_repositories.NewMockTransaction(ctrl)
+			if tt.setup != nil {
+				tt.setup(brandRepo, analyzeRepo, tx)
+			}
+
+			got, err := NewSignalIngestInteractor(tx, brandRepo, analyzeRepo).IngestSignals(context.Background(), tt.inputs)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package usecase
 
 import (
 	"context"
+	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -19,8 +21,11 @@ const (
 var signalPerformanceHorizons = []int{5, 10, 20}
 
 type signalPerformanceInteractorImpl struct {
-	analyzeRepo repositories.AnalyzeStockBrandPriceHistoryRepository
-	priceRepo   repositories.StockBrandsDailyPriceRepository
+	analyzeRepo    repositories.AnalyzeStockBrandPriceHistoryRepository
+	stockBrandRepo repositories.StockBrandRepository
+	priceRepo      repositories.StockBrandsDailyPriceRepository
+	topixRepo      repositories.TopixRepository
+	nikkeiRepo     repositories.NikkeiRepository
 }
 
 // SignalPerformanceInteractor シグナル精度評価
@@ -31,16 +36,33 @@ type SignalPerformanceInteractor interface {
 // NewSignalPerformanceInteractor コンストラクタ
 func NewSignalPerformanceInteractor(
 	analyzeRepo repositories.AnalyzeStockBrandPriceHistoryRepository,
+	stockBrandRepo repositories.StockBrandRepository,
 	priceRepo repositories.StockBrandsDailyPriceRepository,
+	topixRepo repositories.TopixRepository,
+	nikkeiRepo repositories.NikkeiRepository,
 ) SignalPerformanceInteractor {
 	return &signalPerformanceInteractorImpl{
-		analyzeRepo: analyzeRepo,
-		priceRepo:   priceRepo,
+		analyzeRepo:    analyzeRepo,
+		stockBrandRepo: stockBrandRepo,
+		priceRepo:      priceRepo,
+		topixRepo:      topixRepo,
+		nikkeiRepo:     nikkeiRepo,
 	}
 }
 
-// GetSignalPerformance 期間内のシグナルを評価し手法別サマリ（+ method 指定時は明細）を返す。
+// GetSignalPerformance 期間内のシグナルを評価し手法別サマリ（+ method 指定時は明細とイベントスタディ）を返す。
+// サマリには horizon ごとにベンチマークに対する超過リターン、平均リターンのブートストラップ信頼区間と p 値、
+// 同じ日に主要市場の全銘柄から無作為に選んだ場合との比較を、手法間で BH 補正した q 値とともに付ける。
 func (s *signalPerformanceInteractorImpl) GetSignalPerformance(ctx context.Context, filter *models.SignalPerformanceFilter) (*models.SignalPerformance, error) {
+	benchmark := filter.Benchmark
+	if benchmark == "" {
+		benchmark = models.EventStudyBenchmarkTopix
+	}
+	seed := filter.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
 	signals, err := s.analyzeRepo.FindByCreatedAtRange(ctx, filter)
 	if err != nil {
 		return nil, errors.Wrap(err, "signalPerformanceInteractorImpl.GetSignalPerformance: FindByCreatedAtRange")
@@ -48,14 +70,92 @@ func (s *signalPerformanceInteractorImpl) GetSignalPerformance(ctx context.Conte
 
 	if len(signals) == 0 {
 		return &models.SignalPerformance{
-			From:      filter.From,
-			To:        filter.To,
-			Horizons:  signalPerformanceHorizons,
-			Summaries: []*models.SignalPerformanceSummary{},
-			Signals:   []*models.EvaluatedSignal{},
+			From:       filter.From,
+			To:         filter.To,
+			Horizons:   signalPerformanceHorizons,
+			Benchmark:  benchmark,
+			Iterations: domain_service.SignalTestIterations,
+			Seed:       seed,
+			Summaries:  []*models.SignalPerformanceSummary{},
+			Signals:    []*models.EvaluatedSignal{},
 		}, nil
 	}
 
+	evaluated, pricesBySymbol, err := evaluateSignals(ctx, s.priceRepo, signals, filter.From, filter.To)
+	if err != nil {
+		return nil, errors.Wrap(err, "signalPerformanceInteractorImpl.GetSignalPerformance: evaluateSignals")
+	}
+
+	listBenchmark := s.topixRepo.ListTopixDailyPrices
+	if benchmark == models.EventStudyBenchmarkNikkei {
+		listBenchmark = s.nikkeiRepo.ListNikkeiStockAverageDailyPrices
+	}
+	benchmarkTo := filter.To.AddDate(0, 0, signalPerformancePriceLookAhead)
+	benchmarkPrices, err := listBenchmark(ctx, &filter.From, &benchmarkTo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signalPerformanceInteractorImpl.GetSignalPerformance: list %s daily prices", benchmark)
+	}
+	benchmarkClose := domain_service.BenchmarkCloseByDate(benchmarkPrices)
+	for _, ev := range evaluated {
+		if ev.Returns != nil {
+			ev.ExcessReturns = domain_service.SignalExcessReturns(filterPricesFrom(pricesBySymbol[ev.TickerSymbol], ev.Date), ev.Action, ev.Returns, benchmarkClose)
+		}
+	}
+
+	universePrices, err := s.randomEntryUniversePrices(ctx, pricesBySymbol, filter.From, filter.To)
+	if err != nil {
+		return nil, errors.Wrap(err, "signalPerformanceInteractorImpl.GetSignalPerformance: randomEntryUniversePrices")
+	}
+
+	summaries := domain_service.AggregateSignalPerformance(evaluated, signalPerformanceHorizons)
+	domain_service.AttachSignalTests(summaries, evaluated, signalRandomEntryPools(evaluated, universePrices), signalPerformanceHorizons,
+		domain_service.SignalTestIterations, rand.New(rand.NewSource(seed)))
+
+	// method 指定時のみ明細と帯別集計を返す
+	var detail []*models.EvaluatedSignal
+	var rankBands []*models.BandSummary
+	var scoreQuartiles []*models.BandSummary
+	var eventStudy *models.EventStudyResult
+
+	if filter.Method != "" {
+		detail = evaluated
+		rankBands = domain_service.AggregateByRankBand(evaluated, signalPerformanceHorizons)
+		scoreQuartiles = domain_service.AggregateByScoreQuartile(evaluated, signalPerformanceHorizons)
+		// 地合いの影響を除いた効果を見るため、シグナル日をイベントとして TOPIX に対する異常リターンも出す
+		events, truncated := normalizeEvents(signalEvents(signals))
+		eventStudy, err = runEventStudy(ctx, s.priceRepo, s.topixRepo.ListTopixDailyPrices, models.EventStudyBenchmarkTopix,
+			events, domain_service.DefaultEventStudyParams())
+		if err != nil {
+			return nil, errors.Wrap(err, "signalPerformanceInteractorImpl.GetSignalPerformance: runEventStudy")
+		}
+		eventStudy.Truncated = truncated
+	} else {
+		detail = []*models.EvaluatedSignal{}
+	}
+
+	return &models.SignalPerformance{
+		From:           filter.From,
+		To:             filter.To,
+		Horizons:       signalPerformanceHorizons,
+		Benchmark:      benchmark,
+		Iterations:     domain_service.SignalTestIterations,
+		Seed:           seed,
+		Summaries:      summaries,
+		Signals:        detail,
+		RankBands:      rankBands,
+		ScoreQuartiles: scoreQuartiles,
+		EventStudy:     eventStudy,
+	}, nil
+}
+
+// evaluateSignals from〜to のシグナルに、シグナル日の終値（調整後）を基準とした signalPerformanceHorizons 営業日後リターンを付ける。
+// シグナル日の日足が無いシグナルは Returns=nil のまま返す。取得した日足も銘柄 → 日付昇順で返す。
+func evaluateSignals(
+	ctx context.Context,
+	priceRepo repositories.StockBrandsDailyPriceRepository,
+	signals []*models.AnalyzeStockBrandPriceHistory,
+	from, to time.Time,
+) ([]*models.EvaluatedSignal, map[string][]*models.StockBrandDailyPrice, error) {
 	// distinct symbols
 	symbolSet := make(map[string]struct{}, len(signals))
 	for _, sg := range signals {
@@ -67,14 +167,14 @@ func (s *signalPerformanceInteractorImpl) GetSignalPerformance(ctx context.Conte
 	}
 
 	// シグナル日から最大 horizon 分先まで価格を一括取得（N+1回避）
-	priceTo := filter.To.AddDate(0, 0, signalPerformancePriceLookAhead)
-	prices, err := s.priceRepo.ListRangePricesBySymbols(ctx, models.ListRangePricesBySymbolsFilter{
+	priceTo := to.AddDate(0, 0, signalPerformancePriceLookAhead)
+	prices, err := priceRepo.ListRangePricesBySymbols(ctx, models.ListRangePricesBySymbolsFilter{
 		Symbols:  symbols,
-		DateFrom: &filter.From,
+		DateFrom: &from,
 		DateTo:   &priceTo,
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "signalPerformanceInteractorImpl.GetSignalPerformance: ListRangePricesBySymbols")
+		return nil, nil, errors.Wrap(err, "ListRangePricesBySymbols error")
 	}
 
 	// symbol → 日付昇順スライスのマップ
@@ -87,8 +187,7 @@ func (s *signalPerformanceInteractorImpl) GetSignalPerformance(ctx context.Conte
 	for _, sg := range signals {
 		symPrices := pricesBySymbol[sg.TickerSymbol]
 		// シグナル日以降の価格だけを渡す（シグナル日当日 = prices[0] = P0）
-		from := sg.CreatedAt
-		afterSignal := filterPricesFrom(symPrices, from)
+		afterSignal := filterPricesFrom(symPrices, sg.CreatedAt)
 
 		ev := &models.EvaluatedSignal{
 			TickerSymbol: sg.TickerSymbol,
@@ -111,41 +210,74 @@ func (s *signalPerformanceInteractorImpl) GetSignalPerformance(ctx context.Conte
 		}
 		evaluated = append(evaluated, ev)
 	}
+	return evaluated, pricesBySymbol, nil
+}
 
-	summaries := domain_service.AggregateSignalPerformance(evaluated, signalPerformanceHorizons)
+// randomEntryUniversePrices ランダムエントリーの母集団（主要市場の全銘柄）の日足を返す。
+// シグナル銘柄は評価で取得済みの signalPrices を使い、それ以外の銘柄だけをチャンクに分けて取得する。
+func (s *signalPerformanceInteractorImpl) randomEntryUniversePrices(
+	ctx context.Context,
+	signalPrices map[string][]*models.StockBrandDailyPrice,
+	from, to time.Time,
+) (map[string][]*models.StockBrandDailyPrice, error) {
+	brands, err := s.stockBrandRepo.FindAllMainMarkets(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindAllMainMarkets error")
+	}
 
-	// method 指定時のみ明細と帯別集計を返す
-	var detail []*models.EvaluatedSignal
-	var rankBands []*models.BandSummary
-	var scoreQuartiles []*models.BandSummary
+	universe := make(map[string][]*models.StockBrandDailyPrice, len(brands))
+	missing := make([]string, 0, len(brands))
+	for _, b := range brands {
+		if prices, ok := signalPrices[b.TickerSymbol]; ok {
+			universe[b.TickerSymbol] = prices
+			continue
+		}
+		missing = append(missing, b.TickerSymbol)
+	}
 
-	if filter.Method != "" {
-		detail = evaluated
-		rankBands = domain_service.AggregateByRankBand(evaluated, signalPerformanceHorizons)
-		scoreQuartiles = domain_service.AggregateByScoreQuartile(evaluated, signalPerformanceHorizons)
-	} else {
-		detail = []*models.EvaluatedSignal{}
+	fetched, err := listPricesBySymbols(ctx, s.priceRepo, missing, from, to.AddDate(0, 0, signalPerformancePriceLookAhead))
+	if err != nil {
+		return nil, err
 	}
+	for sym, prices := range fetched {
+		universe[sym] = prices
+	}
+	return universe, nil
+}
 
-	return &models.SignalPerformance{
-		From:           filter.From,
-		To:             filter.To,
-		Horizons:       signalPerformanceHorizons,
-		Summaries:      summaries,
-		Signals:        detail,
-		RankBands:      rankBands,
-		ScoreQuartiles: scoreQuartiles,
-	}, nil
+// signalRandomEntryPools シグナル日ごとに、母集団の全銘柄をその日に買っていた場合の horizon 別リターンを集める（ランダムエントリーの抽選元）。
+// 同じシードで同じ結果になるよう、銘柄は銘柄コード順に並べる。
+func signalRandomEntryPools(signals []*models.EvaluatedSignal, pricesBySymbol map[string][]*models.StockBrandDailyPrice) map[string]map[int][]float64 {
+	symbols := make([]string, 0, len(pricesBySymbol))
+	for sym := range pricesBySymbol {
+		symbols = append(symbols, sym)
+	}
+	sort.Strings(symbols)
+
+	pools := make(map[string]map[int][]float64)
+	for _, sg := range signals {
+		key := domain_service.SignalPoolKey(sg)
+		if _, ok := pools[key]; ok {
+			continue
+		}
+		afters := make([][]*models.StockBrandDailyPrice, 0, len(symbols))
+		for _, sym := range symbols {
+			afters = append(afters, filterPricesFrom(pricesBySymbol[sym], sg.Date))
+		}
+		pools[key] = domain_service.SignalRandomEntryPool(afters, signalPerformanceHorizons)
+	}
+	return pools
 }
 
 // filterPricesFrom 昇順の prices から signalDate 以降（当日含む）の行を返す。
+// ランダムエントリーの抽選元づくりで銘柄数×シグナル日数だけ呼ぶため二分探索する。
 func filterPricesFrom(prices []*models.StockBrandDailyPrice, signalDate time.Time) []*models.StockBrandDailyPrice {
 	sigDay := signalDate.Truncate(24 * time.Hour)
-	for i, p := range prices {
-		pDay := p.Date.Truncate(24 * time.Hour)
-		if !pDay.Before(sigDay) {
-			return prices[i:]
-		}
+	i := sort.Search(len(prices), func(i int) bool {
+		return !prices[i].Date.Truncate(24 * time.Hour).Before(sigDay)
+	})
+	if i == len(prices) {
+		return nil
 	}
-	return nil
+	return prices[i:]
 }
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -62,6 +64,10 @@ func TestSignalPerformanceInteractor_GetSignalPerformance(t *testing.T) {
 	type fields struct {
 		analyzeRepo func(ctrl *gomock.Controller) *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository
 		priceRepo   func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandsDailyPriceRepository
+		topixRepo   func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository  // nil なら超過リターン用に空の日足を返す
+		nikkeiRepo  func(ctrl *gomock.Controller) *mock_repositories.MockNikkeiRepository // nil なら呼ばれない
+		// stockBrandRepo ランダムエントリーの母集団。nil なら主要市場の銘柄0件
+		stockBrandRepo func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandRepository
 	}
 
 	tests := []struct {
@@ -151,7 +157,12 @@ func TestSignalPerformanceInteractor_GetSignalPerformance(t *testing.T) {
 					m := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
 					m.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(
 						makePrices("7203", signalDate, 30, 1000), nil,
-					)
+					).Times(2) // シグナル評価用とイベントスタディ用
+					return m
+				},
+				topixRepo: func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository {
+					m := mock_repositories.NewMockTopixRepository(ctrl)
+					m.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2) // 超過リターン用とイベントスタディ用
 					return m
 				},
 			},
@@ -159,6 +170,10 @@ func TestSignalPerformanceInteractor_GetSignalPerformance(t *testing.T) {
 				// method 指定時は Signals に明細が入る
 				assert.NotEmpty(t, got.Signals)
 				assert.Equal(t, method1, got.Signals[0].Method)
+				// シグナル日をイベントとした TOPIX に対するイベントスタディも付く
+				assert.NotNil(t, got.EventStudy)
+				assert.Equal(t, models.EventStudyBenchmarkTopix, got.EventStudy.Benchmark)
+				assert.Equal(t, 1, got.EventStudy.EventCount)
 			},
 		},
 		{
@@ -251,6 +266,33 @@ func TestSignalPerformanceInteractor_GetSignalPerformance(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "異常系: 主要市場の銘柄取得エラー → エラー伝播",
+			filter: &models.SignalPerformanceFilter{
+				From: from,
+				To:   to,
+			},
+			fields: fields{
+				analyzeRepo: func(ctrl *gomock.Controller) *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository {
+					m := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+					m.EXPECT().FindByCreatedAtRange(gomock.Any(), gomock.Any()).Return([]*models.AnalyzeStockBrandPriceHistory{
+						spSignal("7203", method1, models.AnalyzeStockBrandPriceHistoryActionBuy, signalDate),
+					}, nil)
+					return m
+				},
+				priceRepo: func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandsDailyPriceRepository {
+					m := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+					m.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(makePrices("7203", signalDate, 30, 1000), nil)
+					return m
+				},
+				stockBrandRepo: func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandRepository {
+					m := mock_repositories.NewMockStockBrandRepository(ctrl)
+					m.EXPECT().FindAllMainMarkets(gomock.Any()).Return(nil, errors.New("db error"))
+					return m
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "正常系: Sell シグナルのリターン符号反転",
 			filter: &models.SignalPerformanceFilter{
@@ -271,7 +313,12 @@ func TestSignalPerformanceInteractor_GetSignalPerformance(t *testing.T) {
 					// 価格が上昇するリストを返す（Sell の場合はリターン符号が反転されて負になるはず）
 					m.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(
 						makePrices("7203", signalDate, 30, 1000), nil,
-					)
+					).Times(2) // シグナル評価用とイベントスタディ用
+					return m
+				},
+				topixRepo: func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository {
+					m := mock_repositories.NewMockTopixRepository(ctrl)
+					m.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2) // 超過リターン用とイベントスタディ用
 					return m
 				},
 			},
@@ -287,6 +334,113 @@ func TestSignalPerformanceInteractor_GetSignalPerformance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "正常系: 日経平均に対する超過リターンと手法ごとの検定結果が付く / seed が返る",
+			filter: &models.SignalPerformanceFilter{
+				From:      from,
+				To:        to,
+				Benchmark: models.EventStudyBenchmarkNikkei,
+				Seed:      42,
+			},
+			fields: fields{
+				analyzeRepo: func(ctrl *gomock.Controller) *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository {
+					// method1 は値上がりする銘柄だけ、method2 は横ばいの銘柄だけに出る
+					var signals []*models.AnalyzeStockBrandPriceHistory
+					for i := 0; i < 5; i++ {
+						signals = append(signals,
+							spSignal(fmt.Sprintf("1%03d", i), method1, models.AnalyzeStockBrandPriceHistoryActionBuy, signalDate),
+							spSignal(fmt.Sprintf("2%03d", i), method2, models.AnalyzeStockBrandPriceHistoryActionBuy, signalDate))
+					}
+					m := mock_repositories.NewMockAnalyzeStockBrandPriceHistoryRepository(ctrl)
+					m.EXPECT().FindByCreatedAtRange(gomock.Any(), gomock.Any()).Return(signals, nil)
+					return m
+				},
+				priceRepo: func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandsDailyPriceRepository {
+					var prices []*models.StockBrandDailyPrice
+					for i := 0; i < 5; i++ {
+						prices = append(prices, makePrices(fmt.Sprintf("1%03d", i), signalDate, 30, 100+float64(i))...)
+						for d := 0; d < 30; d++ {
+							prices = append(prices, spPrice(fmt.Sprintf("2%03d", i), signalDate.AddDate(0, 0, d), 1000))
+							prices = append(prices, spPrice(fmt.Sprintf("3%03d", i), signalDate.AddDate(0, 0, d), 1000))
+						}
+					}
+					bySymbol := func(_ context.Context, f models.ListRangePricesBySymbolsFilter) ([]*models.StockBrandDailyPrice, error) {
+						var out []*models.StockBrandDailyPrice
+						for _, p := range prices {
+							if slices.Contains(f.Symbols, p.TickerSymbol) {
+								out = append(out, p)
+							}
+						}
+						return out, nil
+					}
+					m := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+					gomock.InOrder(
+						m.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).DoAndReturn(bySymbol),
+						// 母集団のうちシグナルの出ていない銘柄だけを追加で取得する
+						m.EXPECT().ListRangePricesBySymbols(gomock.Any(), models.ListRangePricesBySymbolsFilter{
+							Symbols:  []string{"3000", "3001", "3002", "3003", "3004"},
+							DateFrom: &from,
+							DateTo:   &wantPriceTo,
+						}).DoAndReturn(bySymbol),
+					)
+					return m
+				},
+				stockBrandRepo: func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandRepository {
+					// 主要市場はシグナル銘柄10件と、シグナルの出ていない横ばいの5件
+					var brands []*models.StockBrand
+					for _, prefix := range []string{"1", "2", "3"} {
+						for i := 0; i < 5; i++ {
+							brands = append(brands, &models.StockBrand{TickerSymbol: fmt.Sprintf("%s%03d", prefix, i)})
+						}
+					}
+					m := mock_repositories.NewMockStockBrandRepository(ctrl)
+					m.EXPECT().FindAllMainMarkets(gomock.Any()).Return(brands, nil)
+					return m
+				},
+				topixRepo: func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository {
+					return mock_repositories.NewMockTopixRepository(ctrl)
+				},
+				nikkeiRepo: func(ctrl *gomock.Controller) *mock_repositories.MockNikkeiRepository {
+					// 日経平均は 1000 から毎日 +1
+					var bench models.IndexStockAverageDailyPrices
+					for d := 0; d < 30; d++ {
+						bench = append(bench, &models.IndexStockAverageDailyPrice{Date: signalDate.AddDate(0, 0, d), Close: decimal.NewFromInt(int64(1000 + d))})
+					}
+					m := mock_repositories.NewMockNikkeiRepository(ctrl)
+					m.EXPECT().ListNikkeiStockAverageDailyPrices(gomock.Any(), &from, &wantPriceTo).Return(bench, nil)
+					return m
+				},
+			},
+			check: func(t *testing.T, got *models.SignalPerformance) {
+				assert.Equal(t, models.EventStudyBenchmarkNikkei, got.Benchmark)
+				assert.Equal(t, int64(42), got.Seed)
+				if !assert.Len(t, got.Summaries, 2) {
+					return
+				}
+				up, flat := got.Summaries[0], got.Summaries[1]
+				if up.Method != method1 {
+					up, flat = flat, up
+				}
+
+				// 横ばいの銘柄は日経平均の上昇ぶん（5営業日で +0.5%）だけ負けている
+				ft := flat.Tests[5]
+				assert.Equal(t, 5, ft.ExcessCount)
+				assert.Equal(t, "-0.005", ft.AvgExcessReturn.String())
+				assert.Equal(t, "0", ft.ExcessWinRate.String())
+				assert.Equal(t, "0", ft.CILow.String())
+				assert.False(t, ft.Significant)
+				assert.False(t, ft.BeatsBaseline)
+
+				// 値上がりする銘柄は同じ日の主要市場（15銘柄）からランダムに選ぶより良い
+				ut := up.Tests[5]
+				assert.True(t, ut.AvgExcessReturn.IsPositive())
+				assert.True(t, ut.CILow.IsPositive())
+				assert.Equal(t, 5, ut.BaselineCount)
+				assert.True(t, ut.BaselineAvgReturn.LessThan(up.Stats[5].AvgReturn))
+				assert.NotNil(t, ut.QValue)
+				assert.True(t, ut.BeatsBaseline)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -294,9 +448,28 @@ func TestSignalPerformanceInteractor_GetSignalPerformance(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+			if tt.fields.topixRepo != nil {
+				topixRepo = tt.fields.topixRepo(ctrl)
+			} else {
+				topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			}
+			nikkeiRepo := mock_repositories.NewMockNikkeiRepository(ctrl)
+			if tt.fields.nikkeiRepo != nil {
+				nikkeiRepo = tt.fields.nikkeiRepo(ctrl)
+			}
+			stockBrandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+			if tt.fields.stockBrandRepo != nil {
+				stockBrandRepo = tt.fields.stockBrandRepo(ctrl)
+			} else {
+				stockBrandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(nil, nil).AnyTimes()
+			}
 			interactor := NewSignalPerformanceInteractor(
 				tt.fields.analyzeRepo(ctrl),
+				stockBrandRepo,
 				tt.fields.priceRepo(ctrl),
+				topixRepo,
+				nikkeiRepo,
 			)
 			got, err := interactor.GetSignalPerformance(context.Background(), tt.filter)
 
@@ -400,6 +573,10 @@ func TestSignalPerformanceInteractor_BandAggregation(t *testing.T) {
 	type fields struct {
 		analyzeRepo func(ctrl *gomock.Controller) *mock_repositories.MockAnalyzeStockBrandPriceHistoryRepository
 		priceRepo   func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandsDailyPriceRepository
+		topixRepo   func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository  // nil なら超過リターン用に空の日足を返す
+		nikkeiRepo  func(ctrl *gomock.Controller) *mock_repositories.MockNikkeiRepository // nil なら呼ばれない
+		// stockBrandRepo ランダムエントリーの母集団。nil なら主要市場の銘柄0件
+		stockBrandRepo func(ctrl *gomock.Controller) *mock_repositories.MockStockBrandRepository
 	}
 
 	tests := []struct {
@@ -428,7 +605,12 @@ func TestSignalPerformanceInteractor_BandAggregation(t *testing.T) {
 					m := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
 					m.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(
 						append(makePrices("7203"), makePrices("6758")...), nil,
-					)
+					).Times(2) // シグナル評価用とイベントスタディ用
+					return m
+				},
+				topixRepo: func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository {
+					m := mock_repositories.NewMockTopixRepository(ctrl)
+					m.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2) // 超過リターン用とイベントスタディ用
 					return m
 				},
 			},
@@ -475,6 +657,7 @@ func TestSignalPerformanceInteractor_BandAggregation(t *testing.T) {
 				// method 未指定時は rankBands/scoreQuartiles は nil
 				assert.Nil(t, got.RankBands)
 				assert.Nil(t, got.ScoreQuartiles)
+				assert.Nil(t, got.EventStudy)
 			},
 		},
 		{
@@ -505,7 +688,12 @@ func TestSignalPerformanceInteractor_BandAggregation(t *testing.T) {
 					for _, sym := range []string{"7203", "6758", "9984", "3382"} {
 						prices = append(prices, makePrices(sym)...)
 					}
-					m.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(prices, nil)
+					m.EXPECT().ListRangePricesBySymbols(gomock.Any(), gomock.Any()).Return(prices, nil).Times(2) // シグナル評価用とイベントスタディ用
+					return m
+				},
+				topixRepo: func(ctrl *gomock.Controller) *mock_repositories.MockTopixRepository {
+					m := mock_repositories.NewMockTopixRepository(ctrl)
+					m.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2) // 超過リターン用とイベントスタディ用
 					return m
 				},
 			},
@@ -525,9 +713,28 @@ func TestSignalPerformanceInteractor_BandAggregation(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			topixRepo := mock_repositories.NewMockTopixRepository(ctrl)
+			if tt.fields.topixRepo != nil {
+				topixRepo = tt.fields.topixRepo(ctrl)
+			} else {
+				topixRepo.EXPECT().ListTopixDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			}
+			nikkeiRepo := mock_repositories.NewMockNikkeiRepository(ctrl)
+			if tt.fields.nikkeiRepo != nil {
+				nikkeiRepo = tt.fields.nikkeiRepo(ctrl)
+			}
+			stockBrandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+			if tt.fields.stockBrandRepo != nil {
+				stockBrandRepo = tt.fields.stockBrandRepo(ctrl)
+			} else {
+				stockBrandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(nil, nil).AnyTimes()
+			}
 			interactor := NewSignalPerformanceInteractor(
 				tt.fields.analyzeRepo(ctrl),
+				stockBrandRepo,
 				tt.fields.priceRepo(ctrl),
+				topixRepo,
+				nikkeiRepo,
 			)
 			got, err := interactor.GetSignalPerformance(context.Background(), tt.filter)
 			assert.NoError(t, err)
@@ -20,6 +20,7 @@ type stockBrandInteractorImpl struct {
 	stockBrandsDailyPriceForAnalyzeRepository repositories.StockBrandsDailyPriceForAnalyzeRepository
 	finAnnouncementRepository                 repositories.FinAnnouncementRepository
 	finStatementRepository                    repositories.FinStatementRepository
+	relativeStrengthRepository                repositories.RelativeStrengthRepository
 	stockAPIClient                            gateway.StockAPIClient
 	redisClient                               *redis.Client
 }
@@ -47,6 +48,7 @@ func NewStockBrandInteractor(
 	stockBrandsDailyPriceForAnalyzeRepository repositories.StockBrandsDailyPriceForAnalyzeRepository,
 	finAnnouncementRepository repositories.FinAnnouncementRepository,
 	finStatementRepository repositories.FinStatementRepository,
+	relativeStrengthRepository repositories.RelativeStrengthRepository,
 	stockAPIClient gateway.StockAPIClient,
 	redisClient *redis.Client,
 ) StockBrandInteractor {
@@ -58,6 +60,7 @@ func NewStockBrandInteractor(
 		stockBrandsDailyPriceForAnalyzeRepository: stockBrandsDailyPriceForAnalyzeRepository,
 		finAnnouncementRepository:                 finAnnouncementRepository,
 		finStatementRepository:                    finStatementRepository,
+		relativeStrengthRepository:                relativeStrengthRepository,
 		stockAPIClient:                            stockAPIClient,
 		redisClient:                               redisClient,
 	}
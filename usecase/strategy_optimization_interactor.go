@@ -0,0 +1,321 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math/rand"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/Code0716/stock-price-repository/repositories"
+)
+
+const (
+	strategyOptimizationKeyPrefix = "strategy_optimization:v1:"
+	// strategyOptimizationTTL 結果の保持期間。ジョブごとにキーが増えるため期限を付ける。
+	strategyOptimizationTTL = 30 * 24 * time.Hour
+	// strategyOptimizationRunningKey API から起動して実行中のジョブ ID。同時に1件だけ走らせるためのロックを兼ねる。
+	// ジョブ結果のキー（strategyOptimizationKeyPrefix + jobID）と衝突しないよう別の名前空間にする。
+	strategyOptimizationRunningKey = "strategy_optimization_running:v1"
+	// strategyOptimizationWarmupSlackDays ウォークフォワード期間の前に取るウォームアップ日足の暦日換算に足す余裕（連休分）。
+	strategyOptimizationWarmupSlackDays = 14
+)
+
+var (
+	// ErrStrategyOptimizationNotFound 指定ジョブの結果が存在しない（未登録または期限切れ）。
+	ErrStrategyOptimizationNotFound = errors.New("strategy optimization not found")
+	// ErrStrategyOptimizationRunning 別の最適化ジョブが実行中のため新しいジョブを受け付けない。
+	ErrStrategyOptimizationRunning = errors.New("strategy optimization already running")
+)
+
+type strategyOptimizationInteractorImpl struct {
+	stockBrandRepository                 repositories.StockBrandRepository
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
+	redisClient                          *redis.Client
+}
+
+// StrategyOptimizationInteractor 戦略パラメータの最適化とウォークフォワード検証のインターフェース。
+type StrategyOptimizationInteractor interface {
+	// Optimize 全主要市場銘柄でパラメータを探索・ウォークフォワード検証し、結果を Redis に保存して返す（同期実行）。
+	// concurrency: ワーカー数（<=0 で NumCPU）。
+	Optimize(ctx context.Context, params models.StrategyOptimizationParams, concurrency int) (*models.StrategyOptimizationResult, error)
+	// StartOptimization 実行中状態のジョブを登録し、バックグラウンドで Optimize 相当を実行する。
+	// 登録したジョブ（Status=running）を即座に返す。1ジョブで全コアを使うため、別のジョブが実行中なら ErrStrategyOptimizationRunning を返す。
+	StartOptimization(ctx context.Context, params models.StrategyOptimizationParams) (*models.StrategyOptimizationResult, error)
+	// FailInterruptedOptimizations API の再起動で中断され running のまま残ったジョブを failed にし、実行中ロックを外す。
+	// API サーバーの起動時に呼ぶ（API は1プロセスで動かす前提）。
+	FailInterruptedOptimizations(ctx context.Context) error
+	// GetOptimization ジョブの状態・結果を返す。存在しなければ ErrStrategyOptimizationNotFound。
+	GetOptimization(ctx context.Context, jobID string) (*models.StrategyOptimizationResult, error)
+}
+
+func NewStrategyOptimizationInteractor(
+	stockBrandRepository repositories.StockBrandRepository,
+	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
+	redisClient *redis.Client,
+) StrategyOptimizationInteractor {
+	return &strategyOptimizationInteractorImpl{
+		stockBrandRepository:                 stockBrandRepository,
+		stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
+		redisClient:                          redisClient,
+	}
+}
+
+func (o *strategyOptimizationInteractorImpl) Optimize(ctx context.Context, params models.StrategyOptimizationParams, concurrency int) (*models.StrategyOptimizationResult, error) {
+	job := newStrategyOptimizationJob(params)
+	if err := o.save(ctx, job); err != nil {
+		return nil, err
+	}
+	return o.run(ctx, job, concurrency)
+}
+
+func (o *strategyOptimizationInteractorImpl) StartOptimization(ctx context.Context, params models.StrategyOptimizationParams) (*models.StrategyOptimizationResult, error) {
+	job := newStrategyOptimizationJob(params)
+	acquired, err := o.redisClient.SetNX(ctx, strategyOptimizationRunningKey, job.JobID, 0).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "redisClient.SetNX error")
+	}
+	if !acquired {
+		return nil, ErrStrategyOptimizationRunning
+	}
+	if err := o.save(ctx, job); err != nil {
+		o.releaseRunning(ctx)
+		return nil, err
+	}
+	accepted := *job
+
+	// リクエストの終了でキャンセルされないよう ctx の値だけ引き継ぐ
+	bg := context.WithoutCancel(ctx)
+	go func() {
+		defer o.releaseRunning(bg)
+		if _, err := o.run(bg, job, 0); err != nil {
+			log.Printf("strategy optimization: job %s failed: %v", job.JobID, err)
+		}
+	}()
+	return &accepted, nil
+}
+
+func (o *strategyOptimizationInteractorImpl) FailInterruptedOptimizations(ctx context.Context) error {
+	jobID, err := o.redisClient.Get(ctx, strategyOptimizationRunningKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+		return errors.Wrap(err, "redisClient.Get error")
+	}
+
+	job, err := o.GetOptimization(ctx, jobID)
+	switch {
+	case errors.Is(err, ErrStrategyOptimizationNotFound):
+		// 結果が期限切れで消えていればロックだけ外す
+	case err != nil:
+		return err
+	case job.Status == models.OptimizationStatusRunning:
+		job.Status = models.OptimizationStatusFailed
+		job.Error = "interrupted by server restart"
+		job.CompletedAt = time.Now().Format(time.RFC3339)
+		if err := o.save(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	if err := o.redisClient.Del(ctx, strategyOptimizationRunningKey).Err(); err != nil {
+		return errors.Wrap(err, "redisClient.Del error")
+	}
+	return nil
+}
+
+// releaseRunning 実行中ロックを外す。失敗しても次回起動時の FailInterruptedOptimizations で外れるためログだけ残す。
+func (o *strategyOptimizationInteractorImpl) releaseRunning(ctx context.Context) {
+	if err := o.redisClient.Del(ctx, strategyOptimizationRunningKey).Err(); err != nil {
+		log.Printf("strategy optimization: release running lock failed: %v", err)
+	}
+}
+
+func (o *strategyOptimizationInteractorImpl) GetOptimization(ctx context.Context, jobID string) (*models.StrategyOptimizationResult, error) {
+	raw, err := o.redisClient.Get(ctx, strategyOptimizationKeyPrefix+jobID).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrStrategyOptimizationNotFound
+		}
+		return nil, errors.Wrap(err, "redisClient.Get error")
+	}
+	var result models.StrategyOptimizationResult
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal error")
+	}
+	return &result, nil
+}
+
+func newStrategyOptimizationJob(params models.StrategyOptimizationParams) *models.StrategyOptimizationResult {
+	return &models.StrategyOptimizationResult{
+		JobID:     uuid.NewString(),
+		Status:    models.OptimizationStatusRunning,
+		StartedAt: time.Now().Format(time.RFC3339),
+		Params:    params,
+		Label:     domain_service.StrategyLabel(params.Strategy),
+		Windows:   []models.WalkForwardWindowResult{},
+		Stability: []models.ParameterStability{},
+	}
+}
+
+// run 最適化を実行し、成功・失敗いずれの場合もジョブの最終状態を保存する。
+func (o *strategyOptimizationInteractorImpl) run(ctx context.Context, job *models.StrategyOptimizationResult, concurrency int) (*models.StrategyOptimizationResult, error) {
+	err := o.optimize(ctx, job, concurrency)
+	job.CompletedAt = time.Now().Format(time.RFC3339)
+	if err != nil {
+		job.Status = models.OptimizationStatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = models.OptimizationStatusCompleted
+	}
+	if saveErr := o.save(ctx, job); saveErr != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, saveErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (o *strategyOptimizationInteractorImpl) optimize(ctx context.Context, job *models.StrategyOptimizationResult, concurrency int) error {
+	params := job.Params
+	brands, err := o.stockBrandRepository.FindAllMainMarkets(ctx)
+	if err != nil {
+		return errors.Wrap(err, "FindAllMainMarkets error")
+	}
+
+	now := time.Now()
+	from := now.AddDate(-params.Years, 0, 0)
+	windows := domain_service.BuildWalkForwardWindows(from, now, params.InSampleMonths, params.OutOfSampleMonths)
+	if len(windows) == 0 {
+		return errors.New("walk-forward windows are empty: years must cover inSampleMonths + outOfSampleMonths")
+	}
+
+	space := domain_service.DefaultOptimizerSearchSpace()
+	cost := domain_service.ExitParams{
+		CommissionRate: params.CommissionRate,
+		SlippageRate:   params.SlippageRate,
+		Side:           domain_service.StrategySide(params.Strategy),
+	}
+	var candidates []domain_service.OptimizerCandidate
+	if params.Method == models.OptimizationMethodRandom {
+		candidates = domain_service.RandomOptimizerCandidates(params.Strategy, space, cost, params.Samples, rand.New(rand.NewSource(params.Seed)))
+	} else {
+		candidates = domain_service.GridOptimizerCandidates(params.Strategy, space, cost)
+	}
+
+	// 最初の窓の先頭で指標がウォームアップ済みになるよう、戦略の WarmupBars 分（営業日を暦日に換算）前から日足を取る
+	priceFrom := from.AddDate(0, 0, -(domain_service.StrategyMinHistoryBars(params.Strategy)*7/5 + strategyOptimizationWarmupSlackDays))
+	scores, processed, err := o.runWorkers(ctx, brands, params.Strategy, priceFrom, now, candidates, windows, concurrency)
+	if err != nil {
+		return err
+	}
+
+	job.Candidates = len(candidates)
+	job.TotalStocks = processed
+	domain_service.SummarizeWalkForward(candidates, windows, scores, job)
+	log.Printf("strategy optimization: completed. strategy=%s candidates=%d processed=%d/%d brands",
+		params.Strategy, len(candidates), processed, len(brands))
+	return nil
+}
+
+func (o *strategyOptimizationInteractorImpl) save(ctx context.Context, job *models.StrategyOptimizationResult) error {
+	b, err := json.Marshal(job)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal error")
+	}
+	if err := o.redisClient.Set(ctx, strategyOptimizationKeyPrefix+job.JobID, string(b), strategyOptimizationTTL).Err(); err != nil {
+		return errors.Wrap(err, "redisClient.Set error")
+	}
+	return nil
+}
+
+// runWorkers strategyRankingInteractorImpl.runWorkers と同じ構成のワーカープール。
+// 各ワーカーは自分専用の集計表にのみ書き込み、最後にマージする。
+func (o *strategyOptimizationInteractorImpl) runWorkers(
+	ctx context.Context,
+	brands []*models.StockBrand,
+	strategy string,
+	from, to time.Time,
+	candidates []domain_service.OptimizerCandidate,
+	windows []domain_service.WalkForwardWindow,
+	concurrency int,
+) (domain_service.WalkForwardScores, int, error) {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	asc := models.SortOrderAsc
+	minBars := domain_service.StrategyMinHistoryBars(strategy)
+
+	workerScores := make([]domain_service.WalkForwardScores, concurrency)
+	for w := range workerScores {
+		workerScores[w] = domain_service.NewWalkForwardScores(len(windows), len(candidates))
+	}
+
+	jobs := make(chan *models.StockBrand)
+	var processed atomic.Int64
+	g, gctx := errgroup.WithContext(ctx)
+
+	for w := 0; w < concurrency; w++ {
+		w := w
+		g.Go(func() error {
+			local := workerScores[w]
+			for brand := range jobs {
+				prices, err := o.stockBrandsDailyStockPriceRepository.ListDailyPricesBySymbol(gctx, models.ListDailyPricesBySymbolFilter{
+					TickerSymbol: brand.TickerSymbol,
+					DateFrom:     &from,
+					DateTo:       &to,
+					DateOrder:    &asc,
+				})
+				if err != nil {
+					return errors.Wrap(err, "ListDailyPricesBySymbol error for "+brand.TickerSymbol)
+				}
+				if len(prices) < minBars {
+					continue
+				}
+				domain_service.AccumulateWalkForward(strategy, prices, candidates, windows, local)
+				if n := processed.Add(1); n%200 == 0 {
+					log.Printf("strategy optimization: processed %d/%d brands", n, len(brands))
+				}
+			}
+			return nil
+		})
+	}
+
+	// フィーダ：ctx キャンセルを尊重して銘柄を投入する
+	g.Go(func() error {
+		defer close(jobs)
+		for _, b := range brands {
+			select {
+			case jobs <- b:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, int(processed.Load()), err
+	}
+
+	scores := domain_service.NewWalkForwardScores(len(windows), len(candidates))
+	for _, ws := range workerScores {
+		scores.Merge(ws)
+	}
+	return scores, int(processed.Load()), nil
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Code0716/stock-price-repository/domain_service"
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+)
+
+// recentPrices 今日を末尾とする日次の日足を返す（ウォークフォワード窓は現在時刻基準のため）。
+func recentPrices(days int) []*models.StockBrandDailyPrice {
+	end := time.Now()
+	prices := make([]*models.StockBrandDailyPrice, days)
+	for i := 0; i < days; i++ {
+		c := 100 + float64(i%10)
+		prices[i] = &models.StockBrandDailyPrice{
+			Date:   end.AddDate(0, 0, i-days+1),
+			Open:   decimal.NewFromFloat(c),
+			High:   decimal.NewFromFloat(c + 1),
+			Low:    decimal.NewFromFloat(c - 1),
+			Close:  decimal.NewFromFloat(c),
+			Volume: int64(100000 + i*1000),
+		}
+	}
+	return prices
+}
+
+func TestStrategyOptimizationInteractor_Optimize(t *testing.T) {
+	params := models.StrategyOptimizationParams{
+		Strategy:          "ma_cross",
+		Method:            models.OptimizationMethodRandom,
+		Samples:           3,
+		Seed:              1,
+		Years:             2,
+		InSampleMonths:    12,
+		OutOfSampleMonths: 6,
+	}
+
+	t.Run("正常系: 窓ごとの最良パラメータを集計し Redis に保存する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		_, client := newTestRedis(t)
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(testBrands("7203", "6758"), nil)
+		priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(recentPrices(730), nil).Times(2)
+
+		interactor := NewStrategyOptimizationInteractor(brandRepo, priceRepo, client)
+		got, err := interactor.Optimize(context.Background(), params, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, models.OptimizationStatusCompleted, got.Status)
+		assert.Equal(t, 3, got.Candidates)
+		assert.Equal(t, 2, got.TotalStocks)
+		assert.Len(t, got.Windows, 2)
+		assert.NotNil(t, got.MostFrequent)
+
+		saved, err := interactor.GetOptimization(context.Background(), got.JobID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.OptimizationStatusCompleted, saved.Status)
+		assert.Len(t, saved.Windows, 2)
+	})
+
+	t.Run("正常系: 最初の窓より戦略のウォームアップ分前から日足を取得する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		_, client := newTestRedis(t)
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(testBrands("7203"), nil)
+		var filter models.ListDailyPricesBySymbolFilter
+		priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, f models.ListDailyPricesBySymbolFilter) ([]*models.StockBrandDailyPrice, error) {
+				filter = f
+				return recentPrices(730), nil
+			})
+
+		_, err := NewStrategyOptimizationInteractor(brandRepo, priceRepo, client).Optimize(context.Background(), params, 1)
+		assert.NoError(t, err)
+		if assert.NotNil(t, filter.DateFrom) {
+			firstWindow := time.Now().AddDate(-params.Years, 0, 0)
+			warmup := time.Duration(domain_service.StrategyMinHistoryBars(params.Strategy)) * 24 * time.Hour
+			assert.True(t, filter.DateFrom.Before(firstWindow.Add(-warmup)))
+		}
+	})
+
+	t.Run("異常系: 日足取得エラーは failed として保存する", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mr, client := newTestRedis(t)
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(testBrands("7203"), nil)
+		priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		interactor := NewStrategyOptimizationInteractor(brandRepo, priceRepo, client)
+		got, err := interactor.Optimize(context.Background(), params, 1)
+		assert.Error(t, err)
+		assert.Nil(t, got)
+
+		keys := mr.Keys()
+		assert.Len(t, keys, 1)
+		saved, err := interactor.GetOptimization(context.Background(), keys[0][len(strategyOptimizationKeyPrefix):])
+		assert.NoError(t, err)
+		assert.Equal(t, models.OptimizationStatusFailed, saved.Status)
+		assert.Contains(t, saved.Error, "db error")
+	})
+
+	t.Run("異常系: 窓が作れない期間", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		_, client := newTestRedis(t)
+
+		brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+		brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(testBrands("7203"), nil)
+
+		p := params
+		p.Years = 1
+		_, err := NewStrategyOptimizationInteractor(brandRepo, nil, client).Optimize(context.Background(), p, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestStrategyOptimizationInteractor_GetOptimization_NotFound(t *testing.T) {
+	_, client := newTestRedis(t)
+
+	_, err := NewStrategyOptimizationInteractor(nil, nil, client).GetOptimization(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrStrategyOptimizationNotFound)
+}
+
+func TestStrategyOptimizationInteractor_StartOptimization_Running(t *testing.T) {
+	mr, client := newTestRedis(t)
+	assert.NoError(t, mr.Set(strategyOptimizationRunningKey, "job-1"))
+
+	got, err := NewStrategyOptimizationInteractor(nil, nil, client).StartOptimization(context.Background(), models.StrategyOptimizationParams{Strategy: "ma_cross"})
+	assert.ErrorIs(t, err, ErrStrategyOptimizationRunning)
+	assert.Nil(t, got)
+	assert.Len(t, mr.Keys(), 1, "ジョブを登録しない")
+}
+
+func TestStrategyOptimizationInteractor_FailInterruptedOptimizations(t *testing.T) {
+	t.Run("running のまま残ったジョブを failed にしてロックを外す", func(t *testing.T) {
+		mr, client := newTestRedis(t)
+		interactor := &strategyOptimizationInteractorImpl{redisClient: client}
+		job := newStrategyOptimizationJob(models.StrategyOptimizationParams{Strategy: "ma_cross"})
+		assert.NoError(t, interactor.save(context.Background(), job))
+		assert.NoError(t, mr.Set(strategyOptimizationRunningKey, job.JobID))
+
+		assert.NoError(t, interactor.FailInterruptedOptimizations(context.Background()))
+
+		saved, err := interactor.GetOptimization(context.Background(), job.JobID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.OptimizationStatusFailed, saved.Status)
+		assert.Equal(t, "interrupted by server restart", saved.Error)
+		assert.NotEmpty(t, saved.CompletedAt)
+		assert.False(t, mr.Exists(strategyOptimizationRunningKey))
+	})
+
+	t.Run("結果が消えていればロックだけ外す", func(t *testing.T) {
+		mr, client := newTestRedis(t)
+		assert.NoError(t, mr.Set(strategyOptimizationRunningKey, "missing"))
+
+		assert.NoError(t, NewStrategyOptimizationInteractor(nil, nil, client).FailInterruptedOptimizations(context.Background()))
+		assert.False(t, mr.Exists(strategyOptimizationRunningKey))
+	})
+
+	t.Run("実行中ロックが無ければ何もしない", func(t *testing.T) {
+		mr, client := newTestRedis(t)
+
+		assert.NoError(t, NewStrategyOptimizationInteractor(nil, nil, client).FailInterruptedOptimizations(context.Background()))
+		assert.Empty(t, mr.Keys())
+	})
+}
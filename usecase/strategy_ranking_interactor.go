@@ -5,11 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"reflect"
 	"runtime"
 	"sort"
 	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 	"github.com/shopspring/decimal"
@@ -18,21 +20,35 @@ import (
 	"github.com/Code0716/stock-price-repository/domain_service"
 	"github.com/Code0716/stock-price-repository/models"
 	"github.com/Code0716/stock-price-repository/repositories"
+	"github.com/Code0716/stock-price-repository/util"
 )
 
 const (
 	strategyRankingRedisKey        = "strategy_ranking:v1"
 	strategyRankingStocksKeyPrefix = "strategy_ranking:v1:stocks:"
-	strategyRankingUniverse        = "main_markets"
-	// strategyRankingMinDays は指標ウォームアップに必要な最低日数（backtest_interactor.go と同値）。
-	strategyRankingMinDays = 80
+	// strategyRankingCacheTTL Redis は最新実行のキャッシュ。失効後は MySQL の最新実行から返す。
+	strategyRankingCacheTTL = 7 * 24 * time.Hour
+	// strategyRankingNikkeiWarmupDays 日経平均レジーム判定の200日移動平均のウォームアップに余分に読む暦日数。
+	strategyRankingNikkeiWarmupDays = 400
+	// strategyRankingLiquidityLookbackDays 対象期間の開始時点の流動性（直近20営業日の平均売買代金）を判定するために
+	// 開始日より前に余分に読む暦日数。
+	strategyRankingLiquidityLookbackDays = 45
+	// strategyRankingRunningKey API から起動して実行中の run_id。同時に1件だけ走らせるためのロックを兼ねる。
+	strategyRankingRunningKey = "strategy_ranking_running:v1"
+)
+
+var (
+	// ErrStrategyRankingRunNotFound 指定 run_id の実行記録が存在しない。
+	ErrStrategyRankingRunNotFound = errors.New("strategy ranking run not found")
+	// ErrStrategyRankingRunning 別の戦略ランキングが実行中のため新しい実行を受け付けない。
+	ErrStrategyRankingRunning = errors.New("strategy ranking already running")
 )
 
 // strategyAcc 1戦略の集計アキュムレータ。
 type strategyAcc struct {
-	stockCount    int
-	tradedStocks  int
-	positiveCount int
+	stockCount     int
+	tradedStocks   int
+	positiveCount  int
 	sumTotalReturn decimal.Decimal
 	sumWinRate     decimal.Decimal
 	sumPF          decimal.Decimal
@@ -44,8 +60,9 @@ type strategyAcc struct {
 
 // newAccs 戦略ごとの集計アキュムレータを初期化して返す。
 func newAccs() map[string]*strategyAcc {
-	accs := make(map[string]*strategyAcc, len(domain_service.StrategyOrder))
-	for _, s := range domain_service.StrategyOrder {
+	ids := domain_service.StrategyIDs()
+	accs := make(map[string]*strategyAcc, len(ids))
+	for _, s := range ids {
 		accs[s] = &strategyAcc{}
 	}
 	return accs
@@ -53,7 +70,7 @@ func newAccs() map[string]*strategyAcc {
 
 // mergeAccs src の集計を dst に加算する（ワーカーローカル集計のマージ用）。
 func mergeAccs(dst, src map[string]*strategyAcc) {
-	for _, s := range domain_service.StrategyOrder {
+	for _, s := range domain_service.StrategyIDs() {
 		d, sa := dst[s], src[s]
 		d.stockCount += sa.stockCount
 		d.tradedStocks += sa.tradedStocks
@@ -67,16 +84,49 @@ func mergeAccs(dst, src map[string]*strategyAcc) {
 	}
 }
 
-// accumulateResults 日足と exitParams から各戦略の結果を accs に集計する。
-// 集計用途のため Equity/TradeList を構築しない RunBacktestMetrics を使う。
-func accumulateResults(brand *models.StockBrand, prices []*models.StockBrandDailyPrice, exitParams domain_service.ExitParams, accs map[string]*strategyAcc) {
-	results := make(map[string]models.BacktestResult, len(domain_service.StrategyOrder))
-	for _, s := range domain_service.StrategyOrder {
-		signals := domain_service.EntrySignalsByStrategy(s, prices)
-		// exitSignals は nil を渡して共通ルールのみ使用（ランキングバッチは挙動不変を優先）
-		results[s] = domain_service.RunBacktestMetrics(prices, signals, nil, exitParams)
+// accumulateResults 日足と params から各戦略の結果を accs に集計する。
+// segments があれば全期間のトレードをエントリー日でセグメントに振り分け、segAccs[i] にも集計する
+// （セグメント期間に日足が重ならない銘柄はそのセグメントの母数に含めない）。accs が nil なら全期間には集計せずセグメントだけに集計する。
+// セグメント無しなら集計用途のため Equity/TradeList を構築しない RunBacktestMetrics を使う。
+func accumulateResults(
+	brand *models.StockBrand,
+	prices []*models.StockBrandDailyPrice,
+	params models.BacktestParams,
+	accs map[string]*strategyAcc,
+	segments []models.RankingSegment,
+	segAccs []map[string]*strategyAcc,
+) {
+	strategies := domain_service.RegisteredStrategies()
+	results := make(map[string]models.BacktestResult, len(strategies))
+	for _, st := range strategies {
+		s := st.ID()
+		signals := st.EntrySignals(prices)
+		exitSignals := exitSignalsFor(s, prices, params)
+		if len(segments) == 0 {
+			results[s] = domain_service.RunBacktestMetrics(prices, signals, exitSignals, newExitParams(s, params))
+			continue
+		}
+		results[s] = domain_service.RunBacktest(prices, signals, exitSignals, newExitParams(s, params))
+	}
+	if accs != nil {
+		addStrategyResults(brand, results, accs, true)
 	}
-	for _, s := range domain_service.StrategyOrder {
+
+	for i, seg := range segments {
+		if !domain_service.PricesOverlapSegment(prices, seg) {
+			continue
+		}
+		segResults := make(map[string]models.BacktestResult, len(strategies))
+		for _, s := range domain_service.StrategyIDs() {
+			segResults[s] = domain_service.SummarizeTrades(domain_service.TradesInSegment(results[s].TradeList, seg))
+		}
+		addStrategyResults(brand, segResults, segAccs[i], false)
+	}
+}
+
+// addStrategyResults 1銘柄分の戦略別結果を accs に加算する。keepStocks なら銘柄別ドリルダウン結果も蓄積する。
+func addStrategyResults(brand *models.StockBrand, results map[string]models.BacktestResult, accs map[string]*strategyAcc, keepStocks bool) {
+	for _, s := range domain_service.StrategyIDs() {
 		res := results[s]
 		a := accs[s]
 		a.stockCount++
@@ -90,6 +140,9 @@ func accumulateResults(brand *models.StockBrand, prices []*models.StockBrandDail
 			a.sumWinRate = a.sumWinRate.Add(res.WinRate)
 			a.sumPF = a.sumPF.Add(res.ProfitFactor)
 		}
+		if !keepStocks {
+			continue
+		}
 		// 銘柄別ドリルダウン用に結果を蓄積
 		a.stocks = append(a.stocks, &models.StrategyStockResult{
 			TickerSymbol: brand.TickerSymbol,
@@ -106,7 +159,7 @@ func accumulateResults(brand *models.StockBrand, prices []*models.StockBrandDail
 	// この銘柄で最高 TotalReturn の戦略の bestCount を加算する
 	bestStrategy := ""
 	bestReturn := decimal.NewFromInt(-999)
-	for _, s := range domain_service.StrategyOrder {
+	for _, s := range domain_service.StrategyIDs() {
 		if results[s].Trades > 0 && results[s].TotalReturn.GreaterThan(bestReturn) {
 			bestReturn = results[s].TotalReturn
 			bestStrategy = s
@@ -117,41 +170,99 @@ func accumulateResults(brand *models.StockBrand, prices []*models.StockBrandDail
 	}
 }
 
+// buildStrategyRankingItems 集計アキュムレータから StrategyRankingItem を組み立て、AvgTotalReturn 降順で返す。
+func buildStrategyRankingItems(accs map[string]*strategyAcc) []models.StrategyRankingItem {
+	strategies := domain_service.RegisteredStrategies()
+	items := make([]models.StrategyRankingItem, 0, len(strategies))
+	for _, st := range strategies {
+		s := st.ID()
+		a := accs[s]
+		item := models.StrategyRankingItem{
+			Strategy:     s,
+			Label:        st.Label(),
+			StockCount:   a.stockCount,
+			TradedStocks: a.tradedStocks,
+			TotalTrades:  a.totalTrades,
+			BestCount:    a.bestCount,
+		}
+		if a.stockCount > 0 {
+			item.AvgTotalReturn = a.sumTotalReturn.Div(decimal.NewFromInt(int64(a.stockCount)))
+			item.PositiveRate = decimal.NewFromInt(int64(a.positiveCount)).Div(decimal.NewFromInt(int64(a.stockCount)))
+		}
+		if a.tradedStocks > 0 {
+			item.AvgWinRate = a.sumWinRate.Div(decimal.NewFromInt(int64(a.tradedStocks)))
+			item.AvgProfitFactor = a.sumPF.Div(decimal.NewFromInt(int64(a.tradedStocks)))
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].AvgTotalReturn.GreaterThan(items[j].AvgTotalReturn)
+	})
+	return items
+}
+
 type strategyRankingInteractorImpl struct {
+	tx                                   repositories.Transaction
 	stockBrandRepository                 repositories.StockBrandRepository
 	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
+	nikkeiRepository                     repositories.NikkeiRepository
+	strategyRankingRunRepository         repositories.StrategyRankingRunRepository
 	redisClient                          *redis.Client
 }
 
 // StrategyRankingInteractor 全銘柄横断バックテスト集計インターフェース。
+// 実行ごとの結果は MySQL（strategy_ranking_run*）に run_id 付きで保存し、Redis には主要市場全銘柄の最新実行をキャッシュする。
 type StrategyRankingInteractor interface {
-	// ComputeAndSaveStrategyRanking 全主要市場銘柄を全戦略でバックテストし、集計を Redis に保存する。
-	// years: 直近N年を対象期間とする。concurrency: ワーカー数（<=0 で NumCPU）。処理した銘柄数を返す。
-	ComputeAndSaveStrategyRanking(ctx context.Context, params models.BacktestParams, years, concurrency int) (int, error)
-	// GetStrategyRanking Redis から集計を返す。未計算なら Computed=false の空の StrategyRanking を返す。
-	GetStrategyRanking(ctx context.Context) (*models.StrategyRanking, error)
-	// GetStrategyRankingStocks Redis から戦略別の銘柄ドリルダウン結果を返す。
+	// ComputeAndSaveStrategyRanking req のユニバースを全戦略でバックテストし、全期間とセグメント別の集計を MySQL に保存する（同期実行）。
+	// ユニバースが主要市場全銘柄なら Redis の最新キャッシュも更新する。concurrency: ワーカー数（<=0 で NumCPU）。
+	// 完了した実行記録を返す。失敗時も実行記録は status=failed で残す。
+	ComputeAndSaveStrategyRanking(ctx context.Context, req models.StrategyRankingRequest, concurrency int) (*models.StrategyRankingRun, error)
+	// StartStrategyRanking 実行記録を status=running で登録し、バックグラウンドで ComputeAndSaveStrategyRanking 相当を実行する。
+	// 登録した実行記録を即座に返す。別の実行が API から起動されて実行中なら ErrStrategyRankingRunning。
+	StartStrategyRanking(ctx context.Context, req models.StrategyRankingRequest) (*models.StrategyRankingRun, error)
+	// FailInterruptedStrategyRankings API の再起動で中断され running のまま残った実行を failed にし、実行中ロックを外す。
+	// API サーバーの起動時に呼ぶ（API は1プロセスで動かす前提）。
+	FailInterruptedStrategyRankings(ctx context.Context) error
+	// GetStrategyRanking 集計を返す。runID が空なら主要市場全銘柄の最新実行（Redis キャッシュ、無ければ MySQL の最新）。
+	// 未計算なら Computed=false の空の StrategyRanking を返す。runID 指定で存在しなければ ErrStrategyRankingRunNotFound。
+	GetStrategyRanking(ctx context.Context, runID string) (*models.StrategyRanking, error)
+	// GetStrategyRankingStocks 戦略別の銘柄ドリルダウン結果を返す。runID の扱いは GetStrategyRanking と同じ。
 	// 未計算なら Computed=false の空の StrategyStocks を返す。Items は limit 件に切り、TotalCount は全件数。
-	GetStrategyRankingStocks(ctx context.Context, strategy string, limit int) (*models.StrategyStocks, error)
+	GetStrategyRankingStocks(ctx context.Context, runID, strategy string, limit int) (*models.StrategyStocks, error)
+	// ListStrategyRankingRuns 実行記録を新しい順に最大 limit 件返す。
+	ListStrategyRankingRuns(ctx context.Context, limit int) (*models.StrategyRankingRuns, error)
+	// DiffStrategyRankingRuns 2回の実行の順位・指標・条件の差分を返す。segment="" なら全期間、指定時はそのセグメント同士を比較する。
+	// どちらかが存在しなければ ErrStrategyRankingRunNotFound。
+	DiffStrategyRankingRuns(ctx context.Context, baseRunID, targetRunID, segment string) (*models.StrategyRankingDiff, error)
 }
 
 func NewStrategyRankingInteractor(
+	tx repositories.Transaction,
 	stockBrandRepository repositories.StockBrandRepository,
 	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository,
+	nikkeiRepository repositories.NikkeiRepository,
+	strategyRankingRunRepository repositories.StrategyRankingRunRepository,
 	redisClient *redis.Client,
 ) StrategyRankingInteractor {
 	return &strategyRankingInteractorImpl{
+		tx:                                   tx,
 		stockBrandRepository:                 stockBrandRepository,
 		stockBrandsDailyStockPriceRepository: stockBrandsDailyStockPriceRepository,
+		nikkeiRepository:                     nikkeiRepository,
+		strategyRankingRunRepository:         strategyRankingRunRepository,
 		redisClient:                          redisClient,
 	}
 }
 
-func (r *strategyRankingInteractorImpl) GetStrategyRanking(ctx context.Context) (*models.StrategyRanking, error) {
+func (r *strategyRankingInteractorImpl) GetStrategyRanking(ctx context.Context, runID string) (*models.StrategyRanking, error) {
+	if runID != "" {
+		return r.loadStrategyRanking(ctx, runID)
+	}
 	raw, err := r.redisClient.Get(ctx, strategyRankingRedisKey).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
-			return &models.StrategyRanking{Computed: false, Items: []models.StrategyRankingItem{}}, nil
+			return r.loadLatestStrategyRanking(ctx)
 		}
 		return nil, errors.Wrap(err, "redisClient.Get error")
 	}
@@ -162,16 +273,30 @@ func (r *strategyRankingInteractorImpl) GetStrategyRanking(ctx context.Context)
 	return &ranking, nil
 }
 
-func (r *strategyRankingInteractorImpl) GetStrategyRankingStocks(ctx context.Context, strategy string, limit int) (*models.StrategyStocks, error) {
+func (r *strategyRankingInteractorImpl) GetStrategyRankingStocks(ctx context.Context, runID, strategy string, limit int) (*models.StrategyStocks, error) {
+	if runID != "" {
+		run, err := r.findRun(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+		return r.loadStrategyRankingStocks(ctx, run, strategy, limit)
+	}
 	key := strategyRankingStocksKeyPrefix + strategy
 	raw, err := r.redisClient.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
-			return &models.StrategyStocks{
-				Computed: false,
-				Strategy: strategy,
-				Items:    []*models.StrategyStockResult{},
-			}, nil
+			run, err := r.strategyRankingRunRepository.FindLatest(ctx, models.RankingUniverseMainMarkets)
+			if err != nil {
+				return nil, errors.Wrap(err, "strategyRankingRunRepository.FindLatest error")
+			}
+			if run == nil {
+				return &models.StrategyStocks{
+					Computed: false,
+					Strategy: strategy,
+					Items:    []*models.StrategyStockResult{},
+				}, nil
+			}
+			return r.loadStrategyRankingStocks(ctx, run, strategy, limit)
 		}
 		return nil, errors.Wrap(err, "redisClient.Get error")
 	}
@@ -188,119 +313,542 @@ func (r *strategyRankingInteractorImpl) GetStrategyRankingStocks(ctx context.Con
 	return &stocks, nil
 }
 
-func (r *strategyRankingInteractorImpl) ComputeAndSaveStrategyRanking(ctx context.Context, params models.BacktestParams, years, concurrency int) (int, error) {
-	brands, err := r.stockBrandRepository.FindAllMainMarkets(ctx)
+func (r *strategyRankingInteractorImpl) ComputeAndSaveStrategyRanking(ctx context.Context, req models.StrategyRankingRequest, concurrency int) (*models.StrategyRankingRun, error) {
+	run := newStrategyRankingRun(req)
+	if err := r.strategyRankingRunRepository.Create(ctx, run); err != nil {
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.Create error")
+	}
+	if err := r.run(ctx, run, req, concurrency); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (r *strategyRankingInteractorImpl) StartStrategyRanking(ctx context.Context, req models.StrategyRankingRequest) (*models.StrategyRankingRun, error) {
+	run := newStrategyRankingRun(req)
+	acquired, err := r.redisClient.SetNX(ctx, strategyRankingRunningKey, run.RunID, 0).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "redisClient.SetNX error")
+	}
+	if !acquired {
+		return nil, ErrStrategyRankingRunning
+	}
+	if err := r.strategyRankingRunRepository.Create(ctx, run); err != nil {
+		r.releaseRunning(ctx)
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.Create error")
+	}
+	accepted := *run
+
+	// リクエストの終了でキャンセルされないよう ctx の値だけ引き継ぐ
+	bg := context.WithoutCancel(ctx)
+	go func() {
+		defer r.releaseRunning(bg)
+		if err := r.run(bg, run, req, 0); err != nil {
+			log.Printf("strategy ranking: run %s failed: %v", run.RunID, err)
+		}
+	}()
+	return &accepted, nil
+}
+
+func (r *strategyRankingInteractorImpl) FailInterruptedStrategyRankings(ctx context.Context) error {
+	runID, err := r.redisClient.Get(ctx, strategyRankingRunningKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+		return errors.Wrap(err, "redisClient.Get error")
+	}
+
+	run, err := r.strategyRankingRunRepository.FindByRunID(ctx, runID)
+	if err != nil {
+		return errors.Wrap(err, "strategyRankingRunRepository.FindByRunID error")
+	}
+	// 実行記録が無ければロックだけ外す
+	if run != nil && run.Status == models.RankingRunStatusRunning {
+		run.Status = models.RankingRunStatusFailed
+		run.Error = "interrupted by server restart"
+		if err := r.strategyRankingRunRepository.UpdateRun(ctx, run); err != nil {
+			return errors.Wrap(err, "strategyRankingRunRepository.UpdateRun error")
+		}
+	}
+
+	if err := r.redisClient.Del(ctx, strategyRankingRunningKey).Err(); err != nil {
+		return errors.Wrap(err, "redisClient.Del error")
+	}
+	return nil
+}
+
+// releaseRunning 実行中ロックを外す。失敗しても次回起動時の FailInterruptedStrategyRankings で外れるためログだけ残す。
+func (r *strategyRankingInteractorImpl) releaseRunning(ctx context.Context) {
+	if err := r.redisClient.Del(ctx, strategyRankingRunningKey).Err(); err != nil {
+		log.Printf("strategy ranking: release running lock failed: %v", err)
+	}
+}
+
+func newStrategyRankingRun(req models.StrategyRankingRequest) *models.StrategyRankingRun {
+	return &models.StrategyRankingRun{
+		RunID:        uuid.NewString(),
+		Status:       models.RankingRunStatusRunning,
+		ComputedAt:   time.Now(),
+		Universe:     req.Universe.Key(),
+		UniverseSpec: req.Universe,
+		Years:        req.Years,
+		Params:       req.Params,
+		Segments:     req.Segments,
+	}
+}
+
+// run 集計を実行する。失敗時は実行記録を status=failed に更新する。
+func (r *strategyRankingInteractorImpl) run(ctx context.Context, run *models.StrategyRankingRun, req models.StrategyRankingRequest, concurrency int) error {
+	err := r.compute(ctx, run, req, concurrency)
+	if err == nil {
+		return nil
+	}
+	run.Status = models.RankingRunStatusFailed
+	run.Error = err.Error()
+	if updateErr := r.strategyRankingRunRepository.UpdateRun(ctx, run); updateErr != nil {
+		log.Printf("strategy ranking: failed to mark run %s as failed: %v", run.RunID, updateErr)
+	}
+	return err
+}
+
+func (r *strategyRankingInteractorImpl) compute(ctx context.Context, run *models.StrategyRankingRun, req models.StrategyRankingRequest, concurrency int) error {
+	brands, err := r.resolveUniverse(ctx, req.Universe)
 	if err != nil {
-		return 0, errors.Wrap(err, "FindAllMainMarkets error")
+		return err
 	}
 
-	now := time.Now()
-	from := now.AddDate(-years, 0, 0)
-	exitParams := domain_service.ExitParams{
-		TakeProfit:  params.TakeProfit,
-		StopLoss:    params.StopLoss,
-		MaxHoldDays: params.MaxHoldDays,
+	to := run.ComputedAt
+	from := to.AddDate(-req.Years, 0, 0)
+
+	segments := append([]models.RankingSegment{}, req.Segments...)
+	if req.NikkeiRegimes {
+		regimes, err := r.nikkeiRegimeSegments(ctx, from, to)
+		if err != nil {
+			return err
+		}
+		segments = append(segments, regimes...)
 	}
 
-	accs, processed, err := r.runWorkers(ctx, brands, from, now, exitParams, concurrency)
+	accs, segAccs, processed, err := r.runWorkers(ctx, brands, from, to, req.Params, req.Universe.MinAvgTradingValue, segments, concurrency)
 	if err != nil {
-		return processed, err
+		return err
 	}
 
-	// StrategyRankingItem を組み立て
-	items := make([]models.StrategyRankingItem, 0, len(domain_service.StrategyOrder))
-	for _, s := range domain_service.StrategyOrder {
+	items := buildStrategyRankingItems(accs)
+	segResults := make([]models.StrategyRankingSegmentResult, 0, len(segments))
+	for i, seg := range segments {
+		segResults = append(segResults, models.StrategyRankingSegmentResult{
+			Segment: seg,
+			Items:   buildStrategyRankingItems(segAccs[i]),
+		})
+	}
+	// 銘柄別結果は TotalReturn 降順
+	for _, s := range domain_service.StrategyIDs() {
 		a := accs[s]
-		item := models.StrategyRankingItem{
-			Strategy:    s,
-			Label:       domain_service.StrategyLabels[s],
-			StockCount:  a.stockCount,
-			TradedStocks: a.tradedStocks,
-			TotalTrades: a.totalTrades,
-			BestCount:   a.bestCount,
+		sort.SliceStable(a.stocks, func(i, j int) bool {
+			return a.stocks[i].TotalReturn.GreaterThan(a.stocks[j].TotalReturn)
+		})
+	}
+
+	run.Status = models.RankingRunStatusCompleted
+	run.ComputedAt = time.Now()
+	run.TotalStocks = len(brands)
+	run.ProcessedStocks = processed
+	run.Segments = segments
+
+	// 集計結果を MySQL に保存（履歴）。失敗時は Redis の最新キャッシュも更新しない
+	if err := r.tx.DoInTx(ctx, func(ctx context.Context) error {
+		if err := r.strategyRankingRunRepository.BulkCreateItems(ctx, run.RunID, "", items); err != nil {
+			return errors.Wrap(err, "strategyRankingRunRepository.BulkCreateItems error")
 		}
-		if a.stockCount > 0 {
-			item.AvgTotalReturn = a.sumTotalReturn.Div(decimal.NewFromInt(int64(a.stockCount)))
-			item.PositiveRate = decimal.NewFromInt(int64(a.positiveCount)).Div(decimal.NewFromInt(int64(a.stockCount)))
+		for _, sr := range segResults {
+			if err := r.strategyRankingRunRepository.BulkCreateItems(ctx, run.RunID, sr.Segment.Name, sr.Items); err != nil {
+				return errors.Wrap(err, "strategyRankingRunRepository.BulkCreateItems error for segment "+sr.Segment.Name)
+			}
 		}
-		if a.tradedStocks > 0 {
-			item.AvgWinRate = a.sumWinRate.Div(decimal.NewFromInt(int64(a.tradedStocks)))
-			item.AvgProfitFactor = a.sumPF.Div(decimal.NewFromInt(int64(a.tradedStocks)))
+		for _, s := range domain_service.StrategyIDs() {
+			if err := r.strategyRankingRunRepository.BulkCreateStocks(ctx, run.RunID, s, accs[s].stocks); err != nil {
+				return errors.Wrap(err, "strategyRankingRunRepository.BulkCreateStocks error for "+s)
+			}
 		}
-		items = append(items, item)
+		if err := r.strategyRankingRunRepository.UpdateRun(ctx, run); err != nil {
+			return errors.Wrap(err, "strategyRankingRunRepository.UpdateRun error")
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "DoInTx error")
 	}
 
-	// AvgTotalReturn 降順でソート
-	sort.SliceStable(items, func(i, j int) bool {
-		return items[i].AvgTotalReturn.GreaterThan(items[j].AvgTotalReturn)
-	})
+	// Redis の「最新」は主要市場全銘柄の実行のみ
+	if run.Universe == models.RankingUniverseMainMarkets {
+		if err := r.cacheLatest(ctx, run, items, segResults, accs); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("strategy ranking: completed. run_id=%s universe=%s processed=%d/%d brands", run.RunID, run.Universe, processed, len(brands))
+	return nil
+}
+
+// resolveUniverse ユニバース条件から対象銘柄を返す（流動性条件は日足取得後にワーカーで判定する）。
+// 市場コード指定が無ければ主要市場、ただしウォッチリストのみの指定は全市場から銘柄コードで選ぶ。
+func (r *strategyRankingInteractorImpl) resolveUniverse(ctx context.Context, universe models.RankingUniverse) ([]*models.StockBrand, error) {
+	var (
+		brands []*models.StockBrand
+		err    error
+	)
+	switch {
+	case len(universe.MarketCodes) > 0:
+		brands, err = r.stockBrandRepository.FindWithFilter(ctx, models.NewStockBrandFilter().WithMarketCodes(universe.MarketCodes...))
+		if err != nil {
+			return nil, errors.Wrap(err, "FindWithFilter error")
+		}
+	case len(universe.Symbols) > 0:
+		brands, err = r.stockBrandRepository.FindAll(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindAll error")
+		}
+	default:
+		brands, err = r.stockBrandRepository.FindAllMainMarkets(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "FindAllMainMarkets error")
+		}
+	}
+
+	sectors := make(map[string]bool, len(universe.Sector33Codes))
+	for _, c := range universe.Sector33Codes {
+		sectors[c] = true
+	}
+	symbols := make(map[string]bool, len(universe.Symbols))
+	for _, s := range universe.Symbols {
+		symbols[s] = true
+	}
+	out := make([]*models.StockBrand, 0, len(brands))
+	for _, b := range brands {
+		if len(sectors) > 0 && !sectors[b.Sector33Code] {
+			continue
+		}
+		if len(symbols) > 0 && !symbols[b.TickerSymbol] {
+			continue
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+// nikkeiRegimeSegments 対象期間の日経平均レジーム（nikkei_bull / nikkei_bear）セグメントを返す。
+func (r *strategyRankingInteractorImpl) nikkeiRegimeSegments(ctx context.Context, from, to time.Time) ([]models.RankingSegment, error) {
+	warmupFrom := from.AddDate(0, 0, -strategyRankingNikkeiWarmupDays)
+	index, err := r.nikkeiRepository.ListNikkeiStockAverageDailyPrices(ctx, &warmupFrom, &to)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListNikkeiStockAverageDailyPrices error")
+	}
+	return domain_service.NikkeiRegimeSegments(index, from), nil
+}
 
+// cacheLatest 最新実行の集計と戦略別銘柄ドリルダウンを Redis に保存する。
+func (r *strategyRankingInteractorImpl) cacheLatest(
+	ctx context.Context,
+	run *models.StrategyRankingRun,
+	items []models.StrategyRankingItem,
+	segResults []models.StrategyRankingSegmentResult,
+	accs map[string]*strategyAcc,
+) error {
+	computedAt := run.ComputedAt.Format(time.RFC3339)
 	ranking := models.StrategyRanking{
-		Computed:    true,
-		ComputedAt:  now.Format(time.RFC3339),
-		Universe:    strategyRankingUniverse,
-		TotalStocks: len(brands),
-		Params:      params,
-		Items:       items,
+		Computed:     true,
+		RunID:        run.RunID,
+		Status:       run.Status,
+		ComputedAt:   computedAt,
+		Universe:     run.Universe,
+		UniverseSpec: run.UniverseSpec,
+		TotalStocks:  run.TotalStocks,
+		Params:       run.Params,
+		Items:        items,
+		Segments:     segResults,
 	}
 
 	b, err := json.Marshal(ranking)
 	if err != nil {
-		return processed, errors.Wrap(err, "json.Marshal error")
+		return errors.Wrap(err, "json.Marshal error")
 	}
-	if err := r.redisClient.Set(ctx, strategyRankingRedisKey, string(b), 0).Err(); err != nil {
-		return processed, errors.Wrap(err, "redisClient.Set error")
+	if err := r.redisClient.Set(ctx, strategyRankingRedisKey, string(b), strategyRankingCacheTTL).Err(); err != nil {
+		return errors.Wrap(err, "redisClient.Set error")
 	}
 
 	// 戦略別銘柄ドリルダウンデータを Redis に保存
-	computedAt := now.Format(time.RFC3339)
-	for _, s := range domain_service.StrategyOrder {
+	for _, s := range domain_service.StrategyIDs() {
 		a := accs[s]
-		// TotalReturn 降順にソート
-		sort.SliceStable(a.stocks, func(i, j int) bool {
-			return a.stocks[i].TotalReturn.GreaterThan(a.stocks[j].TotalReturn)
-		})
 		stocksPayload := models.StrategyStocks{
 			Computed:   true,
+			RunID:      run.RunID,
 			ComputedAt: computedAt,
 			Strategy:   s,
-			Label:      domain_service.StrategyLabels[s],
+			Label:      domain_service.StrategyLabel(s),
 			TotalCount: len(a.stocks),
 			Items:      a.stocks,
 		}
 		sb, err := json.Marshal(stocksPayload)
 		if err != nil {
-			return processed, errors.Wrap(err, "json.Marshal stocks error for "+s)
+			return errors.Wrap(err, "json.Marshal stocks error for "+s)
 		}
 		key := strategyRankingStocksKeyPrefix + s
-		if err := r.redisClient.Set(ctx, key, string(sb), 0).Err(); err != nil {
-			return processed, errors.Wrap(err, "redisClient.Set stocks error for "+s)
+		if err := r.redisClient.Set(ctx, key, string(sb), strategyRankingCacheTTL).Err(); err != nil {
+			return errors.Wrap(err, "redisClient.Set stocks error for "+s)
+		}
+	}
+	return nil
+}
+
+func (r *strategyRankingInteractorImpl) ListStrategyRankingRuns(ctx context.Context, limit int) (*models.StrategyRankingRuns, error) {
+	runs, err := r.strategyRankingRunRepository.List(ctx, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.List error")
+	}
+	return &models.StrategyRankingRuns{Items: runs}, nil
+}
+
+func (r *strategyRankingInteractorImpl) DiffStrategyRankingRuns(ctx context.Context, baseRunID, targetRunID, segment string) (*models.StrategyRankingDiff, error) {
+	baseRun, err := r.findRun(ctx, baseRunID)
+	if err != nil {
+		return nil, err
+	}
+	targetRun, err := r.findRun(ctx, targetRunID)
+	if err != nil {
+		return nil, err
+	}
+	baseItems, err := r.strategyRankingRunRepository.ListItems(ctx, baseRun.RunID, segment)
+	if err != nil {
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.ListItems error")
+	}
+	targetItems, err := r.strategyRankingRunRepository.ListItems(ctx, targetRun.RunID, segment)
+	if err != nil {
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.ListItems error")
+	}
+
+	changed, err := changedRankingParams(baseRun, targetRun)
+	if err != nil {
+		return nil, err
+	}
+	return &models.StrategyRankingDiff{
+		Base:          baseRun,
+		Target:        targetRun,
+		Segment:       segment,
+		ChangedParams: changed,
+		Items:         diffStrategyRankingItems(baseItems, targetItems),
+	}, nil
+}
+
+// findRun run_id の実行記録を返す。存在しなければ ErrStrategyRankingRunNotFound。
+func (r *strategyRankingInteractorImpl) findRun(ctx context.Context, runID string) (*models.StrategyRankingRun, error) {
+	run, err := r.strategyRankingRunRepository.FindByRunID(ctx, runID)
+	if err != nil {
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.FindByRunID error")
+	}
+	if run == nil {
+		return nil, ErrStrategyRankingRunNotFound
+	}
+	return run, nil
+}
+
+// loadStrategyRanking MySQL から run_id の集計を組み立てる。
+func (r *strategyRankingInteractorImpl) loadStrategyRanking(ctx context.Context, runID string) (*models.StrategyRanking, error) {
+	run, err := r.findRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return r.buildStrategyRanking(ctx, run)
+}
+
+// loadLatestStrategyRanking MySQL の主要市場全銘柄の最新実行から集計を組み立てる。実行記録が無ければ Computed=false。
+func (r *strategyRankingInteractorImpl) loadLatestStrategyRanking(ctx context.Context) (*models.StrategyRanking, error) {
+	run, err := r.strategyRankingRunRepository.FindLatest(ctx, models.RankingUniverseMainMarkets)
+	if err != nil {
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.FindLatest error")
+	}
+	if run == nil {
+		return &models.StrategyRanking{
+			Computed: false,
+			Items:    []models.StrategyRankingItem{},
+			Segments: []models.StrategyRankingSegmentResult{},
+		}, nil
+	}
+	return r.buildStrategyRanking(ctx, run)
+}
+
+// buildStrategyRanking 実行記録の全期間・セグメント別の集計を組み立てる。完了していない実行は Computed=false。
+func (r *strategyRankingInteractorImpl) buildStrategyRanking(ctx context.Context, run *models.StrategyRankingRun) (*models.StrategyRanking, error) {
+	items, err := r.strategyRankingRunRepository.ListItems(ctx, run.RunID, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.ListItems error")
+	}
+	segResults := make([]models.StrategyRankingSegmentResult, 0, len(run.Segments))
+	for _, seg := range run.Segments {
+		segItems, err := r.strategyRankingRunRepository.ListItems(ctx, run.RunID, seg.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "strategyRankingRunRepository.ListItems error for segment "+seg.Name)
+		}
+		segResults = append(segResults, models.StrategyRankingSegmentResult{Segment: seg, Items: segItems})
+	}
+	return &models.StrategyRanking{
+		Computed:     run.Status == models.RankingRunStatusCompleted,
+		RunID:        run.RunID,
+		Status:       run.Status,
+		ComputedAt:   run.ComputedAt.Format(time.RFC3339),
+		Universe:     run.Universe,
+		UniverseSpec: run.UniverseSpec,
+		TotalStocks:  run.TotalStocks,
+		Params:       run.Params,
+		Items:        items,
+		Segments:     segResults,
+	}, nil
+}
+
+// loadStrategyRankingStocks MySQL から run の戦略別銘柄ドリルダウンを組み立てる。
+func (r *strategyRankingInteractorImpl) loadStrategyRankingStocks(ctx context.Context, run *models.StrategyRankingRun, strategy string, limit int) (*models.StrategyStocks, error) {
+	total, err := r.strategyRankingRunRepository.CountStocks(ctx, run.RunID, strategy)
+	if err != nil {
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.CountStocks error")
+	}
+	stocks, err := r.strategyRankingRunRepository.ListStocks(ctx, run.RunID, strategy, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "strategyRankingRunRepository.ListStocks error")
+	}
+	return &models.StrategyStocks{
+		Computed:   run.Status == models.RankingRunStatusCompleted,
+		RunID:      run.RunID,
+		ComputedAt: run.ComputedAt.Format(time.RFC3339),
+		Strategy:   strategy,
+		Label:      domain_service.StrategyLabel(strategy),
+		TotalCount: total,
+		Items:      stocks,
+	}, nil
+}
+
+// diffStrategyRankingItems target の順位順に戦略ごとの変化を並べる。target に無い戦略（廃止など）は末尾に追加する。
+func diffStrategyRankingItems(base, target []models.StrategyRankingItem) []models.StrategyRankingDiffItem {
+	baseRank := make(map[string]int, len(base))
+	for i, item := range base {
+		baseRank[item.Strategy] = i + 1
+	}
+	targetRank := make(map[string]int, len(target))
+	for i, item := range target {
+		targetRank[item.Strategy] = i + 1
+	}
+
+	out := make([]models.StrategyRankingDiffItem, 0, len(target)+len(base))
+	for i := range target {
+		t := target[i]
+		d := models.StrategyRankingDiffItem{
+			Strategy:   t.Strategy,
+			Label:      t.Label,
+			TargetRank: util.ToPtrGenerics(i + 1),
+			Target:     &t,
+		}
+		if br, ok := baseRank[t.Strategy]; ok {
+			b := base[br-1]
+			d.BaseRank = util.ToPtrGenerics(br)
+			d.Base = &b
+			d.RankChange = util.ToPtrGenerics(br - (i + 1))
+			d.AvgTotalReturnChange = t.AvgTotalReturn.Sub(b.AvgTotalReturn)
+			d.PositiveRateChange = t.PositiveRate.Sub(b.PositiveRate)
+			d.AvgWinRateChange = t.AvgWinRate.Sub(b.AvgWinRate)
+			d.AvgProfitFactorChange = t.AvgProfitFactor.Sub(b.AvgProfitFactor)
+		}
+		out = append(out, d)
+	}
+	for i := range base {
+		b := base[i]
+		if _, ok := targetRank[b.Strategy]; ok {
+			continue
 		}
+		out = append(out, models.StrategyRankingDiffItem{
+			Strategy: b.Strategy,
+			Label:    b.Label,
+			BaseRank: util.ToPtrGenerics(i + 1),
+			Base:     &b,
+		})
 	}
+	return out
+}
 
-	log.Printf("strategy ranking: completed. processed=%d/%d brands", processed, len(brands))
-	return processed, nil
+// changedRankingParams 2回の実行で値が異なる条件のキーを昇順で返す。
+// BacktestParams は JSON キーで比較し、追加されたフィールドも自動で対象になる。
+func changedRankingParams(base, target *models.StrategyRankingRun) ([]string, error) {
+	flatten := func(run *models.StrategyRankingRun) (map[string]any, error) {
+		b, err := json.Marshal(run.Params)
+		if err != nil {
+			return nil, errors.Wrap(err, "json.Marshal params error")
+		}
+		m := map[string]any{}
+		if err := json.Unmarshal(b, &m); err != nil {
+			return nil, errors.Wrap(err, "json.Unmarshal params error")
+		}
+		m["years"] = run.Years
+		m["universe"] = run.Universe
+		return m, nil
+	}
+	bm, err := flatten(base)
+	if err != nil {
+		return nil, err
+	}
+	tm, err := flatten(target)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make([]string, 0)
+	for k, bv := range bm {
+		if !reflect.DeepEqual(bv, tm[k]) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range tm {
+		if _, ok := bm[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
 }
 
 // runWorkers 固定 concurrency 個のワーカーで全銘柄を並列にバックテストし、
-// ワーカーローカルに集計してからマージした accs と処理銘柄数を返す。
+// ワーカーローカルに集計してからマージした accs、セグメント別の segAccs と処理銘柄数を返す。
+// minTradingValue が正なら、対象期間・各セグメント期間の開始日より前20営業日の平均売買代金がそれ未満の銘柄を
+// その期間の集計から除外する（期間中の売買代金で選ぶと先読みになるため、開始時点で判定する）。
 // 各ワーカーは自分専用の accs にのみ書き込むためロック不要。decimal の総和は
 // 順序非依存で厳密なので、結果は逐次版と完全一致する。
 func (r *strategyRankingInteractorImpl) runWorkers(
 	ctx context.Context,
 	brands []*models.StockBrand,
 	from, to time.Time,
-	exitParams domain_service.ExitParams,
+	params models.BacktestParams,
+	minTradingValue decimal.Decimal,
+	segments []models.RankingSegment,
 	concurrency int,
-) (map[string]*strategyAcc, int, error) {
+) (map[string]*strategyAcc, []map[string]*strategyAcc, int, error) {
 	if concurrency <= 0 {
 		concurrency = runtime.NumCPU()
 	}
 	asc := models.SortOrderAsc
+	// 全戦略を同じ日足で走らせるため、最もウォームアップの長い戦略に合わせる
+	minBars := domain_service.StrategyMinHistoryBars()
+	// 流動性条件があれば、開始時点の判定用に開始日より前の日足も読む
+	priceFrom := from
+	if minTradingValue.IsPositive() {
+		priceFrom = from.AddDate(0, 0, -strategyRankingLiquidityLookbackDays)
+	}
+	fromDate := util.DatetimeToDateStr(from)
 
 	workerAccs := make([]map[string]*strategyAcc, concurrency)
+	workerSegAccs := make([][]map[string]*strategyAcc, concurrency)
 	for w := range workerAccs {
 		workerAccs[w] = newAccs()
+		workerSegAccs[w] = make([]map[string]*strategyAcc, len(segments))
+		for i := range segments {
+			workerSegAccs[w][i] = newAccs()
+		}
 	}
 
 	jobs := make(chan *models.StockBrand)
@@ -314,17 +862,30 @@ func (r *strategyRankingInteractorImpl) runWorkers(
 			for brand := range jobs {
 				prices, err := r.stockBrandsDailyStockPriceRepository.ListDailyPricesBySymbol(gctx, models.ListDailyPricesBySymbolFilter{
 					TickerSymbol: brand.TickerSymbol,
-					DateFrom:     &from,
+					DateFrom:     &priceFrom,
 					DateTo:       &to,
 					DateOrder:    &asc,
 				})
 				if err != nil {
 					return errors.Wrap(err, "ListDailyPricesBySymbol error for "+brand.TickerSymbol)
 				}
-				if len(prices) < strategyRankingMinDays {
+				fullAccs, brandSegments := local, segments
+				if minTradingValue.IsPositive() {
+					if domain_service.AvgTradingValueBefore(prices, fromDate, domain_service.RankingLiquidityWindow).LessThan(minTradingValue) {
+						fullAccs = nil
+					}
+					brandSegments = domain_service.LiquidSegmentRanges(prices, segments, domain_service.RankingLiquidityWindow, minTradingValue)
+					// 判定用に読んだ開始日より前の日足はバックテストに使わない
+					start := sort.Search(len(prices), func(i int) bool { return util.DatetimeToDateStr(prices[i].Date) >= fromDate })
+					prices = prices[start:]
+				}
+				if len(prices) < minBars {
+					continue
+				}
+				if fullAccs == nil && !hasSegmentRanges(brandSegments) {
 					continue
 				}
-				accumulateResults(brand, prices, exitParams, local)
+				accumulateResults(brand, prices, params, fullAccs, brandSegments, workerSegAccs[w])
 				if n := processed.Add(1); n%200 == 0 {
 					log.Printf("strategy ranking: processed %d/%d brands", n, len(brands))
 				}
@@ -347,7 +908,7 @@ func (r *strategyRankingInteractorImpl) runWorkers(
 	})
 
 	if err := g.Wait(); err != nil {
-		return nil, int(processed.Load()), err
+		return nil, nil, int(processed.Load()), err
 	}
 
 	// ワーカーローカルの集計をマージ
@@ -355,5 +916,22 @@ func (r *strategyRankingInteractorImpl) runWorkers(
 	for _, wa := range workerAccs {
 		mergeAccs(accs, wa)
 	}
-	return accs, int(processed.Load()), nil
+	segAccs := make([]map[string]*strategyAcc, len(segments))
+	for i := range segments {
+		segAccs[i] = newAccs()
+		for _, wsa := range workerSegAccs {
+			mergeAccs(segAccs[i], wsa[i])
+		}
+	}
+	return accs, segAccs, int(processed.Load()), nil
+}
+
+// hasSegmentRanges いずれかのセグメントに期間が残っているか。
+func hasSegmentRanges(segments []models.RankingSegment) bool {
+	for _, seg := range segments {
+		if len(seg.Ranges) > 0 {
+			return true
+		}
+	}
+	return false
 }
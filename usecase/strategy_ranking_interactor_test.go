@@ -6,9 +6,10 @@ import (
 	"testing"
 	"time"
 
-	"github.com/alicebob/miniredis/v2"
+	"github.com/Code0716/stock-price-repository/domain_service"
 	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
 	"github.com/Code0716/stock-price-repository/models"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 	"github.com/shopspring/decimal"
@@ -55,13 +56,31 @@ func testPrices(n int) []*models.StockBrandDailyPrice {
 	return prices
 }
 
+// newSavingRunRepo セグメント無しの実行1回分の保存（Create + 全期間の BulkCreateItems + 戦略数分の BulkCreateStocks + UpdateRun）を期待するモックを返す。
+func newSavingRunRepo(ctrl *gomock.Controller) (*mock_repositories.MockTransaction, *mock_repositories.MockStrategyRankingRunRepository) {
+	tx := mock_repositories.NewMockTransaction(ctrl)
+	tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	runRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	runRepo.EXPECT().BulkCreateItems(gomock.Any(), gomock.Any(), "", gomock.Any()).Return(nil)
+	runRepo.EXPECT().BulkCreateStocks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(len(domain_service.StrategyIDs()))
+	runRepo.EXPECT().UpdateRun(gomock.Any(), gomock.Any()).Return(nil)
+	return tx, runRepo
+}
+
 func TestStrategyRankingInteractor_GetStrategyRanking_NotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	_, client := newTestRedis(t)
 
-	interactor := NewStrategyRankingInteractor(nil, nil, client)
-	got, err := interactor.GetStrategyRanking(context.Background())
+	// Redis にも MySQL にも実行記録が無い
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	runRepo.EXPECT().FindLatest(gomock.Any(), models.RankingUniverseMainMarkets).Return(nil, nil)
+
+	interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client)
+	got, err := interactor.GetStrategyRanking(context.Background(), "")
 	assert.NoError(t, err)
 	assert.False(t, got.Computed)
 	assert.Empty(t, got.Items)
@@ -75,7 +94,7 @@ func TestStrategyRankingInteractor_GetStrategyRanking_Found(t *testing.T) {
 	ranking := models.StrategyRanking{
 		Computed:    true,
 		ComputedAt:  "2026-06-01T00:00:00Z",
-		Universe:    strategyRankingUniverse,
+		Universe:    models.RankingUniverseMainMarkets,
 		TotalStocks: 10,
 		Params: models.BacktestParams{
 			TakeProfit:  decimal.NewFromFloat(0.10),
@@ -89,8 +108,8 @@ func TestStrategyRankingInteractor_GetStrategyRanking_Found(t *testing.T) {
 	b, _ := json.Marshal(ranking)
 	mr.Set(strategyRankingRedisKey, string(b))
 
-	interactor := NewStrategyRankingInteractor(nil, nil, client)
-	got, err := interactor.GetStrategyRanking(context.Background())
+	interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, nil, client)
+	got, err := interactor.GetStrategyRanking(context.Background(), "")
 	assert.NoError(t, err)
 	assert.True(t, got.Computed)
 	assert.Len(t, got.Items, 1)
@@ -125,17 +144,18 @@ func TestStrategyRankingInteractor_ComputeAndSaveStrategyRanking_Normal(t *testi
 		MaxHoldDays: 20,
 	}
 
-	interactor := NewStrategyRankingInteractor(brandRepo, priceRepo, client)
-	n, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), params, 5, 2)
+	tx, runRepo := newSavingRunRepo(ctrl)
+	interactor := NewStrategyRankingInteractor(tx, brandRepo, priceRepo, nil, runRepo, client)
+	run, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), models.StrategyRankingRequest{Params: params, Years: 5}, 2)
 	assert.NoError(t, err)
-	assert.Equal(t, 2, n)
+	assert.Equal(t, 2, run.ProcessedStocks)
 
 	// Redisに保存されたことを確認
-	got, err := interactor.GetStrategyRanking(context.Background())
+	got, err := interactor.GetStrategyRanking(context.Background(), "")
 	assert.NoError(t, err)
 	assert.True(t, got.Computed)
 	assert.Equal(t, 2, got.TotalStocks)
-	assert.Len(t, got.Items, 5) // 5戦略
+	assert.Len(t, got.Items, len(domain_service.StrategyIDs()))
 	// AvgTotalReturn 降順
 	for i := 1; i < len(got.Items); i++ {
 		assert.True(t, got.Items[i-1].AvgTotalReturn.GreaterThanOrEqual(got.Items[i].AvgTotalReturn))
@@ -155,17 +175,18 @@ func TestStrategyRankingInteractor_ComputeAndSaveStrategyRanking_SkipShortData(t
 	priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
 
 	brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(testBrands("9999"), nil)
-	// 79日分（minBacktestDays未満）→スキップ
-	priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(testPrices(79), nil)
+	// 全戦略の最長ウォームアップ本数未満→スキップ
+	priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(testPrices(domain_service.StrategyMinHistoryBars()-1), nil)
 
 	params := models.BacktestParams{TakeProfit: decimal.NewFromFloat(0.1), StopLoss: decimal.NewFromFloat(0.05), MaxHoldDays: 20}
-	interactor := NewStrategyRankingInteractor(brandRepo, priceRepo, client)
-	n, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), params, 5, 2)
+	tx, runRepo := newSavingRunRepo(ctrl)
+	interactor := NewStrategyRankingInteractor(tx, brandRepo, priceRepo, nil, runRepo, client)
+	run, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), models.StrategyRankingRequest{Params: params, Years: 5}, 2)
 	assert.NoError(t, err)
-	assert.Equal(t, 0, n) // スキップされたので処理0件
+	assert.Equal(t, 0, run.ProcessedStocks) // スキップされたので処理0件
 
 	// Redis には保存されているが StockCount=0
-	got, err := interactor.GetStrategyRanking(context.Background())
+	got, err := interactor.GetStrategyRanking(context.Background(), "")
 	assert.NoError(t, err)
 	assert.True(t, got.Computed)
 	for _, item := range got.Items {
@@ -184,9 +205,18 @@ func TestStrategyRankingInteractor_ComputeAndSaveStrategyRanking_PriceError(t *t
 	brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(testBrands("1234"), nil)
 	priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
 
+	// 失敗した実行も status=failed で残す
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	runRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	runRepo.EXPECT().UpdateRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *models.StrategyRankingRun) error {
+		assert.Equal(t, models.RankingRunStatusFailed, run.Status)
+		assert.Contains(t, run.Error, "db error")
+		return nil
+	})
+
 	params := models.BacktestParams{TakeProfit: decimal.NewFromFloat(0.1), StopLoss: decimal.NewFromFloat(0.05), MaxHoldDays: 20}
-	interactor := NewStrategyRankingInteractor(brandRepo, priceRepo, client)
-	_, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), params, 5, 2)
+	interactor := NewStrategyRankingInteractor(nil, brandRepo, priceRepo, nil, runRepo, client)
+	_, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), models.StrategyRankingRequest{Params: params, Years: 5}, 2)
 	assert.Error(t, err)
 }
 
@@ -215,10 +245,11 @@ func TestStrategyRankingInteractor_ComputeAndSaveStrategyRanking_SavesStockKeys(
 		MaxHoldDays: 20,
 	}
 
-	interactor := NewStrategyRankingInteractor(brandRepo, priceRepo, client)
-	n, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), params, 5, 2)
+	tx, runRepo := newSavingRunRepo(ctrl)
+	interactor := NewStrategyRankingInteractor(tx, brandRepo, priceRepo, nil, runRepo, client)
+	run, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), models.StrategyRankingRequest{Params: params, Years: 5}, 2)
 	assert.NoError(t, err)
-	assert.Equal(t, 2, n)
+	assert.Equal(t, 2, run.ProcessedStocks)
 
 	// 戦略ごとの銘柄キーが Redis に保存されているか確認
 	for _, s := range []string{"macd_bullish", "bollinger_breakout", "triangle_formation", "ma_cross", "multiple_signals"} {
@@ -244,8 +275,11 @@ func TestStrategyRankingInteractor_GetStrategyRankingStocks_NotFound(t *testing.
 	defer ctrl.Finish()
 	_, client := newTestRedis(t)
 
-	interactor := NewStrategyRankingInteractor(nil, nil, client)
-	got, err := interactor.GetStrategyRankingStocks(context.Background(), "macd_bullish", 100)
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	runRepo.EXPECT().FindLatest(gomock.Any(), models.RankingUniverseMainMarkets).Return(nil, nil)
+
+	interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client)
+	got, err := interactor.GetStrategyRankingStocks(context.Background(), "", "macd_bullish", 100)
 	assert.NoError(t, err)
 	assert.False(t, got.Computed)
 	assert.Equal(t, "macd_bullish", got.Strategy)
@@ -274,10 +308,10 @@ func TestStrategyRankingInteractor_GetStrategyRankingStocks_Found(t *testing.T)
 	b, _ := json.Marshal(payload)
 	mr.Set(strategyRankingStocksKeyPrefix+"macd_bullish", string(b))
 
-	interactor := NewStrategyRankingInteractor(nil, nil, client)
+	interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, nil, client)
 
 	// limit=2 で切り取られ TotalCount=3 になるか確認
-	got, err := interactor.GetStrategyRankingStocks(context.Background(), "macd_bullish", 2)
+	got, err := interactor.GetStrategyRankingStocks(context.Background(), "", "macd_bullish", 2)
 	assert.NoError(t, err)
 	assert.True(t, got.Computed)
 	assert.Equal(t, 3, got.TotalCount)
@@ -293,7 +327,572 @@ func TestStrategyRankingInteractor_GetStrategyRankingStocks_InvalidJSON(t *testi
 
 	mr.Set(strategyRankingStocksKeyPrefix+"macd_bullish", "invalid-json")
 
-	interactor := NewStrategyRankingInteractor(nil, nil, client)
-	_, err := interactor.GetStrategyRankingStocks(context.Background(), "macd_bullish", 100)
+	interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, nil, client)
+	_, err := interactor.GetStrategyRankingStocks(context.Background(), "", "macd_bullish", 100)
+	assert.Error(t, err)
+}
+
+func TestAccumulateResults_HonorsCosts(t *testing.T) {
+	base := models.BacktestParams{TakeProfit: decimal.NewFromFloat(0.1), StopLoss: decimal.NewFromFloat(0.05), MaxHoldDays: 20}
+	withCost := base
+	withCost.CommissionRate = decimal.NewFromFloat(0.01)
+	withCost.SlippageRate = decimal.NewFromFloat(0.001)
+
+	brand := &models.StockBrand{TickerSymbol: "7203"}
+	noCostAccs, costAccs := newAccs(), newAccs()
+	accumulateResults(brand, testPrices(90), base, noCostAccs, nil, nil)
+	accumulateResults(brand, testPrices(90), withCost, costAccs, nil, nil)
+
+	traded := 0
+	for _, s := range domain_service.StrategyIDs() {
+		if noCostAccs[s].totalTrades == 0 {
+			continue
+		}
+		traded++
+		assert.True(t, costAccs[s].sumTotalReturn.LessThan(noCostAccs[s].sumTotalReturn), "strategy %s", s)
+	}
+	assert.Greater(t, traded, 0)
+}
+
+func TestStrategyRankingInteractor_ComputeAndSaveStrategyRanking_SavesRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, client := newTestRedis(t)
+
+	brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+	priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+	brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(testBrands("7203", "6758", "9999"), nil)
+	priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(testPrices(90), nil).Times(2)
+	priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(testPrices(10), nil)
+
+	params := models.BacktestParams{
+		TakeProfit:     decimal.NewFromFloat(0.1),
+		StopLoss:       decimal.NewFromFloat(0.05),
+		MaxHoldDays:    20,
+		CommissionRate: decimal.NewFromFloat(0.0005),
+		ExitMode:       models.ExitModeSignal,
+	}
+
+	tx := mock_repositories.NewMockTransaction(ctrl)
+	tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	var saved *models.StrategyRankingRun
+	runRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, run *models.StrategyRankingRun) error {
+			saved = run
+			assert.Equal(t, models.RankingRunStatusRunning, run.Status)
+			return nil
+		})
+	runRepo.EXPECT().BulkCreateItems(gomock.Any(), gomock.Any(), "", gomock.Any()).DoAndReturn(
+		func(_ context.Context, runID, _ string, items []models.StrategyRankingItem) error {
+			assert.Equal(t, saved.RunID, runID)
+			assert.Len(t, items, len(domain_service.StrategyIDs()))
+			return nil
+		})
+	runRepo.EXPECT().UpdateRun(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, run *models.StrategyRankingRun) error {
+			assert.Equal(t, models.RankingRunStatusCompleted, run.Status)
+			return nil
+		})
+	runRepo.EXPECT().BulkCreateStocks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, runID, _ string, stocks []*models.StrategyStockResult) error {
+			assert.Equal(t, saved.RunID, runID)
+			assert.Len(t, stocks, 2)
+			return nil
+		}).Times(len(domain_service.StrategyIDs()))
+
+	interactor := NewStrategyRankingInteractor(tx, brandRepo, priceRepo, nil, runRepo, client)
+	run, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), models.StrategyRankingRequest{Params: params, Years: 5}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, run.ProcessedStocks)
+
+	assert.NotEmpty(t, saved.RunID)
+	assert.Equal(t, models.RankingUniverseMainMarkets, saved.Universe)
+	assert.Equal(t, 5, saved.Years)
+	assert.Equal(t, 3, saved.TotalStocks)
+	assert.Equal(t, 2, saved.ProcessedStocks)
+	assert.Equal(t, params, saved.Params)
+
+	// Redis の最新キャッシュは保存した実行を指す
+	got, err := interactor.GetStrategyRanking(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, saved.RunID, got.RunID)
+}
+
+func TestStrategyRankingInteractor_ComputeAndSaveStrategyRanking_SaveError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr, client := newTestRedis(t)
+
+	brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+	priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+	brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(testBrands("7203"), nil)
+	priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(testPrices(90), nil)
+
+	tx := mock_repositories.NewMockTransaction(ctrl)
+	tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	runRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	runRepo.EXPECT().BulkCreateItems(gomock.Any(), gomock.Any(), "", gomock.Any()).Return(errors.New("db error"))
+	runRepo.EXPECT().UpdateRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *models.StrategyRankingRun) error {
+		assert.Equal(t, models.RankingRunStatusFailed, run.Status)
+		return nil
+	})
+
+	params := models.BacktestParams{TakeProfit: decimal.NewFromFloat(0.1), StopLoss: decimal.NewFromFloat(0.05), MaxHoldDays: 20}
+	interactor := NewStrategyRankingInteractor(tx, brandRepo, priceRepo, nil, runRepo, client)
+	_, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), models.StrategyRankingRequest{Params: params, Years: 5}, 1)
 	assert.Error(t, err)
+	// 保存に失敗したら Redis の最新キャッシュは更新しない
+	assert.False(t, mr.Exists(strategyRankingRedisKey))
+}
+
+func TestStrategyRankingInteractor_GetStrategyRanking_ByRunID(t *testing.T) {
+	computedAt := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	run := &models.StrategyRankingRun{
+		RunID:       "11111111-1111-1111-1111-111111111111",
+		Status:      models.RankingRunStatusCompleted,
+		ComputedAt:  computedAt,
+		Universe:    "main_markets",
+		TotalStocks: 100,
+	}
+	items := []models.StrategyRankingItem{{Strategy: "ma_cross", StockCount: 90}}
+
+	t.Run("正常系: MySQL の実行記録から組み立てる", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		_, client := newTestRedis(t)
+
+		runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+		runRepo.EXPECT().FindByRunID(gomock.Any(), run.RunID).Return(run, nil)
+		runRepo.EXPECT().ListItems(gomock.Any(), run.RunID, "").Return(items, nil)
+
+		interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client)
+		got, err := interactor.GetStrategyRanking(context.Background(), run.RunID)
+		assert.NoError(t, err)
+		assert.True(t, got.Computed)
+		assert.Equal(t, run.RunID, got.RunID)
+		assert.Equal(t, "2026-06-01T09:00:00Z", got.ComputedAt)
+		assert.Equal(t, items, got.Items)
+	})
+
+	t.Run("異常系: 存在しない runID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		_, client := newTestRedis(t)
+
+		runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+		runRepo.EXPECT().FindByRunID(gomock.Any(), run.RunID).Return(nil, nil)
+
+		interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client)
+		_, err := interactor.GetStrategyRanking(context.Background(), run.RunID)
+		assert.ErrorIs(t, err, ErrStrategyRankingRunNotFound)
+	})
+
+	t.Run("正常系: Redis 失効時は MySQL の最新実行を返す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		_, client := newTestRedis(t)
+
+		runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+		runRepo.EXPECT().FindLatest(gomock.Any(), models.RankingUniverseMainMarkets).Return(run, nil)
+		runRepo.EXPECT().ListItems(gomock.Any(), run.RunID, "").Return(items, nil)
+
+		interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client)
+		got, err := interactor.GetStrategyRanking(context.Background(), "")
+		assert.NoError(t, err)
+		assert.Equal(t, run.RunID, got.RunID)
+	})
+}
+
+func TestStrategyRankingInteractor_GetStrategyRankingStocks_ByRunID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, client := newTestRedis(t)
+
+	run := &models.StrategyRankingRun{
+		RunID:      "11111111-1111-1111-1111-111111111111",
+		Status:     models.RankingRunStatusCompleted,
+		ComputedAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	stocks := []*models.StrategyStockResult{{TickerSymbol: "7203", TotalReturn: decimal.NewFromFloat(0.15)}}
+
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	runRepo.EXPECT().FindByRunID(gomock.Any(), run.RunID).Return(run, nil)
+	runRepo.EXPECT().CountStocks(gomock.Any(), run.RunID, "ma_cross").Return(3, nil)
+	runRepo.EXPECT().ListStocks(gomock.Any(), run.RunID, "ma_cross", 1).Return(stocks, nil)
+
+	interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client)
+	got, err := interactor.GetStrategyRankingStocks(context.Background(), run.RunID, "ma_cross", 1)
+	assert.NoError(t, err)
+	assert.True(t, got.Computed)
+	assert.Equal(t, run.RunID, got.RunID)
+	assert.Equal(t, 3, got.TotalCount)
+	assert.Equal(t, stocks, got.Items)
+}
+
+func TestStrategyRankingInteractor_DiffStrategyRankingRuns(t *testing.T) {
+	baseRun := &models.StrategyRankingRun{
+		RunID:    "11111111-1111-1111-1111-111111111111",
+		Universe: "main_markets",
+		Years:    5,
+		Params:   models.BacktestParams{TakeProfit: decimal.NewFromFloat(0.1), StopLoss: decimal.NewFromFloat(0.05), MaxHoldDays: 20},
+	}
+	targetRun := &models.StrategyRankingRun{
+		RunID:    "22222222-2222-2222-2222-222222222222",
+		Universe: "main_markets",
+		Years:    3,
+		Params: models.BacktestParams{
+			TakeProfit:     decimal.NewFromFloat(0.1),
+			StopLoss:       decimal.NewFromFloat(0.05),
+			MaxHoldDays:    20,
+			CommissionRate: decimal.NewFromFloat(0.0005),
+		},
+	}
+	baseItems := []models.StrategyRankingItem{
+		{Strategy: "ma_cross", AvgTotalReturn: decimal.NewFromFloat(0.05)},
+		{Strategy: "macd_bullish", AvgTotalReturn: decimal.NewFromFloat(0.03)},
+		{Strategy: "retired", AvgTotalReturn: decimal.NewFromFloat(0.01)},
+	}
+	targetItems := []models.StrategyRankingItem{
+		{Strategy: "macd_bullish", AvgTotalReturn: decimal.NewFromFloat(0.04)},
+		{Strategy: "ma_cross", AvgTotalReturn: decimal.NewFromFloat(0.02)},
+		{Strategy: "ma_dead_cross", AvgTotalReturn: decimal.NewFromFloat(0.01)},
+	}
+
+	t.Run("正常系: 順位変化・指標差分・変更された条件を返す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+		runRepo.EXPECT().FindByRunID(gomock.Any(), baseRun.RunID).Return(baseRun, nil)
+		runRepo.EXPECT().FindByRunID(gomock.Any(), targetRun.RunID).Return(targetRun, nil)
+		runRepo.EXPECT().ListItems(gomock.Any(), baseRun.RunID, "").Return(baseItems, nil)
+		runRepo.EXPECT().ListItems(gomock.Any(), targetRun.RunID, "").Return(targetItems, nil)
+
+		interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, nil)
+		got, err := interactor.DiffStrategyRankingRuns(context.Background(), baseRun.RunID, targetRun.RunID, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"commissionRate", "years"}, got.ChangedParams)
+		assert.Len(t, got.Items, 4)
+
+		// macd_bullish: 2位 → 1位
+		assert.Equal(t, "macd_bullish", got.Items[0].Strategy)
+		assert.Equal(t, 1, *got.Items[0].RankChange)
+		assert.True(t, got.Items[0].AvgTotalReturnChange.Equal(decimal.NewFromFloat(0.01)))
+		// ma_cross: 1位 → 2位
+		assert.Equal(t, -1, *got.Items[1].RankChange)
+		// ma_dead_cross: target のみ
+		assert.Nil(t, got.Items[2].BaseRank)
+		assert.Nil(t, got.Items[2].RankChange)
+		// retired: base のみ（末尾）
+		assert.Equal(t, "retired", got.Items[3].Strategy)
+		assert.Equal(t, 3, *got.Items[3].BaseRank)
+		assert.Nil(t, got.Items[3].TargetRank)
+	})
+
+	t.Run("異常系: 比較対象の実行が存在しない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+		runRepo.EXPECT().FindByRunID(gomock.Any(), baseRun.RunID).Return(baseRun, nil)
+		runRepo.EXPECT().FindByRunID(gomock.Any(), targetRun.RunID).Return(nil, nil)
+
+		interactor := NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, nil)
+		_, err := interactor.DiffStrategyRankingRuns(context.Background(), baseRun.RunID, targetRun.RunID, "")
+		assert.ErrorIs(t, err, ErrStrategyRankingRunNotFound)
+	})
+}
+
+func TestAccumulateResults_Segments(t *testing.T) {
+	params := models.BacktestParams{TakeProfit: decimal.NewFromFloat(0.1), StopLoss: decimal.NewFromFloat(0.05), MaxHoldDays: 20}
+	brand := &models.StockBrand{TickerSymbol: "7203"}
+	segments := []models.RankingSegment{
+		{Name: "all", Ranges: []models.RankingDateRange{{From: "2020-01-01", To: "2020-12-31"}}},
+		{Name: "early", Ranges: []models.RankingDateRange{{From: "2020-01-01", To: "2020-02-15"}}},
+		{Name: "late", Ranges: []models.RankingDateRange{{From: "2020-02-16", To: "2020-03-31"}}},
+		{Name: "outside", Ranges: []models.RankingDateRange{{From: "2019-01-01", To: "2019-12-31"}}},
+	}
+	accs := newAccs()
+	segAccs := []map[string]*strategyAcc{newAccs(), newAccs(), newAccs(), newAccs()}
+	accumulateResults(brand, testPrices(90), params, accs, segments, segAccs)
+
+	for _, s := range domain_service.StrategyIDs() {
+		// 全期間を覆うセグメントは全期間と同じトレードを数える
+		assert.Equal(t, accs[s].totalTrades, segAccs[0][s].totalTrades, "strategy %s", s)
+		// 期間を分割したセグメントのトレード数の和は全期間と一致する
+		assert.Equal(t, accs[s].totalTrades, segAccs[1][s].totalTrades+segAccs[2][s].totalTrades, "strategy %s", s)
+		assert.Equal(t, 1, segAccs[1][s].stockCount)
+		// 日足が重ならないセグメントは母数に含めない
+		assert.Equal(t, 0, segAccs[3][s].stockCount)
+		// セグメントは銘柄別ドリルダウンを持たない
+		assert.Empty(t, segAccs[0][s].stocks)
+		assert.Len(t, accs[s].stocks, 1)
+	}
+}
+
+func TestStrategyRankingInteractor_ComputeAndSaveStrategyRanking_Segments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr, client := newTestRedis(t)
+
+	brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+	priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+	nikkeiRepo := mock_repositories.NewMockNikkeiRepository(ctrl)
+	brandRepo.EXPECT().FindAllMainMarkets(gomock.Any()).Return(testBrands("7203"), nil)
+	priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(testPrices(90), nil)
+
+	// 直近まで右肩上がりの日経平均 → 対象期間の末尾は nikkei_bull
+	today := time.Now().Truncate(24 * time.Hour)
+	index := make(models.IndexStockAverageDailyPrices, 0, 260)
+	for i := 0; i < 260; i++ {
+		index = append(index, &models.IndexStockAverageDailyPrice{
+			Date:  today.AddDate(0, 0, i-259),
+			Close: decimal.NewFromInt(int64(30000 + i*10)),
+		})
+	}
+	nikkeiRepo.EXPECT().ListNikkeiStockAverageDailyPrices(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, from, to *time.Time) (models.IndexStockAverageDailyPrices, error) {
+			// 200日移動平均のウォームアップ分だけ対象期間より前から読む
+			assert.True(t, from.Before(to.AddDate(-5, 0, 0)))
+			return index, nil
+		})
+
+	tx := mock_repositories.NewMockTransaction(ctrl)
+	tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	runRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	var savedSegments []string
+	runRepo.EXPECT().BulkCreateItems(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, segment string, items []models.StrategyRankingItem) error {
+			savedSegments = append(savedSegments, segment)
+			assert.Len(t, items, len(domain_service.StrategyIDs()))
+			return nil
+		}).Times(4)
+	runRepo.EXPECT().BulkCreateStocks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(len(domain_service.StrategyIDs()))
+	runRepo.EXPECT().UpdateRun(gomock.Any(), gomock.Any()).Return(nil)
+
+	req := models.StrategyRankingRequest{
+		Params: models.BacktestParams{TakeProfit: decimal.NewFromFloat(0.1), StopLoss: decimal.NewFromFloat(0.05), MaxHoldDays: 20},
+		Years:  5,
+		Segments: []models.RankingSegment{
+			{Name: "early_2020", Source: models.RankingSegmentSourceExplicit, Ranges: []models.RankingDateRange{{From: "2020-01-01", To: "2020-02-15"}}},
+		},
+		NikkeiRegimes: true,
+	}
+	interactor := NewStrategyRankingInteractor(tx, brandRepo, priceRepo, nikkeiRepo, runRepo, client)
+	run, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), req, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "early_2020", models.RankingSegmentNikkeiBull, models.RankingSegmentNikkeiBear}, savedSegments)
+	assert.Len(t, run.Segments, 3)
+	assert.NotEmpty(t, run.Segments[1].Ranges)
+	assert.Empty(t, run.Segments[2].Ranges)
+
+	// Redis の最新キャッシュにもセグメント別の集計が入る
+	raw, err := mr.Get(strategyRankingRedisKey)
+	assert.NoError(t, err)
+	var ranking models.StrategyRanking
+	assert.NoError(t, json.Unmarshal([]byte(raw), &ranking))
+	assert.Len(t, ranking.Segments, 3)
+	assert.Equal(t, "early_2020", ranking.Segments[0].Segment.Name)
+	for _, item := range ranking.Segments[0].Items {
+		assert.Equal(t, 1, item.StockCount)
+	}
+	// 日経レジーム期間（直近）に日足が無い銘柄は母数に含めない
+	for _, item := range ranking.Segments[1].Items {
+		assert.Equal(t, 0, item.StockCount)
+	}
+}
+
+func TestStrategyRankingInteractor_ComputeAndSaveStrategyRanking_Universe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr, client := newTestRedis(t)
+
+	brands := []*models.StockBrand{
+		{TickerSymbol: "7203", Sector33Code: "3700"},
+		{TickerSymbol: "7267", Sector33Code: "3700"},
+		{TickerSymbol: "6758", Sector33Code: "3650"},
+	}
+	brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+	brandRepo.EXPECT().FindWithFilter(gomock.Any(), models.NewStockBrandFilter().WithMarketCodes("111")).Return(brands, nil)
+
+	// 対象期間（直近5年）の開始日の30日前から日足がある
+	from := time.Now().AddDate(-5, 0, 0)
+	n := 30 + domain_service.StrategyMinHistoryBars() + 30
+	windowPrices := func() []*models.StockBrandDailyPrice {
+		prices := testPrices(n)
+		for i, p := range prices {
+			p.Date = from.AddDate(0, 0, i-30)
+		}
+		return prices
+	}
+	priceRepo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+	liquid := windowPrices()
+	// 期間中に売買代金が増えて期末時点では条件を満たすが、開始時点では満たさない
+	illiquid := windowPrices()
+	for _, p := range illiquid[:30] {
+		p.Volume = 100
+	}
+	priceRepo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filter models.ListDailyPricesBySymbolFilter) ([]*models.StockBrandDailyPrice, error) {
+			// 開始時点の流動性判定のため開始日より前から読む
+			assert.True(t, filter.DateFrom.Before(from.AddDate(0, 0, -domain_service.RankingLiquidityWindow)))
+			if filter.TickerSymbol == "7267" {
+				return illiquid, nil
+			}
+			return liquid, nil
+		}).Times(2) // 6758 は業種で除外
+
+	tx := mock_repositories.NewMockTransaction(ctrl)
+	tx.EXPECT().DoInTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	runRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *models.StrategyRankingRun) error {
+		assert.Equal(t, "market:111+sector:3700+liquidity:1000000", run.Universe)
+		return nil
+	})
+	runRepo.EXPECT().BulkCreateItems(gomock.Any(), gomock.Any(), "", gomock.Any()).Return(nil)
+	runRepo.EXPECT().BulkCreateStocks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(len(domain_service.StrategyIDs()))
+	runRepo.EXPECT().UpdateRun(gomock.Any(), gomock.Any()).Return(nil)
+
+	req := models.StrategyRankingRequest{
+		Params: models.BacktestParams{TakeProfit: decimal.NewFromFloat(0.1), StopLoss: decimal.NewFromFloat(0.05), MaxHoldDays: 20},
+		Years:  5,
+		Universe: models.RankingUniverse{
+			MarketCodes:        []string{"111"},
+			Sector33Codes:      []string{"3700"},
+			MinAvgTradingValue: decimal.NewFromInt(1_000_000),
+		},
+	}
+	interactor := NewStrategyRankingInteractor(tx, brandRepo, priceRepo, nil, runRepo, client)
+	run, err := interactor.ComputeAndSaveStrategyRanking(context.Background(), req, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, run.TotalStocks)
+	// 7267 は開始時点の流動性不足で除外
+	assert.Equal(t, 1, run.ProcessedStocks)
+	// 主要市場全銘柄以外の実行は Redis の最新キャッシュを更新しない
+	assert.False(t, mr.Exists(strategyRankingRedisKey))
+}
+
+func TestStrategyRankingInteractor_ResolveUniverse_Watchlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ウォッチリストのみの指定は全市場から銘柄コードで選ぶ
+	brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+	brandRepo.EXPECT().FindAll(gomock.Any()).Return(testBrands("7203", "6758", "9984"), nil)
+
+	interactor := &strategyRankingInteractorImpl{stockBrandRepository: brandRepo}
+	got, err := interactor.resolveUniverse(context.Background(), models.RankingUniverse{WatchlistName: "core", Symbols: []string{"9984", "7203"}})
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "7203", got[0].TickerSymbol)
+	assert.Equal(t, "9984", got[1].TickerSymbol)
+}
+
+func TestStrategyRankingInteractor_StartStrategyRanking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, client := newTestRedis(t)
+
+	brandRepo := mock_repositories.NewMockStockBrandRepository(ctrl)
+	brandRepo.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("db error"))
+
+	done := make(chan *models.StrategyRankingRun, 1)
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	runRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	runRepo.EXPECT().UpdateRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *models.StrategyRankingRun) error {
+		done <- run
+		return nil
+	})
+
+	req := models.StrategyRankingRequest{
+		Years:    5,
+		Universe: models.RankingUniverse{WatchlistName: "core", Symbols: []string{"7203"}},
+	}
+	interactor := NewStrategyRankingInteractor(nil, brandRepo, nil, nil, runRepo, client)
+	accepted, err := interactor.StartStrategyRanking(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RankingRunStatusRunning, accepted.Status)
+	assert.Equal(t, "watchlist:core", accepted.Universe)
+
+	select {
+	case run := <-done:
+		assert.Equal(t, accepted.RunID, run.RunID)
+		assert.Equal(t, models.RankingRunStatusFailed, run.Status)
+	case <-time.After(5 * time.Second):
+		t.Fatal("background run did not finish")
+	}
+}
+
+func TestStrategyRankingInteractor_StartStrategyRanking_Running(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mr, client := newTestRedis(t)
+	assert.NoError(t, mr.Set(strategyRankingRunningKey, "run-1"))
+
+	// 実行記録を登録しない
+	runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+	got, err := NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client).
+		StartStrategyRanking(context.Background(), models.StrategyRankingRequest{Years: 5})
+	assert.ErrorIs(t, err, ErrStrategyRankingRunning)
+	assert.Nil(t, got)
+	lock, err := mr.Get(strategyRankingRunningKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "run-1", lock, "ロックは実行中の run のまま")
+}
+
+func TestStrategyRankingInteractor_FailInterruptedStrategyRankings(t *testing.T) {
+	const runID = "11111111-1111-1111-1111-111111111111"
+
+	t.Run("running のまま残った実行を failed にしてロックを外す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mr, client := newTestRedis(t)
+		assert.NoError(t, mr.Set(strategyRankingRunningKey, runID))
+
+		runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+		runRepo.EXPECT().FindByRunID(gomock.Any(), runID).
+			Return(&models.StrategyRankingRun{RunID: runID, Status: models.RankingRunStatusRunning}, nil)
+		runRepo.EXPECT().UpdateRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *models.StrategyRankingRun) error {
+			assert.Equal(t, models.RankingRunStatusFailed, run.Status)
+			assert.Equal(t, "interrupted by server restart", run.Error)
+			return nil
+		})
+
+		assert.NoError(t, NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client).FailInterruptedStrategyRankings(context.Background()))
+		assert.False(t, mr.Exists(strategyRankingRunningKey))
+	})
+
+	t.Run("完了済みや記録の無い実行はロックだけ外す", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mr, client := newTestRedis(t)
+		assert.NoError(t, mr.Set(strategyRankingRunningKey, runID))
+
+		runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+		runRepo.EXPECT().FindByRunID(gomock.Any(), runID).Return(nil, nil)
+
+		assert.NoError(t, NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client).FailInterruptedStrategyRankings(context.Background()))
+		assert.False(t, mr.Exists(strategyRankingRunningKey))
+	})
+
+	t.Run("実行中ロックが無ければ何もしない", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mr, client := newTestRedis(t)
+
+		runRepo := mock_repositories.NewMockStrategyRankingRunRepository(ctrl)
+		assert.NoError(t, NewStrategyRankingInteractor(nil, nil, nil, nil, runRepo, client).FailInterruptedStrategyRankings(context.Background()))
+		assert.Empty(t, mr.Keys())
+	})
 }
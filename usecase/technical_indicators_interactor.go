@@ -28,9 +28,25 @@ const (
 	ichimokuSpanB        = 52
 	ichimokuDisplacement = 26
 	srLookback           = 3
+	donchianPeriod       = 20
+	keltnerEMAPeriod     = 20
+	keltnerATRPeriod     = 10
+	cciPeriod            = 20
+	williamsRPeriod      = 14
+	mfiPeriod            = 14
+	cmfPeriod            = 20
+	aroonPeriod          = 25
+	supertrendATRPeriod  = 10
+	maDeviationSMAPeriod = 25
 )
 
-var srTolerance = decimal.NewFromFloat(0.015)
+var (
+	srTolerance             = decimal.NewFromFloat(0.015)
+	psarStep                = decimal.NewFromFloat(0.02)
+	psarMaxStep             = decimal.NewFromFloat(0.2)
+	keltnerMultiplier       = decimal.NewFromInt(2)
+	supertrendATRMultiplier = decimal.NewFromInt(3)
+)
 
 type technicalIndicatorsInteractorImpl struct {
 	stockBrandsDailyStockPriceRepository repositories.StockBrandsDailyPriceRepository
@@ -38,7 +54,8 @@ type technicalIndicatorsInteractorImpl struct {
 
 type TechnicalIndicatorsInteractor interface {
 	// GetTechnicalIndicators 指定銘柄の期間テクニカル指標時系列を返す。
-	GetTechnicalIndicators(ctx context.Context, symbol string, from, to *time.Time) (*models.TechnicalIndicators, error)
+	// indicators は models.TechnicalIndicatorNames のうち計算する指標名。空ならすべて計算する。
+	GetTechnicalIndicators(ctx context.Context, symbol string, from, to *time.Time, indicators []string) (*models.TechnicalIndicators, error)
 }
 
 func NewTechnicalIndicatorsInteractor(
@@ -49,7 +66,7 @@ func NewTechnicalIndicatorsInteractor(
 	}
 }
 
-func (t *technicalIndicatorsInteractorImpl) GetTechnicalIndicators(ctx context.Context, symbol string, from, to *time.Time) (*models.TechnicalIndicators, error) {
+func (t *technicalIndicatorsInteractorImpl) GetTechnicalIndicators(ctx context.Context, symbol string, from, to *time.Time, indicators []string) (*models.TechnicalIndicators, error) {
 	order := models.SortOrderAsc
 	prices, err := t.stockBrandsDailyStockPriceRepository.ListDailyPricesBySymbol(ctx, models.ListDailyPricesBySymbolFilter{
 		TickerSymbol: symbol,
@@ -61,9 +78,18 @@ func (t *technicalIndicatorsInteractorImpl) GetTechnicalIndicators(ctx context.C
 		return nil, errors.Wrap(err, "ListDailyPricesBySymbol error")
 	}
 
+	if len(indicators) == 0 {
+		indicators = models.TechnicalIndicatorNames
+	}
+	selected := make(map[string]bool, len(indicators))
+	for _, name := range indicators {
+		selected[name] = true
+	}
+
 	result := &models.TechnicalIndicators{
 		Symbol:                  symbol,
 		TradingDays:             len(prices),
+		Indicators:              indicators,
 		FuturePoints:            []models.TechnicalIndicatorPoint{},
 		SupportResistanceLevels: []models.SupportResistanceLevel{},
 	}
@@ -76,21 +102,135 @@ func (t *technicalIndicatorsInteractorImpl) GetTechnicalIndicators(ctx context.C
 		return result, nil
 	}
 
-	atr := domain_service.CalculateATR(prices, atrPeriod)
-	stoch := domain_service.CalculateStochastics(prices, stochK, stochD)
-	adx := domain_service.CalculateADX(prices, adxPeriod)
-	obv := domain_service.CalculateOBV(prices)
-	vwap := domain_service.CalculateRollingVWAP(prices, vwapPeriod)
-	ich := domain_service.CalculateIchimoku(prices, ichimokuConv, ichimokuBase, ichimokuSpanB)
-	levels := domain_service.CalculateSupportResistance(prices, srLookback, srTolerance)
+	// 未選択の指標は nil のまま渡し、ポイントでは null になる
+	var (
+		atr    []decimal.Decimal
+		stoch  []domain_service.StochasticsResult
+		adx    []domain_service.ADXResult
+		obv    []decimal.Decimal
+		vwap   []decimal.Decimal
+		ich    []domain_service.IchimokuResult
+		levels []domain_service.SwingLevel
+	)
+	if selected[models.TechnicalIndicatorATR] {
+		atr = domain_service.CalculateATR(prices, atrPeriod)
+	}
+	if selected[models.TechnicalIndicatorStochastics] {
+		stoch = domain_service.CalculateStochastics(prices, stochK, stochD)
+	}
+	if selected[models.TechnicalIndicatorADX] {
+		adx = domain_service.CalculateADX(prices, adxPeriod)
+	}
+	if selected[models.TechnicalIndicatorOBV] {
+		obv = domain_service.CalculateOBV(prices)
+	}
+	if selected[models.TechnicalIndicatorVWAP] {
+		vwap = domain_service.CalculateRollingVWAP(prices, vwapPeriod)
+	}
+	if selected[models.TechnicalIndicatorIchimoku] {
+		ich = domain_service.CalculateIchimoku(prices, ichimokuConv, ichimokuBase, ichimokuSpanB)
+	}
+	if selected[models.TechnicalIndicatorSupportResistance] {
+		levels = domain_service.CalculateSupportResistance(prices, srLookback, srTolerance)
+	}
 
 	result.Points = buildTechnicalIndicatorPoints(prices, atr, stoch, adx, obv, vwap, ich)
+	applyExtendedIndicators(result.Points, calculateExtendedIndicators(prices, selected))
 	result.FuturePoints = buildFuturePoints(prices, ich)
 	result.SupportResistanceLevels = buildSupportResistanceLevels(levels, prices)
 
 	return result, nil
 }
 
+// extendedIndicatorSeries 追加指標の時系列。未選択・データ不足の指標は nil。
+type extendedIndicatorSeries struct {
+	psar        []domain_service.ParabolicSARResult
+	donchian    []domain_service.ChannelResult
+	keltner     []domain_service.ChannelResult
+	cci         []decimal.Decimal
+	williamsR   []decimal.Decimal
+	mfi         []decimal.Decimal
+	cmf         []decimal.Decimal
+	aroon       []domain_service.AroonResult
+	supertrend  []domain_service.SupertrendResult
+	maDeviation []decimal.Decimal
+}
+
+func calculateExtendedIndicators(prices []*models.StockBrandDailyPrice, selected map[string]bool) extendedIndicatorSeries {
+	var s extendedIndicatorSeries
+	if selected[models.TechnicalIndicatorParabolicSAR] {
+		s.psar = domain_service.CalculateParabolicSAR(prices, psarStep, psarMaxStep)
+	}
+	if selected[models.TechnicalIndicatorDonchian] {
+		s.donchian = domain_service.CalculateDonchianChannels(prices, donchianPeriod)
+	}
+	if selected[models.TechnicalIndicatorKeltner] {
+		s.keltner = domain_service.CalculateKeltnerChannels(prices, keltnerEMAPeriod, keltnerATRPeriod, keltnerMultiplier)
+	}
+	if selected[models.TechnicalIndicatorCCI] {
+		s.cci = domain_service.CalculateCCI(prices, cciPeriod)
+	}
+	if selected[models.TechnicalIndicatorWilliamsR] {
+		s.williamsR = domain_service.CalculateWilliamsR(prices, williamsRPeriod)
+	}
+	if selected[models.TechnicalIndicatorMFI] {
+		s.mfi = domain_service.CalculateMFI(prices, mfiPeriod)
+	}
+	if selected[models.TechnicalIndicatorCMF] {
+		s.cmf = domain_service.CalculateChaikinMoneyFlow(prices, cmfPeriod)
+	}
+	if selected[models.TechnicalIndicatorAroon] {
+		s.aroon = domain_service.CalculateAroon(prices, aroonPeriod)
+	}
+	if selected[models.TechnicalIndicatorSupertrend] {
+		s.supertrend = domain_service.CalculateSupertrend(prices, supertrendATRPeriod, supertrendATRMultiplier)
+	}
+	if selected[models.TechnicalIndicatorMADeviation] {
+		s.maDeviation = domain_service.CalculateMADeviation(domain_service.ExtractClosePrices(prices), maDeviationSMAPeriod)
+	}
+	return s
+}
+
+// applyExtendedIndicators 追加指標の確定値を points に書き込む。
+// 追加指標は 0 が有効値になり得るため、確定判定は IsZero ではなく確定する最初の index で行う。
+func applyExtendedIndicators(points []models.TechnicalIndicatorPoint, s extendedIndicatorSeries) {
+	decAt := func(series []decimal.Decimal, from, i int) *decimal.Decimal {
+		if series == nil || i < from {
+			return nil
+		}
+		v := series[i]
+		return &v
+	}
+	for i := range points {
+		pt := &points[i]
+		if s.psar != nil && i >= 1 {
+			v := s.psar[i].SAR
+			pt.PSAR = &v
+		}
+		if s.donchian != nil && i >= donchianPeriod-1 {
+			upper, mid, lower := s.donchian[i].Upper, s.donchian[i].Middle, s.donchian[i].Lower
+			pt.DonchianUpper, pt.DonchianMid, pt.DonchianLower = &upper, &mid, &lower
+		}
+		if s.keltner != nil && i >= max(keltnerEMAPeriod-1, keltnerATRPeriod) {
+			upper, mid, lower := s.keltner[i].Upper, s.keltner[i].Middle, s.keltner[i].Lower
+			pt.KeltnerUpper, pt.KeltnerMid, pt.KeltnerLower = &upper, &mid, &lower
+		}
+		pt.CCI = decAt(s.cci, cciPeriod-1, i)
+		pt.WilliamsR = decAt(s.williamsR, williamsRPeriod-1, i)
+		pt.MFI = decAt(s.mfi, mfiPeriod, i)
+		pt.CMF = decAt(s.cmf, cmfPeriod-1, i)
+		if s.aroon != nil && i >= aroonPeriod {
+			up, down := s.aroon[i].Up, s.aroon[i].Down
+			pt.AroonUp, pt.AroonDown = &up, &down
+		}
+		if s.supertrend != nil && i >= supertrendATRPeriod {
+			v, up := s.supertrend[i].Value, s.supertrend[i].Uptrend
+			pt.Supertrend, pt.SupertrendUp = &v, &up
+		}
+		pt.MADeviation = decAt(s.maDeviation, maDeviationSMAPeriod-1, i)
+	}
+}
+
 func buildTechnicalIndicatorPoints(
 	prices []*models.StockBrandDailyPrice,
 	atr []decimal.Decimal,
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	mock_repositories "github.com/Code0716/stock-price-repository/mock/repositories"
+	"github.com/Code0716/stock-price-repository/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTechnicalIndicatorsInteractor_GetTechnicalIndicators(t *testing.T) {
+	newInteractor := func(t *testing.T) TechnicalIndicatorsInteractor {
+		ctrl := gomock.NewController(t)
+		repo := mock_repositories.NewMockStockBrandsDailyPriceRepository(ctrl)
+		repo.EXPECT().ListDailyPricesBySymbol(gomock.Any(), gomock.Any()).Return(genPrices(80), nil)
+		return NewTechnicalIndicatorsInteractor(repo)
+	}
+
+	t.Run("indicators 省略時は全指標", func(t *testing.T) {
+		got, err := newInteractor(t).GetTechnicalIndicators(context.Background(), "7203", nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, models.TechnicalIndicatorNames, got.Indicators)
+		require.Len(t, got.Points, 80)
+		last := got.Points[79]
+		assert.NotNil(t, last.ATR)
+		assert.NotNil(t, last.Tenkan)
+		assert.NotNil(t, last.PSAR)
+		assert.NotNil(t, last.DonchianUpper)
+		assert.NotNil(t, last.KeltnerMid)
+		assert.NotNil(t, last.CCI)
+		assert.NotNil(t, last.WilliamsR)
+		assert.NotNil(t, last.MFI)
+		assert.NotNil(t, last.CMF)
+		assert.NotNil(t, last.AroonUp)
+		assert.NotNil(t, last.Supertrend)
+		assert.NotNil(t, last.SupertrendUp)
+		assert.NotNil(t, last.MADeviation)
+		assert.NotEmpty(t, got.FuturePoints)
+		// ウォームアップ中は null
+		assert.Nil(t, got.Points[23].MADeviation)
+		assert.NotNil(t, got.Points[24].MADeviation)
+	})
+
+	t.Run("選択した指標だけ計算する", func(t *testing.T) {
+		got, err := newInteractor(t).GetTechnicalIndicators(context.Background(), "7203", nil, nil,
+			[]string{models.TechnicalIndicatorDonchian, models.TechnicalIndicatorMADeviation})
+		require.NoError(t, err)
+		last := got.Points[79]
+		assert.NotNil(t, last.DonchianUpper)
+		assert.NotNil(t, last.MADeviation)
+		assert.NotNil(t, last.Close)
+		assert.Nil(t, last.ATR)
+		assert.Nil(t, last.Tenkan)
+		assert.Nil(t, last.OBV)
+		assert.Nil(t, last.Supertrend)
+		assert.Empty(t, got.FuturePoints)
+		assert.Empty(t, got.SupportResistanceLevels)
+	})
+}
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"crypto/subtle"
+	"strings"
+)
+
+// ValidBearerToken Authorization ヘッダー（gRPC では authorization メタデータ）の値が "Bearer <token>" と一致するか。
+// token が空のときは常に false（トークン未設定の環境では認証付きの入口を閉じる）。比較は定数時間で行う。
+func ValidBearerToken(authorization, token string) bool {
+	if token == "" {
+		return false
+	}
+	got, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
@@ -0,0 +1,25 @@
+package util
+
+import "testing"
+
+func TestValidBearerToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		token         string
+		want          bool
+	}{
+		{name: "正常系", authorization: "Bearer secret", token: "secret", want: true},
+		{name: "異常系_トークン不一致", authorization: "Bearer other", token: "secret", want: false},
+		{name: "異常系_Bearerなし", authorization: "secret", token: "secret", want: false},
+		{name: "異常系_ヘッダーなし", authorization: "", token: "secret", want: false},
+		{name: "異常系_トークン未設定", authorization: "Bearer ", token: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidBearerToken(tt.authorization, tt.token); got != tt.want {
+				t.Errorf("ValidBearerToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}